@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/koh-sh/apcdeploy/internal/apply"
+	"github.com/spf13/cobra"
+)
+
+var (
+	applyFile           string
+	applyConcurrency    int
+	applyWaitDeploy     bool
+	applyWaitBake       bool
+	applyTimeout        int
+	applyForce          bool
+	applyAcceptLongBake bool
+	applyDescription    string
+)
+
+// ApplyCommand returns the apply command
+func ApplyCommand() *cobra.Command {
+	return newApplyCmd()
+}
+
+func newApplyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "apply",
+		Short: "Deploy every config listed in a manifest file",
+		Long: `Deploy every config listed in a manifest file.
+
+The manifest lists apcdeploy.yml paths and/or inline config blocks:
+
+  configs:
+    - path: services/api/apcdeploy.yml
+    - name: banner
+      config:
+        application: web
+        configuration_profile: banner
+        environment: production
+        data_file: banner.json
+
+Each config is deployed as 'apcdeploy run' would, up to --concurrency at
+once. A failing config does not stop the others; a summary table follows,
+and the command fails if any config failed.`,
+		RunE:         runApply,
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringVarP(&applyFile, "file", "f", "", "Manifest file listing the configs to deploy (required)")
+	_ = cmd.MarkFlagRequired("file")
+	cmd.Flags().IntVar(&applyConcurrency, "concurrency", apply.DefaultConcurrency, "Maximum number of configs deployed at once")
+	cmd.Flags().BoolVar(&applyWaitDeploy, "wait-deploy", false, "Wait for each deployment phase to complete (until baking starts)")
+	cmd.Flags().BoolVar(&applyWaitBake, "wait-bake", false, "Wait for each complete deployment including baking phase")
+	cmd.Flags().IntVar(&applyTimeout, "timeout", 0, timeoutFlagUsage)
+	cmd.Flags().BoolVar(&applyForce, "force", false, "Deploy even when a config has no changes")
+	cmd.Flags().BoolVar(&applyAcceptLongBake, "accept-long-bake", false, acceptLongBakeFlagUsage)
+	cmd.Flags().StringVar(&applyDescription, "description", "", fmt.Sprintf(`Description attached to every configuration version and deployment (max %d chars; defaults to %q, pass "" to clear)`, maxDescriptionLength, defaultDescription))
+
+	return cmd
+}
+
+func runApply(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	if applyWaitDeploy && applyWaitBake {
+		return errors.New("--wait-deploy and --wait-bake cannot be used together")
+	}
+	if applyConcurrency < 1 {
+		return errors.New("--concurrency must be at least 1")
+	}
+	if applyTimeout < 0 {
+		return errors.New("--timeout must be a non-negative value")
+	}
+	if err := validateDescription(applyDescription); err != nil {
+		return err
+	}
+
+	opts := &apply.Options{
+		ManifestFile:   applyFile,
+		Region:         region,
+		Concurrency:    applyConcurrency,
+		WaitDeploy:     applyWaitDeploy,
+		WaitBake:       applyWaitBake,
+		Timeout:        applyTimeout,
+		Force:          applyForce,
+		AcceptLongBake: applyAcceptLongBake,
+		Description:    resolveDescription(cmd, applyDescription),
+	}
+
+	if opts.WaitDeploy || opts.WaitBake {
+		// Ctrl-C stops waiting; the deployments themselves keep running
+		var stop context.CancelFunc
+		ctx, stop = signal.NotifyContext(ctx, os.Interrupt)
+		defer stop()
+	}
+
+	executor := apply.NewExecutor(newReporter(cmd))
+	return executor.Execute(ctx, opts)
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/koh-sh/apcdeploy/internal/apply"
+)
+
+func TestApplyCommand(t *testing.T) {
+	t.Parallel()
+
+	cmd := ApplyCommand()
+	if cmd.Use != "apply" {
+		t.Errorf("Use = %q, want apply", cmd.Use)
+	}
+	if !cmd.SilenceUsage {
+		t.Error("expected SilenceUsage to be true")
+	}
+	if f := cmd.Flags().ShorthandLookup("f"); f == nil || f.Name != "file" {
+		t.Error("expected -f to be the shorthand for --file")
+	}
+	if c := cmd.Flags().Lookup("concurrency"); c == nil || c.DefValue != "4" {
+		t.Errorf("--concurrency default = %v, want %d", c, apply.DefaultConcurrency)
+	}
+}
+
+func TestRunApplyValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{name: "both waits", args: []string{"-f", "m.yml", "--wait-deploy", "--wait-bake"}, wantErr: "--wait-deploy and --wait-bake cannot be used together"},
+		{name: "zero concurrency", args: []string{"-f", "m.yml", "--concurrency", "0"}, wantErr: "--concurrency must be at least 1"},
+		{name: "negative timeout", args: []string{"-f", "m.yml", "--timeout", "-1"}, wantErr: "--timeout must be a non-negative value"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newApplyCmd()
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatalf("ParseFlags() error = %v", err)
+			}
+			defer func() {
+				applyFile = ""
+				applyConcurrency = apply.DefaultConcurrency
+				applyWaitDeploy = false
+				applyWaitBake = false
+				applyTimeout = 0
+			}()
+
+			err := runApply(cmd, nil)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("runApply() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
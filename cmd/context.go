@@ -22,7 +22,7 @@ func ContextCommand() *cobra.Command {
 This command outputs the contents of llms.md, which provides guidelines
 for AI assistants when using the apcdeploy command.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			fmt.Print(llmsContent)
+			fmt.Fprint(cmd.OutOrStdout(), llmsContent)
 			return nil
 		},
 	}
@@ -3,14 +3,32 @@ package cmd
 import (
 	"context"
 	"errors"
+	"fmt"
 	"os"
 
-	"github.com/koh-sh/apcdeploy/internal/cli"
+	"github.com/koh-sh/apcdeploy/internal/bulk"
+	"github.com/koh-sh/apcdeploy/internal/config"
 	"github.com/koh-sh/apcdeploy/internal/diff"
 	"github.com/spf13/cobra"
 )
 
-var diffExitNonzero bool
+var (
+	diffExitNonzero  bool
+	diffExitCode     bool
+	diffProfilesFile string
+	diffOutput       string
+	diffOutputFile   string
+	diffEnvA         string
+	diffEnvB         string
+	diffDumpDir      string
+	diffContext      int
+	diffStat         bool
+	diffApp          string
+	diffProfileRegex string
+	diffConcurrency  int
+	diffFailFast     bool
+	diffDeployment   int32
+)
 
 // DiffCommand returns the diff command
 func DiffCommand() *cobra.Command {
@@ -24,12 +42,57 @@ func newDiffCmd() *cobra.Command {
 		Long: `Show differences between local configuration and the currently deployed configuration in AWS AppConfig.
 
 This command compares your local configuration file with the latest deployed version
-and displays the differences in unified diff format.`,
+and displays the differences in unified diff format. --deployment <n> compares
+against the version served by that deployment instead; the deployment must
+belong to the configured profile.
+
+Exit codes with --exit-code (git-style): 0 when the local file matches the
+deployed version, 1 when it differs or nothing has been deployed yet. Other
+errors also exit 1 and are reported on stderr. --exit-nonzero behaves the same
+except that a first deployment exits 0.
+
+With --profiles-from-file, every target listed in the file is compared against
+its data_file concurrently and an aggregated report is written to stdout.
+--fail-fast stops at the first target that differs (or was never deployed),
+cancels the comparisons still running and exits 1.
+
+With --env-a and --env-b, the configurations currently deployed to the two
+environments are compared instead of the local file ("-" lines come from
+--env-a, "+" lines from --env-b, as named in the diff header), and the command
+exits 1 when they differ.
+
+For large configurations, --diff-context N keeps only N unchanged lines around
+each change (as diff -U N does), and --diff-stat prints only the number of changed lines (and, for
+JSON, the changed top-level keys) instead of the diff.`,
 		RunE:         runDiff,
 		SilenceUsage: true, // Don't show usage on runtime errors
 	}
 
 	cmd.Flags().BoolVar(&diffExitNonzero, "exit-nonzero", false, "Exit with code 1 if differences exist")
+	cmd.Flags().BoolVar(&diffExitCode, "exit-code", false, "Exit with code 1 if differences exist or nothing is deployed yet (git-style)")
+	cmd.Flags().StringVar(&diffProfilesFile, "profiles-from-file", "", "YAML file listing targets (application/profile/environment/region/data_file) to diff in bulk")
+	cmd.Flags().StringVar(&diffApp, "app", "", appFlagUsage+" (with --profiles-from-file)")
+	cmd.Flags().StringVar(&diffProfileRegex, "profile-regex", "", profileRegexFlagUsage+" (with --profiles-from-file)")
+	cmd.Flags().IntVar(&diffConcurrency, "concurrency", bulk.DefaultConcurrency, bulkConcurrencyFlagUsage)
+	cmd.Flags().BoolVar(&diffFailFast, "fail-fast", false, "Stop at the first --profiles-from-file target that differs and exit 1")
+	cmd.Flags().StringVar(&diffOutput, "output", config.OutputFormatText, "Output format: text or json")
+	cmd.Flags().StringVar(&diffOutputFile, "output-file", "", outputFileFlagUsage)
+	cmd.Flags().StringVar(&diffEnvA, "env-a", "", "Environment whose deployed configuration is the left-hand side of the comparison (requires --env-b)")
+	cmd.Flags().StringVar(&diffEnvB, "env-b", "", "Environment whose deployed configuration is the right-hand side of the comparison (requires --env-a)")
+	cmd.Flags().StringVar(&diffDumpDir, "dump-normalized", "", "Debug: write the normalized remote and local content that is compared to this directory")
+	cmd.Flags().IntVar(&diffContext, "diff-context", -1, "Show only this many unchanged lines around each change (-1 shows the whole file)")
+	cmd.Flags().BoolVar(&diffStat, "diff-stat", false, "Print only changed line counts and changed top-level JSON keys instead of the diff")
+	cmd.Flags().Int32Var(&diffDeployment, "deployment", 0, "Compare against the configuration of this deployment number instead of the latest deployment")
+	_ = cmd.Flags().MarkHidden("dump-normalized")
+	cmd.MarkFlagsRequiredTogether("env-a", "env-b")
+	cmd.MarkFlagsMutuallyExclusive("env-a", "profiles-from-file")
+	cmd.MarkFlagsMutuallyExclusive("dump-normalized", "profiles-from-file")
+	cmd.MarkFlagsMutuallyExclusive("dump-normalized", "env-a")
+	cmd.MarkFlagsMutuallyExclusive("diff-context", "diff-stat")
+	cmd.MarkFlagsMutuallyExclusive("diff-stat", "output")
+	cmd.MarkFlagsMutuallyExclusive("diff-context", "output")
+	cmd.MarkFlagsMutuallyExclusive("deployment", "profiles-from-file")
+	cmd.MarkFlagsMutuallyExclusive("deployment", "env-a")
 
 	return cmd
 }
@@ -37,21 +100,68 @@ and displays the differences in unified diff format.`,
 func runDiff(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
 
+	if err := validateOutputFormat(diffOutput); err != nil {
+		return err
+	}
+	if err := validateOutputFile(diffOutputFile, diffOutput == config.OutputFormatJSON); err != nil {
+		return err
+	}
+	filter, err := bulkProfileFilter(diffApp, diffProfileRegex, diffProfilesFile)
+	if err != nil {
+		return err
+	}
+	if err := validateBulkConcurrency(diffConcurrency, cmd.Flags().Changed("concurrency"), diffProfilesFile); err != nil {
+		return err
+	}
+	if diffFailFast && diffProfilesFile == "" {
+		return errors.New("--fail-fast requires --profiles-from-file")
+	}
+	if diffContext < -1 {
+		return fmt.Errorf("--diff-context must be a non-negative number")
+	}
+	if cmd.Flags().Changed("deployment") && diffDeployment < 1 {
+		return fmt.Errorf("--deployment must be a positive deployment number")
+	}
+
 	// Create options
 	opts := &diff.Options{
-		ConfigFile:  configFile,
-		ExitNonzero: diffExitNonzero,
-		Silent:      isSilent(),
+		ConfigFile:     configFile,
+		TargetsFile:    diffProfilesFile,
+		Filter:         filter,
+		Concurrency:    diffConcurrency,
+		Output:         diffOutput,
+		ExitNonzero:    diffExitNonzero,
+		ExitCode:       diffExitCode,
+		FailFast:       diffFailFast,
+		EnvA:           diffEnvA,
+		EnvB:           diffEnvB,
+		Silent:         isSilent(),
+		Region:         region,
+		DumpNormalized: diffDumpDir,
+		DiffStat:       diffStat,
+		Deployment:     diffDeployment,
+		Stderr:         cmd.ErrOrStderr(),
+	}
+	if diffContext >= 0 {
+		opts.DiffContext = &diffContext
 	}
 
 	// Create reporter
-	reporter := cli.GetReporter(isSilent())
+	reporter, finish := newOutputReporter(cmd, diffOutputFile)
 
 	// Run diff
 	executor := diff.NewExecutor(reporter)
-	err := executor.Execute(ctx, opts)
+	switch {
+	case opts.EnvA != "":
+		err = executor.ExecuteCompare(ctx, opts)
+	case opts.TargetsFile != "":
+		err = executor.ExecuteBulk(ctx, opts)
+	default:
+		err = executor.Execute(ctx, opts)
+	}
+	err = finish(err)
 
-	// Handle exit-nonzero case
+	// Handle --exit-nonzero / --exit-code
 	if errors.Is(err, diff.ErrDiffFound) {
 		os.Exit(1)
 	}
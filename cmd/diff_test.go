@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -20,6 +21,21 @@ func TestDiffCommand(t *testing.T) {
 			args:    []string{"--exit-nonzero"},
 			wantErr: false,
 		},
+		{
+			name:    "diff-context flag",
+			args:    []string{"--diff-context", "3"},
+			wantErr: false,
+		},
+		{
+			name:    "diff-stat flag",
+			args:    []string{"--diff-stat"},
+			wantErr: false,
+		},
+		{
+			name:    "non-numeric diff-context",
+			args:    []string{"--diff-context", "some"},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -70,7 +86,7 @@ func TestRunDiffInvalidConfig(t *testing.T) {
 	// Reset flags
 	configFile = "nonexistent.yml"
 
-	err := runDiff(nil, nil)
+	err := runDiff(newDiffCmd(), nil)
 	if err == nil {
 		t.Error("Expected error for nonexistent config, got nil")
 	}
@@ -123,3 +139,29 @@ func TestDiffCommandExitNonzeroFlag(t *testing.T) {
 		})
 	}
 }
+
+func TestRunDiffFailFastRequiresProfilesFile(t *testing.T) {
+	cmd := newDiffCmd()
+	if err := cmd.ParseFlags([]string{"--fail-fast"}); err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+	defer func() { diffFailFast = false }()
+
+	err := runDiff(cmd, nil)
+	if err == nil || !strings.Contains(err.Error(), "--fail-fast requires --profiles-from-file") {
+		t.Errorf("expected --fail-fast validation error, got: %v", err)
+	}
+}
+
+func TestRunDiffDeploymentValidation(t *testing.T) {
+	cmd := newDiffCmd()
+	if err := cmd.ParseFlags([]string{"--deployment", "0"}); err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+	defer func() { diffDeployment = 0 }()
+
+	err := runDiff(cmd, nil)
+	if err == nil || !strings.Contains(err.Error(), "--deployment must be a positive deployment number") {
+		t.Errorf("expected --deployment validation error, got: %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/koh-sh/apcdeploy/internal/doctor"
+	"github.com/spf13/cobra"
+)
+
+// doctorFor is the subcommand whose permissions are checked (--for)
+var doctorFor string
+
+// DoctorCommand returns the doctor command
+func DoctorCommand() *cobra.Command {
+	return newDoctorCmd()
+}
+
+func newDoctorCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Check the IAM permissions a subcommand needs",
+		Long: `Check that the current AWS credentials hold exactly the IAM actions a given
+subcommand needs, against the resources named in apcdeploy.yml.
+
+Each read action is exercised with the smallest call that needs it and
+reported as allowed, DENIED, or not checked (when an earlier lookup it
+depends on failed). Write actions such as appconfig:StartDeployment are
+listed but never called. The command exits non-zero when any action is
+denied, so CI can verify a least-privilege role before relying on it.
+
+--for get starts an AppConfig Data session, which is billed per call.`,
+		Example: `  apcdeploy doctor --for deploy
+  apcdeploy doctor --for status -c staging.yml`,
+		RunE:         runDoctor,
+		SilenceUsage: true, // Don't show usage on runtime errors
+	}
+
+	cmd.Flags().StringVar(&doctorFor, "for", "", fmt.Sprintf("Subcommand to check permissions for (%s)", strings.Join(doctor.Operations(), ", ")))
+	_ = cmd.MarkFlagRequired("for")
+
+	return cmd
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	if _, ok := doctor.RequiredActions(doctorFor); !ok {
+		return fmt.Errorf("invalid --for %q: must be one of %s", doctorFor, strings.Join(doctor.Operations(), ", "))
+	}
+
+	opts := &doctor.Options{
+		ConfigFile: configFile,
+		Region:     region,
+		For:        doctorFor,
+	}
+
+	reporter := newReporter(cmd)
+	executor := doctor.NewExecutor(reporter)
+	return executor.Execute(ctx, opts)
+}
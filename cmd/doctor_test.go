@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDoctorCommandStructure(t *testing.T) {
+	cmd := newDoctorCmd()
+
+	if cmd.Use != "doctor" {
+		t.Errorf("Use = %v, want doctor", cmd.Use)
+	}
+	if cmd.RunE == nil {
+		t.Error("RunE should be set")
+	}
+	flag := cmd.Flags().Lookup("for")
+	if flag == nil {
+		t.Fatal("expected --for flag")
+	}
+	if !strings.Contains(flag.Usage, "deploy") || !strings.Contains(flag.Usage, "status") {
+		t.Errorf("--for usage should list the operations, got %q", flag.Usage)
+	}
+}
+
+func TestDoctorCommandInvalidFor(t *testing.T) {
+	cmd := newDoctorCmd()
+	doctorFor = "apply"
+	t.Cleanup(func() { doctorFor = "" })
+
+	err := runDoctor(cmd, nil)
+	if err == nil || !strings.Contains(err.Error(), "invalid --for") {
+		t.Errorf("expected invalid --for error, got: %v", err)
+	}
+}
@@ -3,23 +3,29 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"time"
 
-	"github.com/koh-sh/apcdeploy/internal/cli"
+	"github.com/koh-sh/apcdeploy/internal/config"
 	"github.com/koh-sh/apcdeploy/internal/edit"
 	"github.com/koh-sh/apcdeploy/internal/prompt"
 	"github.com/spf13/cobra"
 )
 
 var (
-	editRegion             string
 	editApp                string
 	editProfile            string
 	editEnv                string
 	editDeploymentStrategy string
 	editWaitDeploy         bool
 	editWaitBake           bool
+	editAcceptLongBake     bool
 	editTimeout            int
 	editDescription        string
+	editPollBackoff        bool
+	editPollInterval       time.Duration
+	editConfigGlob         string
+	editContentType        string
+	editStrict             bool
 )
 
 // EditCommand returns the edit command
@@ -39,19 +45,35 @@ the target is selected via flags or interactive prompts, similar to 'init'.
 
 If --deployment-strategy is omitted, the strategy of the most recent deployment
 is reused. Validation behavior matches the 'run' command (size limits and
-JSON/YAML syntax checks).`,
+JSON/YAML syntax checks). When the edited content fails validation, the
+editor re-opens on it with a note explaining the error at the top; save it
+unchanged to abort. --strict fails on the first validation error instead.
+
+With --config-glob, every profile whose apcdeploy config file matches the
+pattern is fetched into a temporary directory and $EDITOR is opened once on
+that directory. Invalid files are annotated and the directory re-opened the
+same way; once every file passes, changed files are deployed and unchanged
+ones are skipped.`,
 		RunE:         runEdit,
 		SilenceUsage: true,
 	}
 
-	cmd.Flags().StringVar(&editRegion, "region", "", "AWS region")
 	cmd.Flags().StringVar(&editApp, "app", "", "Application name")
 	cmd.Flags().StringVar(&editProfile, "profile", "", "Configuration Profile name")
 	cmd.Flags().StringVar(&editEnv, "env", "", "Environment name")
 	cmd.Flags().StringVar(&editDeploymentStrategy, "deployment-strategy", "", "Deployment strategy name (defaults to the strategy of the latest deployment)")
 	cmd.Flags().BoolVar(&editWaitDeploy, "wait-deploy", false, "Wait for deployment phase to complete (until baking starts)")
 	cmd.Flags().BoolVar(&editWaitBake, "wait-bake", false, "Wait for complete deployment including baking phase")
-	cmd.Flags().IntVar(&editTimeout, "timeout", DefaultDeploymentTimeout, "Timeout in seconds for deployment")
+	cmd.Flags().BoolVar(&editAcceptLongBake, "accept-long-bake", false, acceptLongBakeFlagUsage)
+	cmd.Flags().IntVar(&editTimeout, "timeout", 0, "Timeout in seconds for deployment (default: with --config-glob the timeout in each config file, else 1800)")
+	cmd.Flags().BoolVar(&editPollBackoff, "poll-backoff", false, "Poll deployment status with exponential backoff (5s doubling up to 1m) while waiting")
+	cmd.Flags().DurationVar(&editPollInterval, "poll-interval", config.DefaultPollingInterval, pollIntervalFlagUsage)
+	cmd.Flags().StringVar(&editContentType, "content-type", "", "Content type to edit and upload as, overriding the type of the deployed version (application/json, application/x-yaml, application/toml or text/plain)")
+	cmd.Flags().BoolVar(&editStrict, "strict", false, "Fail on the first validation error instead of re-opening the editor (for CI)")
+	cmd.Flags().StringVar(&editConfigGlob, "config-glob", "", "Edit every profile whose apcdeploy config file matches this glob (e.g. 'services/*/apcdeploy.yml') in one editor session")
+	cmd.MarkFlagsMutuallyExclusive("config-glob", "app")
+	cmd.MarkFlagsMutuallyExclusive("config-glob", "profile")
+	cmd.MarkFlagsMutuallyExclusive("config-glob", "env")
 	cmd.Flags().StringVar(&editDescription, "description", "", fmt.Sprintf(`Description attached to the configuration version and deployment (max %d chars; defaults to %q, pass "" to clear)`, maxDescriptionLength, defaultDescription))
 
 	return cmd
@@ -60,24 +82,36 @@ JSON/YAML syntax checks).`,
 func runEdit(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
 
+	if err := validatePollInterval(editPollInterval); err != nil {
+		return err
+	}
 	if err := validateDescription(editDescription); err != nil {
 		return err
 	}
 	description := resolveDescription(cmd, editDescription)
+	if err := validateContentTypeFlag(editContentType); err != nil {
+		return err
+	}
 
 	opts := &edit.Options{
-		Region:             editRegion,
+		Region:             region,
 		Application:        editApp,
 		Profile:            editProfile,
 		Environment:        editEnv,
 		DeploymentStrategy: editDeploymentStrategy,
 		WaitDeploy:         editWaitDeploy,
 		WaitBake:           editWaitBake,
+		AcceptLongBake:     editAcceptLongBake,
 		Timeout:            editTimeout,
 		Description:        description,
+		PollBackoff:        editPollBackoff,
+		PollInterval:       editPollInterval,
+		ConfigGlob:         editConfigGlob,
+		ContentType:        editContentType,
+		Strict:             editStrict,
 	}
 
-	reporter := cli.GetReporter(isSilent())
+	reporter := newReporter(cmd)
 	prompter := &prompt.HuhPrompter{}
 
 	executor := edit.NewExecutor(reporter, prompter)
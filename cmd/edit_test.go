@@ -52,12 +52,6 @@ func TestEditCommand(t *testing.T) {
 				require.True(t, cmd.SilenceUsage)
 			},
 		},
-		{
-			name: "has --region flag",
-			check: func(t *testing.T, cmd *cobra.Command) {
-				require.NotNil(t, cmd.Flags().Lookup("region"))
-			},
-		},
 		{
 			name: "has --app flag",
 			check: func(t *testing.T, cmd *cobra.Command) {
@@ -100,6 +94,12 @@ func TestEditCommand(t *testing.T) {
 				require.NotNil(t, cmd.Flags().Lookup("timeout"))
 			},
 		},
+		{
+			name: "has --content-type flag",
+			check: func(t *testing.T, cmd *cobra.Command) {
+				require.NotNil(t, cmd.Flags().Lookup("content-type"))
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/koh-sh/apcdeploy/internal/export"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// exportOutputFile writes the manifest to a file instead of stdout
+	exportOutputFile string
+	// exportDiffManifest compares the current state with a stored manifest
+	exportDiffManifest string
+)
+
+// ExportCommand returns the export command
+func ExportCommand() *cobra.Command {
+	return newExportCmd()
+}
+
+func newExportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export a manifest of the desired and deployed state",
+		Long: `Export a JSON manifest of the target: resolved resource IDs, the deployment
+strategy settings, the latest deployment (version, state and content hash),
+and the hash of the local data file. The manifest has no timestamps, so it can
+be committed and compared by GitOps tooling.
+
+With --diff-manifest, the current state is compared with a stored manifest
+instead; drifted fields are printed as "<field>\t<previous>\t<current>" and the
+command exits 1. This command is read-only.`,
+		RunE:         runExport,
+		SilenceUsage: true, // Don't show usage on runtime errors
+	}
+
+	cmd.Flags().StringVarP(&exportOutputFile, "output-file", "o", "", "Write the manifest to this file instead of stdout (parent directories are created)")
+	cmd.Flags().StringVar(&exportDiffManifest, "diff-manifest", "", "Compare the current state with a previously exported manifest and report drift")
+	cmd.MarkFlagsMutuallyExclusive("output-file", "diff-manifest")
+
+	return cmd
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	opts := &export.Options{
+		ConfigFile:   configFile,
+		DiffManifest: exportDiffManifest,
+		Region:       region,
+	}
+
+	reporter, finish := newOutputReporter(cmd, exportOutputFile)
+
+	executor := export.NewExecutor(reporter)
+	return finish(executor.Execute(ctx, opts))
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExportCommandStructure(t *testing.T) {
+	cmd := newExportCmd()
+
+	if cmd.Use != "export" {
+		t.Errorf("Use = %v, want export", cmd.Use)
+	}
+	if cmd.RunE == nil {
+		t.Error("RunE should be set")
+	}
+	for _, name := range []string{"output-file", "diff-manifest"} {
+		if cmd.Flags().Lookup(name) == nil {
+			t.Errorf("expected --%s flag", name)
+		}
+	}
+	if f := cmd.Flags().ShorthandLookup("o"); f == nil || f.Name != "output-file" {
+		t.Error("expected -o to be the shorthand for --output-file")
+	}
+}
+
+func TestExportCommandMutuallyExclusive(t *testing.T) {
+	cmd := newExportCmd()
+	cmd.SetArgs([]string{"-o", "manifest.json", "--diff-manifest", "old.json"})
+
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "none of the others can be") {
+		t.Errorf("expected mutually exclusive flag error, got: %v", err)
+	}
+}
@@ -2,15 +2,23 @@ package cmd
 
 import (
 	"context"
+	"errors"
 
-	"github.com/koh-sh/apcdeploy/internal/cli"
 	"github.com/koh-sh/apcdeploy/internal/get"
 	"github.com/koh-sh/apcdeploy/internal/prompt"
 	"github.com/spf13/cobra"
 )
 
-// getSkipConfirmation controls whether to skip the confirmation prompt (--yes flag)
-var getSkipConfirmation bool
+var (
+	// getSkipConfirmation controls whether to skip the confirmation prompt (--yes flag)
+	getSkipConfirmation bool
+	// getEnv overrides the environment from the config file (--env flag)
+	getEnv string
+	// getOutputFile writes the configuration to a file (--output-file flag)
+	getOutputFile string
+	// getForce overwrites an existing --output-file (--force flag)
+	getForce bool
+)
 
 // GetCommand returns the get command
 func GetCommand() *cobra.Command {
@@ -24,12 +32,22 @@ func newGetCmd() *cobra.Command {
 		Long: `Get the latest deployed configuration from AWS AppConfig and output to stdout.
 
 WARNING: This command uses AWS AppConfig Data API which incurs charges per API call.
-Use --yes to skip the confirmation prompt (useful for scripts and automation).`,
+Use --yes to skip the confirmation prompt (useful for scripts and automation).
+
+Use --env to read what another environment is serving without editing the
+config file (e.g. compare staging and production with one apcdeploy.yml).
+
+Use --output-file to write the configuration, exactly as served, to a file
+instead of stdout; no confirmation is asked. An existing file is only
+overwritten with --force.`,
 		RunE:         runGet,
 		SilenceUsage: true, // Don't show usage on runtime errors
 	}
 
 	cmd.Flags().BoolVarP(&getSkipConfirmation, "yes", "y", false, "Skip confirmation prompt")
+	cmd.Flags().StringVar(&getEnv, "env", "", "Environment name (overrides the environment in the config file)")
+	cmd.Flags().StringVar(&getOutputFile, "output-file", "", "Write the configuration verbatim to this file instead of stdout (skips the confirmation prompt)")
+	cmd.Flags().BoolVarP(&getForce, "force", "f", false, "Overwrite an existing --output-file")
 
 	return cmd
 }
@@ -37,14 +55,22 @@ Use --yes to skip the confirmation prompt (useful for scripts and automation).`,
 func runGet(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
 
+	if getForce && getOutputFile == "" {
+		return errors.New("--force requires --output-file")
+	}
+
 	// Create options
 	opts := &get.Options{
 		ConfigFile:       configFile,
 		SkipConfirmation: getSkipConfirmation,
+		Environment:      getEnv,
+		Region:           region,
+		OutputFile:       getOutputFile,
+		Force:            getForce,
 	}
 
 	// Create reporter and prompter
-	reporter := cli.GetReporter(isSilent())
+	reporter := newReporter(cmd)
 	prompter := &prompt.HuhPrompter{}
 
 	// Get configuration
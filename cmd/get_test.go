@@ -143,6 +143,19 @@ func TestGetCommandFlags(t *testing.T) {
 	}
 }
 
+func TestRunGetForceRequiresOutputFile(t *testing.T) {
+	cmd := newGetCmd()
+	if err := cmd.ParseFlags([]string{"--force"}); err != nil {
+		t.Fatalf("ParseFlags: %v", err)
+	}
+	defer func() { getForce, getOutputFile = false, "" }()
+
+	err := runGet(cmd, nil)
+	if err == nil || err.Error() != "--force requires --output-file" {
+		t.Errorf("runGet() error = %v, want --force requires --output-file", err)
+	}
+}
+
 func TestGetCommandSilenceUsage(t *testing.T) {
 	cmd := newGetCmd()
 
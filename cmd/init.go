@@ -2,8 +2,11 @@ package cmd
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"os"
 
-	"github.com/koh-sh/apcdeploy/internal/cli"
+	"github.com/koh-sh/apcdeploy/internal/config"
 	initPkg "github.com/koh-sh/apcdeploy/internal/init"
 	"github.com/koh-sh/apcdeploy/internal/prompt"
 	"github.com/spf13/cobra"
@@ -13,11 +16,18 @@ var (
 	initApp        string
 	initProfile    string
 	initEnv        string
-	initRegion     string
 	initOutputData string
 	initForce      bool
+	initFromDeploy int32
+	initConfigFmt  string
+	initCheck      bool
+	initAllEnvs    bool
 )
 
+// defaultJSONConfigFile replaces the default apcdeploy.yml when init is run
+// with --config-format json and no explicit --config.
+const defaultJSONConfigFile = "apcdeploy.json"
+
 // InitCommand returns the init command
 func InitCommand() *cobra.Command {
 	return newInitCmd()
@@ -31,7 +41,21 @@ func newInitCmd() *cobra.Command {
 and generating apcdeploy.yml and data files.
 
 Flags can be provided to skip interactive prompts. If omitted, you will be prompted
-to select from available resources.`,
+to select from available resources.
+
+Use -c/--config to choose the generated config file name and --config-format
+json to write it as JSON (apcdeploy.json unless -c is given). Every command
+loads .json config files as JSON.
+
+Use --check to compare an existing config file with what init would generate
+now (e.g. after the deployment strategy changed in AWS). Resource flags default
+to the values in the existing file; nothing is written and the command exits 1
+when the files differ.
+
+Use --all-environments to generate one config file per environment of the
+application (apcdeploy-<env>.yml for the default -c) in a single run. They share
+one data file seeded from the profile's latest configuration version; config
+and data files that already exist are skipped unless --force is given.`,
 		RunE:         runInit,
 		SilenceUsage: true, // Don't show usage on runtime errors
 	}
@@ -39,9 +63,16 @@ to select from available resources.`,
 	cmd.Flags().StringVar(&initApp, "app", "", "Application name")
 	cmd.Flags().StringVar(&initProfile, "profile", "", "Configuration Profile name")
 	cmd.Flags().StringVar(&initEnv, "env", "", "Environment name")
-	cmd.Flags().StringVar(&initRegion, "region", "", "AWS region")
 	cmd.Flags().StringVarP(&initOutputData, "output-data", "o", "", "Output data file path")
 	cmd.Flags().BoolVarP(&initForce, "force", "f", false, "Overwrite existing files")
+	cmd.Flags().StringVar(&initConfigFmt, "config-format", "", "Config file format: yaml or json (defaults to the --config extension, else yaml)")
+	cmd.Flags().BoolVar(&initCheck, "check", false, "Compare the existing config file with what init would generate now and exit 1 on differences, without writing files")
+	cmd.MarkFlagsMutuallyExclusive("check", "force")
+	cmd.Flags().Int32Var(&initFromDeploy, "from-deployment", 0, "Seed the data file from this deployment number instead of the latest deployment")
+	cmd.Flags().BoolVar(&initAllEnvs, "all-environments", false, "Generate one config file per environment of the application (apcdeploy-<env>.yml) sharing a data file from the latest version; existing files are skipped unless --force")
+	cmd.MarkFlagsMutuallyExclusive("all-environments", "env")
+	cmd.MarkFlagsMutuallyExclusive("all-environments", "check")
+	cmd.MarkFlagsMutuallyExclusive("all-environments", "from-deployment")
 
 	return cmd
 }
@@ -49,23 +80,48 @@ to select from available resources.`,
 func runInit(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
 
+	if initFromDeploy < 0 {
+		return fmt.Errorf("--from-deployment must be a positive deployment number")
+	}
+
+	outputConfig := configFile
+	switch initConfigFmt {
+	case "", config.ConfigFormatYAML:
+	case config.ConfigFormatJSON:
+		if !cmd.Flags().Changed("config") {
+			outputConfig = defaultJSONConfigFile
+		}
+	default:
+		return fmt.Errorf("invalid --config-format %q: must be %q or %q", initConfigFmt, config.ConfigFormatYAML, config.ConfigFormatJSON)
+	}
+
 	// Create options
 	opts := &initPkg.Options{
-		Application: initApp,
-		Profile:     initProfile,
-		Environment: initEnv,
-		Region:      initRegion,
-		ConfigFile:  configFile,
-		OutputData:  initOutputData,
-		Force:       initForce,
-		Silent:      isSilent(),
+		Application:     initApp,
+		Profile:         initProfile,
+		Environment:     initEnv,
+		Region:          region,
+		ConfigFile:      outputConfig,
+		ConfigFormat:    initConfigFmt,
+		OutputData:      initOutputData,
+		Force:           initForce,
+		FromDeployment:  initFromDeploy,
+		Silent:          isSilent(),
+		Check:           initCheck,
+		AllEnvironments: initAllEnvs,
 	}
 
 	// Create reporter and prompter
-	reporter := cli.GetReporter(isSilent())
+	reporter := newReporter(cmd)
 	prompter := &prompt.HuhPrompter{}
 
 	// Run initialization
 	executor := initPkg.NewExecutor(reporter, prompter)
-	return executor.Execute(ctx, opts)
+	err := executor.Execute(ctx, opts)
+
+	// --check reports drift with exit code 1, like diff --exit-nonzero
+	if errors.Is(err, initPkg.ErrConfigDrift) {
+		os.Exit(1)
+	}
+	return err
 }
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -12,17 +13,17 @@ func TestInitCommand(t *testing.T) {
 	}{
 		{
 			name:    "all required flags provided",
-			args:    []string{"--app", "test-app", "--profile", "test-profile", "--env", "test-env", "--region", "us-east-1"},
+			args:    []string{"--app", "test-app", "--profile", "test-profile", "--env", "test-env"},
 			wantErr: false,
 		},
 		{
 			name:    "with optional output-data flag",
-			args:    []string{"--app", "test-app", "--profile", "test-profile", "--env", "test-env", "--region", "us-east-1", "--output-data", "custom-data.json"},
+			args:    []string{"--app", "test-app", "--profile", "test-profile", "--env", "test-env", "--output-data", "custom-data.json"},
 			wantErr: false,
 		},
 		{
 			name:    "with optional force flag",
-			args:    []string{"--app", "test-app", "--profile", "test-profile", "--env", "test-env", "--region", "us-east-1", "--force"},
+			args:    []string{"--app", "test-app", "--profile", "test-profile", "--env", "test-env", "--force"},
 			wantErr: false,
 		},
 	}
@@ -33,7 +34,7 @@ func TestInitCommand(t *testing.T) {
 			initApp = ""
 			initProfile = ""
 			initEnv = ""
-			initRegion = ""
+			region = ""
 			configFile = "apcdeploy.yml"
 			initOutputData = ""
 			initForce = false
@@ -123,12 +124,12 @@ func TestRunInitWithAllFlags(t *testing.T) {
 			initApp = tt.app
 			initProfile = tt.profile
 			initEnv = tt.env
-			initRegion = tt.region
+			region = tt.region
 			configFile = tt.config
 			initOutputData = tt.outputData
 			initForce = tt.force
 
-			err := runInit(nil, nil)
+			err := runInit(newInitCmd(), nil)
 
 			if tt.expectError && err == nil {
 				t.Error("expected error due to AWS client creation without credentials")
@@ -139,3 +140,27 @@ func TestRunInitWithAllFlags(t *testing.T) {
 		})
 	}
 }
+
+func TestInitCommandAllEnvironmentsMutuallyExclusive(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"with env", []string{"--all-environments", "--env", "prod"}},
+		{"with check", []string{"--all-environments", "--check"}},
+		{"with from-deployment", []string{"--all-environments", "--from-deployment", "3"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newInitCmd()
+			cmd.SetArgs(tt.args)
+			defer func() { initAllEnvs, initEnv, initCheck, initFromDeploy = false, "", false, 0 }()
+
+			err := cmd.Execute()
+			if err == nil || !strings.Contains(err.Error(), "none of the others can be") {
+				t.Errorf("expected mutually exclusive flag error, got: %v", err)
+			}
+		})
+	}
+}
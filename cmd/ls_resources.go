@@ -3,18 +3,22 @@ package cmd
 import (
 	"context"
 
-	"github.com/koh-sh/apcdeploy/internal/cli"
+	"github.com/koh-sh/apcdeploy/internal/config"
 	"github.com/koh-sh/apcdeploy/internal/lsresources"
 	"github.com/spf13/cobra"
 )
 
 var (
-	// lsResourcesRegion is the AWS region for listing resources
-	lsResourcesRegion string
 	// lsResourcesJSON enables JSON output format
 	lsResourcesJSON bool
 	// lsResourcesShowStrategies enables displaying deployment strategies
 	lsResourcesShowStrategies bool
+	// lsResourcesOutputFile redirects the JSON output to a file
+	lsResourcesOutputFile string
+	// lsResourcesApp limits the listing to one application
+	lsResourcesApp string
+	// lsResourcesProfileRegex filters configuration profiles by name
+	lsResourcesProfileRegex string
 )
 
 // LsResourcesCommand returns the ls-resources command
@@ -35,9 +39,11 @@ especially for AI agents that cannot use interactive prompts.`,
 		SilenceUsage: true, // Don't show usage on runtime errors
 	}
 
-	cmd.Flags().StringVar(&lsResourcesRegion, "region", "", "AWS region (uses AWS SDK default if not specified)")
 	cmd.Flags().BoolVar(&lsResourcesJSON, "json", false, "Output in JSON format")
 	cmd.Flags().BoolVar(&lsResourcesShowStrategies, "show-strategies", false, "Include deployment strategies in output")
+	cmd.Flags().StringVar(&lsResourcesOutputFile, "output-file", "", outputFileFlagUsage)
+	cmd.Flags().StringVar(&lsResourcesApp, "app", "", appFlagUsage)
+	cmd.Flags().StringVar(&lsResourcesProfileRegex, "profile-regex", "", profileRegexFlagUsage)
 
 	return cmd
 }
@@ -45,17 +51,26 @@ especially for AI agents that cannot use interactive prompts.`,
 func runLsResources(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
 
+	if err := validateOutputFile(lsResourcesOutputFile, lsResourcesJSON); err != nil {
+		return err
+	}
+	filter, err := config.NewProfileFilter(lsResourcesApp, lsResourcesProfileRegex)
+	if err != nil {
+		return err
+	}
+
 	// Create options
 	opts := &lsresources.Options{
-		Region:         lsResourcesRegion,
+		Region:         region,
 		JSON:           lsResourcesJSON,
 		ShowStrategies: lsResourcesShowStrategies,
+		Filter:         filter,
 	}
 
 	// Create reporter
-	reporter := cli.GetReporter(isSilent())
+	reporter, finish := newOutputReporter(cmd, lsResourcesOutputFile)
 
 	// Execute
 	executor := lsresources.NewExecutor(reporter)
-	return executor.Execute(ctx, opts)
+	return finish(executor.Execute(ctx, opts))
 }
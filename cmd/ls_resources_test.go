@@ -15,11 +15,6 @@ func TestLsResourcesCommand(t *testing.T) {
 			args:    []string{},
 			wantErr: false,
 		},
-		{
-			name:    "with region flag",
-			args:    []string{"--region", "us-east-1"},
-			wantErr: false,
-		},
 		{
 			name:    "with json flag",
 			args:    []string{"--json"},
@@ -32,7 +27,7 @@ func TestLsResourcesCommand(t *testing.T) {
 		},
 		{
 			name:    "with all flags",
-			args:    []string{"--region", "us-west-2", "--json", "--show-strategies"},
+			args:    []string{"--json", "--show-strategies"},
 			wantErr: false,
 		},
 	}
@@ -40,7 +35,7 @@ func TestLsResourcesCommand(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Reset global flags for each test
-			lsResourcesRegion = ""
+			region = ""
 			lsResourcesJSON = false
 			lsResourcesShowStrategies = false
 
@@ -76,7 +71,7 @@ func TestLsResourcesCommandStructure(t *testing.T) {
 }
 
 func TestLsResourcesCommandFlags(t *testing.T) {
-	lsResourcesRegion = ""
+	region = ""
 	lsResourcesJSON = false
 	lsResourcesShowStrategies = false
 
@@ -88,12 +83,6 @@ func TestLsResourcesCommandFlags(t *testing.T) {
 		defaultValue string
 		flagType     string
 	}{
-		{
-			name:         "region flag has default",
-			flagName:     "region",
-			defaultValue: "",
-			flagType:     "string",
-		},
 		{
 			name:         "json flag has default",
 			flagName:     "json",
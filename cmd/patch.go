@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/koh-sh/apcdeploy/internal/cli"
+	"github.com/koh-sh/apcdeploy/internal/config"
+	"github.com/koh-sh/apcdeploy/internal/patch"
+	"github.com/spf13/cobra"
+)
+
+var (
+	patchMergePatch   string
+	patchJSONPatch    string
+	patchWaitDeploy   bool
+	patchWaitBake     bool
+	patchTimeout      int
+	patchDescription  string
+	patchPollBackoff  bool
+	patchPollInterval time.Duration
+	patchAcceptLong   bool
+)
+
+// PatchCommand returns the patch command
+func PatchCommand() *cobra.Command {
+	return newPatchCmd()
+}
+
+func newPatchCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "patch",
+		Short: "Apply a JSON patch to the deployed configuration and deploy it",
+		Long: `Apply a JSON merge patch (RFC 7386) or JSON patch (RFC 6902) to the deployed configuration.
+
+This command will:
+1. Fetch the latest deployed configuration (JSON content only)
+2. Apply the patch given by --merge-patch or --json-patch
+3. Validate the result and overwrite the local data file with it
+4. Show the diff against the deployed version (when stdout is a terminal)
+5. Deploy it the same way as 'run'`,
+		RunE:         runPatch,
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringVar(&patchMergePatch, "merge-patch", "", "Path to a JSON merge patch (RFC 7386) file")
+	cmd.Flags().StringVar(&patchJSONPatch, "json-patch", "", "Path to a JSON patch (RFC 6902) file")
+	cmd.MarkFlagsMutuallyExclusive("merge-patch", "json-patch")
+	cmd.MarkFlagsOneRequired("merge-patch", "json-patch")
+	cmd.Flags().BoolVar(&patchWaitDeploy, "wait-deploy", false, "Wait for deployment phase to complete (until baking starts)")
+	cmd.Flags().BoolVar(&patchWaitBake, "wait-bake", false, "Wait for complete deployment including baking phase")
+	cmd.Flags().BoolVar(&patchAcceptLong, "accept-long-bake", false, acceptLongBakeFlagUsage)
+	cmd.Flags().IntVar(&patchTimeout, "timeout", 0, timeoutFlagUsage)
+	cmd.Flags().BoolVar(&patchPollBackoff, "poll-backoff", false, "Poll deployment status with exponential backoff (5s doubling up to 1m) while waiting")
+	cmd.Flags().DurationVar(&patchPollInterval, "poll-interval", config.DefaultPollingInterval, pollIntervalFlagUsage)
+	cmd.Flags().StringVar(&patchDescription, "description", "", fmt.Sprintf(`Description attached to the configuration version and deployment (max %d chars; defaults to %q, pass "" to clear)`, maxDescriptionLength, defaultDescription))
+
+	return cmd
+}
+
+func runPatch(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	if err := validatePollInterval(patchPollInterval); err != nil {
+		return err
+	}
+	if err := validateDescription(patchDescription); err != nil {
+		return err
+	}
+
+	opts := &patch.Options{
+		ConfigFile:     configFile,
+		MergePatchFile: patchMergePatch,
+		JSONPatchFile:  patchJSONPatch,
+		ShowDiff:       cli.IsTerminalWriter(cmd.OutOrStdout()),
+		WaitDeploy:     patchWaitDeploy,
+		WaitBake:       patchWaitBake,
+		Timeout:        patchTimeout,
+		Description:    resolveDescription(cmd, patchDescription),
+		PollBackoff:    patchPollBackoff,
+		PollInterval:   patchPollInterval,
+		Region:         region,
+		AcceptLongBake: patchAcceptLong,
+	}
+
+	reporter := newReporter(cmd)
+
+	executor := patch.NewExecutor(reporter)
+	return executor.Execute(ctx, opts)
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPatchCommandStructure(t *testing.T) {
+	cmd := newPatchCmd()
+
+	if cmd.Use != "patch" {
+		t.Errorf("Use = %v, want patch", cmd.Use)
+	}
+	if cmd.RunE == nil {
+		t.Error("RunE should be set")
+	}
+	for _, name := range []string{"merge-patch", "json-patch", "wait-deploy", "wait-bake", "timeout", "description"} {
+		if cmd.Flags().Lookup(name) == nil {
+			t.Errorf("expected --%s flag", name)
+		}
+	}
+}
+
+func TestPatchCommandPatchFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{"neither patch flag", []string{}, "at least one of the flags"},
+		{"both patch flags", []string{"--merge-patch", "a.json", "--json-patch", "b.json"}, "none of the others can be"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newPatchCmd()
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatalf("ParseFlags() error = %v", err)
+			}
+			err := cmd.ValidateFlagGroups()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Execute() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/koh-sh/apcdeploy/internal/profile"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// profileExportOutputFile writes the bundle to a file instead of stdout
+	profileExportOutputFile string
+	// profileImportApp is the application to create the profile in
+	profileImportApp string
+	// profileImportName overrides the profile name stored in the bundle
+	profileImportName string
+	// profileImportRetrievalRole replaces the bundle's retrieval role ARN
+	profileImportRetrievalRole string
+	// profileImportKeepLambda keeps LAMBDA validators instead of dropping them
+	profileImportKeepLambda bool
+)
+
+// ProfileCommand returns the profile command
+func ProfileCommand() *cobra.Command {
+	return newProfileCmd()
+}
+
+func newProfileCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "profile",
+		Short: "Back up and restore configuration profiles",
+		Long: `Back up a configuration profile to a portable bundle and recreate it in another
+application, region or account.`,
+	}
+
+	cmd.AddCommand(newProfileExportCmd())
+	cmd.AddCommand(newProfileImportCmd())
+
+	return cmd
+}
+
+func newProfileExportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export a profile definition and its latest content",
+		Long: `Export the configuration profile named in apcdeploy.yml as a JSON bundle: its
+name, description, type, location URI, retrieval role and validators, plus the
+content of the newest hosted configuration version (deployed or not). Profiles
+stored outside the hosted store (S3, SSM, Secrets Manager) are exported
+without content. The bundle holds no resource IDs, so it can be imported
+anywhere with 'apcdeploy profile import'. This command is read-only.`,
+		Example:      `  apcdeploy profile export -o flags.profile.json`,
+		RunE:         runProfileExport,
+		SilenceUsage: true, // Don't show usage on runtime errors
+	}
+
+	cmd.Flags().StringVarP(&profileExportOutputFile, "output-file", "o", "", "Write the bundle to this file instead of stdout (parent directories are created)")
+
+	return cmd
+}
+
+func newProfileImportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import <bundle>",
+		Short: "Recreate a profile from an exported bundle",
+		Long: `Create the configuration profile stored in a bundle written by 'apcdeploy
+profile export' inside --app, then create a hosted configuration version with
+the bundled content. The profile is not deployed. An existing profile with the
+same name is never modified; pass --name to import under another name.
+
+LAMBDA validators reference a function ARN in the source account and are
+dropped with a warning unless --keep-lambda-validators is given. JSON_SCHEMA
+validators are always recreated. The region comes from --region, falling back
+to AWS_REGION, AWS_DEFAULT_REGION, then the shared config profile.`,
+		Example: `  apcdeploy profile import flags.profile.json --app my-app --region eu-west-1
+  apcdeploy profile import flags.profile.json --app my-app --name flags-restored`,
+		Args:         cobra.ExactArgs(1),
+		RunE:         runProfileImport,
+		SilenceUsage: true, // Don't show usage on runtime errors
+	}
+
+	cmd.Flags().StringVar(&profileImportApp, "app", "", "Application to create the profile in")
+	cmd.Flags().StringVar(&profileImportName, "name", "", "Profile name to create (defaults to the name in the bundle)")
+	cmd.Flags().StringVar(&profileImportRetrievalRole, "retrieval-role-arn", "", "Retrieval role ARN for the new profile, replacing the one in the bundle")
+	cmd.Flags().BoolVar(&profileImportKeepLambda, "keep-lambda-validators", false, "Recreate LAMBDA validators as-is instead of dropping them")
+	_ = cmd.MarkFlagRequired("app")
+
+	return cmd
+}
+
+func runProfileExport(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	opts := &profile.ExportOptions{
+		ConfigFile: configFile,
+		Region:     region,
+	}
+
+	reporter, finish := newOutputReporter(cmd, profileExportOutputFile)
+
+	executor := profile.NewExecutor(reporter)
+	return finish(executor.Export(ctx, opts))
+}
+
+func runProfileImport(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	opts := &profile.ImportOptions{
+		File:                 args[0],
+		Application:          profileImportApp,
+		Name:                 profileImportName,
+		Region:               region,
+		RetrievalRoleARN:     profileImportRetrievalRole,
+		KeepLambdaValidators: profileImportKeepLambda,
+	}
+
+	reporter := newReporter(cmd)
+	executor := profile.NewExecutor(reporter)
+	return executor.Import(ctx, opts)
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestProfileCommandStructure(t *testing.T) {
+	cmd := newProfileCmd()
+
+	if cmd.Use != "profile" {
+		t.Errorf("Use = %v, want profile", cmd.Use)
+	}
+	names := map[string]bool{}
+	for _, sub := range cmd.Commands() {
+		names[sub.Name()] = true
+	}
+	for _, want := range []string{"export", "import"} {
+		if !names[want] {
+			t.Errorf("expected %q subcommand", want)
+		}
+	}
+}
+
+func TestProfileExportCommandStructure(t *testing.T) {
+	cmd := newProfileExportCmd()
+
+	if cmd.RunE == nil {
+		t.Error("RunE should be set")
+	}
+	if f := cmd.Flags().ShorthandLookup("o"); f == nil || f.Name != "output-file" {
+		t.Error("expected -o to be the shorthand for --output-file")
+	}
+}
+
+func TestProfileImportCommandStructure(t *testing.T) {
+	cmd := newProfileImportCmd()
+
+	for _, name := range []string{"app", "name", "retrieval-role-arn", "keep-lambda-validators"} {
+		if cmd.Flags().Lookup(name) == nil {
+			t.Errorf("expected --%s flag", name)
+		}
+	}
+}
+
+func TestProfileImportRequiresAppAndBundle(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{name: "missing bundle", args: []string{"--app", "a"}, wantErr: "accepts 1 arg(s)"},
+		{name: "missing app", args: []string{"bundle.json"}, wantErr: `required flag(s) "app" not set`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newProfileImportCmd()
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
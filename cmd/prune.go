@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/koh-sh/apcdeploy/internal/prompt"
+	"github.com/koh-sh/apcdeploy/internal/prune"
+	"github.com/spf13/cobra"
+)
+
+var (
+	pruneKeep             int
+	pruneDryRun           bool
+	pruneSkipConfirmation bool
+)
+
+// PruneCommand returns the prune command
+func PruneCommand() *cobra.Command {
+	return newPruneCmd()
+}
+
+func newPruneCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Delete old hosted configuration versions",
+		Long: `Delete old hosted configuration versions of the configuration profile.
+
+AppConfig keeps every hosted configuration version, up to a quota of 1000
+per profile. This command keeps the newest --keep versions and deletes the
+rest with DeleteHostedConfigurationVersion, except versions still in use in
+any environment of the application:
+
+  - versions of completed deployments and of deployments in progress
+  - the version of each environment's latest deployment, whatever its state
+
+The versions to delete are listed before a confirmation prompt; --dry-run
+stops after listing them and --yes skips the prompt.`,
+		RunE:         runPrune,
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().IntVar(&pruneKeep, "keep", prune.DefaultKeep, "Number of newest hosted configuration versions to keep")
+	cmd.Flags().BoolVar(&pruneDryRun, "dry-run", false, "List the versions that would be deleted without deleting them")
+	cmd.Flags().BoolVarP(&pruneSkipConfirmation, "yes", "y", false, "Skip confirmation prompt")
+
+	return cmd
+}
+
+func runPrune(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	opts := &prune.Options{
+		ConfigFile:       configFile,
+		Region:           region,
+		Keep:             pruneKeep,
+		DryRun:           pruneDryRun,
+		SkipConfirmation: pruneSkipConfirmation,
+	}
+
+	executor := prune.NewExecutor(newReporter(cmd), &prompt.HuhPrompter{})
+	return executor.Execute(ctx, opts)
+}
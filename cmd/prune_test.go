@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/koh-sh/apcdeploy/internal/prune"
+)
+
+func TestPruneCommand(t *testing.T) {
+	t.Parallel()
+
+	cmd := PruneCommand()
+	if cmd.Use != "prune" {
+		t.Errorf("Use = %q, want prune", cmd.Use)
+	}
+	if !cmd.SilenceUsage {
+		t.Error("expected SilenceUsage to be true")
+	}
+	keep := cmd.Flags().Lookup("keep")
+	if keep == nil || keep.DefValue != "100" {
+		t.Errorf("--keep default = %v, want %d", keep, prune.DefaultKeep)
+	}
+	if yes := cmd.Flags().ShorthandLookup("y"); yes == nil || yes.Name != "yes" {
+		t.Error("expected -y to be the shorthand for --yes")
+	}
+}
+
+func TestRunPruneNegativeKeep(t *testing.T) {
+	cmd := newPruneCmd()
+	if err := cmd.ParseFlags([]string{"--keep", "-1"}); err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+	defer func() { pruneKeep = prune.DefaultKeep }()
+
+	err := runPrune(cmd, nil)
+	if err == nil || !strings.Contains(err.Error(), "--keep must be a non-negative number") {
+		t.Errorf("expected --keep error, got: %v", err)
+	}
+}
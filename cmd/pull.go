@@ -2,12 +2,22 @@ package cmd
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"strings"
 
-	"github.com/koh-sh/apcdeploy/internal/cli"
+	"github.com/koh-sh/apcdeploy/internal/config"
 	"github.com/koh-sh/apcdeploy/internal/pull"
 	"github.com/spf13/cobra"
 )
 
+var (
+	pullResume      bool
+	pullFromVersion string
+	pullAs          string
+	pullDeployment  int32
+)
+
 // PullCommand returns the pull command
 func PullCommand() *cobra.Command {
 	return newPullCmd()
@@ -23,24 +33,58 @@ This command retrieves the currently deployed configuration and overwrites your
 Useful when configuration changes are made directly in the AWS Console and you want to sync
 your local files with the deployed state.
 
+When the profile has never been deployed, pull fails by default. With
+--from-version latest it instead writes the newest hosted configuration version,
+which is useful to seed a local file for a profile created in the console.
+
+--deployment <n> pulls the configuration version of that deployment instead
+of the latest one, e.g. to restore the data file from a known-good
+deployment. The deployment must belong to the configured profile.
+
+--as json|yaml|text converts the fetched content into that format before
+writing, whatever its content type in AppConfig. The format must match how
+run reads the data file (content_type, else the data_file extension), and
+content that cannot be converted (e.g. plain text to YAML) is an error.
+
 Note: This command does NOT use the AppConfig Data API, so it does not incur per-call charges.`,
 		RunE:         runPull,
 		SilenceUsage: true, // Don't show usage on runtime errors
 	}
 
+	cmd.Flags().BoolVar(&pullResume, "resume", false, "Reuse the content cached by a previous pull whose file write failed, if the deployment is unchanged")
+	cmd.Flags().StringVar(&pullFromVersion, "from-version", "", `When nothing is deployed yet, pull this hosted version instead (only "latest" is supported)`)
+	cmd.Flags().Int32Var(&pullDeployment, "deployment", 0, "Pull the configuration of this deployment number instead of the latest deployment")
+	cmd.Flags().StringVar(&pullAs, "as", "", fmt.Sprintf("Convert the fetched content to this format before writing (%s)", strings.Join(config.Formats(), ", ")))
+	cmd.MarkFlagsMutuallyExclusive("deployment", "from-version")
+
 	return cmd
 }
 
 func runPull(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
 
+	if pullFromVersion != "" && pullFromVersion != pull.FromVersionLatest {
+		return fmt.Errorf("invalid --from-version %q: only %q is supported", pullFromVersion, pull.FromVersionLatest)
+	}
+	if cmd.Flags().Changed("deployment") && pullDeployment < 1 {
+		return fmt.Errorf("--deployment must be a positive deployment number")
+	}
+	if pullAs != "" && !slices.Contains(config.Formats(), pullAs) {
+		return fmt.Errorf("invalid --as %q: must be one of %s", pullAs, strings.Join(config.Formats(), ", "))
+	}
+
 	// Create options
 	opts := &pull.Options{
-		ConfigFile: configFile,
+		ConfigFile:  configFile,
+		Region:      region,
+		Resume:      pullResume,
+		FromVersion: pullFromVersion,
+		Deployment:  pullDeployment,
+		As:          pullAs,
 	}
 
 	// Create reporter
-	reporter := cli.GetReporter(isSilent())
+	reporter := newReporter(cmd)
 
 	// Pull configuration
 	executor := pull.NewExecutor(reporter)
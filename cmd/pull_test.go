@@ -3,6 +3,7 @@ package cmd
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -137,3 +138,57 @@ func TestPullCommandSilenceUsage(t *testing.T) {
 		t.Error("pull command should have SilenceUsage set to true")
 	}
 }
+
+func TestRunPullInvalidAs(t *testing.T) {
+	cmd := newPullCmd()
+	pullAs = "toml"
+	defer func() { pullAs = "" }()
+
+	err := runPull(cmd, nil)
+	if err == nil || !strings.Contains(err.Error(), `invalid --as "toml"`) {
+		t.Errorf("expected invalid --as error, got: %v", err)
+	}
+}
+
+func TestRunPullDeploymentValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{
+			name:    "non-positive deployment",
+			args:    []string{"--deployment", "0"},
+			wantErr: "--deployment must be a positive deployment number",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newPullCmd()
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatalf("ParseFlags() error = %v", err)
+			}
+			defer func() { pullDeployment = 0 }()
+
+			err := runPull(cmd, nil)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("runPull() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPullCommandDeploymentFromVersionMutuallyExclusive(t *testing.T) {
+	cmd := newPullCmd()
+	cmd.SetArgs([]string{"--deployment", "3", "--from-version", "latest"})
+	defer func() {
+		pullDeployment = 0
+		pullFromVersion = ""
+	}()
+
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "none of the others can be") {
+		t.Errorf("Execute() error = %v, want a mutually exclusive flags error", err)
+	}
+}
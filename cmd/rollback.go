@@ -2,14 +2,25 @@ package cmd
 
 import (
 	"context"
+	"fmt"
 
-	"github.com/koh-sh/apcdeploy/internal/cli"
 	"github.com/koh-sh/apcdeploy/internal/prompt"
 	"github.com/koh-sh/apcdeploy/internal/rollback"
 	"github.com/spf13/cobra"
 )
 
-var rollbackSkipConfirmation bool
+var (
+	rollbackSkipConfirmation bool
+	rollbackToDescription    string
+	rollbackLatestMatch      bool
+	rollbackToVersion        int32
+	rollbackToDeployment     int32
+	rollbackToPrevious       bool
+	rollbackWaitDeploy       bool
+	rollbackWaitBake         bool
+	rollbackAcceptLongBake   bool
+	rollbackTimeout          int
+)
 
 // RollbackCommand returns the rollback command
 func RollbackCommand() *cobra.Command {
@@ -19,16 +30,37 @@ func RollbackCommand() *cobra.Command {
 func newRollbackCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "rollback",
-		Short: "Stop an ongoing deployment",
+		Short: "Stop an ongoing deployment or redeploy an earlier version",
 		Long: `Stop an ongoing deployment in AWS AppConfig.
 
 This command stops an in-progress deployment by calling the AWS AppConfig StopDeployment API.
-It automatically finds the current ongoing deployment and stops it.`,
+It automatically finds the current ongoing deployment and stops it.
+
+With --to-previous, --to-version, --to-deployment or --to-description, it instead
+redeploys an earlier configuration version:
+
+  --to-previous     the version of the most recent completed deployment before the current one
+  --to-version      a hosted configuration version by number
+  --to-deployment   the version used by a past deployment
+  --to-description  the version of the newest deployment whose description contains the text
+                    (refuses when several match unless --latest-match is set)
+
+A redeploy is refused while another deployment is in progress.`,
 		RunE:         runRollback,
 		SilenceUsage: true, // Don't show usage on runtime errors
 	}
 
 	cmd.Flags().BoolVarP(&rollbackSkipConfirmation, "yes", "y", false, "Skip confirmation prompt")
+	cmd.Flags().StringVar(&rollbackToDescription, "to-description", "", "Redeploy the version of the newest deployment whose description contains this text")
+	cmd.Flags().BoolVar(&rollbackLatestMatch, "latest-match", false, "With --to-description, use the newest match when several deployments match")
+	cmd.Flags().BoolVar(&rollbackToPrevious, "to-previous", false, "Redeploy the version of the most recent completed deployment before the current one")
+	cmd.Flags().Int32Var(&rollbackToVersion, "to-version", 0, "Redeploy this hosted configuration version")
+	cmd.Flags().Int32Var(&rollbackToDeployment, "to-deployment", 0, "Redeploy the configuration version used by this deployment number")
+	cmd.Flags().BoolVar(&rollbackWaitDeploy, "wait-deploy", false, "After a redeploy, wait for the deployment phase to complete (until baking starts)")
+	cmd.Flags().BoolVar(&rollbackWaitBake, "wait-bake", false, "After a redeploy, wait for complete deployment including baking phase")
+	cmd.Flags().BoolVar(&rollbackAcceptLongBake, "accept-long-bake", false, acceptLongBakeFlagUsage)
+	cmd.Flags().IntVar(&rollbackTimeout, "timeout", 0, "Timeout in seconds for the redeploy wait (default: timeout in the config file, else 1800)")
+	cmd.MarkFlagsMutuallyExclusive("to-previous", "to-version", "to-deployment", "to-description")
 
 	return cmd
 }
@@ -36,18 +68,47 @@ It automatically finds the current ongoing deployment and stops it.`,
 func runRollback(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
 
+	if rollbackLatestMatch && rollbackToDescription == "" {
+		return fmt.Errorf("--latest-match requires --to-description")
+	}
+	if cmd.Flags().Changed("to-version") && rollbackToVersion < 1 {
+		return fmt.Errorf("--to-version must be a positive version number")
+	}
+	if cmd.Flags().Changed("to-deployment") && rollbackToDeployment < 1 {
+		return fmt.Errorf("--to-deployment must be a positive deployment number")
+	}
+
 	// Create options
 	opts := &rollback.Options{
 		ConfigFile:       configFile,
 		Silent:           isSilent(),
 		SkipConfirmation: rollbackSkipConfirmation,
+		Region:           region,
+		ToDescription:    rollbackToDescription,
+		LatestMatch:      rollbackLatestMatch,
+		ToVersion:        rollbackToVersion,
+		ToDeployment:     rollbackToDeployment,
+		ToPrevious:       rollbackToPrevious,
+		WaitDeploy:       rollbackWaitDeploy,
+		WaitBake:         rollbackWaitBake,
+		AcceptLongBake:   rollbackAcceptLongBake,
+		Timeout:          rollbackTimeout,
+	}
+	if (opts.WaitDeploy || opts.WaitBake) && !opts.Redeploys() {
+		return fmt.Errorf("--wait-deploy and --wait-bake require --to-previous, --to-version, --to-deployment or --to-description")
+	}
+	if opts.WaitDeploy && opts.WaitBake {
+		return fmt.Errorf("--wait-deploy and --wait-bake cannot be used together")
 	}
 
 	// Create reporter and prompter
-	reporter := cli.GetReporter(isSilent())
+	reporter := newReporter(cmd)
 	prompter := &prompt.HuhPrompter{}
 
 	// Run rollback
 	executor := rollback.NewExecutor(reporter, prompter)
+	if opts.Redeploys() {
+		return executor.ExecuteRedeploy(ctx, opts)
+	}
 	return executor.Execute(ctx, opts)
 }
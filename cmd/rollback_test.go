@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/spf13/cobra"
@@ -61,3 +62,81 @@ func TestRollbackCommand(t *testing.T) {
 		})
 	}
 }
+
+func TestRunRollbackFlagValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{
+			name:    "wait without a redeploy flag",
+			args:    []string{"--wait-bake"},
+			wantErr: "--wait-deploy and --wait-bake require --to-previous",
+		},
+		{
+			name:    "non-positive version",
+			args:    []string{"--to-version", "0"},
+			wantErr: "--to-version must be a positive version number",
+		},
+		{
+			name:    "non-positive deployment",
+			args:    []string{"--to-deployment", "-1"},
+			wantErr: "--to-deployment must be a positive deployment number",
+		},
+		{
+			name:    "both waits",
+			args:    []string{"--to-previous", "--wait-deploy", "--wait-bake"},
+			wantErr: "--wait-deploy and --wait-bake cannot be used together",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newRollbackCmd()
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatalf("ParseFlags() error = %v", err)
+			}
+			defer func() {
+				rollbackToPrevious = false
+				rollbackWaitDeploy = false
+				rollbackWaitBake = false
+				rollbackToVersion = 0
+				rollbackToDeployment = 0
+			}()
+
+			err := runRollback(cmd, nil)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestRollbackRedeployFlagsMutuallyExclusive(t *testing.T) {
+	cmd := newRollbackCmd()
+	cmd.SetArgs([]string{"--to-previous", "--to-version", "3"})
+	cmd.SetOut(&strings.Builder{})
+	cmd.SetErr(&strings.Builder{})
+	defer func() {
+		rollbackToPrevious = false
+		rollbackToVersion = 0
+	}()
+
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "none of the others can be") {
+		t.Errorf("expected mutually exclusive flag error, got: %v", err)
+	}
+}
+
+func TestRollbackVersionFlagsRejectOutOfRange(t *testing.T) {
+	for _, flag := range []string{"--to-version", "--to-deployment"} {
+		t.Run(flag, func(t *testing.T) {
+			cmd := newRollbackCmd()
+			err := cmd.ParseFlags([]string{flag, "4294967297"})
+			if err == nil || !strings.Contains(err.Error(), "out of range") {
+				t.Errorf("ParseFlags(%s 4294967297) error = %v, want out of range", flag, err)
+			}
+		})
+	}
+}
@@ -1,14 +1,20 @@
 package cmd
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
+	"time"
 	"unicode/utf8"
 
 	awsInternal "github.com/koh-sh/apcdeploy/internal/aws"
 	"github.com/koh-sh/apcdeploy/internal/cli"
+	"github.com/koh-sh/apcdeploy/internal/config"
 	apcerrors "github.com/koh-sh/apcdeploy/internal/errors"
+	"github.com/koh-sh/apcdeploy/internal/reporter"
+	"github.com/koh-sh/apcdeploy/internal/run"
+	"github.com/koh-sh/apcdeploy/internal/tracing"
 	"github.com/spf13/cobra"
 )
 
@@ -16,6 +22,9 @@ import (
 // distinguishable condition that scripts can branch on.
 const (
 	exitNoDeployment = 2
+	// exitChangesFound is returned by run --check when a deployment would
+	// change the configuration.
+	exitChangesFound = 3
 )
 
 var (
@@ -25,10 +34,24 @@ var (
 	date    string
 
 	// Global flags
-	configFile string
-	silent     bool
+	configFile  string
+	silent      bool
+	summaryOnly bool
+	noColor     bool
+	colorMode   string
+	plain       bool
+	caBundle    string
+	region      string
+	awsProfile  string
+	otelEnabled bool
+
+	concurrentResolve bool
 )
 
+// tracingShutdownTimeout bounds how long Execute waits for pending spans to
+// be exported before exiting.
+const tracingShutdownTimeout = 5 * time.Second
+
 // NewRootCommand creates and returns the root command
 func NewRootCommand() *cobra.Command {
 	rootCmd := &cobra.Command{
@@ -37,23 +60,61 @@ func NewRootCommand() *cobra.Command {
 		Long: `apcdeploy is a CLI tool for managing AWS AppConfig deployments.
 It provides commands to initialize, deploy, diff, and check the status of configurations.`,
 		Version: fmt.Sprintf("%s (Built on %s from Git SHA %s)", version, date, commit),
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if noColor {
+				colorMode = cli.ColorNever
+			}
+			if err := cli.ConfigureColor(colorMode); err != nil {
+				return err
+			}
+			cli.ConfigurePlain(plain)
+			awsInternal.SetCABundle(caBundle)
+			awsInternal.SetSharedConfigProfile(awsProfile)
+			awsInternal.SetConcurrentResolve(concurrentResolve)
+			// An OTLP endpoint in the environment only enables tracing when the
+			// binary supports it; an explicit --otel on a build without it is
+			// an error so the missing spans are not a surprise.
+			if tracing.Requested(otelEnabled) {
+				if err := tracing.Setup(cmd.Context(), version); err != nil && otelEnabled {
+					return err
+				}
+			}
+			return nil
+		},
 	}
 
 	// Global flags
 	rootCmd.PersistentFlags().StringVarP(&configFile, "config", "c", "apcdeploy.yml", "config file path")
 	rootCmd.PersistentFlags().BoolVarP(&silent, "silent", "s", false, "suppress verbose output, show only essential information")
+	rootCmd.PersistentFlags().BoolVar(&summaryOnly, "summary-only", false, "hide per-step progress and print one summary line per target when the command finishes")
+	rootCmd.PersistentFlags().StringVar(&region, "region", "", "AWS region; overrides region in the config file (falls back to AWS_REGION, AWS_DEFAULT_REGION, then the shared config profile)")
+	rootCmd.PersistentFlags().StringVar(&awsProfile, "aws-profile", "", "AWS named profile from the shared config/credentials files (overrides AWS_PROFILE; --region still wins over its region)")
+	rootCmd.PersistentFlags().StringVar(&caBundle, "ca-bundle", "", "PEM file with extra CA certificates to trust for AWS API calls (e.g. a TLS-inspecting proxy)")
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "disable colored output (also disabled by NO_COLOR or when stdout is not a terminal); same as --color never")
+	rootCmd.PersistentFlags().StringVar(&colorMode, "color", cli.ColorAuto, "when to color output: auto (terminal only, off with NO_COLOR), always (also when piped), or never")
+	rootCmd.MarkFlagsMutuallyExclusive("no-color", "color")
+	rootCmd.PersistentFlags().BoolVar(&concurrentResolve, "concurrent-resolve", true, "resolve application, profile, environment and strategy names concurrently; set --concurrent-resolve=false for sequential lookups")
+	rootCmd.PersistentFlags().BoolVar(&plain, "plain", false, "strip all decoration (colors, symbols, boxes, spinners) for embedding; line prefixes become words such as 'ok:' and 'error:'")
+	rootCmd.PersistentFlags().BoolVar(&otelEnabled, "otel", false, "export deploy phase spans over OTLP/HTTP (configured by OTEL_EXPORTER_OTLP_* env vars; requires a build with -tags otel)")
 
 	// Add subcommands
 	rootCmd.AddCommand(InitCommand())
 	rootCmd.AddCommand(RunCommand())
+	rootCmd.AddCommand(ApplyCommand())
 	rootCmd.AddCommand(DiffCommand())
 	rootCmd.AddCommand(StatusCommand())
 	rootCmd.AddCommand(GetCommand())
 	rootCmd.AddCommand(PullCommand())
+	rootCmd.AddCommand(PatchCommand())
 	rootCmd.AddCommand(RollbackCommand())
 	rootCmd.AddCommand(LsResourcesCommand())
+	rootCmd.AddCommand(StrategiesCommand())
 	rootCmd.AddCommand(ContextCommand())
 	rootCmd.AddCommand(EditCommand())
+	rootCmd.AddCommand(ExportCommand())
+	rootCmd.AddCommand(DoctorCommand())
+	rootCmd.AddCommand(ProfileCommand())
+	rootCmd.AddCommand(PruneCommand())
 
 	return rootCmd
 }
@@ -72,11 +133,25 @@ func Execute() {
 	// Enable custom error formatting
 	rootCmd.SilenceErrors = true
 
-	if err := rootCmd.Execute(); err != nil {
+	err := rootCmd.Execute()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+	if shutdownErr := tracing.Shutdown(shutdownCtx); shutdownErr != nil {
+		cli.GetReporter(silent, summaryOnly).Warn(fmt.Sprintf("failed to export traces: %v", shutdownErr))
+	}
+	cancel()
+
+	// run --check answers through the exit code; the target rows already
+	// show which targets would change, so nothing is printed as an error.
+	if errors.Is(err, run.ErrChangesFound) {
+		os.Exit(exitChangesFound)
+	}
+
+	if err != nil {
 		// Funnel the top-level error through the Reporter so the styled "✗"
 		// prefix is consistent with the rest of stderr output. Both real and
 		// silent reporters always emit Error.
-		rep := cli.GetReporter(silent)
+		rep := cli.GetReporter(silent, summaryOnly)
 		rep.Error(err.Error())
 		// Append a Resolution: <hint> line when the underlying AWS error code
 		// has a documented remediation (output.md §8.3 / internal/errors).
@@ -102,10 +177,15 @@ func isSilent() bool {
 	return silent
 }
 
+// isSummaryOnly returns whether summary-only mode is enabled
+func isSummaryOnly() bool {
+	return summaryOnly
+}
+
 // maxDescriptionLength matches the AppConfig API limit on the Description
 // field of CreateHostedConfigurationVersion / StartDeployment. Validating
 // locally produces a clearer error than the AWS-side ValidationException.
-const maxDescriptionLength = 1024
+const maxDescriptionLength = awsInternal.DescriptionMaxLength
 
 // defaultDescription is attached to AppConfig configuration versions and
 // deployments when the user did not pass --description. It marks the change
@@ -119,9 +199,15 @@ const defaultDescription = "Deployed by apcdeploy"
 // Empty values are allowed — the AWS wrappers omit the field entirely when
 // description is "".
 func validateDescription(s string) error {
+	return validateDescriptionFlag("description", s)
+}
+
+// validateDescriptionFlag is validateDescription for a description flag
+// other than --description, so the error names the flag that was too long.
+func validateDescriptionFlag(name, s string) error {
 	n := utf8.RuneCountInString(s)
 	if n > maxDescriptionLength {
-		return fmt.Errorf("--description exceeds maximum length of %d characters (got %d)", maxDescriptionLength, n)
+		return fmt.Errorf("--%s exceeds maximum length of %d characters (got %d)", name, maxDescriptionLength, n)
 	}
 	return nil
 }
@@ -136,3 +222,129 @@ func resolveDescription(cmd *cobra.Command, value string) string {
 	}
 	return defaultDescription
 }
+
+// resolveSplitDescriptions returns the version and deployment descriptions
+// for commands that accept --version-description / --deploy-description.
+// Each falls back to the resolved --description when its own flag was not
+// passed, so --description keeps setting both.
+func resolveSplitDescriptions(cmd *cobra.Command, description, versionDescription, deployDescription string) (string, string) {
+	base := resolveDescription(cmd, description)
+	version, deploy := base, base
+	if cmd.Flags().Changed("version-description") {
+		version = versionDescription
+	}
+	if cmd.Flags().Changed("deploy-description") {
+		deploy = deployDescription
+	}
+	return version, deploy
+}
+
+// validateOutputFormat rejects --output values other than text and json
+// before any AWS call is made.
+func validateOutputFormat(v string) error {
+	switch v {
+	case config.OutputFormatText, config.OutputFormatJSON:
+		return nil
+	default:
+		return fmt.Errorf("invalid --output %q: must be %q or %q", v, config.OutputFormatText, config.OutputFormatJSON)
+	}
+}
+
+// timeoutFlagUsage is the shared help text for the deployment --timeout.
+const timeoutFlagUsage = "Timeout in seconds for deployment (default: timeout in the config file, else 1800)"
+
+// acceptLongBakeFlagUsage is the shared help text for --accept-long-bake on
+// the commands without --long-bake-threshold.
+var acceptLongBakeFlagUsage = fmt.Sprintf("With --wait-bake, proceed even when the deployment strategy is estimated to take longer than %s and the timeout", cli.FormatElapsed(run.DefaultLongBakeThreshold))
+
+// contentTypeFlagUsage is the shared help text for --content-type.
+const contentTypeFlagUsage = "Content type to upload as, overriding content_type and the data file extension (application/json, application/x-yaml, application/toml or text/plain)"
+
+// validateContentTypeFlag checks a --content-type value; empty means unset.
+func validateContentTypeFlag(v string) error {
+	if v == "" {
+		return nil
+	}
+	return config.ValidateContentTypeOverride("", v)
+}
+
+// pollIntervalFlagUsage is the shared help text for --poll-interval.
+const pollIntervalFlagUsage = "Interval between deployment status polls while waiting, from 1s to 5m (the starting interval with --poll-backoff)"
+
+// validatePollInterval checks a --poll-interval value against the bounds in
+// config.MinPollingInterval and config.MaxPollingInterval.
+func validatePollInterval(d time.Duration) error {
+	if d < config.MinPollingInterval || d > config.MaxPollingInterval {
+		return fmt.Errorf("--poll-interval must be between %v and %v (got %v)", config.MinPollingInterval, config.MaxPollingInterval, d)
+	}
+	return nil
+}
+
+// outputFileFlagUsage is the shared help text for --output-file.
+const outputFileFlagUsage = "Write the JSON output to this file instead of stdout (parent directories are created)"
+
+// Shared help text for the --app / --profile-regex target filter.
+const (
+	appFlagUsage          = "Only include targets of this application (exact name)"
+	profileRegexFlagUsage = "Only include configuration profiles whose names match this regular expression"
+)
+
+// bulkProfileFilter builds the --app / --profile-regex filter for a
+// --profiles-from-file run. The flags select entries of the targets file, so
+// they are rejected without one.
+func bulkProfileFilter(app, profileRegex, targetsFile string) (*config.ProfileFilter, error) {
+	if targetsFile == "" && (app != "" || profileRegex != "") {
+		return nil, errors.New("--app and --profile-regex require --profiles-from-file")
+	}
+	return config.NewProfileFilter(app, profileRegex)
+}
+
+// bulkConcurrencyFlagUsage is the shared help text for the --concurrency
+// flag of --profiles-from-file runs.
+const bulkConcurrencyFlagUsage = "Maximum number of --profiles-from-file targets processed at once"
+
+// validateBulkConcurrency rejects a --concurrency below 1, or one given
+// (changed) without --profiles-from-file.
+func validateBulkConcurrency(concurrency int, changed bool, targetsFile string) error {
+	if changed && targetsFile == "" {
+		return errors.New("--concurrency requires --profiles-from-file")
+	}
+	if concurrency < 1 {
+		return errors.New("--concurrency must be at least 1")
+	}
+	return nil
+}
+
+// validateOutputFile rejects --output-file unless the command is producing
+// JSON, since only the machine-readable payload is redirected.
+func validateOutputFile(path string, jsonOutput bool) error {
+	if path != "" && !jsonOutput {
+		return errors.New("--output-file requires JSON output")
+	}
+	return nil
+}
+
+// newReporter returns the reporter selected by --silent / --summary-only,
+// writing to the command's output streams (os.Stdout and os.Stderr unless
+// replaced with SetOut / SetErr).
+func newReporter(cmd *cobra.Command) reporter.Reporter {
+	return cli.GetReporterWithWriters(isSilent(), isSummaryOnly(), cmd.OutOrStdout(), cmd.ErrOrStderr())
+}
+
+// newOutputReporter returns the command reporter and a finish func to call
+// with the command's result. When path is set, stdout Data payloads are
+// captured and finish writes them to path atomically; a write failure takes
+// precedence over a nil or ErrDiffFound-style result so it is never lost.
+func newOutputReporter(cmd *cobra.Command, path string) (reporter.Reporter, func(error) error) {
+	rep := newReporter(cmd)
+	if path == "" {
+		return rep, func(err error) error { return err }
+	}
+	fileRep := cli.NewFileDataReporter(rep, path)
+	return fileRep, func(err error) error {
+		if flushErr := fileRep.Flush(); flushErr != nil {
+			return flushErr
+		}
+		return err
+	}
+}
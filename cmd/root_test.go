@@ -2,7 +2,9 @@ package cmd
 
 import (
 	"bytes"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestRootCommand(t *testing.T) {
@@ -67,6 +69,22 @@ func TestGlobalFlags(t *testing.T) {
 	if configFlag == nil {
 		t.Error("config flag not found")
 	}
+
+	regionFlag := rootCmd.PersistentFlags().Lookup("region")
+	if regionFlag == nil {
+		t.Fatal("region flag not found")
+	}
+	if regionFlag.DefValue != "" {
+		t.Errorf("region flag default = %q, want empty", regionFlag.DefValue)
+	}
+
+	resolveFlag := rootCmd.PersistentFlags().Lookup("concurrent-resolve")
+	if resolveFlag == nil {
+		t.Fatal("concurrent-resolve flag not found")
+	}
+	if resolveFlag.DefValue != "true" {
+		t.Errorf("concurrent-resolve flag default = %q, want true", resolveFlag.DefValue)
+	}
 }
 
 func TestExecute(t *testing.T) {
@@ -89,3 +107,134 @@ func TestExecute(t *testing.T) {
 
 	Execute()
 }
+
+func TestBulkProfileFilter(t *testing.T) {
+	tests := []struct {
+		name         string
+		app          string
+		profileRegex string
+		targetsFile  string
+		wantNil      bool
+		wantErr      bool
+	}{
+		{"no filter", "", "", "", true, false},
+		{"filter with targets file", "web", "^feature-", "targets.yml", false, false},
+		{"filter without targets file", "", "^feature-", "", false, true},
+		{"invalid regex", "", "(", "targets.yml", false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := bulkProfileFilter(tt.app, tt.profileRegex, tt.targetsFile)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("bulkProfileFilter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && (f == nil) != tt.wantNil {
+				t.Errorf("bulkProfileFilter() = %v, wantNil %v", f, tt.wantNil)
+			}
+		})
+	}
+}
+
+func TestValidateBulkConcurrency(t *testing.T) {
+	tests := []struct {
+		name        string
+		concurrency int
+		changed     bool
+		targetsFile string
+		wantErr     string
+	}{
+		{name: "default without targets file", concurrency: 4},
+		{name: "set with targets file", concurrency: 8, changed: true, targetsFile: "targets.yml"},
+		{name: "set without targets file", concurrency: 8, changed: true, wantErr: "requires --profiles-from-file"},
+		{name: "zero", concurrency: 0, changed: true, targetsFile: "targets.yml", wantErr: "at least 1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateBulkConcurrency(tt.concurrency, tt.changed, tt.targetsFile)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("validateBulkConcurrency() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("validateBulkConcurrency() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateOutputFile(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		jsonOutput bool
+		wantErr    bool
+	}{
+		{"unset with text", "", false, false},
+		{"set with json", "out/report.json", true, false},
+		{"set with text", "out/report.json", false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateOutputFile(tt.path, tt.jsonOutput); (err != nil) != tt.wantErr {
+				t.Errorf("validateOutputFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidatePollInterval(t *testing.T) {
+	tests := []struct {
+		name     string
+		interval time.Duration
+		wantErr  bool
+	}{
+		{"default", 5 * time.Second, false},
+		{"lower bound", time.Second, false},
+		{"upper bound", 5 * time.Minute, false},
+		{"too fast", 500 * time.Millisecond, true},
+		{"too slow", 6 * time.Minute, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validatePollInterval(tt.interval); (err != nil) != tt.wantErr {
+				t.Errorf("validatePollInterval() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestColorFlag(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{
+			name:    "unknown mode",
+			args:    []string{"--color", "sometimes", "context"},
+			wantErr: `invalid --color "sometimes"`,
+		},
+		{
+			name:    "no-color conflicts with color",
+			args:    []string{"--no-color", "--color", "always", "context"},
+			wantErr: "none of the others can be",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rootCmd := NewRootCommand()
+			rootCmd.SetArgs(tt.args)
+			buf := new(bytes.Buffer)
+			rootCmd.SetOut(buf)
+			rootCmd.SetErr(buf)
+
+			err := rootCmd.Execute()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Execute() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
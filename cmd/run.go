@@ -2,28 +2,63 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
+	"os/signal"
+	"time"
+	"unicode/utf8"
 
+	awsInternal "github.com/koh-sh/apcdeploy/internal/aws"
 	"github.com/koh-sh/apcdeploy/internal/cli"
+	"github.com/koh-sh/apcdeploy/internal/config"
 	"github.com/koh-sh/apcdeploy/internal/run"
 	"github.com/spf13/cobra"
 )
 
 const (
-	// DefaultDeploymentTimeout is the default timeout for deployments in seconds.
-	// Set to 30 minutes to safely cover AppConfig.AllAtOnce (10 min bake) and
-	// AppConfig.Canary10Percent20Minutes (20 min deploy + 10 min bake) under
-	// --wait-bake. Strategies with longer total durations (e.g.
-	// AppConfig.Linear20PercentEvery6Minutes) require an explicit --timeout.
-	DefaultDeploymentTimeout = 1800
+	// DefaultDeploymentTimeout is the default timeout for deployments in
+	// seconds (see config.DefaultDeploymentTimeout).
+	DefaultDeploymentTimeout = config.DefaultDeploymentTimeout
 )
 
 var (
-	runWaitDeploy  bool
-	runWaitBake    bool
-	runTimeout     int
-	runForce       bool
-	runDescription string
+	runWaitDeploy     bool
+	runWaitBake       bool
+	runTimeout        int
+	runForce          bool
+	runSkipDiffCheck  bool
+	runDescription    string
+	runVersionDesc    string
+	runDeployDesc     string
+	runVersionTags    []string
+	runLabel          string
+	runPollBackoff    bool
+	runPollInterval   time.Duration
+	runDataEnv        string
+	runApplyNormalize bool
+	runEnvsByTag      string
+	runEnvs           []string
+	runValidateRemote bool
+	runKeepValidation bool
+	runNoState        bool
+	runVerify         bool
+	runDumpDir        string
+	runListStrategies bool
+	runGuardAlarms    []string
+	runWaitForSlot    bool
+	runDryRun         bool
+	runCheck          bool
+	runExpandEnv      bool
+	runDataFile       string
+	runContentType    string
+	runStrategy       string
+	runConfigVersion  int32
+	runAcceptLongBake bool
+	runCreateOnly     bool
+	runLongBake       time.Duration
+	runOutput         string
+	runOutputFile     string
 )
 
 // RunCommand returns the run command
@@ -42,16 +77,62 @@ This command will:
 2. Validate the configuration data
 3. Create a new hosted configuration version
 4. Start a deployment to the specified environment
-5. Optionally wait for the deployment phase (--wait-deploy) or full completion (--wait-bake)`,
+5. Optionally wait for the deployment phase (--wait-deploy) or full completion (--wait-bake)
+
+With --config-version N, steps 1-3 are replaced by checking that hosted
+configuration version N exists; it is deployed as is and the data file is
+not read.
+
+With --create-only, the command stops after step 3 and prints the new
+version number, so a later job can promote it with --config-version.`,
 		RunE:         runRun,
 		SilenceUsage: true, // Don't show usage on runtime errors
 	}
 
 	cmd.Flags().BoolVar(&runWaitDeploy, "wait-deploy", false, "Wait for deployment phase to complete (until baking starts)")
 	cmd.Flags().BoolVar(&runWaitBake, "wait-bake", false, "Wait for complete deployment including baking phase")
-	cmd.Flags().IntVar(&runTimeout, "timeout", DefaultDeploymentTimeout, "Timeout in seconds for deployment")
+	cmd.Flags().BoolVar(&runAcceptLongBake, "accept-long-bake", false, "With --wait-bake, proceed even when the deployment strategy is estimated to take longer than --long-bake-threshold")
+	cmd.Flags().DurationVar(&runLongBake, "long-bake-threshold", run.DefaultLongBakeThreshold, "With --wait-bake, warn and require --accept-long-bake when the strategy's rollout plus final bake time exceeds this")
+	cmd.Flags().IntVar(&runTimeout, "timeout", 0, timeoutFlagUsage)
 	cmd.Flags().BoolVar(&runForce, "force", false, "Force deployment even when there are no changes")
+	cmd.Flags().BoolVar(&runSkipDiffCheck, "skip-diff-check", false, "Deploy without comparing against the deployed configuration (it is not fetched); an ongoing deployment still fails the run")
+	cmd.Flags().BoolVar(&runSkipDiffCheck, "no-diff-check", false, "Alias for --skip-diff-check")
+	_ = cmd.Flags().MarkHidden("no-diff-check")
+	cmd.Flags().BoolVar(&runPollBackoff, "poll-backoff", false, "Poll deployment status with exponential backoff (5s doubling up to 1m) while waiting")
+	cmd.Flags().DurationVar(&runPollInterval, "poll-interval", config.DefaultPollingInterval, pollIntervalFlagUsage)
+	cmd.Flags().StringVar(&runDataFile, "data-file", "", `Deploy this file instead of data_file; "-" reads the data from stdin (requires --content-type or content_type in the config file)`)
+	cmd.Flags().StringVar(&runContentType, "content-type", "", contentTypeFlagUsage)
+	cmd.Flags().StringVar(&runStrategy, "deployment-strategy", "", "Deploy with this deployment strategy instead of deployment_strategy from the config (predefined or custom name)")
+	cmd.Flags().Int32Var(&runConfigVersion, "config-version", 0, "Deploy this existing hosted configuration version instead of uploading the data file")
+	cmd.Flags().BoolVar(&runCreateOnly, "create-only", false, "Create the hosted configuration version and print its number without deploying it (promote it later with --config-version)")
+	cmd.Flags().StringVar(&runDataEnv, "data-base64-env", "", "Read the configuration content from this base64-encoded environment variable instead of data_file")
+	cmd.Flags().BoolVar(&runExpandEnv, "expand-env", false, "Substitute ${VAR} and $VAR references in the data with environment variables before validating and uploading ($$ is a literal $); unset variables are an error")
+	cmd.Flags().BoolVar(&runApplyNormalize, "apply-normalize", false, "Upload text content normalized (LF line endings, single trailing newline, text_normalize options) instead of as-is")
+	cmd.Flags().StringVar(&runEnvsByTag, "environments-by-tag", "", "Deploy to every environment of the application tagged key=value instead of the configured environment")
+	cmd.Flags().StringSliceVar(&runEnvs, "environment", nil, "Deploy to this environment instead of the configured one (repeatable or comma-separated; deployed in order, stopping at the first failure)")
+	cmd.Flags().StringSliceVar(&runEnvs, "env", nil, "Alias for --environment")
+	_ = cmd.Flags().MarkHidden("env")
+	cmd.MarkFlagsMutuallyExclusive("environment", "env")
+	cmd.MarkFlagsMutuallyExclusive("environment", "environments-by-tag")
+	cmd.MarkFlagsMutuallyExclusive("env", "environments-by-tag")
+	cmd.Flags().BoolVar(&runValidateRemote, "validate-remote", false, "Run AppConfig's validators by creating a throwaway configuration version without deploying; the version is deleted afterwards")
+	cmd.Flags().BoolVar(&runKeepValidation, "keep-validation-version", false, "With --validate-remote, keep the created version instead of deleting it")
+	cmd.Flags().BoolVar(&runNoState, "no-state", false, "Do not read or write the local deploy record (.apcdeploy.last.json)")
+	cmd.Flags().BoolVar(&runVerify, "verify", false, "After waiting, fetch the served configuration and fail if it differs from the uploaded content (requires --wait-deploy or --wait-bake)")
+	cmd.Flags().BoolVar(&runListStrategies, "list-strategies", false, "Print the deployment strategy names available in the region, one per line, and exit without deploying")
+	cmd.Flags().StringArrayVar(&runGuardAlarms, "guard-alarm", nil, "CloudWatch alarm name that must not be in ALARM state before deploying (repeatable)")
+	cmd.Flags().BoolVar(&runWaitForSlot, "wait-for-slot", false, "If a deployment is already in progress on the environment, wait for it to finish (up to --timeout) instead of failing")
+	cmd.Flags().BoolVar(&runDryRun, "dry-run", false, "Resolve, validate and compare as a real run would, then print the diff and the resolved resources without creating a version or deploying")
+	cmd.Flags().BoolVar(&runCheck, "check", false, "Compare with the deployed configuration without deploying; exit 0 if nothing would change, 3 if a deployment would change it, 1 on error")
+	cmd.Flags().StringVar(&runOutput, "output", config.OutputFormatText, "Output format: text or json (json prints the outcome of every target and the warnings reported)")
+	cmd.Flags().StringVar(&runOutputFile, "output-file", "", outputFileFlagUsage)
+	cmd.Flags().StringVar(&runDumpDir, "dump-normalized", "", "Debug: write the normalized deployed and local content used for change detection to this directory")
+	_ = cmd.Flags().MarkHidden("dump-normalized")
 	cmd.Flags().StringVar(&runDescription, "description", "", fmt.Sprintf(`Description attached to the configuration version and deployment (max %d chars; defaults to %q, pass "" to clear)`, maxDescriptionLength, defaultDescription))
+	cmd.Flags().StringVar(&runVersionDesc, "version-description", "", "Description attached to the configuration version only, overriding --description")
+	cmd.Flags().StringVar(&runDeployDesc, "deploy-description", "", "Description attached to the deployment only, overriding --description")
+	cmd.Flags().StringArrayVar(&runVersionTags, "version-tag", nil, "key=value recorded in the configuration version description for traceability, e.g. build=123 (repeatable; shown by status)")
+	cmd.Flags().StringVar(&runLabel, "label", "", `Label recorded as a "[label]" prefix of the deployment description, cut to fit the description limit (shown by status)`)
 
 	return cmd
 }
@@ -59,22 +140,122 @@ This command will:
 func runRun(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
 
+	if err := validateOutputFormat(runOutput); err != nil {
+		return err
+	}
+	jsonOutput := runOutput == config.OutputFormatJSON
+	if err := validateOutputFile(runOutputFile, jsonOutput); err != nil {
+		return err
+	}
+	if jsonOutput && runListStrategies {
+		return errors.New("--output json cannot be used with --list-strategies")
+	}
+	if jsonOutput && runDryRun {
+		return errors.New("--output json cannot be used with --dry-run")
+	}
+	if jsonOutput && runCheck {
+		return errors.New("--output json cannot be used with --check")
+	}
+	if err := validateContentTypeFlag(runContentType); err != nil {
+		return err
+	}
+	if runLongBake <= 0 {
+		return errors.New("--long-bake-threshold must be a positive duration")
+	}
+	if err := validatePollInterval(runPollInterval); err != nil {
+		return err
+	}
 	if err := validateDescription(runDescription); err != nil {
 		return err
 	}
-	description := resolveDescription(cmd, runDescription)
+	if err := validateDescriptionFlag("version-description", runVersionDesc); err != nil {
+		return err
+	}
+	if err := validateDescriptionFlag("deploy-description", runDeployDesc); err != nil {
+		return err
+	}
+	versionDescription, deployDescription := resolveSplitDescriptions(cmd, runDescription, runVersionDesc, runDeployDesc)
+	versionTags, err := awsInternal.ParseVersionTags(runVersionTags)
+	if err != nil {
+		return err
+	}
+	versionDescription = awsInternal.AppendVersionTags(versionDescription, versionTags)
+	if n := utf8.RuneCountInString(versionDescription); n > maxDescriptionLength {
+		return fmt.Errorf("version description with --version-tag exceeds maximum length of %d characters (got %d)", maxDescriptionLength, n)
+	}
+	if err := awsInternal.ValidateDeploymentLabel(runLabel); err != nil {
+		return err
+	}
+	deployDescription = awsInternal.ApplyDeploymentLabel(deployDescription, runLabel)
 
 	opts := &run.Options{
-		ConfigFile:  configFile,
-		WaitDeploy:  runWaitDeploy,
-		WaitBake:    runWaitBake,
-		Timeout:     runTimeout,
-		Force:       runForce,
-		Description: description,
+		ConfigFile:            configFile,
+		WaitDeploy:            runWaitDeploy,
+		WaitBake:              runWaitBake,
+		Timeout:               runTimeout,
+		Force:                 runForce,
+		SkipDiffCheck:         runSkipDiffCheck,
+		VersionDescription:    versionDescription,
+		DeploymentDescription: deployDescription,
+		PollBackoff:           runPollBackoff,
+		PollInterval:          runPollInterval,
+		DataBase64Env:         runDataEnv,
+		ApplyNormalize:        runApplyNormalize,
+		EnvironmentsByTag:     runEnvsByTag,
+		Environments:          runEnvs,
+		ValidateRemote:        runValidateRemote,
+		KeepValidationVersion: runKeepValidation,
+		NoState:               runNoState,
+		Verify:                runVerify,
+		DumpNormalized:        runDumpDir,
+		Region:                region,
+		ListStrategies:        runListStrategies,
+		GuardAlarms:           runGuardAlarms,
+		WaitForSlot:           runWaitForSlot,
+		DryRun:                runDryRun,
+		Check:                 runCheck,
+		ExpandEnv:             runExpandEnv,
+		DataFile:              runDataFile,
+		ContentType:           runContentType,
+		DeploymentStrategy:    runStrategy,
+		ConfigVersion:         runConfigVersion,
+		CreateOnly:            runCreateOnly,
+		AcceptLongBake:        runAcceptLongBake,
+		LongBakeThreshold:     runLongBake,
+		Stdin:                 cmd.InOrStdin(),
+	}
+
+	if opts.WaitDeploy || opts.WaitBake {
+		// Ctrl-C stops waiting; the deployment itself keeps running
+		var stop context.CancelFunc
+		ctx, stop = signal.NotifyContext(ctx, os.Interrupt)
+		defer stop()
 	}
 
-	reporter := cli.GetReporter(isSilent())
+	reporter, finish := newOutputReporter(cmd, runOutputFile)
+	if !jsonOutput {
+		executor := run.NewExecutor(reporter)
+		err = executor.Execute(ctx, opts)
+		if err == nil && opts.CreateOnly {
+			// The staged version number is the stdout payload so a script
+			// can hand it to the job that promotes it
+			for _, res := range executor.Results() {
+				if res.Status == run.StatusCreated {
+					reporter.Data(fmt.Appendf(nil, "%d\n", res.Version))
+				}
+			}
+		}
+		// --check's ErrChangesFound is mapped to its exit code by Execute
+		return finish(err)
+	}
 
-	executor := run.NewExecutor(reporter)
-	return executor.Execute(ctx, opts)
+	// Warnings are collected so the JSON report carries them alongside the
+	// per-target results; they are still printed to stderr as usual.
+	collector := cli.NewWarningCollector(reporter)
+	executor := run.NewExecutor(collector)
+	err = executor.Execute(ctx, opts)
+	if reportErr := executor.WriteReport(collector.Warnings()); reportErr != nil && err == nil {
+		err = reportErr
+	}
+	return finish(err)
 }
@@ -1,8 +1,11 @@
 package cmd
 
 import (
+	"slices"
 	"strings"
 	"testing"
+
+	"github.com/koh-sh/apcdeploy/internal/run"
 )
 
 func TestRunCommand(t *testing.T) {
@@ -40,7 +43,7 @@ func TestRunCommand(t *testing.T) {
 			configFile = "apcdeploy.yml"
 			runWaitDeploy = false
 			runWaitBake = false
-			runTimeout = DefaultDeploymentTimeout
+			runTimeout = 0
 			runForce = false
 			runDescription = ""
 
@@ -55,11 +58,43 @@ func TestRunCommand(t *testing.T) {
 	}
 }
 
+func TestRunEnvironmentAlias(t *testing.T) {
+	for _, flag := range []string{"--environment", "--env"} {
+		t.Run(flag, func(t *testing.T) {
+			runEnvs = nil
+			defer func() { runEnvs = nil }()
+
+			if err := newRunCmd().ParseFlags([]string{flag, "staging,production"}); err != nil {
+				t.Fatalf("ParseFlags() error = %v", err)
+			}
+			if !slices.Equal(runEnvs, []string{"staging", "production"}) {
+				t.Errorf("%s set environments %v, want [staging production]", flag, runEnvs)
+			}
+		})
+	}
+}
+
+func TestRunSkipDiffCheckAlias(t *testing.T) {
+	for _, flag := range []string{"--skip-diff-check", "--no-diff-check"} {
+		t.Run(flag, func(t *testing.T) {
+			runSkipDiffCheck = false
+			defer func() { runSkipDiffCheck = false }()
+
+			if err := newRunCmd().ParseFlags([]string{flag}); err != nil {
+				t.Fatalf("ParseFlags() error = %v", err)
+			}
+			if !runSkipDiffCheck {
+				t.Errorf("%s did not set the skip-diff-check option", flag)
+			}
+		})
+	}
+}
+
 func TestRunCommandFlags(t *testing.T) {
 	configFile = "apcdeploy.yml"
 	runWaitDeploy = false
 	runWaitBake = false
-	runTimeout = DefaultDeploymentTimeout
+	runTimeout = 0
 	runForce = false
 	runDescription = ""
 
@@ -71,9 +106,14 @@ func TestRunCommandFlags(t *testing.T) {
 		defaultValue string
 	}{
 		{
-			name:         "timeout flag has default",
+			name:         "timeout flag defaults to the config file",
 			flagName:     "timeout",
-			defaultValue: "1800",
+			defaultValue: "0",
+		},
+		{
+			name:         "long-bake threshold defaults to 30 minutes",
+			flagName:     "long-bake-threshold",
+			defaultValue: "30m0s",
 		},
 	}
 
@@ -96,7 +136,7 @@ func TestRunCommandWaitFlags(t *testing.T) {
 	configFile = "apcdeploy.yml"
 	runWaitDeploy = false
 	runWaitBake = false
-	runTimeout = DefaultDeploymentTimeout
+	runTimeout = 0
 	runForce = false
 	runDescription = ""
 
@@ -211,6 +251,36 @@ func TestResolveDescription(t *testing.T) {
 	}
 }
 
+// TestResolveSplitDescriptions verifies that --version-description and
+// --deploy-description each override --description for their own field only.
+func TestResolveSplitDescriptions(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		wantVersion string
+		wantDeploy  string
+	}{
+		{name: "defaults", args: []string{}, wantVersion: defaultDescription, wantDeploy: defaultDescription},
+		{name: "description sets both", args: []string{"--description", "hotfix"}, wantVersion: "hotfix", wantDeploy: "hotfix"},
+		{name: "version only", args: []string{"--description", "hotfix", "--version-description", "adds flag"}, wantVersion: "adds flag", wantDeploy: "hotfix"},
+		{name: "deploy only", args: []string{"--deploy-description", "ticket 42"}, wantVersion: defaultDescription, wantDeploy: "ticket 42"},
+		{name: "explicit empty deploy", args: []string{"--version-description", "v", "--deploy-description", ""}, wantVersion: "v", wantDeploy: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newRunCmd()
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatalf("ParseFlags: %v", err)
+			}
+			version, deploy := resolveSplitDescriptions(cmd, runDescription, runVersionDesc, runDeployDesc)
+			if version != tt.wantVersion || deploy != tt.wantDeploy {
+				t.Errorf("resolveSplitDescriptions = (%q, %q), want (%q, %q)", version, deploy, tt.wantVersion, tt.wantDeploy)
+			}
+		})
+	}
+}
+
 // TestValidateDescription covers the 1024-rune client-side guard. We exercise
 // the boundary explicitly (1024 OK, 1025 rejected) for both ASCII and a
 // multibyte rune so a regression to byte-counting (len(s) > 1024) would be
@@ -238,3 +308,66 @@ func TestValidateDescription(t *testing.T) {
 		})
 	}
 }
+
+func TestRunRunOutputValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{name: "unknown format", args: []string{"--output", "yaml"}, wantErr: `invalid --output "yaml"`},
+		{name: "output file needs json", args: []string{"--output-file", "out.json"}, wantErr: "--output-file requires JSON output"},
+		{name: "json with list-strategies", args: []string{"--output", "json", "--list-strategies"}, wantErr: "--output json cannot be used with --list-strategies"},
+		{name: "json with dry-run", args: []string{"--output", "json", "--dry-run"}, wantErr: "--output json cannot be used with --dry-run"},
+		{name: "json with check", args: []string{"--output", "json", "--check"}, wantErr: "--output json cannot be used with --check"},
+		{name: "unsupported content type", args: []string{"--content-type", "application/xml"}, wantErr: `invalid --content-type "application/xml"`},
+		{name: "zero long-bake threshold", args: []string{"--long-bake-threshold", "0s"}, wantErr: "--long-bake-threshold must be a positive duration"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newRunCmd()
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatalf("ParseFlags: %v", err)
+			}
+			defer func() {
+				runOutput, runOutputFile, runListStrategies, runDryRun, runCheck = "text", "", false, false, false
+				runContentType = ""
+				runLongBake = run.DefaultLongBakeThreshold
+			}()
+
+			err := runRun(cmd, nil)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestRunRunVersionTagValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{name: "not a pair", args: []string{"--version-tag", "build"}, wantErr: `invalid version tag "build"`},
+		{name: "duplicate key", args: []string{"--version-tag", "build=1", "--version-tag", "build=2"}, wantErr: "duplicate version tag key"},
+		{name: "too long with tags", args: []string{"--version-description", strings.Repeat("a", maxDescriptionLength-10), "--version-tag", "build=123"}, wantErr: "version description with --version-tag exceeds maximum length"},
+		{name: "label with a bracket", args: []string{"--label", "v1]"}, wantErr: `invalid label "v1]"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newRunCmd()
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatalf("ParseFlags: %v", err)
+			}
+			defer func() { runVersionTags, runVersionDesc, runLabel = nil, "", "" }()
+
+			err := runRun(cmd, nil)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -2,13 +2,40 @@ package cmd
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"time"
 
+	"github.com/koh-sh/apcdeploy/internal/bulk"
 	"github.com/koh-sh/apcdeploy/internal/cli"
+	"github.com/koh-sh/apcdeploy/internal/config"
 	"github.com/koh-sh/apcdeploy/internal/status"
 	"github.com/spf13/cobra"
 )
 
-var statusDeploymentID string
+var (
+	statusDeploymentID string
+	statusProfilesFile string
+	statusOutput       string
+	statusOutputFile   string
+	statusFindVersion  string
+	statusTUI          bool
+	statusRefresh      time.Duration
+	statusMaxAge       string
+	statusStrict       bool
+	statusApp          string
+	statusProfileRegex string
+	statusConcurrency  int
+	statusCheckDrift   bool
+	statusContentOnly  bool
+	statusWatch        bool
+	statusTimeout      int
+	statusHistory      int
+	statusSince        string
+)
 
 // StatusCommand returns the status command
 func StatusCommand() *cobra.Command {
@@ -22,12 +49,64 @@ func newStatusCmd() *cobra.Command {
 		Long: `Show the status of deployments in AWS AppConfig.
 
 This command displays information about the latest deployment or a specific deployment
-identified by deployment number.`,
+identified by deployment number. With --output json, a single JSON document
+describing the deployment is written to stdout instead of the report.
+
+With --profiles-from-file, the latest deployment of every target listed in the
+file is checked concurrently and an aggregated report is written to stdout.
+
+With --tui, a full-screen dashboard of the same targets (or the config file's
+single target) is refreshed every --refresh-interval; failed lookups and
+rolled-back deployments are highlighted. Press r to refresh, q to quit.
+
+When the deployment used a different strategy than deployment_strategy (e.g.
+after a manual deployment), a "Drift detected" block is printed.
+
+With --check-drift, the local data file is compared with the content of the
+deployed version (normalized as in diff), and the deployment's strategy with
+deployment_strategy; the command exits 1 on drift. A new version or
+deployment description alone is never drift. --content-only compares the
+content only.
+
+With --watch, a deployment that is still rolling out is polled until it
+completes, starts baking or rolls back (up to --timeout); Ctrl-C stops
+watching without affecting the deployment.
+
+With --history, the most recent deployments of the profile (10 by default,
+or --history=N) are listed newest first; --output json prints them as an
+array.`,
 		RunE:         runStatus,
 		SilenceUsage: true, // Don't show usage on runtime errors
 	}
 
 	cmd.Flags().StringVar(&statusDeploymentID, "deployment", "", "Deployment number to check (defaults to latest)")
+	cmd.Flags().StringVar(&statusProfilesFile, "profiles-from-file", "", "YAML file listing targets (application/profile/environment/region) to check in bulk")
+	cmd.Flags().StringVar(&statusApp, "app", "", appFlagUsage+" (with --profiles-from-file)")
+	cmd.Flags().StringVar(&statusProfileRegex, "profile-regex", "", profileRegexFlagUsage+" (with --profiles-from-file)")
+	cmd.Flags().IntVar(&statusConcurrency, "concurrency", bulk.DefaultConcurrency, bulkConcurrencyFlagUsage)
+	cmd.Flags().StringVar(&statusOutput, "output", config.OutputFormatText, "Output format: text or json (a JSON report of the deployment, or of every target with --profiles-from-file)")
+	cmd.Flags().StringVar(&statusOutputFile, "output-file", "", outputFileFlagUsage)
+	cmd.Flags().StringVar(&statusFindVersion, "find-version-by-description", "", "Find the newest configuration version whose description contains this text and report whether it is deployed")
+	cmd.Flags().StringVar(&statusMaxAge, "max-version-age", "", "Warn when the deployed version completed longer ago than this (e.g. 90d, 2w, 36h; overrides max_version_age in the config)")
+	cmd.Flags().BoolVar(&statusStrict, "strict", false, "Fail instead of warning when the deployed version is older than the max version age")
+	cmd.Flags().BoolVar(&statusCheckDrift, "check-drift", false, "Exit 1 when the local data file differs from the deployed content (normalized) or the deployment used another strategy than deployment_strategy; descriptions are ignored")
+	cmd.Flags().BoolVar(&statusContentOnly, "content-only", false, "With --check-drift, compare only the configuration content, not the deployment strategy")
+	cmd.Flags().BoolVar(&statusTUI, "tui", false, "Show a live-updating dashboard (requires a terminal)")
+	cmd.Flags().DurationVar(&statusRefresh, "refresh-interval", status.DefaultRefreshInterval, "How often --tui re-fetches deployment states")
+	cmd.Flags().BoolVar(&statusWatch, "watch", false, "Keep polling an in-progress deployment until it completes, starts baking or rolls back")
+	cmd.Flags().IntVar(&statusTimeout, "timeout", DefaultDeploymentTimeout, "Timeout in seconds for --watch")
+	cmd.Flags().IntVar(&statusHistory, "history", 0, "List the last N deployments of the profile in a table instead of the latest one (--history alone lists 10)")
+	cmd.Flags().Lookup("history").NoOptDefVal = strconv.Itoa(status.DefaultHistoryLimit)
+	cmd.Flags().StringVar(&statusSince, "since", "", "With --history, list only deployments started after this time: a duration back from now (24h, 7d) or an RFC 3339 timestamp")
+	cmd.MarkFlagsMutuallyExclusive("deployment", "profiles-from-file", "find-version-by-description")
+	cmd.MarkFlagsMutuallyExclusive("tui", "deployment")
+	cmd.MarkFlagsMutuallyExclusive("tui", "find-version-by-description")
+	cmd.MarkFlagsMutuallyExclusive("tui", "output-file")
+	cmd.MarkFlagsMutuallyExclusive("check-drift", "profiles-from-file")
+	cmd.MarkFlagsMutuallyExclusive("check-drift", "find-version-by-description")
+	cmd.MarkFlagsMutuallyExclusive("check-drift", "tui")
+	cmd.MarkFlagsMutuallyExclusive("watch", "profiles-from-file", "find-version-by-description", "tui")
+	cmd.MarkFlagsMutuallyExclusive("history", "deployment", "profiles-from-file", "find-version-by-description", "tui", "check-drift", "watch")
 
 	return cmd
 }
@@ -35,17 +114,117 @@ identified by deployment number.`,
 func runStatus(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
 
+	if err := validateOutputFormat(statusOutput); err != nil {
+		return err
+	}
+	if err := validateOutputFile(statusOutputFile, statusOutput == config.OutputFormatJSON); err != nil {
+		return err
+	}
+	if err := validateStatusTUI(statusTUI, statusOutput, statusRefresh, cli.IsTerminalWriter(cmd.OutOrStdout())); err != nil {
+		return err
+	}
+	if statusOutput == config.OutputFormatJSON && statusFindVersion != "" {
+		return errors.New("--output json cannot be used with --find-version-by-description")
+	}
+	if statusContentOnly && !statusCheckDrift {
+		return errors.New("--content-only requires --check-drift")
+	}
+	if cmd.Flags().Changed("timeout") && !statusWatch {
+		return errors.New("--timeout requires --watch")
+	}
+	if statusTimeout <= 0 {
+		return errors.New("--timeout must be a positive number of seconds")
+	}
+	if cmd.Flags().Changed("history") && statusHistory <= 0 {
+		return errors.New("--history must be a positive number of deployments")
+	}
+	var since time.Time
+	if statusSince != "" {
+		if statusHistory == 0 {
+			return errors.New("--since requires --history")
+		}
+		var err error
+		if since, err = config.ParseSince(statusSince, time.Now()); err != nil {
+			return err
+		}
+	}
+	filter, err := bulkProfileFilter(statusApp, statusProfileRegex, statusProfilesFile)
+	if err != nil {
+		return err
+	}
+	if err := validateBulkConcurrency(statusConcurrency, cmd.Flags().Changed("concurrency"), statusProfilesFile); err != nil {
+		return err
+	}
+	var maxAge time.Duration
+	if statusMaxAge != "" {
+		var err error
+		if maxAge, err = config.ParseAge(statusMaxAge); err != nil {
+			return fmt.Errorf("invalid --max-version-age: %w", err)
+		}
+	}
+
 	// Create options
 	opts := &status.Options{
-		ConfigFile:   configFile,
-		DeploymentID: statusDeploymentID,
-		Silent:       isSilent(),
+		ConfigFile:               configFile,
+		DeploymentID:             statusDeploymentID,
+		TargetsFile:              statusProfilesFile,
+		Filter:                   filter,
+		Concurrency:              statusConcurrency,
+		Output:                   statusOutput,
+		Silent:                   isSilent(),
+		FindVersionByDescription: statusFindVersion,
+		Region:                   region,
+		MaxVersionAge:            maxAge,
+		Strict:                   statusStrict,
+		CheckDrift:               statusCheckDrift,
+		ContentOnly:              statusContentOnly,
+		TUI:                      statusTUI,
+		RefreshInterval:          statusRefresh,
+		Watch:                    statusWatch,
+		Timeout:                  time.Duration(statusTimeout) * time.Second,
+		History:                  statusHistory,
+		Since:                    since,
+	}
+
+	if opts.Watch {
+		// Ctrl-C stops polling; the deployment itself keeps running
+		var stop context.CancelFunc
+		ctx, stop = signal.NotifyContext(ctx, os.Interrupt)
+		defer stop()
 	}
 
 	// Create reporter
-	reporter := cli.GetReporter(isSilent())
+	reporter, finish := newOutputReporter(cmd, statusOutputFile)
 
 	// Run status check
 	executor := status.NewExecutor(reporter)
-	return executor.Execute(ctx, opts)
+	switch {
+	case opts.TUI:
+		return executor.ExecuteTUI(ctx, opts)
+	case opts.TargetsFile != "":
+		return finish(executor.ExecuteBulk(ctx, opts))
+	case opts.FindVersionByDescription != "":
+		return finish(executor.ExecuteFindVersion(ctx, opts))
+	case opts.History > 0:
+		return finish(executor.ExecuteHistory(ctx, opts))
+	}
+	return finish(executor.Execute(ctx, opts))
+}
+
+// validateStatusTUI rejects --tui when its output could not be shown: off a
+// terminal, with JSON output, or with a non-positive refresh interval.
+func validateStatusTUI(tui bool, output string, interval time.Duration, terminal bool) error {
+	if !tui {
+		return nil
+	}
+	if output == config.OutputFormatJSON {
+		return errors.New("--tui cannot be combined with --output json")
+	}
+	if interval <= 0 {
+		return errors.New("--refresh-interval must be positive")
+	}
+	if !terminal {
+		return errors.New("--tui requires stdout to be a terminal")
+	}
+	return nil
 }
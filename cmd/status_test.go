@@ -3,7 +3,11 @@ package cmd
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/koh-sh/apcdeploy/internal/status"
 )
 
 func TestStatusCommand(t *testing.T) {
@@ -154,6 +158,26 @@ func TestStatusCommandFlags(t *testing.T) {
 			flagName:     "deployment",
 			defaultValue: "",
 		},
+		{
+			name:         "check-drift flag defaults to off",
+			flagName:     "check-drift",
+			defaultValue: "false",
+		},
+		{
+			name:         "content-only flag defaults to off",
+			flagName:     "content-only",
+			defaultValue: "false",
+		},
+		{
+			name:         "watch flag defaults to off",
+			flagName:     "watch",
+			defaultValue: "false",
+		},
+		{
+			name:         "timeout flag defaults to the deployment timeout",
+			flagName:     "timeout",
+			defaultValue: "1800",
+		},
 	}
 
 	for _, tt := range tests {
@@ -171,6 +195,76 @@ func TestStatusCommandFlags(t *testing.T) {
 	}
 }
 
+func TestRunStatusContentOnlyRequiresCheckDrift(t *testing.T) {
+	cmd := newStatusCmd()
+	statusContentOnly = true
+	t.Cleanup(func() { statusContentOnly = false })
+
+	err := runStatus(cmd, nil)
+	if err == nil || !strings.Contains(err.Error(), "--content-only requires --check-drift") {
+		t.Errorf("expected --content-only error, got: %v", err)
+	}
+}
+
+func TestRunStatusFlagValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{name: "timeout without watch", args: []string{"--timeout", "60"}, wantErr: "--timeout requires --watch"},
+		{name: "non-positive timeout", args: []string{"--watch", "--timeout", "0"}, wantErr: "--timeout must be a positive number of seconds"},
+		{name: "json with find-version", args: []string{"--output", "json", "--find-version-by-description", "release"}, wantErr: "--output json cannot be used with --find-version-by-description"},
+		{name: "non-positive history", args: []string{"--history=0"}, wantErr: "--history must be a positive number of deployments"},
+		{name: "since without history", args: []string{"--since", "24h"}, wantErr: "--since requires --history"},
+		{name: "invalid since", args: []string{"--history", "--since", "yesterday"}, wantErr: `invalid --since "yesterday"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newStatusCmd()
+			defer func() {
+				statusWatch = false
+				statusTimeout = DefaultDeploymentTimeout
+				statusOutput = "text"
+				statusFindVersion = ""
+				statusHistory = 0
+				statusSince = ""
+			}()
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatalf("ParseFlags() error = %v", err)
+			}
+
+			err := runStatus(cmd, nil)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("runStatus() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStatusHistoryFlag(t *testing.T) {
+	tests := []struct {
+		args []string
+		want int
+	}{
+		{args: []string{"--history"}, want: status.DefaultHistoryLimit},
+		{args: []string{"--history=3"}, want: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			defer func() { statusHistory = 0 }()
+			if err := newStatusCmd().ParseFlags(tt.args); err != nil {
+				t.Fatalf("ParseFlags() error = %v", err)
+			}
+			if statusHistory != tt.want {
+				t.Errorf("--history = %d, want %d", statusHistory, tt.want)
+			}
+		})
+	}
+}
+
 func TestStatusCommandSilenceUsage(t *testing.T) {
 	cmd := newStatusCmd()
 
@@ -179,3 +273,32 @@ func TestStatusCommandSilenceUsage(t *testing.T) {
 		t.Error("status command should have SilenceUsage set to true")
 	}
 }
+
+func TestValidateStatusTUI(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		tui      bool
+		output   string
+		interval time.Duration
+		terminal bool
+		wantErr  bool
+	}{
+		{name: "tui off ignores everything", output: "json", interval: 0},
+		{name: "terminal", tui: true, output: "text", interval: time.Second, terminal: true},
+		{name: "not a terminal", tui: true, output: "text", interval: time.Second, wantErr: true},
+		{name: "json output", tui: true, output: "json", interval: time.Second, terminal: true, wantErr: true},
+		{name: "zero interval", tui: true, output: "text", terminal: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := validateStatusTUI(tt.tui, tt.output, tt.interval, tt.terminal)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateStatusTUI() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
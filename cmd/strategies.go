@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/koh-sh/apcdeploy/internal/config"
+	"github.com/koh-sh/apcdeploy/internal/lsresources"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// strategiesOutput is the output format (text or json)
+	strategiesOutput string
+	// strategiesOutputFile redirects the JSON output to a file
+	strategiesOutputFile string
+)
+
+// StrategiesCommand returns the strategies command
+func StrategiesCommand() *cobra.Command {
+	return newStrategiesCmd()
+}
+
+func newStrategiesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "strategies",
+		Short: "List deployment strategies with their rollout settings",
+		Long: `List all AWS AppConfig deployment strategies in the region, both predefined
+(AppConfig.*) and custom, with their growth type, growth factor, deployment
+duration, and final bake time.
+
+Use this to choose a value for the deployment_strategy field in apcdeploy.yml.
+This command does not require an apcdeploy.yml file and is read-only.`,
+		RunE:         runStrategies,
+		SilenceUsage: true, // Don't show usage on runtime errors
+	}
+
+	cmd.Flags().StringVar(&strategiesOutput, "output", config.OutputFormatText, "Output format: text or json")
+	cmd.Flags().StringVar(&strategiesOutputFile, "output-file", "", outputFileFlagUsage)
+
+	return cmd
+}
+
+func runStrategies(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	if err := validateOutputFormat(strategiesOutput); err != nil {
+		return err
+	}
+	if err := validateOutputFile(strategiesOutputFile, strategiesOutput == config.OutputFormatJSON); err != nil {
+		return err
+	}
+
+	opts := &lsresources.Options{
+		Region:         region,
+		JSON:           strategiesOutput == config.OutputFormatJSON,
+		ShowStrategies: true,
+	}
+
+	reporter, finish := newOutputReporter(cmd, strategiesOutputFile)
+
+	executor := lsresources.NewExecutor(reporter)
+	return finish(executor.ExecuteStrategies(ctx, opts))
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStrategiesCommandStructure(t *testing.T) {
+	cmd := newStrategiesCmd()
+
+	if cmd.Use != "strategies" {
+		t.Errorf("Use = %v, want strategies", cmd.Use)
+	}
+	if cmd.RunE == nil {
+		t.Error("RunE should be set")
+	}
+	for _, name := range []string{"output"} {
+		if cmd.Flags().Lookup(name) == nil {
+			t.Errorf("expected --%s flag", name)
+		}
+	}
+}
+
+func TestStrategiesCommandInvalidOutput(t *testing.T) {
+	cmd := newStrategiesCmd()
+	strategiesOutput = "yaml"
+	t.Cleanup(func() { strategiesOutput = "text" })
+
+	err := runStrategies(cmd, nil)
+	if err == nil || !strings.Contains(err.Error(), "invalid --output") {
+		t.Errorf("expected invalid --output error, got: %v", err)
+	}
+}
@@ -0,0 +1,149 @@
+// Package apply deploys every config listed in a manifest file with the run
+// executor, a bounded number at a time, and summarizes the outcome.
+package apply
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/koh-sh/apcdeploy/internal/config"
+	"github.com/koh-sh/apcdeploy/internal/reporter"
+	"github.com/koh-sh/apcdeploy/internal/run"
+)
+
+// Executor handles the manifest deployment orchestration
+type Executor struct {
+	reporter reporter.Reporter
+	// newRun builds the run executor for one entry
+	newRun func(reporter.Reporter) *run.Executor
+	// mu serializes the entries' messages (see entryReporter)
+	mu sync.Mutex
+}
+
+// NewExecutor creates a new apply executor
+func NewExecutor(rep reporter.Reporter) *Executor {
+	return &Executor{
+		reporter: rep,
+		newRun:   run.NewExecutor,
+	}
+}
+
+// NewExecutorWithFactory creates a new apply executor whose runs use a
+// custom deployer factory. This is useful for testing with mock deployers
+func NewExecutorWithFactory(rep reporter.Reporter, factory func(context.Context, *config.Config) (*run.Deployer, error)) *Executor {
+	return &Executor{
+		reporter: rep,
+		newRun: func(r reporter.Reporter) *run.Executor {
+			return run.NewExecutorWithFactory(r, factory)
+		},
+	}
+}
+
+// entryResult is the outcome of one manifest entry.
+type entryResult struct {
+	name   string
+	target run.TargetResult
+	err    error
+}
+
+// Execute deploys every config in the manifest. Each entry gets one row in
+// a shared Targets block and runs through the run executor as `apcdeploy
+// run -c <config>` would, with messages prefixed by the entry name. At most
+// opts.Concurrency entries run at once; a failing entry does not stop the
+// others. A summary table follows the rows, and the returned error counts
+// the failed entries.
+//
+// The local deploy record is not used: several entries can share a
+// directory, and concurrent runs would overwrite each other's record.
+func (e *Executor) Execute(ctx context.Context, opts *Options) error {
+	if opts.Concurrency < 1 {
+		return errors.New("--concurrency must be at least 1")
+	}
+	entries, err := LoadManifest(opts.ManifestFile)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, len(entries))
+	for i, entry := range entries {
+		names[i] = entry.Name
+	}
+	rows := e.reporter.Targets(names)
+	defer rows.Close()
+
+	results := make([]entryResult, len(entries))
+	sem := make(chan struct{}, opts.Concurrency)
+	var wg sync.WaitGroup
+	for i, entry := range entries {
+		wg.Go(func() {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results[i] = e.deployEntry(ctx, opts, entry, rows)
+		})
+	}
+	wg.Wait()
+	rows.Close()
+
+	failed := 0
+	tableRows := make([][]string, len(results))
+	for i, res := range results {
+		status := res.target.Status
+		errMsg := ""
+		if res.err != nil {
+			failed++
+			status = run.StatusFailed
+			errMsg = res.err.Error()
+		}
+		tableRows[i] = []string{res.name, status, versionCell(res.target.Version), versionCell(res.target.DeploymentNumber), errMsg}
+	}
+	e.reporter.Table([]string{"Config", "Status", "Version", "Deployment", "Error"}, tableRows)
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d configs failed", failed, len(results))
+	}
+	return nil
+}
+
+// deployEntry runs one entry and marks its row failed when the run returns
+// before reaching a terminal state (e.g. an unreadable config file); rows
+// already finalized by the run ignore the extra Fail.
+func (e *Executor) deployEntry(ctx context.Context, opts *Options, entry Entry, rows reporter.Targets) entryResult {
+	configFile := entry.Path
+	if entry.Config != nil {
+		configFile = opts.ManifestFile
+	}
+	rep := newEntryReporter(e.reporter, rows, entry.Name, &e.mu)
+	executor := e.newRun(rep)
+	err := executor.Execute(ctx, &run.Options{
+		ConfigFile:            configFile,
+		Config:                entry.Config,
+		Region:                opts.Region,
+		WaitDeploy:            opts.WaitDeploy,
+		WaitBake:              opts.WaitBake,
+		Timeout:               opts.Timeout,
+		Force:                 opts.Force,
+		AcceptLongBake:        opts.AcceptLongBake,
+		VersionDescription:    opts.Description,
+		DeploymentDescription: opts.Description,
+		NoState:               true,
+	})
+	if err != nil {
+		rep.Targets(nil).Fail(entry.Name, err)
+	}
+	res := entryResult{name: entry.Name, err: err}
+	if targets := executor.Results(); len(targets) > 0 {
+		res.target = targets[0]
+	}
+	return res
+}
+
+// versionCell renders a version or deployment number, blank when unset.
+func versionCell(n int32) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.Itoa(int(n))
+}
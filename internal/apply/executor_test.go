@@ -0,0 +1,178 @@
+package apply
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig/types"
+	awsInternal "github.com/koh-sh/apcdeploy/internal/aws"
+	"github.com/koh-sh/apcdeploy/internal/aws/mock"
+	"github.com/koh-sh/apcdeploy/internal/config"
+	reportertest "github.com/koh-sh/apcdeploy/internal/reporter/testing"
+	"github.com/koh-sh/apcdeploy/internal/run"
+)
+
+// newApplyMock returns a client for test-app/test-profile with the
+// environments prod and staging and nothing deployed yet. running tracks
+// CreateHostedConfigurationVersion calls in flight and peak the most seen
+// at once.
+func newApplyMock(running, peak *atomic.Int32) *mock.MockAppConfigClient {
+	m := mock.NewResolvingClient("AWS.Freeform")
+	m.ListEnvironmentsFunc = func(ctx context.Context, params *appconfig.ListEnvironmentsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListEnvironmentsOutput, error) {
+		return &appconfig.ListEnvironmentsOutput{Items: []types.Environment{
+			{Id: aws.String("env-prod"), Name: aws.String("prod")},
+			{Id: aws.String("env-staging"), Name: aws.String("staging")},
+		}}, nil
+	}
+	m.ListDeploymentsFunc = func(ctx context.Context, params *appconfig.ListDeploymentsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListDeploymentsOutput, error) {
+		return &appconfig.ListDeploymentsOutput{}, nil
+	}
+	m.CreateHostedConfigurationVersionFunc = func(ctx context.Context, params *appconfig.CreateHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.CreateHostedConfigurationVersionOutput, error) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		return &appconfig.CreateHostedConfigurationVersionOutput{VersionNumber: 7}, nil
+	}
+	m.StartDeploymentFunc = func(ctx context.Context, params *appconfig.StartDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.StartDeploymentOutput, error) {
+		return &appconfig.StartDeploymentOutput{DeploymentNumber: 3}, nil
+	}
+	return m
+}
+
+func TestExecute(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		concurrency int
+		wantPeak    int32
+	}{
+		{name: "sequential", concurrency: 1, wantPeak: 1},
+		{name: "concurrent", concurrency: 4, wantPeak: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			for _, env := range []string{"prod", "staging"} {
+				cfg := "application: test-app\nconfiguration_profile: test-profile\nenvironment: " + env + "\ndata_file: data.json\nregion: us-east-1\n"
+				if err := os.MkdirAll(filepath.Join(dir, env), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(filepath.Join(dir, env, "apcdeploy.yml"), []byte(cfg), 0o644); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(filepath.Join(dir, env, "data.json"), []byte(`{"env": "`+env+`"}`), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := os.WriteFile(filepath.Join(dir, "inline.json"), []byte(`{"inline": true}`), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			manifest := filepath.Join(dir, "manifest.yml")
+			content := `configs:
+  - path: prod/apcdeploy.yml
+  - name: staging
+    path: staging/apcdeploy.yml
+  - name: inline
+    config:
+      application: test-app
+      configuration_profile: test-profile
+      environment: prod
+      data_file: inline.json
+      region: us-east-1
+`
+			if err := os.WriteFile(manifest, []byte(content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			var running, peak atomic.Int32
+			mockClient := newApplyMock(&running, &peak)
+			factory := func(_ context.Context, cfg *config.Config) (*run.Deployer, error) {
+				return run.NewWithClient(cfg, awsInternal.NewTestClient(mockClient)), nil
+			}
+			rep := &reportertest.MockReporter{}
+			err := NewExecutorWithFactory(rep, factory).Execute(context.Background(), &Options{ManifestFile: manifest, Concurrency: tt.concurrency})
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+
+			if got := peak.Load(); got != tt.wantPeak {
+				t.Errorf("peak concurrent versions = %d, want %d", got, tt.wantPeak)
+			}
+			wantIDs := []string{"prod/apcdeploy.yml", "staging", "inline"}
+			if len(rep.TargetsCalls) != 1 || strings.Join(rep.TargetsCalls[0].IDs, ",") != strings.Join(wantIDs, ",") {
+				t.Fatalf("Targets calls = %+v, want one block with rows %v", rep.TargetsCalls, wantIDs)
+			}
+			if !rep.HasMessage("warn: staging: us-east-1/test-app/test-profile/staging: no previous deployment") {
+				t.Errorf("expected warnings prefixed with the entry name, got %v", rep.Messages)
+			}
+			if len(rep.Tables) != 1 {
+				t.Fatalf("expected one summary table, got %d", len(rep.Tables))
+			}
+			for _, row := range rep.Tables[0].Rows {
+				if row[1] != run.StatusStarted || row[2] != "7" || row[3] != "3" {
+					t.Errorf("summary row = %v, want started v7 deployment 3", row)
+				}
+			}
+		})
+	}
+}
+
+func TestExecuteFailedEntry(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "apcdeploy.yml"), []byte("application: test-app\nconfiguration_profile: test-profile\nenvironment: prod\ndata_file: data.json\nregion: us-east-1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "data.json"), []byte(`{"a": 1}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	manifest := filepath.Join(dir, "manifest.yml")
+	if err := os.WriteFile(manifest, []byte("configs:\n  - name: ok\n    path: apcdeploy.yml\n  - name: missing\n    path: missing/apcdeploy.yml\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var running, peak atomic.Int32
+	mockClient := newApplyMock(&running, &peak)
+	factory := func(_ context.Context, cfg *config.Config) (*run.Deployer, error) {
+		return run.NewWithClient(cfg, awsInternal.NewTestClient(mockClient)), nil
+	}
+	rep := &reportertest.MockReporter{}
+	err := NewExecutorWithFactory(rep, factory).Execute(context.Background(), &Options{ManifestFile: manifest, Concurrency: DefaultConcurrency})
+	if err == nil || err.Error() != "1 of 2 configs failed" {
+		t.Fatalf("Execute() error = %v, want \"1 of 2 configs failed\"", err)
+	}
+
+	final := map[string]string{}
+	for _, tr := range rep.TargetsCalls[0].Transitions {
+		if tr.Kind == "done" || tr.Kind == "fail" {
+			if _, seen := final[tr.ID]; !seen {
+				final[tr.ID] = tr.Kind
+			}
+		}
+	}
+	if final["ok"] != "done" || final["missing"] != "fail" {
+		t.Errorf("final row states = %v, want ok done and missing fail", final)
+	}
+	rows := rep.Tables[0].Rows
+	if rows[0][1] != run.StatusStarted || rows[1][1] != run.StatusFailed || !strings.Contains(rows[1][4], "failed to load configuration") {
+		t.Errorf("summary rows = %v, want ok started and missing failed with the load error", rows)
+	}
+}
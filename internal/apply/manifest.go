@@ -0,0 +1,86 @@
+package apply
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/goccy/go-yaml"
+	"github.com/koh-sh/apcdeploy/internal/config"
+)
+
+// Manifest is the file passed to apply -f. Each entry names an apcdeploy.yml
+// (path) or embeds the same keys inline (config):
+//
+//	configs:
+//	  - path: services/api/apcdeploy.yml
+//	  - name: banner
+//	    config:
+//	      application: web
+//	      configuration_profile: banner
+//	      environment: production
+//	      data_file: banner.json
+type Manifest struct {
+	Configs []Entry `yaml:"configs"`
+}
+
+// Entry is one config of a manifest.
+type Entry struct {
+	// Name labels the entry's row and summary line. It defaults to Path as
+	// written, or application/profile/environment for inline configs.
+	Name string `yaml:"name,omitempty"`
+	// Path is an apcdeploy.yml, relative to the manifest's directory
+	Path string `yaml:"path,omitempty"`
+	// Config is an inline config; data_file and schema_file are relative to
+	// the manifest's directory
+	Config *config.Config `yaml:"config,omitempty"`
+}
+
+// LoadManifest reads a manifest, resolves each path against the manifest's
+// directory and prepares inline configs like LoadConfig would. Entries must
+// set exactly one of path and config, and names must be unique.
+func LoadManifest(path string) ([]Entry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+	var m Manifest
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	if len(m.Configs) == 0 {
+		return nil, fmt.Errorf("manifest %s lists no configs", path)
+	}
+
+	dir := filepath.Dir(path)
+	seen := make(map[string]int, len(m.Configs))
+	entries := make([]Entry, 0, len(m.Configs))
+	for i, entry := range m.Configs {
+		switch {
+		case entry.Path != "" && entry.Config != nil:
+			return nil, fmt.Errorf("manifest entry #%d sets both path and config", i+1)
+		case entry.Path != "":
+			if entry.Name == "" {
+				entry.Name = entry.Path
+			}
+			if !filepath.IsAbs(entry.Path) {
+				entry.Path = filepath.Join(dir, entry.Path)
+			}
+		case entry.Config != nil:
+			if err := config.PrepareConfig(entry.Config, path); err != nil {
+				return nil, fmt.Errorf("manifest entry #%d: %w", i+1, err)
+			}
+			if entry.Name == "" {
+				entry.Name = fmt.Sprintf("%s/%s/%s", entry.Config.Application, entry.Config.ConfigurationProfile, entry.Config.Environment)
+			}
+		default:
+			return nil, fmt.Errorf("manifest entry #%d needs path or config", i+1)
+		}
+		if prev, ok := seen[entry.Name]; ok {
+			return nil, fmt.Errorf("manifest entry #%d has the same name as entry #%d: %s", i+1, prev, entry.Name)
+		}
+		seen[entry.Name] = i + 1
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
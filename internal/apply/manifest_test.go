@@ -0,0 +1,83 @@
+package apply
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeManifest(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "manifest.yml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write manifest: %v", err)
+	}
+	return path
+}
+
+func TestLoadManifest(t *testing.T) {
+	t.Parallel()
+
+	path := writeManifest(t, `configs:
+  - path: api/apcdeploy.yml
+  - name: flags
+    path: /abs/apcdeploy.yml
+  - config:
+      application: web
+      configuration_profile: banner
+      environment: prod
+      data_file: banner.json
+`)
+	dir := filepath.Dir(path)
+
+	entries, err := LoadManifest(path)
+	if err != nil {
+		t.Fatalf("LoadManifest() error = %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("got %d entries, want 3", len(entries))
+	}
+	if entries[0].Name != "api/apcdeploy.yml" || entries[0].Path != filepath.Join(dir, "api", "apcdeploy.yml") {
+		t.Errorf("entries[0] = %+v, want the path resolved against the manifest", entries[0])
+	}
+	if entries[1].Name != "flags" || entries[1].Path != "/abs/apcdeploy.yml" {
+		t.Errorf("entries[1] = %+v, want the absolute path kept", entries[1])
+	}
+	inline := entries[2]
+	if inline.Name != "web/banner/prod" {
+		t.Errorf("inline name = %q, want web/banner/prod", inline.Name)
+	}
+	if inline.Config.DataFile != filepath.Join(dir, "banner.json") {
+		t.Errorf("inline data_file = %q, want it resolved against the manifest", inline.Config.DataFile)
+	}
+	if inline.Config.DeploymentStrategy == "" {
+		t.Error("inline config did not get the default deployment strategy")
+	}
+}
+
+func TestLoadManifestErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{name: "no configs", content: "configs: []\n", wantErr: "lists no configs"},
+		{name: "neither path nor config", content: "configs:\n  - name: x\n", wantErr: "entry #1 needs path or config"},
+		{name: "both path and config", content: "configs:\n  - path: a.yml\n    config:\n      application: a\n", wantErr: "entry #1 sets both path and config"},
+		{name: "invalid inline config", content: "configs:\n  - config:\n      application: a\n", wantErr: "entry #1: invalid configuration: configuration_profile is required"},
+		{name: "duplicate names", content: "configs:\n  - path: a.yml\n  - path: a.yml\n", wantErr: "entry #2 has the same name as entry #1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := LoadManifest(writeManifest(t, tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadManifest() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package apply
+
+// DefaultConcurrency is the number of configs apply deploys at once when
+// --concurrency is not given.
+const DefaultConcurrency = 4
+
+// Options contains the options for the apply operation
+type Options struct {
+	// ManifestFile lists the configs to deploy (-f)
+	ManifestFile string
+	// Region overrides the region of every config (--region)
+	Region string
+	// Concurrency bounds how many configs are deployed at once
+	// (--concurrency)
+	Concurrency int
+	// WaitDeploy, WaitBake, Timeout, Force and AcceptLongBake are passed
+	// to every run as the run flags of the same name
+	WaitDeploy     bool
+	WaitBake       bool
+	Timeout        int
+	Force          bool
+	AcceptLongBake bool
+	// Description is attached to every configuration version and
+	// deployment (--description)
+	Description string
+}
@@ -0,0 +1,105 @@
+package apply
+
+import (
+	"sync"
+	"time"
+
+	"github.com/koh-sh/apcdeploy/internal/reporter"
+)
+
+// entryReporter is the reporter handed to one entry's run executor. Messages
+// are prefixed with the entry name so concurrent runs stay readable, and the
+// run's Targets block is folded into the entry's row of apply's shared block.
+// mu is shared by every entry so concurrent runs never write at once.
+type entryReporter struct {
+	reporter.Reporter
+	name string
+	rows reporter.Targets
+	mu   *sync.Mutex
+}
+
+func newEntryReporter(rep reporter.Reporter, rows reporter.Targets, name string, mu *sync.Mutex) *entryReporter {
+	return &entryReporter{Reporter: rep, name: name, rows: rows, mu: mu}
+}
+
+// emit calls fn with msg prefixed by the entry name, holding the shared lock.
+func (r *entryReporter) emit(fn func(string), msg string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	fn(r.name + ": " + msg)
+}
+
+func (r *entryReporter) Step(msg string)     { r.emit(r.Reporter.Step, msg) }
+func (r *entryReporter) Success(msg string)  { r.emit(r.Reporter.Success, msg) }
+func (r *entryReporter) Info(msg string)     { r.emit(r.Reporter.Info, msg) }
+func (r *entryReporter) Warn(msg string)     { r.emit(r.Reporter.Warn, msg) }
+func (r *entryReporter) Error(msg string)    { r.emit(r.Reporter.Error, msg) }
+func (r *entryReporter) Header(title string) { r.emit(r.Reporter.Header, title) }
+
+func (r *entryReporter) Box(title string, lines []string) {
+	r.emit(func(t string) { r.Reporter.Box(t, lines) }, title)
+}
+
+func (r *entryReporter) Table(headers []string, rows [][]string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Reporter.Table(headers, rows)
+}
+
+func (r *entryReporter) Data(p []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Reporter.Data(p)
+}
+
+func (r *entryReporter) Diff(p []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Reporter.Diff(p)
+}
+
+// Targets returns a view that drives the entry's row whatever ids the run
+// opens; apply deploys one target per entry.
+func (r *entryReporter) Targets([]string) reporter.Targets {
+	return &entryRow{rows: r.rows, name: r.name, mu: r.mu}
+}
+
+// entryRow maps a run's row updates onto the entry's row, holding the same
+// lock as entryReporter. Close is left to apply, which owns the shared block.
+type entryRow struct {
+	rows reporter.Targets
+	name string
+	mu   *sync.Mutex
+}
+
+func (t *entryRow) SetPhase(_, phase, detail string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.rows.SetPhase(t.name, phase, detail)
+}
+
+func (t *entryRow) SetProgress(_ string, percent float64, eta time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.rows.SetProgress(t.name, percent, eta)
+}
+
+func (t *entryRow) Done(_, summary string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.rows.Done(t.name, summary)
+}
+
+func (t *entryRow) Fail(_ string, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.rows.Fail(t.name, err)
+}
+
+func (t *entryRow) Skip(_, reason string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.rows.Skip(t.name, reason)
+}
+
+func (t *entryRow) Close() {}
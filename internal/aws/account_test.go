@@ -0,0 +1,63 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/koh-sh/apcdeploy/internal/aws/mock"
+)
+
+func TestCheckAccount(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		expected     string
+		stsErr       error
+		wantMismatch bool
+		wantErr      bool
+	}{
+		{name: "matching account", expected: "123456789012"},
+		{name: "different account", expected: "210987654321", wantMismatch: true, wantErr: true},
+		{name: "STS failure", expected: "123456789012", stsErr: errors.New("expired token"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			calls := 0
+			client := NewTestClient(&mock.MockAppConfigClient{})
+			client.STS = &mock.MockSTSClient{
+				GetCallerIdentityFunc: func(ctx context.Context, params *sts.GetCallerIdentityInput, optFns ...func(*sts.Options)) (*sts.GetCallerIdentityOutput, error) {
+					calls++
+					if tt.stsErr != nil {
+						return nil, tt.stsErr
+					}
+					return &sts.GetCallerIdentityOutput{Account: aws.String("123456789012")}, nil
+				},
+			}
+
+			err := client.CheckAccount(context.Background(), tt.expected)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CheckAccount() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if errors.Is(err, ErrAccountMismatch) != tt.wantMismatch {
+				t.Errorf("errors.Is(err, ErrAccountMismatch) = %v, want %v", !tt.wantMismatch, tt.wantMismatch)
+			}
+
+			// A second lookup is served from the cache unless the first failed.
+			_ = client.CheckAccount(context.Background(), tt.expected)
+			wantCalls := 1
+			if tt.stsErr != nil {
+				wantCalls = 2
+			}
+			if calls != wantCalls {
+				t.Errorf("GetCallerIdentity calls = %d, want %d", calls, wantCalls)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// CheckAlarms returns ErrAlarmFiring when any of the named CloudWatch alarms
+// is in the ALARM state, naming the first one and its state reason. Alarms
+// that do not exist are an error so a typo cannot silently disable the
+// guard; OK and INSUFFICIENT_DATA do not block.
+func (c *Client) CheckAlarms(ctx context.Context, names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+	if c.CloudWatch == nil {
+		return fmt.Errorf("failed to describe alarms: CloudWatch client is not configured")
+	}
+	states, err := describeAlarms(ctx, c.CloudWatch, names)
+	if err != nil {
+		return err
+	}
+
+	byName := make(map[string]AlarmState, len(states))
+	for _, s := range states {
+		byName[s.Name] = s
+	}
+	var missing []string
+	for _, name := range names {
+		s, ok := byName[name]
+		if !ok {
+			missing = append(missing, name)
+			continue
+		}
+		if s.State == AlarmStateAlarm {
+			if s.Reason != "" {
+				return fmt.Errorf("%w: %s is in ALARM state (%s)", ErrAlarmFiring, name, s.Reason)
+			}
+			return fmt.Errorf("%w: %s is in ALARM state", ErrAlarmFiring, name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("guard alarm not found in %s: %s", c.Region, strings.Join(missing, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cwtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+)
+
+func TestCheckAlarms(t *testing.T) {
+	t.Parallel()
+
+	alarms := &cloudwatch.DescribeAlarmsOutput{
+		MetricAlarms: []cwtypes.MetricAlarm{
+			{AlarmName: aws.String("api-5xx"), StateValue: cwtypes.StateValueOk},
+			{AlarmName: aws.String("latency"), StateValue: cwtypes.StateValueInsufficientData},
+			{AlarmName: aws.String("errors"), StateValue: cwtypes.StateValueAlarm, StateReason: aws.String("Threshold Crossed")},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		names      []string
+		apiErr     error
+		wantFiring bool
+		wantErr    string
+	}{
+		{name: "no alarms skips the call", names: nil},
+		{name: "ok and insufficient data pass", names: []string{"api-5xx", "latency"}},
+		{name: "firing alarm blocks", names: []string{"api-5xx", "errors"}, wantFiring: true, wantErr: "errors is in ALARM state (Threshold Crossed)"},
+		{name: "unknown alarm is an error", names: []string{"api-5xx", "typo"}, wantErr: "guard alarm not found in us-east-1: typo"},
+		{name: "api error", names: []string{"api-5xx"}, apiErr: errors.New("AccessDenied"), wantErr: "AccessDenied"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			calls := 0
+			client := &Client{Region: "us-east-1", CloudWatch: cloudWatchFunc(func(context.Context, *cloudwatch.DescribeAlarmsInput) (*cloudwatch.DescribeAlarmsOutput, error) {
+				calls++
+				if tt.apiErr != nil {
+					return nil, tt.apiErr
+				}
+				return alarms, nil
+			})}
+
+			err := client.CheckAlarms(context.Background(), tt.names)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want containing %q", err, tt.wantErr)
+			}
+			if got := errors.Is(err, ErrAlarmFiring); got != tt.wantFiring {
+				t.Errorf("errors.Is(err, ErrAlarmFiring) = %v, want %v", got, tt.wantFiring)
+			}
+			if len(tt.names) == 0 && calls != 0 {
+				t.Errorf("DescribeAlarms called %d times with no alarm names", calls)
+			}
+		})
+	}
+}
@@ -2,13 +2,23 @@ package aws
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"net/http"
+	"os"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
 	awsConfig "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/appconfig"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig/types"
 	"github.com/aws/aws-sdk-go-v2/service/appconfigdata"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/koh-sh/apcdeploy/internal/clock"
 	"github.com/koh-sh/apcdeploy/internal/config"
 )
 
@@ -17,40 +27,154 @@ import (
 type Client struct {
 	// appConfig is the underlying AWS SDK client (private field implementing AppConfigSDKAPI)
 	// This can be either *appconfig.Client in production or mock.MockAppConfigClient in tests
-	appConfig       AppConfigSDKAPI
-	AppConfigData   AppConfigDataAPI
+	appConfig     AppConfigSDKAPI
+	AppConfigData AppConfigDataAPI
+	// STS is used to look up the account ID for resource ARNs (tag lookups)
+	STS STSAPI
+	// CloudWatch reads alarm states for the pre-deploy alarm guard
+	CloudWatch      CloudWatchAPI
 	Region          string
 	PollingInterval time.Duration // Interval for polling deployment status (default: 5s)
+	// PollBackoff switches deployment waits from fixed-interval polling to an
+	// exponential backoff that starts at PollingInterval and doubles up to
+	// MaxPollingInterval (default: 1m).
+	PollBackoff        bool
+	MaxPollingInterval time.Duration
+	// MaxAttempts and RetryBaseDelay configure the retryer of the AppConfig
+	// SDK client (default: config.DefaultMaxAttempts and
+	// config.DefaultRetryBaseDelay). NewClient passes them to the retryer
+	// when it builds the SDK client.
+	MaxAttempts    int
+	RetryBaseDelay time.Duration
+	// Clock drives the deployment wait loops (nil means the real clock).
+	// Tests inject a fake to run polling and bake countdowns instantly.
+	Clock clock.Clock
+
+	// accountID caches the caller account returned by STS (see AccountID).
+	accountMu sync.Mutex
+	accountID string
+
+	// strategies caches the full deployment strategy list (see
+	// ListAllDeploymentStrategies).
+	strategiesMu sync.Mutex
+	strategies   []types.DeploymentStrategy
 }
 
-// NewClient creates a new AWS client with the specified region
-func NewClient(ctx context.Context, region string) (*Client, error) {
-	var cfg aws.Config
-	var err error
+// caBundlePath is an extra PEM CA bundle trusted by every AWS client
+// (--ca-bundle). Set once at startup by SetCABundle.
+var caBundlePath string
+
+// SetCABundle makes clients created afterwards trust the PEM certificates in
+// path in addition to the system roots. An empty path restores the default.
+func SetCABundle(path string) {
+	caBundlePath = path
+}
+
+// sharedConfigProfile is the AWS named profile every client loads its
+// credentials and defaults from (--aws-profile). Set once at startup by
+// SetSharedConfigProfile.
+var sharedConfigProfile string
+
+// SetSharedConfigProfile makes clients created afterwards use the named
+// profile from the shared AWS config and credentials files. An empty name
+// restores the SDK default (AWS_PROFILE, then "default").
+func SetSharedConfigProfile(name string) {
+	sharedConfigProfile = name
+}
+
+// loadDefaultConfig is awsConfig.LoadDefaultConfig, swapped out in tests to
+// inspect the load options without real credentials.
+var loadDefaultConfig = awsConfig.LoadDefaultConfig
+
+// LoadConfig loads the SDK config shared by all AWS clients. The HTTP client
+// honors the standard proxy environment variables (HTTPS_PROXY, HTTP_PROXY,
+// NO_PROXY) and trusts the --ca-bundle certificates when set. The
+// --aws-profile named profile is used when set. An empty region falls back
+// to AWS_REGION and AWS_DEFAULT_REGION (see config.ResolveRegion); either
+// wins over the profile's region, which the SDK uses when neither is set.
+func LoadConfig(ctx context.Context, region string) (aws.Config, error) {
+	region = config.ResolveRegion(region, "")
+
+	rootCAs, err := loadCABundle()
+	if err != nil {
+		return aws.Config{}, err
+	}
+
+	httpClient := awshttp.NewBuildableClient().WithTransportOptions(func(tr *http.Transport) {
+		tr.Proxy = http.ProxyFromEnvironment
+		if rootCAs != nil {
+			if tr.TLSClientConfig == nil {
+				tr.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+			}
+			tr.TLSClientConfig.RootCAs = rootCAs
+		}
+	})
+	opts := []func(*awsConfig.LoadOptions) error{
+		awsConfig.WithHTTPClient(httpClient),
+	}
 
-	// Load AWS config
 	if region != "" {
-		// If region is explicitly provided, use it
-		cfg, err = awsConfig.LoadDefaultConfig(ctx,
-			awsConfig.WithRegion(region),
-		)
-	} else {
-		// Otherwise, let AWS SDK resolve the default region from AWS config
-		cfg, err = awsConfig.LoadDefaultConfig(ctx)
+		opts = append(opts, awsConfig.WithRegion(region))
+	}
+
+	if sharedConfigProfile != "" {
+		opts = append(opts, awsConfig.WithSharedConfigProfile(sharedConfigProfile))
 	}
 
+	cfg, err := loadDefaultConfig(ctx, opts...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+		return aws.Config{}, fmt.Errorf("failed to load AWS config: %w", err)
 	}
+	return cfg, nil
+}
 
-	// Create AppConfig client
-	appconfigClient := appconfig.NewFromConfig(cfg)
-	appconfigdataClient := appconfigdata.NewFromConfig(cfg)
+// loadCABundle returns the system root pool with the --ca-bundle
+// certificates appended, or nil when no bundle is set. The SDK's
+// WithCustomCABundle would replace the system roots instead, breaking every
+// endpoint the bundle does not cover.
+func loadCABundle() (*x509.CertPool, error) {
+	if caBundlePath == "" {
+		return nil, nil
+	}
+	pem, err := os.ReadFile(caBundlePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("failed to read CA bundle: no PEM certificates found in %s", caBundlePath)
+	}
+	return pool, nil
+}
 
-	return &Client{
-		appConfig:       appconfigClient,
-		AppConfigData:   appconfigdataClient,
+// NewClient creates a new AWS client with the specified region. It returns
+// ErrNoRegion when no region resolves.
+func NewClient(ctx context.Context, region string) (*Client, error) {
+	cfg, err := LoadConfig(ctx, region)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Region == "" {
+		return nil, ErrNoRegion
+	}
+
+	client := &Client{
+		AppConfigData:   appconfigdata.NewFromConfig(cfg),
+		STS:             sts.NewFromConfig(cfg),
+		CloudWatch:      cloudwatch.NewFromConfig(cfg),
 		Region:          cfg.Region,
 		PollingInterval: config.DefaultPollingInterval,
-	}, nil
+		MaxAttempts:     config.DefaultMaxAttempts,
+		RetryBaseDelay:  config.DefaultRetryBaseDelay,
+	}
+
+	// Create AppConfig client
+	client.appConfig = appconfig.NewFromConfig(cfg, func(o *appconfig.Options) {
+		o.Retryer = newRetryer(client.MaxAttempts, client.RetryBaseDelay)
+	})
+
+	return client, nil
 }
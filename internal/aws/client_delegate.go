@@ -29,6 +29,10 @@ func (c *Client) GetDeployment(ctx context.Context, params *appconfig.GetDeploym
 	return c.appConfig.GetDeployment(ctx, params, optFns...)
 }
 
+func (c *Client) GetDeploymentStrategy(ctx context.Context, params *appconfig.GetDeploymentStrategyInput, optFns ...func(*appconfig.Options)) (*appconfig.GetDeploymentStrategyOutput, error) {
+	return c.appConfig.GetDeploymentStrategy(ctx, params, optFns...)
+}
+
 // Note: CreateHostedConfigurationVersion and StartDeployment are not delegated here
 // because they have convenience wrapper methods in deployment.go with different signatures.
 // Those convenience wrappers call c.appConfig methods directly.
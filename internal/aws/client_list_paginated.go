@@ -32,6 +32,7 @@ package aws
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/aws/aws-sdk-go-v2/service/appconfig"
 	"github.com/aws/aws-sdk-go-v2/service/appconfig/types"
@@ -111,9 +112,18 @@ func (c *Client) ListAllEnvironments(ctx context.Context, appID string) ([]types
 	return allItems, nil
 }
 
-// ListAllDeploymentStrategies retrieves all deployment strategies with pagination handling
+// ListAllDeploymentStrategies retrieves all deployment strategies with pagination handling.
+// The first successful listing is cached on the client, so resolving a
+// strategy name and later mapping its ID back to a name costs one listing
+// per command. Callers get their own copy of the cached slice.
 func (c *Client) ListAllDeploymentStrategies(ctx context.Context) ([]types.DeploymentStrategy, error) {
-	var allItems []types.DeploymentStrategy
+	c.strategiesMu.Lock()
+	defer c.strategiesMu.Unlock()
+	if c.strategies != nil {
+		return slices.Clone(c.strategies), nil
+	}
+
+	allItems := []types.DeploymentStrategy{}
 	var nextToken *string
 
 	for {
@@ -132,7 +142,8 @@ func (c *Client) ListAllDeploymentStrategies(ctx context.Context) ([]types.Deplo
 		nextToken = output.NextToken
 	}
 
-	return allItems, nil
+	c.strategies = allItems
+	return slices.Clone(allItems), nil
 }
 
 // ListAllDeployments retrieves all deployments for an application and environment with pagination handling
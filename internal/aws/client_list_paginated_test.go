@@ -451,6 +451,66 @@ func TestListAllDeploymentStrategies(t *testing.T) {
 	}
 }
 
+func TestListAllDeploymentStrategiesCached(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	fail := true
+	client := &Client{
+		appConfig: &mock.MockAppConfigClient{
+			ListDeploymentStrategiesFunc: func(ctx context.Context, params *appconfig.ListDeploymentStrategiesInput, optFns ...func(*appconfig.Options)) (*appconfig.ListDeploymentStrategiesOutput, error) {
+				calls++
+				if fail {
+					return nil, errors.New("throttled")
+				}
+				if params.NextToken == nil {
+					return &appconfig.ListDeploymentStrategiesOutput{
+						Items:     []types.DeploymentStrategy{{Id: aws.String("st-1"), Name: aws.String("AllAtOnce")}},
+						NextToken: aws.String("page2"),
+					}, nil
+				}
+				return &appconfig.ListDeploymentStrategiesOutput{
+					Items: []types.DeploymentStrategy{{Id: aws.String("st-2"), Name: aws.String("Custom")}},
+				}, nil
+			},
+		},
+	}
+	ctx := context.Background()
+
+	// Errors are not cached
+	if _, err := client.ListAllDeploymentStrategies(ctx); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	fail = false
+
+	first, err := client.ListAllDeploymentStrategies(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Callers may modify their copy without affecting the cache
+	first[0].Name = aws.String("changed")
+
+	second, err := client.ListAllDeploymentStrategies(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("ListDeploymentStrategies called %d times, want 3 (one failure, then two pages once)", calls)
+	}
+	if len(second) != 2 || aws.ToString(second[0].Name) != "AllAtOnce" || aws.ToString(second[1].Name) != "Custom" {
+		t.Errorf("cached strategies = %+v, want both pages unchanged", second)
+	}
+
+	// A fresh client lists again
+	fresh := &Client{appConfig: client.appConfig}
+	if _, err := fresh.ListAllDeploymentStrategies(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 5 {
+		t.Errorf("ListDeploymentStrategies called %d times after a fresh client, want 5", calls)
+	}
+}
+
 func TestListAllDeployments(t *testing.T) {
 	t.Parallel()
 
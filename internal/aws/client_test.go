@@ -2,18 +2,37 @@ package aws
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	awsConfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig"
+	"github.com/aws/aws-sdk-go-v2/service/appconfigdata"
+	"github.com/koh-sh/apcdeploy/internal/config"
 )
 
 func TestNewClient(t *testing.T) {
 	tests := []struct {
-		name        string
-		region      string
-		setupEnv    map[string]string
-		wantErr     bool
-		errContains string
+		name     string
+		region   string
+		setupEnv map[string]string
+		// sharedConfig is written to AWS_CONFIG_FILE when set
+		sharedConfig string
+		wantRegion   string
+		wantErr      bool
+		errContains  string
 	}{
 		{
 			name:    "successful client creation with region",
@@ -26,7 +45,7 @@ func TestNewClient(t *testing.T) {
 			setupEnv: map[string]string{
 				"AWS_REGION": "ap-northeast-1",
 			},
-			wantErr: false,
+			wantRegion: "ap-northeast-1",
 		},
 		{
 			name:   "successful client creation with AWS_DEFAULT_REGION env",
@@ -34,27 +53,55 @@ func TestNewClient(t *testing.T) {
 			setupEnv: map[string]string{
 				"AWS_DEFAULT_REGION": "eu-west-1",
 			},
-			wantErr: false,
+			wantRegion: "eu-west-1",
 		},
 		{
-			name:    "successful client creation with SDK default region resolution",
-			region:  "",
-			wantErr: false,
+			name:   "AWS_REGION wins over AWS_DEFAULT_REGION",
+			region: "",
+			setupEnv: map[string]string{
+				"AWS_REGION":         "ap-northeast-1",
+				"AWS_DEFAULT_REGION": "eu-west-1",
+			},
+			wantRegion: "ap-northeast-1",
+		},
+		{
+			name:         "shared config profile region",
+			region:       "",
+			sharedConfig: "[default]\nregion = sa-east-1\n",
+			wantRegion:   "sa-east-1",
+		},
+		{
+			name:   "AWS_DEFAULT_REGION wins over the shared config profile",
+			region: "",
+			setupEnv: map[string]string{
+				"AWS_DEFAULT_REGION": "eu-west-1",
+			},
+			sharedConfig: "[default]\nregion = sa-east-1\n",
+			wantRegion:   "eu-west-1",
+		},
+		{
+			name:        "no region anywhere",
+			region:      "",
+			wantErr:     true,
+			errContains: "no AWS region configured",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Setup environment variables
-			if tt.setupEnv != nil {
-				for k, v := range tt.setupEnv {
-					os.Setenv(k, v)
-					defer os.Unsetenv(k)
+			// Start from no region at all: no env vars and no shared config
+			t.Setenv("AWS_REGION", "")
+			t.Setenv("AWS_DEFAULT_REGION", "")
+			sharedConfigPath := filepath.Join(t.TempDir(), "config")
+			if tt.sharedConfig != "" {
+				if err := os.WriteFile(sharedConfigPath, []byte(tt.sharedConfig), 0o644); err != nil {
+					t.Fatal(err)
 				}
-			} else {
-				// Clear region env vars for clean test
-				os.Unsetenv("AWS_REGION")
-				os.Unsetenv("AWS_DEFAULT_REGION")
+			}
+			t.Setenv("AWS_CONFIG_FILE", sharedConfigPath)
+			t.Setenv("AWS_PROFILE", "")
+			for k, v := range tt.setupEnv {
+				t.Setenv(k, v)
 			}
 
 			ctx := context.Background()
@@ -83,8 +130,15 @@ func TestNewClient(t *testing.T) {
 			}
 
 			// Verify region is set correctly
-			if tt.region != "" && client.Region != tt.region {
-				t.Errorf("expected client.Region to be %q, got %q", tt.region, client.Region)
+			wantRegion := tt.wantRegion
+			if wantRegion == "" {
+				wantRegion = tt.region
+			}
+			if wantRegion != "" && client.Region != wantRegion {
+				t.Errorf("expected client.Region to be %q, got %q", wantRegion, client.Region)
+			}
+			if client.MaxAttempts != config.DefaultMaxAttempts || client.RetryBaseDelay != config.DefaultRetryBaseDelay {
+				t.Errorf("retry settings = (%d, %v), want (%d, %v)", client.MaxAttempts, client.RetryBaseDelay, config.DefaultMaxAttempts, config.DefaultRetryBaseDelay)
 			}
 		})
 	}
@@ -130,3 +184,139 @@ func TestNewClientWithProfile(t *testing.T) {
 		})
 	}
 }
+
+// writeTestCABundle writes a self-signed PEM certificate to a temp file.
+func writeTestCABundle(t *testing.T) string {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "apcdeploy test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// TestNewClientCABundle checks that --ca-bundle and the proxy-aware
+// transport reach both the AppConfig and AppConfigData clients, and that the
+// bundle is added to the system roots rather than replacing them. It mutates
+// package state, so it does not run in parallel.
+func TestNewClientCABundle(t *testing.T) {
+	t.Cleanup(func() { SetCABundle("") })
+
+	bundle := writeTestCABundle(t)
+	SetCABundle(bundle)
+	data, err := os.ReadFile(bundle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantRoots, err := x509.SystemCertPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantRoots.AppendCertsFromPEM(data)
+
+	client, err := NewClient(context.Background(), "us-east-1")
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	httpClients := map[string]any{
+		"appconfig":     client.appConfig.(*appconfig.Client).Options().HTTPClient,
+		"appconfigdata": client.AppConfigData.(*appconfigdata.Client).Options().HTTPClient,
+	}
+	for name, hc := range httpClients {
+		buildable, ok := hc.(*awshttp.BuildableClient)
+		if !ok {
+			t.Fatalf("%s HTTP client = %T, want *http.BuildableClient", name, hc)
+		}
+		tr := buildable.GetTransport()
+		if tr.Proxy == nil {
+			t.Errorf("%s transport does not honor proxy environment variables", name)
+		}
+		if tr.TLSClientConfig == nil || tr.TLSClientConfig.RootCAs == nil {
+			t.Errorf("%s transport does not carry the custom CA bundle", name)
+		} else if !tr.TLSClientConfig.RootCAs.Equal(wantRoots) {
+			t.Errorf("%s transport roots are not the system roots plus the CA bundle", name)
+		}
+	}
+}
+
+func TestNewClientCABundleErrors(t *testing.T) {
+	t.Cleanup(func() { SetCABundle("") })
+
+	notPEM := filepath.Join(t.TempDir(), "bundle.pem")
+	if err := os.WriteFile(notPEM, []byte("not a certificate"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range []string{filepath.Join(t.TempDir(), "missing.pem"), notPEM} {
+		SetCABundle(path)
+		if _, err := NewClient(context.Background(), "us-east-1"); err == nil || !strings.Contains(err.Error(), "failed to read CA bundle") {
+			t.Errorf("NewClient() with CA bundle %s error = %v, want CA bundle error", path, err)
+		}
+	}
+}
+
+// TestLoadConfigSharedConfigProfile checks that --aws-profile reaches the
+// SDK loader next to the region override. It swaps package state, so it
+// does not run in parallel.
+func TestLoadConfigSharedConfigProfile(t *testing.T) {
+	tests := []struct {
+		name        string
+		profile     string
+		region      string
+		wantProfile string
+		wantRegion  string
+	}{
+		{name: "profile only", profile: "staging", wantProfile: "staging"},
+		{name: "profile and region", profile: "staging", region: "eu-west-1", wantProfile: "staging", wantRegion: "eu-west-1"},
+		{name: "no profile", region: "us-east-1", wantRegion: "us-east-1"},
+	}
+
+	original := loadDefaultConfig
+	t.Cleanup(func() {
+		loadDefaultConfig = original
+		SetSharedConfigProfile("")
+	})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got awsConfig.LoadOptions
+			loadDefaultConfig = func(ctx context.Context, optFns ...func(*awsConfig.LoadOptions) error) (aws.Config, error) {
+				for _, fn := range optFns {
+					if err := fn(&got); err != nil {
+						return aws.Config{}, err
+					}
+				}
+				return aws.Config{Region: got.Region}, nil
+			}
+			SetSharedConfigProfile(tt.profile)
+
+			if _, err := LoadConfig(context.Background(), tt.region); err != nil {
+				t.Fatalf("LoadConfig() error = %v", err)
+			}
+			if got.SharedConfigProfile != tt.wantProfile {
+				t.Errorf("SharedConfigProfile = %q, want %q", got.SharedConfigProfile, tt.wantProfile)
+			}
+			if got.Region != tt.wantRegion {
+				t.Errorf("Region = %q, want %q", got.Region, tt.wantRegion)
+			}
+		})
+	}
+}
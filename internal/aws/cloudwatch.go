@@ -0,0 +1,50 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cwtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+)
+
+// AlarmStateAlarm is the CloudWatch state of an alarm that is firing.
+const AlarmStateAlarm = "ALARM"
+
+// AlarmState is the current state of a CloudWatch metric or composite alarm.
+type AlarmState struct {
+	Name   string
+	State  string // OK, ALARM or INSUFFICIENT_DATA
+	Reason string
+}
+
+// describeAlarmsBatch is the maximum number of AlarmNames per request.
+const describeAlarmsBatch = 100
+
+// describeAlarms returns the state of the named metric and composite
+// alarms, following pagination. Names that do not exist are absent from the
+// result.
+func describeAlarms(ctx context.Context, api CloudWatchAPI, names []string) ([]AlarmState, error) {
+	var states []AlarmState
+	for start := 0; start < len(names); start += describeAlarmsBatch {
+		batch := names[start:min(start+describeAlarmsBatch, len(names))]
+		paginator := cloudwatch.NewDescribeAlarmsPaginator(api, &cloudwatch.DescribeAlarmsInput{
+			AlarmNames: batch,
+			AlarmTypes: []cwtypes.AlarmType{cwtypes.AlarmTypeMetricAlarm, cwtypes.AlarmTypeCompositeAlarm},
+			MaxRecords: aws.Int32(describeAlarmsBatch),
+		})
+		for paginator.HasMorePages() {
+			out, err := paginator.NextPage(ctx)
+			if err != nil {
+				return nil, wrapAWSError(err, "DescribeAlarms")
+			}
+			for _, a := range out.MetricAlarms {
+				states = append(states, AlarmState{Name: aws.ToString(a.AlarmName), State: string(a.StateValue), Reason: aws.ToString(a.StateReason)})
+			}
+			for _, a := range out.CompositeAlarms {
+				states = append(states, AlarmState{Name: aws.ToString(a.AlarmName), State: string(a.StateValue), Reason: aws.ToString(a.StateReason)})
+			}
+		}
+	}
+	return states, nil
+}
@@ -0,0 +1,79 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cwtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+)
+
+// cloudWatchFunc adapts a function to CloudWatchAPI.
+type cloudWatchFunc func(ctx context.Context, params *cloudwatch.DescribeAlarmsInput) (*cloudwatch.DescribeAlarmsOutput, error)
+
+func (f cloudWatchFunc) DescribeAlarms(ctx context.Context, params *cloudwatch.DescribeAlarmsInput, _ ...func(*cloudwatch.Options)) (*cloudwatch.DescribeAlarmsOutput, error) {
+	return f(ctx, params)
+}
+
+func TestDescribeAlarms(t *testing.T) {
+	t.Parallel()
+
+	names := make([]string, 150)
+	for i := range names {
+		names[i] = fmt.Sprintf("alarm-%03d", i)
+	}
+
+	var batches [][]string
+	api := cloudWatchFunc(func(_ context.Context, params *cloudwatch.DescribeAlarmsInput) (*cloudwatch.DescribeAlarmsOutput, error) {
+		if want := []cwtypes.AlarmType{cwtypes.AlarmTypeMetricAlarm, cwtypes.AlarmTypeCompositeAlarm}; !reflect.DeepEqual(params.AlarmTypes, want) {
+			t.Errorf("AlarmTypes = %v, want %v", params.AlarmTypes, want)
+		}
+		if params.NextToken == nil {
+			batches = append(batches, params.AlarmNames)
+			// First page: metric alarms, with a second page to follow.
+			return &cloudwatch.DescribeAlarmsOutput{
+				MetricAlarms: []cwtypes.MetricAlarm{{AlarmName: aws.String(params.AlarmNames[0]), StateValue: cwtypes.StateValueAlarm, StateReason: aws.String("Threshold Crossed")}},
+				NextToken:    aws.String("page2"),
+			}, nil
+		}
+		return &cloudwatch.DescribeAlarmsOutput{
+			CompositeAlarms: []cwtypes.CompositeAlarm{{AlarmName: aws.String(params.AlarmNames[1]), StateValue: cwtypes.StateValueOk}},
+		}, nil
+	})
+
+	got, err := describeAlarms(context.Background(), api, names)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(batches) != 2 || len(batches[0]) != 100 || len(batches[1]) != 50 {
+		t.Fatalf("got %d batches, want 100 names then 50", len(batches))
+	}
+	want := []AlarmState{
+		{Name: "alarm-000", State: "ALARM", Reason: "Threshold Crossed"},
+		{Name: "alarm-001", State: "OK"},
+		{Name: "alarm-100", State: "ALARM", Reason: "Threshold Crossed"},
+		{Name: "alarm-101", State: "OK"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("describeAlarms() = %+v, want %+v", got, want)
+	}
+}
+
+func TestDescribeAlarmsError(t *testing.T) {
+	t.Parallel()
+
+	api := cloudWatchFunc(func(context.Context, *cloudwatch.DescribeAlarmsInput) (*cloudwatch.DescribeAlarmsOutput, error) {
+		return nil, errors.New("AccessDenied: not authorized to perform cloudwatch:DescribeAlarms")
+	})
+
+	_, err := describeAlarms(context.Background(), api, []string{"errors"})
+	if err == nil || !strings.Contains(err.Error(), "DescribeAlarms failed") || !strings.Contains(err.Error(), "AccessDenied") {
+		t.Fatalf("error = %v, want DescribeAlarms failed: AccessDenied", err)
+	}
+}
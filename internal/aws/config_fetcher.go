@@ -39,6 +39,44 @@ func GetLatestDeployedConfiguration(ctx context.Context, client *Client, appID,
 		return nil, nil
 	}
 
+	return fetchDeployedVersion(ctx, client, appID, profileID, deployment)
+}
+
+// GetDeployedConfigurationByNumber retrieves the configuration served by a
+// specific historical deployment. It fails when the deployment does not
+// exist or belongs to a different configuration profile.
+func GetDeployedConfigurationByNumber(ctx context.Context, client *Client, appID, envID, profileID string, deploymentNumber int32) (*DeployedConfigInfo, error) {
+	deployment, err := GetDeploymentByNumber(ctx, client, appID, envID, profileID, deploymentNumber)
+	if err != nil {
+		return nil, err
+	}
+	return fetchDeployedVersion(ctx, client, appID, profileID, deployment)
+}
+
+// GetLatestHostedConfiguration retrieves the content of the newest hosted
+// configuration version of a profile, deployed or not. DeploymentNumber is
+// left zero. Returns nil if the profile has no hosted versions.
+func GetLatestHostedConfiguration(ctx context.Context, client *Client, appID, profileID string) (*DeployedConfigInfo, error) {
+	versions, err := client.ListAllHostedConfigurationVersions(ctx, appID, profileID)
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return nil, nil
+	}
+	latest := versions[0].VersionNumber
+	for _, v := range versions[1:] {
+		latest = max(latest, v.VersionNumber)
+	}
+
+	return fetchDeployedVersion(ctx, client, appID, profileID, &DeploymentInfo{
+		ConfigurationVersion: strconv.Itoa(int(latest)),
+	})
+}
+
+// fetchDeployedVersion loads the hosted configuration version referenced by
+// deployment and packages it with the deployment metadata.
+func fetchDeployedVersion(ctx context.Context, client *Client, appID, profileID string, deployment *DeploymentInfo) (*DeployedConfigInfo, error) {
 	// Parse version number from deployment
 	versionNum, err := strconv.ParseInt(deployment.ConfigurationVersion, 10, 32)
 	if err != nil {
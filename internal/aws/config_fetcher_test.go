@@ -3,6 +3,7 @@ package aws
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -230,3 +231,142 @@ func TestGetLatestDeployedConfiguration(t *testing.T) {
 		})
 	}
 }
+
+func TestGetDeployedConfigurationByNumber(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		profileID        string
+		getDeploymentErr error
+		wantErr          string
+	}{
+		{
+			name:      "returns the requested deployment's version",
+			profileID: "prof-789",
+		},
+		{
+			name:      "rejects deployment of another profile",
+			profileID: "prof-other",
+			wantErr:   "is not for this configuration profile",
+		},
+		{
+			name:             "propagates GetDeployment error",
+			profileID:        "prof-789",
+			getDeploymentErr: fmt.Errorf("not found"),
+			wantErr:          "failed to get deployment #3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var requestedVersion int32
+			mockClient := &mock.MockAppConfigClient{
+				GetDeploymentFunc: func(ctx context.Context, params *appconfig.GetDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.GetDeploymentOutput, error) {
+					if tt.getDeploymentErr != nil {
+						return nil, tt.getDeploymentErr
+					}
+					return &appconfig.GetDeploymentOutput{
+						DeploymentNumber:       *params.DeploymentNumber,
+						ConfigurationProfileId: aws.String("prof-789"),
+						ConfigurationVersion:   aws.String("5"),
+						DeploymentStrategyId:   aws.String("strategy-1"),
+						State:                  types.DeploymentStateRolledBack,
+					}, nil
+				},
+				GetHostedConfigurationVersionFunc: func(ctx context.Context, params *appconfig.GetHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.GetHostedConfigurationVersionOutput, error) {
+					requestedVersion = *params.VersionNumber
+					return &appconfig.GetHostedConfigurationVersionOutput{
+						Content:     []byte("key: value\n"),
+						ContentType: aws.String("application/x-yaml"),
+					}, nil
+				},
+			}
+
+			info, err := GetDeployedConfigurationByNumber(context.Background(), NewTestClient(mockClient), "app-123", "env-456", tt.profileID, 3)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if requestedVersion != 5 {
+				t.Errorf("expected version 5 to be fetched, got %d", requestedVersion)
+			}
+			if info.DeploymentNumber != 3 || info.VersionNumber != 5 {
+				t.Errorf("unexpected info: %+v", info)
+			}
+			if info.ContentType != "application/x-yaml" || string(info.Content) != "key: value\n" {
+				t.Errorf("unexpected content: %q (%s)", info.Content, info.ContentType)
+			}
+		})
+	}
+}
+
+func TestGetLatestHostedConfiguration(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		versions    []int32
+		wantNil     bool
+		wantVersion int32
+	}{
+		{
+			name:        "picks the highest version number",
+			versions:    []int32{2, 7, 5},
+			wantVersion: 7,
+		},
+		{
+			name:     "no hosted versions",
+			versions: nil,
+			wantNil:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var requestedVersion int32
+			mockClient := &mock.MockAppConfigClient{
+				ListHostedConfigurationVersionsFunc: func(ctx context.Context, params *appconfig.ListHostedConfigurationVersionsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListHostedConfigurationVersionsOutput, error) {
+					items := make([]types.HostedConfigurationVersionSummary, 0, len(tt.versions))
+					for _, v := range tt.versions {
+						items = append(items, types.HostedConfigurationVersionSummary{VersionNumber: v})
+					}
+					return &appconfig.ListHostedConfigurationVersionsOutput{Items: items}, nil
+				},
+				GetHostedConfigurationVersionFunc: func(ctx context.Context, params *appconfig.GetHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.GetHostedConfigurationVersionOutput, error) {
+					requestedVersion = *params.VersionNumber
+					return &appconfig.GetHostedConfigurationVersionOutput{
+						Content:     []byte(`{"seed": true}`),
+						ContentType: aws.String("application/json"),
+					}, nil
+				},
+			}
+
+			info, err := GetLatestHostedConfiguration(context.Background(), NewTestClient(mockClient), "app-123", "prof-789")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantNil {
+				if info != nil {
+					t.Errorf("expected nil info, got %+v", info)
+				}
+				return
+			}
+			if requestedVersion != tt.wantVersion || info.VersionNumber != tt.wantVersion {
+				t.Errorf("fetched v%d (info v%d), want v%d", requestedVersion, info.VersionNumber, tt.wantVersion)
+			}
+			if info.DeploymentNumber != 0 || string(info.Content) != `{"seed": true}` {
+				t.Errorf("unexpected info: %+v", info)
+			}
+		})
+	}
+}
@@ -28,6 +28,71 @@ func (c *Client) CheckOngoingDeployment(ctx context.Context, applicationID, envi
 	return false, nil, nil
 }
 
+// LatestDeploymentSummary returns the newest deployment of the environment,
+// whatever its configuration profile, or nil when it has none. It makes a
+// single ListDeployments call: AppConfig lists deployments newest first.
+func (c *Client) LatestDeploymentSummary(ctx context.Context, applicationID, environmentID string) (*types.DeploymentSummary, error) {
+	output, err := c.appConfig.ListDeployments(ctx, &appconfig.ListDeploymentsInput{
+		ApplicationId: aws.String(applicationID),
+		EnvironmentId: aws.String(environmentID),
+		MaxResults:    aws.Int32(1),
+	})
+	if err != nil {
+		return nil, wrapAWSError(err, "failed to list deployments")
+	}
+	if len(output.Items) == 0 {
+		return nil, nil
+	}
+	return &output.Items[0], nil
+}
+
+// WaitForNoOngoingDeployment polls CheckOngoingDeployment on the configured
+// schedule until the environment has no DEPLOYING or BAKING deployment, so a
+// new deployment can start. onWait is invoked with the blocking deployment on
+// every poll that still finds one; nil is allowed. It returns immediately when
+// nothing is in progress, and fails when timeout elapses first or ctx is
+// cancelled.
+func (c *Client) WaitForNoOngoingDeployment(
+	ctx context.Context,
+	applicationID, environmentID string,
+	timeout time.Duration,
+	onWait func(ongoing *types.DeploymentSummary),
+) error {
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	clk := c.clock()
+	deadline := clk.Now().Add(timeout)
+	schedule := c.newPollSchedule()
+	var blocking int32
+	for {
+		ongoing, summary, err := c.CheckOngoingDeployment(waitCtx, applicationID, environmentID)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return ctxErr
+			}
+			if waitCtx.Err() != nil {
+				return fmt.Errorf("timed out after %v waiting for deployment #%d to finish", timeout, blocking)
+			}
+			return err
+		}
+		if !ongoing {
+			return nil
+		}
+		blocking = summary.DeploymentNumber
+		if onWait != nil {
+			onWait(summary)
+		}
+
+		if !sleepUntilNextPoll(waitCtx, clk, schedule.next(), deadline) {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return ctxErr
+			}
+			return fmt.Errorf("timed out after %v waiting for deployment #%d to finish", timeout, blocking)
+		}
+	}
+}
+
 // CreateHostedConfigurationVersion creates a new hosted configuration version
 func (c *Client) CreateHostedConfigurationVersion(
 	ctx context.Context,
@@ -54,6 +119,25 @@ func (c *Client) CreateHostedConfigurationVersion(
 	return output.VersionNumber, nil
 }
 
+// DeleteHostedConfigurationVersion deletes a hosted configuration version
+func (c *Client) DeleteHostedConfigurationVersion(
+	ctx context.Context,
+	applicationID, profileID string,
+	versionNumber int32,
+) error {
+	input := &appconfig.DeleteHostedConfigurationVersionInput{
+		ApplicationId:          aws.String(applicationID),
+		ConfigurationProfileId: aws.String(profileID),
+		VersionNumber:          aws.Int32(versionNumber),
+	}
+
+	if _, err := c.appConfig.DeleteHostedConfigurationVersion(ctx, input); err != nil {
+		return wrapAWSError(err, "failed to delete hosted configuration version")
+	}
+
+	return nil
+}
+
 // StartDeployment starts a new deployment
 func (c *Client) StartDeployment(
 	ctx context.Context,
@@ -103,6 +187,38 @@ func (c *Client) StopDeployment(
 	return nil
 }
 
+// StrategyTiming is the rollout schedule of a deployment strategy.
+type StrategyTiming struct {
+	// DeploymentDuration is how long the rollout takes to reach 100%
+	DeploymentDuration time.Duration
+	// FinalBakeTime is how long AppConfig monitors after the rollout
+	FinalBakeTime time.Duration
+	GrowthType    types.GrowthType
+	GrowthFactor  float32
+}
+
+// Total is the time a deployment with this strategy takes to reach COMPLETE.
+func (t StrategyTiming) Total() time.Duration {
+	return t.DeploymentDuration + t.FinalBakeTime
+}
+
+// GetStrategyTiming fetches the rollout duration, final bake time and growth
+// schedule of a deployment strategy.
+func (c *Client) GetStrategyTiming(ctx context.Context, strategyID string) (*StrategyTiming, error) {
+	output, err := c.appConfig.GetDeploymentStrategy(ctx, &appconfig.GetDeploymentStrategyInput{
+		DeploymentStrategyId: aws.String(strategyID),
+	})
+	if err != nil {
+		return nil, wrapAWSError(err, "failed to get deployment strategy")
+	}
+	return &StrategyTiming{
+		DeploymentDuration: time.Duration(output.DeploymentDurationInMinutes) * time.Minute,
+		FinalBakeTime:      time.Duration(output.FinalBakeTimeInMinutes) * time.Minute,
+		GrowthType:         output.GrowthType,
+		GrowthFactor:       aws.ToFloat32(output.GrowthFactor),
+	}, nil
+}
+
 // rolledBackError formats the error returned when a deployment has reached
 // the ROLLED_BACK state. It pulls the most recent rollback description from
 // the event log when available, falling back to a generic message otherwise.
@@ -132,6 +248,13 @@ func ExtractRollbackReason(eventLog []types.DeploymentEvent) string {
 	return ""
 }
 
+// waitCanceledError is returned when the caller's ctx ends a wait loop
+// (e.g. Ctrl-C) before its own timeout. It wraps ctx.Err() so callers can
+// tell cancellation from a timeout with errors.Is.
+func waitCanceledError(deploymentNumber int32, err error) error {
+	return fmt.Errorf("stopped waiting for deployment #%d, which is still running in AWS: %w", deploymentNumber, err)
+}
+
 // DeploymentTickFunc is invoked on each polling tick of a deployment wait
 // loop, with the current state, percentage-complete reported by AWS, and the
 // configured deployment duration (DeploymentDurationInMinutes converted to a
@@ -150,16 +273,14 @@ func (c *Client) waitForDeploymentWithCondition(
 	checkComplete func(types.DeploymentState) bool,
 	onTick DeploymentTickFunc,
 ) error {
+	parent := ctx
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	// Use configured polling interval, default to 5s if not set
-	pollingInterval := c.PollingInterval
-	if pollingInterval == 0 {
-		pollingInterval = 5 * time.Second
-	}
-	ticker := time.NewTicker(pollingInterval)
-	defer ticker.Stop()
+	// Poll on the configured schedule (fixed 5s by default, or backoff)
+	clk := c.clock()
+	deadline := clk.Now().Add(timeout)
+	schedule := c.newPollSchedule()
 
 	checkDeployment := func() (bool, error) {
 		input := &appconfig.GetDeploymentInput{
@@ -170,6 +291,9 @@ func (c *Client) waitForDeploymentWithCondition(
 
 		output, err := c.appConfig.GetDeployment(ctx, input)
 		if err != nil {
+			if ctxErr := parent.Err(); ctxErr != nil {
+				return false, waitCanceledError(deploymentNumber, ctxErr)
+			}
 			return false, wrapAWSError(err, "failed to get deployment status")
 		}
 
@@ -212,13 +336,14 @@ func (c *Client) waitForDeploymentWithCondition(
 
 	// Then check periodically
 	for {
-		select {
-		case <-ctx.Done():
-			return fmt.Errorf("deployment timed out after %v", timeout)
-		case <-ticker.C:
-			if complete, err := checkDeployment(); err != nil || complete {
-				return err
+		if !sleepUntilNextPoll(ctx, clk, schedule.next(), deadline) {
+			if ctxErr := parent.Err(); ctxErr != nil && clk.Now().Before(deadline) {
+				return waitCanceledError(deploymentNumber, ctxErr)
 			}
+			return fmt.Errorf("deployment timed out after %v", timeout)
+		}
+		if complete, err := checkDeployment(); err != nil || complete {
+			return err
 		}
 	}
 }
@@ -287,17 +412,15 @@ func (c *Client) WaitForBakingComplete(
 	timeout time.Duration,
 	onTick BakeTickFunc,
 ) error {
+	parent := ctx
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	pollingInterval := c.PollingInterval
-	if pollingInterval == 0 {
-		pollingInterval = 5 * time.Second
-	}
-	ticker := time.NewTicker(pollingInterval)
-	defer ticker.Stop()
+	clk := c.clock()
+	deadline := clk.Now().Add(timeout)
+	schedule := c.newPollSchedule()
 
-	bakeStart := time.Now()
+	bakeStart := clk.Now()
 
 	checkDeployment := func() (bool, error) {
 		input := &appconfig.GetDeploymentInput{
@@ -308,6 +431,9 @@ func (c *Client) WaitForBakingComplete(
 
 		output, err := c.appConfig.GetDeployment(ctx, input)
 		if err != nil {
+			if ctxErr := parent.Err(); ctxErr != nil {
+				return false, waitCanceledError(deploymentNumber, ctxErr)
+			}
 			return false, wrapAWSError(err, "failed to get deployment status")
 		}
 
@@ -322,7 +448,7 @@ func (c *Client) WaitForBakingComplete(
 
 		case types.DeploymentStateBaking:
 			if onTick != nil {
-				onTick(time.Since(bakeStart), bakeDuration)
+				onTick(clk.Now().Sub(bakeStart), bakeDuration)
 			}
 			return false, nil
 
@@ -339,13 +465,14 @@ func (c *Client) WaitForBakingComplete(
 	}
 
 	for {
-		select {
-		case <-ctx.Done():
-			return fmt.Errorf("bake phase timed out after %v", timeout)
-		case <-ticker.C:
-			if complete, err := checkDeployment(); err != nil || complete {
-				return err
+		if !sleepUntilNextPoll(ctx, clk, schedule.next(), deadline) {
+			if ctxErr := parent.Err(); ctxErr != nil && clk.Now().Before(deadline) {
+				return waitCanceledError(deploymentNumber, ctxErr)
 			}
+			return fmt.Errorf("bake phase timed out after %v", timeout)
+		}
+		if complete, err := checkDeployment(); err != nil || complete {
+			return err
 		}
 	}
 }
@@ -365,6 +492,26 @@ func GetLatestDeployment(ctx context.Context, client *Client, applicationID, env
 	return getLatestDeploymentInternal(ctx, client, applicationID, environmentID, profileID, true)
 }
 
+// GetDeploymentByNumber retrieves a specific deployment of the
+// configuration profile. It fails when the deployment does not exist, and
+// with a *ProfileMismatchError when it belongs to another profile.
+func GetDeploymentByNumber(ctx context.Context, client *Client, applicationID, environmentID, profileID string, deploymentNumber int32) (*DeploymentInfo, error) {
+	details, err := GetDeploymentDetails(ctx, client, applicationID, environmentID, deploymentNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deployment #%d: %w", deploymentNumber, err)
+	}
+	if details.ConfigurationProfileID != profileID {
+		return nil, &ProfileMismatchError{DeploymentNumber: deploymentNumber}
+	}
+	return &DeploymentInfo{
+		DeploymentNumber:     details.DeploymentNumber,
+		ConfigurationVersion: details.ConfigurationVersion,
+		DeploymentStrategyID: details.DeploymentStrategyID,
+		State:                details.State,
+		Description:          details.Description,
+	}, nil
+}
+
 // GetLatestDeploymentIncludingRollback retrieves the latest deployment for the specified configuration profile
 // This function includes ROLLED_BACK deployments and returns the absolute latest deployment
 func GetLatestDeploymentIncludingRollback(ctx context.Context, client *Client, applicationID, environmentID, profileID string) (*DeploymentInfo, error) {
@@ -456,6 +603,12 @@ type DeploymentDetails struct {
 	PercentageComplete     float32
 	GrowthFactor           float32
 	FinalBakeTimeInMinutes int32
+	// Label is the run --label prefix of Description; like VersionTags it is
+	// filled in by status, which then strips it from Description
+	Label string
+	// VersionTags are the run --version-tag pairs embedded in the deployed
+	// version's description; filled in by status, not GetDeploymentDetails
+	VersionTags []VersionTag
 }
 
 // GetDeploymentDetails retrieves detailed information about a specific deployment
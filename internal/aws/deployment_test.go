@@ -3,13 +3,16 @@ package aws
 import (
 	"context"
 	"errors"
+	"slices"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/appconfig"
 	"github.com/aws/aws-sdk-go-v2/service/appconfig/types"
 	"github.com/koh-sh/apcdeploy/internal/aws/mock"
+	clocktest "github.com/koh-sh/apcdeploy/internal/clock/testing"
 )
 
 func TestCheckOngoingDeployment(t *testing.T) {
@@ -108,6 +111,86 @@ func TestCheckOngoingDeployment(t *testing.T) {
 	}
 }
 
+func TestWaitForNoOngoingDeployment(t *testing.T) {
+	t.Parallel()
+
+	deploying := []types.DeploymentSummary{{DeploymentNumber: 4, State: types.DeploymentStateDeploying}}
+	finished := []types.DeploymentSummary{{DeploymentNumber: 4, State: types.DeploymentStateComplete}}
+
+	tests := []struct {
+		name        string
+		busyPolls   int // polls that still report deployment #4 in progress
+		timeout     time.Duration
+		cancel      bool
+		listErr     error
+		wantWaits   int
+		wantErr     string
+		wantErrIs   error
+		wantNoError bool
+	}{
+		{name: "free immediately", busyPolls: 0, timeout: time.Second, wantNoError: true},
+		{name: "frees after two polls", busyPolls: 2, timeout: time.Second, wantWaits: 2, wantNoError: true},
+		{name: "times out", busyPolls: 1000, timeout: 30 * time.Millisecond, wantErr: "waiting for deployment #4 to finish"},
+		{name: "cancelled", busyPolls: 1000, timeout: time.Second, cancel: true, wantErrIs: context.Canceled},
+		{name: "list error", timeout: time.Second, listErr: errors.New("boom"), wantErr: "failed to list deployments"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			polls := 0
+			client := &Client{
+				PollingInterval: time.Millisecond,
+				appConfig: &mock.MockAppConfigClient{
+					ListDeploymentsFunc: func(ctx context.Context, params *appconfig.ListDeploymentsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListDeploymentsOutput, error) {
+						if tt.listErr != nil {
+							return nil, tt.listErr
+						}
+						polls++
+						if polls <= tt.busyPolls {
+							return &appconfig.ListDeploymentsOutput{Items: deploying}, nil
+						}
+						return &appconfig.ListDeploymentsOutput{Items: finished}, nil
+					},
+				},
+			}
+
+			waits := 0
+			err := client.WaitForNoOngoingDeployment(ctx, "app-123", "env-123", tt.timeout, func(ongoing *types.DeploymentSummary) {
+				waits++
+				if ongoing.DeploymentNumber != 4 {
+					t.Errorf("onWait got deployment #%d, want #4", ongoing.DeploymentNumber)
+				}
+				if tt.cancel {
+					cancel()
+				}
+			})
+
+			switch {
+			case tt.wantNoError:
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if waits != tt.wantWaits {
+					t.Errorf("onWait called %d times, want %d", waits, tt.wantWaits)
+				}
+			case tt.wantErrIs != nil:
+				if !errors.Is(err, tt.wantErrIs) {
+					t.Errorf("expected %v, got %v", tt.wantErrIs, err)
+				}
+			default:
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+			}
+		})
+	}
+}
+
 func TestCreateHostedConfigurationVersion(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -938,6 +1021,159 @@ func TestWaitForDeploymentPhase_StopsAtBaking(t *testing.T) {
 	}
 }
 
+// TestWaitCanceled checks that both wait loops return as soon as the
+// caller's context ends instead of sleeping out the poll interval, and that
+// the error says the deployment is still running.
+func TestWaitCanceled(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		state types.DeploymentState
+		wait  func(ctx context.Context, c *Client) error
+	}{
+		{
+			name:  "deploy phase",
+			state: types.DeploymentStateDeploying,
+			wait: func(ctx context.Context, c *Client) error {
+				return c.WaitForDeploymentPhase(ctx, "app-123", "env-123", 3, true, time.Hour, nil)
+			},
+		},
+		{
+			name:  "bake phase",
+			state: types.DeploymentStateBaking,
+			wait: func(ctx context.Context, c *Client) error {
+				return c.WaitForBakingComplete(ctx, "app-123", "env-123", 3, time.Hour, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := &Client{
+				appConfig: &mock.MockAppConfigClient{
+					GetDeploymentFunc: func(ctx context.Context, params *appconfig.GetDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.GetDeploymentOutput, error) {
+						return &appconfig.GetDeploymentOutput{DeploymentNumber: 3, State: tt.state}, nil
+					},
+				},
+				PollingInterval: time.Minute,
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+
+			start := time.Now()
+			err := tt.wait(ctx, client)
+			if elapsed := time.Since(start); elapsed > 5*time.Second {
+				t.Errorf("wait returned after %v, want it to stop on cancellation", elapsed)
+			}
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Fatalf("wait error = %v, want context.DeadlineExceeded", err)
+			}
+			if !strings.Contains(err.Error(), "deployment #3, which is still running in AWS") {
+				t.Errorf("wait error = %q, want it to say the deployment is still running", err)
+			}
+		})
+	}
+}
+
+func TestDeleteHostedConfigurationVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		mockFunc    func(ctx context.Context, params *appconfig.DeleteHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.DeleteHostedConfigurationVersionOutput, error)
+		wantErr     bool
+		errContains string
+	}{
+		{
+			name: "successful delete",
+			mockFunc: func(ctx context.Context, params *appconfig.DeleteHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.DeleteHostedConfigurationVersionOutput, error) {
+				if aws.ToString(params.ApplicationId) != "app-123" || aws.ToString(params.ConfigurationProfileId) != "profile-123" || aws.ToInt32(params.VersionNumber) != 7 {
+					t.Errorf("unexpected input: %+v", params)
+				}
+				return &appconfig.DeleteHostedConfigurationVersionOutput{}, nil
+			},
+		},
+		{
+			name: "API error",
+			mockFunc: func(ctx context.Context, params *appconfig.DeleteHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.DeleteHostedConfigurationVersionOutput, error) {
+				return nil, errors.New("API error")
+			},
+			wantErr:     true,
+			errContains: "failed to delete hosted configuration version",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := &Client{appConfig: &mock.MockAppConfigClient{DeleteHostedConfigurationVersionFunc: tt.mockFunc}}
+			err := client.DeleteHostedConfigurationVersion(context.Background(), "app-123", "profile-123", 7)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DeleteHostedConfigurationVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !strings.Contains(err.Error(), tt.errContains) {
+				t.Errorf("DeleteHostedConfigurationVersion() error = %v, should contain %v", err, tt.errContains)
+			}
+		})
+	}
+}
+
+func TestLatestDeploymentSummary(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		items      []types.DeploymentSummary
+		err        error
+		wantNumber int32
+		wantNil    bool
+		wantErr    bool
+	}{
+		{name: "newest deployment", items: []types.DeploymentSummary{{DeploymentNumber: 7}}, wantNumber: 7},
+		{name: "no deployments", wantNil: true},
+		{name: "API error", err: errors.New("API error"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := &Client{appConfig: &mock.MockAppConfigClient{
+				ListDeploymentsFunc: func(ctx context.Context, params *appconfig.ListDeploymentsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListDeploymentsOutput, error) {
+					if aws.ToInt32(params.MaxResults) != 1 {
+						t.Errorf("MaxResults = %d, want 1", aws.ToInt32(params.MaxResults))
+					}
+					if params.NextToken != nil {
+						t.Error("LatestDeploymentSummary should not page")
+					}
+					return &appconfig.ListDeploymentsOutput{Items: tt.items}, tt.err
+				},
+			}}
+			got, err := client.LatestDeploymentSummary(context.Background(), "app-123", "env-123")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LatestDeploymentSummary() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if tt.wantNil {
+				if got != nil {
+					t.Errorf("LatestDeploymentSummary() = %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil || got.DeploymentNumber != tt.wantNumber {
+				t.Errorf("LatestDeploymentSummary() = %+v, want deployment #%d", got, tt.wantNumber)
+			}
+		})
+	}
+}
+
 func TestStopDeployment(t *testing.T) {
 	t.Parallel()
 
@@ -1003,6 +1239,61 @@ func TestStopDeployment(t *testing.T) {
 	}
 }
 
+func TestGetStrategyTiming(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		mockClient := &mock.MockAppConfigClient{
+			GetDeploymentStrategyFunc: func(ctx context.Context, params *appconfig.GetDeploymentStrategyInput, optFns ...func(*appconfig.Options)) (*appconfig.GetDeploymentStrategyOutput, error) {
+				if aws.ToString(params.DeploymentStrategyId) != "strategy-123" {
+					t.Errorf("DeploymentStrategyId = %q, want strategy-123", aws.ToString(params.DeploymentStrategyId))
+				}
+				return &appconfig.GetDeploymentStrategyOutput{
+					DeploymentDurationInMinutes: 20,
+					FinalBakeTimeInMinutes:      45,
+					GrowthType:                  types.GrowthTypeLinear,
+					GrowthFactor:                aws.Float32(10),
+				}, nil
+			},
+		}
+		client := &Client{appConfig: mockClient}
+
+		got, err := client.GetStrategyTiming(context.Background(), "strategy-123")
+		if err != nil {
+			t.Fatalf("GetStrategyTiming() error = %v", err)
+		}
+		want := StrategyTiming{
+			DeploymentDuration: 20 * time.Minute,
+			FinalBakeTime:      45 * time.Minute,
+			GrowthType:         types.GrowthTypeLinear,
+			GrowthFactor:       10,
+		}
+		if *got != want {
+			t.Errorf("GetStrategyTiming() = %+v, want %+v", *got, want)
+		}
+		if got.Total() != 65*time.Minute {
+			t.Errorf("Total() = %v, want 1h5m", got.Total())
+		}
+	})
+
+	t.Run("API error", func(t *testing.T) {
+		t.Parallel()
+
+		mockClient := &mock.MockAppConfigClient{
+			GetDeploymentStrategyFunc: func(ctx context.Context, params *appconfig.GetDeploymentStrategyInput, optFns ...func(*appconfig.Options)) (*appconfig.GetDeploymentStrategyOutput, error) {
+				return nil, errors.New("API error")
+			},
+		}
+		client := &Client{appConfig: mockClient}
+
+		if _, err := client.GetStrategyTiming(context.Background(), "strategy-123"); err == nil || !strings.Contains(err.Error(), "failed to get deployment strategy") {
+			t.Errorf("GetStrategyTiming() error = %v, want failed to get deployment strategy", err)
+		}
+	})
+}
+
 func TestExtractRollbackReason(t *testing.T) {
 	t.Parallel()
 
@@ -1226,9 +1517,11 @@ func TestWaitForBakingComplete_TickElapsed(t *testing.T) {
 		},
 	}
 
+	clk := clocktest.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
 	client := &Client{
 		appConfig:       mockClient,
-		PollingInterval: 30 * time.Millisecond,
+		PollingInterval: 5 * time.Second,
+		Clock:           clk,
 	}
 
 	type tickRecord struct {
@@ -1241,7 +1534,7 @@ func TestWaitForBakingComplete_TickElapsed(t *testing.T) {
 		"app-123",
 		"env-123",
 		1,
-		2*time.Second,
+		time.Hour,
 		func(elapsed, total time.Duration) {
 			ticks = append(ticks, tickRecord{elapsed: elapsed, total: total})
 		},
@@ -1249,21 +1542,15 @@ func TestWaitForBakingComplete_TickElapsed(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if len(ticks) < 3 {
-		t.Fatalf("expected at least 3 ticks, got %d", len(ticks))
-	}
+	// Elapsed follows the fake clock; the COMPLETE tick reports the full
+	// bake duration.
 	wantTotal := 60 * time.Minute
-	for i, tk := range ticks {
-		if tk.total != wantTotal {
-			t.Errorf("tick %d total = %v, want %v", i, tk.total, wantTotal)
-		}
-	}
-	for i := 1; i < len(ticks); i++ {
-		if ticks[i].elapsed < ticks[i-1].elapsed {
-			t.Errorf("tick %d elapsed = %v not monotonic with previous %v", i, ticks[i].elapsed, ticks[i-1].elapsed)
-		}
+	want := []tickRecord{
+		{elapsed: 0, total: wantTotal},
+		{elapsed: 5 * time.Second, total: wantTotal},
+		{elapsed: wantTotal, total: wantTotal},
 	}
-	if ticks[len(ticks)-1].elapsed != wantTotal {
-		t.Errorf("final tick elapsed = %v, want %v (full bake duration)", ticks[len(ticks)-1].elapsed, wantTotal)
+	if !slices.Equal(ticks, want) {
+		t.Errorf("ticks = %v, want %v", ticks, want)
 	}
 }
@@ -4,11 +4,59 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"unicode"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/appconfig/types"
 	"github.com/aws/smithy-go"
+	"github.com/koh-sh/apcdeploy/internal/config"
 )
 
+// ErrAccountMismatch is returned when the caller's account differs from the
+// account_id set in the config file.
+var ErrAccountMismatch = errors.New("AWS account mismatch")
+
+// ErrAlarmFiring is returned when a guard alarm is in the ALARM state.
+var ErrAlarmFiring = errors.New("guard alarm is firing")
+
+// ErrNoRegion is returned by NewClient when no region is configured at any
+// tier of config.ResolveRegion nor in the shared config profile.
+var ErrNoRegion = errors.New("no AWS region configured: pass --region, set region in the config file, or set AWS_REGION or AWS_DEFAULT_REGION")
+
+// Resolver errors. They are wrapped with the name that failed to resolve,
+// e.g. "application not found: my-app", so callers can branch with
+// errors.Is while the message stays readable.
+var (
+	ErrApplicationNotFound       = errors.New("application not found")
+	ErrMultipleApplicationsFound = errors.New("multiple applications found")
+	ErrProfileNotFound           = errors.New("configuration profile not found")
+	ErrMultipleProfilesFound     = errors.New("multiple configuration profiles found")
+	ErrEnvironmentNotFound       = errors.New("environment not found")
+	ErrMultipleEnvironmentsFound = errors.New("multiple environments found")
+	ErrStrategyNotFound          = errors.New("deployment strategy not found")
+	ErrMultipleStrategiesFound   = errors.New("multiple deployment strategies found")
+)
+
+// ErrDeploymentInProgress is returned when another deployment is still
+// DEPLOYING or BAKING on the target environment.
+var ErrDeploymentInProgress = errors.New("deployment already in progress")
+
+// ProfileMismatchError is returned when a deployment looked up by number
+// belongs to a different configuration profile than the resolved one.
+type ProfileMismatchError struct {
+	DeploymentNumber int32
+	// Profile is the expected profile name; empty renders "this
+	// configuration profile"
+	Profile string
+}
+
+func (e *ProfileMismatchError) Error() string {
+	if e.Profile == "" {
+		return fmt.Sprintf("deployment #%d is not for this configuration profile", e.DeploymentNumber)
+	}
+	return fmt.Sprintf("deployment #%d is not for configuration profile %s", e.DeploymentNumber, e.Profile)
+}
+
 // wrapAWSError wraps an AWS API error with additional context
 func wrapAWSError(err error, operation string) error {
 	if err == nil {
@@ -38,6 +86,106 @@ func IsValidationError(err error) bool {
 	return false
 }
 
+// IsAccessDenied reports whether err is an IAM authorization failure
+// (AccessDeniedException from AppConfig, AccessDenied from STS-style APIs).
+func IsAccessDenied(err error) bool {
+	var apiErr smithy.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	switch apiErr.ErrorCode() {
+	case "AccessDeniedException", "AccessDenied":
+		return true
+	}
+	return false
+}
+
+// IsResourceNotFound reports whether err is AppConfig's
+// ResourceNotFoundException, typically because a resource the config file
+// names was deleted after it was resolved.
+func IsResourceNotFound(err error) bool {
+	var notFoundErr *types.ResourceNotFoundException
+	if errors.As(err, &notFoundErr) {
+		return true
+	}
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.ErrorCode() == "ResourceNotFoundException"
+	}
+	return false
+}
+
+// ResourceGoneError is returned in place of a ResourceNotFoundException for
+// an application, configuration profile, environment or deployment strategy
+// the config file names. It unwraps to the original AWS error.
+type ResourceGoneError struct {
+	// Kind is "application", "configuration profile", "environment" or
+	// "deployment strategy"
+	Kind string
+	// Name is the resource name from the config file
+	Name string
+	// Application is the owning application name; empty for applications
+	// and deployment strategies
+	Application string
+	Err         error
+}
+
+func (e *ResourceGoneError) Error() string {
+	msg := fmt.Sprintf("%s '%s' no longer exists", e.Kind, e.Name)
+	if e.Application != "" {
+		msg += fmt.Sprintf(" in application '%s'", e.Application)
+	}
+	return msg + "; run 'apcdeploy init' to regenerate config"
+}
+
+func (e *ResourceGoneError) Unwrap() error { return e.Err }
+
+// notFoundKinds maps the ResourceName of a ResourceNotFoundException,
+// lowercased without separators or an "id" suffix, to the resource kind
+// ExplainNotFound reports. Deployments and hosted versions map to no config
+// entry, so they are absent and reported as is.
+var notFoundKinds = map[string]string{
+	"application":          "application",
+	"configurationprofile": "configuration profile",
+	"environment":          "environment",
+	"deploymentstrategy":   "deployment strategy",
+}
+
+// ExplainNotFound translates a *types.ResourceNotFoundException for a
+// resource cfg names into a *ResourceGoneError, using the exception's
+// ResourceName (e.g. "Environment" or "ConfigurationProfileId") to tell
+// which one is gone. Other errors, and not-found errors for deployments,
+// versions or without a ResourceName, are returned unchanged.
+func ExplainNotFound(err error, cfg *config.Config) error {
+	var notFoundErr *types.ResourceNotFoundException
+	if cfg == nil || !errors.As(err, &notFoundErr) {
+		return err
+	}
+	resource := strings.Map(func(r rune) rune {
+		if !unicode.IsLetter(r) {
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, aws.ToString(notFoundErr.ResourceName))
+	kind := notFoundKinds[strings.TrimSuffix(resource, "id")]
+
+	gone := &ResourceGoneError{Kind: kind, Err: err}
+	switch kind {
+	case "application":
+		gone.Name = cfg.Application
+	case "configuration profile":
+		gone.Name, gone.Application = cfg.ConfigurationProfile, cfg.Application
+	case "environment":
+		gone.Name, gone.Application = cfg.Environment, cfg.Application
+	case "deployment strategy":
+		gone.Name = cfg.DeploymentStrategy
+	}
+	if gone.Name == "" {
+		return err
+	}
+	return gone
+}
+
 // FormatValidationError formats a validation error with detailed information
 func FormatValidationError(err error) string {
 	var sb strings.Builder
@@ -2,11 +2,14 @@ package aws
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 	"testing"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/appconfig/types"
 	"github.com/aws/smithy-go"
+	"github.com/koh-sh/apcdeploy/internal/config"
 )
 
 func TestWrapAWSError(t *testing.T) {
@@ -111,6 +114,111 @@ func TestIsValidationError(t *testing.T) {
 	}
 }
 
+func TestIsAccessDenied(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "appconfig access denied",
+			err:  fmt.Errorf("list applications: %w", &smithy.GenericAPIError{Code: "AccessDeniedException"}),
+			want: true,
+		},
+		{
+			name: "sts style access denied",
+			err:  &smithy.GenericAPIError{Code: "AccessDenied"},
+			want: true,
+		},
+		{
+			name: "other API error",
+			err:  &smithy.GenericAPIError{Code: "ResourceNotFoundException"},
+			want: false,
+		},
+		{
+			name: "plain error",
+			err:  errors.New("AccessDeniedException"),
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsAccessDenied(tt.err); got != tt.want {
+				t.Errorf("IsAccessDenied() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExplainNotFound(t *testing.T) {
+	cfg := &config.Config{
+		Application:          "test-app",
+		ConfigurationProfile: "test-profile",
+		Environment:          "test-env",
+		DeploymentStrategy:   "AppConfig.AllAtOnce",
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "deleted environment",
+			err:  fmt.Errorf("list deployments: %w", &types.ResourceNotFoundException{Message: aws.String("Environment env-123 not found in application app-123"), ResourceName: aws.String("Environment")}),
+			want: "environment 'test-env' no longer exists in application 'test-app'; run 'apcdeploy init' to regenerate config",
+		},
+		{
+			name: "deleted configuration profile named by its ID field",
+			err:  &types.ResourceNotFoundException{Message: aws.String("Configuration profile profile-123 does not exist"), ResourceName: aws.String("ConfigurationProfileId")},
+			want: "configuration profile 'test-profile' no longer exists in application 'test-app'; run 'apcdeploy init' to regenerate config",
+		},
+		{
+			name: "deleted application",
+			err:  &types.ResourceNotFoundException{Message: aws.String("Application app-123 not found"), ResourceName: aws.String("Application")},
+			want: "application 'test-app' no longer exists; run 'apcdeploy init' to regenerate config",
+		},
+		{
+			name: "deleted deployment strategy",
+			err:  &types.ResourceNotFoundException{Message: aws.String("Deployment strategy strategy-123 not found"), ResourceName: aws.String("DeploymentStrategy")},
+			want: "deployment strategy 'AppConfig.AllAtOnce' no longer exists; run 'apcdeploy init' to regenerate config",
+		},
+		{
+			name: "missing deployment is reported as is",
+			err:  &types.ResourceNotFoundException{Message: aws.String("Deployment 99 not found in environment env-123"), ResourceName: aws.String("Deployment")},
+			want: "ResourceNotFoundException: Deployment 99 not found in environment env-123",
+		},
+		{
+			name: "message is not used without a resource name",
+			err:  &types.ResourceNotFoundException{Message: aws.String("Application app-123 not found")},
+			want: "ResourceNotFoundException: Application app-123 not found",
+		},
+		{
+			name: "untyped not-found errors are unchanged",
+			err:  &smithy.GenericAPIError{Code: "ResourceNotFoundException", Message: "Configuration profile profile-123 does not exist"},
+			want: "api error ResourceNotFoundException: Configuration profile profile-123 does not exist",
+		},
+		{
+			name: "other errors are unchanged",
+			err:  errors.New("throttled"),
+			want: "throttled",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ExplainNotFound(tt.err, cfg)
+			if got.Error() != tt.want {
+				t.Errorf("ExplainNotFound() = %q, want %q", got.Error(), tt.want)
+			}
+			if !errors.Is(got, tt.err) {
+				t.Error("ExplainNotFound() must wrap the original error")
+			}
+		})
+	}
+}
+
 func TestFormatValidationError(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -7,6 +7,8 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/appconfig"
 	"github.com/aws/aws-sdk-go-v2/service/appconfig/types"
 	"github.com/aws/aws-sdk-go-v2/service/appconfigdata"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
 )
 
 // AppConfigSDKAPI defines the minimal AWS SDK interface needed for Client's internal operations.
@@ -30,13 +32,21 @@ type AppConfigSDKAPI interface {
 	GetConfigurationProfile(ctx context.Context, params *appconfig.GetConfigurationProfileInput, optFns ...func(*appconfig.Options)) (*appconfig.GetConfigurationProfileOutput, error)
 	GetHostedConfigurationVersion(ctx context.Context, params *appconfig.GetHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.GetHostedConfigurationVersionOutput, error)
 	GetDeployment(ctx context.Context, params *appconfig.GetDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.GetDeploymentOutput, error)
+	GetDeploymentStrategy(ctx context.Context, params *appconfig.GetDeploymentStrategyInput, optFns ...func(*appconfig.Options)) (*appconfig.GetDeploymentStrategyOutput, error)
 
-	// Create/Start methods (used by convenience wrappers in deployment.go)
+	// Tag methods (used by ListEnvironmentTags in tags.go)
+	ListTagsForResource(ctx context.Context, params *appconfig.ListTagsForResourceInput, optFns ...func(*appconfig.Options)) (*appconfig.ListTagsForResourceOutput, error)
+
+	// Create/Start methods (used by convenience wrappers in deployment.go and profile.go)
+	CreateConfigurationProfile(ctx context.Context, params *appconfig.CreateConfigurationProfileInput, optFns ...func(*appconfig.Options)) (*appconfig.CreateConfigurationProfileOutput, error)
 	CreateHostedConfigurationVersion(ctx context.Context, params *appconfig.CreateHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.CreateHostedConfigurationVersionOutput, error)
 	StartDeployment(ctx context.Context, params *appconfig.StartDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.StartDeploymentOutput, error)
 
 	// Stop methods (used by convenience wrappers in deployment.go)
 	StopDeployment(ctx context.Context, params *appconfig.StopDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.StopDeploymentOutput, error)
+
+	// Delete methods (used by convenience wrappers in deployment.go)
+	DeleteHostedConfigurationVersion(ctx context.Context, params *appconfig.DeleteHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.DeleteHostedConfigurationVersionOutput, error)
 }
 
 // AppConfigAPI defines the interface for external code that needs AppConfig operations.
@@ -44,10 +54,10 @@ type AppConfigSDKAPI interface {
 //   - Pagination-aware ListAll* methods (automatically handle pagination)
 //   - Raw SDK Get methods (for retrieving individual resources)
 //
-// Notably, this does NOT include raw SDK List methods or Create/Start methods. Raw List
-// is internal to the pagination wrappers in client_list_paginated.go; Create/Start are
-// exposed through convenience wrapper methods on *Client (see deployment.go) with
-// simplified signatures. External code should use *Client directly when deploying
+// Notably, this does NOT include raw SDK List methods or Create/Start/Delete methods. Raw
+// List is internal to the pagination wrappers in client_list_paginated.go; Create/Start/Delete
+// (including DeleteHostedConfigurationVersion) are exposed through convenience wrapper
+// methods on *Client (see deployment.go) with simplified signatures. External code should use *Client directly when deploying
 // configurations.
 type AppConfigAPI interface {
 	// Pagination-aware List methods - automatically handle pagination to retrieve all resources
@@ -62,6 +72,7 @@ type AppConfigAPI interface {
 	GetConfigurationProfile(ctx context.Context, params *appconfig.GetConfigurationProfileInput, optFns ...func(*appconfig.Options)) (*appconfig.GetConfigurationProfileOutput, error)
 	GetHostedConfigurationVersion(ctx context.Context, params *appconfig.GetHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.GetHostedConfigurationVersionOutput, error)
 	GetDeployment(ctx context.Context, params *appconfig.GetDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.GetDeploymentOutput, error)
+	GetDeploymentStrategy(ctx context.Context, params *appconfig.GetDeploymentStrategyInput, optFns ...func(*appconfig.Options)) (*appconfig.GetDeploymentStrategyOutput, error)
 }
 
 // AppConfigDataAPI defines the interface for AppConfigData operations.
@@ -75,3 +86,15 @@ type AppConfigDataAPI interface {
 type AccountAPI interface {
 	ListRegions(ctx context.Context, params *account.ListRegionsInput, optFns ...func(*account.Options)) (*account.ListRegionsOutput, error)
 }
+
+// CloudWatchAPI defines the CloudWatch operations used by the alarm guard
+// (run --guard-alarm).
+type CloudWatchAPI interface {
+	DescribeAlarms(ctx context.Context, params *cloudwatch.DescribeAlarmsInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.DescribeAlarmsOutput, error)
+}
+
+// STSAPI defines the interface for AWS STS operations. It is used to look up
+// the caller's account ID when building resource ARNs.
+type STSAPI interface {
+	GetCallerIdentity(ctx context.Context, params *sts.GetCallerIdentityInput, optFns ...func(*sts.Options)) (*sts.GetCallerIdentityOutput, error)
+}
@@ -0,0 +1,70 @@
+package aws
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DescriptionMaxLength is the AppConfig limit, in Unicode characters, on the
+// Description of a hosted configuration version or deployment.
+const DescriptionMaxLength = 1024
+
+// A deployment label (run --label) is stored as a "[label] " prefix of the
+// deployment description, since StartDeployment takes no free-form tags:
+//
+//	[release-2026.10] Deployed by apcdeploy
+const (
+	labelOpen  = "["
+	labelClose = "]"
+)
+
+// ValidateDeploymentLabel rejects labels that cannot be read back from the
+// description prefix: ']' would end the label early and line breaks would
+// split it.
+func ValidateDeploymentLabel(label string) error {
+	if strings.ContainsAny(label, "]\r\n") {
+		return fmt.Errorf("invalid label %q: cannot contain ']' or line breaks", label)
+	}
+	return nil
+}
+
+// ApplyDeploymentLabel prefixes description with "[label]". A label that is
+// empty after trimming leaves description unchanged. The result is cut to
+// DescriptionMaxLength characters, shortening the description first and the
+// label only when it does not fit on its own.
+func ApplyDeploymentLabel(description, label string) string {
+	label = strings.TrimSpace(label)
+	if label == "" {
+		return description
+	}
+	if maxLabel := DescriptionMaxLength - len(labelOpen+labelClose); len([]rune(label)) > maxLabel {
+		label = string([]rune(label)[:maxLabel])
+	}
+	prefix := labelOpen + label + labelClose
+	if description == "" {
+		return prefix
+	}
+	labeled := []rune(prefix + " " + description)
+	if len(labeled) > DescriptionMaxLength {
+		labeled = labeled[:DescriptionMaxLength]
+	}
+	return strings.TrimRight(string(labeled), " ")
+}
+
+// SplitDeploymentLabel separates the label added by ApplyDeploymentLabel
+// from the rest of a deployment description. Descriptions without a label
+// prefix are returned whole with an empty label.
+func SplitDeploymentLabel(description string) (label, rest string) {
+	if !strings.HasPrefix(description, labelOpen) {
+		return "", description
+	}
+	end := strings.Index(description, labelClose)
+	if end < 0 {
+		return "", description
+	}
+	label = description[len(labelOpen):end]
+	if strings.TrimSpace(label) == "" {
+		return "", description
+	}
+	return label, strings.TrimPrefix(description[end+len(labelClose):], " ")
+}
@@ -0,0 +1,81 @@
+package aws
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestApplyDeploymentLabel(t *testing.T) {
+	t.Parallel()
+
+	long := strings.Repeat("あ", DescriptionMaxLength)
+
+	tests := []struct {
+		name        string
+		description string
+		label       string
+		want        string
+	}{
+		{name: "label prefixes the description", description: "Deployed by apcdeploy", label: "release-1", want: "[release-1] Deployed by apcdeploy"},
+		{name: "empty label is ignored", description: "Deployed by apcdeploy", label: "", want: "Deployed by apcdeploy"},
+		{name: "blank label is ignored", description: "Deployed by apcdeploy", label: "  ", want: "Deployed by apcdeploy"},
+		{name: "label is trimmed", description: "", label: " hotfix ", want: "[hotfix]"},
+		{name: "description is cut to the limit", description: long, label: "v1", want: "[v1] " + long[:len("あ")*(DescriptionMaxLength-5)]},
+		{name: "label alone is cut to the limit", description: "x", label: long, want: "[" + long[:len("あ")*(DescriptionMaxLength-2)] + "]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := ApplyDeploymentLabel(tt.description, tt.label)
+			if got != tt.want {
+				t.Errorf("ApplyDeploymentLabel() = %q, want %q", got, tt.want)
+			}
+			if n := utf8.RuneCountInString(got); n > DescriptionMaxLength {
+				t.Errorf("ApplyDeploymentLabel() is %d characters, limit is %d", n, DescriptionMaxLength)
+			}
+		})
+	}
+}
+
+func TestSplitDeploymentLabel(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		description string
+		wantLabel   string
+		wantRest    string
+	}{
+		{description: "[release-1] Deployed by apcdeploy", wantLabel: "release-1", wantRest: "Deployed by apcdeploy"},
+		{description: "[hotfix]", wantLabel: "hotfix", wantRest: ""},
+		{description: "Deployed by apcdeploy", wantRest: "Deployed by apcdeploy"},
+		{description: "[unterminated", wantRest: "[unterminated"},
+		{description: "[] empty", wantRest: "[] empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			label, rest := SplitDeploymentLabel(tt.description)
+			if label != tt.wantLabel || rest != tt.wantRest {
+				t.Errorf("SplitDeploymentLabel() = (%q, %q), want (%q, %q)", label, rest, tt.wantLabel, tt.wantRest)
+			}
+		})
+	}
+}
+
+func TestValidateDeploymentLabel(t *testing.T) {
+	t.Parallel()
+
+	for _, label := range []string{"release-1", "2026-10-16 rollout", ""} {
+		if err := ValidateDeploymentLabel(label); err != nil {
+			t.Errorf("ValidateDeploymentLabel(%q) error = %v", label, err)
+		}
+	}
+	for _, label := range []string{"a]b", "line\nbreak"} {
+		if err := ValidateDeploymentLabel(label); err == nil {
+			t.Errorf("ValidateDeploymentLabel(%q) = nil, want error", label)
+		}
+	}
+}
@@ -23,8 +23,13 @@ type MockAppConfigClient struct {
 	GetConfigurationProfileFunc       func(ctx context.Context, params *appconfig.GetConfigurationProfileInput, optFns ...func(*appconfig.Options)) (*appconfig.GetConfigurationProfileOutput, error)
 	GetHostedConfigurationVersionFunc func(ctx context.Context, params *appconfig.GetHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.GetHostedConfigurationVersionOutput, error)
 	GetDeploymentFunc                 func(ctx context.Context, params *appconfig.GetDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.GetDeploymentOutput, error)
+	GetDeploymentStrategyFunc         func(ctx context.Context, params *appconfig.GetDeploymentStrategyInput, optFns ...func(*appconfig.Options)) (*appconfig.GetDeploymentStrategyOutput, error)
+
+	// Tag methods
+	ListTagsForResourceFunc func(ctx context.Context, params *appconfig.ListTagsForResourceInput, optFns ...func(*appconfig.Options)) (*appconfig.ListTagsForResourceOutput, error)
 
 	// Create methods
+	CreateConfigurationProfileFunc       func(ctx context.Context, params *appconfig.CreateConfigurationProfileInput, optFns ...func(*appconfig.Options)) (*appconfig.CreateConfigurationProfileOutput, error)
 	CreateHostedConfigurationVersionFunc func(ctx context.Context, params *appconfig.CreateHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.CreateHostedConfigurationVersionOutput, error)
 
 	// Start methods
@@ -33,6 +38,9 @@ type MockAppConfigClient struct {
 	// Stop methods
 	StopDeploymentFunc func(ctx context.Context, params *appconfig.StopDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.StopDeploymentOutput, error)
 
+	// Delete methods
+	DeleteHostedConfigurationVersionFunc func(ctx context.Context, params *appconfig.DeleteHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.DeleteHostedConfigurationVersionOutput, error)
+
 	// Pagination-aware List methods
 	ListAllApplicationsFunc                func(ctx context.Context) ([]types.Application, error)
 	ListAllConfigurationProfilesFunc       func(ctx context.Context, appID string) ([]types.ConfigurationProfileSummary, error)
@@ -82,8 +90,22 @@ func (m *MockAppConfigClient) GetDeployment(ctx context.Context, params *appconf
 	return m.GetDeploymentFunc(ctx, params, optFns...)
 }
 
+func (m *MockAppConfigClient) GetDeploymentStrategy(ctx context.Context, params *appconfig.GetDeploymentStrategyInput, optFns ...func(*appconfig.Options)) (*appconfig.GetDeploymentStrategyOutput, error) {
+	return m.GetDeploymentStrategyFunc(ctx, params, optFns...)
+}
+
+// Tag methods
+
+func (m *MockAppConfigClient) ListTagsForResource(ctx context.Context, params *appconfig.ListTagsForResourceInput, optFns ...func(*appconfig.Options)) (*appconfig.ListTagsForResourceOutput, error) {
+	return m.ListTagsForResourceFunc(ctx, params, optFns...)
+}
+
 // Create methods
 
+func (m *MockAppConfigClient) CreateConfigurationProfile(ctx context.Context, params *appconfig.CreateConfigurationProfileInput, optFns ...func(*appconfig.Options)) (*appconfig.CreateConfigurationProfileOutput, error) {
+	return m.CreateConfigurationProfileFunc(ctx, params, optFns...)
+}
+
 func (m *MockAppConfigClient) CreateHostedConfigurationVersion(ctx context.Context, params *appconfig.CreateHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.CreateHostedConfigurationVersionOutput, error) {
 	return m.CreateHostedConfigurationVersionFunc(ctx, params, optFns...)
 }
@@ -100,6 +122,12 @@ func (m *MockAppConfigClient) StopDeployment(ctx context.Context, params *appcon
 	return m.StopDeploymentFunc(ctx, params, optFns...)
 }
 
+// Delete methods
+
+func (m *MockAppConfigClient) DeleteHostedConfigurationVersion(ctx context.Context, params *appconfig.DeleteHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.DeleteHostedConfigurationVersionOutput, error) {
+	return m.DeleteHostedConfigurationVersionFunc(ctx, params, optFns...)
+}
+
 // Pagination-aware List methods
 
 func (m *MockAppConfigClient) ListAllApplications(ctx context.Context) ([]types.Application, error) {
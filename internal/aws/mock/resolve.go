@@ -0,0 +1,53 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig/types"
+)
+
+// NewResolvingClient returns a MockAppConfigClient whose List and Get stubs
+// resolve the resources most executor tests deploy to:
+//   - application "test-app" (app-123)
+//   - configuration profile "test-profile" (profile-123) of profileType
+//   - environment "test-env" (env-123)
+//   - deployment strategy "AppConfig.AllAtOnce" (strategy-123)
+//
+// Tests set the remaining Func fields, or replace these, for the behavior
+// under test.
+func NewResolvingClient(profileType string) *MockAppConfigClient {
+	return &MockAppConfigClient{
+		ListApplicationsFunc: func(ctx context.Context, params *appconfig.ListApplicationsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListApplicationsOutput, error) {
+			return &appconfig.ListApplicationsOutput{
+				Items: []types.Application{{Id: aws.String("app-123"), Name: aws.String("test-app")}},
+			}, nil
+		},
+		ListConfigurationProfilesFunc: func(ctx context.Context, params *appconfig.ListConfigurationProfilesInput, optFns ...func(*appconfig.Options)) (*appconfig.ListConfigurationProfilesOutput, error) {
+			return &appconfig.ListConfigurationProfilesOutput{
+				Items: []types.ConfigurationProfileSummary{{Id: aws.String("profile-123"), Name: aws.String("test-profile"), Type: aws.String(profileType)}},
+			}, nil
+		},
+		GetConfigurationProfileFunc: func(ctx context.Context, params *appconfig.GetConfigurationProfileInput, optFns ...func(*appconfig.Options)) (*appconfig.GetConfigurationProfileOutput, error) {
+			return &appconfig.GetConfigurationProfileOutput{Id: aws.String("profile-123"), Name: aws.String("test-profile"), Type: aws.String(profileType)}, nil
+		},
+		ListEnvironmentsFunc: func(ctx context.Context, params *appconfig.ListEnvironmentsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListEnvironmentsOutput, error) {
+			return &appconfig.ListEnvironmentsOutput{
+				Items: []types.Environment{{Id: aws.String("env-123"), Name: aws.String("test-env")}},
+			}, nil
+		},
+		ListDeploymentStrategiesFunc: func(ctx context.Context, params *appconfig.ListDeploymentStrategiesInput, optFns ...func(*appconfig.Options)) (*appconfig.ListDeploymentStrategiesOutput, error) {
+			return &appconfig.ListDeploymentStrategiesOutput{
+				Items: []types.DeploymentStrategy{{
+					Id:                          aws.String("strategy-123"),
+					Name:                        aws.String("AppConfig.AllAtOnce"),
+					DeploymentDurationInMinutes: 0,
+					GrowthType:                  types.GrowthTypeLinear,
+					GrowthFactor:                aws.Float32(100),
+					FinalBakeTimeInMinutes:      10,
+				}},
+			}, nil
+		},
+	}
+}
@@ -0,0 +1,82 @@
+package aws
+
+import (
+	"context"
+	"time"
+
+	"github.com/koh-sh/apcdeploy/internal/clock"
+)
+
+// pollSchedule yields the delay before each successive deployment status
+// poll. Implementations are not safe for concurrent use; each wait loop
+// builds its own.
+type pollSchedule interface {
+	next() time.Duration
+}
+
+// fixedSchedule polls at a constant interval.
+type fixedSchedule struct {
+	interval time.Duration
+}
+
+func (s *fixedSchedule) next() time.Duration { return s.interval }
+
+// backoffSchedule starts at the initial interval and doubles after every
+// poll until it reaches maxInterval. It keeps early polls responsive while
+// cutting GetDeployment calls during multi-hour rollouts and bakes.
+type backoffSchedule struct {
+	current     time.Duration
+	maxInterval time.Duration
+}
+
+func (s *backoffSchedule) next() time.Duration {
+	d := s.current
+	s.current = min(s.current*2, s.maxInterval)
+	return d
+}
+
+// newPollSchedule returns the schedule configured on the client: a fixed
+// PollingInterval by default, or an exponential backoff from
+// PollingInterval up to MaxPollingInterval when PollBackoff is set.
+func (c *Client) newPollSchedule() pollSchedule {
+	interval := c.PollingInterval
+	if interval == 0 {
+		interval = 5 * time.Second
+	}
+	if !c.PollBackoff {
+		return &fixedSchedule{interval: interval}
+	}
+	maxInterval := c.MaxPollingInterval
+	if maxInterval == 0 {
+		maxInterval = defaultMaxPollingInterval
+	}
+	return &backoffSchedule{current: interval, maxInterval: max(interval, maxInterval)}
+}
+
+// defaultMaxPollingInterval caps the backoff schedule when the client does
+// not set MaxPollingInterval.
+const defaultMaxPollingInterval = time.Minute
+
+// clock returns the client's Clock, defaulting to the real one.
+func (c *Client) clock() clock.Clock {
+	if c.Clock == nil {
+		return clock.Real
+	}
+	return c.Clock
+}
+
+// sleepUntilNextPoll waits d (capped at the time left before deadline) on
+// clk. It reports false when ctx is done or the deadline has passed, in
+// which case the caller should stop polling.
+func sleepUntilNextPoll(ctx context.Context, clk clock.Clock, d time.Duration, deadline time.Time) bool {
+	remaining := deadline.Sub(clk.Now())
+	if remaining <= 0 || ctx.Err() != nil {
+		return false
+	}
+	select {
+	case <-ctx.Done():
+		return false
+	case <-clk.After(min(d, remaining)):
+	}
+	return clk.Now().Before(deadline)
+}
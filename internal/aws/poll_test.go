@@ -0,0 +1,139 @@
+package aws
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/appconfig"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig/types"
+	"github.com/koh-sh/apcdeploy/internal/aws/mock"
+	clocktest "github.com/koh-sh/apcdeploy/internal/clock/testing"
+)
+
+func TestNewPollSchedule(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		client *Client
+		want   []time.Duration
+	}{
+		{
+			name:   "fixed default interval",
+			client: &Client{},
+			want:   []time.Duration{5 * time.Second, 5 * time.Second, 5 * time.Second},
+		},
+		{
+			name:   "fixed custom interval",
+			client: &Client{PollingInterval: 2 * time.Second},
+			want:   []time.Duration{2 * time.Second, 2 * time.Second},
+		},
+		{
+			name:   "backoff doubles up to default cap",
+			client: &Client{PollBackoff: true},
+			want: []time.Duration{
+				5 * time.Second, 10 * time.Second, 20 * time.Second, 40 * time.Second,
+				time.Minute, time.Minute,
+			},
+		},
+		{
+			name:   "backoff with custom cap",
+			client: &Client{PollBackoff: true, PollingInterval: time.Second, MaxPollingInterval: 3 * time.Second},
+			want:   []time.Duration{time.Second, 2 * time.Second, 3 * time.Second, 3 * time.Second},
+		},
+		{
+			name:   "cap below initial interval keeps initial",
+			client: &Client{PollBackoff: true, PollingInterval: 10 * time.Second, MaxPollingInterval: time.Second},
+			want:   []time.Duration{10 * time.Second, 10 * time.Second},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			schedule := tt.client.newPollSchedule()
+			for i, want := range tt.want {
+				if got := schedule.next(); got != want {
+					t.Errorf("poll %d: next() = %v, want %v", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestWaitForDeploymentPhaseWithBackoff(t *testing.T) {
+	t.Parallel()
+
+	// Complete on the 5th poll. The fake clock records every wait, so the
+	// backoff schedule can be asserted exactly without real sleeps.
+	polls := 0
+	mockClient := &mock.MockAppConfigClient{
+		GetDeploymentFunc: func(ctx context.Context, params *appconfig.GetDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.GetDeploymentOutput, error) {
+			polls++
+			state := types.DeploymentStateDeploying
+			if polls >= 5 {
+				state = types.DeploymentStateComplete
+			}
+			return &appconfig.GetDeploymentOutput{DeploymentNumber: 1, State: state}, nil
+		},
+	}
+
+	clk := clocktest.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	client := &Client{
+		appConfig:          mockClient,
+		PollingInterval:    5 * time.Second,
+		PollBackoff:        true,
+		MaxPollingInterval: 40 * time.Second,
+		Clock:              clk,
+	}
+
+	if err := client.WaitForDeploymentPhase(context.Background(), "app-123", "env-123", 1, true, time.Hour, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if polls != 5 {
+		t.Fatalf("expected 5 polls, got %d", polls)
+	}
+	want := []time.Duration{5 * time.Second, 10 * time.Second, 20 * time.Second, 40 * time.Second}
+	if got := clk.Waits(); !slices.Equal(got, want) {
+		t.Errorf("waits = %v, want %v", got, want)
+	}
+}
+
+func TestWaitForDeploymentPhaseTimeoutFakeClock(t *testing.T) {
+	t.Parallel()
+
+	polls := 0
+	mockClient := &mock.MockAppConfigClient{
+		GetDeploymentFunc: func(ctx context.Context, params *appconfig.GetDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.GetDeploymentOutput, error) {
+			polls++
+			return &appconfig.GetDeploymentOutput{DeploymentNumber: 1, State: types.DeploymentStateDeploying}, nil
+		},
+	}
+
+	clk := clocktest.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	client := &Client{
+		appConfig:       mockClient,
+		PollingInterval: 5 * time.Second,
+		Clock:           clk,
+	}
+
+	err := client.WaitForDeploymentPhase(context.Background(), "app-123", "env-123", 1, false, 30*time.Second, nil)
+	if err == nil || !strings.Contains(err.Error(), "deployment timed out after 30s") {
+		t.Fatalf("error = %v, want timeout", err)
+	}
+	// The immediate check plus one poll per 5s wait before the 30s deadline.
+	if polls != 6 {
+		t.Errorf("polls = %d, want 6", polls)
+	}
+	var total time.Duration
+	for _, d := range clk.Waits() {
+		total += d
+	}
+	if total != 30*time.Second {
+		t.Errorf("total wait = %v, want 30s", total)
+	}
+}
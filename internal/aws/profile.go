@@ -0,0 +1,73 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig/types"
+)
+
+// LocationHosted is the location URI of profiles whose content lives in the
+// AppConfig hosted configuration store.
+const LocationHosted = "hosted"
+
+// ProfileDefinition is the account-independent part of a configuration
+// profile: everything CreateConfigurationProfile needs except the
+// application it belongs to.
+type ProfileDefinition struct {
+	Name             string
+	Description      string
+	Type             string
+	LocationURI      string
+	RetrievalRoleARN string
+	Validators       []types.Validator
+}
+
+// GetProfileDefinition reads the definition of an existing configuration
+// profile, including its validators.
+func (c *Client) GetProfileDefinition(ctx context.Context, applicationID, profileID string) (*ProfileDefinition, error) {
+	output, err := c.appConfig.GetConfigurationProfile(ctx, &appconfig.GetConfigurationProfileInput{
+		ApplicationId:          aws.String(applicationID),
+		ConfigurationProfileId: aws.String(profileID),
+	})
+	if err != nil {
+		return nil, wrapAWSError(err, "failed to get configuration profile")
+	}
+
+	return &ProfileDefinition{
+		Name:             aws.ToString(output.Name),
+		Description:      aws.ToString(output.Description),
+		Type:             aws.ToString(output.Type),
+		LocationURI:      aws.ToString(output.LocationUri),
+		RetrievalRoleARN: aws.ToString(output.RetrievalRoleArn),
+		Validators:       output.Validators,
+	}, nil
+}
+
+// CreateConfigurationProfile creates a configuration profile from def in the
+// given application and returns the new profile ID.
+func (c *Client) CreateConfigurationProfile(ctx context.Context, applicationID string, def *ProfileDefinition) (string, error) {
+	input := &appconfig.CreateConfigurationProfileInput{
+		ApplicationId: aws.String(applicationID),
+		Name:          aws.String(def.Name),
+		LocationUri:   aws.String(def.LocationURI),
+		Validators:    def.Validators,
+	}
+	if def.Description != "" {
+		input.Description = aws.String(def.Description)
+	}
+	if def.Type != "" {
+		input.Type = aws.String(def.Type)
+	}
+	if def.RetrievalRoleARN != "" {
+		input.RetrievalRoleArn = aws.String(def.RetrievalRoleARN)
+	}
+
+	output, err := c.appConfig.CreateConfigurationProfile(ctx, input)
+	if err != nil {
+		return "", wrapAWSError(err, "failed to create configuration profile")
+	}
+
+	return aws.ToString(output.Id), nil
+}
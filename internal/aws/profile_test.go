@@ -0,0 +1,110 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig/types"
+	"github.com/koh-sh/apcdeploy/internal/aws/mock"
+)
+
+func TestGetProfileDefinition(t *testing.T) {
+	t.Parallel()
+
+	client := NewTestClient(&mock.MockAppConfigClient{
+		GetConfigurationProfileFunc: func(ctx context.Context, params *appconfig.GetConfigurationProfileInput, optFns ...func(*appconfig.Options)) (*appconfig.GetConfigurationProfileOutput, error) {
+			if aws.ToString(params.ApplicationId) != "app-1" || aws.ToString(params.ConfigurationProfileId) != "prof-1" {
+				t.Errorf("unexpected ids: %s/%s", aws.ToString(params.ApplicationId), aws.ToString(params.ConfigurationProfileId))
+			}
+			return &appconfig.GetConfigurationProfileOutput{
+				Name:             aws.String("flags"),
+				Description:      aws.String("feature flags"),
+				Type:             aws.String("AWS.AppConfig.FeatureFlags"),
+				LocationUri:      aws.String("hosted"),
+				RetrievalRoleArn: aws.String("arn:aws:iam::123456789012:role/r"),
+				Validators:       []types.Validator{{Type: types.ValidatorTypeJsonSchema, Content: aws.String("{}")}},
+			}, nil
+		},
+	})
+
+	def, err := client.GetProfileDefinition(context.Background(), "app-1", "prof-1")
+	if err != nil {
+		t.Fatalf("GetProfileDefinition() error = %v", err)
+	}
+	if def.Name != "flags" || def.Description != "feature flags" || def.Type != "AWS.AppConfig.FeatureFlags" ||
+		def.LocationURI != LocationHosted || def.RetrievalRoleARN != "arn:aws:iam::123456789012:role/r" {
+		t.Errorf("unexpected definition: %+v", def)
+	}
+	if len(def.Validators) != 1 || def.Validators[0].Type != types.ValidatorTypeJsonSchema {
+		t.Errorf("unexpected validators: %+v", def.Validators)
+	}
+}
+
+func TestCreateConfigurationProfile(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		def     *ProfileDefinition
+		apiErr  error
+		check   func(t *testing.T, in *appconfig.CreateConfigurationProfileInput)
+		wantErr bool
+	}{
+		{
+			name: "optional fields omitted when empty",
+			def:  &ProfileDefinition{Name: "p", LocationURI: "hosted"},
+			check: func(t *testing.T, in *appconfig.CreateConfigurationProfileInput) {
+				if in.Description != nil || in.Type != nil || in.RetrievalRoleArn != nil {
+					t.Errorf("expected optional fields to be nil: %+v", in)
+				}
+			},
+		},
+		{
+			name: "all fields passed through",
+			def: &ProfileDefinition{
+				Name: "p", Description: "d", Type: "AWS.Freeform", LocationURI: "ssm-parameter://x",
+				RetrievalRoleARN: "arn:role", Validators: []types.Validator{{Type: types.ValidatorTypeLambda, Content: aws.String("arn:fn")}},
+			},
+			check: func(t *testing.T, in *appconfig.CreateConfigurationProfileInput) {
+				if aws.ToString(in.Description) != "d" || aws.ToString(in.Type) != "AWS.Freeform" ||
+					aws.ToString(in.LocationUri) != "ssm-parameter://x" || aws.ToString(in.RetrievalRoleArn) != "arn:role" || len(in.Validators) != 1 {
+					t.Errorf("unexpected input: %+v", in)
+				}
+			},
+		},
+		{
+			name:    "api error",
+			def:     &ProfileDefinition{Name: "p", LocationURI: "hosted"},
+			apiErr:  errors.New("boom"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client := NewTestClient(&mock.MockAppConfigClient{
+				CreateConfigurationProfileFunc: func(ctx context.Context, params *appconfig.CreateConfigurationProfileInput, optFns ...func(*appconfig.Options)) (*appconfig.CreateConfigurationProfileOutput, error) {
+					if tt.check != nil {
+						tt.check(t, params)
+					}
+					if tt.apiErr != nil {
+						return nil, tt.apiErr
+					}
+					return &appconfig.CreateConfigurationProfileOutput{Id: aws.String("new-id")}, nil
+				},
+			})
+
+			id, err := client.CreateConfigurationProfile(context.Background(), "app-1", tt.def)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CreateConfigurationProfile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && id != "new-id" {
+				t.Errorf("id = %q, want new-id", id)
+			}
+		})
+	}
+}
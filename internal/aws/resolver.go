@@ -4,21 +4,49 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/aws/aws-sdk-go-v2/service/appconfig"
 	"github.com/aws/aws-sdk-go-v2/service/appconfig/types"
 	"github.com/koh-sh/apcdeploy/internal/config"
 )
 
+// Resolve modes reported by ResolveMode.
+const (
+	ResolveModeConcurrent = "concurrent"
+	ResolveModeSequential = "sequential"
+)
+
+// concurrentResolve controls whether ResolveAll fans its lookups out
+// (--concurrent-resolve, default on). Set once at startup by
+// SetConcurrentResolve.
+var concurrentResolve = true
+
+// SetConcurrentResolve switches resolvers created afterwards between
+// concurrent and sequential resolution.
+func SetConcurrentResolve(on bool) {
+	concurrentResolve = on
+}
+
+// ResolveMode returns the resolution mode new resolvers use.
+func ResolveMode() string {
+	if concurrentResolve {
+		return ResolveModeConcurrent
+	}
+	return ResolveModeSequential
+}
+
 // Resolver handles AWS resource name to ID resolution
 type Resolver struct {
-	client AppConfigAPI
+	client     AppConfigAPI
+	concurrent bool
 }
 
 // NewResolver creates a new resolver with the given client
 func NewResolver(client *Client) *Resolver {
 	return &Resolver{
-		client: client,
+		client:     client,
+		concurrent: concurrentResolve,
 	}
 }
 
@@ -32,7 +60,7 @@ func (r *Resolver) ResolveApplication(ctx context.Context, appName string) (stri
 	return resolveByName(
 		allItems,
 		appName,
-		"application",
+		ErrApplicationNotFound, ErrMultipleApplicationsFound,
 		func(app types.Application) *string { return app.Name },
 		func(app types.Application) *string { return app.Id },
 	)
@@ -62,11 +90,11 @@ func (r *Resolver) ResolveConfigurationProfile(ctx context.Context, appID, profi
 	}
 
 	if len(matches) == 0 {
-		return nil, fmt.Errorf("configuration profile not found: %s", profileName)
+		return nil, fmt.Errorf("%w: %s", ErrProfileNotFound, profileName)
 	}
 
 	if len(matches) > 1 {
-		return nil, fmt.Errorf("multiple configuration profiles found with name: %s", profileName)
+		return nil, fmt.Errorf("%w with name: %s", ErrMultipleProfilesFound, profileName)
 	}
 
 	// Get detailed profile information
@@ -101,7 +129,7 @@ func (r *Resolver) ResolveEnvironment(ctx context.Context, appID, envName string
 	return resolveByName(
 		allItems,
 		envName,
-		"environment",
+		ErrEnvironmentNotFound, ErrMultipleEnvironmentsFound,
 		func(env types.Environment) *string { return env.Name },
 		func(env types.Environment) *string { return env.Id },
 	)
@@ -114,13 +142,26 @@ func (r *Resolver) ResolveDeploymentStrategy(ctx context.Context, strategyName s
 		return "", fmt.Errorf("failed to list deployment strategies: %w", err)
 	}
 
-	return resolveByName(
+	id, err := resolveByName(
 		allItems,
 		strategyName,
-		"deployment strategy",
+		ErrStrategyNotFound, ErrMultipleStrategiesFound,
 		func(strategy types.DeploymentStrategy) *string { return strategy.Name },
 		func(strategy types.DeploymentStrategy) *string { return strategy.Id },
 	)
+	if err != nil {
+		names := make([]string, 0, len(allItems))
+		for _, strategy := range allItems {
+			if strategy.Name != nil {
+				names = append(names, *strategy.Name)
+			}
+		}
+		if suggestions := suggestNames(strategyName, names); len(suggestions) > 0 {
+			err = fmt.Errorf("%w (did you mean %s?)", err, strings.Join(suggestions, ", "))
+		}
+		return "", fmt.Errorf("%w; run 'apcdeploy run --list-strategies' to print the supported names", err)
+	}
+	return id, nil
 }
 
 // ResolveDeploymentStrategyIDToName resolves a deployment strategy ID to its name
@@ -163,7 +204,16 @@ type ResolvedResources struct {
 // ResolveAll resolves all AWS AppConfig resources (application, profile, environment, strategy).
 // If strategyName is empty, deployment strategy resolution is skipped (DeploymentStrategyID will be empty).
 // This is useful for commands like 'get' and 'init' that don't require a deployment strategy.
+//
+// In concurrent mode the application is resolved first, then the profile,
+// environment and strategy lookups run in parallel. Both modes return the
+// same result, and the same error when several lookups fail (the first in
+// application, profile, environment, strategy order).
 func (r *Resolver) ResolveAll(ctx context.Context, appName, profileName, envName, strategyName string) (*ResolvedResources, error) {
+	if r.concurrent {
+		return r.resolveAllConcurrent(ctx, appName, profileName, envName, strategyName)
+	}
+
 	// Resolve application first as other resources depend on it
 	appID, err := r.ResolveApplication(ctx, appName)
 	if err != nil {
@@ -198,3 +248,44 @@ func (r *Resolver) ResolveAll(ctx context.Context, appName, profileName, envName
 		DeploymentStrategyID: strategyID,
 	}, nil
 }
+
+// resolveAllConcurrent is the concurrent path of ResolveAll. The
+// application is resolved first since every other lookup but the strategy
+// depends on it and a wrong application name should fail without further
+// calls; profile, environment and strategy then resolve in parallel. Errors
+// are reported in the sequential order so both modes fail the same way.
+func (r *Resolver) resolveAllConcurrent(ctx context.Context, appName, profileName, envName, strategyName string) (*ResolvedResources, error) {
+	appID, err := r.ResolveApplication(ctx, appName)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		wg          sync.WaitGroup
+		profile     *ProfileInfo
+		profileErr  error
+		envID       string
+		envErr      error
+		strategyID  string
+		strategyErr error
+	)
+	wg.Go(func() { profile, profileErr = r.ResolveConfigurationProfile(ctx, appID, profileName) })
+	wg.Go(func() { envID, envErr = r.ResolveEnvironment(ctx, appID, envName) })
+	if strategyName != "" {
+		wg.Go(func() { strategyID, strategyErr = r.ResolveDeploymentStrategy(ctx, strategyName) })
+	}
+	wg.Wait()
+
+	for _, err := range []error{profileErr, envErr, strategyErr} {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &ResolvedResources{
+		ApplicationID:        appID,
+		Profile:              profile,
+		EnvironmentID:        envID,
+		DeploymentStrategyID: strategyID,
+	}, nil
+}
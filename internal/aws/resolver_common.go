@@ -2,15 +2,17 @@ package aws
 
 import (
 	"fmt"
+	"strings"
 )
 
 // resolveByName resolves a resource by name using a generic approach
 // It returns an error if no matches or multiple matches are found
 // The nameGetter and idGetter functions extract the Name and Id fields from each item
+// notFound and multiple are the sentinel errors wrapped for each case
 func resolveByName[T any](
 	items []T,
 	name string,
-	resourceType string,
+	notFound, multiple error,
 	nameGetter func(T) *string,
 	idGetter func(T) *string,
 ) (string, error) {
@@ -26,12 +28,60 @@ func resolveByName[T any](
 	}
 
 	if len(matches) == 0 {
-		return "", fmt.Errorf("%s not found: %s", resourceType, name)
+		return "", fmt.Errorf("%w: %s", notFound, name)
 	}
 
 	if len(matches) > 1 {
-		return "", fmt.Errorf("multiple %ss found with name: %s", resourceType, name)
+		return "", fmt.Errorf("%w with name: %s", multiple, name)
 	}
 
 	return matches[0], nil
 }
+
+// maxSuggestions caps how many "did you mean" candidates suggestNames returns.
+const maxSuggestions = 3
+
+// suggestNames returns the candidates that look like a mistyped name: a
+// case-insensitive match, a case-insensitive substring in either direction,
+// or an edit distance of at most a third of the name's length. Candidates
+// keep their input order.
+func suggestNames(name string, candidates []string) []string {
+	if name == "" {
+		return nil
+	}
+	lower := strings.ToLower(name)
+	maxDistance := max(len(lower)/3, 1)
+
+	var suggestions []string
+	for _, candidate := range candidates {
+		if len(suggestions) == maxSuggestions {
+			break
+		}
+		c := strings.ToLower(candidate)
+		if c == lower || strings.Contains(c, lower) || strings.Contains(lower, c) || levenshtein(lower, c) <= maxDistance {
+			suggestions = append(suggestions, candidate)
+		}
+	}
+	return suggestions
+}
+
+// levenshtein returns the edit distance between a and b (byte-wise).
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
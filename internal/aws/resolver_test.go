@@ -3,8 +3,11 @@ package aws
 import (
 	"context"
 	"errors"
+	"reflect"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/appconfig"
@@ -511,6 +514,16 @@ func TestResolveDeploymentStrategy(t *testing.T) {
 			wantErr:     true,
 			errContains: "deployment strategy not found",
 		},
+		{
+			name:         "typo suggests the close name",
+			strategyName: "AppConfig.AllAtOnse",
+			mockStrategies: []types.DeploymentStrategy{
+				{Id: aws.String("strategy-123"), Name: aws.String("AppConfig.AllAtOnce")},
+				{Id: aws.String("strategy-456"), Name: aws.String("AppConfig.Linear50PercentEvery30Seconds")},
+			},
+			wantErr:     true,
+			errContains: "(did you mean AppConfig.AllAtOnce?); run 'apcdeploy run --list-strategies'",
+		},
 		{
 			name:         "API error",
 			strategyName: "AppConfig.AllAtOnce",
@@ -841,43 +854,330 @@ func TestResolveAll(t *testing.T) {
 				},
 			}
 
-			resolver := &Resolver{
-				client: mockClient,
-			}
+			for _, concurrent := range []bool{false, true} {
+				resolver := &Resolver{
+					client:     mockClient,
+					concurrent: concurrent,
+				}
 
-			ctx := context.Background()
-			result, err := resolver.ResolveAll(ctx, tt.appName, tt.profileName, tt.envName, tt.strategyName)
+				ctx := context.Background()
+				result, err := resolver.ResolveAll(ctx, tt.appName, tt.profileName, tt.envName, tt.strategyName)
 
-			if tt.wantErr {
-				if err == nil {
-					t.Error("expected error, got nil")
+				if tt.wantErr {
+					if err == nil {
+						t.Error("expected error, got nil")
+					}
+					continue
 				}
-				return
-			}
 
-			if err != nil {
-				t.Errorf("unexpected error: %v", err)
-				return
-			}
+				if err != nil {
+					t.Errorf("unexpected error (concurrent=%v): %v", concurrent, err)
+					continue
+				}
 
-			if result.ApplicationID != tt.wantAppID {
-				t.Errorf("result.ApplicationID = %v, want %v", result.ApplicationID, tt.wantAppID)
+				checkResolved(t, result, tt.wantAppID, tt.wantProfileID, tt.wantProfileType, tt.wantEnvID, tt.wantStrategyID)
 			}
+		})
+	}
+}
+
+// checkResolved compares every field of a ResolveAll result.
+func checkResolved(t *testing.T, result *ResolvedResources, wantAppID, wantProfileID, wantProfileType, wantEnvID, wantStrategyID string) {
+	t.Helper()
+	if result.ApplicationID != wantAppID {
+		t.Errorf("result.ApplicationID = %v, want %v", result.ApplicationID, wantAppID)
+	}
+	if result.Profile.ID != wantProfileID {
+		t.Errorf("result.Profile.ID = %v, want %v", result.Profile.ID, wantProfileID)
+	}
+	if result.Profile.Type != wantProfileType {
+		t.Errorf("result.Profile.Type = %v, want %v", result.Profile.Type, wantProfileType)
+	}
+	if result.EnvironmentID != wantEnvID {
+		t.Errorf("result.EnvironmentID = %v, want %v", result.EnvironmentID, wantEnvID)
+	}
+	if result.DeploymentStrategyID != wantStrategyID {
+		t.Errorf("result.DeploymentStrategyID = %v, want %v", result.DeploymentStrategyID, wantStrategyID)
+	}
+}
+
+// TestResolveAllModesReportSameError checks that concurrent resolution
+// reports the same error as sequential resolution when several lookups
+// fail at once.
+func TestResolveAllModesReportSameError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name                                  string
+		failApp, failProfile, failEnv, failSt bool
+		wantErr                               string
+	}{
+		{name: "application wins", failApp: true, failProfile: true, failSt: true, wantErr: "applications"},
+		{name: "profile before environment", failProfile: true, failEnv: true, failSt: true, wantErr: "configuration profiles"},
+		{name: "environment before strategy", failEnv: true, failSt: true, wantErr: "environments"},
+		{name: "strategy alone", failSt: true, wantErr: "deployment strategies"},
+	}
+
+	fail := func(on bool, what string) error {
+		if on {
+			return errors.New(what + " unavailable")
+		}
+		return nil
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
 
-			if result.Profile.ID != tt.wantProfileID {
-				t.Errorf("result.Profile.ID = %v, want %v", result.Profile.ID, tt.wantProfileID)
+			mockClient := &mock.MockAppConfigClient{
+				ListAllApplicationsFunc: func(ctx context.Context) ([]types.Application, error) {
+					return []types.Application{{Id: aws.String("app-1"), Name: aws.String("app")}}, fail(tt.failApp, "applications")
+				},
+				ListAllConfigurationProfilesFunc: func(ctx context.Context, appID string) ([]types.ConfigurationProfileSummary, error) {
+					return []types.ConfigurationProfileSummary{{Id: aws.String("prof-1"), Name: aws.String("prof")}}, fail(tt.failProfile, "configuration profiles")
+				},
+				GetConfigurationProfileFunc: func(ctx context.Context, params *appconfig.GetConfigurationProfileInput, optFns ...func(*appconfig.Options)) (*appconfig.GetConfigurationProfileOutput, error) {
+					return &appconfig.GetConfigurationProfileOutput{Type: aws.String(config.ProfileTypeFreeform)}, nil
+				},
+				ListAllEnvironmentsFunc: func(ctx context.Context, appID string) ([]types.Environment, error) {
+					return []types.Environment{{Id: aws.String("env-1"), Name: aws.String("env")}}, fail(tt.failEnv, "environments")
+				},
+				ListAllDeploymentStrategiesFunc: func(ctx context.Context) ([]types.DeploymentStrategy, error) {
+					return []types.DeploymentStrategy{{Id: aws.String("st-1"), Name: aws.String("st")}}, fail(tt.failSt, "deployment strategies")
+				},
 			}
 
-			if result.Profile.Type != tt.wantProfileType {
-				t.Errorf("result.Profile.Type = %v, want %v", result.Profile.Type, tt.wantProfileType)
+			var errs [2]error
+			for i, concurrent := range []bool{false, true} {
+				_, errs[i] = (&Resolver{client: mockClient, concurrent: concurrent}).ResolveAll(context.Background(), "app", "prof", "env", "st")
+			}
+			if errs[0] == nil || errs[1] == nil || errs[0].Error() != errs[1].Error() {
+				t.Fatalf("sequential error %v != concurrent error %v", errs[0], errs[1])
 			}
+			if !strings.Contains(errs[1].Error(), tt.wantErr) {
+				t.Errorf("error = %v, want mention of %q", errs[1], tt.wantErr)
+			}
+		})
+	}
+}
 
-			if result.EnvironmentID != tt.wantEnvID {
-				t.Errorf("result.EnvironmentID = %v, want %v", result.EnvironmentID, tt.wantEnvID)
+// TestResolveAllConcurrentFansOut checks that the profile, environment and
+// strategy lookups are in flight at the same time once the application is
+// resolved.
+func TestResolveAllConcurrentFansOut(t *testing.T) {
+	t.Parallel()
+
+	var started sync.WaitGroup
+	started.Add(3)
+	// Each lookup blocks until all three have started, so a sequential
+	// implementation would time out here instead of returning.
+	barrier := func() error {
+		started.Done()
+		done := make(chan struct{})
+		go func() { started.Wait(); close(done) }()
+		select {
+		case <-done:
+			return nil
+		case <-time.After(5 * time.Second):
+			return errors.New("lookups did not run concurrently")
+		}
+	}
+
+	mockClient := &mock.MockAppConfigClient{
+		ListAllApplicationsFunc: func(ctx context.Context) ([]types.Application, error) {
+			return []types.Application{{Id: aws.String("app-1"), Name: aws.String("app")}}, nil
+		},
+		ListAllConfigurationProfilesFunc: func(ctx context.Context, appID string) ([]types.ConfigurationProfileSummary, error) {
+			return []types.ConfigurationProfileSummary{{Id: aws.String("prof-1"), Name: aws.String("prof")}}, barrier()
+		},
+		GetConfigurationProfileFunc: func(ctx context.Context, params *appconfig.GetConfigurationProfileInput, optFns ...func(*appconfig.Options)) (*appconfig.GetConfigurationProfileOutput, error) {
+			return &appconfig.GetConfigurationProfileOutput{Type: aws.String(config.ProfileTypeFreeform)}, nil
+		},
+		ListAllEnvironmentsFunc: func(ctx context.Context, appID string) ([]types.Environment, error) {
+			return []types.Environment{{Id: aws.String("env-1"), Name: aws.String("env")}}, barrier()
+		},
+		ListAllDeploymentStrategiesFunc: func(ctx context.Context) ([]types.DeploymentStrategy, error) {
+			return []types.DeploymentStrategy{{Id: aws.String("st-1"), Name: aws.String("st")}}, barrier()
+		},
+	}
+
+	got, err := (&Resolver{client: mockClient, concurrent: true}).ResolveAll(context.Background(), "app", "prof", "env", "st")
+	if err != nil {
+		t.Fatalf("ResolveAll() error = %v", err)
+	}
+	want := &ResolvedResources{
+		ApplicationID:        "app-1",
+		Profile:              &ProfileInfo{ID: "prof-1", Name: "prof", Type: config.ProfileTypeFreeform},
+		EnvironmentID:        "env-1",
+		DeploymentStrategyID: "st-1",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ResolveAll() = %+v, want %+v", got, want)
+	}
+}
+
+func TestSuggestNames(t *testing.T) {
+	t.Parallel()
+
+	candidates := []string{"AppConfig.AllAtOnce", "AppConfig.Linear50PercentEvery30Seconds", "AppConfig.Canary10Percent20Minutes", "Custom.Slow"}
+	tests := []struct {
+		name string
+		in   string
+		want []string
+	}{
+		{name: "case mismatch", in: "appconfig.allatonce", want: []string{"AppConfig.AllAtOnce"}},
+		{name: "small typo", in: "AppConfig.AllAtOnse", want: []string{"AppConfig.AllAtOnce"}},
+		{name: "missing prefix", in: "AllAtOnce", want: []string{"AppConfig.AllAtOnce"}},
+		{name: "substring matches several", in: "Percent", want: []string{"AppConfig.Linear50PercentEvery30Seconds", "AppConfig.Canary10Percent20Minutes"}},
+		{name: "nothing close", in: "Blue/Green", want: nil},
+		{name: "empty name", in: "", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := suggestNames(tt.in, candidates)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("suggestNames(%q) = %v, want %v", tt.in, got, tt.want)
 			}
+		})
+	}
+}
+
+func TestResolverSentinelErrors(t *testing.T) {
+	t.Parallel()
 
-			if result.DeploymentStrategyID != tt.wantStrategyID {
-				t.Errorf("result.DeploymentStrategyID = %v, want %v", result.DeploymentStrategyID, tt.wantStrategyID)
+	mockClient := func(names []string) *mock.MockAppConfigClient {
+		return &mock.MockAppConfigClient{
+			ListAllApplicationsFunc: func(ctx context.Context) ([]types.Application, error) {
+				var items []types.Application
+				for i, n := range names {
+					items = append(items, types.Application{Id: aws.String(string(rune('a' + i))), Name: aws.String(n)})
+				}
+				return items, nil
+			},
+			ListAllConfigurationProfilesFunc: func(ctx context.Context, appID string) ([]types.ConfigurationProfileSummary, error) {
+				var items []types.ConfigurationProfileSummary
+				for i, n := range names {
+					items = append(items, types.ConfigurationProfileSummary{Id: aws.String(string(rune('a' + i))), Name: aws.String(n)})
+				}
+				return items, nil
+			},
+			ListAllEnvironmentsFunc: func(ctx context.Context, appID string) ([]types.Environment, error) {
+				var items []types.Environment
+				for i, n := range names {
+					items = append(items, types.Environment{Id: aws.String(string(rune('a' + i))), Name: aws.String(n)})
+				}
+				return items, nil
+			},
+			ListAllDeploymentStrategiesFunc: func(ctx context.Context) ([]types.DeploymentStrategy, error) {
+				var items []types.DeploymentStrategy
+				for i, n := range names {
+					items = append(items, types.DeploymentStrategy{Id: aws.String(string(rune('a' + i))), Name: aws.String(n)})
+				}
+				return items, nil
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		names   []string
+		resolve func(r *Resolver) error
+		want    error
+		wantMsg string
+	}{
+		{
+			name:  "application not found",
+			names: []string{"other"},
+			resolve: func(r *Resolver) error {
+				_, err := r.ResolveApplication(context.Background(), "app")
+				return err
+			},
+			want:    ErrApplicationNotFound,
+			wantMsg: "application not found: app",
+		},
+		{
+			name:  "multiple applications",
+			names: []string{"app", "app"},
+			resolve: func(r *Resolver) error {
+				_, err := r.ResolveApplication(context.Background(), "app")
+				return err
+			},
+			want:    ErrMultipleApplicationsFound,
+			wantMsg: "multiple applications found with name: app",
+		},
+		{
+			name:  "profile not found",
+			names: []string{"other"},
+			resolve: func(r *Resolver) error {
+				_, err := r.ResolveConfigurationProfile(context.Background(), "app-id", "profile")
+				return err
+			},
+			want:    ErrProfileNotFound,
+			wantMsg: "configuration profile not found: profile",
+		},
+		{
+			name:  "multiple profiles",
+			names: []string{"profile", "profile"},
+			resolve: func(r *Resolver) error {
+				_, err := r.ResolveConfigurationProfile(context.Background(), "app-id", "profile")
+				return err
+			},
+			want:    ErrMultipleProfilesFound,
+			wantMsg: "multiple configuration profiles found with name: profile",
+		},
+		{
+			name:  "environment not found",
+			names: []string{"other"},
+			resolve: func(r *Resolver) error {
+				_, err := r.ResolveEnvironment(context.Background(), "app-id", "env")
+				return err
+			},
+			want:    ErrEnvironmentNotFound,
+			wantMsg: "environment not found: env",
+		},
+		{
+			name:  "multiple environments",
+			names: []string{"env", "env"},
+			resolve: func(r *Resolver) error {
+				_, err := r.ResolveEnvironment(context.Background(), "app-id", "env")
+				return err
+			},
+			want:    ErrMultipleEnvironmentsFound,
+			wantMsg: "multiple environments found with name: env",
+		},
+		{
+			name:  "strategy not found",
+			names: []string{"other"},
+			resolve: func(r *Resolver) error {
+				_, err := r.ResolveDeploymentStrategy(context.Background(), "Quick")
+				return err
+			},
+			want:    ErrStrategyNotFound,
+			wantMsg: "deployment strategy not found: Quick",
+		},
+		{
+			name:  "multiple strategies",
+			names: []string{"Quick", "Quick"},
+			resolve: func(r *Resolver) error {
+				_, err := r.ResolveDeploymentStrategy(context.Background(), "Quick")
+				return err
+			},
+			want:    ErrMultipleStrategiesFound,
+			wantMsg: "multiple deployment strategies found with name: Quick",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.resolve(&Resolver{client: mockClient(tt.names)})
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("error = %v, want errors.Is %v", err, tt.want)
+			}
+			if !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Errorf("error = %q, want it to contain %q", err, tt.wantMsg)
 			}
 		})
 	}
@@ -0,0 +1,42 @@
+package aws
+
+import (
+	"math/rand/v2"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/koh-sh/apcdeploy/internal/config"
+)
+
+// newRetryer returns the retryer for AppConfig calls: the SDK's standard
+// retryer, which covers throttling, 5xx responses and transport failures
+// (connection resets, EOF, dial and TLS timeouts), with maxAttempts attempts
+// and jittered exponential backoff starting at baseDelay.
+// AppConfig reports throttling as TooManyRequestsException, which the
+// standard retryer does not know, so it is added to the retryable codes.
+func newRetryer(maxAttempts int, baseDelay time.Duration) aws.Retryer {
+	standard := retry.NewStandard(func(o *retry.StandardOptions) {
+		o.MaxAttempts = maxAttempts
+		o.Backoff = jitterBackoff{base: baseDelay, max: config.DefaultMaxRetryBackoff}
+	})
+	return retry.AddWithErrorCodes(standard, "TooManyRequestsException")
+}
+
+// jitterBackoff waits a random duration up to base doubled per retry,
+// capped at max ("full jitter"). The SDK's ExponentialJitterBackoff fixes
+// the base at one second, so it cannot honor Client.RetryBaseDelay.
+type jitterBackoff struct {
+	base time.Duration
+	max  time.Duration
+}
+
+// BackoffDelay implements retry.BackoffDelayer. attempt is 1 for the first
+// retry.
+func (b jitterBackoff) BackoffDelay(attempt int, _ error) (time.Duration, error) {
+	ceiling := b.max
+	if shift := attempt - 1; shift < 32 && b.base<<shift < b.max && b.base<<shift > 0 {
+		ceiling = b.base << shift
+	}
+	return time.Duration(rand.Int64N(int64(ceiling) + 1)), nil
+}
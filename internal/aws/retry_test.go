@@ -0,0 +1,91 @@
+package aws
+
+import (
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"testing"
+	"time"
+
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig/types"
+	"github.com/aws/smithy-go"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"github.com/koh-sh/apcdeploy/internal/config"
+)
+
+func serverError(status int) error {
+	return &awshttp.ResponseError{
+		ResponseError: &smithyhttp.ResponseError{
+			Response: &smithyhttp.Response{Response: &http.Response{StatusCode: status}},
+			Err:      errors.New("server error"),
+		},
+	}
+}
+
+func TestNewRetryer(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "appconfig throttling", err: &smithy.GenericAPIError{Code: "TooManyRequestsException"}, want: true},
+		{name: "generic throttling code", err: &smithy.GenericAPIError{Code: "ThrottlingException"}, want: true},
+		{name: "request timeout", err: &smithy.GenericAPIError{Code: "RequestTimeout"}, want: true},
+		{name: "5xx response", err: serverError(http.StatusServiceUnavailable), want: true},
+		{name: "connection reset", err: &smithyhttp.RequestSendError{Err: &net.OpError{Op: "read", Err: syscall.ECONNRESET}}, want: true},
+		{name: "unexpected EOF", err: &smithyhttp.RequestSendError{Err: io.ErrUnexpectedEOF}, want: true},
+		{name: "dial timeout", err: &smithyhttp.RequestSendError{Err: &net.OpError{Op: "dial", Err: syscall.ETIMEDOUT}}, want: true},
+		{name: "4xx response", err: serverError(http.StatusForbidden), want: false},
+		{name: "bad request", err: &types.BadRequestException{}, want: false},
+		{name: "resource not found", err: &types.ResourceNotFoundException{}, want: false},
+	}
+
+	retryer := newRetryer(config.DefaultMaxAttempts, config.DefaultRetryBaseDelay)
+	if got := retryer.MaxAttempts(); got != config.DefaultMaxAttempts {
+		t.Errorf("MaxAttempts() = %d, want %d", got, config.DefaultMaxAttempts)
+	}
+	if got := newRetryer(2, time.Millisecond).MaxAttempts(); got != 2 {
+		t.Errorf("MaxAttempts() = %d, want 2", got)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := retryer.IsErrorRetryable(tt.err); got != tt.want {
+				t.Errorf("IsErrorRetryable(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJitterBackoff(t *testing.T) {
+	t.Parallel()
+
+	b := jitterBackoff{base: 100 * time.Millisecond, max: time.Second}
+	tests := []struct {
+		attempt int
+		ceiling time.Duration
+	}{
+		{attempt: 1, ceiling: 100 * time.Millisecond},
+		{attempt: 2, ceiling: 200 * time.Millisecond},
+		{attempt: 4, ceiling: 800 * time.Millisecond},
+		{attempt: 5, ceiling: time.Second},
+		{attempt: 100, ceiling: time.Second},
+	}
+	for _, tt := range tests {
+		for range 50 {
+			got, err := b.BackoffDelay(tt.attempt, nil)
+			if err != nil {
+				t.Fatalf("BackoffDelay(%d) error = %v", tt.attempt, err)
+			}
+			if got < 0 || got > tt.ceiling {
+				t.Fatalf("BackoffDelay(%d) = %v, want within [0, %v]", tt.attempt, got, tt.ceiling)
+			}
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+// ParseTagFilter parses a "key=value" tag filter as accepted by
+// --environments-by-tag. The value may be empty ("key=") but the key may not.
+func ParseTagFilter(s string) (key, value string, err error) {
+	key, value, ok := strings.Cut(s, "=")
+	if !ok || strings.TrimSpace(key) == "" {
+		return "", "", fmt.Errorf("invalid tag filter %q: expected key=value", s)
+	}
+	return key, value, nil
+}
+
+// EnvironmentARN builds the ARN of an AppConfig environment. AppConfig's
+// List/Get environment APIs do not return ARNs, so tag lookups have to
+// assemble them from the account ID and region.
+func EnvironmentARN(region, accountID, appID, envID string) string {
+	return fmt.Sprintf("arn:%s:appconfig:%s:%s:application/%s/environment/%s", partitionForRegion(region), region, accountID, appID, envID)
+}
+
+// partitionForRegion returns the ARN partition a region belongs to.
+func partitionForRegion(region string) string {
+	switch {
+	case strings.HasPrefix(region, "cn-"):
+		return "aws-cn"
+	case strings.HasPrefix(region, "us-gov-"):
+		return "aws-us-gov"
+	default:
+		return "aws"
+	}
+}
+
+// AccountID returns the AWS account ID of the caller. The result is cached
+// on the client, so repeated lookups (tag ARNs, the account_id guard) cost a
+// single STS call; failures are not cached.
+func (c *Client) AccountID(ctx context.Context) (string, error) {
+	c.accountMu.Lock()
+	defer c.accountMu.Unlock()
+	if c.accountID != "" {
+		return c.accountID, nil
+	}
+	if c.STS == nil {
+		return "", fmt.Errorf("failed to get caller identity: STS client is not configured")
+	}
+	output, err := c.STS.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get caller identity: %w", err)
+	}
+	c.accountID = aws.ToString(output.Account)
+	return c.accountID, nil
+}
+
+// CheckAccount returns ErrAccountMismatch when the caller's credentials do
+// not belong to the expected account (the account_id config guard).
+func (c *Client) CheckAccount(ctx context.Context, expected string) error {
+	actual, err := c.AccountID(ctx)
+	if err != nil {
+		return err
+	}
+	if actual != expected {
+		return fmt.Errorf("%w: credentials belong to account %s, but account_id is %s", ErrAccountMismatch, actual, expected)
+	}
+	return nil
+}
+
+// ListEnvironmentTags returns the tags attached to the environment with the
+// given ARN.
+func (c *Client) ListEnvironmentTags(ctx context.Context, envARN string) (map[string]string, error) {
+	output, err := c.appConfig.ListTagsForResource(ctx, &appconfig.ListTagsForResourceInput{
+		ResourceArn: aws.String(envARN),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags for %s: %w", envARN, err)
+	}
+	return output.Tags, nil
+}
+
+// ListEnvironmentsByTag returns the environments of the application whose
+// tag key equals value, in the order ListEnvironments returns them.
+func (c *Client) ListEnvironmentsByTag(ctx context.Context, appID, key, value string) ([]types.Environment, error) {
+	envs, err := c.ListAllEnvironments(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+	if len(envs) == 0 {
+		return nil, nil
+	}
+
+	accountID, err := c.AccountID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []types.Environment
+	for _, env := range envs {
+		tags, err := c.ListEnvironmentTags(ctx, EnvironmentARN(c.Region, accountID, appID, aws.ToString(env.Id)))
+		if err != nil {
+			return nil, err
+		}
+		if v, ok := tags[key]; ok && v == value {
+			matched = append(matched, env)
+		}
+	}
+	return matched, nil
+}
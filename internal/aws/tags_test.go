@@ -0,0 +1,150 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/koh-sh/apcdeploy/internal/aws/mock"
+)
+
+func TestParseTagFilter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		input     string
+		wantKey   string
+		wantValue string
+		wantErr   bool
+	}{
+		{name: "key and value", input: "tier=canary", wantKey: "tier", wantValue: "canary"},
+		{name: "empty value", input: "tier=", wantKey: "tier", wantValue: ""},
+		{name: "value containing equals", input: "expr=a=b", wantKey: "expr", wantValue: "a=b"},
+		{name: "missing separator", input: "tier", wantErr: true},
+		{name: "empty key", input: "=canary", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			key, value, err := ParseTagFilter(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTagFilter(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if key != tt.wantKey || value != tt.wantValue {
+				t.Errorf("ParseTagFilter(%q) = (%q, %q), want (%q, %q)", tt.input, key, value, tt.wantKey, tt.wantValue)
+			}
+		})
+	}
+}
+
+func TestEnvironmentARN(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		region string
+		want   string
+	}{
+		{"us-east-1", "arn:aws:appconfig:us-east-1:123456789012:application/app/environment/env"},
+		{"cn-north-1", "arn:aws-cn:appconfig:cn-north-1:123456789012:application/app/environment/env"},
+		{"us-gov-west-1", "arn:aws-us-gov:appconfig:us-gov-west-1:123456789012:application/app/environment/env"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.region, func(t *testing.T) {
+			t.Parallel()
+			if got := EnvironmentARN(tt.region, "123456789012", "app", "env"); got != tt.want {
+				t.Errorf("EnvironmentARN() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListEnvironmentsByTag(t *testing.T) {
+	t.Parallel()
+
+	envTags := map[string]map[string]string{
+		"env-1": {"tier": "canary"},
+		"env-2": {"tier": "production"},
+		"env-3": {"tier": "canary", "team": "a"},
+		"env-4": {},
+	}
+	newAppConfigMock := func(tagsErr error) *mock.MockAppConfigClient {
+		return &mock.MockAppConfigClient{
+			ListEnvironmentsFunc: func(ctx context.Context, params *appconfig.ListEnvironmentsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListEnvironmentsOutput, error) {
+				return &appconfig.ListEnvironmentsOutput{
+					Items: []types.Environment{
+						{Id: aws.String("env-1"), Name: aws.String("canary-a")},
+						{Id: aws.String("env-2"), Name: aws.String("production")},
+						{Id: aws.String("env-3"), Name: aws.String("canary-b")},
+						{Id: aws.String("env-4"), Name: aws.String("untagged")},
+					},
+				}, nil
+			},
+			ListTagsForResourceFunc: func(ctx context.Context, params *appconfig.ListTagsForResourceInput, optFns ...func(*appconfig.Options)) (*appconfig.ListTagsForResourceOutput, error) {
+				if tagsErr != nil {
+					return nil, tagsErr
+				}
+				arn := aws.ToString(params.ResourceArn)
+				if !strings.HasPrefix(arn, "arn:aws:appconfig:us-east-1:123456789012:application/app-1/environment/") {
+					t.Errorf("unexpected ResourceArn %q", arn)
+				}
+				return &appconfig.ListTagsForResourceOutput{Tags: envTags[arn[strings.LastIndex(arn, "/")+1:]]}, nil
+			},
+		}
+	}
+	stsMock := &mock.MockSTSClient{
+		GetCallerIdentityFunc: func(ctx context.Context, params *sts.GetCallerIdentityInput, optFns ...func(*sts.Options)) (*sts.GetCallerIdentityOutput, error) {
+			return &sts.GetCallerIdentityOutput{Account: aws.String("123456789012")}, nil
+		},
+	}
+
+	tests := []struct {
+		name      string
+		key       string
+		value     string
+		tagsErr   error
+		wantNames []string
+		wantErr   bool
+	}{
+		{name: "matches in list order", key: "tier", value: "canary", wantNames: []string{"canary-a", "canary-b"}},
+		{name: "single match", key: "team", value: "a", wantNames: []string{"canary-b"}},
+		{name: "no match", key: "tier", value: "staging", wantNames: nil},
+		{name: "tag lookup error", key: "tier", value: "canary", tagsErr: errors.New("access denied"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client := NewTestClient(newAppConfigMock(tt.tagsErr))
+			client.STS = stsMock
+
+			got, err := client.ListEnvironmentsByTag(context.Background(), "app-1", tt.key, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ListEnvironmentsByTag() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var names []string
+			for _, env := range got {
+				names = append(names, aws.ToString(env.Name))
+			}
+			if strings.Join(names, ",") != strings.Join(tt.wantNames, ",") {
+				t.Errorf("ListEnvironmentsByTag() = %v, want %v", names, tt.wantNames)
+			}
+		})
+	}
+}
+
+func TestAccountIDWithoutSTS(t *testing.T) {
+	t.Parallel()
+
+	client := NewTestClient(&mock.MockAppConfigClient{})
+	if _, err := client.AccountID(context.Background()); err == nil {
+		t.Error("AccountID() expected error without an STS client")
+	}
+}
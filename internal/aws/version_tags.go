@@ -0,0 +1,134 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig"
+)
+
+// VersionTag is a key=value pair recorded on a hosted configuration version
+// by run --version-tag (CI build id, commit, pipeline run, ...). Hosted
+// versions cannot carry AppConfig resource tags, so the pairs are embedded
+// at the end of the version description in a parseable block:
+//
+//	Deployed by apcdeploy [apcdeploy-tags: build=123; commit=4f2a9c1]
+type VersionTag struct {
+	Key   string
+	Value string
+}
+
+const (
+	versionTagsOpen  = "[apcdeploy-tags: "
+	versionTagsClose = "]"
+	versionTagsSep   = "; "
+)
+
+// versionTagKey limits keys to characters that survive the embedded format
+// and read naturally in CI (e.g. "ci.build", "git/commit").
+var versionTagKey = regexp.MustCompile(`^[A-Za-z0-9_.:/@+-]+$`)
+
+// ParseVersionTags parses --version-tag values ("key=value"). Keys must be
+// unique; values may be empty but cannot contain ';', ']' or line breaks,
+// which would make the embedded block ambiguous.
+func ParseVersionTags(values []string) ([]VersionTag, error) {
+	tags := make([]VersionTag, 0, len(values))
+	seen := make(map[string]bool, len(values))
+	for _, s := range values {
+		key, value, ok := strings.Cut(s, "=")
+		if !ok || !versionTagKey.MatchString(key) {
+			return nil, fmt.Errorf("invalid version tag %q: expected key=value with a key of letters, digits and _.:/@+-", s)
+		}
+		if strings.ContainsAny(value, ";]\r\n") {
+			return nil, fmt.Errorf("invalid version tag %q: the value cannot contain ';', ']' or line breaks", s)
+		}
+		if seen[key] {
+			return nil, fmt.Errorf("duplicate version tag key %q", key)
+		}
+		seen[key] = true
+		tags = append(tags, VersionTag{Key: key, Value: value})
+	}
+	return tags, nil
+}
+
+// AppendVersionTags embeds tags at the end of a version description. The
+// description is returned unchanged when there are no tags.
+func AppendVersionTags(description string, tags []VersionTag) string {
+	if len(tags) == 0 {
+		return description
+	}
+	block := versionTagsOpen + FormatVersionTags(tags, versionTagsSep) + versionTagsClose
+	if description == "" {
+		return block
+	}
+	return description + " " + block
+}
+
+// SplitVersionTags separates a version description into the text written
+// by the user and the tags embedded by AppendVersionTags. Descriptions
+// without a well-formed block are returned whole, with no tags.
+func SplitVersionTags(description string) (string, []VersionTag) {
+	start := strings.LastIndex(description, versionTagsOpen)
+	if start < 0 || !strings.HasSuffix(description, versionTagsClose) {
+		return description, nil
+	}
+	body := strings.TrimSuffix(description[start+len(versionTagsOpen):], versionTagsClose)
+	var tags []VersionTag
+	for pair := range strings.SplitSeq(body, versionTagsSep) {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || !versionTagKey.MatchString(key) {
+			return description, nil
+		}
+		tags = append(tags, VersionTag{Key: key, Value: value})
+	}
+	return strings.TrimSuffix(description[:start], " "), tags
+}
+
+// FormatVersionTags joins tags as key=value pairs separated by sep.
+func FormatVersionTags(tags []VersionTag, sep string) string {
+	pairs := make([]string, len(tags))
+	for i, t := range tags {
+		pairs[i] = t.Key + "=" + t.Value
+	}
+	return strings.Join(pairs, sep)
+}
+
+// ErrVersionNotFound is returned when a hosted configuration version is not
+// among the profile's versions.
+var ErrVersionNotFound = errors.New("hosted configuration version not found")
+
+// GetHostedConfigurationVersionDescription returns the description of a
+// hosted configuration version. The description is read from the version
+// list, newest first, so the content itself is never downloaded. A version
+// that is not listed is ErrVersionNotFound.
+func GetHostedConfigurationVersionDescription(ctx context.Context, client *Client, applicationID, profileID, versionNumber string) (string, error) {
+	version, err := strconv.ParseInt(versionNumber, 10, 32)
+	if err != nil {
+		return "", fmt.Errorf("invalid version number: %s", versionNumber)
+	}
+	var nextToken *string
+	for {
+		output, err := client.appConfig.ListHostedConfigurationVersions(ctx, &appconfig.ListHostedConfigurationVersionsInput{
+			ApplicationId:          aws.String(applicationID),
+			ConfigurationProfileId: aws.String(profileID),
+			NextToken:              nextToken,
+		})
+		if err != nil {
+			return "", wrapAWSError(err, "failed to list hosted configuration versions")
+		}
+		for _, item := range output.Items {
+			if item.VersionNumber == int32(version) {
+				return aws.ToString(item.Description), nil
+			}
+		}
+		if output.NextToken == nil {
+			return "", ErrVersionNotFound
+		}
+		nextToken = output.NextToken
+	}
+}
@@ -0,0 +1,128 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig/types"
+	"github.com/koh-sh/apcdeploy/internal/aws/mock"
+)
+
+func TestParseVersionTags(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		values  []string
+		want    []VersionTag
+		wantErr string
+	}{
+		{name: "pairs in order", values: []string{"build=123", "git/commit=4f2a9c1", "note="}, want: []VersionTag{{"build", "123"}, {"git/commit", "4f2a9c1"}, {"note", ""}}},
+		{name: "value may contain =", values: []string{"url=https://ci/run?id=1"}, want: []VersionTag{{"url", "https://ci/run?id=1"}}},
+		{name: "missing equals", values: []string{"build"}, wantErr: "expected key=value"},
+		{name: "space in key", values: []string{"ci build=1"}, wantErr: "expected key=value"},
+		{name: "separator in value", values: []string{"note=a; b"}, wantErr: "cannot contain"},
+		{name: "duplicate key", values: []string{"build=1", "build=2"}, wantErr: `duplicate version tag key "build"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseVersionTags(tt.values)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseVersionTags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVersionTagsRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	tags := []VersionTag{{"build", "123"}, {"commit", "4f2a9c1"}}
+	tests := []struct {
+		name        string
+		description string
+		want        string
+	}{
+		{name: "with description", description: "Deployed by apcdeploy", want: "Deployed by apcdeploy [apcdeploy-tags: build=123; commit=4f2a9c1]"},
+		{name: "empty description", description: "", want: "[apcdeploy-tags: build=123; commit=4f2a9c1]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := AppendVersionTags(tt.description, tags)
+			if got != tt.want {
+				t.Fatalf("AppendVersionTags() = %q, want %q", got, tt.want)
+			}
+			text, parsed := SplitVersionTags(got)
+			if text != tt.description || !reflect.DeepEqual(parsed, tags) {
+				t.Errorf("SplitVersionTags() = (%q, %v), want (%q, %v)", text, parsed, tt.description, tags)
+			}
+		})
+	}
+
+	if got := AppendVersionTags("unchanged", nil); got != "unchanged" {
+		t.Errorf("AppendVersionTags without tags = %q", got)
+	}
+}
+
+func TestSplitVersionTagsWithoutBlock(t *testing.T) {
+	t.Parallel()
+
+	for _, description := range []string{
+		"plain description",
+		"ends with [brackets]",
+		"[apcdeploy-tags: not a pair]",
+	} {
+		text, tags := SplitVersionTags(description)
+		if text != description || tags != nil {
+			t.Errorf("SplitVersionTags(%q) = (%q, %v), want the description unchanged", description, text, tags)
+		}
+	}
+}
+
+func TestGetHostedConfigurationVersionDescription(t *testing.T) {
+	t.Parallel()
+
+	// Two pages, newest first; version 4 is on the second page.
+	client := NewTestClient(&mock.MockAppConfigClient{
+		ListHostedConfigurationVersionsFunc: func(ctx context.Context, params *appconfig.ListHostedConfigurationVersionsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListHostedConfigurationVersionsOutput, error) {
+			if params.NextToken == nil {
+				return &appconfig.ListHostedConfigurationVersionsOutput{
+					Items:     []types.HostedConfigurationVersionSummary{{VersionNumber: 6}, {VersionNumber: 5}},
+					NextToken: aws.String("page-2"),
+				}, nil
+			}
+			return &appconfig.ListHostedConfigurationVersionsOutput{
+				Items: []types.HostedConfigurationVersionSummary{{VersionNumber: 4, Description: aws.String("release notes")}},
+			}, nil
+		},
+	})
+
+	got, err := GetHostedConfigurationVersionDescription(context.Background(), client, "app", "profile", "4")
+	if err != nil || got != "release notes" {
+		t.Errorf("got (%q, %v), want (\"release notes\", nil)", got, err)
+	}
+	if _, err := GetHostedConfigurationVersionDescription(context.Background(), client, "app", "profile", "3"); !errors.Is(err, ErrVersionNotFound) {
+		t.Errorf("err = %v, want ErrVersionNotFound for an unlisted version", err)
+	}
+	if _, err := GetHostedConfigurationVersionDescription(context.Background(), client, "app", "profile", "s3-version-id"); err == nil {
+		t.Error("expected an error for a non-numeric version")
+	}
+}
@@ -0,0 +1,104 @@
+// Package bulk runs a read-only command against every target listed in a
+// --profiles-from-file targets file. Each target keeps its own Targets row;
+// the per-target work runs concurrently, up to --concurrency targets at once,
+// and the caller aggregates results.
+package bulk
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/koh-sh/apcdeploy/internal/aws"
+	"github.com/koh-sh/apcdeploy/internal/config"
+)
+
+// DefaultConcurrency is the number of targets processed at once when
+// --concurrency is not given.
+const DefaultConcurrency = 4
+
+// Target is one entry of a targets file paired with the AWS client for its
+// region and its canonical identifier.
+type Target struct {
+	ID     string
+	Config *config.Config
+	Client *aws.Client
+}
+
+// Prepare loads the targets file and builds one AWS client per distinct
+// region. Clients are shared between targets in the same region so the SDK
+// default config chain is only evaluated once per region. A non-empty region
+// (--region) overrides every target's region. Targets rejected by filter
+// (--app / --profile-regex) are dropped before any client is built; a filter
+// that keeps nothing is an error.
+func Prepare(ctx context.Context, path, region string, filter *config.ProfileFilter, factory func(context.Context, string) (*aws.Client, error)) ([]Target, error) {
+	cfgs, err := config.LoadTargetsFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	clients := make(map[string]*aws.Client)
+	targets := make([]Target, 0, len(cfgs))
+	for _, cfg := range cfgs {
+		if !filter.Match(cfg.Application, cfg.ConfigurationProfile) {
+			continue
+		}
+		cfg.ApplyRegionOverride(region)
+		client, ok := clients[cfg.Region]
+		if !ok {
+			client, err = factory(ctx, cfg.Region)
+			if err != nil {
+				return nil, fmt.Errorf("failed to initialize AWS client: %w", err)
+			}
+			clients[cfg.Region] = client
+		}
+		targets = append(targets, Target{
+			ID:     config.Identifier(client.Region, cfg),
+			Config: cfg,
+			Client: client,
+		})
+	}
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("no targets in %s match %s", path, filter)
+	}
+	return targets, nil
+}
+
+// IDs returns the identifiers of targets in file order, ready to be passed
+// to Reporter.Targets.
+func IDs(targets []Target) []string {
+	ids := make([]string, len(targets))
+	for i, t := range targets {
+		ids[i] = t.ID
+	}
+	return ids
+}
+
+// Run calls fn for every target, at most concurrency at once, and waits for
+// all of them. A concurrency below 1 uses DefaultConcurrency. fn receives
+// the target's index so callers can store results in a pre-sized slice
+// without extra locking.
+func Run(targets []Target, concurrency int, fn func(i int, t Target)) {
+	if concurrency < 1 {
+		concurrency = DefaultConcurrency
+	}
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, t := range targets {
+		wg.Go(func() {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			fn(i, t)
+		})
+	}
+	wg.Wait()
+}
+
+// FailedError summarizes a bulk run in which some targets failed. It returns
+// nil when failed is zero.
+func FailedError(failed, total int) error {
+	if failed == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d of %d targets failed", failed, total)
+}
@@ -0,0 +1,218 @@
+package bulk
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/koh-sh/apcdeploy/internal/aws"
+	"github.com/koh-sh/apcdeploy/internal/aws/mock"
+	"github.com/koh-sh/apcdeploy/internal/config"
+)
+
+func writeTargets(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "targets.yml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write targets file: %v", err)
+	}
+	return path
+}
+
+func TestPrepare(t *testing.T) {
+	t.Parallel()
+
+	path := writeTargets(t, `- application: app
+  profile: flags
+  environment: prod
+  region: us-east-1
+- application: app
+  profile: flags
+  environment: staging
+  region: us-east-1
+- application: app
+  profile: flags
+  environment: prod
+  region: eu-west-1
+`)
+
+	var calls atomic.Int32
+	factory := func(_ context.Context, region string) (*aws.Client, error) {
+		calls.Add(1)
+		return aws.NewTestClientFull(&mock.MockAppConfigClient{}, nil, region, 0), nil
+	}
+
+	targets, err := Prepare(context.Background(), path, "", nil, factory)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("expected one client per region (2), got %d", got)
+	}
+	want := []string{
+		"us-east-1/app/flags/prod",
+		"us-east-1/app/flags/staging",
+		"eu-west-1/app/flags/prod",
+	}
+	ids := IDs(targets)
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Errorf("ids[%d] = %q, want %q", i, ids[i], want[i])
+		}
+	}
+}
+
+func TestPrepareRegionOverride(t *testing.T) {
+	t.Parallel()
+
+	path := writeTargets(t, `- application: app
+  profile: flags
+  environment: prod
+  region: us-east-1
+- application: app
+  profile: flags
+  environment: prod
+  region: eu-west-1
+`)
+
+	var regions []string
+	factory := func(_ context.Context, region string) (*aws.Client, error) {
+		regions = append(regions, region)
+		return aws.NewTestClientFull(&mock.MockAppConfigClient{}, nil, region, 0), nil
+	}
+
+	targets, err := Prepare(context.Background(), path, "ap-northeast-1", nil, factory)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(regions) != 1 || regions[0] != "ap-northeast-1" {
+		t.Errorf("factory regions = %v, want [ap-northeast-1]", regions)
+	}
+	for _, tg := range targets {
+		if tg.Config.Region != "ap-northeast-1" {
+			t.Errorf("target %s region = %q, want ap-northeast-1", tg.ID, tg.Config.Region)
+		}
+	}
+}
+
+func TestPrepareFilter(t *testing.T) {
+	t.Parallel()
+
+	path := writeTargets(t, `- application: web
+  profile: feature-ui
+  environment: prod
+  region: us-east-1
+- application: web
+  profile: theme
+  environment: prod
+  region: us-east-1
+- application: api
+  profile: feature-flags
+  environment: prod
+  region: eu-west-1
+`)
+
+	var calls atomic.Int32
+	factory := func(_ context.Context, region string) (*aws.Client, error) {
+		calls.Add(1)
+		return aws.NewTestClientFull(&mock.MockAppConfigClient{}, nil, region, 0), nil
+	}
+
+	filter, err := config.NewProfileFilter("web", "^feature-")
+	if err != nil {
+		t.Fatalf("NewProfileFilter() error = %v", err)
+	}
+	targets, err := Prepare(context.Background(), path, "", filter, factory)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ids := IDs(targets); len(ids) != 1 || ids[0] != "us-east-1/web/feature-ui/prod" {
+		t.Errorf("ids = %v, want [us-east-1/web/feature-ui/prod]", ids)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("filtered-out regions should not get a client, got %d clients", got)
+	}
+
+	filter, err = config.NewProfileFilter("", "^nothing$")
+	if err != nil {
+		t.Fatalf("NewProfileFilter() error = %v", err)
+	}
+	if _, err := Prepare(context.Background(), path, "", filter, factory); err == nil || !strings.Contains(err.Error(), "no targets") {
+		t.Errorf("expected an error when no target matches, got: %v", err)
+	}
+}
+
+func TestPrepareFactoryError(t *testing.T) {
+	t.Parallel()
+
+	path := writeTargets(t, `- application: app
+  profile: flags
+  environment: prod
+`)
+	factory := func(context.Context, string) (*aws.Client, error) {
+		return nil, errors.New("no credentials")
+	}
+
+	if _, err := Prepare(context.Background(), path, "", nil, factory); err == nil {
+		t.Fatal("expected error from client factory")
+	}
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		concurrency int
+		wantMax     int32
+	}{
+		{name: "bounded", concurrency: 2, wantMax: 2},
+		{name: "default", concurrency: 0, wantMax: DefaultConcurrency},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			targets := make([]Target, 10)
+			seen := make([]bool, len(targets))
+			var running, peak atomic.Int32
+			Run(targets, tt.concurrency, func(i int, _ Target) {
+				n := running.Add(1)
+				for {
+					p := peak.Load()
+					if n <= p || peak.CompareAndSwap(p, n) {
+						break
+					}
+				}
+				time.Sleep(10 * time.Millisecond)
+				running.Add(-1)
+				seen[i] = true
+			})
+			for i, ok := range seen {
+				if !ok {
+					t.Errorf("target %d was not visited", i)
+				}
+			}
+			if got := peak.Load(); got > tt.wantMax {
+				t.Errorf("peak concurrency = %d, want at most %d", got, tt.wantMax)
+			}
+		})
+	}
+}
+
+func TestFailedError(t *testing.T) {
+	t.Parallel()
+
+	if err := FailedError(0, 3); err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+	if err := FailedError(2, 3); err == nil || err.Error() != "2 of 3 targets failed" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
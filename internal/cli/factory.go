@@ -1,13 +1,29 @@
 package cli
 
-import "github.com/koh-sh/apcdeploy/internal/reporter"
+import (
+	"io"
+	"os"
 
-// GetReporter returns the appropriate Reporter based on the --silent flag.
-// This is the single source of truth for silent-mode selection — executors
-// must not branch on opts.Silent themselves.
-func GetReporter(silent bool) reporter.Reporter {
+	"github.com/koh-sh/apcdeploy/internal/reporter"
+)
+
+// GetReporter returns the appropriate Reporter based on the --silent and
+// --summary-only flags. This is the single source of truth for output-mode
+// selection — executors must not branch on opts.Silent themselves. --silent
+// takes precedence when both are set.
+func GetReporter(silent, summaryOnly bool) reporter.Reporter {
+	return GetReporterWithWriters(silent, summaryOnly, os.Stdout, os.Stderr)
+}
+
+// GetReporterWithWriters is GetReporter writing payloads to out and
+// everything else to errOut instead of the process stdout and stderr, for
+// embedding the commands or asserting on their rendered output.
+func GetReporterWithWriters(silent, summaryOnly bool, out, errOut io.Writer) reporter.Reporter {
 	if silent {
-		return NewSilentReporter()
+		return NewSilentReporterWithWriters(out, errOut)
+	}
+	if summaryOnly {
+		return NewSummaryReporterWithWriters(out, errOut)
 	}
-	return NewReporter()
+	return NewReporterWithWriters(out, errOut)
 }
@@ -1,6 +1,8 @@
 package cli
 
 import (
+	"bytes"
+	"strings"
 	"testing"
 )
 
@@ -9,41 +11,78 @@ func TestGetReporter(t *testing.T) {
 	tests := []struct {
 		name        string
 		silent      bool
+		summaryOnly bool
 		wantType    string
-		description string
 	}{
 		{
-			name:        "returns regular reporter when silent is false",
-			silent:      false,
-			wantType:    "*cli.Reporter",
-			description: "Should return a regular Reporter when silent mode is disabled",
+			name:     "returns regular reporter by default",
+			wantType: "*cli.Reporter",
 		},
 		{
-			name:        "returns silent reporter when silent is true",
+			name:     "returns silent reporter when silent is true",
+			silent:   true,
+			wantType: "*cli.SilentReporter",
+		},
+		{
+			name:        "returns summary reporter when summary-only is true",
+			summaryOnly: true,
+			wantType:    "*cli.SummaryReporter",
+		},
+		{
+			name:        "silent takes precedence over summary-only",
 			silent:      true,
+			summaryOnly: true,
 			wantType:    "*cli.SilentReporter",
-			description: "Should return a SilentReporter when silent mode is enabled",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			got := GetReporter(tt.silent)
-
-			// Check that it implements the interface
-			_ = got
-
-			// Check the concrete type
-			switch tt.silent {
-			case true:
-				if _, ok := got.(*SilentReporter); !ok {
-					t.Errorf("GetReporter(%v) = %T, want %s", tt.silent, got, tt.wantType)
-				}
-			case false:
-				if _, ok := got.(*Reporter); !ok {
-					t.Errorf("GetReporter(%v) = %T, want %s", tt.silent, got, tt.wantType)
-				}
+			got := GetReporter(tt.silent, tt.summaryOnly)
+
+			var ok bool
+			switch tt.wantType {
+			case "*cli.SilentReporter":
+				_, ok = got.(*SilentReporter)
+			case "*cli.SummaryReporter":
+				_, ok = got.(*SummaryReporter)
+			default:
+				_, ok = got.(*Reporter)
+			}
+			if !ok {
+				t.Errorf("GetReporter(%v, %v) = %T, want %s", tt.silent, tt.summaryOnly, got, tt.wantType)
+			}
+		})
+	}
+}
+
+func TestGetReporterWithWriters(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		silent      bool
+		summaryOnly bool
+	}{
+		{name: "regular reporter"},
+		{name: "silent reporter", silent: true},
+		{name: "summary reporter", summaryOnly: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var out, errOut bytes.Buffer
+			rep := GetReporterWithWriters(tt.silent, tt.summaryOnly, &out, &errOut)
+
+			rep.Data([]byte("payload\n"))
+			rep.Error("boom")
+
+			if got := out.String(); got != "payload\n" {
+				t.Errorf("out = %q, want %q", got, "payload\n")
+			}
+			if got := errOut.String(); !strings.Contains(got, "boom") || strings.Contains(got, "payload") {
+				t.Errorf("errOut = %q, want only the error", got)
 			}
 		})
 	}
@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/koh-sh/apcdeploy/internal/config"
+	"github.com/koh-sh/apcdeploy/internal/reporter"
+)
+
+// FileDataReporter wraps a Reporter and captures Data payloads so they can
+// be written to a file (--output-file) instead of stdout. Every other kind,
+// including Diff, is delegated unchanged.
+type FileDataReporter struct {
+	reporter.Reporter
+
+	// NoClobber makes Flush fail with an error matching fs.ErrExist instead
+	// of replacing an existing file
+	NoClobber bool
+
+	path string
+	mu   sync.Mutex
+	buf  bytes.Buffer
+}
+
+// NewFileDataReporter returns a FileDataReporter that writes captured Data
+// to path on Flush.
+func NewFileDataReporter(rep reporter.Reporter, path string) *FileDataReporter {
+	return &FileDataReporter{Reporter: rep, path: path}
+}
+
+// Data buffers p instead of writing it to stdout.
+func (r *FileDataReporter) Data(p []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.buf.Write(p)
+}
+
+// Flush writes the captured payload to the output file, creating parent
+// directories as needed. The file is replaced atomically so a failed run
+// never leaves a half-written report behind. Nothing is written when no
+// payload was captured (e.g. the command failed before producing one).
+func (r *FileDataReporter) Flush() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.buf.Len() == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	write := config.WriteFileAtomic
+	if r.NoClobber {
+		write = config.CreateFileAtomic
+	}
+	if err := write(r.path, r.buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
+	return nil
+}
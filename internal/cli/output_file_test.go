@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	reportertest "github.com/koh-sh/apcdeploy/internal/reporter/testing"
+)
+
+func TestFileDataReporter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		data     []string
+		wantFile bool
+	}{
+		{"writes captured payload", []string{`{"a":`, "1}\n"}, true},
+		{"skips file when nothing captured", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "nested", "dir", "report.json")
+			inner := &reportertest.MockReporter{}
+			rep := NewFileDataReporter(inner, path)
+			for _, d := range tt.data {
+				rep.Data([]byte(d))
+			}
+			rep.Diff([]byte("+x\n"))
+
+			if err := rep.Flush(); err != nil {
+				t.Fatalf("Flush() error = %v", err)
+			}
+			if string(inner.Stdout) != "+x\n" {
+				t.Errorf("expected only the diff on stdout, got %q", inner.Stdout)
+			}
+
+			got, err := os.ReadFile(path)
+			if !tt.wantFile {
+				if !os.IsNotExist(err) {
+					t.Errorf("expected no output file, got err=%v content=%q", err, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to read output file: %v", err)
+			}
+			if string(got) != "{\"a\":1}\n" {
+				t.Errorf("output file = %q", got)
+			}
+		})
+	}
+}
@@ -14,49 +14,62 @@ import (
 // Reporter is the TTY-aware Reporter implementation backed by lipgloss styles.
 // It writes human-facing kinds to stderr and machine-readable payloads to
 // stdout, degrading borders/animations when the underlying file is not a
-// terminal.
+// terminal (or when --plain is set).
 type Reporter struct {
 	outW   io.Writer
 	errW   io.Writer
 	outTTY bool
 	errTTY bool
+	// colorDiff colors Diff output even when stdout is not a terminal
+	// (--color always without --plain)
+	colorDiff bool
 }
 
 var _ reporter.Reporter = (*Reporter)(nil)
 
 // NewReporter constructs a Reporter bound to os.Stdout / os.Stderr.
 func NewReporter() *Reporter {
+	return NewReporterWithWriters(os.Stdout, os.Stderr)
+}
+
+// NewReporterWithWriters constructs a Reporter that writes payloads to out
+// and everything else to errOut. Terminal features are only enabled for a
+// writer that is an *os.File connected to a terminal, so a buffer receives
+// plain output.
+func NewReporterWithWriters(out, errOut io.Writer) *Reporter {
 	return &Reporter{
-		outW:   os.Stdout,
-		errW:   os.Stderr,
-		outTTY: IsTerminal(os.Stdout),
-		errTTY: IsTerminal(os.Stderr),
+		outW:   out,
+		errW:   errOut,
+		outTTY: !plainMode && IsTerminalWriter(out),
+		errTTY: !plainMode && IsTerminalWriter(errOut),
+
+		colorDiff: forceColor && !plainMode,
 	}
 }
 
 // Step announces the start of a long-running step.
 func (r *Reporter) Step(msg string) {
-	fmt.Fprintf(r.errW, "%s %s\n", styles.step.Render(symStep), msg)
+	fmt.Fprintf(r.errW, "%s %s\n", styles.step.Render(glyphs.step), msg)
 }
 
 // Success marks a step as successfully completed.
 func (r *Reporter) Success(msg string) {
-	fmt.Fprintf(r.errW, "%s %s\n", styles.success.Render(symSuccess), msg)
+	fmt.Fprintf(r.errW, "%s %s\n", styles.success.Render(glyphs.success), msg)
 }
 
 // Info reports neutral information.
 func (r *Reporter) Info(msg string) {
-	fmt.Fprintf(r.errW, "%s %s\n", styles.info.Render(symInfo), msg)
+	fmt.Fprintf(r.errW, "%s %s\n", styles.info.Render(glyphs.info), msg)
 }
 
 // Warn reports a non-fatal anomaly.
 func (r *Reporter) Warn(msg string) {
-	fmt.Fprintf(r.errW, "%s %s\n", styles.warn.Render(symWarn), msg)
+	fmt.Fprintf(r.errW, "%s %s\n", styles.warn.Render(glyphs.warn), msg)
 }
 
 // Error reports a fatal error.
 func (r *Reporter) Error(msg string) {
-	fmt.Fprintf(r.errW, "%s %s\n", styles.errorS.Render(symError), msg)
+	fmt.Fprintf(r.errW, "%s %s\n", styles.errorS.Render(glyphs.errorS), msg)
 }
 
 // Header renders a section heading. In TTY mode it emits a styled title with
@@ -129,9 +142,10 @@ func (r *Reporter) Data(p []byte) {
 	_, _ = r.outW.Write(p)
 }
 
-// Diff writes a unified diff payload to stdout. Colorized when stdout is a TTY.
+// Diff writes a unified diff payload to stdout. Colorized when stdout is a
+// TTY or --color always is set.
 func (r *Reporter) Diff(p []byte) {
-	if !r.outTTY {
+	if !r.outTTY && !r.colorDiff {
 		_, _ = r.outW.Write(p)
 		return
 	}
@@ -22,9 +22,15 @@ var _ reporter.Reporter = (*SilentReporter)(nil)
 
 // NewSilentReporter constructs a SilentReporter bound to os.Stdout / os.Stderr.
 func NewSilentReporter() *SilentReporter {
+	return NewSilentReporterWithWriters(os.Stdout, os.Stderr)
+}
+
+// NewSilentReporterWithWriters constructs a SilentReporter writing to out
+// and errOut.
+func NewSilentReporterWithWriters(out, errOut io.Writer) *SilentReporter {
 	return &SilentReporter{
-		outW: os.Stdout,
-		errW: os.Stderr,
+		outW: out,
+		errW: errOut,
 	}
 }
 
@@ -35,7 +41,7 @@ func (r *SilentReporter) Warn(string)    {}
 
 // Error is the one stderr kind that is preserved in silent mode.
 func (r *SilentReporter) Error(msg string) {
-	fmt.Fprintf(r.errW, "%s %s\n", symError, msg)
+	fmt.Fprintf(r.errW, "%s %s\n", glyphs.errorS, msg)
 }
 
 func (r *SilentReporter) Header(string)              {}
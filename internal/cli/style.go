@@ -1,23 +1,70 @@
 package cli
 
 import (
+	"fmt"
+	"os"
+
 	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 )
 
-// Symbols used as line prefixes by the Reporter. The contract limits visual
-// glyphs to this set — see .claude/rules/output-contract.md.
+// glyphSet holds the line prefix of each Reporter kind. Every prefix this
+// package prints goes through the active set in glyphs, so --plain can swap
+// all of them in one place.
 //
-// symPending and symSkip are reserved for the multi-item Checklist primitive
-// (pending row and skipped row respectively); the rest are emitted by their
+// pending and skip are reserved for the multi-item Targets rows (pending
+// row and skipped row respectively); the rest are emitted by their
 // corresponding Reporter kinds.
+type glyphSet struct {
+	step    string
+	success string
+	info    string
+	warn    string
+	errorS  string
+	pending string
+	skip    string
+}
+
+// decoratedGlyphs is the default set. The contract limits visual glyphs to
+// this set — see .claude/rules/output-contract.md.
+var decoratedGlyphs = glyphSet{
+	step:    "⏳",
+	success: "✓",
+	info:    "ℹ",
+	warn:    "⚠",
+	errorS:  "✗",
+	pending: "○",
+	skip:    "→",
+}
+
+// plainGlyphs replaces each glyph with an ASCII word for --plain, so tools
+// embedding apcdeploy can match line prefixes without handling Unicode.
+var plainGlyphs = glyphSet{
+	step:    "step:",
+	success: "ok:",
+	info:    "info:",
+	warn:    "warning:",
+	errorS:  "error:",
+	pending: "pending:",
+	skip:    "skipped:",
+}
+
+var (
+	// glyphs is the active prefix set; ConfigurePlain switches it.
+	glyphs = decoratedGlyphs
+	// plainMode forces the non-TTY rendering (no boxes, table borders,
+	// spinners or in-place rows) even on a terminal. Set by ConfigurePlain.
+	plainMode bool
+	// forceColor keeps colors on when output is piped. Set by
+	// ConfigureColor(ColorAlways).
+	forceColor bool
+)
+
+// Color modes accepted by --color.
 const (
-	symStep    = "⏳"
-	symSuccess = "✓"
-	symInfo    = "ℹ"
-	symWarn    = "⚠"
-	symError   = "✗"
-	symPending = "○"
-	symSkip    = "→"
+	ColorAuto   = "auto"
+	ColorAlways = "always"
+	ColorNever  = "never"
 )
 
 // styles holds the lipgloss styles used by the Reporter. Centralizing them
@@ -79,6 +126,59 @@ var styles = struct {
 	diffPlain: lipgloss.NewStyle(),
 }
 
+// ConfigureColor is the single switch for ANSI colors. Every styled string in
+// this package (Reporter kinds, diff lines, tables, StateBadge, ...) goes
+// through lipgloss, which already renders plain text when stdout is not a
+// terminal or NO_COLOR is set. mode is the --color value: ColorAuto keeps
+// that detection, ColorNever (or --no-color) forces the plain profile on a
+// terminal, and ColorAlways forces 256 colors even when piped or NO_COLOR is
+// set, so commands never decide on color themselves. --plain takes
+// precedence: under ConfigurePlain(true) every mode renders without color.
+// Call it before constructing a Reporter.
+func ConfigureColor(mode string) error {
+	forceColor = false
+	switch mode {
+	case ColorAuto:
+		if os.Getenv("NO_COLOR") != "" {
+			lipgloss.SetColorProfile(termenv.Ascii)
+		}
+	case ColorAlways:
+		if plainMode {
+			break
+		}
+		forceColor = true
+		lipgloss.SetColorProfile(termenv.ANSI256)
+	case ColorNever:
+		lipgloss.SetColorProfile(termenv.Ascii)
+	default:
+		return fmt.Errorf("invalid --color %q: must be one of %s, %s, %s", mode, ColorAuto, ColorAlways, ColorNever)
+	}
+	return nil
+}
+
+// ConfigurePlain is the single switch for --plain. It strips every
+// decoration at once: glyph prefixes become ASCII words ("ok:", "warning:",
+// "error:"), colors are disabled even under --color always, and Reporters
+// render as if stderr were not a terminal, so headers, boxes, tables,
+// progress rows and diffs degrade to plain lines. Call it before
+// constructing a Reporter.
+func ConfigurePlain(plain bool) {
+	plainMode = plain
+	if plain {
+		glyphs = plainGlyphs
+		forceColor = false
+		lipgloss.SetColorProfile(termenv.Ascii)
+		return
+	}
+	glyphs = decoratedGlyphs
+}
+
+// WarnPrefix returns the active warning prefix ("⚠", or "warning:" under
+// --plain) for the few notices written outside a Reporter.
+func WarnPrefix() string {
+	return glyphs.warn
+}
+
 // HeadingText renders a label with bold + bright color, used for primary
 // names (region values, application names) in the lsresources tree view.
 func HeadingText(s string) string {
@@ -91,6 +191,12 @@ func SubtleText(s string) string {
 	return styles.subtle.Render(s)
 }
 
+// ErrorText renders a string in the error color, used to highlight failed
+// rows in the status TUI.
+func ErrorText(s string) string {
+	return styles.errorS.Render(s)
+}
+
 // StateBadge renders an AppConfig deployment state with a state-appropriate
 // color. lipgloss honors NO_COLOR and strips ANSI when rendering to a
 // non-terminal, so callers can hand the result straight to Reporter.Table
@@ -1,8 +1,13 @@
 package cli
 
 import (
+	"bytes"
 	"strings"
 	"testing"
+	"unicode"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 )
 
 func TestStateBadge(t *testing.T) {
@@ -41,3 +46,179 @@ func TestHeadingAndSubtleText(t *testing.T) {
 		t.Errorf("SubtleText must preserve raw text")
 	}
 }
+
+// exerciseAllKinds drives every styled Reporter kind plus the exported style
+// helpers.
+func exerciseAllKinds(r *Reporter) {
+	r.Success("done")
+	r.Warn("careful")
+	r.Error("boom")
+	r.Header("Title")
+	r.Box("T", []string{"line"})
+	r.Table([]string{"State"}, [][]string{{StateBadge("COMPLETE")}, {HeadingText("app")}, {SubtleText("id")}})
+	r.Diff([]byte("--- a\n+++ b\n@@ -1 +1 @@\n-old\n+new\n"))
+}
+
+// TestPipedOutputHasNoEscapes asserts that output going to a non-terminal
+// (as under `go test`, where stdout is a pipe) carries no ANSI sequences.
+func TestPipedOutputHasNoEscapes(t *testing.T) {
+	t.Parallel()
+
+	r, out, errBuf := newTestReporter()
+	exerciseAllKinds(r)
+	if got := out.String() + errBuf.String(); strings.Contains(got, "\x1b") {
+		t.Errorf("piped output contains escape sequences: %q", got)
+	}
+}
+
+// TestConfigureColorNoColor forces a color profile as a terminal would get,
+// then checks that ConfigureColor(ColorNever) (--no-color) strips colors even from
+// a TTY-flagged Reporter. It mutates global lipgloss state, so it does not
+// run in parallel and restores the profile afterwards.
+func TestConfigureColorNoColor(t *testing.T) {
+	prev := lipgloss.ColorProfile()
+	t.Cleanup(func() { lipgloss.SetColorProfile(prev) })
+
+	lipgloss.SetColorProfile(termenv.ANSI256)
+	r, out, _ := newTTYReporter()
+	r.Diff([]byte("+new\n"))
+	if !strings.Contains(out.String(), "\x1b[") {
+		t.Fatalf("expected colored diff on a terminal profile, got %q", out.String())
+	}
+
+	if err := ConfigureColor(ColorNever); err != nil {
+		t.Fatal(err)
+	}
+	r, out, errBuf := newTTYReporter()
+	r.Success("done")
+	r.Diff([]byte("+new\n-old\n"))
+	r.Table([]string{"State"}, [][]string{{StateBadge("ROLLED_BACK")}})
+	for _, got := range []string{out.String(), errBuf.String()} {
+		// Cursor-free kinds only: any remaining "\x1b[" would be a color code.
+		if strings.Contains(got, "\x1b[") {
+			t.Errorf("output contains ANSI codes after ConfigureColor(ColorNever): %q", got)
+		}
+	}
+}
+
+func TestConfigureColorNoColorEnv(t *testing.T) {
+	prev := lipgloss.ColorProfile()
+	t.Cleanup(func() { lipgloss.SetColorProfile(prev) })
+	t.Setenv("NO_COLOR", "1")
+
+	lipgloss.SetColorProfile(termenv.ANSI256)
+	if err := ConfigureColor(ColorAuto); err != nil {
+		t.Fatal(err)
+	}
+	if got := StateBadge("COMPLETE"); strings.Contains(got, "\x1b") {
+		t.Errorf("StateBadge() = %q, want plain text with NO_COLOR set", got)
+	}
+}
+
+// TestConfigureColorAlways checks that --color always keeps colors on a
+// piped Reporter, including the diff, and that an unknown mode is rejected.
+// It mutates package and lipgloss state, so it does not run in parallel.
+func TestConfigureColorAlways(t *testing.T) {
+	prev := lipgloss.ColorProfile()
+	t.Cleanup(func() {
+		_ = ConfigureColor(ColorAuto)
+		lipgloss.SetColorProfile(prev)
+	})
+	t.Setenv("NO_COLOR", "1")
+
+	lipgloss.SetColorProfile(termenv.Ascii)
+	if err := ConfigureColor(ColorAlways); err != nil {
+		t.Fatal(err)
+	}
+	var out, errBuf bytes.Buffer
+	r := NewReporter()
+	r.outW, r.errW = &out, &errBuf
+	r.outTTY, r.errTTY = false, false
+	r.Success("done")
+	r.Diff([]byte("+new\n"))
+	for name, got := range map[string]string{"stdout": out.String(), "stderr": errBuf.String()} {
+		if !strings.Contains(got, "\x1b[") {
+			t.Errorf("%s has no ANSI codes under --color always: %q", name, got)
+		}
+	}
+
+	if err := ConfigureColor("sometimes"); err == nil || !strings.Contains(err.Error(), "must be one of auto, always, never") {
+		t.Errorf("ConfigureColor(sometimes) error = %v", err)
+	}
+}
+
+// TestConfigurePlain checks that --plain swaps every glyph for an ASCII word
+// and that Reporters built afterwards render without decoration. It mutates
+// package and lipgloss state, so it does not run in parallel and restores
+// both afterwards.
+func TestConfigurePlain(t *testing.T) {
+	prev := lipgloss.ColorProfile()
+	t.Cleanup(func() {
+		ConfigurePlain(false)
+		lipgloss.SetColorProfile(prev)
+	})
+
+	lipgloss.SetColorProfile(termenv.ANSI256)
+	ConfigurePlain(true)
+
+	var out, errBuf bytes.Buffer
+	r := NewReporter()
+	if r.outTTY || r.errTTY {
+		t.Fatal("plain Reporter must render as non-TTY")
+	}
+	r.outW, r.errW = &out, &errBuf
+	exerciseAllKinds(r)
+	r.Info("note")
+	tg := r.Targets([]string{"app/profile/env"})
+	tg.Done("app/profile/env", "deployed v3")
+	tg.Close()
+
+	got := out.String() + errBuf.String()
+	for _, want := range []string{"ok: done\n", "warning: careful\n", "error: boom\n", "info: note\n", "app/profile/env: ok: deployed v3\n"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("plain output missing %q:\n%s", want, got)
+		}
+	}
+	for _, c := range got {
+		if c > unicode.MaxASCII {
+			t.Fatalf("plain output contains non-ASCII %q:\n%s", c, got)
+		}
+	}
+	if WarnPrefix() != "warning:" {
+		t.Errorf("WarnPrefix() = %q, want warning:", WarnPrefix())
+	}
+
+	// --plain wins over --color always, whichever is configured first
+	for _, plainFirst := range []bool{false, true} {
+		if plainFirst {
+			ConfigurePlain(true)
+		}
+		if err := ConfigureColor(ColorAlways); err != nil {
+			t.Fatal(err)
+		}
+		if !plainFirst {
+			ConfigurePlain(true)
+		}
+		out.Reset()
+		errBuf.Reset()
+		r := NewReporter()
+		if r.colorDiff {
+			t.Errorf("plain Reporter (plain first: %v) must not force diff colors", plainFirst)
+		}
+		r.outW, r.errW = &out, &errBuf
+		r.Success("done")
+		r.Diff([]byte("+new\n-old\n"))
+		if got := out.String() + errBuf.String(); strings.Contains(got, "\x1b[") {
+			t.Errorf("--plain --color always (plain first: %v) emitted ANSI codes: %q", plainFirst, got)
+		}
+		ConfigurePlain(false)
+	}
+	if err := ConfigureColor(ColorAuto); err != nil {
+		t.Fatal(err)
+	}
+
+	ConfigurePlain(false)
+	if WarnPrefix() != "⚠" {
+		t.Errorf("WarnPrefix() after ConfigurePlain(false) = %q, want ⚠", WarnPrefix())
+	}
+}
@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/koh-sh/apcdeploy/internal/reporter"
+)
+
+// SummaryReporter is the --summary-only variant of Reporter. Like
+// SilentReporter it drops per-step progress (Step / Success / Info / Header /
+// Box / Table / Spin and Targets phase/progress updates), but it buffers each
+// target's outcome and prints one concise line per target when the Targets
+// block closes, for both success and failure. Warn and Error still reach
+// stderr; Data / Diff still reach stdout.
+type SummaryReporter struct {
+	SilentReporter
+}
+
+var _ reporter.Reporter = (*SummaryReporter)(nil)
+
+// NewSummaryReporter constructs a SummaryReporter bound to os.Stdout / os.Stderr.
+func NewSummaryReporter() *SummaryReporter {
+	return NewSummaryReporterWithWriters(os.Stdout, os.Stderr)
+}
+
+// NewSummaryReporterWithWriters constructs a SummaryReporter writing to out
+// and errOut.
+func NewSummaryReporterWithWriters(out, errOut io.Writer) *SummaryReporter {
+	return &SummaryReporter{
+		SilentReporter: SilentReporter{
+			outW: out,
+			errW: errOut,
+		},
+	}
+}
+
+// Warn is preserved in summary mode: warnings are not progress.
+func (r *SummaryReporter) Warn(msg string) {
+	fmt.Fprintf(r.errW, "%s %s\n", glyphs.warn, msg)
+}
+
+// Targets returns a handle that records the terminal outcome of each row and
+// emits the summary lines on Close, in the order the ids were supplied.
+func (r *SummaryReporter) Targets(ids []string) reporter.Targets {
+	clean := make([]string, len(ids))
+	for i, id := range ids {
+		clean[i] = sanitizeIdentifier(id)
+	}
+	return &summaryTargets{
+		w:        r.errW,
+		ids:      clean,
+		outcomes: make(map[string]string, len(ids)),
+	}
+}
+
+var _ reporter.Targets = (*summaryTargets)(nil)
+
+type summaryTargets struct {
+	w        io.Writer
+	mu       sync.Mutex
+	ids      []string
+	outcomes map[string]string
+	closed   bool
+}
+
+func (t *summaryTargets) SetPhase(string, string, string)            {}
+func (t *summaryTargets) SetProgress(string, float64, time.Duration) {}
+
+func (t *summaryTargets) Done(id, summary string) {
+	t.record(id, glyphs.success+" "+summary)
+}
+
+func (t *summaryTargets) Fail(id string, err error) {
+	msg := ""
+	if err != nil {
+		msg = err.Error()
+	}
+	t.record(id, glyphs.errorS+" failed: "+msg)
+}
+
+func (t *summaryTargets) Skip(id, reason string) {
+	t.record(id, glyphs.skip+" "+reason)
+}
+
+// record keeps the first terminal outcome per row, mirroring the other
+// Targets implementations where repeated terminal calls are dropped.
+func (t *summaryTargets) record(id, line string) {
+	clean := sanitizeIdentifier(id)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		return
+	}
+	if _, ok := t.outcomes[clean]; ok {
+		return
+	}
+	t.outcomes[clean] = line
+}
+
+// Close emits one `<id>: <outcome>` line per row that reached a terminal
+// state. Rows left running (e.g. after an early return) print nothing; the
+// top-level error line covers them.
+func (t *summaryTargets) Close() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		return
+	}
+	t.closed = true
+	for _, id := range t.ids {
+		if line, ok := t.outcomes[id]; ok {
+			fmt.Fprintf(t.w, "%s: %s\n", id, line)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// newTestSummaryReporter wires the summary reporter to in-memory buffers.
+func newTestSummaryReporter() (*SummaryReporter, *bytes.Buffer, *bytes.Buffer) {
+	var out, errBuf bytes.Buffer
+	r := &SummaryReporter{SilentReporter: SilentReporter{outW: &out, errW: &errBuf}}
+	return r, &out, &errBuf
+}
+
+func TestSummaryReporter_SuppressesProgress(t *testing.T) {
+	t.Parallel()
+
+	r, out, errBuf := newTestSummaryReporter()
+	r.Step("a")
+	r.Success("b")
+	r.Info("c")
+	r.Header("d")
+	r.Box("title", []string{"line"})
+	r.Table([]string{"H"}, [][]string{{"v"}})
+	tg := r.Targets([]string{"app/profile/env"})
+	tg.SetPhase("app/profile/env", "preparing", "")
+	tg.SetProgress("app/profile/env", 0.5, 0)
+
+	if out.Len() != 0 || errBuf.Len() != 0 {
+		t.Errorf("summary reporter emitted progress: stdout=%q stderr=%q", out.String(), errBuf.String())
+	}
+	tg.Close()
+	if errBuf.Len() != 0 {
+		t.Errorf("row without outcome should print nothing, got %q", errBuf.String())
+	}
+}
+
+func TestSummaryReporter_EmitsOutcomesOnClose(t *testing.T) {
+	t.Parallel()
+
+	r, _, errBuf := newTestSummaryReporter()
+	tg := r.Targets([]string{"a", "b", "c"})
+	tg.Fail("b", errors.New("boom"))
+	tg.Done("a", "started — v3, AppConfig.AllAtOnce, deployment #2, previously v2")
+	tg.Done("a", "ignored second outcome")
+	tg.Skip("c", "skipped (no changes)")
+
+	if errBuf.Len() != 0 {
+		t.Fatalf("outcomes must be buffered until Close, got %q", errBuf.String())
+	}
+	tg.Close()
+	tg.Close()
+
+	want := "a: ✓ started — v3, AppConfig.AllAtOnce, deployment #2, previously v2\n" +
+		"b: ✗ failed: boom\n" +
+		"c: → skipped (no changes)\n"
+	if got := errBuf.String(); got != want {
+		t.Errorf("summary output = %q, want %q", got, want)
+	}
+}
+
+func TestSummaryReporter_PreservesWarnErrorAndPayloads(t *testing.T) {
+	t.Parallel()
+
+	r, out, errBuf := newTestSummaryReporter()
+	r.Warn("careful")
+	r.Error("fatal")
+	r.Data([]byte("payload"))
+	r.Diff([]byte("+x\n"))
+
+	if !strings.Contains(errBuf.String(), "careful") || !strings.Contains(errBuf.String(), "fatal") {
+		t.Errorf("Warn and Error should reach stderr; got %q", errBuf.String())
+	}
+	if out.String() != "payload+x\n" {
+		t.Errorf("Data/Diff should reach stdout; got %q", out.String())
+	}
+}
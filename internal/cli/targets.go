@@ -122,15 +122,15 @@ func padID(id string, width int) string {
 func renderRow(row *targetsRow, frame string) string {
 	switch row.state {
 	case rowDone:
-		return styles.success.Render(symSuccess) + " " + row.summary
+		return styles.success.Render(glyphs.success) + " " + row.summary
 	case rowFail:
-		return styles.errorS.Render(symError) + " failed: " + row.errMsg
+		return styles.errorS.Render(glyphs.errorS) + " failed: " + row.errMsg
 	case rowSkip:
-		return styles.subtle.Render(symSkip) + " " + styles.subtle.Render(row.reason)
+		return styles.subtle.Render(glyphs.skip) + " " + styles.subtle.Render(row.reason)
 	case rowRunning:
 		return renderRunning(row, frame)
 	default:
-		return styles.subtle.Render(symPending) + " " + styles.subtle.Render("pending")
+		return styles.subtle.Render(glyphs.pending) + " " + styles.subtle.Render("pending")
 	}
 }
 
@@ -140,12 +140,15 @@ func renderRunning(row *targetsRow, frame string) string {
 	var b strings.Builder
 	if row.hasProgress {
 		b.WriteString(renderBar(row.percent))
-		fmt.Fprintf(&b, "  %3d%% ", clampPercent(row.percent))
+		b.WriteString("  ")
+		b.WriteString(row.phase)
+		b.WriteString(": ")
+		b.WriteString(progressText(row.percent))
 	} else {
 		b.WriteString(styles.step.Render(frame))
 		b.WriteString(" ")
+		b.WriteString(row.phase)
 	}
-	b.WriteString(row.phase)
 	if row.detail != "" {
 		b.WriteString(" ")
 		b.WriteString(styles.subtle.Render(row.detail))
@@ -164,6 +167,12 @@ func renderBar(percent float64) string {
 	return styles.success.Render(full) + styles.subtle.Render(empty)
 }
 
+// progressText renders the "NN% complete" fragment shared by the TTY and
+// plain renderers for the deploying sub-phase.
+func progressText(percent float64) string {
+	return fmt.Sprintf("%d%% complete", clampPercent(percent))
+}
+
 func clampPercent(p float64) int {
 	switch {
 	case p <= 0:
@@ -8,8 +8,8 @@ import (
 
 // plainTargets is the non-TTY Targets implementation. Without in-place
 // updates, each phase transition emits a new line in `<id>: <body>` form
-// (output.md §6.2). Progress is decimated to 25/50/75/100% so CI logs
-// stay clean.
+// (output.md §6.2). Progress emits a line only when the whole percent
+// changes, so CI logs follow the rollout without repeating polls.
 type plainTargets struct {
 	targetsBase
 	w io.Writer
@@ -18,18 +18,18 @@ type plainTargets struct {
 	// the same phase (e.g. successive SetPhase("preparing")) are dropped.
 	lastPhase map[string]string
 
-	// progressThreshold[id] is the highest deploying-progress threshold
-	// already announced (0/25/50/75/100). Once 100 is announced no further
-	// progress lines fire for that row.
-	progressThreshold map[string]int
+	// lastPercent[id] is the last whole deploying percent announced for
+	// the row. Absent means no percent has been announced yet in the
+	// current phase.
+	lastPercent map[string]int
 }
 
 func newPlainTargets(r *Reporter, ids []string) *plainTargets {
 	return &plainTargets{
-		targetsBase:       newTargetsBase(ids),
-		w:                 r.errW,
-		lastPhase:         make(map[string]string, len(ids)),
-		progressThreshold: make(map[string]int, len(ids)),
+		targetsBase: newTargetsBase(ids),
+		w:           r.errW,
+		lastPhase:   make(map[string]string, len(ids)),
+		lastPercent: make(map[string]int, len(ids)),
 	}
 }
 
@@ -50,6 +50,7 @@ func (t *plainTargets) SetPhase(id, phase, detail string) {
 		return
 	}
 	t.lastPhase[clean] = phase
+	delete(t.lastPercent, clean)
 	body := phase
 	if detail != "" {
 		body += " " + detail
@@ -57,9 +58,11 @@ func (t *plainTargets) SetPhase(id, phase, detail string) {
 	fmt.Fprintf(t.w, "%s: %s\n", clean, body)
 }
 
-// SetProgress emits `<id>: <phase> NN%` only when the percent crosses a new
-// 25-step threshold. Calling SetProgress also pins the row's phase to
-// "deploying" (the only sub-phase that reports a real percent).
+// SetProgress emits `<id>: <phase>: NN% complete` whenever the whole
+// percent changes, so each poll that advances a linear or canary rollout
+// produces a line while polls reporting the same percent stay silent.
+// Calling SetProgress also pins the row's phase to "deploying" (the only
+// sub-phase that reports a real percent).
 func (t *plainTargets) SetProgress(id string, percent float64, _ time.Duration) {
 	clean := sanitizeIdentifier(id)
 	t.mu.Lock()
@@ -74,19 +77,19 @@ func (t *plainTargets) SetProgress(id string, percent float64, _ time.Duration)
 	if row.phase == "" {
 		row.phase = "deploying"
 	}
-	threshold := percentThreshold(percent)
-	if threshold <= t.progressThreshold[clean] {
+	whole := clampPercent(percent)
+	if last, seen := t.lastPercent[clean]; seen && last == whole {
 		return
 	}
-	t.progressThreshold[clean] = threshold
-	fmt.Fprintf(t.w, "%s: %s %d%%\n", clean, row.phase, threshold)
+	t.lastPercent[clean] = whole
+	fmt.Fprintf(t.w, "%s: %s: %s\n", clean, row.phase, progressText(percent))
 }
 
 // Done emits a single success line.
 func (t *plainTargets) Done(id, summary string) {
 	clean := sanitizeIdentifier(id)
 	t.terminal(clean, rowDone, func() {
-		fmt.Fprintf(t.w, "%s: %s %s\n", clean, symSuccess, summary)
+		fmt.Fprintf(t.w, "%s: %s %s\n", clean, glyphs.success, summary)
 	})
 }
 
@@ -99,7 +102,7 @@ func (t *plainTargets) Fail(id string, err error) {
 		msg = err.Error()
 	}
 	t.terminal(clean, rowFail, func() {
-		fmt.Fprintf(t.w, "%s: %s failed: %s\n", clean, symError, msg)
+		fmt.Fprintf(t.w, "%s: %s failed: %s\n", clean, glyphs.errorS, msg)
 	})
 }
 
@@ -107,7 +110,7 @@ func (t *plainTargets) Fail(id string, err error) {
 func (t *plainTargets) Skip(id, reason string) {
 	clean := sanitizeIdentifier(id)
 	t.terminal(clean, rowSkip, func() {
-		fmt.Fprintf(t.w, "%s: %s %s\n", clean, symSkip, reason)
+		fmt.Fprintf(t.w, "%s: %s %s\n", clean, glyphs.skip, reason)
 	})
 }
 
@@ -130,21 +133,3 @@ func (t *plainTargets) Close() {
 	t.closed = true
 	t.mu.Unlock()
 }
-
-// percentThreshold maps a [0, 1] percent to the highest crossed 25-step
-// threshold (0/25/50/75/100). Values below 25% return 0 so newPlainTargets's
-// zero-valued map matches "no thresholds announced yet".
-func percentThreshold(percent float64) int {
-	switch {
-	case percent >= 1.0:
-		return 100
-	case percent >= 0.75:
-		return 75
-	case percent >= 0.50:
-		return 50
-	case percent >= 0.25:
-		return 25
-	default:
-		return 0
-	}
-}
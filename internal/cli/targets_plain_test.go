@@ -40,25 +40,42 @@ func TestPlainTargets_PhaseTransitions(t *testing.T) {
 	}
 }
 
-func TestPlainTargets_ProgressDecimation(t *testing.T) {
+func TestPlainTargets_ProgressEachChange(t *testing.T) {
 	t.Parallel()
 
 	pt, buf := newTestPlainTargets(t, []string{"id"})
 	defer pt.Close()
 
-	for _, p := range []float64{0.1, 0.25, 0.30, 0.5, 0.6, 0.75, 0.99, 1.0} {
+	for _, p := range []float64{0.2, 0.2, 0.4, 0.4, 0.6, 1.0} {
 		pt.SetProgress("id", p, 0)
 	}
 
 	out := buf.String()
-	for _, want := range []string{"id: deploying 25%", "id: deploying 50%", "id: deploying 75%", "id: deploying 100%"} {
+	for _, want := range []string{"id: deploying: 20% complete\n", "id: deploying: 40% complete\n", "id: deploying: 60% complete\n", "id: deploying: 100% complete\n"} {
 		if !strings.Contains(out, want) {
 			t.Errorf("missing line %q in:\n%s", want, out)
 		}
 	}
-	// 0.1 should not produce a 0% line.
-	if strings.Contains(out, "deploying 0%") {
-		t.Errorf("unexpected 0%% line in: %s", out)
+	// Repeated polls at the same percent stay silent.
+	if got := strings.Count(out, "40% complete"); got != 1 {
+		t.Errorf("expected exactly 1 '40%% complete' line, got %d in %q", got, out)
+	}
+}
+
+func TestPlainTargets_ProgressThenBaking(t *testing.T) {
+	t.Parallel()
+
+	pt, buf := newTestPlainTargets(t, []string{"id"})
+	defer pt.Close()
+
+	pt.SetPhase("id", "deploying", "")
+	pt.SetProgress("id", 0.5, 0)
+	pt.SetProgress("id", 1.0, 0)
+	pt.SetPhase("id", "baking", "(~3 min left)")
+
+	want := "id: deploying\nid: deploying: 50% complete\nid: deploying: 100% complete\nid: baking (~3 min left)\n"
+	if got := buf.String(); got != want {
+		t.Errorf("output = %q, want %q", got, want)
 	}
 }
 
@@ -80,26 +80,22 @@ func TestFormatETA(t *testing.T) {
 	}
 }
 
-func TestPercentThreshold(t *testing.T) {
+func TestProgressText(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
 		percent float64
-		want    int
+		want    string
 	}{
-		{0, 0},
-		{0.1, 0},
-		{0.25, 25},
-		{0.49, 25},
-		{0.5, 50},
-		{0.74, 50},
-		{0.75, 75},
-		{0.99, 75},
-		{1, 100},
+		{0, "0% complete"},
+		{0.4, "40% complete"},
+		{0.425, "43% complete"},
+		{1, "100% complete"},
+		{1.5, "100% complete"},
 	}
 	for _, tt := range tests {
-		if got := percentThreshold(tt.percent); got != tt.want {
-			t.Errorf("percentThreshold(%v) = %d, want %d", tt.percent, got, tt.want)
+		if got := progressText(tt.percent); got != tt.want {
+			t.Errorf("progressText(%v) = %q, want %q", tt.percent, got, tt.want)
 		}
 	}
 }
@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"io"
 	"os"
 
 	"golang.org/x/term"
@@ -14,3 +15,10 @@ func IsTerminal(f *os.File) bool {
 	}
 	return term.IsTerminal(int(f.Fd()))
 }
+
+// IsTerminalWriter reports whether w is an *os.File connected to a
+// terminal. Any other writer (a buffer, a pipe wrapper) is not a terminal.
+func IsTerminalWriter(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return ok && IsTerminal(f)
+}
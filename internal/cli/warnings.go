@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"sync"
+
+	"github.com/koh-sh/apcdeploy/internal/reporter"
+)
+
+// WarningCollector wraps a Reporter and records every Warn message so the
+// cmd layer can attach them to a machine-readable result. Warnings are still
+// delegated to the wrapped Reporter, and Warn is safe to call from
+// concurrent targets.
+type WarningCollector struct {
+	reporter.Reporter
+
+	mu       sync.Mutex
+	warnings []string
+}
+
+// NewWarningCollector returns a WarningCollector delegating to rep.
+func NewWarningCollector(rep reporter.Reporter) *WarningCollector {
+	return &WarningCollector{Reporter: rep}
+}
+
+// Warn records msg and forwards it to the wrapped Reporter.
+func (c *WarningCollector) Warn(msg string) {
+	c.mu.Lock()
+	c.warnings = append(c.warnings, msg)
+	c.mu.Unlock()
+	c.Reporter.Warn(msg)
+}
+
+// Warnings returns the collected messages in the order they were reported.
+// The result is never nil so it encodes as an empty JSON array.
+func (c *WarningCollector) Warnings() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string{}, c.warnings...)
+}
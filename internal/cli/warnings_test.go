@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	reportertest "github.com/koh-sh/apcdeploy/internal/reporter/testing"
+)
+
+func TestWarningCollector(t *testing.T) {
+	t.Parallel()
+
+	inner := &reportertest.MockReporter{}
+	c := NewWarningCollector(inner)
+	if got := c.Warnings(); got == nil || len(got) != 0 {
+		t.Errorf("expected an empty non-nil slice, got %#v", got)
+	}
+
+	c.Warn("first")
+	c.Info("not a warning")
+	c.Warn("second")
+
+	got := c.Warnings()
+	if len(got) != 2 || got[0] != "first" || got[1] != "second" {
+		t.Errorf("Warnings() = %v, want [first second]", got)
+	}
+	if !inner.HasMessage("warn: first") || !inner.HasMessage("info: not a warning") {
+		t.Errorf("messages were not forwarded: %v", inner.Messages)
+	}
+}
+
+func TestWarningCollectorConcurrent(t *testing.T) {
+	t.Parallel()
+
+	c := NewWarningCollector(NewSilentReporter())
+	var wg sync.WaitGroup
+	for i := range 50 {
+		wg.Go(func() { c.Warn(fmt.Sprintf("warning %d", i)) })
+	}
+	wg.Wait()
+
+	if got := len(c.Warnings()); got != 50 {
+		t.Errorf("collected %d warnings, want 50", got)
+	}
+}
@@ -0,0 +1,64 @@
+// Package testing provides a fake clock.Clock for deterministic tests.
+package testing
+
+import (
+	"sync"
+	"time"
+)
+
+// Fake is a clock.Clock whose time only moves when a caller waits on it or
+// calls Advance. Sleep and After return immediately after advancing the
+// clock by the requested duration, so a wait loop with a multi-minute
+// timeout runs to completion in microseconds.
+type Fake struct {
+	mu    sync.Mutex
+	now   time.Time
+	waits []time.Duration
+}
+
+// NewFake returns a Fake clock starting at start.
+func NewFake(start time.Time) *Fake {
+	return &Fake{now: start}
+}
+
+// Now implements clock.Clock.
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// Sleep implements clock.Clock by advancing the clock by d.
+func (f *Fake) Sleep(d time.Duration) {
+	f.wait(d)
+}
+
+// After implements clock.Clock. The clock is advanced by d and the returned
+// channel has already fired.
+func (f *Fake) After(d time.Duration) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+	ch <- f.wait(d)
+	return ch
+}
+
+// Advance moves the clock forward by d without recording a wait.
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+}
+
+// Waits returns the durations passed to Sleep and After, in call order.
+func (f *Fake) Waits() []time.Duration {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]time.Duration(nil), f.waits...)
+}
+
+func (f *Fake) wait(d time.Duration) time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.waits = append(f.waits, d)
+	f.now = f.now.Add(d)
+	return f.now
+}
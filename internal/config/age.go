@@ -0,0 +1,55 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseAge parses an age threshold such as max_version_age. On top of Go
+// durations ("36h") it accepts whole days ("90d") and weeks ("2w"), which
+// is how config staleness is usually expressed. The result must be positive.
+func ParseAge(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	days := 0
+	switch {
+	case strings.HasSuffix(s, "d"):
+		days = 1
+	case strings.HasSuffix(s, "w"):
+		days = 7
+	}
+
+	var d time.Duration
+	if days > 0 {
+		n, err := strconv.Atoi(s[:len(s)-1])
+		if err != nil {
+			return 0, fmt.Errorf("invalid age %q: expected e.g. 90d, 2w or 36h", s)
+		}
+		d = time.Duration(n*days) * 24 * time.Hour
+	} else {
+		var err error
+		if d, err = time.ParseDuration(s); err != nil {
+			return 0, fmt.Errorf("invalid age %q: expected e.g. 90d, 2w or 36h", s)
+		}
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("invalid age %q: must be positive", s)
+	}
+	return d, nil
+}
+
+// ParseSince parses a --since cut-off: an RFC 3339 timestamp
+// ("2026-03-01T00:00:00Z"), or an age as accepted by ParseAge ("24h", "7d")
+// counted back from now.
+func ParseSince(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	d, err := ParseAge(s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --since %q: expected a duration such as 24h or 7d, or an RFC 3339 time such as 2026-03-01T00:00:00Z", s)
+	}
+	return now.Add(-d), nil
+}
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseAge(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{in: "90d", want: 90 * 24 * time.Hour},
+		{in: "2w", want: 14 * 24 * time.Hour},
+		{in: "36h", want: 36 * time.Hour},
+		{in: " 1d ", want: 24 * time.Hour},
+		{in: "0d", wantErr: true},
+		{in: "-5d", wantErr: true},
+		{in: "xd", wantErr: true},
+		{in: "1.5d", wantErr: true},
+		{in: "ninety", wantErr: true},
+		{in: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseAge(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseAge(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseAge(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSince(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		in      string
+		want    time.Time
+		wantErr bool
+	}{
+		{in: "24h", want: now.Add(-24 * time.Hour)},
+		{in: "7d", want: now.Add(-7 * 24 * time.Hour)},
+		{in: "2026-03-01T00:00:00Z", want: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{in: "2026-03-01T09:00:00+09:00", want: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{in: "2026-03-01", wantErr: true},
+		{in: "-24h", wantErr: true},
+		{in: "yesterday", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseSince(tt.in, now)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseSince(%q) = %v, want error", tt.in, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseSince(%q) error = %v", tt.in, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseSince(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
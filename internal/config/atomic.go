@@ -0,0 +1,70 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// createTemp creates the staging file for WriteFileAtomic and
+// CreateFileAtomic. It is a
+// package-level variable so tests can inject write failures; in production it
+// is always os.CreateTemp.
+var createTemp = os.CreateTemp
+
+// WriteFileAtomic writes data to a temporary file in the same directory as
+// path and renames it into place, so an interrupted or failed write never
+// leaves path truncated. An existing file's permissions are preserved;
+// otherwise perm is used.
+func WriteFileAtomic(path string, data []byte, perm os.FileMode) error {
+	if info, err := os.Stat(path); err == nil {
+		perm = info.Mode().Perm()
+	}
+	return writeAtomic(path, data, perm, func(tmpPath string) error {
+		if err := os.Rename(tmpPath, path); err != nil {
+			return fmt.Errorf("failed to replace %s: %w", path, err)
+		}
+		return nil
+	})
+}
+
+// CreateFileAtomic is WriteFileAtomic for a path that must not exist yet.
+// The temporary file is hard-linked into place, which fails with an error
+// matching fs.ErrExist when path exists, even if it appeared after an
+// earlier check.
+func CreateFileAtomic(path string, data []byte, perm os.FileMode) error {
+	return writeAtomic(path, data, perm, func(tmpPath string) error {
+		if err := os.Link(tmpPath, path); err != nil {
+			return fmt.Errorf("failed to create %s: %w", path, err)
+		}
+		return nil
+	})
+}
+
+// writeAtomic stages data with perm in a temporary file next to path and
+// hands it to place; the temporary file is removed afterwards.
+func writeAtomic(path string, data []byte, perm os.FileMode, place func(tmpPath string) error) error {
+	tmp, err := createTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	// Best-effort cleanup; after a successful rename the temp path is gone.
+	defer os.Remove(tmpPath)
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to sync temporary file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close temporary file: %w", err)
+	}
+	if err := os.Chmod(tmpPath, perm); err != nil {
+		return fmt.Errorf("failed to set file permissions: %w", err)
+	}
+	return place(tmpPath)
+}
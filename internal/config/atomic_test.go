@@ -0,0 +1,151 @@
+package config
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteFileAtomic(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		existing bool
+		mode     os.FileMode
+		wantMode os.FileMode
+	}{
+		{"new file uses default permissions", false, 0, 0o644},
+		{"existing file keeps its permissions", true, 0o600, 0o600},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			path := filepath.Join(dir, "data.json")
+			if tt.existing {
+				if err := os.WriteFile(path, []byte("old"), tt.mode); err != nil {
+					t.Fatalf("failed to write existing file: %v", err)
+				}
+				if err := os.Chmod(path, tt.mode); err != nil {
+					t.Fatalf("failed to chmod: %v", err)
+				}
+			}
+
+			if err := WriteFileAtomic(path, []byte("new"), 0o644); err != nil {
+				t.Fatalf("WriteFileAtomic() error = %v", err)
+			}
+
+			got, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("failed to read file: %v", err)
+			}
+			if string(got) != "new" {
+				t.Errorf("content = %q, want %q", got, "new")
+			}
+			info, err := os.Stat(path)
+			if err != nil {
+				t.Fatalf("failed to stat file: %v", err)
+			}
+			if info.Mode().Perm() != tt.wantMode {
+				t.Errorf("mode = %o, want %o", info.Mode().Perm(), tt.wantMode)
+			}
+			assertNoTempFiles(t, dir)
+		})
+	}
+}
+
+func TestCreateFileAtomic(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		existing bool
+		wantErr  bool
+		want     string
+	}{
+		{"new file is created", false, false, "new"},
+		{"existing file is kept", true, true, "old"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			path := filepath.Join(dir, "data.json")
+			if tt.existing {
+				if err := os.WriteFile(path, []byte("old"), 0o644); err != nil {
+					t.Fatalf("failed to write existing file: %v", err)
+				}
+			}
+
+			err := CreateFileAtomic(path, []byte("new"), 0o644)
+			if got := errors.Is(err, fs.ErrExist); got != tt.wantErr {
+				t.Fatalf("errors.Is(err, fs.ErrExist) = %v, want %v (err: %v)", got, tt.wantErr, err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("CreateFileAtomic() error = %v", err)
+			}
+
+			got, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("failed to read file: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("content = %q, want %q", got, tt.want)
+			}
+			assertNoTempFiles(t, dir)
+		})
+	}
+}
+
+// TestWriteFileAtomicWriteFailure swaps the package-level createTemp, so it
+// must not run in parallel with other WriteFileAtomic callers.
+func TestWriteFileAtomicWriteFailure(t *testing.T) {
+	orig := createTemp
+	t.Cleanup(func() { createTemp = orig })
+	// Hand back an already-closed file so the write into it fails.
+	createTemp = func(dir, pattern string) (*os.File, error) {
+		f, err := orig(dir, pattern)
+		if err != nil {
+			return nil, err
+		}
+		f.Close()
+		return f, nil
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "data.json")
+	if err := os.WriteFile(path, []byte(`{"key": "original"}`), 0o644); err != nil {
+		t.Fatalf("failed to write existing file: %v", err)
+	}
+
+	if err := WriteDataFile([]byte(`{"key": "pulled"}`), ContentTypeJSON, path, "", true); err == nil {
+		t.Fatal("expected error when the temporary write fails")
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+	if string(got) != `{"key": "original"}` {
+		t.Errorf("target was modified: %q", got)
+	}
+	assertNoTempFiles(t, dir)
+}
+
+func assertNoTempFiles(t *testing.T, dir string) {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("failed to read dir: %v", err)
+	}
+	for _, e := range entries {
+		if e.Name() != "data.json" {
+			t.Errorf("leftover file %q", e.Name())
+		}
+	}
+}
@@ -6,6 +6,14 @@ const (
 	// MaxConfigSize is the maximum size for configuration data (2MB)
 	MaxConfigSize = 2 * 1024 * 1024
 
+	// ConfigSizeWarningThreshold is the data size (80% of MaxConfigSize)
+	// from which run warns that the content is approaching the limit
+	ConfigSizeWarningThreshold = MaxConfigSize * 8 / 10
+
+	// StdinDataFile as data_file (or run --data-file) reads the
+	// configuration data from stdin
+	StdinDataFile = "-"
+
 	// Profile types
 	// ProfileTypeFeatureFlags represents AWS AppConfig FeatureFlags profile type
 	ProfileTypeFeatureFlags = "AWS.AppConfig.FeatureFlags"
@@ -23,6 +31,32 @@ const (
 	// DefaultPollingInterval is the default interval for polling deployment status
 	DefaultPollingInterval = 5 * time.Second
 
+	// MinPollingInterval and MaxPollingInterval bound --poll-interval: faster
+	// polling only burns API calls, slower polling hides the rollout
+	MinPollingInterval = time.Second
+	MaxPollingInterval = 5 * time.Minute
+
+	// DefaultDeploymentTimeout is the default --timeout in seconds. Set to
+	// 30 minutes to safely cover AppConfig.AllAtOnce (10 min bake) and
+	// AppConfig.Canary10Percent20Minutes (20 min deploy + 10 min bake) under
+	// --wait-bake. Strategies with longer total durations (e.g.
+	// AppConfig.Linear20PercentEvery6Minutes) need timeout in the config
+	// file or an explicit --timeout.
+	DefaultDeploymentTimeout = 1800
+
+	// DefaultMaxAttempts is the default number of attempts for an AppConfig
+	// API call that fails with a throttling, transient server or transport
+	// error
+	DefaultMaxAttempts = 5
+
+	// DefaultRetryBaseDelay is the upper bound of the jittered delay before
+	// the first retry; it doubles for each later retry
+	DefaultRetryBaseDelay = 1 * time.Second
+
+	// DefaultMaxRetryBackoff caps the jittered exponential delay between
+	// retries
+	DefaultMaxRetryBackoff = 20 * time.Second
+
 	// Content types
 	// ContentTypeJSON represents JSON content type
 	ContentTypeJSON = "application/json"
@@ -32,4 +66,33 @@ const (
 
 	// ContentTypeText represents plain text content type
 	ContentTypeText = "text/plain"
+
+	// ContentTypeTOML represents TOML content type
+	ContentTypeTOML = "application/toml"
+
+	// Output formats
+	// OutputFormatText is the default human-readable output format
+	OutputFormatText = "text"
+
+	// OutputFormatJSON emits a machine-readable JSON document on stdout
+	OutputFormatJSON = "json"
+
+	// Config file formats
+	// ConfigFormatYAML writes apcdeploy config files as YAML (default)
+	ConfigFormatYAML = "yaml"
+
+	// ConfigFormatJSON writes apcdeploy config files as JSON
+	ConfigFormatJSON = "json"
+
+	// Text normalizations (text_normalize)
+	// TextNormalizeTrimTrailingWS strips trailing whitespace (ASCII and
+	// Unicode) from each line. This is the default; the option is still
+	// accepted so existing configs keep loading.
+	TextNormalizeTrimTrailingWS = "trim_trailing_ws"
+
+	// TextNormalizeKeepTrailingWS keeps trailing whitespace significant
+	TextNormalizeKeepTrailingWS = "keep_trailing_ws"
+
+	// TextNormalizeCollapseBlankLines collapses consecutive blank lines into one
+	TextNormalizeCollapseBlankLines = "collapse_blank_lines"
 )
@@ -0,0 +1,83 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// featureFlagsContentType is the content type used for FeatureFlags
+// profiles. AppConfig only accepts JSON for them today; the value is kept in
+// one place so every caller agrees, and can be swapped for edge cases such
+// as tests through SetFeatureFlagsContentType.
+var featureFlagsContentType = ContentTypeJSON
+
+// SetFeatureFlagsContentType overrides the content type used for FeatureFlags
+// profiles and returns a function restoring the previous value. It changes
+// package-level state, so callers must not run in parallel with other users
+// of ProfileContentType.
+func SetFeatureFlagsContentType(contentType string) (restore func()) {
+	prev := featureFlagsContentType
+	featureFlagsContentType = contentType
+	return func() { featureFlagsContentType = prev }
+}
+
+// SupportedContentTypes are the content types accepted for content_type and
+// --content-type.
+var SupportedContentTypes = []string{ContentTypeJSON, ContentTypeYAML, ContentTypeTOML, ContentTypeText}
+
+// ValidateContentTypeOverride checks an explicit content type given on the
+// command line (--content-type) for a profile of profileType: it must be
+// supported, and profile types that impose their own content type
+// (FeatureFlags) only accept that one.
+func ValidateContentTypeOverride(profileType, contentType string) error {
+	if !slices.Contains(SupportedContentTypes, contentType) {
+		return fmt.Errorf("invalid --content-type %q: must be one of %s", contentType, strings.Join(SupportedContentTypes, ", "))
+	}
+	if ct, ok := ProfileContentType(profileType); ok && ct != contentType {
+		return fmt.Errorf("--content-type %s cannot be used with %s profiles, which are always %s", contentType, profileType, ct)
+	}
+	return nil
+}
+
+// ProfileContentType returns the content type imposed by the profile type.
+// ok is false for profile types that leave the choice to the data file
+// (Freeform).
+func ProfileContentType(profileType string) (contentType string, ok bool) {
+	if profileType == ProfileTypeFeatureFlags {
+		return featureFlagsContentType, true
+	}
+	return "", false
+}
+
+// EffectiveContentType returns contentType unless the profile type imposes
+// its own, e.g. for content fetched from AppConfig.
+func EffectiveContentType(profileType, contentType string) string {
+	if ct, ok := ProfileContentType(profileType); ok {
+		return ct
+	}
+	return contentType
+}
+
+// ContentTypeFor determines the content type to upload. The profile type
+// wins (FeatureFlags), then an explicit content_type from the config, then
+// the data file extension; unknown extensions are treated as text.
+func ContentTypeFor(profileType, configured, dataPath string) string {
+	if ct, ok := ProfileContentType(profileType); ok {
+		return ct
+	}
+	if configured != "" {
+		return configured
+	}
+	switch strings.ToLower(filepath.Ext(dataPath)) {
+	case ".json":
+		return ContentTypeJSON
+	case ".yaml", ".yml":
+		return ContentTypeYAML
+	case ".toml":
+		return ContentTypeTOML
+	default:
+		return ContentTypeText
+	}
+}
@@ -0,0 +1,101 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestContentTypeFor(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		profileType string
+		configured  string
+		dataPath    string
+		want        string
+	}{
+		{"feature flags ignore extension", ProfileTypeFeatureFlags, "", "flags.yaml", ContentTypeJSON},
+		{"feature flags ignore content_type", ProfileTypeFeatureFlags, ContentTypeText, "flags.txt", ContentTypeJSON},
+		{"content_type wins over extension", ProfileTypeFreeform, ContentTypeYAML, "data.json", ContentTypeYAML},
+		{"json extension", ProfileTypeFreeform, "", "data.json", ContentTypeJSON},
+		{"yml extension is case-insensitive", ProfileTypeFreeform, "", "DATA.YML", ContentTypeYAML},
+		{"toml extension", ProfileTypeFreeform, "", "data.toml", ContentTypeTOML},
+		{"txt extension", ProfileTypeFreeform, "", "data.txt", ContentTypeText},
+		{"unknown extension is text", ProfileTypeFreeform, "", "data.conf", ContentTypeText},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := ContentTypeFor(tt.profileType, tt.configured, tt.dataPath); got != tt.want {
+				t.Errorf("ContentTypeFor(%q, %q, %q) = %q, want %q", tt.profileType, tt.configured, tt.dataPath, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEffectiveContentType(t *testing.T) {
+	t.Parallel()
+
+	if got := EffectiveContentType(ProfileTypeFeatureFlags, "text/plain"); got != ContentTypeJSON {
+		t.Errorf("EffectiveContentType(FeatureFlags) = %q, want %q", got, ContentTypeJSON)
+	}
+	if got := EffectiveContentType(ProfileTypeFreeform, "application/json; charset=utf-8"); got != "application/json; charset=utf-8" {
+		t.Errorf("EffectiveContentType(Freeform) = %q, want the fetched type unchanged", got)
+	}
+}
+
+func TestValidateContentTypeOverride(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		profileType string
+		contentType string
+		wantErr     string
+	}{
+		{"json for freeform", ProfileTypeFreeform, ContentTypeJSON, ""},
+		{"toml for freeform", ProfileTypeFreeform, ContentTypeTOML, ""},
+		{"unknown profile type", "", ContentTypeText, ""},
+		{"json for feature flags", ProfileTypeFeatureFlags, ContentTypeJSON, ""},
+		{"unsupported type", ProfileTypeFreeform, "application/xml", `invalid --content-type "application/xml"`},
+		{"yaml for feature flags", ProfileTypeFeatureFlags, ContentTypeYAML, "cannot be used with AWS.AppConfig.FeatureFlags profiles"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := ValidateContentTypeOverride(tt.profileType, tt.contentType)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateContentTypeOverride() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateContentTypeOverride() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestSetFeatureFlagsContentType mutates package state, so it does not run in
+// parallel with the tests above.
+func TestSetFeatureFlagsContentType(t *testing.T) {
+	restore := SetFeatureFlagsContentType(ContentTypeYAML)
+	if got, ok := ProfileContentType(ProfileTypeFeatureFlags); !ok || got != ContentTypeYAML {
+		t.Errorf("ProfileContentType() = %q, %v, want the override", got, ok)
+	}
+	if got := ContentTypeFor(ProfileTypeFeatureFlags, "", "flags.json"); got != ContentTypeYAML {
+		t.Errorf("ContentTypeFor() = %q, want the override", got)
+	}
+
+	restore()
+	if got, _ := ProfileContentType(ProfileTypeFeatureFlags); got != ContentTypeJSON {
+		t.Errorf("ProfileContentType() after restore = %q, want %q", got, ContentTypeJSON)
+	}
+	if _, ok := ProfileContentType(ProfileTypeFreeform); ok {
+		t.Error("Freeform profiles must not impose a content type")
+	}
+}
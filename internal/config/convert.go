@@ -0,0 +1,113 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+)
+
+// Data formats accepted by ConvertContent (pull --as)
+const (
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+	FormatText = "text"
+)
+
+// Formats returns the data formats accepted by ConvertContent.
+func Formats() []string {
+	return []string{FormatJSON, FormatYAML, FormatText}
+}
+
+// ContentTypeForFormat returns the content type of a data format.
+func ContentTypeForFormat(format string) string {
+	switch format {
+	case FormatJSON:
+		return ContentTypeJSON
+	case FormatYAML:
+		return ContentTypeYAML
+	default:
+		return ContentTypeText
+	}
+}
+
+// ConvertContent converts content fetched with contentType into format and
+// returns the converted bytes with their new content type. JSON and YAML
+// convert into each other, keeping key order, and anything can be kept as
+// text. Text converts only when it already parses as the requested format,
+// and to YAML only when it is a mapping or sequence, since any plain string
+// is technically a YAML scalar.
+func ConvertContent(content []byte, contentType, format string) ([]byte, string, error) {
+	target := ContentTypeForFormat(format)
+	source := mediaType(contentType)
+	switch format {
+	case FormatText:
+		return content, target, nil
+	case FormatJSON:
+		switch source {
+		case ContentTypeYAML, "application/yaml":
+			out, err := yaml.YAMLToJSON(content)
+			if err != nil {
+				return nil, "", fmt.Errorf("cannot convert YAML content to JSON: %w", err)
+			}
+			return out, target, nil
+		default:
+			if !json.Valid(content) {
+				return nil, "", fmt.Errorf("cannot convert %s content to JSON: it is not valid JSON", sourceLabel(source))
+			}
+			return content, target, nil
+		}
+	case FormatYAML:
+		switch source {
+		case ContentTypeYAML, "application/yaml":
+			return content, target, nil
+		case ContentTypeJSON:
+			if !json.Valid(content) {
+				return nil, "", fmt.Errorf("cannot convert JSON content to YAML: it is not valid JSON")
+			}
+			out, err := yaml.JSONToYAML(content)
+			if err != nil {
+				return nil, "", fmt.Errorf("cannot convert JSON content to YAML: %w", err)
+			}
+			return out, target, nil
+		default:
+			var data any
+			if err := yaml.Unmarshal(content, &data); err != nil {
+				return nil, "", fmt.Errorf("cannot convert %s content to YAML: %w", sourceLabel(source), err)
+			}
+			switch data.(type) {
+			case map[string]any, []any:
+				return content, target, nil
+			default:
+				return nil, "", fmt.Errorf("cannot convert %s content to YAML: it is not a YAML mapping or sequence", sourceLabel(source))
+			}
+		}
+	default:
+		return nil, "", fmt.Errorf("unknown format %q: must be one of %s", format, strings.Join(Formats(), ", "))
+	}
+}
+
+// mediaType lowercases a content type and strips parameters such as
+// "; charset=utf-8".
+func mediaType(contentType string) string {
+	ct := strings.ToLower(strings.TrimSpace(contentType))
+	if idx := strings.Index(ct, ";"); idx != -1 {
+		ct = strings.TrimSpace(ct[:idx])
+	}
+	return ct
+}
+
+// sourceLabel names a media type in conversion errors.
+func sourceLabel(ct string) string {
+	switch ct {
+	case ContentTypeJSON:
+		return "JSON"
+	case ContentTypeText:
+		return "text"
+	case "":
+		return "untyped"
+	default:
+		return ct
+	}
+}
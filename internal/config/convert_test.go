@@ -0,0 +1,69 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConvertContent(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		content     string
+		contentType string
+		format      string
+		want        string
+		wantType    string
+		wantErr     string
+	}{
+		{
+			name: "json to yaml keeps key order", content: `{"b":1,"a":{"c":[1,2]}}`, contentType: ContentTypeJSON, format: FormatYAML,
+			want: "b: 1\na:\n  c:\n  - 1\n  - 2\n", wantType: ContentTypeYAML,
+		},
+		{
+			name: "yaml to json keeps key order", content: "b: 1\na: x\n", contentType: "application/x-yaml; charset=utf-8", format: FormatJSON,
+			want: "{\"b\": 1, \"a\": \"x\"}\n", wantType: ContentTypeJSON,
+		},
+		{
+			name: "json text to json", content: `{"a":1}`, contentType: ContentTypeText, format: FormatJSON,
+			want: `{"a":1}`, wantType: ContentTypeJSON,
+		},
+		{
+			name: "yaml mapping text to yaml", content: "a: 1\n", contentType: ContentTypeText, format: FormatYAML,
+			want: "a: 1\n", wantType: ContentTypeYAML,
+		},
+		{
+			name: "anything to text", content: "a: [", contentType: ContentTypeYAML, format: FormatText,
+			want: "a: [", wantType: ContentTypeText,
+		},
+		{
+			name: "same format is unchanged", content: "a: 1\n", contentType: ContentTypeYAML, format: FormatYAML,
+			want: "a: 1\n", wantType: ContentTypeYAML,
+		},
+		{name: "plain text to yaml", content: "just some words", contentType: ContentTypeText, format: FormatYAML, wantErr: "not a YAML mapping or sequence"},
+		{name: "plain text to json", content: "just some words", contentType: ContentTypeText, format: FormatJSON, wantErr: "cannot convert text content to JSON"},
+		{name: "invalid json to yaml", content: "{", contentType: ContentTypeJSON, format: FormatYAML, wantErr: "not valid JSON"},
+		{name: "invalid yaml to json", content: "a: [", contentType: ContentTypeYAML, format: FormatJSON, wantErr: "cannot convert YAML content to JSON"},
+		{name: "unknown format", content: "{}", contentType: ContentTypeJSON, format: "toml", wantErr: `unknown format "toml"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, gotType, err := ConvertContent([]byte(tt.content), tt.contentType, tt.format)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(got) != tt.want || gotType != tt.wantType {
+				t.Errorf("ConvertContent() = (%q, %q), want (%q, %q)", got, gotType, tt.want, tt.wantType)
+			}
+		})
+	}
+}
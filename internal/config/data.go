@@ -1,8 +1,12 @@
 package config
 
 import (
+	"encoding/base64"
 	"fmt"
+	"io"
 	"os"
+	"slices"
+	"strings"
 )
 
 // LoadDataFile loads a configuration data file
@@ -21,6 +25,19 @@ func LoadDataFile(path string) ([]byte, error) {
 	return data, nil
 }
 
+// LoadDataReader reads configuration data from r (stdin for data_file "-")
+// with the same size limit as LoadDataFile.
+func LoadDataReader(r io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, MaxConfigSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read data from stdin: %w", err)
+	}
+	if len(data) > MaxConfigSize {
+		return nil, fmt.Errorf("data from stdin exceeds maximum allowed size (%d bytes)", MaxConfigSize)
+	}
+	return data, nil
+}
+
 // checkDataFileSize checks if a file is within the size limit
 func checkDataFileSize(path string) error {
 	info, err := os.Stat(path)
@@ -34,3 +51,54 @@ func checkDataFileSize(path string) error {
 
 	return nil
 }
+
+// LoadDataBase64Env reads the named environment variable and base64-decodes
+// it into configuration content. Used by --data-base64-env so CI secrets can
+// be deployed without being written to disk.
+func LoadDataBase64Env(name string) ([]byte, error) {
+	encoded, ok := os.LookupEnv(name)
+	if !ok {
+		return nil, fmt.Errorf("environment variable %s is not set", name)
+	}
+	// Secrets pasted into CI settings often carry a trailing newline or are
+	// wrapped across lines; neither is part of the base64 alphabet.
+	encoded = strings.Join(strings.Fields(encoded), "")
+	if encoded == "" {
+		return nil, fmt.Errorf("environment variable %s is empty", name)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode base64 from environment variable %s: %w", name, err)
+	}
+
+	if len(data) > MaxConfigSize {
+		return nil, fmt.Errorf("decoded data size (%d bytes) exceeds maximum allowed size (%d bytes)", len(data), MaxConfigSize)
+	}
+
+	return data, nil
+}
+
+// ExpandEnv substitutes ${VAR} and $VAR references in data with values from
+// lookup (os.LookupEnv outside tests), as os.Expand does. Used by
+// --expand-env so templated data files can be filled in at deploy time.
+// A reference to an unset variable is an error naming every missing
+// variable rather than an empty substitution; set-but-empty is allowed.
+// "$$" is an escaped literal "$", so "$$schema" expands to "$schema".
+func ExpandEnv(data []byte, lookup func(string) (string, bool)) ([]byte, error) {
+	var missing []string
+	expanded := os.Expand(string(data), func(name string) string {
+		if name == "$" {
+			return "$"
+		}
+		value, ok := lookup(name)
+		if !ok && !slices.Contains(missing, name) {
+			missing = append(missing, name)
+		}
+		return value
+	})
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("data file references unset environment variable(s): %s", strings.Join(missing, ", "))
+	}
+	return []byte(expanded), nil
+}
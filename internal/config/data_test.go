@@ -1,6 +1,8 @@
 package config
 
 import (
+	"encoding/base64"
+	"strings"
 	"testing"
 )
 
@@ -74,3 +76,148 @@ func Test_checkDataFileSize(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadDataBase64Env(t *testing.T) {
+	const envName = "APCDEPLOY_TEST_DATA_B64"
+	payload := `{"key": "value"}`
+
+	tests := []struct {
+		name    string
+		unset   bool
+		value   string
+		want    string
+		wantErr string
+	}{
+		{
+			name:  "valid base64",
+			value: base64.StdEncoding.EncodeToString([]byte(payload)),
+			want:  payload,
+		},
+		{
+			name:  "surrounding whitespace and line wraps are ignored",
+			value: "  " + base64.StdEncoding.EncodeToString([]byte(payload))[:8] + "\n" + base64.StdEncoding.EncodeToString([]byte(payload))[8:] + "\n",
+			want:  payload,
+		},
+		{
+			name:    "unset variable",
+			unset:   true,
+			wantErr: "is not set",
+		},
+		{
+			name:    "empty variable",
+			value:   "",
+			wantErr: "is empty",
+		},
+		{
+			name:    "invalid base64",
+			value:   "not base64!",
+			wantErr: "failed to decode base64",
+		},
+		{
+			name:    "decoded data too large",
+			value:   base64.StdEncoding.EncodeToString(make([]byte, MaxConfigSize+1)),
+			wantErr: "exceeds maximum allowed size",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.unset {
+				t.Setenv(envName, tt.value)
+			}
+
+			got, err := LoadDataBase64Env(envName)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("LoadDataBase64Env() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpandEnv(t *testing.T) {
+	t.Parallel()
+
+	env := map[string]string{"DATABASE_HOST": "db.internal", "PORT": "5432", "EMPTY": ""}
+	lookup := func(name string) (string, bool) {
+		v, ok := env[name]
+		return v, ok
+	}
+
+	tests := []struct {
+		name    string
+		data    string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "braced and bare references",
+			data: `{"host": "${DATABASE_HOST}", "port": $PORT}`,
+			want: `{"host": "db.internal", "port": 5432}`,
+		},
+		{
+			name: "set but empty is substituted",
+			data: `prefix=${EMPTY}`,
+			want: `prefix=`,
+		},
+		{
+			name: "no references",
+			data: `{"key": "value"}`,
+			want: `{"key": "value"}`,
+		},
+		{
+			name: "double dollar is a literal dollar",
+			data: `{"$$schema": "s.json", "$$ref": "#/${PORT}", "price": "$$$PORT"}`,
+			want: `{"$schema": "s.json", "$ref": "#/5432", "price": "$5432"}`,
+		},
+		{
+			name:    "every missing variable is listed once",
+			data:    `${MISSING_A} ${PORT} $MISSING_B ${MISSING_A}`,
+			wantErr: "unset environment variable(s): MISSING_A, MISSING_B",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ExpandEnv([]byte(tt.data), lookup)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ExpandEnv() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ExpandEnv() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("ExpandEnv() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadDataReader(t *testing.T) {
+	t.Parallel()
+
+	data, err := LoadDataReader(strings.NewReader(`{"key": "value"}`))
+	if err != nil {
+		t.Fatalf("LoadDataReader() error = %v", err)
+	}
+	if string(data) != `{"key": "value"}` {
+		t.Errorf("LoadDataReader() = %q", data)
+	}
+
+	_, err = LoadDataReader(strings.NewReader(strings.Repeat("a", MaxConfigSize+1)))
+	if err == nil || !strings.Contains(err.Error(), "exceeds maximum allowed size") {
+		t.Errorf("LoadDataReader() error = %v, want size error", err)
+	}
+}
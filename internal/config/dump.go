@@ -0,0 +1,44 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Normalized dump file names, suffixed with the data file extension
+const (
+	dumpRemoteName = "remote.normalized"
+	dumpLocalName  = "local.normalized"
+)
+
+// DumpNormalized writes the exact strings fed to change detection to dir
+// (--dump-normalized), so normalization effects such as FeatureFlags
+// timestamp stripping, YAML reflow or trailing newlines can be inspected with
+// any diff tool. remote is nil when nothing has been deployed yet; only the
+// local file is written then. It returns the paths written.
+func DumpNormalized(dir, ext string, remote *string, local string) ([]string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create dump directory: %w", err)
+	}
+
+	files := []struct {
+		name    string
+		content *string
+	}{
+		{dumpRemoteName, remote},
+		{dumpLocalName, &local},
+	}
+	var written []string
+	for _, f := range files {
+		if f.content == nil {
+			continue
+		}
+		path := filepath.Join(dir, f.name+ext)
+		if err := WriteFileAtomic(path, []byte(*f.content), 0o644); err != nil {
+			return written, fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		written = append(written, path)
+	}
+	return written, nil
+}
@@ -0,0 +1,62 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDumpNormalized(t *testing.T) {
+	t.Parallel()
+
+	remote := "{\n  \"a\": 1\n}"
+	tests := []struct {
+		name   string
+		remote *string
+		want   map[string]string
+	}{
+		{
+			name:   "both sides",
+			remote: &remote,
+			want: map[string]string{
+				"remote.normalized.json": remote,
+				"local.normalized.json":  "{\n  \"a\": 2\n}",
+			},
+		},
+		{
+			name: "first deployment writes only local",
+			want: map[string]string{
+				"local.normalized.json": "{\n  \"a\": 2\n}",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dir := filepath.Join(t.TempDir(), "dump")
+
+			paths, err := DumpNormalized(dir, ".json", tt.remote, "{\n  \"a\": 2\n}")
+			if err != nil {
+				t.Fatalf("DumpNormalized() error = %v", err)
+			}
+			if len(paths) != len(tt.want) {
+				t.Errorf("DumpNormalized() paths = %v, want %d files", paths, len(tt.want))
+			}
+			for name, want := range tt.want {
+				got, err := os.ReadFile(filepath.Join(dir, name))
+				if err != nil {
+					t.Fatalf("read %s: %v", name, err)
+				}
+				if string(got) != want {
+					t.Errorf("%s = %q, want %q", name, got, want)
+				}
+			}
+			if tt.remote == nil {
+				if _, err := os.Stat(filepath.Join(dir, "remote.normalized.json")); !os.IsNotExist(err) {
+					t.Errorf("remote dump should not exist, stat err = %v", err)
+				}
+			}
+		})
+	}
+}
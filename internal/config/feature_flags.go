@@ -0,0 +1,340 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// featureFlagsSchemaVersion is the only schema version AppConfig accepts for
+// FeatureFlags documents.
+const featureFlagsSchemaVersion = "1"
+
+// featureFlagKeyPattern matches flag keys and attribute names as AppConfig
+// requires: a lowercase letter followed by up to 63 letters, digits,
+// underscores or hyphens.
+var featureFlagKeyPattern = regexp.MustCompile(`^[a-z][a-zA-Z\d_-]{0,63}$`)
+
+// featureFlagAttributeTypes are the accepted constraints.type values.
+var featureFlagAttributeTypes = []string{"string", "number", "boolean", "string[]", "number[]"}
+
+// FeatureFlagsError reports the first violation of the FeatureFlags schema
+// and where it was found.
+type FeatureFlagsError struct {
+	// Path is the JSON path of the offending value (e.g. "$.flags.beta.name")
+	Path string
+	// Message describes the violation
+	Message string
+}
+
+func (e *FeatureFlagsError) Error() string {
+	return fmt.Sprintf("invalid feature flags document at %s: %s", e.Path, e.Message)
+}
+
+// ValidateFeatureFlags checks a FeatureFlags JSON document against the
+// structure AppConfig enforces server-side, so common mistakes fail before
+// CreateHostedConfigurationVersion:
+//   - top level: "flags" and "values" objects and "version": "1"
+//   - each flag: a valid key, a non-empty "name", optional "description",
+//     "attributes" with a supported constraints.type, and
+//     "_deprecation": {"status": "planned"}
+//   - each value: a defined flag, a boolean "enabled", and attribute values
+//     matching their declared type
+//
+// The _createdAt/_updatedAt fields AppConfig adds, and any other keys
+// starting with an underscore, are ignored. The first violation is returned
+// as a *FeatureFlagsError; keys are visited in sorted order so the result is
+// deterministic. Syntax errors are left to ValidateData.
+func ValidateFeatureFlags(data []byte) error {
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("invalid JSON syntax: %w", err)
+	}
+	root, ok := doc.(map[string]any)
+	if !ok {
+		return flagsErr("$", "expected an object")
+	}
+
+	for _, key := range sortedKeys(root) {
+		switch key {
+		case "flags", "values", "version":
+		default:
+			if !strings.HasPrefix(key, "_") {
+				return flagsErr("$", fmt.Sprintf("unknown key %q (expected flags, values and version)", key))
+			}
+		}
+	}
+	version, ok := root["version"]
+	if !ok {
+		return flagsErr("$", `missing required key "version"`)
+	}
+	if version != featureFlagsSchemaVersion {
+		return flagsErr("$.version", fmt.Sprintf("must be %q", featureFlagsSchemaVersion))
+	}
+
+	flags, err := requiredObject(root, "flags", "$")
+	if err != nil {
+		return err
+	}
+	attributes := make(map[string]map[string]string, len(flags))
+	for _, key := range sortedKeys(flags) {
+		types, err := validateFlag(key, flags[key])
+		if err != nil {
+			return err
+		}
+		attributes[key] = types
+	}
+
+	values, err := requiredObject(root, "values", "$")
+	if err != nil {
+		return err
+	}
+	for _, key := range sortedKeys(values) {
+		path := "$.values." + key
+		types, defined := attributes[key]
+		if !defined {
+			return flagsErr(path, fmt.Sprintf("no flag %q is defined in flags", key))
+		}
+		if err := validateFlagValue(path, values[key], types); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateFlag checks one flag definition and returns the declared type of
+// each of its attributes ("" when the attribute has no constraints.type).
+func validateFlag(key string, v any) (map[string]string, error) {
+	path := "$.flags." + key
+	if !featureFlagKeyPattern.MatchString(key) {
+		return nil, flagsErr(path, "flag key must start with a lowercase letter and contain only letters, digits, '_' or '-' (max 64)")
+	}
+	flag, ok := v.(map[string]any)
+	if !ok {
+		return nil, flagsErr(path, "expected an object")
+	}
+
+	name, ok := flag["name"]
+	if !ok {
+		return nil, flagsErr(path, `missing required key "name"`)
+	}
+	if s, ok := name.(string); !ok || s == "" {
+		return nil, flagsErr(path+".name", "expected a non-empty string")
+	}
+	if desc, ok := flag["description"]; ok {
+		if _, ok := desc.(string); !ok {
+			return nil, flagsErr(path+".description", "expected a string")
+		}
+	}
+	if dep, ok := flag["_deprecation"]; ok {
+		if err := validateDeprecation(path+"._deprecation", dep); err != nil {
+			return nil, err
+		}
+	}
+	for _, k := range sortedKeys(flag) {
+		switch k {
+		case "name", "description", "attributes":
+		default:
+			if !strings.HasPrefix(k, "_") {
+				return nil, flagsErr(path, fmt.Sprintf("unknown key %q", k))
+			}
+		}
+	}
+
+	types := map[string]string{}
+	attrs, ok := flag["attributes"]
+	if !ok {
+		return types, nil
+	}
+	attrMap, ok := attrs.(map[string]any)
+	if !ok {
+		return nil, flagsErr(path+".attributes", "expected an object")
+	}
+	for _, name := range sortedKeys(attrMap) {
+		typ, err := validateAttribute(path+".attributes."+name, name, attrMap[name])
+		if err != nil {
+			return nil, err
+		}
+		types[name] = typ
+	}
+	return types, nil
+}
+
+// validateDeprecation checks a flag's _deprecation block; "planned" is the
+// only status AppConfig accepts.
+func validateDeprecation(path string, v any) error {
+	dep, ok := v.(map[string]any)
+	if !ok {
+		return flagsErr(path, "expected an object")
+	}
+	status, ok := dep["status"]
+	if !ok {
+		return flagsErr(path, `missing required key "status"`)
+	}
+	if status != "planned" {
+		return flagsErr(path+".status", `must be "planned"`)
+	}
+	return nil
+}
+
+// validateAttribute checks one attribute definition and returns its
+// constraints.type.
+func validateAttribute(path, name string, v any) (string, error) {
+	if !featureFlagKeyPattern.MatchString(name) {
+		return "", flagsErr(path, "attribute name must start with a lowercase letter and contain only letters, digits, '_' or '-' (max 64)")
+	}
+	attr, ok := v.(map[string]any)
+	if !ok {
+		return "", flagsErr(path, "expected an object")
+	}
+	c, ok := attr["constraints"]
+	if !ok {
+		return "", nil
+	}
+	constraints, ok := c.(map[string]any)
+	if !ok {
+		return "", flagsErr(path+".constraints", "expected an object")
+	}
+	t, ok := constraints["type"]
+	if !ok {
+		return "", flagsErr(path+".constraints", `missing required key "type"`)
+	}
+	typ, _ := t.(string)
+	if !slices.Contains(featureFlagAttributeTypes, typ) {
+		return "", flagsErr(path+".constraints.type", "must be one of "+strings.Join(featureFlagAttributeTypes, ", "))
+	}
+	if r, ok := constraints["required"]; ok {
+		if _, ok := r.(bool); !ok {
+			return "", flagsErr(path+".constraints.required", "expected a boolean")
+		}
+	}
+	return typ, nil
+}
+
+// validateFlagValue checks one entry of "values" against the attribute
+// types declared by its flag.
+func validateFlagValue(path string, v any, types map[string]string) error {
+	value, ok := v.(map[string]any)
+	if !ok {
+		return flagsErr(path, "expected an object")
+	}
+	enabled, ok := value["enabled"]
+	if !ok {
+		return flagsErr(path, `missing required key "enabled"`)
+	}
+	if _, ok := enabled.(bool); !ok {
+		return flagsErr(path+".enabled", "expected a boolean")
+	}
+	for _, name := range sortedKeys(value) {
+		typ := types[name]
+		if name == "enabled" || typ == "" {
+			continue
+		}
+		if !matchesAttributeType(value[name], typ) {
+			return flagsErr(path+"."+name, "expected a value of type "+typ)
+		}
+	}
+	return nil
+}
+
+// matchesAttributeType reports whether v is a valid value for an attribute
+// of type typ.
+func matchesAttributeType(v any, typ string) bool {
+	switch typ {
+	case "string":
+		_, ok := v.(string)
+		return ok
+	case "number":
+		_, ok := v.(float64)
+		return ok
+	case "boolean":
+		_, ok := v.(bool)
+		return ok
+	case "string[]", "number[]":
+		items, ok := v.([]any)
+		if !ok {
+			return false
+		}
+		elem := strings.TrimSuffix(typ, "[]")
+		for _, item := range items {
+			if !matchesAttributeType(item, elem) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// requiredObject returns parent[key] as an object.
+func requiredObject(parent map[string]any, key, path string) (map[string]any, error) {
+	v, ok := parent[key]
+	if !ok {
+		return nil, flagsErr(path, fmt.Sprintf("missing required key %q", key))
+	}
+	obj, ok := v.(map[string]any)
+	if !ok {
+		return nil, flagsErr(path+"."+key, "expected an object")
+	}
+	return obj, nil
+}
+
+func flagsErr(path, msg string) error {
+	return &FeatureFlagsError{Path: path, Message: msg}
+}
+
+// isYAMLPath reports whether path has a .yaml or .yml extension.
+func isYAMLPath(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return true
+	}
+	return false
+}
+
+// FeatureFlagsUploadContent returns data in the form uploaded for a profile
+// of profileType. A FeatureFlags data file authored in YAML is converted to
+// the JSON AppConfig requires (comments are dropped); anything else is
+// returned unchanged.
+func FeatureFlagsUploadContent(profileType, dataPath string, data []byte) ([]byte, error) {
+	if profileType != ProfileTypeFeatureFlags || !isYAMLPath(dataPath) {
+		return data, nil
+	}
+	converted, _, err := ConvertContent(data, ContentTypeYAML, FormatJSON)
+	if err != nil {
+		return nil, err
+	}
+	normalized, err := NormalizeJSON(string(converted), "")
+	if err != nil {
+		return nil, err
+	}
+	return []byte(normalized), nil
+}
+
+// FeatureFlagsLocalContent converts FeatureFlags JSON fetched from AppConfig
+// into YAML when the local data file is YAML, dropping _createdAt and
+// _updatedAt as WriteDataFile does for JSON. ok is false when no conversion
+// applies.
+func FeatureFlagsLocalContent(profileType, dataPath string, content []byte) (converted []byte, ok bool, err error) {
+	if profileType != ProfileTypeFeatureFlags || !isYAMLPath(dataPath) {
+		return nil, false, nil
+	}
+	formatted, err := formatJSON(content, profileType)
+	if err != nil {
+		return nil, false, err
+	}
+	converted, _, err = ConvertContent(formatted, ContentTypeJSON, FormatYAML)
+	if err != nil {
+		return nil, false, err
+	}
+	return converted, true, nil
+}
+
+// sortedKeys returns the keys of m in sorted order.
+func sortedKeys(m map[string]any) []string {
+	return slices.Sorted(maps.Keys(m))
+}
@@ -0,0 +1,220 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateFeatureFlags(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		data     string
+		wantPath string
+		wantMsg  string
+	}{
+		{
+			name: "valid document with attributes and timestamps",
+			data: `{
+  "flags": {
+    "checkout": {
+      "name": "New checkout",
+      "description": "Rollout of the new checkout flow",
+      "_createdAt": "2024-01-01T00:00:00Z",
+      "_updatedAt": "2024-01-02T00:00:00Z",
+      "_deprecation": {"status": "planned"},
+      "attributes": {
+        "limit": {"constraints": {"type": "number", "required": true}},
+        "regions": {"constraints": {"type": "string[]"}},
+        "note": {}
+      }
+    }
+  },
+  "values": {
+    "checkout": {"enabled": true, "limit": 5, "regions": ["us", "eu"], "note": 1, "_variants": []}
+  },
+  "version": "1"
+}`,
+		},
+		{
+			name:     "not an object",
+			data:     `[]`,
+			wantPath: "$",
+			wantMsg:  "expected an object",
+		},
+		{
+			name:     "missing version",
+			data:     `{"flags": {}, "values": {}}`,
+			wantPath: "$",
+			wantMsg:  `missing required key "version"`,
+		},
+		{
+			name:     "wrong version",
+			data:     `{"flags": {}, "values": {}, "version": 1}`,
+			wantPath: "$.version",
+			wantMsg:  `must be "1"`,
+		},
+		{
+			name:     "missing values",
+			data:     `{"flags": {}, "version": "1"}`,
+			wantPath: "$",
+			wantMsg:  `missing required key "values"`,
+		},
+		{
+			name:     "unknown top-level key",
+			data:     `{"flag": {}, "values": {}, "version": "1"}`,
+			wantPath: "$",
+			wantMsg:  `unknown key "flag"`,
+		},
+		{
+			name:     "invalid flag key",
+			data:     `{"flags": {"Beta": {"name": "Beta"}}, "values": {}, "version": "1"}`,
+			wantPath: "$.flags.Beta",
+			wantMsg:  "flag key must start with a lowercase letter",
+		},
+		{
+			name:     "missing flag name",
+			data:     `{"flags": {"beta": {"description": "x"}}, "values": {}, "version": "1"}`,
+			wantPath: "$.flags.beta",
+			wantMsg:  `missing required key "name"`,
+		},
+		{
+			name:     "unsupported attribute type",
+			data:     `{"flags": {"beta": {"name": "Beta", "attributes": {"level": {"constraints": {"type": "integer"}}}}}, "values": {}, "version": "1"}`,
+			wantPath: "$.flags.beta.attributes.level.constraints.type",
+			wantMsg:  "must be one of string, number, boolean, string[], number[]",
+		},
+		{
+			name:     "bad deprecation status",
+			data:     `{"flags": {"beta": {"name": "Beta", "_deprecation": {"status": "deprecated"}}}, "values": {}, "version": "1"}`,
+			wantPath: "$.flags.beta._deprecation.status",
+			wantMsg:  `must be "planned"`,
+		},
+		{
+			name:     "value for an undefined flag",
+			data:     `{"flags": {}, "values": {"beta": {"enabled": true}}, "version": "1"}`,
+			wantPath: "$.values.beta",
+			wantMsg:  `no flag "beta" is defined in flags`,
+		},
+		{
+			name:     "enabled is not a boolean",
+			data:     `{"flags": {"beta": {"name": "Beta"}}, "values": {"beta": {"enabled": "true"}}, "version": "1"}`,
+			wantPath: "$.values.beta.enabled",
+			wantMsg:  "expected a boolean",
+		},
+		{
+			name:     "attribute value of the wrong type",
+			data:     `{"flags": {"beta": {"name": "Beta", "attributes": {"ids": {"constraints": {"type": "number[]"}}}}}, "values": {"beta": {"enabled": true, "ids": [1, "2"]}}, "version": "1"}`,
+			wantPath: "$.values.beta.ids",
+			wantMsg:  "expected a value of type number[]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := ValidateFeatureFlags([]byte(tt.data))
+			if tt.wantPath == "" {
+				if err != nil {
+					t.Errorf("ValidateFeatureFlags() error = %v", err)
+				}
+				return
+			}
+			var flagsErr *FeatureFlagsError
+			if !errors.As(err, &flagsErr) {
+				t.Fatalf("ValidateFeatureFlags() error = %v, want *FeatureFlagsError", err)
+			}
+			if flagsErr.Path != tt.wantPath {
+				t.Errorf("Path = %q, want %q", flagsErr.Path, tt.wantPath)
+			}
+			if !strings.Contains(flagsErr.Message, tt.wantMsg) {
+				t.Errorf("Message = %q, want it to contain %q", flagsErr.Message, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestFeatureFlagsUploadContent(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		profileType string
+		dataPath    string
+		data        string
+		want        string
+		wantErr     bool
+	}{
+		{
+			name:        "YAML feature flags become JSON",
+			profileType: ProfileTypeFeatureFlags,
+			dataPath:    "flags.yml",
+			data:        "# comment\nversion: \"1\"\nflags: {}\nvalues: {}\n",
+			want:        "{\n  \"flags\": {},\n  \"values\": {},\n  \"version\": \"1\"\n}",
+		},
+		{
+			name:        "JSON feature flags are unchanged",
+			profileType: ProfileTypeFeatureFlags,
+			dataPath:    "flags.json",
+			data:        `{"version":"1"}`,
+			want:        `{"version":"1"}`,
+		},
+		{
+			name:        "freeform YAML is unchanged",
+			profileType: ProfileTypeFreeform,
+			dataPath:    "data.yaml",
+			data:        "key: value\n",
+			want:        "key: value\n",
+		},
+		{
+			name:        "invalid YAML",
+			profileType: ProfileTypeFeatureFlags,
+			dataPath:    "flags.yaml",
+			data:        "flags: [\n",
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := FeatureFlagsUploadContent(tt.profileType, tt.dataPath, []byte(tt.data))
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("FeatureFlagsUploadContent() = %s, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FeatureFlagsUploadContent() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("FeatureFlagsUploadContent() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFeatureFlagsLocalContent(t *testing.T) {
+	t.Parallel()
+
+	remote := []byte(`{"flags":{"beta":{"name":"Beta","_createdAt":"2024-01-01T00:00:00Z"}},"values":{},"version":"1"}`)
+
+	got, ok, err := FeatureFlagsLocalContent(ProfileTypeFeatureFlags, "flags.yaml", remote)
+	if err != nil || !ok {
+		t.Fatalf("FeatureFlagsLocalContent() ok = %v, error = %v", ok, err)
+	}
+	if strings.Contains(string(got), "_createdAt") || strings.Contains(string(got), "{\"") {
+		t.Errorf("FeatureFlagsLocalContent() = %s, want YAML without timestamps", got)
+	}
+	changed, err := HasContentChanged(got, remote, ".yaml", ProfileTypeFeatureFlags, TextNormalizeOptions{})
+	if err != nil || changed {
+		t.Errorf("converted YAML differs from the deployed JSON (changed = %v, err = %v)", changed, err)
+	}
+
+	if _, ok, _ := FeatureFlagsLocalContent(ProfileTypeFeatureFlags, "flags.json", remote); ok {
+		t.Error("a JSON data file must not be converted")
+	}
+}
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// ProfileFilter narrows fleet-wide operations (ls-resources, bulk targets
+// files) to one application and/or the configuration profiles whose names
+// match a regular expression. A nil *ProfileFilter matches everything.
+type ProfileFilter struct {
+	// Application is an exact application name (--app); empty matches all
+	Application string
+	// ProfileRegex is matched against profile names (--profile-regex);
+	// nil matches all
+	ProfileRegex *regexp.Regexp
+}
+
+// NewProfileFilter builds a filter from the --app and --profile-regex flag
+// values. It returns nil when both are empty and an error when the regular
+// expression does not compile.
+func NewProfileFilter(application, profileRegex string) (*ProfileFilter, error) {
+	if application == "" && profileRegex == "" {
+		return nil, nil
+	}
+	f := &ProfileFilter{Application: application}
+	if profileRegex != "" {
+		re, err := regexp.Compile(profileRegex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --profile-regex %q: %w", profileRegex, err)
+		}
+		f.ProfileRegex = re
+	}
+	return f, nil
+}
+
+// MatchApplication reports whether the application passes the filter.
+func (f *ProfileFilter) MatchApplication(application string) bool {
+	return f == nil || f.Application == "" || f.Application == application
+}
+
+// MatchProfile reports whether the profile name passes the regex.
+func (f *ProfileFilter) MatchProfile(profile string) bool {
+	return f == nil || f.ProfileRegex == nil || f.ProfileRegex.MatchString(profile)
+}
+
+// Match reports whether a target passes both the application and the
+// profile conditions.
+func (f *ProfileFilter) Match(application, profile string) bool {
+	return f.MatchApplication(application) && f.MatchProfile(profile)
+}
+
+// String describes the filter for messages, e.g.
+// `app "web", profile =~ "^feature-"`.
+func (f *ProfileFilter) String() string {
+	switch {
+	case f == nil:
+		return "no filter"
+	case f.Application != "" && f.ProfileRegex != nil:
+		return fmt.Sprintf("app %q, profile =~ %q", f.Application, f.ProfileRegex.String())
+	case f.Application != "":
+		return fmt.Sprintf("app %q", f.Application)
+	default:
+		return fmt.Sprintf("profile =~ %q", f.ProfileRegex.String())
+	}
+}
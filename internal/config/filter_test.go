@@ -0,0 +1,87 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewProfileFilter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		app          string
+		profileRegex string
+		wantNil      bool
+		wantErr      string
+		matches      [][2]string
+		rejects      [][2]string
+		wantString   string
+	}{
+		{
+			name:    "no flags",
+			wantNil: true,
+			matches: [][2]string{{"any", "thing"}},
+		},
+		{
+			name:       "app only",
+			app:        "web",
+			matches:    [][2]string{{"web", "flags"}},
+			rejects:    [][2]string{{"api", "flags"}, {"web-2", "flags"}},
+			wantString: `app "web"`,
+		},
+		{
+			name:         "regex only",
+			profileRegex: "^feature-",
+			matches:      [][2]string{{"web", "feature-x"}, {"api", "feature-"}},
+			rejects:      [][2]string{{"web", "my-feature-x"}},
+			wantString:   `profile =~ "^feature-"`,
+		},
+		{
+			name:         "app and regex",
+			app:          "web",
+			profileRegex: "flags$",
+			matches:      [][2]string{{"web", "ui-flags"}},
+			rejects:      [][2]string{{"api", "ui-flags"}, {"web", "settings"}},
+			wantString:   `app "web", profile =~ "flags$"`,
+		},
+		{
+			name:         "invalid regex",
+			profileRegex: "feature-(",
+			wantErr:      "invalid --profile-regex",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := NewProfileFilter(tt.app, tt.profileRegex)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got: %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if (f == nil) != tt.wantNil {
+				t.Fatalf("NewProfileFilter() = %v, wantNil %v", f, tt.wantNil)
+			}
+			for _, m := range tt.matches {
+				if !f.Match(m[0], m[1]) {
+					t.Errorf("Match(%q, %q) = false, want true", m[0], m[1])
+				}
+			}
+			for _, m := range tt.rejects {
+				if f.Match(m[0], m[1]) {
+					t.Errorf("Match(%q, %q) = true, want false", m[0], m[1])
+				}
+			}
+			if tt.wantString != "" && f.String() != tt.wantString {
+				t.Errorf("String() = %q, want %q", f.String(), tt.wantString)
+			}
+		})
+	}
+}
@@ -5,18 +5,39 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"strings"
 
 	"github.com/goccy/go-yaml"
 )
 
 // GenerateConfigFile generates an apcdeploy.yml file with the given parameters
-func GenerateConfigFile(app, profile, env, dataFile, region, deploymentStrategy, outputPath string, force bool) error {
+// format is ConfigFormatYAML or ConfigFormatJSON; empty derives it from the
+// outputPath extension
+func GenerateConfigFile(app, profile, env, dataFile, region, deploymentStrategy, outputPath, format string, force bool) error {
 	// Check if file already exists
 	if _, err := os.Stat(outputPath); err == nil && !force {
 		return fmt.Errorf("config file already exists at %s (use --force to overwrite)", outputPath)
 	}
 
+	if format == "" {
+		format = ConfigFormatForPath(outputPath)
+	}
+
+	data, err := RenderConfigFile(app, profile, env, dataFile, region, deploymentStrategy, format)
+	if err != nil {
+		return err
+	}
+
+	// Write to file
+	if err := os.WriteFile(outputPath, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+
+	return nil
+}
+
+// RenderConfigFile returns the config file content GenerateConfigFile writes,
+// in ConfigFormatYAML or ConfigFormatJSON
+func RenderConfigFile(app, profile, env, dataFile, region, deploymentStrategy, format string) ([]byte, error) {
 	// Use provided deployment strategy, or default to AllAtOnce if empty
 	strategy := deploymentStrategy
 	if strategy == "" {
@@ -33,18 +54,21 @@ func GenerateConfigFile(app, profile, env, dataFile, region, deploymentStrategy,
 		Region:               region,
 	}
 
-	// Marshal to YAML
-	data, err := yaml.Marshal(&cfg)
-	if err != nil {
-		return fmt.Errorf("failed to marshal config: %w", err)
+	var data []byte
+	var err error
+	switch format {
+	case ConfigFormatYAML:
+		data, err = yaml.Marshal(&cfg)
+	case ConfigFormatJSON:
+		data, err = json.MarshalIndent(&cfg, "", "  ")
+		data = append(data, '\n')
+	default:
+		return nil, fmt.Errorf("unsupported config format: %s (must be %s or %s)", format, ConfigFormatYAML, ConfigFormatJSON)
 	}
-
-	// Write to file
-	if err := os.WriteFile(outputPath, data, 0o644); err != nil {
-		return fmt.Errorf("failed to write config file: %w", err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
 	}
-
-	return nil
+	return data, nil
 }
 
 // DetermineDataFileName determines the appropriate data file name based on content type
@@ -55,16 +79,13 @@ func DetermineDataFileName(contentType string) string {
 // ExtensionForContentType returns the file extension (including the leading dot)
 // for the given AppConfig content type. Unknown types fall back to ".json".
 func ExtensionForContentType(contentType string) string {
-	ct := strings.ToLower(strings.TrimSpace(contentType))
-	if idx := strings.Index(ct, ";"); idx != -1 {
-		ct = strings.TrimSpace(ct[:idx])
-	}
-
-	switch ct {
+	switch mediaType(contentType) {
 	case ContentTypeJSON:
 		return ".json"
 	case ContentTypeYAML, "application/yaml":
 		return ".yaml"
+	case ContentTypeTOML:
+		return ".toml"
 	case ContentTypeText:
 		return ".txt"
 	default:
@@ -74,23 +95,19 @@ func ExtensionForContentType(contentType string) string {
 
 // WriteDataFile writes configuration data to a file with appropriate formatting
 // For FeatureFlags profile type, it removes _updatedAt and _createdAt fields
+// The file is replaced atomically, so an interrupted write (e.g. Ctrl-C during
+// pull) leaves the previous content intact
 func WriteDataFile(content []byte, contentType, outputPath, profileType string, force bool) error {
 	// Check if file already exists
 	if _, err := os.Stat(outputPath); err == nil && !force {
 		return fmt.Errorf("data file already exists at %s (use --force to overwrite)", outputPath)
 	}
 
-	// Normalize content type
-	ct := strings.ToLower(strings.TrimSpace(contentType))
-	if idx := strings.Index(ct, ";"); idx != -1 {
-		ct = strings.TrimSpace(ct[:idx])
-	}
-
 	var dataToWrite []byte
 	var err error
 
 	// Format based on content type
-	switch ct {
+	switch mediaType(contentType) {
 	case ContentTypeJSON:
 		// Format JSON with indentation
 		dataToWrite, err = formatJSON(content, profileType)
@@ -103,7 +120,7 @@ func WriteDataFile(content []byte, contentType, outputPath, profileType string,
 	}
 
 	// Write to file
-	if err := os.WriteFile(outputPath, dataToWrite, 0o644); err != nil {
+	if err := WriteFileAtomic(outputPath, dataToWrite, 0o644); err != nil {
 		return fmt.Errorf("failed to write data file: %w", err)
 	}
 
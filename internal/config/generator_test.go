@@ -1,6 +1,7 @@
 package config
 
 import (
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"strings"
@@ -91,7 +92,7 @@ func TestGenerateConfigFile(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := GenerateConfigFile(tt.app, tt.profile, tt.env, tt.dataFile, tt.region, tt.deploymentStrategy, tt.outputPath, false)
+			err := GenerateConfigFile(tt.app, tt.profile, tt.env, tt.dataFile, tt.region, tt.deploymentStrategy, tt.outputPath, "", false)
 
 			if tt.wantErr && err == nil {
 				t.Error("expected error but got none")
@@ -135,13 +136,13 @@ func TestGenerateConfigFileOverwrite(t *testing.T) {
 	}
 
 	// Try to generate - should fail without force flag
-	err := GenerateConfigFile("app", "profile", "env", "data.json", "us-east-1", "", configPath, false)
+	err := GenerateConfigFile("app", "profile", "env", "data.json", "us-east-1", "", configPath, "", false)
 	if err == nil {
 		t.Error("expected error when overwriting existing file, but got none")
 	}
 
 	// Try again with force flag - should succeed
-	err = GenerateConfigFile("app", "profile", "env", "data.json", "us-east-1", "", configPath, true)
+	err = GenerateConfigFile("app", "profile", "env", "data.json", "us-east-1", "", configPath, "", true)
 	if err != nil {
 		t.Errorf("expected success with force flag, but got error: %v", err)
 	}
@@ -159,6 +160,7 @@ func TestExtensionForContentType(t *testing.T) {
 		{name: "json with charset", contentType: "application/json; charset=utf-8", want: ".json"},
 		{name: "yaml", contentType: "application/x-yaml", want: ".yaml"},
 		{name: "yaml alternative", contentType: "application/yaml", want: ".yaml"},
+		{name: "toml", contentType: "application/toml", want: ".toml"},
 		{name: "text", contentType: "text/plain", want: ".txt"},
 		{name: "unknown defaults to json", contentType: "application/octet-stream", want: ".json"},
 		{name: "empty defaults to json", contentType: "", want: ".json"},
@@ -508,3 +510,58 @@ func Test_formatJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateConfigFileJSONRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		outputPath string
+		format     string
+	}{
+		{"explicit json format", "apcdeploy.conf", ConfigFormatJSON},
+		{"json derived from extension", "apcdeploy.json", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), tt.outputPath)
+			if err := GenerateConfigFile("app", "profile", "env", "data.json", "us-east-1", "", path, tt.format, false); err != nil {
+				t.Fatalf("GenerateConfigFile() error = %v", err)
+			}
+
+			raw, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("failed to read config: %v", err)
+			}
+			var decoded map[string]string
+			if err := json.Unmarshal(raw, &decoded); err != nil {
+				t.Fatalf("expected JSON output, got %q: %v", raw, err)
+			}
+			if decoded["configuration_profile"] != "profile" {
+				t.Errorf("unexpected JSON keys: %v", decoded)
+			}
+
+			// LoadConfig picks the decoder by extension; the YAML decoder
+			// also accepts JSON, so both paths must round-trip.
+			cfg, err := LoadConfig(path)
+			if err != nil {
+				t.Fatalf("LoadConfig() error = %v", err)
+			}
+			if cfg.Application != "app" || cfg.Environment != "env" || cfg.DeploymentStrategy != DefaultDeploymentStrategy {
+				t.Errorf("unexpected config after round trip: %+v", cfg)
+			}
+		})
+	}
+}
+
+func TestGenerateConfigFileUnsupportedFormat(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "apcdeploy.yml")
+	err := GenerateConfigFile("app", "profile", "env", "data.json", "", "", path, "toml", false)
+	if err == nil || !strings.Contains(err.Error(), "unsupported config format") {
+		t.Errorf("expected unsupported config format error, got %v", err)
+	}
+}
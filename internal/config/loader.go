@@ -1,14 +1,18 @@
 package config
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/goccy/go-yaml"
 )
 
-// LoadConfig loads and validates a configuration file
+// LoadConfig loads and validates a configuration file in YAML or JSON (see
+// decodeConfig for how the format is chosen).
 func LoadConfig(path string) (*Config, error) {
 	// Read file
 	data, err := os.ReadFile(path)
@@ -16,34 +20,89 @@ func LoadConfig(path string) (*Config, error) {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
-	// Parse YAML
-	var config Config
-	if err := yaml.Unmarshal(data, &config); err != nil {
-		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	config, err := decodeConfig(path, data)
+	if err != nil {
+		return nil, err
 	}
+	if err := PrepareConfig(config, path); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
 
+// PrepareConfig finishes a decoded config the way LoadConfig does: it sets
+// defaults, validates, and resolves relative data_file and schema_file
+// paths against the directory of path. It is exported for configs embedded
+// in another file (apply manifests), where path is that file.
+func PrepareConfig(config *Config, path string) error {
 	// Set defaults
 	config.setDefaults()
 
 	// Validate
 	if err := config.validate(); err != nil {
-		return nil, fmt.Errorf("invalid configuration: %w", err)
+		return fmt.Errorf("invalid configuration: %w", err)
 	}
 
 	// Resolve data file path if relative
 	absConfigPath, err := filepath.Abs(path)
 	if err != nil {
-		return nil, fmt.Errorf("failed to resolve config path: %w", err)
+		return fmt.Errorf("failed to resolve config path: %w", err)
 	}
 	config.DataFile = resolveDataFilePath(absConfigPath, config.DataFile)
+	if config.SchemaFile != "" {
+		config.SchemaFile = resolveDataFilePath(absConfigPath, config.SchemaFile)
+	}
+	return nil
+}
 
+// ReadConfigFile parses a config file as written, applying defaults but
+// without validation or data_file path resolution. It is meant for comparing
+// the file itself (init --check); use LoadConfig everywhere else.
+func ReadConfigFile(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	config, err := decodeConfig(path, data)
+	if err != nil {
+		return nil, err
+	}
+	config.setDefaults()
+	return config, nil
+}
+
+// decodeConfig parses a config file into Config. .json files are decoded as
+// JSON and .yml/.yaml files as YAML; for any other extension the content is
+// sniffed, treating a leading "{" as JSON.
+func decodeConfig(path string, data []byte) (*Config, error) {
+	format := ConfigFormatYAML
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		format = ConfigFormatJSON
+	case ".yml", ".yaml":
+	default:
+		if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+			format = ConfigFormatJSON
+		}
+	}
+
+	var config Config
+	if format == ConfigFormatJSON {
+		if err := json.Unmarshal(data, &config); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON: %w", err)
+		}
+		return &config, nil
+	}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
 	return &config, nil
 }
 
 // resolveDataFilePath resolves a data file path relative to the config file
 func resolveDataFilePath(configPath, dataFile string) string {
-	// If data file is already absolute, return as-is
-	if filepath.IsAbs(dataFile) {
+	// If data file is already absolute or stdin, return as-is
+	if filepath.IsAbs(dataFile) || dataFile == StdinDataFile {
 		return dataFile
 	}
 
@@ -51,3 +110,12 @@ func resolveDataFilePath(configPath, dataFile string) string {
 	configDir := filepath.Dir(configPath)
 	return filepath.Join(configDir, dataFile)
 }
+
+// ConfigFormatForPath returns ConfigFormatJSON for paths ending in .json and
+// ConfigFormatYAML otherwise.
+func ConfigFormatForPath(path string) string {
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		return ConfigFormatJSON
+	}
+	return ConfigFormatYAML
+}
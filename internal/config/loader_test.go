@@ -1,7 +1,9 @@
 package config
 
 import (
+	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 )
 
@@ -26,6 +28,16 @@ func TestLoadConfig(t *testing.T) {
 			path:    "../../testdata/config/malformed.yml",
 			wantErr: true,
 		},
+		{
+			name:    "valid JSON config file",
+			path:    "../../testdata/config/valid.json",
+			wantErr: false,
+		},
+		{
+			name:    "malformed JSON",
+			path:    "../../testdata/config/malformed.json",
+			wantErr: true,
+		},
 		{
 			name:    "invalid config - missing required fields",
 			path:    "../../testdata/config/invalid.yml",
@@ -112,6 +124,25 @@ func TestLoadConfigValues(t *testing.T) {
 	}
 }
 
+func TestLoadConfigSchemaFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "apcdeploy.yml")
+	content := "application: app\nconfiguration_profile: prof\nenvironment: env\ndata_file: data.json\nschema_file: schemas/config.json\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if want := filepath.Join(dir, "schemas", "config.json"); cfg.SchemaFile != want {
+		t.Errorf("SchemaFile = %q, want %q", cfg.SchemaFile, want)
+	}
+}
+
 func Test_resolveDataFilePath(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -131,6 +162,11 @@ func Test_resolveDataFilePath(t *testing.T) {
 			dataFile:   "/absolute/path/data.json",
 			wantAbs:    true,
 		},
+		{
+			name:       "stdin unchanged",
+			configPath: "/path/to/apcdeploy.yml",
+			dataFile:   "-",
+		},
 	}
 
 	for _, tt := range tests {
@@ -139,9 +175,65 @@ func Test_resolveDataFilePath(t *testing.T) {
 			if tt.wantAbs && !filepath.IsAbs(result) {
 				t.Errorf("Expected absolute path, got %s", result)
 			}
-			if filepath.IsAbs(tt.dataFile) && result != tt.dataFile {
-				t.Errorf("Expected absolute path unchanged, got %s", result)
+			if (filepath.IsAbs(tt.dataFile) || tt.dataFile == StdinDataFile) && result != tt.dataFile {
+				t.Errorf("Expected path unchanged, got %s", result)
 			}
 		})
 	}
 }
+
+func TestLoadConfigJSONAndYAMLEquivalent(t *testing.T) {
+	t.Parallel()
+
+	yamlContent := `application: TestApp
+configuration_profile: TestProfile
+environment: Production
+deployment_strategy: AppConfig.Linear50PercentEvery30Seconds
+data_file: data.json
+region: us-east-1
+content_type: application/json
+`
+	jsonContent := `{
+  "application": "TestApp",
+  "configuration_profile": "TestProfile",
+  "environment": "Production",
+  "deployment_strategy": "AppConfig.Linear50PercentEvery30Seconds",
+  "data_file": "data.json",
+  "region": "us-east-1",
+  "content_type": "application/json"
+}
+`
+	dir := t.TempDir()
+	files := map[string]string{
+		"apcdeploy.yml":  yamlContent,
+		"apcdeploy.json": jsonContent,
+		// No recognised extension: the format is sniffed from the content.
+		"apcdeploy.conf": jsonContent,
+		"apcdeploy":      yamlContent,
+	}
+
+	want, err := loadFromString(t, dir, "apcdeploy.yml", yamlContent)
+	if err != nil {
+		t.Fatalf("LoadConfig(yaml) error = %v", err)
+	}
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			got, err := loadFromString(t, dir, name, content)
+			if err != nil {
+				t.Fatalf("LoadConfig(%s) error = %v", name, err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("LoadConfig(%s) = %+v, want %+v", name, got, want)
+			}
+		})
+	}
+}
+
+func loadFromString(t *testing.T, dir, name, content string) (*Config, error) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+	return LoadConfig(path)
+}
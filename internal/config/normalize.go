@@ -5,8 +5,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"unicode"
 
 	"github.com/goccy/go-yaml"
+	"github.com/pelletier/go-toml/v2"
 )
 
 // NormalizeJSON normalizes JSON content by parsing and re-formatting with sorted keys.
@@ -18,11 +20,12 @@ import (
 //
 // Returns:
 //   - string: Normalized JSON with consistent formatting
-//   - error: Any error during parsing or formatting
+//   - error: Any error during parsing (a *SyntaxError when the position is
+//     known) or formatting
 func NormalizeJSON(content string, profileType string) (string, error) {
 	var data any
 	if err := json.Unmarshal([]byte(content), &data); err != nil {
-		return "", fmt.Errorf("invalid JSON: %w", err)
+		return "", jsonSyntaxError([]byte(content), err)
 	}
 
 	// For FeatureFlags, remove _updatedAt and _createdAt fields recursively
@@ -46,11 +49,12 @@ func NormalizeJSON(content string, profileType string) (string, error) {
 //
 // Returns:
 //   - string: Normalized YAML with consistent formatting
-//   - error: Any error during parsing or formatting
+//   - error: Any error during parsing (a *SyntaxError when the position is
+//     known) or formatting
 func NormalizeYAML(content string) (string, error) {
 	var data any
 	if err := yaml.Unmarshal([]byte(content), &data); err != nil {
-		return "", fmt.Errorf("invalid YAML: %w", err)
+		return "", yamlSyntaxError([]byte(content), err)
 	}
 
 	// Re-marshal with consistent formatting
@@ -62,46 +66,133 @@ func NormalizeYAML(content string) (string, error) {
 	return string(normalized), nil
 }
 
-// NormalizeText normalizes text content by ensuring consistent line endings.
+// NormalizeTOML normalizes TOML content by parsing and re-formatting with
+// sorted keys, so reordered keys and tables compare equal.
+//
+// Parameters:
+//   - content: TOML string to normalize
+//
+// Returns:
+//   - string: Normalized TOML with consistent formatting
+//   - error: Any error during parsing or formatting
+func NormalizeTOML(content string) (string, error) {
+	var data map[string]any
+	if err := toml.Unmarshal([]byte(content), &data); err != nil {
+		return "", fmt.Errorf("invalid TOML: %w", err)
+	}
+
+	// Maps are encoded with their keys sorted
+	normalized, err := toml.Marshal(data)
+	if err != nil {
+		return "", fmt.Errorf("failed to format TOML: %w", err)
+	}
+
+	return string(normalized), nil
+}
+
+// TextNormalizeOptions holds the text normalizations chosen by the
+// text_normalize config key. The zero value unifies line endings and the
+// trailing newline and strips trailing whitespace from each line.
+type TextNormalizeOptions struct {
+	// KeepTrailingWhitespace turns off stripping whitespace at the end of
+	// each line. Stripping covers spaces and tabs as well as Unicode
+	// whitespace such as U+00A0 (no-break space) and U+3000 (ideographic
+	// space); leading indentation is always kept.
+	KeepTrailingWhitespace bool
+	// CollapseBlankLines reduces runs of blank lines to a single blank line
+	CollapseBlankLines bool
+}
+
+// TextNormalizeOptions returns the text normalizations chosen in the config.
+func (c *Config) TextNormalizeOptions() TextNormalizeOptions {
+	var opts TextNormalizeOptions
+	if c == nil {
+		return opts
+	}
+	for _, name := range c.TextNormalize {
+		switch name {
+		case TextNormalizeKeepTrailingWS:
+			opts.KeepTrailingWhitespace = true
+		case TextNormalizeCollapseBlankLines:
+			opts.CollapseBlankLines = true
+		}
+	}
+	return opts
+}
+
+// NormalizeText normalizes text content by ensuring consistent line endings
+// and, unless opts keeps it, removing trailing whitespace from each line.
 //
 // Parameters:
 //   - content: Text string to normalize
+//   - opts: Optional normalizations applied on top of the line ending handling
 //
 // Returns:
 //   - string: Normalized text with LF line endings and single trailing newline
-func NormalizeText(content string) string {
+func NormalizeText(content string, opts TextNormalizeOptions) string {
 	// Convert CRLF to LF
 	content = strings.ReplaceAll(content, "\r\n", "\n")
+
+	if !opts.KeepTrailingWhitespace || opts.CollapseBlankLines {
+		lines := strings.Split(content, "\n")
+		out := lines[:0]
+		for _, line := range lines {
+			if !opts.KeepTrailingWhitespace {
+				line = strings.TrimRightFunc(line, unicode.IsSpace)
+			}
+			// A line is blank when it has nothing left after trimming, so
+			// whitespace-only lines only collapse when trimming is also on
+			if opts.CollapseBlankLines && line == "" && len(out) > 0 && out[len(out)-1] == "" {
+				continue
+			}
+			out = append(out, line)
+		}
+		content = strings.Join(out, "\n")
+	}
+
 	// Ensure single trailing newline
 	content = strings.TrimRight(content, "\n") + "\n"
 	return content
 }
 
 // NormalizeByExtension dispatches to the appropriate normalizer based on a
-// file extension (".json", ".yaml"/".yml", otherwise treated as text).
+// file extension (".json", ".yaml"/".yml", ".toml", otherwise treated as
+// text).
 // The extension match is case-insensitive (".JSON" works the same as ".json").
-func NormalizeByExtension(content, ext, profileType string) (string, error) {
+// textOpts only applies to text content.
+func NormalizeByExtension(content, ext, profileType string, textOpts TextNormalizeOptions) (string, error) {
 	switch strings.ToLower(ext) {
 	case ".json":
 		return NormalizeJSON(content, profileType)
 	case ".yaml", ".yml":
+		// FeatureFlags YAML is uploaded as JSON, so the deployed JSON and the
+		// local YAML both compare in normalized JSON form
+		if profileType == ProfileTypeFeatureFlags {
+			converted, err := yaml.YAMLToJSON([]byte(content))
+			if err != nil {
+				return "", fmt.Errorf("invalid YAML: %w", err)
+			}
+			return NormalizeJSON(string(converted), profileType)
+		}
 		return NormalizeYAML(content)
+	case ".toml":
+		return NormalizeTOML(content)
 	default:
-		return NormalizeText(content), nil
+		return NormalizeText(content, textOpts), nil
 	}
 }
 
 // HasContentChanged reports whether two content blobs differ after
 // normalization. It short-circuits when the raw bytes are already equal.
-func HasContentChanged(before, after []byte, ext, profileType string) (bool, error) {
+func HasContentChanged(before, after []byte, ext, profileType string, textOpts TextNormalizeOptions) (bool, error) {
 	if bytes.Equal(before, after) {
 		return false, nil
 	}
-	normalizedBefore, err := NormalizeByExtension(string(before), ext, profileType)
+	normalizedBefore, err := NormalizeByExtension(string(before), ext, profileType, textOpts)
 	if err != nil {
 		return false, fmt.Errorf("failed to normalize original content: %w", err)
 	}
-	normalizedAfter, err := NormalizeByExtension(string(after), ext, profileType)
+	normalizedAfter, err := NormalizeByExtension(string(after), ext, profileType, textOpts)
 	if err != nil {
 		return false, fmt.Errorf("failed to normalize edited content: %w", err)
 	}
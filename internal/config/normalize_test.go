@@ -98,6 +98,47 @@ func TestNormalizeYAML(t *testing.T) {
 	}
 }
 
+func TestNormalizeTOML(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "keys are sorted",
+			content: "b = 2\na = 1\n",
+			want:    "a = 1\nb = 2\n",
+		},
+		{
+			name:    "tables are sorted",
+			content: "[server]\nport = 8080\nhost = 'localhost'\n\n[app]\nname = \"demo\"\n",
+			want:    "[app]\nname = 'demo'\n\n[server]\nhost = 'localhost'\nport = 8080\n",
+		},
+		{
+			name:    "invalid TOML",
+			content: "a = \n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := NormalizeTOML(tt.content)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NormalizeTOML() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeTOML() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestNormalizeText(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -134,11 +175,16 @@ func TestNormalizeText(t *testing.T) {
 			content: "",
 			want:    "\n",
 		},
+		{
+			name:    "trailing whitespace is ignored by default",
+			content: "key = value \t\u00a0\n",
+			want:    "key = value\n",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := NormalizeText(tt.content)
+			got := NormalizeText(tt.content, TextNormalizeOptions{})
 			if got != tt.want {
 				t.Errorf("NormalizeText() = %q, want %q", got, tt.want)
 			}
@@ -146,6 +192,131 @@ func TestNormalizeText(t *testing.T) {
 	}
 }
 
+func TestNormalizeTextTrimTrailingWhitespace(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{name: "spaces", content: "key = value   \nnext  \n", want: "key = value\nnext\n"},
+		{name: "tabs", content: "key = value\t\t\nnext\t", want: "key = value\nnext\n"},
+		{name: "no-break spaces", content: "key = value\u00a0\u00a0\nnext\u00a0\n", want: "key = value\nnext\n"},
+		{name: "ideographic and thin spaces", content: "キー\u3000\nnext\u2009\n", want: "キー\nnext\n"},
+		{name: "mixed with CRLF", content: "a \u00a0\t\r\nb\r\n", want: "a\nb\n"},
+		{name: "leading indentation is kept", content: "\u00a0 \tindented \u00a0\n    four\n", want: "\u00a0 \tindented\n    four\n"},
+		{name: "whitespace-only lines become empty", content: "a\n\u00a0\t\nb\n\n\n", want: "a\n\nb\n"},
+		{name: "inner whitespace is kept", content: "a\u00a0=\u00a0b\u00a0\n", want: "a\u00a0=\u00a0b\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := NormalizeText(tt.content, TextNormalizeOptions{})
+			if got != tt.want {
+				t.Errorf("NormalizeText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeTextOptions(t *testing.T) {
+	t.Parallel()
+
+	content := "line1  \r\n\tindented\t\n\n\n  \n\nline2 \n\n"
+	tests := []struct {
+		name string
+		opts TextNormalizeOptions
+		want string
+	}{
+		{
+			name: "defaults trim trailing whitespace and keep blank lines",
+			opts: TextNormalizeOptions{},
+			want: "line1\n\tindented\n\n\n\n\nline2\n",
+		},
+		{
+			name: "keep trailing whitespace",
+			opts: TextNormalizeOptions{KeepTrailingWhitespace: true},
+			want: "line1  \n\tindented\t\n\n\n  \n\nline2 \n",
+		},
+		{
+			name: "keep trailing whitespace and collapse blank lines",
+			opts: TextNormalizeOptions{KeepTrailingWhitespace: true, CollapseBlankLines: true},
+			want: "line1  \n\tindented\t\n\n  \n\nline2 \n",
+		},
+		{
+			name: "collapse blank lines",
+			opts: TextNormalizeOptions{CollapseBlankLines: true},
+			want: "line1\n\tindented\n\nline2\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := NormalizeText(content, tt.opts); got != tt.want {
+				t.Errorf("NormalizeText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfigTextNormalizeOptions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		cfg  *Config
+		want TextNormalizeOptions
+	}{
+		{"nil config", nil, TextNormalizeOptions{}},
+		{"unset", &Config{}, TextNormalizeOptions{}},
+		{"trim is the default", &Config{TextNormalize: []string{TextNormalizeTrimTrailingWS}}, TextNormalizeOptions{}},
+		{"keep only", &Config{TextNormalize: []string{TextNormalizeKeepTrailingWS}}, TextNormalizeOptions{KeepTrailingWhitespace: true}},
+		{"collapse only", &Config{TextNormalize: []string{TextNormalizeCollapseBlankLines}}, TextNormalizeOptions{CollapseBlankLines: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.cfg.TextNormalizeOptions(); got != tt.want {
+				t.Errorf("TextNormalizeOptions() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHasContentChangedTextOptions(t *testing.T) {
+	t.Parallel()
+
+	before := []byte("key = value\n\nother = 1\n")
+	after := []byte("key = value  \n\n\n\nother = 1\n")
+
+	tests := []struct {
+		name        string
+		opts        TextNormalizeOptions
+		wantChanged bool
+	}{
+		{"defaults still see extra blank lines", TextNormalizeOptions{}, true},
+		{"keep reports whitespace drift", TextNormalizeOptions{KeepTrailingWhitespace: true, CollapseBlankLines: true}, true},
+		{"collapse ignores the drift", TextNormalizeOptions{CollapseBlankLines: true}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := HasContentChanged(before, after, ".txt", "", tt.opts)
+			if err != nil {
+				t.Fatalf("HasContentChanged() error = %v", err)
+			}
+			if got != tt.wantChanged {
+				t.Errorf("HasContentChanged() = %v, want %v", got, tt.wantChanged)
+			}
+		})
+	}
+}
+
 func TestNormalizeByExtension(t *testing.T) {
 	t.Parallel()
 
@@ -159,16 +330,18 @@ func TestNormalizeByExtension(t *testing.T) {
 		{name: "json", content: `{"key":"value"}`, ext: ".json", profileType: ProfileTypeFreeform},
 		{name: "yaml", content: "key: value\n", ext: ".yaml", profileType: ProfileTypeFreeform},
 		{name: "yml", content: "key: value\n", ext: ".yml", profileType: ProfileTypeFreeform},
+		{name: "toml", content: "key = 'value'\n", ext: ".toml", profileType: ProfileTypeFreeform},
 		{name: "txt falls back to text normalizer", content: "plain text\ncontent", ext: ".txt", profileType: ProfileTypeFreeform},
 		{name: "unknown extension falls back to text", content: "hello", ext: ".unknown", profileType: ProfileTypeFreeform},
 		{name: "invalid json", content: `{invalid}`, ext: ".json", profileType: ProfileTypeFreeform, wantErr: true},
 		{name: "invalid yaml", content: ":\ninvalid\n:", ext: ".yaml", profileType: ProfileTypeFreeform, wantErr: true},
+		{name: "invalid toml", content: "key = ", ext: ".toml", profileType: ProfileTypeFreeform, wantErr: true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			got, err := NormalizeByExtension(tt.content, tt.ext, tt.profileType)
+			got, err := NormalizeByExtension(tt.content, tt.ext, tt.profileType, TextNormalizeOptions{})
 			if (err != nil) != tt.wantErr {
 				t.Errorf("NormalizeByExtension() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -242,7 +415,7 @@ func TestHasContentChanged(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			got, err := HasContentChanged(tt.before, tt.after, tt.ext, tt.profileType)
+			got, err := HasContentChanged(tt.before, tt.after, tt.ext, tt.profileType, TextNormalizeOptions{})
 			if (err != nil) != tt.wantErr {
 				t.Errorf("HasContentChanged() error = %v, wantErr %v", err, tt.wantErr)
 				return
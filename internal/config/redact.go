@@ -0,0 +1,232 @@
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/pelletier/go-toml/v2"
+)
+
+const (
+	// RedactedValue replaces sensitive values in human-facing output
+	RedactedValue = "[REDACTED]"
+	// RedactedChangedValue replaces a sensitive value on the new side of a
+	// diff when it differs from the old one, so a secret rotation still shows
+	// up as a change without revealing either value
+	RedactedChangedValue = "[REDACTED (changed)]"
+)
+
+// sensitiveKeyMarkers are the key substrings (case-insensitive) whose values
+// are always redacted, in addition to the redact_fields paths.
+var sensitiveKeyMarkers = []string{"password", "secret", "token"}
+
+// isSensitiveKey reports whether a key name matches the default heuristics.
+func isSensitiveKey(key string) bool {
+	lower := strings.ToLower(key)
+	for _, marker := range sensitiveKeyMarkers {
+		if strings.Contains(lower, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactPair masks sensitive values in two versions of the same normalized
+// content (as produced by NormalizeByExtension) for display, e.g. as the two
+// sides of a diff. A value is sensitive when its key matches the default
+// heuristics or its path matches one of fields (dotted paths such as
+// "database.password"; "*" matches any single key or array index). Values
+// that differ between the versions are masked as RedactedChangedValue on the
+// local side. Content that fails to parse is redacted line by line.
+func RedactPair(remote, local, ext string, fields []string) (string, string) {
+	rd := &redactor{}
+	for _, f := range fields {
+		rd.patterns = append(rd.patterns, strings.Split(f, "."))
+	}
+
+	switch strings.ToLower(ext) {
+	case ".json":
+		var r, l any
+		if json.Unmarshal([]byte(remote), &r) == nil && json.Unmarshal([]byte(local), &l) == nil {
+			r, l = rd.pair(r, l, nil)
+			if !rd.masked {
+				return remote, local
+			}
+			rOut, rErr := json.MarshalIndent(r, "", "  ")
+			lOut, lErr := json.MarshalIndent(l, "", "  ")
+			if rErr == nil && lErr == nil {
+				return string(rOut), string(lOut)
+			}
+		}
+	case ".yaml", ".yml":
+		var r, l any
+		if yaml.Unmarshal([]byte(remote), &r) == nil && yaml.Unmarshal([]byte(local), &l) == nil {
+			r, l = rd.pair(r, l, nil)
+			if !rd.masked {
+				return remote, local
+			}
+			rOut, rErr := yaml.Marshal(r)
+			lOut, lErr := yaml.Marshal(l)
+			if rErr == nil && lErr == nil {
+				return string(rOut), string(lOut)
+			}
+		}
+	case ".toml":
+		var r, l map[string]any
+		if toml.Unmarshal([]byte(remote), &r) == nil && toml.Unmarshal([]byte(local), &l) == nil {
+			rv, lv := rd.pair(r, l, nil)
+			if !rd.masked {
+				return remote, local
+			}
+			rOut, rErr := toml.Marshal(rv)
+			lOut, lErr := toml.Marshal(lv)
+			if rErr == nil && lErr == nil {
+				return string(rOut), string(lOut)
+			}
+		}
+	}
+	return redactTextPair(remote, local, rd.patterns)
+}
+
+// redactor holds the split redact_fields paths and records whether any
+// value was masked, so unaffected content keeps its original rendering.
+type redactor struct {
+	patterns [][]string
+	masked   bool
+}
+
+// pair walks both documents in step; a nil document means the path is absent
+// on that side.
+func (rd *redactor) pair(remote, local any, path []string) (any, any) {
+	if len(path) > 0 && (isSensitiveKey(path[len(path)-1]) || matchesAny(path, rd.patterns)) {
+		rd.masked = true
+		return maskPair(remote, local)
+	}
+
+	switch l := local.(type) {
+	case map[string]any:
+		r, _ := remote.(map[string]any)
+		for k, lv := range l {
+			rv, ok := r[k]
+			if !ok {
+				rv = nil
+			}
+			nr, nl := rd.pair(rv, lv, append(path, k))
+			l[k] = nl
+			if ok {
+				r[k] = nr
+			}
+		}
+		for k, rv := range r {
+			if _, ok := l[k]; !ok {
+				r[k], _ = rd.pair(rv, nil, append(path, k))
+			}
+		}
+		return remote, l
+	case []any:
+		r, _ := remote.([]any)
+		for i := range l {
+			var rv any
+			if i < len(r) {
+				rv = r[i]
+			}
+			nr, nl := rd.pair(rv, l[i], append(path, strconv.Itoa(i)))
+			l[i] = nl
+			if i < len(r) {
+				r[i] = nr
+			}
+		}
+		for i := len(l); i < len(r); i++ {
+			r[i], _ = rd.pair(r[i], nil, append(path, strconv.Itoa(i)))
+		}
+		return remote, l
+	}
+
+	// local is a scalar (or absent): remote may still hold a container with
+	// sensitive children.
+	switch remote.(type) {
+	case map[string]any, []any:
+		_, remote = rd.pair(nil, remote, path)
+	}
+	return remote, local
+}
+
+// maskPair replaces both sides of a sensitive value, marking the local side
+// when it changed. Absent (nil) sides stay absent.
+func maskPair(remote, local any) (any, any) {
+	var r, l any
+	if remote != nil {
+		r = RedactedValue
+	}
+	if local != nil {
+		l = RedactedValue
+		if remote != nil && !reflect.DeepEqual(remote, local) {
+			l = RedactedChangedValue
+		}
+	}
+	return r, l
+}
+
+// matchesAny reports whether path matches one of the dotted patterns.
+func matchesAny(path []string, patterns [][]string) bool {
+	for _, p := range patterns {
+		if len(p) != len(path) {
+			continue
+		}
+		match := true
+		for i := range p {
+			if p[i] != "*" && p[i] != path[i] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
+
+// textAssignment matches "key = value", "key: value" and quoted keys in
+// freeform text such as .properties, .env or .ini content.
+var textAssignment = regexp.MustCompile(`^(\s*(?:export\s+)?["']?([A-Za-z0-9_.\-]+)["']?\s*[:=]\s*)(\S.*)$`)
+
+// redactTextPair redacts assignments whose key is sensitive or matches a
+// single-segment redact_fields entry (dotted paths also match keys written
+// with dots, as in .properties files).
+func redactTextPair(remote, local string, patterns [][]string) (string, string) {
+	sensitive := func(key string) bool {
+		return isSensitiveKey(key) || matchesAny(strings.Split(key, "."), patterns)
+	}
+
+	remoteValues := map[string]string{}
+	redact := func(content string, changed func(key, value string) bool) string {
+		lines := strings.Split(content, "\n")
+		for i, line := range lines {
+			m := textAssignment.FindStringSubmatch(line)
+			if m == nil || !sensitive(m[2]) {
+				continue
+			}
+			mask := RedactedValue
+			if changed(m[2], m[3]) {
+				mask = RedactedChangedValue
+			}
+			lines[i] = m[1] + mask
+		}
+		return strings.Join(lines, "\n")
+	}
+
+	redactedRemote := redact(remote, func(key, value string) bool {
+		remoteValues[key] = value
+		return false
+	})
+	redactedLocal := redact(local, func(key, value string) bool {
+		old, ok := remoteValues[key]
+		return ok && old != value
+	})
+	return redactedRemote, redactedLocal
+}
@@ -0,0 +1,110 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRedactPair(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		remote     string
+		local      string
+		ext        string
+		fields     []string
+		wantRemote string
+		wantLocal  string
+	}{
+		{
+			name:       "heuristic keys are masked case-insensitively",
+			remote:     "{\n  \"DB_Password\": \"a\",\n  \"apiToken\": \"t\",\n  \"host\": \"h\"\n}",
+			local:      "{\n  \"DB_Password\": \"a\",\n  \"apiToken\": \"t\",\n  \"host\": \"h\"\n}",
+			ext:        ".json",
+			wantRemote: "{\n  \"DB_Password\": \"[REDACTED]\",\n  \"apiToken\": \"[REDACTED]\",\n  \"host\": \"h\"\n}",
+			wantLocal:  "{\n  \"DB_Password\": \"[REDACTED]\",\n  \"apiToken\": \"[REDACTED]\",\n  \"host\": \"h\"\n}",
+		},
+		{
+			name:       "nested path from redact_fields",
+			remote:     "{\n  \"db\": {\n    \"dsn\": \"old\",\n    \"host\": \"h\"\n  }\n}",
+			local:      "{\n  \"db\": {\n    \"dsn\": \"new\",\n    \"host\": \"h\"\n  }\n}",
+			ext:        ".json",
+			fields:     []string{"db.dsn"},
+			wantRemote: "{\n  \"db\": {\n    \"dsn\": \"[REDACTED]\",\n    \"host\": \"h\"\n  }\n}",
+			wantLocal:  "{\n  \"db\": {\n    \"dsn\": \"[REDACTED (changed)]\",\n    \"host\": \"h\"\n  }\n}",
+		},
+		{
+			name:       "wildcard matches array indexes",
+			remote:     "{\n  \"users\": [\n    {\n      \"key\": \"k1\",\n      \"name\": \"a\"\n    }\n  ]\n}",
+			local:      "{\n  \"users\": [\n    {\n      \"key\": \"k1\",\n      \"name\": \"a\"\n    },\n    {\n      \"key\": \"k2\",\n      \"name\": \"b\"\n    }\n  ]\n}",
+			ext:        ".json",
+			fields:     []string{"users.*.key"},
+			wantRemote: "{\n  \"users\": [\n    {\n      \"key\": \"[REDACTED]\",\n      \"name\": \"a\"\n    }\n  ]\n}",
+			wantLocal:  "{\n  \"users\": [\n    {\n      \"key\": \"[REDACTED]\",\n      \"name\": \"a\"\n    },\n    {\n      \"key\": \"[REDACTED]\",\n      \"name\": \"b\"\n    }\n  ]\n}",
+		},
+		{
+			name:       "sensitive key masks the whole subtree",
+			remote:     "secrets:\n  a: 1\n  b: 2\nport: 80\n",
+			local:      "secrets:\n  a: 1\nport: 80\n",
+			ext:        ".yaml",
+			wantRemote: "port: 80\nsecrets: \"[REDACTED]\"\n",
+			wantLocal:  "port: 80\nsecrets: \"[REDACTED (changed)]\"\n",
+		},
+		{
+			name:       "removed sensitive container on the remote side is still masked",
+			remote:     "app:\n  auth:\n    client_secret: s\n",
+			local:      "app: disabled\n",
+			ext:        ".yml",
+			wantRemote: "app:\n  auth:\n    client_secret: \"[REDACTED]\"\n",
+			wantLocal:  "app: disabled\n",
+		},
+		{
+			name:       "nothing sensitive keeps the original rendering",
+			remote:     "a: 1\n",
+			local:      "a: 2\n",
+			ext:        ".yaml",
+			wantRemote: "a: 1\n",
+			wantLocal:  "a: 2\n",
+		},
+		{
+			name:       "toml tables",
+			remote:     "[db]\nhost = 'h'\npassword = 'old'\n",
+			local:      "[db]\nhost = 'h'\npassword = 'new'\n",
+			ext:        ".toml",
+			wantRemote: "[db]\nhost = 'h'\npassword = '[REDACTED]'\n",
+			wantLocal:  "[db]\nhost = 'h'\npassword = '[REDACTED (changed)]'\n",
+		},
+		{
+			name:       "text assignments",
+			remote:     "host=h\nDB_PASSWORD=old\napi.key: k\n",
+			local:      "host=h\nDB_PASSWORD=new\napi.key: k\n",
+			ext:        ".properties",
+			fields:     []string{"api.key"},
+			wantRemote: "host=h\nDB_PASSWORD=[REDACTED]\napi.key: [REDACTED]\n",
+			wantLocal:  "host=h\nDB_PASSWORD=[REDACTED (changed)]\napi.key: [REDACTED]\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			gotRemote, gotLocal := RedactPair(tt.remote, tt.local, tt.ext, tt.fields)
+			if gotRemote != tt.wantRemote {
+				t.Errorf("remote = %q, want %q", gotRemote, tt.wantRemote)
+			}
+			if gotLocal != tt.wantLocal {
+				t.Errorf("local = %q, want %q", gotLocal, tt.wantLocal)
+			}
+		})
+	}
+}
+
+func TestRedactPairInvalidJSONFallsBackToText(t *testing.T) {
+	t.Parallel()
+
+	_, got := RedactPair("{", "token: abc", ".json", nil)
+	if strings.Contains(got, "abc") {
+		t.Errorf("RedactPair() = %q, want the token value masked", got)
+	}
+}
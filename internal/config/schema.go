@@ -0,0 +1,161 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dlclark/regexp2"
+	"github.com/goccy/go-yaml"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// SchemaError reports the first place a document violates the JSON Schema
+// named by schema_file.
+type SchemaError struct {
+	// Path is the JSON path of the offending value (e.g. "$.servers[0].port")
+	Path string
+	// Message describes the violation
+	Message string
+}
+
+func (e *SchemaError) Error() string {
+	return fmt.Sprintf("configuration does not match schema at %s: %s", e.Path, e.Message)
+}
+
+// schemaPatternTimeout bounds a single "pattern" match so a pathological
+// expression cannot hang validation.
+const schemaPatternTimeout = time.Second
+
+// ValidateSchema validates data of contentType against the JSON Schema stored
+// at schemaPath. YAML is converted to JSON first; other content types cannot
+// be checked and are an error rather than silently skipped. An empty
+// schemaPath does nothing.
+func ValidateSchema(data []byte, contentType, schemaPath string) error {
+	if schemaPath == "" {
+		return nil
+	}
+	switch mediaType(contentType) {
+	case ContentTypeJSON:
+		return ValidateSchemaFile(data, schemaPath)
+	case ContentTypeYAML, "application/yaml":
+		jsonData, err := yaml.YAMLToJSON(data)
+		if err != nil {
+			return fmt.Errorf("invalid YAML syntax: %w", err)
+		}
+		return ValidateSchemaFile(jsonData, schemaPath)
+	default:
+		return fmt.Errorf("schema_file only applies to JSON and YAML data, not %s", mediaType(contentType))
+	}
+}
+
+// ValidateSchemaFile validates a JSON document against the JSON Schema stored
+// at schemaPath using a full JSON Schema implementation (draft 2020-12 unless
+// the schema names another draft with $schema). "format" is asserted,
+// "pattern" uses ECMA-262 regular expressions as the specification requires,
+// and $ref may point into the same schema or at files next to it. The first
+// violation is returned as a *SchemaError.
+func ValidateSchemaFile(data []byte, schemaPath string) error {
+	raw, err := os.ReadFile(schemaPath)
+	if err != nil {
+		return fmt.Errorf("failed to read schema file: %w", err)
+	}
+	schemaDoc, err := jsonschema.UnmarshalJSON(bytes.NewReader(raw))
+	if err != nil {
+		return fmt.Errorf("invalid schema file %s: %w", schemaPath, err)
+	}
+	absPath, err := filepath.Abs(schemaPath)
+	if err != nil {
+		return fmt.Errorf("failed to read schema file: %w", err)
+	}
+
+	compiler := jsonschema.NewCompiler()
+	compiler.AssertFormat()
+	compiler.UseRegexpEngine(ecmaRegexpCompile)
+	if err := compiler.AddResource(absPath, schemaDoc); err != nil {
+		return fmt.Errorf("invalid schema file %s: %w", schemaPath, err)
+	}
+	schema, err := compiler.Compile(absPath)
+	if err != nil {
+		return fmt.Errorf("invalid schema file %s: %w", schemaPath, err)
+	}
+
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("invalid JSON syntax: %w", err)
+	}
+	err = schema.Validate(doc)
+	var verr *jsonschema.ValidationError
+	if errors.As(err, &verr) {
+		return toSchemaError(verr, doc)
+	}
+	return err
+}
+
+// schemaPrinter renders validation messages.
+var schemaPrinter = message.NewPrinter(language.English)
+
+// toSchemaError reduces a validation error tree to its first leaf, which
+// names the innermost violated keyword.
+func toSchemaError(verr *jsonschema.ValidationError, doc any) *SchemaError {
+	for len(verr.Causes) > 0 {
+		verr = verr.Causes[0]
+	}
+	return &SchemaError{
+		Path:    instancePath(verr.InstanceLocation, doc),
+		Message: verr.ErrorKind.LocalizedString(schemaPrinter),
+	}
+}
+
+// instancePath renders a JSON pointer into doc as "$.key[0].other". doc is
+// walked alongside so numeric object keys are not mistaken for indexes.
+func instancePath(tokens []string, doc any) string {
+	var b strings.Builder
+	b.WriteString("$")
+	cur := doc
+	for _, tok := range tokens {
+		switch v := cur.(type) {
+		case []any:
+			i, _ := strconv.Atoi(tok)
+			fmt.Fprintf(&b, "[%d]", i)
+			if i >= 0 && i < len(v) {
+				cur = v[i]
+			} else {
+				cur = nil
+			}
+		case map[string]any:
+			b.WriteString("." + tok)
+			cur = v[tok]
+		default:
+			b.WriteString("." + tok)
+			cur = nil
+		}
+	}
+	return b.String()
+}
+
+// ecmaRegexp adapts regexp2 in ECMAScript mode to jsonschema.Regexp.
+type ecmaRegexp struct {
+	*regexp2.Regexp
+}
+
+func (r ecmaRegexp) MatchString(s string) bool {
+	ok, err := r.Regexp.MatchString(s)
+	return err == nil && ok
+}
+
+func ecmaRegexpCompile(expr string) (jsonschema.Regexp, error) {
+	re, err := regexp2.Compile(expr, regexp2.ECMAScript)
+	if err != nil {
+		return nil, err
+	}
+	re.MatchTimeout = schemaPatternTimeout
+	return ecmaRegexp{re}, nil
+}
@@ -0,0 +1,240 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateSchemaFile(t *testing.T) {
+	t.Parallel()
+
+	schema := `{
+  "type": "object",
+  "required": ["name", "port"],
+  "additionalProperties": false,
+  "properties": {
+    "name": {"type": "string", "minLength": 1},
+    "port": {"type": "integer", "minimum": 1, "maximum": 65535},
+    "mode": {"enum": ["fast", "safe"]},
+    "owner": {"type": "string", "format": "email"},
+    "user": {"type": "string", "pattern": "^(?!admin$)[a-z]+$"},
+    "ratio": {"type": "number", "multipleOf": 0.25},
+    "labels": {"type": "object", "minProperties": 1, "patternProperties": {"^x-": {"type": "string"}}},
+    "tls": {"type": "object", "dependentRequired": {"cert": ["key"]}},
+    "servers": {"type": "array", "items": {"$ref": "#/$defs/server"}}
+  },
+  "if": {"properties": {"mode": {"const": "safe"}}, "required": ["mode"]},
+  "then": {"required": ["owner"]},
+  "$defs": {
+    "server": {"type": "object", "required": ["host"], "properties": {"host": {"type": "string", "pattern": "^[a-z.]+$"}}}
+  }
+}`
+
+	tests := []struct {
+		name     string
+		data     string
+		wantPath string
+		wantMsg  string
+	}{
+		{
+			name: "valid document",
+			data: `{"name": "api", "port": 8080, "mode": "safe", "owner": "ops@example.com", "user": "deploy", "ratio": 0.75, "labels": {"x-team": "core"}, "tls": {"cert": "c", "key": "k"}, "servers": [{"host": "a.example"}]}`,
+		},
+		{
+			name:     "wrong type",
+			data:     `{"name": "api", "port": "8080"}`,
+			wantPath: "$.port",
+			wantMsg:  "got string, want integer",
+		},
+		{
+			name:     "fractional integer",
+			data:     `{"name": "api", "port": 80.5}`,
+			wantPath: "$.port",
+			wantMsg:  "got number, want integer",
+		},
+		{
+			name:     "missing required key",
+			data:     `{"name": "api"}`,
+			wantPath: "$",
+			wantMsg:  `missing property 'port'`,
+		},
+		{
+			name:     "unknown key",
+			data:     `{"name": "api", "port": 80, "debug": true}`,
+			wantPath: "$",
+			wantMsg:  `additional properties 'debug' not allowed`,
+		},
+		{
+			name:     "out of range",
+			data:     `{"name": "api", "port": 70000}`,
+			wantPath: "$.port",
+			wantMsg:  "maximum: got 70,000, want 65,535",
+		},
+		{
+			name:     "not in enum",
+			data:     `{"name": "api", "port": 80, "mode": "turbo"}`,
+			wantPath: "$.mode",
+			wantMsg:  "value must be one of",
+		},
+		{
+			name:     "format is asserted",
+			data:     `{"name": "api", "port": 80, "owner": "not-an-email"}`,
+			wantPath: "$.owner",
+			wantMsg:  "is not valid email",
+		},
+		{
+			name:     "pattern uses ECMA-262 syntax",
+			data:     `{"name": "api", "port": 80, "user": "admin"}`,
+			wantPath: "$.user",
+			wantMsg:  "does not match pattern",
+		},
+		{
+			name:     "multipleOf",
+			data:     `{"name": "api", "port": 80, "ratio": 0.3}`,
+			wantPath: "$.ratio",
+			wantMsg:  "multipleOf: got 0.3, want 0.25",
+		},
+		{
+			name:     "minProperties",
+			data:     `{"name": "api", "port": 80, "labels": {}}`,
+			wantPath: "$.labels",
+			wantMsg:  "minProperties",
+		},
+		{
+			name:     "patternProperties",
+			data:     `{"name": "api", "port": 80, "labels": {"x-team": 1}}`,
+			wantPath: "$.labels.x-team",
+			wantMsg:  "got number, want string",
+		},
+		{
+			name:     "dependentRequired",
+			data:     `{"name": "api", "port": 80, "tls": {"cert": "c"}}`,
+			wantPath: "$.tls",
+			wantMsg:  "key",
+		},
+		{
+			name:     "if/then",
+			data:     `{"name": "api", "port": 80, "mode": "safe"}`,
+			wantPath: "$",
+			wantMsg:  "missing property 'owner'",
+		},
+		{
+			name:     "nested violation through $ref",
+			data:     `{"name": "api", "port": 80, "servers": [{"host": "a.example"}, {"host": "B"}]}`,
+			wantPath: "$.servers[1].host",
+			wantMsg:  "does not match pattern",
+		},
+	}
+
+	dir := t.TempDir()
+	schemaPath := filepath.Join(dir, "schema.json")
+	if err := os.WriteFile(schemaPath, []byte(schema), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := ValidateSchemaFile([]byte(tt.data), schemaPath)
+			if tt.wantPath == "" {
+				if err != nil {
+					t.Errorf("ValidateSchemaFile() error = %v", err)
+				}
+				return
+			}
+			var schemaErr *SchemaError
+			if !errors.As(err, &schemaErr) {
+				t.Fatalf("ValidateSchemaFile() error = %v, want *SchemaError", err)
+			}
+			if schemaErr.Path != tt.wantPath {
+				t.Errorf("Path = %q, want %q", schemaErr.Path, tt.wantPath)
+			}
+			if !strings.Contains(schemaErr.Message, tt.wantMsg) {
+				t.Errorf("Message = %q, want it to contain %q", schemaErr.Message, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestValidateSchemaFileErrors(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	badSchema := filepath.Join(dir, "bad.json")
+	if err := os.WriteFile(badSchema, []byte(`{"type": `), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	invalidSchema := filepath.Join(dir, "invalid.json")
+	if err := os.WriteFile(invalidSchema, []byte(`{"type": 5}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		schemaPath string
+		wantErr    string
+	}{
+		{name: "missing schema file", schemaPath: filepath.Join(dir, "missing.json"), wantErr: "failed to read schema file"},
+		{name: "schema is not JSON", schemaPath: badSchema, wantErr: "invalid schema file"},
+		{name: "schema is not a valid schema", schemaPath: invalidSchema, wantErr: "invalid schema file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := ValidateSchemaFile([]byte(`{}`), tt.schemaPath)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateSchemaFile() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateSchema(t *testing.T) {
+	t.Parallel()
+
+	schemaPath := filepath.Join(t.TempDir(), "schema.json")
+	if err := os.WriteFile(schemaPath, []byte(`{"type": "object", "properties": {"port": {"type": "integer"}}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		data        string
+		contentType string
+		schemaPath  string
+		wantSchema  bool
+		wantErr     string
+	}{
+		{name: "no schema file", data: "anything", contentType: ContentTypeText},
+		{name: "matching JSON", data: `{"port": 80}`, contentType: ContentTypeJSON, schemaPath: schemaPath},
+		{name: "matching YAML", data: "port: 80\n", contentType: ContentTypeYAML, schemaPath: schemaPath},
+		{name: "YAML violating the schema", data: "port: \"80\"\n", contentType: ContentTypeYAML, schemaPath: schemaPath, wantSchema: true},
+		{name: "text cannot be checked", data: "port=80", contentType: ContentTypeText, schemaPath: schemaPath, wantErr: "schema_file only applies to JSON and YAML data, not text/plain"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := ValidateSchema([]byte(tt.data), tt.contentType, tt.schemaPath)
+			var schemaErr *SchemaError
+			if got := errors.As(err, &schemaErr); got != tt.wantSchema {
+				t.Fatalf("ValidateSchema() error = %v, want *SchemaError %v", err, tt.wantSchema)
+			}
+			if tt.wantSchema {
+				return
+			}
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateSchema() error = %v", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateSchema() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
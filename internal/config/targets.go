@@ -0,0 +1,87 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/goccy/go-yaml"
+)
+
+// TargetEntry is a single row of a --profiles-from-file targets file. It
+// names one AppConfig profile/environment pair without requiring a full
+// apcdeploy.yml to be checked in for it.
+type TargetEntry struct {
+	Application string `yaml:"application"`
+	Profile     string `yaml:"profile"`
+	Environment string `yaml:"environment"`
+	Region      string `yaml:"region,omitempty"`
+	// DataFile is optional. Read-only commands that compare against local
+	// content (diff) require it; status ignores it.
+	DataFile string `yaml:"data_file,omitempty"`
+}
+
+// LoadTargetsFile reads a YAML list of TargetEntry rows and converts each
+// one into a Config so executors can reuse their single-config code path.
+// Relative data_file paths are resolved against the targets file's
+// directory, mirroring LoadConfig.
+func LoadTargetsFile(path string) ([]*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read targets file: %w", err)
+	}
+
+	var entries []TargetEntry
+	if err := yaml.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse targets file: %w", err)
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("targets file %s contains no entries", path)
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve targets file path: %w", err)
+	}
+
+	configs := make([]*Config, 0, len(entries))
+	seen := make(map[string]int, len(entries))
+	for i, entry := range entries {
+		if err := entry.validate(); err != nil {
+			return nil, fmt.Errorf("invalid target #%d: %w", i+1, err)
+		}
+		cfg := &Config{
+			Application:          entry.Application,
+			ConfigurationProfile: entry.Profile,
+			Environment:          entry.Environment,
+			Region:               entry.Region,
+		}
+		if entry.DataFile != "" {
+			cfg.DataFile = resolveDataFilePath(absPath, entry.DataFile)
+		}
+		cfg.setDefaults()
+
+		id := Identifier("", cfg)
+		if prev, ok := seen[id]; ok {
+			return nil, fmt.Errorf("duplicate target #%d: same as target #%d (%s)", i+1, prev, id)
+		}
+		seen[id] = i + 1
+		configs = append(configs, cfg)
+	}
+
+	return configs, nil
+}
+
+// validate checks that the entry names a complete target
+func (e *TargetEntry) validate() error {
+	if e.Application == "" {
+		return fmt.Errorf("application is required")
+	}
+	if e.Profile == "" {
+		return fmt.Errorf("profile is required")
+	}
+	if e.Environment == "" {
+		return fmt.Errorf("environment is required")
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadTargetsFile(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		content   string
+		wantErr   string
+		wantCount int
+	}{
+		{
+			name: "valid targets",
+			content: `- application: app
+  profile: flags
+  environment: prod
+  region: us-east-1
+- application: app
+  profile: flags
+  environment: staging
+  data_file: data/staging.json
+`,
+			wantCount: 2,
+		},
+		{
+			name:    "empty list",
+			content: "[]\n",
+			wantErr: "contains no entries",
+		},
+		{
+			name: "missing profile",
+			content: `- application: app
+  environment: prod
+`,
+			wantErr: "invalid target #1: profile is required",
+		},
+		{
+			name: "duplicate target",
+			content: `- application: app
+  profile: flags
+  environment: prod
+- application: app
+  profile: flags
+  environment: prod
+`,
+			wantErr: "duplicate target #2",
+		},
+		{
+			name:    "malformed YAML",
+			content: "- application: [unclosed\n",
+			wantErr: "failed to parse targets file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			path := filepath.Join(dir, "targets.yml")
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatalf("failed to write targets file: %v", err)
+			}
+
+			cfgs, err := LoadTargetsFile(path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(cfgs) != tt.wantCount {
+				t.Fatalf("expected %d targets, got %d", tt.wantCount, len(cfgs))
+			}
+		})
+	}
+}
+
+func TestLoadTargetsFileValues(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "targets.yml")
+	content := `- application: app
+  profile: flags
+  environment: staging
+  region: ap-northeast-1
+  data_file: data/staging.json
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write targets file: %v", err)
+	}
+
+	cfgs, err := LoadTargetsFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cfg := cfgs[0]
+	if cfg.Application != "app" || cfg.ConfigurationProfile != "flags" || cfg.Environment != "staging" || cfg.Region != "ap-northeast-1" {
+		t.Errorf("unexpected config: %+v", cfg)
+	}
+	if want := filepath.Join(dir, "data", "staging.json"); cfg.DataFile != want {
+		t.Errorf("DataFile = %q, want %q", cfg.DataFile, want)
+	}
+	if cfg.DeploymentStrategy != DefaultDeploymentStrategy {
+		t.Errorf("DeploymentStrategy = %q, want default", cfg.DeploymentStrategy)
+	}
+}
+
+func TestLoadTargetsFileNotFound(t *testing.T) {
+	t.Parallel()
+
+	_, err := LoadTargetsFile(filepath.Join(t.TempDir(), "missing.yml"))
+	if err == nil || !strings.Contains(err.Error(), "failed to read targets file") {
+		t.Fatalf("expected read error, got %v", err)
+	}
+}
@@ -1,15 +1,56 @@
 package config
 
-import "fmt"
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
 
-// Config represents the apcdeploy.yml configuration file
+// Config represents the apcdeploy.yml configuration file. The same keys are
+// used when the file is written as JSON (e.g. apcdeploy.json).
 type Config struct {
-	Application          string `yaml:"application"`
-	ConfigurationProfile string `yaml:"configuration_profile"`
-	Environment          string `yaml:"environment"`
-	DeploymentStrategy   string `yaml:"deployment_strategy"`
-	DataFile             string `yaml:"data_file"`
-	Region               string `yaml:"region,omitempty"`
+	Application          string `yaml:"application" json:"application"`
+	ConfigurationProfile string `yaml:"configuration_profile" json:"configuration_profile"`
+	Environment          string `yaml:"environment" json:"environment"`
+	DeploymentStrategy   string `yaml:"deployment_strategy" json:"deployment_strategy"`
+	DataFile             string `yaml:"data_file" json:"data_file"`
+	Region               string `yaml:"region,omitempty" json:"region,omitempty"`
+	// ContentType overrides the content type derived from the data_file
+	// extension. It is mainly needed when the payload does not come from
+	// data_file (e.g. --data-base64-env).
+	ContentType string `yaml:"content_type,omitempty" json:"content_type,omitempty"`
+	// SchemaFile is an optional JSON Schema that JSON data is validated
+	// against before upload. Like DataFile, a relative path is resolved
+	// against the config file's directory.
+	SchemaFile string `yaml:"schema_file,omitempty" json:"schema_file,omitempty"`
+	// TextNormalize adjusts the normalizations applied to text content when
+	// comparing it with the deployed version (keep_trailing_ws,
+	// collapse_blank_lines). Trailing whitespace is ignored by default.
+	TextNormalize []string `yaml:"text_normalize,omitempty" json:"text_normalize,omitempty"`
+	// RedactFields lists dotted paths (e.g. database.password, users.*.key)
+	// whose values are masked in printed diffs, on top of the built-in
+	// password/secret/token key heuristics. Uploaded content is unaffected.
+	RedactFields []string `yaml:"redact_fields,omitempty" json:"redact_fields,omitempty"`
+	// AccountID, when set, makes run refuse to deploy unless the caller's
+	// credentials belong to this 12-digit AWS account.
+	AccountID string `yaml:"account_id,omitempty" json:"account_id,omitempty"`
+	// MaxVersionAge (e.g. "90d") makes status warn when the deployed
+	// version completed longer ago than this (see ParseAge).
+	MaxVersionAge string `yaml:"max_version_age,omitempty" json:"max_version_age,omitempty"`
+	// Timeout is the deployment wait timeout in seconds used by run, patch,
+	// rollback and edit --config-glob when --timeout is not given (see
+	// EffectiveTimeout).
+	Timeout int `yaml:"timeout,omitempty" json:"timeout,omitempty"`
+}
+
+// RequireDataFilePath returns an error when data_file is "-" (stdin), which
+// only run accepts. command names the caller in the message.
+func (c *Config) RequireDataFilePath(command string) error {
+	if c.DataFile == StdinDataFile {
+		return fmt.Errorf(`data_file "-" (stdin) is only supported by run; %s needs a file path`, command)
+	}
+	return nil
 }
 
 // validate checks if the configuration is valid
@@ -26,9 +67,88 @@ func (c *Config) validate() error {
 	if c.DataFile == "" {
 		return fmt.Errorf("data_file is required")
 	}
+	if c.ContentType != "" && !slices.Contains(SupportedContentTypes, c.ContentType) {
+		return fmt.Errorf("unsupported content_type: %s (must be %s, %s, %s, or %s)", c.ContentType, ContentTypeJSON, ContentTypeYAML, ContentTypeTOML, ContentTypeText)
+	}
+	for _, name := range c.TextNormalize {
+		switch name {
+		case TextNormalizeTrimTrailingWS, TextNormalizeKeepTrailingWS, TextNormalizeCollapseBlankLines:
+		default:
+			return fmt.Errorf("unsupported text_normalize option: %s (must be %s, %s or %s)", name, TextNormalizeKeepTrailingWS, TextNormalizeTrimTrailingWS, TextNormalizeCollapseBlankLines)
+		}
+	}
+	if slices.Contains(c.TextNormalize, TextNormalizeTrimTrailingWS) && slices.Contains(c.TextNormalize, TextNormalizeKeepTrailingWS) {
+		return fmt.Errorf("text_normalize cannot list both %s and %s", TextNormalizeTrimTrailingWS, TextNormalizeKeepTrailingWS)
+	}
+	for _, field := range c.RedactFields {
+		if field == "" || strings.HasPrefix(field, ".") || strings.HasSuffix(field, ".") || strings.Contains(field, "..") {
+			return fmt.Errorf("invalid redact_fields entry: %q", field)
+		}
+	}
+	if c.AccountID != "" && !isAccountID(c.AccountID) {
+		return fmt.Errorf("invalid account_id: %q (must be a 12-digit AWS account ID)", c.AccountID)
+	}
+	if c.Timeout < 0 {
+		return fmt.Errorf("timeout must be a non-negative value")
+	}
+	if c.MaxVersionAge != "" {
+		if _, err := ParseAge(c.MaxVersionAge); err != nil {
+			return fmt.Errorf("invalid max_version_age: %w", err)
+		}
+	}
 	return nil
 }
 
+// isAccountID reports whether s looks like an AWS account ID (12 digits).
+func isAccountID(s string) bool {
+	if len(s) != 12 {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// ApplyRegionOverride applies the global --region flag, resolving Region
+// with ResolveRegion. When Region is still empty after this call, the SDK
+// falls back to the shared config profile's region.
+func (c *Config) ApplyRegionOverride(region string) {
+	c.Region = ResolveRegion(region, c.Region)
+}
+
+// ResolveRegion returns the region to use. The precedence is the --region
+// flag > region in the config file > AWS_REGION > AWS_DEFAULT_REGION; it
+// returns "" when none is set.
+func ResolveRegion(flag, configured string) string {
+	return resolveRegion(flag, configured, os.Getenv)
+}
+
+func resolveRegion(flag, configured string, getenv func(string) string) string {
+	for _, region := range []string{flag, configured, getenv("AWS_REGION"), getenv("AWS_DEFAULT_REGION")} {
+		if region = strings.TrimSpace(region); region != "" {
+			return region
+		}
+	}
+	return ""
+}
+
+// EffectiveTimeout returns the deployment wait timeout in seconds. The
+// precedence is flag > timeout in the config file > DefaultDeploymentTimeout;
+// a zero flag means --timeout was not given.
+func (c *Config) EffectiveTimeout(flag int) int {
+	switch {
+	case flag > 0:
+		return flag
+	case c.Timeout > 0:
+		return c.Timeout
+	default:
+		return DefaultDeploymentTimeout
+	}
+}
+
 // setDefaults sets default values for optional fields
 func (c *Config) setDefaults() {
 	if c.DeploymentStrategy == "" {
@@ -21,6 +21,72 @@ func TestConfigValidation(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "valid redact_fields",
+			config: Config{
+				Application:          "MyApp",
+				ConfigurationProfile: "MyProfile",
+				Environment:          "Production",
+				DataFile:             "data.json",
+				RedactFields:         []string{"database.password", "users.*.key"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "malformed redact_fields path",
+			config: Config{
+				Application:          "MyApp",
+				ConfigurationProfile: "MyProfile",
+				Environment:          "Production",
+				DataFile:             "data.json",
+				RedactFields:         []string{"database..password"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid account_id",
+			config: Config{
+				Application:          "MyApp",
+				ConfigurationProfile: "MyProfile",
+				Environment:          "Production",
+				DataFile:             "data.json",
+				AccountID:            "012345678901",
+			},
+			wantErr: false,
+		},
+		{
+			name: "malformed account_id",
+			config: Config{
+				Application:          "MyApp",
+				ConfigurationProfile: "MyProfile",
+				Environment:          "Production",
+				DataFile:             "data.json",
+				AccountID:            "1234-5678-9012",
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative timeout",
+			config: Config{
+				Application:          "MyApp",
+				ConfigurationProfile: "MyProfile",
+				Environment:          "Production",
+				DataFile:             "data.json",
+				Timeout:              -1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid max_version_age",
+			config: Config{
+				Application:          "MyApp",
+				ConfigurationProfile: "MyProfile",
+				Environment:          "Production",
+				DataFile:             "data.json",
+				MaxVersionAge:        "ninety days",
+			},
+			wantErr: true,
+		},
 		{
 			name: "missing application",
 			config: Config{
@@ -61,6 +127,61 @@ func TestConfigValidation(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid content type",
+			config: Config{
+				Application:          "MyApp",
+				ConfigurationProfile: "MyProfile",
+				Environment:          "Production",
+				DataFile:             "data.json",
+				ContentType:          ContentTypeYAML,
+			},
+			wantErr: false,
+		},
+		{
+			name: "unsupported content type",
+			config: Config{
+				Application:          "MyApp",
+				ConfigurationProfile: "MyProfile",
+				Environment:          "Production",
+				DataFile:             "data.json",
+				ContentType:          "application/xml",
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid text normalize options",
+			config: Config{
+				Application:          "MyApp",
+				ConfigurationProfile: "MyProfile",
+				Environment:          "Production",
+				DataFile:             "data.txt",
+				TextNormalize:        []string{TextNormalizeTrimTrailingWS, TextNormalizeCollapseBlankLines},
+			},
+			wantErr: false,
+		},
+		{
+			name: "trim and keep trailing whitespace together",
+			config: Config{
+				Application:          "MyApp",
+				ConfigurationProfile: "MyProfile",
+				Environment:          "Production",
+				DataFile:             "data.txt",
+				TextNormalize:        []string{TextNormalizeTrimTrailingWS, TextNormalizeKeepTrailingWS},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unsupported text normalize option",
+			config: Config{
+				Application:          "MyApp",
+				ConfigurationProfile: "MyProfile",
+				Environment:          "Production",
+				DataFile:             "data.txt",
+				TextNormalize:        []string{"lowercase"},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -159,3 +280,96 @@ func TestConfigFields(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigEffectiveTimeout(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		flag    int
+		timeout int
+		want    int
+	}{
+		{name: "flag wins", flag: 60, timeout: 3600, want: 60},
+		{name: "config file without flag", timeout: 3600, want: 3600},
+		{name: "built-in default", want: DefaultDeploymentTimeout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			c := &Config{Timeout: tt.timeout}
+			if got := c.EffectiveTimeout(tt.flag); got != tt.want {
+				t.Errorf("EffectiveTimeout(%d) = %d, want %d", tt.flag, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveRegion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		flag       string
+		configured string
+		env        map[string]string
+		want       string
+	}{
+		{
+			name:       "flag wins",
+			flag:       "eu-west-1",
+			configured: "us-east-1",
+			env:        map[string]string{"AWS_REGION": "us-west-2", "AWS_DEFAULT_REGION": "ap-northeast-1"},
+			want:       "eu-west-1",
+		},
+		{
+			name:       "config file without flag",
+			configured: "us-east-1",
+			env:        map[string]string{"AWS_REGION": "us-west-2", "AWS_DEFAULT_REGION": "ap-northeast-1"},
+			want:       "us-east-1",
+		},
+		{
+			name: "AWS_REGION",
+			env:  map[string]string{"AWS_REGION": "us-west-2", "AWS_DEFAULT_REGION": "ap-northeast-1"},
+			want: "us-west-2",
+		},
+		{
+			name: "AWS_DEFAULT_REGION",
+			env:  map[string]string{"AWS_DEFAULT_REGION": "ap-northeast-1"},
+			want: "ap-northeast-1",
+		},
+		{
+			name: "blank values are skipped",
+			flag: " ",
+			env:  map[string]string{"AWS_REGION": "", "AWS_DEFAULT_REGION": "ap-northeast-1"},
+			want: "ap-northeast-1",
+		},
+		{
+			name: "nothing set",
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			getenv := func(key string) string { return tt.env[key] }
+			if got := resolveRegion(tt.flag, tt.configured, getenv); got != tt.want {
+				t.Errorf("resolveRegion(%q, %q) = %q, want %q", tt.flag, tt.configured, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfigRequireDataFilePath(t *testing.T) {
+	t.Parallel()
+
+	if err := (&Config{DataFile: "data.json"}).RequireDataFilePath("pull"); err != nil {
+		t.Errorf("RequireDataFilePath() error = %v for a file path", err)
+	}
+	err := (&Config{DataFile: StdinDataFile}).RequireDataFilePath("pull")
+	if err == nil || err.Error() != `data_file "-" (stdin) is only supported by run; pull needs a file path` {
+		t.Errorf("RequireDataFilePath() error = %v for stdin", err)
+	}
+}
@@ -1,21 +1,48 @@
 package config
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/goccy/go-yaml"
+	"github.com/pelletier/go-toml/v2"
 )
 
+const (
+	// excerptContextLines is the number of lines shown before and after the
+	// failing line in a syntax error excerpt.
+	excerptContextLines = 1
+	// excerptMaxLineLength caps each excerpt line so a minified payload does
+	// not dump the whole file into the error.
+	excerptMaxLineLength = 80
+)
+
+// CheckDataSize rejects data larger than the MaxConfigSize hosted
+// configuration limit, with the exact size and limit in bytes. It reports
+// whether data is at or above ConfigSizeWarningThreshold so callers can warn
+// before the limit is reached.
+func CheckDataSize(data []byte) (nearLimit bool, err error) {
+	if len(data) > MaxConfigSize {
+		return false, fmt.Errorf("configuration is %d bytes, limit is %d", len(data), MaxConfigSize)
+	}
+	return len(data) >= ConfigSizeWarningThreshold, nil
+}
+
 // ValidateData validates configuration data against the AppConfig size limit
 // and the syntax rules for the given content type.
 //
 // Supported content types:
 //   - ContentTypeJSON: rejects invalid JSON
 //   - ContentTypeYAML: rejects invalid YAML
+//   - ContentTypeTOML: rejects invalid TOML
 //   - ContentTypeText: no syntax check
 //
-// Any other content type returns an error.
+// Any other content type returns an error. Syntax errors are *SyntaxError
+// values carrying the line and column of the failure plus a short excerpt of
+// the surrounding lines.
 func ValidateData(data []byte, contentType string) error {
 	if len(data) > MaxConfigSize {
 		return fmt.Errorf("configuration data size %d bytes exceeds maximum allowed size of %d bytes (2MB)", len(data), MaxConfigSize)
@@ -25,12 +52,17 @@ func ValidateData(data []byte, contentType string) error {
 	case ContentTypeJSON:
 		var js any
 		if err := json.Unmarshal(data, &js); err != nil {
-			return fmt.Errorf("invalid JSON syntax: %w", err)
+			return jsonSyntaxError(data, err)
 		}
 	case ContentTypeYAML:
 		var ym any
 		if err := yaml.Unmarshal(data, &ym); err != nil {
-			return fmt.Errorf("invalid YAML syntax: %w", err)
+			return yamlSyntaxError(data, err)
+		}
+	case ContentTypeTOML:
+		var tm map[string]any
+		if err := toml.Unmarshal(data, &tm); err != nil {
+			return tomlSyntaxError(data, err)
 		}
 	case ContentTypeText:
 		// no syntax check
@@ -40,3 +72,136 @@ func ValidateData(data []byte, contentType string) error {
 
 	return nil
 }
+
+// SyntaxError is a JSON, YAML or TOML syntax error with the position where
+// the decoder stopped. Its message carries an excerpt of the surrounding
+// lines.
+type SyntaxError struct {
+	// Format is the syntax that failed ("JSON", "YAML" or "TOML")
+	Format string
+	// Line and Column are 1-based
+	Line   int
+	Column int
+	// Message is the decoder's description of the problem
+	Message string
+	// Excerpt shows the lines around Line with a caret under Column
+	Excerpt string
+	// Err is the underlying decoder error
+	Err error
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("invalid %s syntax at line %d, column %d: %s\n%s", e.Format, e.Line, e.Column, e.Message, e.Excerpt)
+}
+
+func (e *SyntaxError) Unwrap() error {
+	return e.Err
+}
+
+// newSyntaxError builds a *SyntaxError for data, rendering the excerpt.
+func newSyntaxError(format string, data []byte, line, column int, message string, err error) *SyntaxError {
+	return &SyntaxError{
+		Format:  format,
+		Line:    line,
+		Column:  column,
+		Message: message,
+		Excerpt: excerpt(data, line, column),
+		Err:     err,
+	}
+}
+
+// jsonSyntaxError wraps a JSON decode error in a *SyntaxError when the
+// decoder reports a byte offset.
+func jsonSyntaxError(data []byte, err error) error {
+	var synErr *json.SyntaxError
+	if !errors.As(err, &synErr) {
+		return fmt.Errorf("invalid JSON syntax: %w", err)
+	}
+	// Offset counts the bytes read before the error, so the offending byte
+	// is the one just before it.
+	pos := min(max(int(synErr.Offset)-1, 0), len(data))
+	line := bytes.Count(data[:pos], []byte("\n")) + 1
+	column := pos - bytes.LastIndexByte(data[:pos], '\n')
+	return newSyntaxError("JSON", data, line, column, err.Error(), err)
+}
+
+// yamlSyntaxError wraps a YAML decode error in a *SyntaxError when the
+// decoder reports a token.
+func yamlSyntaxError(data []byte, err error) error {
+	var yamlErr yaml.Error
+	if !errors.As(err, &yamlErr) || yamlErr.GetToken() == nil || yamlErr.GetToken().Position == nil {
+		return fmt.Errorf("invalid YAML syntax: %w", err)
+	}
+	p := yamlErr.GetToken().Position
+	return newSyntaxError("YAML", data, p.Line, p.Column, yamlErr.GetMessage(), err)
+}
+
+// tomlSyntaxError wraps a TOML decode error in a *SyntaxError when the
+// decoder reports a position.
+func tomlSyntaxError(data []byte, err error) error {
+	var decErr *toml.DecodeError
+	if !errors.As(err, &decErr) {
+		return fmt.Errorf("invalid TOML syntax: %w", err)
+	}
+	line, column := decErr.Position()
+	return newSyntaxError("TOML", data, line, column, err.Error(), err)
+}
+
+// excerpt renders the lines around line (1-based) with line numbers and a
+// caret under column. Long lines are cut to a window of
+// excerptMaxLineLength bytes centered on column, so the offending position
+// of a minified payload stays visible; "..." marks the cut ends.
+func excerpt(data []byte, line, column int) string {
+	lines := strings.Split(string(data), "\n")
+	if line < 1 || line > len(lines) {
+		return ""
+	}
+	first := max(line-excerptContextLines, 1)
+	last := min(line+excerptContextLines, len(lines))
+	width := len(fmt.Sprint(last))
+
+	// Every line shares the window so the context stays aligned with the
+	// failing line.
+	start := 0
+	if errLine := lines[line-1]; len(errLine) > excerptMaxLineLength {
+		start = min(max(column-1-excerptMaxLineLength/2, 0), len(errLine)-excerptMaxLineLength)
+	}
+
+	var b strings.Builder
+	for n := first; n <= last; n++ {
+		text := excerptWindow(lines[n-1], start)
+		marker := " "
+		if n == line {
+			marker = ">"
+		}
+		fmt.Fprintf(&b, "%s %*d | %s\n", marker, width, n, text)
+		if n == line && column > start && column <= start+excerptMaxLineLength+1 {
+			offset := column - 1 - start
+			if start > 0 {
+				offset += len("...")
+			}
+			fmt.Fprintf(&b, "  %s | %s^\n", strings.Repeat(" ", width), strings.Repeat(" ", offset))
+		}
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// excerptWindow returns the excerptMaxLineLength bytes of text from start,
+// with "..." where text continues beyond either end.
+func excerptWindow(text string, start int) string {
+	if start == 0 && len(text) <= excerptMaxLineLength {
+		return text
+	}
+	end := min(start+excerptMaxLineLength, len(text))
+	window := ""
+	if start < end {
+		window = strings.ToValidUTF8(text[start:end], "")
+	}
+	if start > 0 {
+		window = "..." + window
+	}
+	if end < len(text) {
+		window += "..."
+	}
+	return window
+}
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"errors"
 	"strings"
 	"testing"
 )
@@ -18,6 +19,8 @@ func TestValidateData(t *testing.T) {
 		{name: "invalid json", data: []byte(`{`), contentType: ContentTypeJSON, wantErr: "invalid JSON syntax"},
 		{name: "valid yaml", data: []byte("a: 1"), contentType: ContentTypeYAML},
 		{name: "invalid yaml", data: []byte("a: :\n  b"), contentType: ContentTypeYAML, wantErr: "invalid YAML syntax"},
+		{name: "valid toml", data: []byte("a = 1\n[b]\nc = 'x'\n"), contentType: ContentTypeTOML},
+		{name: "invalid toml", data: []byte("a = "), contentType: ContentTypeTOML, wantErr: "invalid TOML syntax"},
 		{name: "text ok", data: []byte("hello"), contentType: ContentTypeText},
 		{name: "unsupported type", data: []byte("x"), contentType: "application/xml", wantErr: "unsupported content type"},
 		{
@@ -44,3 +47,192 @@ func TestValidateData(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateDataSyntaxErrorLocation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		data        string
+		contentType string
+		wantParts   []string
+	}{
+		{
+			name:        "json missing comma",
+			data:        "{\n  \"a\": 1\n  \"b\": 2\n}\n",
+			contentType: ContentTypeJSON,
+			wantParts:   []string{"invalid JSON syntax at line 3, column 3", "> 3 |   \"b\": 2", "  2 |   \"a\": 1", "  4 | }"},
+		},
+		{
+			name:        "json truncated",
+			data:        "{",
+			contentType: ContentTypeJSON,
+			wantParts:   []string{"invalid JSON syntax at line 1, column 1", "> 1 | {"},
+		},
+		{
+			name:        "yaml invalid key",
+			data:        "a: 1\nb: :\n  c\nd: 3\n",
+			contentType: ContentTypeYAML,
+			wantParts:   []string{"invalid YAML syntax at line 2, column 4", "> 2 | b: :", "  1 | a: 1"},
+		},
+		{
+			name:        "toml missing value",
+			data:        "a = 1\nb = \nc = 3\n",
+			contentType: ContentTypeTOML,
+			wantParts:   []string{"invalid TOML syntax at line 2", "> 2 | b = ", "  1 | a = 1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := ValidateData([]byte(tt.data), tt.contentType)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			for _, part := range tt.wantParts {
+				if !strings.Contains(err.Error(), part) {
+					t.Errorf("expected error to contain %q, got:\n%s", part, err)
+				}
+			}
+		})
+	}
+}
+
+func TestSyntaxErrorPosition(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		parse      func() error
+		wantFormat string
+		wantLine   int
+		wantColumn int
+	}{
+		{
+			name:       "ValidateData JSON",
+			parse:      func() error { return ValidateData([]byte("{\n  \"a\": 1\n  \"b\": 2\n}\n"), ContentTypeJSON) },
+			wantFormat: "JSON",
+			wantLine:   3,
+			wantColumn: 3,
+		},
+		{
+			name: "NormalizeJSON",
+			parse: func() error {
+				_, err := NormalizeJSON("{\n  \"a\": 1,\n}", "")
+				return err
+			},
+			wantFormat: "JSON",
+			wantLine:   3,
+			wantColumn: 1,
+		},
+		{
+			name: "NormalizeYAML",
+			parse: func() error {
+				_, err := NormalizeYAML("a: 1\nb: :\n  c\nd: 3\n")
+				return err
+			},
+			wantFormat: "YAML",
+			wantLine:   2,
+			wantColumn: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var synErr *SyntaxError
+			if err := tt.parse(); !errors.As(err, &synErr) {
+				t.Fatalf("error = %v, want *SyntaxError", err)
+			}
+			if synErr.Format != tt.wantFormat || synErr.Line != tt.wantLine || synErr.Column != tt.wantColumn {
+				t.Errorf("got %s at %d:%d, want %s at %d:%d", synErr.Format, synErr.Line, synErr.Column, tt.wantFormat, tt.wantLine, tt.wantColumn)
+			}
+		})
+	}
+}
+
+func TestExcerptTruncatesLongLines(t *testing.T) {
+	t.Parallel()
+
+	long := strings.Repeat("x", 500)
+	got := excerpt([]byte(long), 1, 300)
+	if strings.Contains(got, strings.Repeat("x", excerptMaxLineLength+1)) {
+		t.Errorf("excerpt was not truncated: %q", got)
+	}
+	if !strings.Contains(got, "| ..."+strings.Repeat("x", excerptMaxLineLength)+"...") {
+		t.Errorf("expected truncation markers on both ends, got %q", got)
+	}
+}
+
+func TestExcerptCentersOnErrorColumn(t *testing.T) {
+	t.Parallel()
+
+	// A minified document with the error far past the first 80 bytes
+	data := []byte(`{"padding": "` + strings.Repeat("a", 200) + `", "bad": ?, "tail": "` + strings.Repeat("b", 200) + `"}`)
+	column := strings.Index(string(data), "?") + 1
+	got := excerpt(data, 1, column)
+
+	lines := strings.Split(got, "\n")
+	if len(lines) != 2 {
+		t.Fatalf("excerpt = %q, want the line and a caret line", got)
+	}
+	caret := strings.Index(lines[1], "^")
+	if caret < 0 || caret >= len(lines[0]) || lines[0][caret] != '?' {
+		t.Errorf("caret does not point at the error:\n%s", got)
+	}
+	if !strings.Contains(lines[0], "| ...") || !strings.HasSuffix(lines[0], "...") {
+		t.Errorf("expected truncation markers on both ends, got %q", lines[0])
+	}
+}
+
+func TestExcerptErrorNearLineEnd(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(strings.Repeat("a", 200) + "?")
+	got := excerpt(data, 1, len(data))
+	lines := strings.Split(got, "\n")
+	caret := strings.Index(lines[1], "^")
+	if len(lines) != 2 || caret < 0 || caret >= len(lines[0]) || lines[0][caret] != '?' {
+		t.Errorf("caret does not point at the error:\n%s", got)
+	}
+	if strings.HasSuffix(lines[0], "...") {
+		t.Errorf("the window should end with the line, got %q", lines[0])
+	}
+}
+
+func TestCheckDataSize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		size      int
+		wantNear  bool
+		wantError string
+	}{
+		{name: "small", size: 10},
+		{name: "just below the warning threshold", size: ConfigSizeWarningThreshold - 1},
+		{name: "at the warning threshold", size: ConfigSizeWarningThreshold, wantNear: true},
+		{name: "at the limit", size: MaxConfigSize, wantNear: true},
+		{name: "over the limit", size: MaxConfigSize + 1, wantError: "configuration is 2097153 bytes, limit is 2097152"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			near, err := CheckDataSize(make([]byte, tt.size))
+			if tt.wantError != "" {
+				if err == nil || err.Error() != tt.wantError {
+					t.Fatalf("CheckDataSize() error = %v, want %q", err, tt.wantError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CheckDataSize() error = %v", err)
+			}
+			if near != tt.wantNear {
+				t.Errorf("CheckDataSize() nearLimit = %v, want %v", near, tt.wantNear)
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package diff
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/koh-sh/apcdeploy/internal/aws"
+	"github.com/koh-sh/apcdeploy/internal/bulk"
+	"github.com/koh-sh/apcdeploy/internal/config"
+)
+
+// errNoDataFile is reported for targets-file entries without a data_file,
+// since diff has nothing local to compare against.
+var errNoDataFile = errors.New("data_file is required for diff")
+
+// targetDiff is one row of the aggregated bulk diff report, and the whole
+// report for single-target --output json.
+type targetDiff struct {
+	Target      string `json:"target"`
+	Application string `json:"application"`
+	Profile     string `json:"profile"`
+	Environment string `json:"environment"`
+	Region      string `json:"region"`
+	Changed     bool   `json:"changed"`
+	// FirstDeploy is true when the target has no deployment yet, so the
+	// whole local file would be the initial payload.
+	FirstDeploy bool   `json:"first_deploy,omitempty"`
+	Added       int    `json:"added"`
+	Removed     int    `json:"removed"`
+	Skipped     bool   `json:"skipped,omitempty"` // not compared because --fail-fast stopped the run
+	Error       string `json:"error,omitempty"`
+
+	result *Result
+}
+
+// ExecuteBulk diffs every target in opts.TargetsFile against its local
+// data_file concurrently.
+//
+// Each target gets its own Targets row. For text output the unified diffs
+// of changed targets go to stdout, each preceded by a "=== <id> ===" header
+// (output.md §7.2 stdout header rules for N>1). For --output json a single
+// JSON array of targetDiff is written instead. Failed targets make the
+// command return an error; otherwise ErrDiffFound is returned when any
+// target would make the single-target command fail under ExitNonzero or
+// ExitCode (see exitCodeError).
+//
+// With FailFast the first changed target (a first deployment included)
+// cancels the comparisons still in flight; rows that end with that
+// cancellation are skipped, while other errors still fail, and ErrDiffFound
+// is returned regardless of the exit flags.
+func (e *Executor) ExecuteBulk(ctx context.Context, opts *Options) error {
+	targets, err := bulk.Prepare(ctx, opts.TargetsFile, opts.Region, opts.Filter, e.clientFactory)
+	if err != nil {
+		return err
+	}
+
+	tg := e.reporter.Targets(bulk.IDs(targets))
+	defer tg.Close()
+
+	runCtx, stop := context.WithCancel(ctx)
+	defer stop()
+
+	results := make([]targetDiff, len(targets))
+	bulk.Run(targets, opts.Concurrency, func(i int, t bulk.Target) {
+		res := targetDiff{
+			Target:      t.ID,
+			Application: t.Config.Application,
+			Profile:     t.Config.ConfigurationProfile,
+			Environment: t.Config.Environment,
+			Region:      t.Client.Region,
+		}
+		defer func() { results[i] = res }()
+
+		err := e.diffTarget(runCtx, t, &res)
+		// Only a cancellation we caused is a skip; the caller's own ctx
+		// ending, or any other error that races with the stop, is still
+		// reported as a failure.
+		if errors.Is(err, context.Canceled) && runCtx.Err() != nil && ctx.Err() == nil {
+			tg.Skip(t.ID, "skipped (fail-fast)")
+			res.Skipped = true
+			return
+		}
+		if err != nil {
+			tg.Fail(t.ID, err)
+			res.Error = err.Error()
+			return
+		}
+		if res.Changed && opts.FailFast {
+			stop()
+		}
+		switch {
+		case res.FirstDeploy:
+			tg.Done(t.ID, "no prior deployment")
+		case res.Changed:
+			tg.Done(t.ID, formatDiffSummary(res.Added, res.Removed))
+		default:
+			tg.Done(t.ID, "no changes")
+		}
+	})
+	// Finalise the rows before the stdout payload so TTY redraws do not
+	// interleave with it.
+	tg.Close()
+
+	failed, skipped := 0, 0
+	diffFound := false
+	for _, r := range results {
+		if r.Skipped {
+			skipped++
+		}
+		if r.Error != "" {
+			failed++
+		}
+		if exitCodeError(opts, r.Changed, r.FirstDeploy) != nil || (opts.FailFast && r.Changed) {
+			diffFound = true
+		}
+	}
+
+	if opts.Output == config.OutputFormatJSON {
+		out, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode diff report: %w", err)
+		}
+		e.reporter.Data(append(out, '\n'))
+	} else {
+		for _, r := range results {
+			if r.result == nil {
+				continue
+			}
+			emitChanges(e.reporter, "=== "+r.Target+" ===\n", r.result.FileName, r.result, opts)
+		}
+	}
+
+	if err := bulk.FailedError(failed, len(results)); err != nil {
+		return err
+	}
+	if skipped > 0 {
+		e.reporter.Warn(fmt.Sprintf("--fail-fast: stopped after the first difference; %d of %d targets were not compared", skipped, len(results)))
+	}
+	if diffFound {
+		return ErrDiffFound
+	}
+	return nil
+}
+
+// diffTarget compares a single target's local data_file with its latest
+// deployment and records the outcome on res.
+func (e *Executor) diffTarget(ctx context.Context, t bulk.Target, res *targetDiff) error {
+	if t.Config.DataFile == "" {
+		return errNoDataFile
+	}
+	if err := t.Config.RequireDataFilePath("diff"); err != nil {
+		return err
+	}
+	localData, err := config.LoadDataFile(t.Config.DataFile)
+	if err != nil {
+		return fmt.Errorf("failed to load local configuration file: %w", err)
+	}
+
+	resources, err := aws.NewResolver(t.Client).ResolveAll(ctx, t.Config.Application, t.Config.ConfigurationProfile, t.Config.Environment, "")
+	if err != nil {
+		return err
+	}
+
+	deployment, err := aws.GetLatestDeployment(ctx, t.Client, resources.ApplicationID, resources.EnvironmentID, resources.Profile.ID)
+	if err != nil {
+		return err
+	}
+	if deployment == nil {
+		res.FirstDeploy = true
+		res.Changed = true
+		return nil
+	}
+
+	remoteData, err := aws.GetHostedConfigurationVersion(ctx, t.Client, resources.ApplicationID, resources.Profile.ID, deployment.ConfigurationVersion)
+	if err != nil {
+		return err
+	}
+
+	result, err := Calculate(string(remoteData), string(localData), t.Config.DataFile, resources.Profile.Type, t.Config.TextNormalizeOptions())
+	if err != nil {
+		return err
+	}
+	result.Redact(t.Config.RedactFields)
+	res.Changed = result.HasChanges
+	if result.HasChanges {
+		res.Added, res.Removed = CountChanges(result.UnifiedDiff)
+		res.result = result
+	}
+	return nil
+}
@@ -0,0 +1,272 @@
+package diff
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig/types"
+	awsInternal "github.com/koh-sh/apcdeploy/internal/aws"
+	"github.com/koh-sh/apcdeploy/internal/aws/mock"
+	"github.com/koh-sh/apcdeploy/internal/config"
+	reportertest "github.com/koh-sh/apcdeploy/internal/reporter/testing"
+)
+
+// newBulkMock returns a client with two environments: "prod" serves
+// {"key": "old"} as version 1, "staging" has never been deployed.
+func newBulkMock() *mock.MockAppConfigClient {
+	m := mock.NewResolvingClient("AWS.Freeform")
+	m.ListEnvironmentsFunc = func(ctx context.Context, params *appconfig.ListEnvironmentsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListEnvironmentsOutput, error) {
+		return &appconfig.ListEnvironmentsOutput{
+			Items: []types.Environment{
+				{Id: aws.String("env-prod"), Name: aws.String("prod")},
+				{Id: aws.String("env-staging"), Name: aws.String("staging")},
+			},
+		}, nil
+	}
+	m.ListDeploymentsFunc = func(ctx context.Context, params *appconfig.ListDeploymentsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListDeploymentsOutput, error) {
+		if aws.ToString(params.EnvironmentId) != "env-prod" {
+			return &appconfig.ListDeploymentsOutput{}, nil
+		}
+		return &appconfig.ListDeploymentsOutput{
+			Items: []types.DeploymentSummary{{DeploymentNumber: 1, State: types.DeploymentStateComplete}},
+		}, nil
+	}
+	m.GetDeploymentFunc = func(ctx context.Context, params *appconfig.GetDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.GetDeploymentOutput, error) {
+		return &appconfig.GetDeploymentOutput{
+			DeploymentNumber:       1,
+			ConfigurationVersion:   aws.String("1"),
+			ConfigurationProfileId: aws.String("profile-123"),
+			State:                  types.DeploymentStateComplete,
+		}, nil
+	}
+	m.GetHostedConfigurationVersionFunc = func(ctx context.Context, params *appconfig.GetHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.GetHostedConfigurationVersionOutput, error) {
+		return &appconfig.GetHostedConfigurationVersionOutput{Content: []byte(`{"key": "old"}`)}, nil
+	}
+	return m
+}
+
+// writeBulkFixture writes a targets file plus the data files it references
+// and returns the targets file path.
+func writeBulkFixture(t *testing.T, prodData string) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		"prod.json":    prodData,
+		"staging.json": `{"key": "new"}`,
+		"targets.yml": `- application: test-app
+  profile: test-profile
+  environment: prod
+  data_file: prod.json
+- application: test-app
+  profile: test-profile
+  environment: staging
+  data_file: staging.json
+`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	return filepath.Join(dir, "targets.yml")
+}
+
+func newBulkExecutor(rep *reportertest.MockReporter) *Executor {
+	return NewExecutorWithFactory(rep, func(ctx context.Context, region string) (*awsInternal.Client, error) {
+		return awsInternal.NewTestClient(newBulkMock()), nil
+	})
+}
+
+func TestExecuteBulkText(t *testing.T) {
+	t.Parallel()
+
+	rep := &reportertest.MockReporter{}
+	err := newBulkExecutor(rep).ExecuteBulk(context.Background(), &Options{
+		TargetsFile: writeBulkFixture(t, `{"key": "new"}`),
+		Output:      config.OutputFormatText,
+		ExitNonzero: true,
+	})
+	if !errors.Is(err, ErrDiffFound) {
+		t.Fatalf("expected ErrDiffFound, got %v", err)
+	}
+
+	stdout := string(rep.Stdout)
+	if !strings.HasPrefix(stdout, "=== us-east-1/test-app/test-profile/prod ===\n") {
+		t.Errorf("expected prod diff with header, got %q", stdout)
+	}
+	if strings.Contains(stdout, "staging") {
+		t.Errorf("first-deploy target should not emit a diff, got %q", stdout)
+	}
+}
+
+// TestExecuteBulkExitFlagsFirstDeploy checks that the exit flags treat a
+// first deployment as single-target mode does: only --exit-code fails.
+func TestExecuteBulkExitFlagsFirstDeploy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		opts    Options
+		wantErr bool
+	}{
+		{name: "exit-nonzero ignores first deploy", opts: Options{ExitNonzero: true}},
+		{name: "exit-code counts first deploy", opts: Options{ExitCode: true}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := tt.opts
+			opts.TargetsFile = writeBulkFixture(t, `{"key": "old"}`)
+			opts.Output = config.OutputFormatText
+			err := newBulkExecutor(&reportertest.MockReporter{}).ExecuteBulk(context.Background(), &opts)
+			if got := errors.Is(err, ErrDiffFound); got != tt.wantErr {
+				t.Errorf("errors.Is(err, ErrDiffFound) = %v, want %v (err: %v)", got, tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestExecuteBulkJSON(t *testing.T) {
+	t.Parallel()
+
+	rep := &reportertest.MockReporter{}
+	err := newBulkExecutor(rep).ExecuteBulk(context.Background(), &Options{
+		TargetsFile: writeBulkFixture(t, `{"key": "old"}`),
+		Output:      config.OutputFormatJSON,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got []targetDiff
+	if err := json.Unmarshal(rep.Stdout, &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, rep.Stdout)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(got))
+	}
+	if got[0].Changed {
+		t.Errorf("prod should be unchanged: %+v", got[0])
+	}
+	if !got[1].Changed || !got[1].FirstDeploy {
+		t.Errorf("staging should be a first deploy: %+v", got[1])
+	}
+}
+
+func TestExecuteBulkMissingDataFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "targets.yml")
+	content := `- application: test-app
+  profile: test-profile
+  environment: prod
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write targets file: %v", err)
+	}
+
+	rep := &reportertest.MockReporter{}
+	err := newBulkExecutor(rep).ExecuteBulk(context.Background(), &Options{TargetsFile: path})
+	if err == nil || err.Error() != "1 of 1 targets failed" {
+		t.Fatalf("expected aggregated failure, got %v", err)
+	}
+	tr := rep.TargetsCalls[0].Transitions
+	if last := tr[len(tr)-1]; last.Kind != "fail" || !errors.Is(last.Err, errNoDataFile) {
+		t.Errorf("expected data_file failure, got %+v", last)
+	}
+}
+
+func TestExecuteBulkFailFast(t *testing.T) {
+	t.Parallel()
+
+	// prod hangs until its context is cancelled, so the test only finishes
+	// promptly if staging's first deploy stops the run.
+	client := newBulkMock()
+	listDeployments := client.ListDeploymentsFunc
+	client.ListDeploymentsFunc = func(ctx context.Context, params *appconfig.ListDeploymentsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListDeploymentsOutput, error) {
+		if aws.ToString(params.EnvironmentId) == "env-prod" {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(10 * time.Second):
+				return nil, errors.New("prod comparison was not cancelled")
+			}
+		}
+		return listDeployments(ctx, params, optFns...)
+	}
+	rep := &reportertest.MockReporter{}
+	executor := NewExecutorWithFactory(rep, func(ctx context.Context, region string) (*awsInternal.Client, error) {
+		return awsInternal.NewTestClient(client), nil
+	})
+
+	start := time.Now()
+	err := executor.ExecuteBulk(context.Background(), &Options{
+		TargetsFile: writeBulkFixture(t, `{"key": "old"}`),
+		Output:      config.OutputFormatJSON,
+		FailFast:    true,
+	})
+	if !errors.Is(err, ErrDiffFound) {
+		t.Fatalf("expected ErrDiffFound, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("fail-fast took %v; in-flight targets were not cancelled", elapsed)
+	}
+
+	var got []targetDiff
+	if err := json.Unmarshal(rep.Stdout, &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, rep.Stdout)
+	}
+	if len(got) != 2 || !got[0].Skipped || got[0].Error != "" || !got[1].Changed {
+		t.Errorf("expected prod skipped and staging changed, got %+v", got)
+	}
+	if !rep.HasMessage("1 of 2 targets were not compared") {
+		t.Errorf("expected a fail-fast warning, got %v", rep.Messages)
+	}
+}
+
+func TestExecuteBulkFailFastKeepsRealFailures(t *testing.T) {
+	t.Parallel()
+
+	// prod fails with its own error once the run is stopped; that is a
+	// failure, not a fail-fast skip.
+	client := newBulkMock()
+	listDeployments := client.ListDeploymentsFunc
+	client.ListDeploymentsFunc = func(ctx context.Context, params *appconfig.ListDeploymentsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListDeploymentsOutput, error) {
+		if aws.ToString(params.EnvironmentId) == "env-prod" {
+			<-ctx.Done()
+			return nil, errors.New("access denied")
+		}
+		return listDeployments(ctx, params, optFns...)
+	}
+	rep := &reportertest.MockReporter{}
+	executor := NewExecutorWithFactory(rep, func(ctx context.Context, region string) (*awsInternal.Client, error) {
+		return awsInternal.NewTestClient(client), nil
+	})
+
+	err := executor.ExecuteBulk(context.Background(), &Options{
+		TargetsFile: writeBulkFixture(t, `{"key": "old"}`),
+		Output:      config.OutputFormatJSON,
+		FailFast:    true,
+	})
+	if err == nil || err.Error() != "1 of 2 targets failed" {
+		t.Fatalf("expected aggregated failure, got %v", err)
+	}
+
+	var got []targetDiff
+	if err := json.Unmarshal(rep.Stdout, &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, rep.Stdout)
+	}
+	if len(got) != 2 || got[0].Skipped || !strings.Contains(got[0].Error, "access denied") {
+		t.Errorf("expected prod to fail with its own error, got %+v", got)
+	}
+}
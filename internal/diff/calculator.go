@@ -3,10 +3,8 @@ package diff
 import (
 	"fmt"
 	"path/filepath"
-	"strings"
 
 	"github.com/koh-sh/apcdeploy/internal/config"
-	"github.com/sergi/go-diff/diffmatchpatch"
 )
 
 // Result represents the result of a diff calculation
@@ -15,15 +13,22 @@ type Result struct {
 	RemoteContent string
 	// LocalContent is the local configuration content
 	LocalContent string
-	// UnifiedDiff is the unified diff output
+	// UnifiedDiff is the unified diff (see Unified) with the whole file as
+	// context; Render narrows it
 	UnifiedDiff string
 	// HasChanges indicates whether there are any differences
 	HasChanges bool
 	// FileName is the name of the local file being compared
 	FileName string
+
+	// shownRemote and shownLocal are the contents the diff is rendered
+	// from: the normalized contents, masked by Redact
+	shownRemote, shownLocal string
+	// remoteLabel and localLabel name the sides in the ---/+++ header
+	remoteLabel, localLabel string
 }
 
-// calculate computes the diff between remote and local configuration.
+// Calculate computes the diff between remote and local configuration.
 // For FeatureFlags profile type, it removes _updatedAt and _createdAt fields
 // before comparing to avoid false positives from auto-generated timestamps.
 //
@@ -35,93 +40,61 @@ type Result struct {
 //   - localContent: The local configuration content
 //   - fileName: Name of the local file (used to determine file type)
 //   - profileType: AWS AppConfig profile type (e.g., "AWS.AppConfig.FeatureFlags")
+//   - textOpts: Extra normalizations for text content (text_normalize)
 //
 // Returns:
 //   - *Result: Diff result containing normalized contents and unified diff
 //   - error: Any error during normalization or diff calculation
-func calculate(remoteContent, localContent, fileName, profileType string) (*Result, error) {
+func Calculate(remoteContent, localContent, fileName, profileType string, textOpts config.TextNormalizeOptions) (*Result, error) {
 	// Normalize content based on file extension
 	ext := filepath.Ext(fileName)
 
-	normalizedRemote, err := config.NormalizeByExtension(remoteContent, ext, profileType)
+	normalizedRemote, err := config.NormalizeByExtension(remoteContent, ext, profileType, textOpts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to normalize remote content: %w", err)
 	}
 
-	normalizedLocal, err := config.NormalizeByExtension(localContent, ext, profileType)
+	normalizedLocal, err := config.NormalizeByExtension(localContent, ext, profileType, textOpts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to normalize local content: %w", err)
 	}
 
-	// Generate line-based unified diff
-	dmp := diffmatchpatch.New()
-
-	// Convert texts to line-based diffs
-	lineText1, lineText2, lineArray := dmp.DiffLinesToChars(normalizedRemote, normalizedLocal)
-	diffs := dmp.DiffMain(lineText1, lineText2, false)
-	diffs = dmp.DiffCharsToLines(diffs, lineArray)
-
-	// Check if there are any changes
-	hasChanges := false
-	for _, d := range diffs {
-		if d.Type != diffmatchpatch.DiffEqual {
-			hasChanges = true
-			break
-		}
-	}
-
-	// Generate unified diff format
-	unifiedDiff := formatDiffs(diffs)
+	unifiedDiff := Unified(normalizedRemote, normalizedLocal, -1)
 
 	return &Result{
 		RemoteContent: normalizedRemote,
 		LocalContent:  normalizedLocal,
 		UnifiedDiff:   unifiedDiff,
-		HasChanges:    hasChanges,
+		HasChanges:    unifiedDiff != "",
 		FileName:      fileName,
+		shownRemote:   normalizedRemote,
+		shownLocal:    normalizedLocal,
+		remoteLabel:   "remote",
+		localLabel:    "local",
 	}, nil
 }
 
-// formatDiffs converts line-based diffs to a simple diff format.
-// It processes each diff chunk and formats lines with prefixes:
-//   - "+" for added lines
-//   - "-" for deleted lines
-//   - " " for context lines (unchanged)
-//
-// Empty lines are skipped to produce a cleaner output.
-//
-// Parameters:
-//   - diffs: Slice of diff chunks from go-diff library
-//
-// Returns:
-//   - string: Formatted diff output
-func formatDiffs(diffs []diffmatchpatch.Diff) string {
-	var result strings.Builder
+// Render renders the diff with context unchanged lines around each change
+// (see Unified), honoring Redact.
+func (r *Result) Render(context int) string {
+	return unifiedLabeled(r.remoteLabel, r.localLabel, r.shownRemote, r.shownLocal, context)
+}
 
-	for _, diff := range diffs {
-		lines := strings.SplitSeq(diff.Text, "\n")
-		for line := range lines {
-			// Skip empty lines
-			if line == "" {
-				continue
-			}
+// Relabel names the two sides in the ---/+++ header instead of "remote"
+// and "local", e.g. the environments compared by diff --env-a/--env-b.
+func (r *Result) Relabel(remote, local string) {
+	r.remoteLabel, r.localLabel = remote, local
+	r.UnifiedDiff = r.Render(-1)
+}
 
-			switch diff.Type {
-			case diffmatchpatch.DiffInsert:
-				result.WriteString("+")
-				result.WriteString(line)
-				result.WriteString("\n")
-			case diffmatchpatch.DiffDelete:
-				result.WriteString("-")
-				result.WriteString(line)
-				result.WriteString("\n")
-			case diffmatchpatch.DiffEqual:
-				result.WriteString(" ")
-				result.WriteString(line)
-				result.WriteString("\n")
-			}
-		}
+// Redact masks sensitive values (see config.RedactPair) in UnifiedDiff for
+// display. HasChanges keeps reflecting the real content, and a change to a
+// masked value still shows up as a changed line.
+func (r *Result) Redact(fields []string) {
+	remote, local := config.RedactPair(r.RemoteContent, r.LocalContent, filepath.Ext(r.FileName), fields)
+	if remote == r.RemoteContent && local == r.LocalContent {
+		return
 	}
-
-	return result.String()
+	r.shownRemote, r.shownLocal = remote, local
+	r.UnifiedDiff = r.Render(-1)
 }
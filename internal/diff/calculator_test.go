@@ -92,7 +92,7 @@ func TestCalculate(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := calculate(tt.remoteContent, tt.localContent, tt.fileName, "")
+			result, err := Calculate(tt.remoteContent, tt.localContent, tt.fileName, "", config.TextNormalizeOptions{})
 
 			if tt.wantErr {
 				if err == nil {
@@ -261,7 +261,7 @@ func TestNormalizeText(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := config.NormalizeText(tt.input)
+			result := config.NormalizeText(tt.input, config.TextNormalizeOptions{})
 			if result != tt.expected {
 				t.Errorf("NormalizeText() = %q, want %q", result, tt.expected)
 			}
@@ -316,7 +316,7 @@ func TestNormalizeContent(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := config.NormalizeByExtension(tt.content, tt.ext, "")
+			result, err := config.NormalizeByExtension(tt.content, tt.ext, "", config.TextNormalizeOptions{})
 
 			if tt.wantErr {
 				if err == nil {
@@ -342,7 +342,7 @@ func TestCalculateResult(t *testing.T) {
 	localContent := `{"key": "new"}`
 	fileName := "config.json"
 
-	result, err := calculate(remoteContent, localContent, fileName, "")
+	result, err := Calculate(remoteContent, localContent, fileName, "", config.TextNormalizeOptions{})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -414,7 +414,7 @@ func TestCalculateFeatureFlags(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := calculate(tt.remoteContent, tt.localContent, "config.json", tt.profileType)
+			result, err := Calculate(tt.remoteContent, tt.localContent, "config.json", tt.profileType, config.TextNormalizeOptions{})
 			if err != nil {
 				t.Fatalf("unexpected error: %v", err)
 			}
@@ -441,3 +441,32 @@ func TestCalculateFeatureFlags(t *testing.T) {
 		})
 	}
 }
+
+func TestResultRedact(t *testing.T) {
+	t.Parallel()
+
+	remote := `{"db":{"host":"h","password":"old"},"level":"info"}`
+	local := `{"db":{"host":"h","password":"new"},"level":"debug"}`
+
+	result, err := Calculate(remote, local, "config.json", "", config.TextNormalizeOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result.Redact(nil)
+
+	if !result.HasChanges {
+		t.Error("HasChanges should stay true after redaction")
+	}
+	if strings.Contains(result.UnifiedDiff, "old") || strings.Contains(result.UnifiedDiff, "\"new\"") {
+		t.Errorf("UnifiedDiff leaks a redacted value:\n%s", result.UnifiedDiff)
+	}
+	if !strings.Contains(result.UnifiedDiff, `+    "password": "[REDACTED (changed)]"`) {
+		t.Errorf("UnifiedDiff should mark the changed secret:\n%s", result.UnifiedDiff)
+	}
+	if !strings.Contains(result.UnifiedDiff, `+  "level": "debug"`) {
+		t.Errorf("UnifiedDiff should keep non-sensitive changes:\n%s", result.UnifiedDiff)
+	}
+	if !strings.Contains(result.LocalContent, `"new"`) {
+		t.Error("LocalContent must not be redacted")
+	}
+}
@@ -0,0 +1,118 @@
+package diff
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/koh-sh/apcdeploy/internal/aws"
+	"github.com/koh-sh/apcdeploy/internal/config"
+)
+
+// compareReport is the --output json payload of an environment comparison.
+type compareReport struct {
+	Application string `json:"application"`
+	Profile     string `json:"profile"`
+	Region      string `json:"region"`
+	EnvA        string `json:"env_a"`
+	EnvB        string `json:"env_b"`
+	VersionA    int32  `json:"version_a"`
+	VersionB    int32  `json:"version_b"`
+	Changed     bool   `json:"changed"`
+	Added       int    `json:"added"`
+	Removed     int    `json:"removed"`
+}
+
+// ExecuteCompare diffs the configuration currently deployed to opts.EnvA
+// against the one deployed to opts.EnvB, using the application and profile
+// from the config file. The local data_file is not read.
+//
+// Each environment gets its own Targets row finalised with the deployed
+// version. The unified diff ("-" lines from EnvA, "+" lines from EnvB) or,
+// with --output json, a compareReport goes to stdout. ErrDiffFound is
+// returned whenever the environments differ, so the command exits 1.
+func (e *Executor) ExecuteCompare(ctx context.Context, opts *Options) error {
+	cfg, err := config.LoadConfig(opts.ConfigFile)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	cfg.ApplyRegionOverride(opts.Region)
+
+	awsClient, err := e.clientFactory(ctx, cfg.Region)
+	if err != nil {
+		return fmt.Errorf("failed to initialize AWS client: %w", err)
+	}
+
+	envs := []string{opts.EnvA, opts.EnvB}
+	ids := make([]string, len(envs))
+	for i, env := range envs {
+		envCfg := *cfg
+		envCfg.Environment = env
+		ids[i] = config.Identifier(awsClient.Region, &envCfg)
+	}
+	tg := e.reporter.Targets(ids)
+	defer tg.Close()
+
+	resolver := aws.NewResolver(awsClient)
+	deployed := make([]*aws.DeployedConfigInfo, len(envs))
+	var profileType string
+	for i, env := range envs {
+		tg.SetPhase(ids[i], "fetching", "")
+		resources, err := resolver.ResolveAll(ctx, cfg.Application, cfg.ConfigurationProfile, env, "")
+		if err != nil {
+			tg.Fail(ids[i], err)
+			return fmt.Errorf("failed to resolve resources: %w", err)
+		}
+		info, err := aws.GetLatestDeployedConfiguration(ctx, awsClient, resources.ApplicationID, resources.EnvironmentID, resources.Profile.ID)
+		if err != nil {
+			tg.Fail(ids[i], err)
+			return fmt.Errorf("failed to get deployed configuration of %q: %w", env, err)
+		}
+		if info == nil {
+			tg.Fail(ids[i], aws.ErrNoDeployment)
+			return fmt.Errorf("%w in environment %q", aws.ErrNoDeployment, env)
+		}
+		tg.Done(ids[i], fmt.Sprintf("fetched v%d", info.VersionNumber))
+		deployed[i] = info
+		profileType = resources.Profile.Type
+	}
+	tg.Close()
+
+	// Normalise both sides by the content type AppConfig recorded for EnvA;
+	// the file name only carries the extension into Calculate.
+	fileName := "deployed" + config.ExtensionForContentType(deployed[0].ContentType)
+	result, err := Calculate(string(deployed[0].Content), string(deployed[1].Content), fileName, profileType, cfg.TextNormalizeOptions())
+	if err != nil {
+		return fmt.Errorf("failed to calculate diff: %w", err)
+	}
+	result.Redact(cfg.RedactFields)
+	result.Relabel(opts.EnvA, opts.EnvB)
+
+	if opts.Output == config.OutputFormatJSON {
+		report := compareReport{
+			Application: cfg.Application,
+			Profile:     cfg.ConfigurationProfile,
+			Region:      awsClient.Region,
+			EnvA:        opts.EnvA,
+			EnvB:        opts.EnvB,
+			VersionA:    deployed[0].VersionNumber,
+			VersionB:    deployed[1].VersionNumber,
+			Changed:     result.HasChanges,
+		}
+		if result.HasChanges {
+			report.Added, report.Removed = CountChanges(result.UnifiedDiff)
+		}
+		out, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode diff report: %w", err)
+		}
+		e.reporter.Data(append(out, '\n'))
+	} else if result.HasChanges {
+		emitChanges(e.reporter, "", opts.EnvA+" → "+opts.EnvB, result, opts)
+	}
+
+	if result.HasChanges {
+		return ErrDiffFound
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package diff
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig/types"
+	awsInternal "github.com/koh-sh/apcdeploy/internal/aws"
+	"github.com/koh-sh/apcdeploy/internal/config"
+	reportertest "github.com/koh-sh/apcdeploy/internal/reporter/testing"
+)
+
+// newCompareExecutor extends the bulk mock so every environment except
+// "staging" is deployed; "qa" serves version 2 with prodContent replaced by
+// qaContent.
+func newCompareExecutor(rep *reportertest.MockReporter, qaContent string) *Executor {
+	m := newBulkMock()
+	m.ListEnvironmentsFunc = func(ctx context.Context, params *appconfig.ListEnvironmentsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListEnvironmentsOutput, error) {
+		return &appconfig.ListEnvironmentsOutput{
+			Items: []types.Environment{
+				{Id: aws.String("env-prod"), Name: aws.String("prod")},
+				{Id: aws.String("env-qa"), Name: aws.String("qa")},
+				{Id: aws.String("env-staging"), Name: aws.String("staging")},
+			},
+		}, nil
+	}
+	m.ListDeploymentsFunc = func(ctx context.Context, params *appconfig.ListDeploymentsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListDeploymentsOutput, error) {
+		switch aws.ToString(params.EnvironmentId) {
+		case "env-prod":
+			return &appconfig.ListDeploymentsOutput{Items: []types.DeploymentSummary{{DeploymentNumber: 1, State: types.DeploymentStateComplete}}}, nil
+		case "env-qa":
+			return &appconfig.ListDeploymentsOutput{Items: []types.DeploymentSummary{{DeploymentNumber: 2, State: types.DeploymentStateComplete}}}, nil
+		}
+		return &appconfig.ListDeploymentsOutput{}, nil
+	}
+	m.GetDeploymentFunc = func(ctx context.Context, params *appconfig.GetDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.GetDeploymentOutput, error) {
+		version := "1"
+		if aws.ToInt32(params.DeploymentNumber) == 2 {
+			version = "2"
+		}
+		return &appconfig.GetDeploymentOutput{
+			DeploymentNumber:       aws.ToInt32(params.DeploymentNumber),
+			ConfigurationVersion:   aws.String(version),
+			ConfigurationProfileId: aws.String("profile-123"),
+			State:                  types.DeploymentStateComplete,
+		}, nil
+	}
+	m.GetHostedConfigurationVersionFunc = func(ctx context.Context, params *appconfig.GetHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.GetHostedConfigurationVersionOutput, error) {
+		content := `{"key": "old"}`
+		if aws.ToInt32(params.VersionNumber) == 2 {
+			content = qaContent
+		}
+		return &appconfig.GetHostedConfigurationVersionOutput{
+			Content:       []byte(content),
+			ContentType:   aws.String(config.ContentTypeJSON),
+			VersionNumber: aws.ToInt32(params.VersionNumber),
+		}, nil
+	}
+	return NewExecutorWithFactory(rep, func(ctx context.Context, region string) (*awsInternal.Client, error) {
+		return awsInternal.NewTestClient(m), nil
+	})
+}
+
+func writeCompareConfig(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "apcdeploy.yml")
+	content := "application: test-app\nconfiguration_profile: test-profile\nenvironment: prod\ndata_file: data.json\nregion: us-east-1\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	return path
+}
+
+func TestExecuteCompare(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		qaContent  string
+		envB       string
+		output     string
+		wantErr    error
+		wantStdout []string
+	}{
+		{name: "identical", qaContent: `{"key":"old"}`, envB: "qa", output: config.OutputFormatText},
+		{
+			name: "different text", qaContent: `{"key": "new"}`, envB: "qa", output: config.OutputFormatText,
+			wantErr: ErrDiffFound, wantStdout: []string{"--- prod\n+++ qa\n", `-  "key": "old"`, `+  "key": "new"`},
+		},
+		{
+			name: "different json", qaContent: `{"key": "new"}`, envB: "qa", output: config.OutputFormatJSON,
+			wantErr: ErrDiffFound, wantStdout: []string{`"changed": true`, `"env_b": "qa"`, `"version_b": 2`, `"added": 1`},
+		},
+		{name: "no deployment in env-b", envB: "staging", output: config.OutputFormatText, wantErr: awsInternal.ErrNoDeployment},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rep := &reportertest.MockReporter{}
+			err := newCompareExecutor(rep, tt.qaContent).ExecuteCompare(context.Background(), &Options{
+				ConfigFile: writeCompareConfig(t),
+				EnvA:       "prod",
+				EnvB:       tt.envB,
+				Output:     tt.output,
+			})
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+			stdout := string(rep.Stdout)
+			for _, want := range tt.wantStdout {
+				if !strings.Contains(stdout, want) {
+					t.Errorf("expected stdout to contain %q, got:\n%s", want, stdout)
+				}
+			}
+			if tt.output == config.OutputFormatJSON {
+				var report compareReport
+				if err := json.Unmarshal(rep.Stdout, &report); err != nil {
+					t.Errorf("invalid JSON: %v", err)
+				}
+			}
+		})
+	}
+}
@@ -2,7 +2,6 @@ package diff
 
 import (
 	"bytes"
-	"io"
 	"strings"
 	"testing"
 
@@ -10,18 +9,8 @@ import (
 	mockreporter "github.com/koh-sh/apcdeploy/internal/reporter/testing"
 )
 
-// withWarningSink temporarily redirects the package-level warning sink to the
-// given writer for the duration of the test, restoring the original on
-// cleanup. Tests using this helper MUST NOT run in parallel — the sink is
-// package-scoped and concurrent overrides would race.
-func withWarningSink(t *testing.T, w io.Writer) {
-	t.Helper()
-	orig := inProgressWarningSink
-	inProgressWarningSink = w
-	t.Cleanup(func() { inProgressWarningSink = orig })
-}
-
 func TestDisplay(t *testing.T) {
+	t.Parallel()
 	const id = "us-east-1/app/prof/env"
 
 	tests := []struct {
@@ -78,14 +67,12 @@ func TestDisplay(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Subtests intentionally do NOT call t.Parallel() — they swap the
-			// package-level inProgressWarningSink and would race otherwise.
+			t.Parallel()
 			var stderrBuf bytes.Buffer
-			withWarningSink(t, &stderrBuf)
 
 			r := &mockreporter.MockReporter{}
 			tg := r.Targets([]string{id})
-			display(r, tg, id, tt.result, tt.deployment)
+			display(r, tg, id, tt.result, tt.deployment, &Options{Stderr: &stderrBuf})
 			tg.Close()
 
 			if got := string(r.Stdout); got != tt.wantStdout {
@@ -127,10 +114,10 @@ func TestDisplay(t *testing.T) {
 }
 
 func TestDisplay_NilDeployment(t *testing.T) {
+	t.Parallel()
 	const id = "us-east-1/app/prof/env"
 
 	var stderrBuf bytes.Buffer
-	withWarningSink(t, &stderrBuf)
 
 	r := &mockreporter.MockReporter{}
 	tg := r.Targets([]string{id})
@@ -140,6 +127,7 @@ func TestDisplay_NilDeployment(t *testing.T) {
 		id,
 		&Result{HasChanges: true, UnifiedDiff: "+a\n", FileName: "data.json"},
 		nil,
+		&Options{Stderr: &stderrBuf},
 	)
 	tg.Close()
 
@@ -149,7 +137,7 @@ func TestDisplay_NilDeployment(t *testing.T) {
 	}
 }
 
-func Test_countChanges(t *testing.T) {
+func TestCountChanges(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
@@ -169,12 +157,12 @@ func Test_countChanges(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			added, removed := countChanges(tt.diff)
+			added, removed := CountChanges(tt.diff)
 			if added != tt.wantAdded {
-				t.Errorf("countChanges() added = %v, want %v", added, tt.wantAdded)
+				t.Errorf("CountChanges() added = %v, want %v", added, tt.wantAdded)
 			}
 			if removed != tt.wantRemoved {
-				t.Errorf("countChanges() removed = %v, want %v", removed, tt.wantRemoved)
+				t.Errorf("CountChanges() removed = %v, want %v", removed, tt.wantRemoved)
 			}
 		})
 	}
@@ -2,8 +2,11 @@ package diff
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"path/filepath"
+	"strings"
 
 	"github.com/koh-sh/apcdeploy/internal/aws"
 	"github.com/koh-sh/apcdeploy/internal/config"
@@ -47,6 +50,13 @@ func NewExecutorWithFactory(rep reporter.Reporter, factory func(context.Context,
 //     stdout (acts as the right-hand side of the would-be diff).
 //   - errors:        ✗ failed: <message> on the Targets row.
 //
+// With --deployment the comparison is against the version served by that
+// deployment, which must belong to the resolved configuration profile.
+//
+// With --output json the stdout payload in every case is replaced by a single
+// targetDiff object ("changed", "first_deploy", "added", "removed") so CI can
+// gate on change detection without parsing the patch.
+//
 // The in-progress deployment warning still bypasses the Reporter via display
 // (CONTRACT EXCEPTION) so scripts under --silent still see the risk note.
 func (e *Executor) Execute(ctx context.Context, opts *Options) error {
@@ -54,6 +64,10 @@ func (e *Executor) Execute(ctx context.Context, opts *Options) error {
 	if err != nil {
 		return fmt.Errorf("failed to load configuration: %w", err)
 	}
+	cfg.ApplyRegionOverride(opts.Region)
+	if err := cfg.RequireDataFilePath("diff"); err != nil {
+		return err
+	}
 
 	awsClient, err := e.clientFactory(ctx, cfg.Region)
 	if err != nil {
@@ -77,21 +91,59 @@ func (e *Executor) Execute(ctx context.Context, opts *Options) error {
 		return fmt.Errorf("failed to resolve resources: %w", err)
 	}
 
-	deployment, err := aws.GetLatestDeployment(ctx, awsClient, resources.ApplicationID, resources.EnvironmentID, resources.Profile.ID)
-	if err != nil {
-		tg.Fail(id, err)
-		return fmt.Errorf("failed to get latest deployment: %w", err)
+	var deployment *aws.DeploymentInfo
+	if opts.Deployment != 0 {
+		deployment, err = aws.GetDeploymentByNumber(ctx, awsClient, resources.ApplicationID, resources.EnvironmentID, resources.Profile.ID, opts.Deployment)
+		if err != nil {
+			tg.Fail(id, err)
+			return err
+		}
+	} else {
+		deployment, err = aws.GetLatestDeployment(ctx, awsClient, resources.ApplicationID, resources.EnvironmentID, resources.Profile.ID)
+		if err != nil {
+			err = aws.ExplainNotFound(err, cfg)
+			tg.Fail(id, err)
+			return fmt.Errorf("failed to get latest deployment: %w", err)
+		}
+	}
+
+	report := targetDiff{
+		Target:      id,
+		Application: cfg.Application,
+		Profile:     cfg.ConfigurationProfile,
+		Environment: cfg.Environment,
+		Region:      awsClient.Region,
 	}
+	jsonOutput := opts.Output == config.OutputFormatJSON
 
 	if deployment == nil {
+		if opts.DumpNormalized != "" {
+			normalizedLocal, err := config.NormalizeByExtension(string(localData), filepath.Ext(cfg.DataFile), resources.Profile.Type, cfg.TextNormalizeOptions())
+			if err != nil {
+				tg.Fail(id, err)
+				return fmt.Errorf("failed to normalize local content: %w", err)
+			}
+			if err := e.dumpNormalized(opts.DumpNormalized, nil, normalizedLocal, cfg.DataFile); err != nil {
+				tg.Fail(id, err)
+				return err
+			}
+		}
 		tg.Done(id, "no prior deployment")
+		if jsonOutput {
+			report.FirstDeploy = true
+			report.Changed = true
+			if err := e.writeReport(report); err != nil {
+				return err
+			}
+			return exitCodeError(opts, true, true)
+		}
 		// The local data is the would-be initial deployment payload — emit it
 		// to stdout so consumers can pipe it into apcdeploy run / git apply.
 		e.reporter.Data(localData)
 		if len(localData) > 0 && localData[len(localData)-1] != '\n' {
 			e.reporter.Data([]byte("\n"))
 		}
-		return nil
+		return exitCodeError(opts, true, true)
 	}
 
 	remoteData, err := aws.GetHostedConfigurationVersion(ctx, awsClient, resources.ApplicationID, resources.Profile.ID, deployment.ConfigurationVersion)
@@ -100,17 +152,68 @@ func (e *Executor) Execute(ctx context.Context, opts *Options) error {
 		return fmt.Errorf("failed to get deployed configuration: %w", err)
 	}
 
-	diffResult, err := calculate(string(remoteData), string(localData), cfg.DataFile, resources.Profile.Type)
+	diffResult, err := Calculate(string(remoteData), string(localData), cfg.DataFile, resources.Profile.Type, cfg.TextNormalizeOptions())
 	if err != nil {
 		tg.Fail(id, err)
 		return fmt.Errorf("failed to calculate diff: %w", err)
 	}
+	if opts.DumpNormalized != "" {
+		if err := e.dumpNormalized(opts.DumpNormalized, &diffResult.RemoteContent, diffResult.LocalContent, cfg.DataFile); err != nil {
+			tg.Fail(id, err)
+			return err
+		}
+	}
+	diffResult.Redact(cfg.RedactFields)
+
+	if jsonOutput {
+		report.Changed = diffResult.HasChanges
+		if diffResult.HasChanges {
+			report.Added, report.Removed = CountChanges(diffResult.UnifiedDiff)
+			tg.Done(id, formatDiffSummary(report.Added, report.Removed))
+		} else {
+			tg.Done(id, "no changes")
+		}
+		displayDeploymentWarning(opts.stderr(), deployment)
+		if err := e.writeReport(report); err != nil {
+			return err
+		}
+	} else {
+		display(e.reporter, tg, id, diffResult, deployment, opts)
+	}
 
-	display(e.reporter, tg, id, diffResult, deployment)
+	return exitCodeError(opts, diffResult.HasChanges, false)
+}
 
-	if opts.ExitNonzero && diffResult.HasChanges {
-		return ErrDiffFound
+// dumpNormalized writes the compared strings for --dump-normalized and
+// reports where they went.
+func (e *Executor) dumpNormalized(dir string, remote *string, local, fileName string) error {
+	paths, err := config.DumpNormalized(dir, filepath.Ext(fileName), remote, local)
+	if err != nil {
+		return fmt.Errorf("failed to dump normalized content: %w", err)
 	}
+	e.reporter.Info("normalized content written to " + strings.Join(paths, ", "))
+	return nil
+}
 
+// writeReport emits a single-target targetDiff as the JSON stdout payload.
+func (e *Executor) writeReport(report targetDiff) error {
+	out, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode diff report: %w", err)
+	}
+	e.reporter.Data(append(out, '\n'))
+	return nil
+}
+
+// exitCodeError maps a diff outcome onto ErrDiffFound. --exit-nonzero only
+// fires for real content differences, while the git-style --exit-code also
+// treats a first deployment (no remote version) as a change.
+func exitCodeError(opts *Options, changed, firstDeploy bool) error {
+	if !changed {
+		return nil
+	}
+	if opts.ExitCode || (opts.ExitNonzero && !firstDeploy) {
+		return ErrDiffFound
+	}
 	return nil
 }
@@ -2,6 +2,9 @@ package diff
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -12,6 +15,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/appconfig/types"
 	awsInternal "github.com/koh-sh/apcdeploy/internal/aws"
 	"github.com/koh-sh/apcdeploy/internal/aws/mock"
+	"github.com/koh-sh/apcdeploy/internal/config"
 	reportertest "github.com/koh-sh/apcdeploy/internal/reporter/testing"
 )
 
@@ -448,3 +452,229 @@ region: us-east-1
 		t.Errorf("expected no error when no differences exist, got: %v", err)
 	}
 }
+
+func TestExecutorExitCodeAndJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		environment string
+		data        string
+		opts        Options
+		wantErr     bool
+		wantChanged bool
+		wantFirst   bool
+	}{
+		{"identical exit-code", "prod", `{"key": "old"}`, Options{ExitCode: true}, false, false, false},
+		{"different exit-code", "prod", `{"key": "new"}`, Options{ExitCode: true}, true, true, false},
+		{"first deploy exit-code", "staging", `{"key": "new"}`, Options{ExitCode: true}, true, true, true},
+		{"first deploy exit-nonzero", "staging", `{"key": "new"}`, Options{ExitNonzero: true}, false, true, true},
+		{"different without exit flags", "prod", `{"key": "new"}`, Options{}, false, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			configPath := filepath.Join(dir, "apcdeploy.yml")
+			configContent := "application: test-app\nconfiguration_profile: test-profile\nenvironment: " + tt.environment + "\ndata_file: data.json\nregion: us-east-1\n"
+			if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+			if err := os.WriteFile(filepath.Join(dir, "data.json"), []byte(tt.data), 0o644); err != nil {
+				t.Fatalf("Failed to write data: %v", err)
+			}
+
+			opts := tt.opts
+			opts.ConfigFile = configPath
+			opts.Output = config.OutputFormatJSON
+
+			rep := &reportertest.MockReporter{}
+			err := newBulkExecutor(rep).Execute(context.Background(), &opts)
+			if tt.wantErr != errors.Is(err, ErrDiffFound) {
+				t.Fatalf("Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var got targetDiff
+			if err := json.Unmarshal(rep.Stdout, &got); err != nil {
+				t.Fatalf("invalid JSON: %v\n%s", err, rep.Stdout)
+			}
+			if got.Changed != tt.wantChanged || got.FirstDeploy != tt.wantFirst {
+				t.Errorf("report = %+v, want changed=%v first_deploy=%v", got, tt.wantChanged, tt.wantFirst)
+			}
+			if tt.wantChanged && !tt.wantFirst && (got.Added != 1 || got.Removed != 1) {
+				t.Errorf("expected +1/-1 summary, got +%d/-%d", got.Added, got.Removed)
+			}
+		})
+	}
+}
+
+// TestExecutorDeployment checks that --deployment diffs against the version
+// served by that deployment rather than the latest one.
+func TestExecutorDeployment(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		environment string
+		deployment  int32
+		data        string
+		wantChanged bool
+		wantErr     string
+	}{
+		{name: "matches the older deployment", environment: "prod", deployment: 2, data: `{"key": "older"}`},
+		{name: "differs from the older deployment", environment: "prod", deployment: 2, data: `{"key": "old"}`, wantChanged: true},
+		{name: "environment without a latest deployment", environment: "staging", deployment: 2, data: `{"key": "older"}`},
+		{name: "deployment of another profile", environment: "prod", deployment: 4, data: `{"key": "older"}`, wantErr: "deployment #4 is not for this configuration profile"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			configPath := filepath.Join(dir, "apcdeploy.yml")
+			configContent := "application: test-app\nconfiguration_profile: test-profile\nenvironment: " + tt.environment + "\ndata_file: data.json\nregion: us-east-1\n"
+			if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+			if err := os.WriteFile(filepath.Join(dir, "data.json"), []byte(tt.data), 0o644); err != nil {
+				t.Fatalf("Failed to write data: %v", err)
+			}
+
+			mockClient := newBulkMock()
+			mockClient.GetDeploymentFunc = func(ctx context.Context, params *appconfig.GetDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.GetDeploymentOutput, error) {
+				profileID := "profile-123"
+				if *params.DeploymentNumber == 4 {
+					profileID = "profile-other"
+				}
+				return &appconfig.GetDeploymentOutput{
+					DeploymentNumber:       *params.DeploymentNumber,
+					ConfigurationVersion:   aws.String(fmt.Sprint(*params.DeploymentNumber)),
+					ConfigurationProfileId: aws.String(profileID),
+					State:                  types.DeploymentStateComplete,
+				}, nil
+			}
+			mockClient.GetHostedConfigurationVersionFunc = func(ctx context.Context, params *appconfig.GetHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.GetHostedConfigurationVersionOutput, error) {
+				if *params.VersionNumber == 2 {
+					return &appconfig.GetHostedConfigurationVersionOutput{Content: []byte(`{"key": "older"}`)}, nil
+				}
+				return &appconfig.GetHostedConfigurationVersionOutput{Content: []byte(`{"key": "old"}`)}, nil
+			}
+			rep := &reportertest.MockReporter{}
+			executor := NewExecutorWithFactory(rep, func(ctx context.Context, region string) (*awsInternal.Client, error) {
+				return awsInternal.NewTestClient(mockClient), nil
+			})
+
+			err := executor.Execute(context.Background(), &Options{ConfigFile: configPath, Output: config.OutputFormatJSON, Deployment: tt.deployment})
+			if tt.wantErr != "" {
+				var mismatch *awsInternal.ProfileMismatchError
+				if !errors.As(err, &mismatch) || err.Error() != tt.wantErr {
+					t.Fatalf("Execute() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var got targetDiff
+			if err := json.Unmarshal(rep.Stdout, &got); err != nil {
+				t.Fatalf("invalid JSON: %v\n%s", err, rep.Stdout)
+			}
+			if got.Changed != tt.wantChanged || got.FirstDeploy {
+				t.Errorf("report = %+v, want changed=%v", got, tt.wantChanged)
+			}
+		})
+	}
+}
+
+// TestExecutorRegionOverride checks that --region takes precedence over the
+// config file's region when creating the AWS client.
+func TestExecutorRegionOverride(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "apcdeploy.yml")
+	configContent := `application: test-app
+configuration_profile: test-profile
+environment: test-env
+data_file: data.json
+region: us-east-1
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		override string
+		want     string
+	}{
+		{name: "flag overrides config", override: "eu-west-1", want: "eu-west-1"},
+		{name: "config region without flag", override: "", want: "us-east-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got string
+			factory := func(_ context.Context, region string) (*awsInternal.Client, error) {
+				got = region
+				return nil, errors.New("stop")
+			}
+			executor := NewExecutorWithFactory(&reportertest.MockReporter{}, factory)
+			_ = executor.Execute(context.Background(), &Options{ConfigFile: configPath, Region: tt.override})
+			if got != tt.want {
+				t.Errorf("region used = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestExecutorDumpNormalized checks that --dump-normalized writes the exact
+// normalized strings that were compared.
+func TestExecutorDumpNormalized(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "apcdeploy.yml")
+	configContent := "application: test-app\nconfiguration_profile: test-profile\nenvironment: prod\ndata_file: data.json\nregion: us-east-1\n"
+	if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "data.json"), []byte(`{"key":"new"}`), 0o644); err != nil {
+		t.Fatalf("Failed to write data: %v", err)
+	}
+
+	dumpDir := filepath.Join(dir, "dump")
+	rep := &reportertest.MockReporter{}
+	if err := newBulkExecutor(rep).Execute(context.Background(), &Options{ConfigFile: configPath, DumpNormalized: dumpDir}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for name, want := range map[string]string{
+		"remote.normalized.json": "{\n  \"key\": \"old\"\n}",
+		"local.normalized.json":  "{\n  \"key\": \"new\"\n}",
+	} {
+		got, err := os.ReadFile(filepath.Join(dumpDir, name))
+		if err != nil {
+			t.Fatalf("read %s: %v", name, err)
+		}
+		if string(got) != want {
+			t.Errorf("%s = %q, want %q", name, got, want)
+		}
+	}
+	found := false
+	for _, msg := range rep.Messages {
+		if strings.HasPrefix(msg, "info: normalized content written to "+dumpDir) {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected an info message naming the dump directory, got %v", rep.Messages)
+	}
+}
@@ -1,11 +1,63 @@
 package diff
 
+import (
+	"io"
+	"os"
+
+	"github.com/koh-sh/apcdeploy/internal/config"
+)
+
 // Options contains the configuration for diff operation
 type Options struct {
 	// ConfigFile is the path to the apcdeploy configuration file
 	ConfigFile string
+	// TargetsFile is the path to a --profiles-from-file targets list. When
+	// set, ConfigFile is ignored and every listed target is diffed.
+	TargetsFile string
+	// Filter keeps only the TargetsFile entries matching --app and
+	// --profile-regex (nil keeps all)
+	Filter *config.ProfileFilter
+	// Concurrency bounds how many TargetsFile entries are processed at once
+	// (--concurrency; below 1 uses bulk.DefaultConcurrency)
+	Concurrency int
+	// EnvA and EnvB, when both set, switch diff to comparing what is
+	// deployed to the two environments instead of the local data_file
+	EnvA string
+	EnvB string
+	// Output is the stdout format ("text" or "json")
+	Output string
 	// ExitNonzero indicates whether to exit with code 1 if differences exist
 	ExitNonzero bool
+	// ExitCode is the git-style variant of ExitNonzero: it also exits with
+	// code 1 when there is no prior deployment (the whole file is a change)
+	ExitCode bool
+	// FailFast stops a --profiles-from-file run at the first target that
+	// differs, cancelling the rest, and exits with code 1 (--fail-fast)
+	FailFast bool
 	// Silent indicates whether to suppress verbose output
 	Silent bool
+	// DumpNormalized is a directory to write the normalized remote and local
+	// content to, as compared (--dump-normalized; single-target diff only)
+	DumpNormalized string
+	// DiffContext limits the unchanged lines shown around each change
+	// (--diff-context); nil shows the whole file
+	DiffContext *int
+	// DiffStat prints only line counts and changed top-level JSON keys
+	// instead of the diff (--diff-stat)
+	DiffStat bool
+	// Deployment, when non-zero, diffs against the version served by this
+	// deployment number instead of the latest deployment (--deployment)
+	Deployment int32
+	// Region overrides the region from the config file (--region)
+	Region string
+	// Stderr receives the in-progress deployment notice; nil means os.Stderr
+	Stderr io.Writer
+}
+
+// stderr returns the writer for the in-progress deployment notice.
+func (o *Options) stderr() io.Writer {
+	if o.Stderr == nil {
+		return os.Stderr
+	}
+	return o.Stderr
 }
@@ -0,0 +1,100 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/koh-sh/apcdeploy/internal/reporter"
+)
+
+// Stat summarises a diff for --diff-stat: the changed line counts and, for
+// JSON objects, which top-level keys were added, removed or changed.
+type Stat struct {
+	Added   int
+	Removed int
+	// KeysAdded, KeysRemoved and KeysChanged are sorted top-level keys. They
+	// stay empty for non-JSON content or when either side is not an object.
+	KeysAdded   []string
+	KeysRemoved []string
+	KeysChanged []string
+}
+
+// ComputeStat builds the Stat for a calculated diff. Keys are compared on
+// the normalized content, so formatting-only differences never list a key.
+func ComputeStat(result *Result) Stat {
+	var s Stat
+	s.Added, s.Removed = CountChanges(result.UnifiedDiff)
+	if !strings.EqualFold(filepath.Ext(result.FileName), ".json") {
+		return s
+	}
+
+	var remote, local map[string]any
+	if json.Unmarshal([]byte(result.RemoteContent), &remote) != nil || json.Unmarshal([]byte(result.LocalContent), &local) != nil {
+		return s
+	}
+	for key, lv := range local {
+		rv, ok := remote[key]
+		switch {
+		case !ok:
+			s.KeysAdded = append(s.KeysAdded, key)
+		case !reflect.DeepEqual(rv, lv):
+			s.KeysChanged = append(s.KeysChanged, key)
+		}
+	}
+	for key := range remote {
+		if _, ok := local[key]; !ok {
+			s.KeysRemoved = append(s.KeysRemoved, key)
+		}
+	}
+	slices.Sort(s.KeysAdded)
+	slices.Sort(s.KeysRemoved)
+	slices.Sort(s.KeysChanged)
+	return s
+}
+
+// Format renders the Stat as plain text lines headed by label.
+func (s Stat) Format(label string) string {
+	var b strings.Builder
+	total := s.Added + s.Removed
+	fmt.Fprintf(&b, "%s: %d %s changed (+%d -%d)\n", label, total, pluralLines(total), s.Added, s.Removed)
+	for _, group := range []struct {
+		name string
+		keys []string
+	}{
+		{"changed", s.KeysChanged},
+		{"added", s.KeysAdded},
+		{"removed", s.KeysRemoved},
+	} {
+		if len(group.keys) > 0 {
+			fmt.Fprintf(&b, "  top-level keys %s: %s\n", group.name, strings.Join(group.keys, ", "))
+		}
+	}
+	return b.String()
+}
+
+// pluralLines returns "line" or "lines" for n.
+func pluralLines(n int) string {
+	if n == 1 {
+		return "line"
+	}
+	return "lines"
+}
+
+// emitChanges writes a changed result to stdout: the diff rendered with
+// opts.DiffContext lines of context, or only its Stat with opts.DiffStat. header is written
+// first (the "=== <id> ===" line of multi-target output, or empty).
+func emitChanges(r reporter.Reporter, header, label string, result *Result, opts *Options) {
+	if opts.DiffStat {
+		r.Data([]byte(header + ComputeStat(result).Format(label)))
+		return
+	}
+	unified := result.UnifiedDiff
+	if opts.DiffContext != nil {
+		unified = result.Render(*opts.DiffContext)
+	}
+	r.Diff([]byte(header + ensureTrailingNewline(unified)))
+}
@@ -0,0 +1,205 @@
+package diff
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/koh-sh/apcdeploy/internal/config"
+	reportertest "github.com/koh-sh/apcdeploy/internal/reporter/testing"
+)
+
+func TestResultRender(t *testing.T) {
+	t.Parallel()
+
+	result, err := Calculate("a\nb\nc\nd\ne\nf\ng\nh\ni\nk\n", "a\nb\nc\nD\ne\nf\ng\nh\ni\nj\nk\n", "data.txt", "AWS.Freeform", config.TextNormalizeOptions{})
+	if err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		context int
+		want    string
+	}{
+		{
+			name:    "negative keeps the whole file",
+			context: -1,
+			want:    "--- remote\n+++ local\n@@ -1,10 +1,11 @@\n a\n b\n c\n-d\n+D\n e\n f\n g\n h\n i\n+j\n k\n",
+		},
+		{
+			name:    "zero context",
+			context: 0,
+			want:    "--- remote\n+++ local\n@@ -4 +4 @@\n-d\n+D\n@@ -9,0 +10 @@\n+j\n",
+		},
+		{
+			name:    "one line of context",
+			context: 1,
+			want:    "--- remote\n+++ local\n@@ -3,3 +3,3 @@\n c\n-d\n+D\n e\n@@ -9,2 +9,3 @@\n i\n+j\n k\n",
+		},
+		{
+			name:    "overlapping context shares a hunk",
+			context: 3,
+			want:    "--- remote\n+++ local\n@@ -1,10 +1,11 @@\n a\n b\n c\n-d\n+D\n e\n f\n g\n h\n i\n+j\n k\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := result.Render(tt.context); got != tt.want {
+				t.Errorf("Render(%d) = %q, want %q", tt.context, got, tt.want)
+			}
+		})
+	}
+	if result.UnifiedDiff != result.Render(-1) {
+		t.Errorf("UnifiedDiff = %q, want the whole-file rendering", result.UnifiedDiff)
+	}
+}
+
+// TestRenderLargeInput diffs a 200k-line text payload with two edits and
+// checks the rendered diff shrinks to the changes plus their context.
+func TestRenderLargeInput(t *testing.T) {
+	t.Parallel()
+
+	const total = 200000
+	remote := make([]string, total)
+	for i := range remote {
+		remote[i] = fmt.Sprintf("line %d", i)
+	}
+	local := slices.Clone(remote)
+	local[1000] = "edited 1000"
+	local[150000] = "edited 150000"
+
+	result, err := Calculate(strings.Join(remote, "\n")+"\n", strings.Join(local, "\n")+"\n", "data.txt", "AWS.Freeform", config.TextNormalizeOptions{})
+	if err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+
+	got := result.Render(3)
+	lines := strings.Split(strings.TrimSuffix(got, "\n"), "\n")
+	// The ---/+++ header, then two hunks of a @@ line and 3+2+3 lines.
+	if len(lines) != 2+2*9 {
+		t.Fatalf("expected %d lines, got %d:\n%s", 2+2*9, len(lines), got)
+	}
+	for _, want := range []string{"@@ -998,7 +998,7 @@", "-line 1000", "+edited 1000", "@@ -149998,7 +149998,7 @@", "+edited 150000"} {
+		if !slices.Contains(lines, want) {
+			t.Errorf("expected %q in output:\n%s", want, got)
+		}
+	}
+
+	stat := ComputeStat(result)
+	if stat.Added != 2 || stat.Removed != 2 {
+		t.Errorf("ComputeStat() = +%d -%d, want +2 -2", stat.Added, stat.Removed)
+	}
+}
+
+func TestComputeStat(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		remote      string
+		local       string
+		fileName    string
+		wantChanged []string
+		wantAdded   []string
+		wantRemoved []string
+		wantFormat  string
+	}{
+		{
+			name:        "json top-level keys",
+			remote:      `{"a": 1, "b": {"x": true}, "c": "same", "gone": 0}`,
+			local:       `{"a": 2, "b": {"x": false}, "c": "same", "new": [1]}`,
+			fileName:    "data.json",
+			wantChanged: []string{"a", "b"},
+			wantAdded:   []string{"new"},
+			wantRemoved: []string{"gone"},
+			wantFormat:  "data.json: 8 lines changed (+5 -3)\n  top-level keys changed: a, b\n  top-level keys added: new\n  top-level keys removed: gone\n",
+		},
+		{
+			name:       "json array has no keys",
+			remote:     `[1, 2]`,
+			local:      `[1, 3]`,
+			fileName:   "data.json",
+			wantFormat: "data.json: 2 lines changed (+1 -1)\n",
+		},
+		{
+			name:       "text has no keys",
+			remote:     "a\nb\n",
+			local:      "a\nc\nd\n",
+			fileName:   "data.txt",
+			wantFormat: "data.txt: 3 lines changed (+2 -1)\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := Calculate(tt.remote, tt.local, tt.fileName, "AWS.Freeform", config.TextNormalizeOptions{})
+			if err != nil {
+				t.Fatalf("Calculate() error = %v", err)
+			}
+			stat := ComputeStat(result)
+			if !slices.Equal(stat.KeysChanged, tt.wantChanged) || !slices.Equal(stat.KeysAdded, tt.wantAdded) || !slices.Equal(stat.KeysRemoved, tt.wantRemoved) {
+				t.Errorf("ComputeStat() keys = changed %v added %v removed %v, want %v %v %v",
+					stat.KeysChanged, stat.KeysAdded, stat.KeysRemoved, tt.wantChanged, tt.wantAdded, tt.wantRemoved)
+			}
+			if got := stat.Format(tt.fileName); got != tt.wantFormat {
+				t.Errorf("Format() = %q, want %q", got, tt.wantFormat)
+			}
+		})
+	}
+}
+
+func TestEmitChanges(t *testing.T) {
+	t.Parallel()
+
+	result, err := Calculate("{\"a\": 1}", "{\"a\": 2}", "data.json", "AWS.Freeform", config.TextNormalizeOptions{})
+	if err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+	zero := 0
+
+	tests := []struct {
+		name       string
+		opts       *Options
+		wantStdout string
+		stat       bool
+	}{
+		{
+			name:       "full diff",
+			opts:       &Options{},
+			wantStdout: "--- remote\n+++ local\n@@ -1,3 +1,3 @@\n {\n-  \"a\": 1\n+  \"a\": 2\n }\n",
+		},
+		{
+			name:       "limited context",
+			opts:       &Options{DiffContext: &zero},
+			wantStdout: "--- remote\n+++ local\n@@ -2 +2 @@\n-  \"a\": 1\n+  \"a\": 2\n",
+		},
+		{
+			name:       "stat only",
+			opts:       &Options{DiffStat: true},
+			wantStdout: "data.json: 2 lines changed (+1 -1)\n  top-level keys changed: a\n",
+			stat:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := &reportertest.MockReporter{}
+			emitChanges(r, "", result.FileName, result, tt.opts)
+			if got := string(r.Stdout); got != tt.wantStdout {
+				t.Errorf("stdout = %q, want %q", got, tt.wantStdout)
+			}
+			// The stat is plain data; only a real diff goes through Diff.
+			if got := r.HasMessage("diff: "); got == tt.stat {
+				t.Errorf("emitted through Diff = %v, want %v", got, !tt.stat)
+			}
+		})
+	}
+}
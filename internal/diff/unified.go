@@ -0,0 +1,140 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// DefaultContext is the number of unchanged lines kept around each change,
+// as in diff -u.
+const DefaultContext = 3
+
+// Unified renders a standard unified diff from remote to local: a
+// "--- remote" / "+++ local" header followed by @@ hunks with context
+// unchanged lines around each change; a negative context keeps the whole
+// file in one hunk. It is the only diff renderer, and takes its arguments in
+// the same order as Calculate. Both sides are expected to be normalized
+// already (see config.NormalizeByExtension) so formatting-only differences
+// never show up. It returns "" when the contents are equal. An empty remote
+// renders every local line as an addition, as for a first deployment.
+func Unified(remote, local string, context int) string {
+	return unifiedLabeled("remote", "local", remote, local, context)
+}
+
+// unifiedLabeled is Unified with the names of the two sides for the
+// ---/+++ header.
+func unifiedLabeled(remoteLabel, localLabel, remote, local string, context int) string {
+	ops := lineOps(remote, local)
+	if context < 0 {
+		context = len(ops)
+	}
+
+	// oldLines[i] and newLines[i] count the remote and local lines before
+	// ops[i], giving each hunk its starting line numbers.
+	oldLines := make([]int, len(ops)+1)
+	newLines := make([]int, len(ops)+1)
+	for i, op := range ops {
+		oldLines[i+1], newLines[i+1] = oldLines[i], newLines[i]
+		if op.kind != '+' {
+			oldLines[i+1]++
+		}
+		if op.kind != '-' {
+			newLines[i+1]++
+		}
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(ops); {
+		for i < len(ops) && ops[i].kind == ' ' {
+			i++
+		}
+		if i == len(ops) {
+			break
+		}
+		start := max(i-context, 0)
+		end := i
+		for {
+			for end < len(ops) && ops[end].kind != ' ' {
+				end++
+			}
+			next := end
+			for next < len(ops) && ops[next].kind == ' ' {
+				next++
+			}
+			// Changes separated by at most two contexts' worth of
+			// unchanged lines share a hunk.
+			if next < len(ops) && next-end <= 2*context {
+				end = next
+				continue
+			}
+			end = min(end+context, len(ops))
+			break
+		}
+
+		if b.Len() == 0 {
+			fmt.Fprintf(&b, "--- %s\n+++ %s\n", remoteLabel, localLabel)
+		}
+		fmt.Fprintf(&b, "@@ -%s +%s @@\n",
+			hunkRange(oldLines[start], oldLines[end]-oldLines[start]),
+			hunkRange(newLines[start], newLines[end]-newLines[start]))
+		for _, op := range ops[start:end] {
+			b.WriteByte(op.kind)
+			b.WriteString(op.text)
+			b.WriteByte('\n')
+		}
+		i = end
+	}
+	return b.String()
+}
+
+// hunkRange renders one side of a hunk header. before is the number of lines
+// preceding the hunk; an empty side points at the line before it.
+func hunkRange(before, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", before)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", before+1)
+	}
+	return fmt.Sprintf("%d,%d", before+1, count)
+}
+
+// lineOp is one line of an edit script: kind is ' ' (unchanged), '-'
+// (remote only) or '+' (local only).
+type lineOp struct {
+	kind byte
+	text string
+}
+
+// lineOps computes the line-level edit script from remote to local.
+func lineOps(remote, local string) []lineOp {
+	dmp := diffmatchpatch.New()
+	a, b, lines := dmp.DiffLinesToChars(withTrailingNewline(remote), withTrailingNewline(local))
+	diffs := dmp.DiffCharsToLines(dmp.DiffMain(a, b, false), lines)
+
+	var ops []lineOp
+	for _, d := range diffs {
+		kind := byte(' ')
+		switch d.Type {
+		case diffmatchpatch.DiffInsert:
+			kind = '+'
+		case diffmatchpatch.DiffDelete:
+			kind = '-'
+		}
+		for line := range strings.SplitSeq(strings.TrimSuffix(d.Text, "\n"), "\n") {
+			ops = append(ops, lineOp{kind: kind, text: line})
+		}
+	}
+	return ops
+}
+
+// withTrailingNewline terminates the last line so a missing final newline
+// on one side does not turn the last line into a change.
+func withTrailingNewline(s string) string {
+	if s == "" || strings.HasSuffix(s, "\n") {
+		return s
+	}
+	return s + "\n"
+}
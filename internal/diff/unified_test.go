@@ -0,0 +1,82 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnified(t *testing.T) {
+	t.Parallel()
+
+	lines := func(n int, change map[int]string) string {
+		var b strings.Builder
+		for i := 1; i <= n; i++ {
+			if s, ok := change[i]; ok {
+				b.WriteString(s + "\n")
+				continue
+			}
+			b.WriteString("l" + string(rune('a'+i-1)) + "\n")
+		}
+		return b.String()
+	}
+
+	tests := []struct {
+		name   string
+		local  string
+		remote string
+		want   string
+	}{
+		{
+			name:   "equal contents render nothing",
+			local:  "a\nb\n",
+			remote: "a\nb\n",
+			want:   "",
+		},
+		{
+			name:   "missing trailing newline is not a change",
+			local:  "a\nb",
+			remote: "a\nb\n",
+			want:   "",
+		},
+		{
+			name:   "single change with context",
+			local:  "a\nB\nc\n",
+			remote: "a\nb\nc\n",
+			want:   "--- remote\n+++ local\n@@ -1,3 +1,3 @@\n a\n-b\n+B\n c\n",
+		},
+		{
+			name:  "empty remote adds every line",
+			local: "a\nb\n",
+			want:  "--- remote\n+++ local\n@@ -0,0 +1,2 @@\n+a\n+b\n",
+		},
+		{
+			name:   "distant changes get separate hunks",
+			local:  lines(12, map[int]string{1: "X", 12: "Y"}),
+			remote: lines(12, nil),
+			want: "--- remote\n+++ local\n" +
+				"@@ -1,4 +1,4 @@\n-la\n+X\n lb\n lc\n ld\n" +
+				"@@ -9,4 +9,4 @@\n li\n lj\n lk\n-ll\n+Y\n",
+		},
+		{
+			name:   "nearby changes share a hunk",
+			local:  lines(8, map[int]string{2: "X", 7: "Y"}),
+			remote: lines(8, nil),
+			want:   "--- remote\n+++ local\n@@ -1,8 +1,8 @@\n la\n-lb\n+X\n lc\n ld\n le\n lf\n-lg\n+Y\n lh\n",
+		},
+		{
+			name:   "removed line",
+			local:  "a\nc\n",
+			remote: "a\nb\nc\n",
+			want:   "--- remote\n+++ local\n@@ -1,3 +1,2 @@\n a\n-b\n c\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := Unified(tt.remote, tt.local, DefaultContext); got != tt.want {
+				t.Errorf("Unified() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -40,6 +40,12 @@ func DeploymentStatus(r reporter.Reporter, deployment *aws.DeploymentDetails, cf
 	if deployment.State != types.DeploymentStateRolledBack && deployment.Description != "" {
 		rows = append(rows, []string{"Description", deployment.Description})
 	}
+	if deployment.Label != "" {
+		rows = append(rows, []string{"Label", deployment.Label})
+	}
+	if len(deployment.VersionTags) > 0 {
+		rows = append(rows, []string{"Version Tags", aws.FormatVersionTags(deployment.VersionTags, ", ")})
+	}
 	if deployment.DeploymentStrategyName != "" {
 		rows = append(rows, []string{"Strategy", deployment.DeploymentStrategyName})
 	}
@@ -52,6 +58,9 @@ func DeploymentStatus(r reporter.Reporter, deployment *aws.DeploymentDetails, cf
 			duration := deployment.CompletedAt.Sub(*deployment.StartedAt)
 			rows = append(rows, []string{"Duration", formatDuration(duration)})
 		}
+		if deployment.State == types.DeploymentStateComplete {
+			rows = append(rows, []string{"Age", FormatAge(time.Since(*deployment.CompletedAt))})
+		}
 	}
 	r.Table([]string{"Field", "Value"}, rows)
 
@@ -88,6 +97,27 @@ func DeploymentStatus(r reporter.Reporter, deployment *aws.DeploymentDetails, cf
 	}
 }
 
+// DeploymentHistory renders status --history: one row per deployment, in
+// the order given.
+func DeploymentHistory(r reporter.Reporter, deployments []*aws.DeploymentDetails) {
+	rows := make([][]string, 0, len(deployments))
+	for _, d := range deployments {
+		started := ""
+		if d.StartedAt != nil {
+			started = formatTime(*d.StartedAt)
+		}
+		rows = append(rows, []string{
+			strconv.Itoa(int(d.DeploymentNumber)),
+			cli.StateBadge(string(d.State)),
+			d.ConfigurationVersion,
+			d.DeploymentStrategyName,
+			started,
+			fmt.Sprintf("%.0f%%", d.PercentageComplete),
+		})
+	}
+	r.Table([]string{"Deployment", "State", "Version", "Strategy", "Started", "Progress"}, rows)
+}
+
 // formatTime formats a time.Time for display.
 func formatTime(t time.Time) string {
 	return t.Local().Format("2006-01-02 15:04:05 MST")
@@ -111,6 +141,21 @@ func formatDuration(d time.Duration) string {
 	return fmt.Sprintf("%ds", s)
 }
 
+// FormatAge renders how long ago a deployment completed, in days and hours
+// once it is at least a day old ("120d 4h", "90d") and as formatDuration
+// below that.
+func FormatAge(d time.Duration) string {
+	if d < 24*time.Hour {
+		return formatDuration(max(d, 0))
+	}
+	days := d / (24 * time.Hour)
+	hours := (d - days*24*time.Hour) / time.Hour
+	if hours == 0 {
+		return fmt.Sprintf("%dd", days)
+	}
+	return fmt.Sprintf("%dd %dh", days, hours)
+}
+
 // formatCurrentPhase determines the current phase of deployment.
 func formatCurrentPhase(deployment *aws.DeploymentDetails) string {
 	if deployment.State == types.DeploymentStateBaking {
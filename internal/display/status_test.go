@@ -208,6 +208,30 @@ func TestFormatDuration(t *testing.T) {
 	}
 }
 
+func TestFormatAge(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		age  time.Duration
+		want string
+	}{
+		{"under a day", 3*time.Hour + 2*time.Minute, "3h 2m 0s"},
+		{"days and hours", 120*24*time.Hour + 4*time.Hour + 30*time.Minute, "120d 4h"},
+		{"whole days", 24 * time.Hour, "1d"},
+		{"clock skew", -time.Minute, "0s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := FormatAge(tt.age); got != tt.want {
+				t.Errorf("FormatAge() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestFormatCurrentPhase(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,145 @@
+package doctor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	awsInternal "github.com/koh-sh/apcdeploy/internal/aws"
+	"github.com/koh-sh/apcdeploy/internal/config"
+	"github.com/koh-sh/apcdeploy/internal/reporter"
+)
+
+// ErrMissingPermissions is returned when at least one probed action is
+// denied.
+var ErrMissingPermissions = errors.New("missing IAM permissions")
+
+// Results of a single action check.
+const (
+	ResultAllowed    = "allowed"
+	ResultDenied     = "DENIED"
+	ResultNotChecked = "not checked"
+	ResultError      = "error"
+)
+
+// ClientFactory is a function type that creates an AWS client for a given region
+type ClientFactory func(ctx context.Context, region string) (*awsInternal.Client, error)
+
+// Executor handles the permission check orchestration
+type Executor struct {
+	reporter      reporter.Reporter
+	clientFactory ClientFactory
+}
+
+// NewExecutor creates a new doctor executor
+func NewExecutor(rep reporter.Reporter) *Executor {
+	return &Executor{
+		reporter:      rep,
+		clientFactory: awsInternal.NewClient,
+	}
+}
+
+// NewExecutorWithFactory creates a new doctor executor with a custom client factory
+// This is useful for testing with mock clients
+func NewExecutorWithFactory(rep reporter.Reporter, factory ClientFactory) *Executor {
+	return &Executor{
+		reporter:      rep,
+		clientFactory: factory,
+	}
+}
+
+// Check is the outcome for one IAM action.
+type Check struct {
+	Action string
+	Result string
+	Detail string
+}
+
+// Execute checks the IAM actions opts.For needs against the resources in
+// the config file. Read actions are exercised with the smallest call that
+// needs them; write actions are listed as not checked since they cannot be
+// tried without side effects.
+//
+// Output shape: a spinner while probing, then a Table of action / result /
+// detail rows. Denied actions make Execute return ErrMissingPermissions
+// naming them; other probe failures (e.g. an application name that does not
+// exist) return an error pointing at the table.
+func (e *Executor) Execute(ctx context.Context, opts *Options) error {
+	actions, ok := RequiredActions(opts.For)
+	if !ok {
+		return fmt.Errorf("unknown operation %q for --for (valid: %s)", opts.For, strings.Join(Operations(), ", "))
+	}
+
+	cfg, err := config.LoadConfig(opts.ConfigFile)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	cfg.ApplyRegionOverride(opts.Region)
+
+	client, err := e.clientFactory(ctx, cfg.Region)
+	if err != nil {
+		return fmt.Errorf("failed to initialize AWS client: %w", err)
+	}
+
+	sp := e.reporter.Spin(fmt.Sprintf("Checking IAM permissions for %s (%s)...", opts.For, config.Identifier(client.Region, cfg)))
+	checks := runChecks(ctx, &probeState{client: client, cfg: cfg}, actions)
+	sp.Stop()
+
+	rows := make([][]string, 0, len(checks))
+	var denied, failed, unchecked []string
+	for _, c := range checks {
+		rows = append(rows, []string{c.Action, c.Result, c.Detail})
+		switch c.Result {
+		case ResultDenied:
+			denied = append(denied, c.Action)
+		case ResultError:
+			failed = append(failed, c.Action)
+		case ResultNotChecked:
+			unchecked = append(unchecked, c.Action)
+		}
+	}
+	e.reporter.Table([]string{"Action", "Result", "Detail"}, rows)
+
+	if len(denied) > 0 {
+		return fmt.Errorf("%w for %s: %s", ErrMissingPermissions, opts.For, strings.Join(denied, ", "))
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("could not check %s for %s; see the Detail column", strings.Join(failed, ", "), opts.For)
+	}
+	if len(unchecked) > 0 {
+		e.reporter.Success(fmt.Sprintf("No denied actions for %s (%d not checked)", opts.For, len(unchecked)))
+		return nil
+	}
+	e.reporter.Success(fmt.Sprintf("All %d actions for %s are allowed", len(checks), opts.For))
+	return nil
+}
+
+// runChecks probes actions in order, threading the found IDs through s.
+func runChecks(ctx context.Context, s *probeState, actions []string) []Check {
+	checks := make([]Check, 0, len(actions))
+	for _, action := range actions {
+		p, ok := probes[action]
+		if !ok {
+			checks = append(checks, Check{Action: action, Result: ResultNotChecked, Detail: "write action; not exercised"})
+			continue
+		}
+		checks = append(checks, classify(action, p(ctx, s)))
+	}
+	return checks
+}
+
+// classify turns a probe error into a Check.
+func classify(action string, err error) Check {
+	var notChecked errNotChecked
+	switch {
+	case err == nil:
+		return Check{Action: action, Result: ResultAllowed}
+	case errors.As(err, &notChecked):
+		return Check{Action: action, Result: ResultNotChecked, Detail: notChecked.Error()}
+	case awsInternal.IsAccessDenied(err):
+		return Check{Action: action, Result: ResultDenied, Detail: "access denied"}
+	default:
+		return Check{Action: action, Result: ResultError, Detail: err.Error()}
+	}
+}
@@ -0,0 +1,255 @@
+package doctor
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig/types"
+	"github.com/aws/aws-sdk-go-v2/service/appconfigdata"
+	"github.com/aws/smithy-go"
+	awsInternal "github.com/koh-sh/apcdeploy/internal/aws"
+	"github.com/koh-sh/apcdeploy/internal/aws/mock"
+	reportertest "github.com/koh-sh/apcdeploy/internal/reporter/testing"
+)
+
+func createTestConfig(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "apcdeploy.yml")
+	content := `application: test-app
+configuration_profile: test-profile
+environment: test-env
+deployment_strategy: AppConfig.AllAtOnce
+data_file: data.json
+region: us-east-1
+`
+	if err := os.WriteFile(configPath, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "data.json"), []byte(`{}`), 0o644); err != nil {
+		t.Fatalf("Failed to write data: %v", err)
+	}
+	return configPath
+}
+
+var errDenied = &smithy.GenericAPIError{Code: "AccessDeniedException", Message: "not authorized"}
+
+// newMockFactory serves one application, profile, environment and
+// deployment; every action in deny fails with AccessDeniedException.
+func newMockFactory(deny ...string) ClientFactory {
+	denied := func(action string) error {
+		for _, d := range deny {
+			if d == action {
+				return errDenied
+			}
+		}
+		return nil
+	}
+	appConfig := &mock.MockAppConfigClient{
+		ListApplicationsFunc: func(ctx context.Context, params *appconfig.ListApplicationsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListApplicationsOutput, error) {
+			if err := denied(actionListApplications); err != nil {
+				return nil, err
+			}
+			return &appconfig.ListApplicationsOutput{Items: []types.Application{{Id: aws.String("app-1"), Name: aws.String("test-app")}}}, nil
+		},
+		ListConfigurationProfilesFunc: func(ctx context.Context, params *appconfig.ListConfigurationProfilesInput, optFns ...func(*appconfig.Options)) (*appconfig.ListConfigurationProfilesOutput, error) {
+			if err := denied(actionListConfigurationProfiles); err != nil {
+				return nil, err
+			}
+			return &appconfig.ListConfigurationProfilesOutput{Items: []types.ConfigurationProfileSummary{{Id: aws.String("prof-1"), Name: aws.String("test-profile")}}}, nil
+		},
+		GetConfigurationProfileFunc: func(ctx context.Context, params *appconfig.GetConfigurationProfileInput, optFns ...func(*appconfig.Options)) (*appconfig.GetConfigurationProfileOutput, error) {
+			if err := denied(actionGetConfigurationProfile); err != nil {
+				return nil, err
+			}
+			return &appconfig.GetConfigurationProfileOutput{}, nil
+		},
+		ListEnvironmentsFunc: func(ctx context.Context, params *appconfig.ListEnvironmentsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListEnvironmentsOutput, error) {
+			if err := denied(actionListEnvironments); err != nil {
+				return nil, err
+			}
+			return &appconfig.ListEnvironmentsOutput{Items: []types.Environment{{Id: aws.String("env-1"), Name: aws.String("test-env")}}}, nil
+		},
+		ListDeploymentStrategiesFunc: func(ctx context.Context, params *appconfig.ListDeploymentStrategiesInput, optFns ...func(*appconfig.Options)) (*appconfig.ListDeploymentStrategiesOutput, error) {
+			if err := denied(actionListDeploymentStrategies); err != nil {
+				return nil, err
+			}
+			return &appconfig.ListDeploymentStrategiesOutput{}, nil
+		},
+		ListDeploymentsFunc: func(ctx context.Context, params *appconfig.ListDeploymentsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListDeploymentsOutput, error) {
+			if err := denied(actionListDeployments); err != nil {
+				return nil, err
+			}
+			return &appconfig.ListDeploymentsOutput{Items: []types.DeploymentSummary{{DeploymentNumber: 3}}}, nil
+		},
+		GetDeploymentFunc: func(ctx context.Context, params *appconfig.GetDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.GetDeploymentOutput, error) {
+			if err := denied(actionGetDeployment); err != nil {
+				return nil, err
+			}
+			return &appconfig.GetDeploymentOutput{ConfigurationProfileId: aws.String("prof-1"), ConfigurationVersion: aws.String("2")}, nil
+		},
+		ListHostedConfigurationVersionsFunc: func(ctx context.Context, params *appconfig.ListHostedConfigurationVersionsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListHostedConfigurationVersionsOutput, error) {
+			if err := denied(actionListHostedConfigurationVersions); err != nil {
+				return nil, err
+			}
+			return &appconfig.ListHostedConfigurationVersionsOutput{Items: []types.HostedConfigurationVersionSummary{{VersionNumber: 2}}}, nil
+		},
+		GetHostedConfigurationVersionFunc: func(ctx context.Context, params *appconfig.GetHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.GetHostedConfigurationVersionOutput, error) {
+			if err := denied(actionGetHostedConfigurationVersion); err != nil {
+				return nil, err
+			}
+			return &appconfig.GetHostedConfigurationVersionOutput{}, nil
+		},
+	}
+	appConfigData := &mock.MockAppConfigDataClient{
+		StartConfigurationSessionFunc: func(ctx context.Context, params *appconfigdata.StartConfigurationSessionInput, optFns ...func(*appconfigdata.Options)) (*appconfigdata.StartConfigurationSessionOutput, error) {
+			if err := denied(actionStartConfigurationSession); err != nil {
+				return nil, err
+			}
+			return &appconfigdata.StartConfigurationSessionOutput{InitialConfigurationToken: aws.String("token")}, nil
+		},
+		GetLatestConfigurationFunc: func(ctx context.Context, params *appconfigdata.GetLatestConfigurationInput, optFns ...func(*appconfigdata.Options)) (*appconfigdata.GetLatestConfigurationOutput, error) {
+			if err := denied(actionGetLatestConfiguration); err != nil {
+				return nil, err
+			}
+			return &appconfigdata.GetLatestConfigurationOutput{}, nil
+		},
+	}
+	return func(ctx context.Context, region string) (*awsInternal.Client, error) {
+		return awsInternal.NewTestClientWithData(appConfig, appConfigData), nil
+	}
+}
+
+func TestExecute(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		operation   string
+		deny        []string
+		wantResults map[string]string
+		wantErr     string
+	}{
+		{
+			name:      "status fully allowed",
+			operation: "status",
+			wantResults: map[string]string{
+				actionListApplications: ResultAllowed,
+				actionGetDeployment:    ResultAllowed,
+			},
+		},
+		{
+			name:      "deploy leaves writes unchecked",
+			operation: "deploy",
+			wantResults: map[string]string{
+				actionGetHostedConfigurationVersion:    ResultAllowed,
+				actionCreateHostedConfigurationVersion: ResultNotChecked,
+				actionStartDeployment:                  ResultNotChecked,
+			},
+		},
+		{
+			name:      "denied action is named and dependents are skipped",
+			operation: "status",
+			deny:      []string{actionListDeployments},
+			wantResults: map[string]string{
+				actionListEnvironments: ResultAllowed,
+				actionListDeployments:  ResultDenied,
+				actionGetDeployment:    ResultNotChecked,
+			},
+			wantErr: "missing IAM permissions for status: appconfig:ListDeployments",
+		},
+		{
+			name:      "get probes the data plane",
+			operation: "get",
+			deny:      []string{actionGetLatestConfiguration},
+			wantResults: map[string]string{
+				actionStartConfigurationSession: ResultAllowed,
+				actionGetLatestConfiguration:    ResultDenied,
+			},
+			wantErr: "appconfig:GetLatestConfiguration",
+		},
+		{
+			name:      "pull falls back to the latest hosted version",
+			operation: "pull",
+			deny:      []string{actionGetDeployment},
+			wantResults: map[string]string{
+				actionGetDeployment:                   ResultDenied,
+				actionListHostedConfigurationVersions: ResultAllowed,
+				actionGetHostedConfigurationVersion:   ResultAllowed,
+			},
+			wantErr: "appconfig:GetDeployment",
+		},
+		{
+			name:      "unknown operation",
+			operation: "apply",
+			wantErr:   `unknown operation "apply"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			reporter := &reportertest.MockReporter{}
+			executor := NewExecutorWithFactory(reporter, newMockFactory(tt.deny...))
+			err := executor.Execute(context.Background(), &Options{ConfigFile: createTestConfig(t), For: tt.operation})
+
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got: %v", tt.wantErr, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(tt.wantResults) == 0 {
+				return
+			}
+
+			if len(reporter.Tables) != 1 {
+				t.Fatalf("expected one table, got %d", len(reporter.Tables))
+			}
+			got := make(map[string]string)
+			for _, row := range reporter.Tables[0].Rows {
+				got[row[0]] = row[1]
+			}
+			for action, want := range tt.wantResults {
+				if got[action] != want {
+					t.Errorf("%s = %q, want %q", action, got[action], want)
+				}
+			}
+		})
+	}
+}
+
+func TestExecuteMissingApplication(t *testing.T) {
+	t.Parallel()
+
+	factory := func(ctx context.Context, region string) (*awsInternal.Client, error) {
+		return awsInternal.NewTestClient(&mock.MockAppConfigClient{
+			ListApplicationsFunc: func(ctx context.Context, params *appconfig.ListApplicationsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListApplicationsOutput, error) {
+				return &appconfig.ListApplicationsOutput{}, nil
+			},
+		}), nil
+	}
+
+	reporter := &reportertest.MockReporter{}
+	err := NewExecutorWithFactory(reporter, factory).Execute(context.Background(), &Options{ConfigFile: createTestConfig(t), For: "get"})
+	if err == nil || errors.Is(err, ErrMissingPermissions) {
+		t.Fatalf("expected a non-permission error, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), actionListApplications) {
+		t.Errorf("error should name the failed probe, got: %v", err)
+	}
+	for _, row := range reporter.Tables[0].Rows[1:] {
+		if row[1] != ResultNotChecked {
+			t.Errorf("%s = %q, want %q after the application lookup failed", row[0], row[1], ResultNotChecked)
+		}
+	}
+}
@@ -0,0 +1,10 @@
+package doctor
+
+// Options contains the configuration options for the doctor command
+type Options struct {
+	ConfigFile string
+	// Region overrides the region from the config file (--region)
+	Region string
+	// For is the subcommand whose IAM permissions are checked (--for)
+	For string
+}
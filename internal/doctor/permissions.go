@@ -0,0 +1,100 @@
+package doctor
+
+import (
+	"maps"
+	"slices"
+)
+
+// IAM actions used by apcdeploy, in the order they are probed. Later probes
+// use the IDs found by earlier ones, so the order matters.
+const (
+	actionListApplications                 = "appconfig:ListApplications"
+	actionListConfigurationProfiles        = "appconfig:ListConfigurationProfiles"
+	actionGetConfigurationProfile          = "appconfig:GetConfigurationProfile"
+	actionListEnvironments                 = "appconfig:ListEnvironments"
+	actionListDeploymentStrategies         = "appconfig:ListDeploymentStrategies"
+	actionListDeployments                  = "appconfig:ListDeployments"
+	actionGetDeployment                    = "appconfig:GetDeployment"
+	actionListHostedConfigurationVersions  = "appconfig:ListHostedConfigurationVersions"
+	actionGetHostedConfigurationVersion    = "appconfig:GetHostedConfigurationVersion"
+	actionStartConfigurationSession        = "appconfig:StartConfigurationSession"
+	actionGetLatestConfiguration           = "appconfig:GetLatestConfiguration"
+	actionCreateHostedConfigurationVersion = "appconfig:CreateHostedConfigurationVersion"
+	actionStartDeployment                  = "appconfig:StartDeployment"
+	actionStopDeployment                   = "appconfig:StopDeployment"
+)
+
+// resolveActions are needed by every operation to turn the names in
+// apcdeploy.yml into resource IDs.
+var resolveActions = []string{
+	actionListApplications,
+	actionListConfigurationProfiles,
+	actionGetConfigurationProfile,
+	actionListEnvironments,
+}
+
+// operationActions maps each subcommand accepted by --for to the IAM
+// actions it calls. Write actions are listed too; they are reported but
+// never exercised.
+var operationActions = map[string][]string{
+	"deploy": append(slices.Clone(resolveActions),
+		actionListDeploymentStrategies,
+		actionListDeployments,
+		actionGetDeployment,
+		actionGetHostedConfigurationVersion,
+		actionCreateHostedConfigurationVersion,
+		actionStartDeployment,
+	),
+	"diff": append(slices.Clone(resolveActions),
+		actionListDeploymentStrategies,
+		actionListDeployments,
+		actionGetDeployment,
+		actionGetHostedConfigurationVersion,
+	),
+	"get": append(slices.Clone(resolveActions),
+		actionStartConfigurationSession,
+		actionGetLatestConfiguration,
+	),
+	"pull": append(slices.Clone(resolveActions),
+		actionListDeployments,
+		actionGetDeployment,
+		actionListHostedConfigurationVersions,
+		actionGetHostedConfigurationVersion,
+	),
+	"rollback": append(slices.Clone(resolveActions),
+		actionListDeploymentStrategies,
+		actionListDeployments,
+		actionGetDeployment,
+		actionStartDeployment,
+		actionStopDeployment,
+	),
+	"status": append(slices.Clone(resolveActions),
+		actionListDeploymentStrategies,
+		actionListDeployments,
+		actionGetDeployment,
+	),
+}
+
+// operationAliases lets --for take the subcommand name for operations
+// whose action set is shared.
+var operationAliases = map[string]string{
+	"run": "deploy",
+}
+
+// Operations returns the values accepted by --for, sorted.
+func Operations() []string {
+	ops := slices.Collect(maps.Keys(operationActions))
+	ops = slices.AppendSeq(ops, maps.Keys(operationAliases))
+	slices.Sort(ops)
+	return ops
+}
+
+// RequiredActions returns the IAM actions the operation needs, in probe
+// order, and false when the operation is unknown.
+func RequiredActions(operation string) ([]string, bool) {
+	if alias, ok := operationAliases[operation]; ok {
+		operation = alias
+	}
+	actions, ok := operationActions[operation]
+	return actions, ok
+}
@@ -0,0 +1,88 @@
+package doctor
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRequiredActions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		operation string
+		wantOK    bool
+		contains  []string
+		excludes  []string
+	}{
+		{
+			operation: "deploy",
+			wantOK:    true,
+			contains:  []string{actionListApplications, actionCreateHostedConfigurationVersion, actionStartDeployment},
+			excludes:  []string{actionStartConfigurationSession},
+		},
+		{
+			operation: "run",
+			wantOK:    true,
+			contains:  []string{actionStartDeployment},
+		},
+		{
+			operation: "status",
+			wantOK:    true,
+			contains:  []string{actionListDeployments, actionGetDeployment},
+			excludes:  []string{actionStartDeployment, actionGetHostedConfigurationVersion},
+		},
+		{
+			operation: "get",
+			wantOK:    true,
+			contains:  []string{actionStartConfigurationSession, actionGetLatestConfiguration},
+		},
+		{
+			operation: "unknown",
+			wantOK:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.operation, func(t *testing.T) {
+			t.Parallel()
+
+			actions, ok := RequiredActions(tt.operation)
+			if ok != tt.wantOK {
+				t.Fatalf("RequiredActions(%q) ok = %v, want %v", tt.operation, ok, tt.wantOK)
+			}
+			for _, a := range tt.contains {
+				if !slices.Contains(actions, a) {
+					t.Errorf("RequiredActions(%q) missing %s", tt.operation, a)
+				}
+			}
+			for _, a := range tt.excludes {
+				if slices.Contains(actions, a) {
+					t.Errorf("RequiredActions(%q) should not include %s", tt.operation, a)
+				}
+			}
+		})
+	}
+}
+
+// TestOperationsHaveProbeOrder checks every action set starts with the
+// resolve lookups the later probes depend on, and that every action is
+// either probed or deliberately left as a write.
+func TestOperationsHaveProbeOrder(t *testing.T) {
+	t.Parallel()
+
+	writes := []string{actionCreateHostedConfigurationVersion, actionStartDeployment, actionStopDeployment}
+	for _, op := range Operations() {
+		actions, ok := RequiredActions(op)
+		if !ok {
+			t.Fatalf("Operations() lists %q but RequiredActions does not know it", op)
+		}
+		if !slices.Equal(actions[:len(resolveActions)], resolveActions) {
+			t.Errorf("%s: actions must start with the resolve lookups, got %v", op, actions)
+		}
+		for _, a := range actions {
+			if _, probed := probes[a]; !probed && !slices.Contains(writes, a) {
+				t.Errorf("%s: read action %s has no probe", op, a)
+			}
+		}
+	}
+}
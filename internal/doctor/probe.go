@@ -0,0 +1,181 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig"
+	"github.com/aws/aws-sdk-go-v2/service/appconfigdata"
+	awsInternal "github.com/koh-sh/apcdeploy/internal/aws"
+	"github.com/koh-sh/apcdeploy/internal/config"
+)
+
+// probeState carries the IDs found by earlier probes to the later ones.
+type probeState struct {
+	client *awsInternal.Client
+	cfg    *config.Config
+
+	appID            string
+	profileID        string
+	envID            string
+	deploymentNumber int32
+	version          string
+	sessionToken     *string
+}
+
+// errNotChecked marks a probe that could not run, e.g. because an earlier
+// probe it depends on failed or there is no deployment to read yet.
+type errNotChecked string
+
+func (e errNotChecked) Error() string { return string(e) }
+
+// probe issues the minimal read call for one action.
+type probe func(ctx context.Context, s *probeState) error
+
+// probes maps each read action to its probe. Write actions have no entry:
+// they cannot be exercised without changing AppConfig.
+var probes = map[string]probe{
+	actionListApplications: func(ctx context.Context, s *probeState) error {
+		apps, err := s.client.ListAllApplications(ctx)
+		if err != nil {
+			return err
+		}
+		for _, app := range apps {
+			if aws.ToString(app.Name) == s.cfg.Application {
+				s.appID = aws.ToString(app.Id)
+				return nil
+			}
+		}
+		return fmt.Errorf("application %q not found", s.cfg.Application)
+	},
+	actionListConfigurationProfiles: func(ctx context.Context, s *probeState) error {
+		if s.appID == "" {
+			return errNotChecked("needs " + actionListApplications)
+		}
+		profiles, err := s.client.ListAllConfigurationProfiles(ctx, s.appID)
+		if err != nil {
+			return err
+		}
+		for _, p := range profiles {
+			if aws.ToString(p.Name) == s.cfg.ConfigurationProfile {
+				s.profileID = aws.ToString(p.Id)
+				return nil
+			}
+		}
+		return fmt.Errorf("configuration profile %q not found", s.cfg.ConfigurationProfile)
+	},
+	actionGetConfigurationProfile: func(ctx context.Context, s *probeState) error {
+		if s.profileID == "" {
+			return errNotChecked("needs " + actionListConfigurationProfiles)
+		}
+		_, err := s.client.GetConfigurationProfile(ctx, &appconfig.GetConfigurationProfileInput{
+			ApplicationId:          aws.String(s.appID),
+			ConfigurationProfileId: aws.String(s.profileID),
+		})
+		return err
+	},
+	actionListEnvironments: func(ctx context.Context, s *probeState) error {
+		if s.appID == "" {
+			return errNotChecked("needs " + actionListApplications)
+		}
+		envs, err := s.client.ListAllEnvironments(ctx, s.appID)
+		if err != nil {
+			return err
+		}
+		for _, env := range envs {
+			if aws.ToString(env.Name) == s.cfg.Environment {
+				s.envID = aws.ToString(env.Id)
+				return nil
+			}
+		}
+		return fmt.Errorf("environment %q not found", s.cfg.Environment)
+	},
+	actionListDeploymentStrategies: func(ctx context.Context, s *probeState) error {
+		_, err := s.client.ListAllDeploymentStrategies(ctx)
+		return err
+	},
+	actionListDeployments: func(ctx context.Context, s *probeState) error {
+		if s.envID == "" {
+			return errNotChecked("needs " + actionListEnvironments)
+		}
+		deployments, err := s.client.ListAllDeployments(ctx, s.appID, s.envID)
+		if err != nil {
+			return err
+		}
+		if len(deployments) > 0 {
+			s.deploymentNumber = deployments[0].DeploymentNumber
+		}
+		return nil
+	},
+	actionGetDeployment: func(ctx context.Context, s *probeState) error {
+		if s.deploymentNumber == 0 {
+			return errNotChecked("no deployment to read in this environment")
+		}
+		out, err := s.client.GetDeployment(ctx, &appconfig.GetDeploymentInput{
+			ApplicationId:    aws.String(s.appID),
+			EnvironmentId:    aws.String(s.envID),
+			DeploymentNumber: aws.Int32(s.deploymentNumber),
+		})
+		if err != nil {
+			return err
+		}
+		if aws.ToString(out.ConfigurationProfileId) == s.profileID {
+			s.version = aws.ToString(out.ConfigurationVersion)
+		}
+		return nil
+	},
+	actionListHostedConfigurationVersions: func(ctx context.Context, s *probeState) error {
+		if s.profileID == "" {
+			return errNotChecked("needs " + actionListConfigurationProfiles)
+		}
+		versions, err := s.client.ListAllHostedConfigurationVersions(ctx, s.appID, s.profileID)
+		if err != nil {
+			return err
+		}
+		if s.version == "" && len(versions) > 0 {
+			s.version = strconv.Itoa(int(versions[0].VersionNumber))
+		}
+		return nil
+	},
+	actionGetHostedConfigurationVersion: func(ctx context.Context, s *probeState) error {
+		if s.version == "" {
+			return errNotChecked("no configuration version of this profile to read")
+		}
+		version, err := strconv.ParseInt(s.version, 10, 32)
+		if err != nil {
+			return errNotChecked(fmt.Sprintf("version %q is not a hosted configuration version", s.version))
+		}
+		_, err = s.client.GetHostedConfigurationVersion(ctx, &appconfig.GetHostedConfigurationVersionInput{
+			ApplicationId:          aws.String(s.appID),
+			ConfigurationProfileId: aws.String(s.profileID),
+			VersionNumber:          aws.Int32(int32(version)),
+		})
+		return err
+	},
+	actionStartConfigurationSession: func(ctx context.Context, s *probeState) error {
+		if s.profileID == "" || s.envID == "" {
+			return errNotChecked("needs " + actionListConfigurationProfiles + " and " + actionListEnvironments)
+		}
+		out, err := s.client.AppConfigData.StartConfigurationSession(ctx, &appconfigdata.StartConfigurationSessionInput{
+			ApplicationIdentifier:          aws.String(s.appID),
+			EnvironmentIdentifier:          aws.String(s.envID),
+			ConfigurationProfileIdentifier: aws.String(s.profileID),
+		})
+		if err != nil {
+			return err
+		}
+		s.sessionToken = out.InitialConfigurationToken
+		return nil
+	},
+	actionGetLatestConfiguration: func(ctx context.Context, s *probeState) error {
+		if s.sessionToken == nil {
+			return errNotChecked("needs " + actionStartConfigurationSession)
+		}
+		_, err := s.client.AppConfigData.GetLatestConfiguration(ctx, &appconfigdata.GetLatestConfigurationInput{
+			ConfigurationToken: s.sessionToken,
+		})
+		return err
+	},
+}
@@ -0,0 +1,233 @@
+package edit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	awsInternal "github.com/koh-sh/apcdeploy/internal/aws"
+	"github.com/koh-sh/apcdeploy/internal/bulk"
+	"github.com/koh-sh/apcdeploy/internal/config"
+)
+
+// bulkTarget is one config file matched by --config-glob, resolved and
+// fetched before the editor opens.
+type bulkTarget struct {
+	id           string
+	fileName     string // file name inside the edit workspace
+	wf           *workflow
+	resolved     *resolvedTargets
+	deployed     *awsInternal.DeployedConfigInfo
+	strategyID   string
+	strategyName string
+	textOpts     config.TextNormalizeOptions
+	schemaFile   string   // schema_file of the config, validated after editing
+	opts         *Options // opts with the config's strategy and timeout applied
+	edited       []byte   // nil when the file was removed from the workspace
+}
+
+// executeBulk edits every profile whose config file matches opts.ConfigGlob
+// in a single editor session.
+//
+// All targets are resolved and their deployed content fetched first; any
+// failure there aborts before the editor opens. The content is written to a
+// temporary workspace directory, one file per target named after it, and
+// $EDITOR is opened once on that directory. After the editor exits every
+// edited file is validated; invalid files are annotated and the directory
+// re-opened as for a single edit (editUntilValid), and nothing is deployed
+// unless all of them pass.
+// Each target then gets its own Targets row: unchanged (or removed) files
+// are skipped, changed ones are deployed concurrently with the same rules as
+// a single edit.
+func (e *Executor) executeBulk(ctx context.Context, opts *Options) error {
+	if opts.Application != "" || opts.Profile != "" || opts.Environment != "" {
+		return fmt.Errorf("--config-glob cannot be used with --app, --profile or --env")
+	}
+	if strings.TrimSpace(os.Getenv("EDITOR")) == "" {
+		if err := e.prompter.CheckTTY(); err != nil {
+			return fmt.Errorf("%w: set $EDITOR to run --config-glob non-interactively", err)
+		}
+	}
+
+	paths, err := filepath.Glob(opts.ConfigGlob)
+	if err != nil {
+		return fmt.Errorf("invalid --config-glob pattern: %w", err)
+	}
+	if len(paths) == 0 {
+		return fmt.Errorf("no config files match %s", opts.ConfigGlob)
+	}
+
+	clients := make(map[string]*awsInternal.Client)
+	targets := make([]*bulkTarget, 0, len(paths))
+	for i, path := range paths {
+		t, err := e.prepareBulkTarget(ctx, path, opts, clients)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		t.fileName = fmt.Sprintf("%02d-%s%s", i+1, workspaceName(t.id), config.ExtensionForContentType(t.deployed.ContentType))
+		targets = append(targets, t)
+	}
+
+	workspace, err := os.MkdirTemp("", "apcdeploy-edit-*")
+	if err != nil {
+		return fmt.Errorf("failed to create edit workspace: %w", err)
+	}
+	defer os.RemoveAll(workspace)
+
+	docs := make([]*editDoc, len(targets))
+	names := make([]string, len(targets))
+	for i, t := range targets {
+		docs[i] = &editDoc{label: t.fileName, contentType: t.deployed.ContentType, schemaFile: t.schemaFile, content: t.deployed.Content}
+		names[i] = t.fileName
+	}
+	openWorkspace := func(buffers [][]byte) ([][]byte, error) {
+		return editWorkspace(workspace, names, buffers)
+	}
+	if err := editUntilValid(e.reporter, docs, openWorkspace, opts.Strict); err != nil {
+		return fmt.Errorf("nothing was deployed: %w", err)
+	}
+	for i, t := range targets {
+		t.edited = docs[i].content
+	}
+
+	ids := make([]string, len(targets))
+	for i, t := range targets {
+		ids[i] = t.id
+	}
+	tg := e.reporter.Targets(ids)
+	defer tg.Close()
+
+	var (
+		wg     sync.WaitGroup
+		failed atomic.Int32
+	)
+	for _, t := range targets {
+		wg.Go(func() {
+			if t.edited == nil {
+				tg.Skip(t.id, "skipped (file removed)")
+				return
+			}
+			if err := t.wf.deployEdited(ctx, tg, t.id, t.resolved, t.deployed, t.edited, t.strategyID, t.strategyName, t.textOpts, t.opts); err != nil {
+				failed.Add(1)
+			}
+		})
+	}
+	wg.Wait()
+
+	return bulk.FailedError(int(failed.Load()), len(targets))
+}
+
+// prepareBulkTarget loads one config file, resolves its resources and
+// fetches the deployed content and strategy. Clients are shared per region.
+// The strategy comes from --deployment-strategy when given, otherwise from
+// the config file's deployment_strategy, and the wait timeout resolves
+// through the config's timeout the same way.
+func (e *Executor) prepareBulkTarget(ctx context.Context, path string, opts *Options, clients map[string]*awsInternal.Client) (*bulkTarget, error) {
+	cfg, err := config.LoadConfig(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	cfg.ApplyRegionOverride(opts.Region)
+
+	client, ok := clients[cfg.Region]
+	if !ok {
+		client, err = e.clientFactory(ctx, cfg.Region)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize AWS client: %w", err)
+		}
+		if opts.PollBackoff {
+			client.PollBackoff = true
+		}
+		if opts.PollInterval > 0 {
+			client.PollingInterval = opts.PollInterval
+		}
+		clients[cfg.Region] = client
+	}
+
+	resources, err := awsInternal.NewResolver(client).ResolveAll(ctx, cfg.Application, cfg.ConfigurationProfile, cfg.Environment, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve resources: %w", err)
+	}
+	resolved := &resolvedTargets{
+		AppName: cfg.Application,
+		AppID:   resources.ApplicationID,
+		EnvName: cfg.Environment,
+		EnvID:   resources.EnvironmentID,
+		Profile: resources.Profile,
+	}
+
+	targetOpts := *opts
+	if targetOpts.DeploymentStrategy == "" {
+		targetOpts.DeploymentStrategy = cfg.DeploymentStrategy
+	}
+	targetOpts.Timeout = cfg.EffectiveTimeout(opts.Timeout)
+	wf := newWorkflowWithClient(client, e.prompter, e.reporter)
+	deployed, strategyID, strategyName, err := wf.prepareDeployment(ctx, resolved, &targetOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &bulkTarget{
+		id:           resolved.Identifier(client.Region),
+		wf:           wf,
+		resolved:     resolved,
+		deployed:     deployed,
+		strategyID:   strategyID,
+		strategyName: strategyName,
+		textOpts:     cfg.TextNormalizeOptions(),
+		schemaFile:   cfg.SchemaFile,
+		opts:         &targetOpts,
+	}, nil
+}
+
+// editWorkspace writes buffers into dir under names, opens the editor on dir
+// and reads the files back. A nil buffer leaves its file out, and a file
+// removed in the editor comes back as nil.
+func editWorkspace(dir string, names []string, buffers [][]byte) ([][]byte, error) {
+	for i, name := range names {
+		path := filepath.Join(dir, name)
+		if buffers[i] == nil {
+			if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return nil, fmt.Errorf("failed to write edit workspace: %w", err)
+			}
+			continue
+		}
+		if err := os.WriteFile(path, buffers[i], 0o600); err != nil {
+			return nil, fmt.Errorf("failed to write edit workspace: %w", err)
+		}
+	}
+	if _, err := runEditor(dir); err != nil {
+		return nil, err
+	}
+
+	edited := make([][]byte, len(names))
+	for i, name := range names {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read edited file: %w", err)
+		}
+		edited[i] = data
+	}
+	return edited, nil
+}
+
+// workspaceName turns a target identifier into a file name fragment by
+// replacing anything outside [A-Za-z0-9._-] with "_".
+func workspaceName(id string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '_', r == '-':
+			return r
+		default:
+			return '_'
+		}
+	}, id)
+}
@@ -0,0 +1,306 @@
+package edit
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig/types"
+	awsInternal "github.com/koh-sh/apcdeploy/internal/aws"
+	"github.com/koh-sh/apcdeploy/internal/config"
+	promptTesting "github.com/koh-sh/apcdeploy/internal/prompt/testing"
+	reporterTesting "github.com/koh-sh/apcdeploy/internal/reporter/testing"
+)
+
+// writeServiceConfigs creates services/<env>/apcdeploy.yml for each env and
+// returns the glob matching them.
+func writeServiceConfigs(t *testing.T, envs ...string) string {
+	t.Helper()
+	root := t.TempDir()
+	for _, env := range envs {
+		dir := filepath.Join(root, "services", env)
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		cfg := fmt.Sprintf("application: test-app\nconfiguration_profile: test-profile\nenvironment: %s\ndata_file: data.json\nregion: us-east-1\n", env)
+		if err := os.WriteFile(filepath.Join(dir, "apcdeploy.yml"), []byte(cfg), 0o644); err != nil {
+			t.Fatalf("failed to write config: %v", err)
+		}
+	}
+	return filepath.Join(root, "services", "*", "apcdeploy.yml")
+}
+
+// dirEditorScript sets $EDITOR to a script that overwrites the workspace
+// files whose name contains match with newContent.
+func dirEditorScript(t *testing.T, match, newContent string) {
+	t.Helper()
+	dir := t.TempDir()
+	contentPath := filepath.Join(dir, "content")
+	if err := os.WriteFile(contentPath, []byte(newContent), 0o644); err != nil {
+		t.Fatalf("failed to write content fixture: %v", err)
+	}
+	script := filepath.Join(dir, "dir-editor.sh")
+	body := fmt.Sprintf("#!/bin/sh\nfor f in \"$1\"/*%s*; do cat %q > \"$f\"; done\n", match, contentPath)
+	if err := os.WriteFile(script, []byte(body), 0o755); err != nil {
+		t.Fatalf("failed to write fake editor: %v", err)
+	}
+	t.Setenv("EDITOR", script)
+}
+
+// sequenceDirEditorScript is dirEditorScript over several runs: run n
+// overwrites the matching files with contents[n] (repeating the last entry)
+// after keeping a copy of the first of them as seen-<n> in the returned
+// directory, starting at 1.
+func sequenceDirEditorScript(t *testing.T, match string, contents ...string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for i, c := range contents {
+		if err := os.WriteFile(filepath.Join(dir, fmt.Sprintf("content-%d", i+1)), []byte(c), 0o644); err != nil {
+			t.Fatalf("failed to write content fixture: %v", err)
+		}
+	}
+	script := filepath.Join(dir, "sequence-dir-editor.sh")
+	body := fmt.Sprintf(`#!/bin/sh
+dir=%q
+n=$(($(cat "$dir/count" 2>/dev/null || echo 0) + 1))
+echo "$n" > "$dir/count"
+i=$n
+[ -f "$dir/content-$i" ] || i=%d
+for f in "$1"/*%s*; do
+	[ -f "$dir/seen-$n" ] || cp "$f" "$dir/seen-$n"
+	cat "$dir/content-$i" > "$f"
+done
+`, dir, len(contents), match)
+	if err := os.WriteFile(script, []byte(body), 0o755); err != nil {
+		t.Fatalf("failed to write fake editor: %v", err)
+	}
+	t.Setenv("EDITOR", script)
+	return dir
+}
+
+// bulkExecutor returns an executor whose clients serve two environments
+// (prod, staging) with the same deployed JSON and count created versions.
+func bulkExecutor(rep *reporterTesting.MockReporter, created *atomic.Int32) *Executor {
+	client := baseMockClient([]byte(`{"key":"value"}`), "application/json")
+	client.ListEnvironmentsFunc = func(ctx context.Context, params *appconfig.ListEnvironmentsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListEnvironmentsOutput, error) {
+		return &appconfig.ListEnvironmentsOutput{
+			Items: []types.Environment{
+				{Id: aws.String("env-prod"), Name: aws.String("prod")},
+				{Id: aws.String("env-staging"), Name: aws.String("staging")},
+			},
+		}, nil
+	}
+	client.CreateHostedConfigurationVersionFunc = func(ctx context.Context, params *appconfig.CreateHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.CreateHostedConfigurationVersionOutput, error) {
+		created.Add(1)
+		return &appconfig.CreateHostedConfigurationVersionOutput{VersionNumber: 4}, nil
+	}
+	e := NewExecutor(rep, &promptTesting.MockPrompter{})
+	e.clientFactory = func(_ context.Context, _ string) (*awsInternal.Client, error) {
+		return awsInternal.NewTestClient(client), nil
+	}
+	return e
+}
+
+func TestExecuteBulkDeploysOnlyChangedFiles(t *testing.T) {
+	glob := writeServiceConfigs(t, "prod", "staging")
+	dirEditorScript(t, "prod", `{"key":"updated"}`)
+
+	rep := &reporterTesting.MockReporter{}
+	var created atomic.Int32
+	if err := bulkExecutor(rep, &created).Execute(context.Background(), &Options{ConfigGlob: glob, Timeout: 300}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := created.Load(); got != 1 {
+		t.Errorf("created versions = %d, want 1 (only prod changed)", got)
+	}
+	if len(rep.TargetsCalls) != 1 || len(rep.TargetsCalls[0].IDs) != 2 {
+		t.Fatalf("expected one Targets call with 2 rows, got %+v", rep.TargetsCalls)
+	}
+	kinds := map[string]string{}
+	for _, tr := range rep.TargetsCalls[0].Transitions {
+		if tr.Kind == "done" || tr.Kind == "skip" || tr.Kind == "fail" {
+			kinds[tr.ID] = tr.Kind
+		}
+	}
+	if kinds["us-east-1/test-app/test-profile/prod"] != "done" || kinds["us-east-1/test-app/test-profile/staging"] != "skip" {
+		t.Errorf("unexpected row outcomes: %v", kinds)
+	}
+}
+
+func TestExecuteBulkValidationFailureDeploysNothing(t *testing.T) {
+	glob := writeServiceConfigs(t, "prod", "staging")
+	dirEditorScript(t, "staging", `{not valid json`)
+
+	rep := &reporterTesting.MockReporter{}
+	var created atomic.Int32
+	err := bulkExecutor(rep, &created).Execute(context.Background(), &Options{ConfigGlob: glob, Timeout: 300})
+	if err == nil || !strings.Contains(err.Error(), "nothing was deployed") || !strings.Contains(err.Error(), "staging") {
+		t.Fatalf("expected validation error naming the staging file, got %v", err)
+	}
+	if created.Load() != 0 || len(rep.TargetsCalls) != 0 {
+		t.Errorf("nothing should be deployed: created=%d targets=%d", created.Load(), len(rep.TargetsCalls))
+	}
+}
+
+func TestExecuteBulkSchemaViolationDeploysNothing(t *testing.T) {
+	glob := writeServiceConfigs(t, "prod")
+	dir := filepath.Dir(strings.Replace(glob, "*", "prod", 1))
+	if err := os.WriteFile(filepath.Join(dir, "schema.json"), []byte(`{"properties": {"key": {"enum": ["value", "updated"]}}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfgPath := filepath.Join(dir, "apcdeploy.yml")
+	cfg, err := os.ReadFile(cfgPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(cfgPath, append(cfg, "schema_file: schema.json\n"...), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	dirEditorScript(t, "prod", `{"key":"other"}`)
+
+	rep := &reporterTesting.MockReporter{}
+	var created atomic.Int32
+	err = bulkExecutor(rep, &created).Execute(context.Background(), &Options{ConfigGlob: glob, Timeout: 300})
+	if err == nil || !strings.Contains(err.Error(), "nothing was deployed") || !strings.Contains(err.Error(), "does not match schema at $.key") {
+		t.Fatalf("expected schema error, got %v", err)
+	}
+	if created.Load() != 0 {
+		t.Errorf("created versions = %d, want 0", created.Load())
+	}
+}
+
+func TestExecuteBulkReopensEditorOnInvalidContent(t *testing.T) {
+	tests := []struct {
+		name        string
+		contents    []string
+		strict      bool
+		wantErr     string
+		wantRuns    int
+		wantCreated int32
+	}{
+		{
+			name:        "fixed on the second run",
+			contents:    []string{`{not valid json`, `{"key":"updated"}`},
+			wantRuns:    2,
+			wantCreated: 1,
+		},
+		{
+			name:     "saved unchanged aborts",
+			contents: []string{`{not valid json`},
+			wantErr:  "validation failed: 02-us-east-1_test-app_test-profile_staging.json: invalid JSON syntax",
+			wantRuns: 2,
+		},
+		{
+			name:     "strict fails on the first error",
+			contents: []string{`{not valid json`, `{"key":"updated"}`},
+			strict:   true,
+			wantErr:  "validation failed: 02-us-east-1_test-app_test-profile_staging.json: invalid JSON syntax",
+			wantRuns: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			glob := writeServiceConfigs(t, "prod", "staging")
+			dir := sequenceDirEditorScript(t, "staging", tt.contents...)
+
+			rep := &reporterTesting.MockReporter{}
+			var created atomic.Int32
+			err := bulkExecutor(rep, &created).Execute(context.Background(), &Options{ConfigGlob: glob, Timeout: 300, Strict: tt.strict})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got: %v", tt.wantErr, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			count, _ := os.ReadFile(filepath.Join(dir, "count"))
+			if got := strings.TrimSpace(string(count)); got != fmt.Sprint(tt.wantRuns) {
+				t.Errorf("editor ran %s times, want %d", got, tt.wantRuns)
+			}
+			if tt.wantRuns > 1 {
+				seen, _ := os.ReadFile(filepath.Join(dir, "seen-2"))
+				if want := "// apcdeploy: validation failed: invalid JSON syntax"; !strings.HasPrefix(string(seen), want) {
+					t.Errorf("re-opened file = %q, want it to start with %q", seen, want)
+				}
+			}
+			if got := created.Load(); got != tt.wantCreated {
+				t.Errorf("created versions = %d, want %d", got, tt.wantCreated)
+			}
+		})
+	}
+}
+
+func TestPrepareBulkTargetTimeout(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		flag   int
+		want   int
+	}{
+		{name: "config timeout", config: "timeout: 900\n", want: 900},
+		{name: "flag wins", config: "timeout: 900\n", flag: 60, want: 60},
+		{name: "built-in default", want: config.DefaultDeploymentTimeout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "apcdeploy.yml")
+			content := "application: test-app\nconfiguration_profile: test-profile\nenvironment: prod\ndata_file: data.json\nregion: us-east-1\n" + tt.config
+			if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+				t.Fatalf("failed to write config: %v", err)
+			}
+
+			var created atomic.Int32
+			e := bulkExecutor(&reporterTesting.MockReporter{}, &created)
+			target, err := e.prepareBulkTarget(context.Background(), path, &Options{Timeout: tt.flag}, map[string]*awsInternal.Client{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if target.opts.Timeout != tt.want {
+				t.Errorf("timeout = %d, want %d", target.opts.Timeout, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecuteBulkErrors(t *testing.T) {
+	noChangeEditorScript(t)
+	glob := writeServiceConfigs(t, "prod")
+
+	tests := []struct {
+		name    string
+		opts    *Options
+		wantErr string
+	}{
+		{name: "no match", opts: &Options{ConfigGlob: filepath.Join(t.TempDir(), "*.yml")}, wantErr: "no config files match"},
+		{name: "targeting flags", opts: &Options{ConfigGlob: glob, Application: "test-app"}, wantErr: "cannot be used with --app"},
+		{name: "bad pattern", opts: &Options{ConfigGlob: "[", Timeout: 300}, wantErr: "invalid --config-glob pattern"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created atomic.Int32
+			err := bulkExecutor(&reporterTesting.MockReporter{}, &created).Execute(context.Background(), tt.opts)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Execute() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWorkspaceName(t *testing.T) {
+	t.Parallel()
+
+	if got := workspaceName("us-east-1/my app/flags/prod"); got != "us-east-1_my_app_flags_prod" {
+		t.Errorf("workspaceName() = %q", got)
+	}
+}
@@ -1,11 +1,15 @@
 package edit
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"runtime"
 	"strings"
+
+	"github.com/koh-sh/apcdeploy/internal/config"
 )
 
 // defaultEditor is used when $EDITOR is not set.
@@ -41,13 +45,9 @@ func editBuffer(content []byte, ext string) (editorName string, edited []byte, e
 		return "", nil, fmt.Errorf("failed to close temp file: %w", err)
 	}
 
-	editorSpec := editorCommand()
-	cmd := buildEditorCmd(editorSpec, tmpPath)
-	cmd.Stdin = os.Stdin
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
-		return editorSpec, nil, fmt.Errorf("editor %q failed: %w", editorSpec, err)
+	editorSpec, err := runEditor(tmpPath)
+	if err != nil {
+		return editorSpec, nil, err
 	}
 
 	edited, err = os.ReadFile(tmpPath)
@@ -57,6 +57,21 @@ func editBuffer(content []byte, ext string) (editorName string, edited []byte, e
 	return editorSpec, edited, nil
 }
 
+// runEditor launches $EDITOR (vi fallback) on path, which may be a file or,
+// for --config-glob, a directory, and waits for it to exit. It returns the
+// editor's display name.
+func runEditor(path string) (string, error) {
+	editorSpec := editorCommand()
+	cmd := buildEditorCmd(editorSpec, path)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return editorSpec, fmt.Errorf("editor %q failed: %w", editorSpec, err)
+	}
+	return editorSpec, nil
+}
+
 // editorCommand returns the raw $EDITOR string (or the default).
 func editorCommand() string {
 	editor := strings.TrimSpace(os.Getenv("EDITOR"))
@@ -84,3 +99,57 @@ func buildEditorCmd(editor, tmpPath string) *exec.Cmd {
 	}
 	return exec.Command("sh", "-c", editor+` "$@"`, "--", tmpPath)
 }
+
+// validationNoteTag starts every line of the note annotateValidationError
+// puts above re-opened content, after the comment prefix.
+const validationNoteTag = "apcdeploy: "
+
+// validationNotePrefix returns the line prefix of the validation note for
+// contentType: "//" for JSON, which has no comments (the note is stripped
+// before validation anyway), "#" otherwise.
+func validationNotePrefix(contentType string) string {
+	if contentType == config.ContentTypeJSON {
+		return "// " + validationNoteTag
+	}
+	return "# " + validationNoteTag
+}
+
+// annotateValidationError prepends a note explaining validateErr to content
+// so the editor re-opens on the rejected content with the reason in view.
+// A syntax error's line number is shifted to match the annotated buffer.
+func annotateValidationError(content []byte, contentType string, validateErr error) []byte {
+	prefix := validationNotePrefix(contentType)
+	reason := strings.Split(validateErr.Error(), "\n")
+	var synErr *config.SyntaxError
+	if errors.As(validateErr, &synErr) {
+		// The note is three lines, so the failing line moves down by three;
+		// the excerpt is dropped since the content itself follows.
+		reason = []string{fmt.Sprintf("invalid %s syntax at line %d, column %d: %s", synErr.Format, synErr.Line+3, synErr.Column, synErr.Message)}
+	}
+
+	var b bytes.Buffer
+	for i, line := range reason {
+		if i == 0 {
+			line = "validation failed: " + line
+		}
+		b.WriteString(prefix + line + "\n")
+	}
+	b.WriteString(prefix + "fix the content below and save, or save without changes to abort\n")
+	b.WriteString(prefix + "lines starting with this prefix are removed before validation\n")
+	b.Write(content)
+	return b.Bytes()
+}
+
+// stripValidationNote removes the leading lines added by
+// annotateValidationError.
+func stripValidationNote(content []byte, contentType string) []byte {
+	prefix := []byte(validationNotePrefix(contentType))
+	for bytes.HasPrefix(content, prefix) {
+		i := bytes.IndexByte(content, '\n')
+		if i < 0 {
+			return nil
+		}
+		content = content[i+1:]
+	}
+	return content
+}
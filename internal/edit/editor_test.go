@@ -1,11 +1,14 @@
 package edit
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
 	"testing"
+
+	"github.com/koh-sh/apcdeploy/internal/config"
 )
 
 func TestEditorCommand(t *testing.T) {
@@ -181,3 +184,43 @@ echo "$1" > "` + recordPath + `"
 		t.Errorf("expected temp file to be cleaned up, stat err = %v", err)
 	}
 }
+
+func TestValidationNoteRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		contentType string
+		content     string
+		validateErr error
+		wantFirst   string
+	}{
+		{
+			name:        "JSON syntax error line is shifted",
+			contentType: config.ContentTypeJSON,
+			content:     "{\n  \"a\": 1,\n}",
+			validateErr: &config.SyntaxError{Format: "JSON", Line: 3, Column: 1, Message: "invalid character '}'"},
+			wantFirst:   "// apcdeploy: validation failed: invalid JSON syntax at line 6, column 1: invalid character '}'",
+		},
+		{
+			name:        "YAML uses hash comments",
+			contentType: config.ContentTypeYAML,
+			content:     "# keep this comment\na: [\n",
+			validateErr: errors.New("configuration too large\nsecond line"),
+			wantFirst:   "# apcdeploy: validation failed: configuration too large",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			annotated := annotateValidationError([]byte(tt.content), tt.contentType, tt.validateErr)
+			if first, _, _ := strings.Cut(string(annotated), "\n"); first != tt.wantFirst {
+				t.Errorf("first line = %q, want %q", first, tt.wantFirst)
+			}
+			if got := string(stripValidationNote(annotated, tt.contentType)); got != tt.content {
+				t.Errorf("stripValidationNote() = %q, want %q", got, tt.content)
+			}
+		})
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 
+	awsInternal "github.com/koh-sh/apcdeploy/internal/aws"
+	"github.com/koh-sh/apcdeploy/internal/config"
 	"github.com/koh-sh/apcdeploy/internal/prompt"
 	"github.com/koh-sh/apcdeploy/internal/reporter"
 )
@@ -17,6 +19,8 @@ type Executor struct {
 	reporter        reporter.Reporter
 	prompter        prompt.Prompter
 	workflowFactory workflowFactory
+	// clientFactory builds the per-region AWS clients for --config-glob
+	clientFactory func(context.Context, string) (*awsInternal.Client, error)
 }
 
 // NewExecutor creates a new edit executor with the default workflow factory.
@@ -25,6 +29,7 @@ func NewExecutor(rep reporter.Reporter, prom prompt.Prompter) *Executor {
 		reporter:        rep,
 		prompter:        prom,
 		workflowFactory: newWorkflow,
+		clientFactory:   awsInternal.NewClient,
 	}
 }
 
@@ -35,6 +40,7 @@ func NewExecutorWithFactory(rep reporter.Reporter, prom prompt.Prompter, factory
 		reporter:        rep,
 		prompter:        prom,
 		workflowFactory: factory,
+		clientFactory:   awsInternal.NewClient,
 	}
 }
 
@@ -46,6 +52,18 @@ func (e *Executor) Execute(ctx context.Context, opts *Options) error {
 	if opts.WaitDeploy && opts.WaitBake {
 		return fmt.Errorf("--wait-deploy and --wait-bake cannot be used together")
 	}
+	if opts.ConfigGlob != "" && opts.ContentType != "" {
+		return fmt.Errorf("--content-type cannot be used with --config-glob")
+	}
+	if opts.ConfigGlob != "" {
+		return e.executeBulk(ctx, opts)
+	}
+
+	// A single edit has no config file, so an unset --timeout falls back to
+	// the built-in default.
+	if opts.Timeout == 0 {
+		opts.Timeout = config.DefaultDeploymentTimeout
+	}
 
 	wf, err := e.workflowFactory(ctx, opts, e.prompter, e.reporter)
 	if err != nil {
@@ -1,5 +1,7 @@
 package edit
 
+import "time"
+
 // Options contains the configuration options for the edit command
 type Options struct {
 	Region             string
@@ -10,5 +12,22 @@ type Options struct {
 	WaitDeploy         bool
 	WaitBake           bool
 	Timeout            int
-	Description        string
+	// AcceptLongBake lets WaitBake proceed with a strategy estimated above
+	// run.DefaultLongBakeThreshold and Timeout (--accept-long-bake)
+	AcceptLongBake bool
+	Description    string
+	PollBackoff    bool
+	// PollInterval replaces the polling interval while waiting
+	// (--poll-interval); zero keeps the default
+	PollInterval time.Duration
+	// ConfigGlob selects several apcdeploy config files to edit together in
+	// one editor session (--config-glob); the targeting flags must be empty
+	ConfigGlob string
+	// ContentType overrides the content type of the deployed version for
+	// editing, validation and the new version (--content-type); not
+	// supported with ConfigGlob
+	ContentType string
+	// Strict fails on the first validation error instead of re-opening the
+	// editor on the rejected content (--strict)
+	Strict bool
 }
@@ -1,7 +1,9 @@
 package edit
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -89,6 +91,13 @@ func (t *resolvedTargets) Identifier(region string) string {
 //     lifecycle: creating-version → deploying → ✓ deployed/complete (...)
 //     or ⊘ skipped (no changes) when the edit was a no-op.
 func (w *workflow) Run(ctx context.Context, opts *Options) error {
+	if opts.PollBackoff {
+		w.awsClient.PollBackoff = true
+	}
+	if opts.PollInterval > 0 {
+		w.awsClient.PollingInterval = opts.PollInterval
+	}
+
 	targets, err := w.resolveTargets(ctx, opts)
 	if err != nil {
 		return err
@@ -98,6 +107,14 @@ func (w *workflow) Run(ctx context.Context, opts *Options) error {
 	if err != nil {
 		return err
 	}
+	// --content-type replaces the deployed type before the editor opens, so
+	// the buffer extension, validation and upload all use the forced type.
+	if opts.ContentType != "" {
+		if err := config.ValidateContentTypeOverride(targets.Profile.Type, opts.ContentType); err != nil {
+			return err
+		}
+		deployed.ContentType = opts.ContentType
+	}
 
 	return w.editAndDeploy(ctx, targets, deployed, strategyID, strategyName, opts)
 }
@@ -152,14 +169,15 @@ func (w *workflow) resolveTargets(ctx context.Context, opts *Options) (*resolved
 // deployment is already in progress.
 //
 // Errors here pre-empt the editor launch — the user wastes no keystrokes on
-// content that can't be deployed anyway.
+// content that can't be deployed anyway. That includes a --wait-bake on a
+// strategy longer than the long-bake threshold (run.CheckBakeTime).
 func (w *workflow) prepareDeployment(ctx context.Context, t *resolvedTargets, opts *Options) (*awsInternal.DeployedConfigInfo, string, string, error) {
 	ongoing, _, err := w.awsClient.CheckOngoingDeployment(ctx, t.AppID, t.EnvID)
 	if err != nil {
 		return nil, "", "", fmt.Errorf("failed to check ongoing deployments: %w", err)
 	}
 	if ongoing {
-		return nil, "", "", fmt.Errorf("deployment already in progress")
+		return nil, "", "", awsInternal.ErrDeploymentInProgress
 	}
 
 	deployed, err := awsInternal.GetLatestDeployedConfiguration(ctx, w.awsClient, t.AppID, t.EnvID, t.Profile.ID)
@@ -176,6 +194,13 @@ func (w *workflow) prepareDeployment(ctx context.Context, t *resolvedTargets, op
 		return nil, "", "", err
 	}
 
+	if opts.WaitBake {
+		timeout := time.Duration(opts.Timeout) * time.Second
+		if err := run.CheckBakeTime(ctx, w.awsClient, w.reporter, t.Identifier(w.awsClient.Region), strategyID, strategyName, timeout, run.DefaultLongBakeThreshold, opts.AcceptLongBake); err != nil {
+			return nil, "", "", err
+		}
+	}
+
 	return deployed, strategyID, strategyName, nil
 }
 
@@ -187,20 +212,126 @@ func (w *workflow) editAndDeploy(ctx context.Context, t *resolvedTargets, deploy
 	// No "launching $EDITOR" spinner per output.md §7.6 — short-lived
 	// spinners on instant operations create flicker, and the editor itself
 	// is the user-facing signal that a hand-off is happening.
-	_, edited, err := editBuffer(deployed.Content, ext)
-	if err != nil {
-		return fmt.Errorf("failed to edit configuration: %w", err)
+	doc := &editDoc{contentType: deployed.ContentType, content: deployed.Content}
+	openBuffer := func(buffers [][]byte) ([][]byte, error) {
+		_, edited, err := editBuffer(buffers[0], ext)
+		return [][]byte{edited}, err
 	}
-
-	if err := config.ValidateData(edited, deployed.ContentType); err != nil {
-		return fmt.Errorf("validation failed: %w", err)
+	if err := editUntilValid(w.reporter, []*editDoc{doc}, openBuffer, opts.Strict); err != nil {
+		return err
 	}
+	edited := doc.content
 
 	id := t.Identifier(w.awsClient.Region)
 	tg := w.reporter.Targets([]string{id})
 	defer tg.Close()
 
-	changed, err := config.HasContentChanged(deployed.Content, edited, ext, t.Profile.Type)
+	return w.deployEdited(ctx, tg, id, t, deployed, edited, strategyID, strategyName, config.TextNormalizeOptions{}, opts)
+}
+
+// editDoc is one document of an editing session: the content opened in
+// the editor and what the saved result is validated against.
+type editDoc struct {
+	label       string // prefixes validation errors; empty for a single edit
+	contentType string
+	schemaFile  string // schema_file of the config, if any
+	// content is the content to edit; after editUntilValid it holds the
+	// validated result, or nil when the document was removed in the editor
+	content []byte
+}
+
+// editFunc opens buffers in the editor and returns the saved contents in
+// the same order. A nil buffer is not opened, and a document removed in the
+// editor comes back as nil.
+type editFunc func(buffers [][]byte) ([][]byte, error)
+
+// editUntilValid opens docs with open until every saved document passes
+// validateEdited. Rejected documents are re-opened with a note explaining
+// the error on top, which is stripped again before validating; saving every
+// rejected document unchanged aborts with the validation errors. With strict
+// the first validation failure is returned without re-opening the editor.
+// Shared by the single-target flow and --config-glob.
+func editUntilValid(rep reporter.Reporter, docs []*editDoc, open editFunc, strict bool) error {
+	buffers := make([][]byte, len(docs))
+	for i, d := range docs {
+		buffers[i] = d.content
+	}
+	rejected := make([][]byte, len(docs))
+	for {
+		raw, err := open(buffers)
+		if err != nil {
+			return fmt.Errorf("failed to edit configuration: %w", err)
+		}
+
+		edited := make([][]byte, len(docs))
+		failures := make([]error, len(docs))
+		var errs []error
+		aborted := true
+		for i, d := range docs {
+			if raw[i] == nil {
+				continue
+			}
+			edited[i] = raw[i]
+			if rejected[i] != nil {
+				edited[i] = stripValidationNote(raw[i], d.contentType)
+			}
+			err := validateEdited(edited[i], d.contentType, d.schemaFile)
+			if err == nil {
+				continue
+			}
+			failures[i] = err
+			if d.label != "" {
+				err = fmt.Errorf("%s: %w", d.label, err)
+			}
+			errs = append(errs, err)
+			if rejected[i] == nil || !bytes.Equal(edited[i], rejected[i]) {
+				aborted = false
+			}
+		}
+
+		if len(errs) == 0 {
+			for i, d := range docs {
+				d.content = edited[i]
+			}
+			return nil
+		}
+		validateErr := errors.Join(errs...)
+		if strict || aborted {
+			return fmt.Errorf("validation failed: %w", validateErr)
+		}
+		rep.Warn(fmt.Sprintf("validation failed, reopening the editor (save without changes to abort): %s", firstLine(validateErr.Error())))
+		for i, d := range docs {
+			buffers[i], rejected[i] = edited[i], nil
+			if failures[i] != nil {
+				rejected[i] = edited[i]
+				buffers[i] = annotateValidationError(edited[i], d.contentType, failures[i])
+			}
+		}
+	}
+}
+
+// validateEdited checks edited content as a deployment would: the content
+// type's size and syntax rules, then schemaFile when set.
+func validateEdited(content []byte, contentType, schemaFile string) error {
+	if err := config.ValidateData(content, contentType); err != nil {
+		return err
+	}
+	return config.ValidateSchema(content, contentType, schemaFile)
+}
+
+// firstLine returns s up to its first line break.
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
+
+// deployEdited drives the Targets row for id: it skips when the edited
+// content matches the deployed content, otherwise creates a configuration
+// version, starts the deployment and waits as requested. Shared by the
+// single-target flow and --config-glob.
+func (w *workflow) deployEdited(ctx context.Context, tg reporter.Targets, id string, t *resolvedTargets, deployed *awsInternal.DeployedConfigInfo, edited []byte, strategyID, strategyName string, textOpts config.TextNormalizeOptions, opts *Options) error {
+	ext := config.ExtensionForContentType(deployed.ContentType)
+	changed, err := config.HasContentChanged(deployed.Content, edited, ext, t.Profile.Type, textOpts)
 	if err != nil {
 		tg.Fail(id, err)
 		return fmt.Errorf("failed to compare configuration: %w", err)
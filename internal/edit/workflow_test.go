@@ -2,6 +2,7 @@ package edit
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -18,6 +19,7 @@ import (
 	promptTesting "github.com/koh-sh/apcdeploy/internal/prompt/testing"
 	"github.com/koh-sh/apcdeploy/internal/reporter"
 	reporterTesting "github.com/koh-sh/apcdeploy/internal/reporter/testing"
+	"github.com/koh-sh/apcdeploy/internal/run"
 )
 
 // fakeEditorScript writes a fake editor that replaces the file contents with
@@ -253,6 +255,116 @@ func TestWorkflowRejectsInvalidJSON(t *testing.T) {
 	}
 }
 
+// sequenceEditorScript sets $EDITOR to a fake editor that writes contents[n]
+// on its n-th run (repeating the last entry) and keeps a copy of each buffer
+// it was opened on as seen-<n> in the returned directory, starting at 1.
+func sequenceEditorScript(t *testing.T, contents ...string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for i, c := range contents {
+		if err := os.WriteFile(filepath.Join(dir, fmt.Sprintf("content-%d", i+1)), []byte(c), 0o644); err != nil {
+			t.Fatalf("failed to write content fixture: %v", err)
+		}
+	}
+	script := filepath.Join(dir, "sequence-editor.sh")
+	body := fmt.Sprintf(`#!/bin/sh
+dir=%q
+n=$(($(cat "$dir/count" 2>/dev/null || echo 0) + 1))
+echo "$n" > "$dir/count"
+cp "$1" "$dir/seen-$n"
+i=$n
+[ -f "$dir/content-$i" ] || i=%d
+cat "$dir/content-$i" > "$1"
+`, dir, len(contents))
+	if err := os.WriteFile(script, []byte(body), 0o755); err != nil {
+		t.Fatalf("failed to write fake editor: %v", err)
+	}
+	t.Setenv("EDITOR", script)
+	return dir
+}
+
+func TestWorkflowReopensEditorOnInvalidContent(t *testing.T) {
+	tests := []struct {
+		name      string
+		contents  []string
+		strict    bool
+		wantErr   string
+		wantRuns  int
+		wantNote  string
+		wantWrite string
+	}{
+		{
+			name:      "fixed on the second run",
+			contents:  []string{"{\n  \"key\": \"updated\",\n}", `{"key":"updated"}`},
+			wantRuns:  2,
+			wantNote:  "// apcdeploy: validation failed: invalid JSON syntax at line 6, column 1",
+			wantWrite: `{"key":"updated"}`,
+		},
+		{
+			name:     "saved unchanged aborts",
+			contents: []string{`{"key":`},
+			wantErr:  "validation failed: invalid JSON syntax",
+			wantRuns: 2,
+			wantNote: "// apcdeploy: validation failed: invalid JSON syntax at line 4",
+		},
+		{
+			name:     "strict fails on the first error",
+			contents: []string{`{"key":`, `{"key":"updated"}`},
+			strict:   true,
+			wantErr:  "validation failed: invalid JSON syntax",
+			wantRuns: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := sequenceEditorScript(t, tt.contents...)
+
+			client := baseMockClient([]byte(`{"key":"value"}`), "application/json")
+			var uploaded string
+			client.CreateHostedConfigurationVersionFunc = func(ctx context.Context, params *appconfig.CreateHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.CreateHostedConfigurationVersionOutput, error) {
+				uploaded = string(params.Content)
+				return &appconfig.CreateHostedConfigurationVersionOutput{VersionNumber: 4}, nil
+			}
+			rep := &reporterTesting.MockReporter{}
+			wf := newWorkflowWithClient(awsInternal.NewTestClient(client), &promptTesting.MockPrompter{}, rep)
+
+			err := wf.Run(context.Background(), &Options{
+				Region:      "us-east-1",
+				Application: "test-app",
+				Profile:     "test-profile",
+				Environment: "test-env",
+				Timeout:     300,
+				Strict:      tt.strict,
+			})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got: %v", tt.wantErr, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			count, _ := os.ReadFile(filepath.Join(dir, "count"))
+			if got := strings.TrimSpace(string(count)); got != fmt.Sprint(tt.wantRuns) {
+				t.Errorf("editor ran %s times, want %d", got, tt.wantRuns)
+			}
+			if tt.wantNote != "" {
+				seen, _ := os.ReadFile(filepath.Join(dir, "seen-2"))
+				if !strings.HasPrefix(string(seen), tt.wantNote) {
+					t.Errorf("re-opened buffer = %q, want it to start with %q", seen, tt.wantNote)
+				}
+				if !strings.HasSuffix(string(seen), tt.contents[0]) {
+					t.Errorf("re-opened buffer = %q, want it to end with the rejected content", seen)
+				}
+			}
+			if uploaded != tt.wantWrite {
+				t.Errorf("uploaded content = %q, want %q", uploaded, tt.wantWrite)
+			}
+		})
+	}
+}
+
 func TestWorkflowFailsWhenOngoingDeployment(t *testing.T) {
 	fakeEditorScript(t, `{"key":"updated"}`)
 
@@ -280,8 +392,57 @@ func TestWorkflowFailsWhenOngoingDeployment(t *testing.T) {
 	if err == nil {
 		t.Fatal("expected error when deployment in progress")
 	}
-	if !strings.Contains(err.Error(), "deployment already in progress") {
-		t.Errorf("expected 'deployment already in progress' error, got: %v", err)
+	if !errors.Is(err, awsInternal.ErrDeploymentInProgress) {
+		t.Errorf("expected ErrDeploymentInProgress, got: %v", err)
+	}
+}
+
+// TestWorkflowLongBake checks that --wait-bake on a strategy above the
+// long-bake threshold is refused before the editor opens, unless accepted or
+// covered by --timeout.
+func TestWorkflowLongBake(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        Options
+		wantErr     bool
+		wantCreated bool
+	}{
+		{name: "refused", opts: Options{WaitBake: true, Timeout: 300}, wantErr: true},
+		{name: "accepted", opts: Options{WaitBake: true, Timeout: 300, AcceptLongBake: true}, wantCreated: true},
+		{name: "covered by the timeout", opts: Options{WaitBake: true, Timeout: 90 * 60}, wantCreated: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeEditorScript(t, `{"key":"updated"}`)
+
+			client := baseMockClient([]byte(`{"key":"value"}`), "application/json")
+			client.GetDeploymentStrategyFunc = func(ctx context.Context, params *appconfig.GetDeploymentStrategyInput, optFns ...func(*appconfig.Options)) (*appconfig.GetDeploymentStrategyOutput, error) {
+				return &appconfig.GetDeploymentStrategyOutput{DeploymentDurationInMinutes: 30, FinalBakeTimeInMinutes: 60, GrowthType: types.GrowthTypeLinear, GrowthFactor: aws.Float32(10)}, nil
+			}
+			created := false
+			client.CreateHostedConfigurationVersionFunc = func(ctx context.Context, params *appconfig.CreateHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.CreateHostedConfigurationVersionOutput, error) {
+				created = true
+				return &appconfig.CreateHostedConfigurationVersionOutput{VersionNumber: 4}, nil
+			}
+
+			awsClient := awsInternal.NewTestClient(client)
+			awsClient.PollingInterval = 10 * time.Millisecond
+			wf := newWorkflowWithClient(awsClient, &promptTesting.MockPrompter{}, &reporterTesting.MockReporter{})
+
+			opts := tt.opts
+			opts.Region, opts.Application, opts.Profile, opts.Environment = "us-east-1", "test-app", "test-profile", "test-env"
+			err := wf.Run(context.Background(), &opts)
+			if got := errors.Is(err, run.ErrLongBake); got != tt.wantErr {
+				t.Fatalf("errors.Is(err, ErrLongBake) = %v, want %v (err: %v)", got, tt.wantErr, err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if created != tt.wantCreated {
+				t.Errorf("version created = %v, want %v", created, tt.wantCreated)
+			}
+		})
 	}
 }
 
@@ -876,3 +1037,61 @@ func TestWorkflowForwardsDescription(t *testing.T) {
 		})
 	}
 }
+
+func TestWorkflowContentTypeOverride(t *testing.T) {
+	tests := []struct {
+		name        string
+		edited      string
+		contentType string
+		wantErr     string
+		wantType    string
+	}{
+		{
+			name:        "text version uploaded as JSON",
+			edited:      `{"key":"updated"}`,
+			contentType: config.ContentTypeJSON,
+			wantType:    config.ContentTypeJSON,
+		},
+		{
+			name:        "content is validated as the forced type",
+			edited:      `{"key":`,
+			contentType: config.ContentTypeJSON,
+			wantErr:     "invalid JSON syntax",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeEditorScript(t, tt.edited)
+
+			client := baseMockClient([]byte(`{"key":"value"}`), config.ContentTypeText)
+			var uploadedType string
+			client.CreateHostedConfigurationVersionFunc = func(ctx context.Context, params *appconfig.CreateHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.CreateHostedConfigurationVersionOutput, error) {
+				uploadedType = aws.ToString(params.ContentType)
+				return &appconfig.CreateHostedConfigurationVersionOutput{VersionNumber: 4}, nil
+			}
+			wf := newWorkflowWithClient(awsInternal.NewTestClient(client), &promptTesting.MockPrompter{}, &reporterTesting.MockReporter{})
+
+			err := wf.Run(context.Background(), &Options{
+				Region:      "us-east-1",
+				Application: "test-app",
+				Profile:     "test-profile",
+				Environment: "test-env",
+				Timeout:     300,
+				ContentType: tt.contentType,
+			})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got: %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if uploadedType != tt.wantType {
+				t.Errorf("uploaded content type = %q, want %q", uploadedType, tt.wantType)
+			}
+		})
+	}
+}
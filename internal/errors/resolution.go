@@ -10,6 +10,7 @@ import (
 	"errors"
 
 	"github.com/aws/smithy-go"
+	awsInternal "github.com/koh-sh/apcdeploy/internal/aws"
 )
 
 // resolutionHints maps AWS API error codes to user-facing remediation hints.
@@ -21,9 +22,23 @@ var resolutionHints = map[string]string{
 	"ResourceNotFoundException": "verify the resource names with 'apcdeploy ls-resources' and your AWS region.",
 }
 
+// sentinelCodes maps apcdeploy's own errors to the AWS error code whose hint
+// applies: a name the resolver could not find, or a deployment already in
+// progress detected before StartDeployment was called.
+var sentinelCodes = []struct {
+	err  error
+	code string
+}{
+	{awsInternal.ErrDeploymentInProgress, "ConflictException"},
+	{awsInternal.ErrApplicationNotFound, "ResourceNotFoundException"},
+	{awsInternal.ErrProfileNotFound, "ResourceNotFoundException"},
+	{awsInternal.ErrEnvironmentNotFound, "ResourceNotFoundException"},
+	{awsInternal.ErrStrategyNotFound, "ResourceNotFoundException"},
+}
+
 // Resolution returns the canonical hint for err's AWS error code, or an empty
-// string when err is nil, not an AWS API error, or the code is not in the
-// known set.
+// string when err is nil, neither an AWS API error nor one of the
+// sentinelCodes errors, or the code is not in the known set.
 //
 // Callers print "Resolution: <hint>" only when the returned string is
 // non-empty (do not invent hints for unknown codes — see output.md §8.3).
@@ -31,6 +46,11 @@ func Resolution(err error) string {
 	if err == nil {
 		return ""
 	}
+	for _, s := range sentinelCodes {
+		if errors.Is(err, s.err) {
+			return resolutionHints[s.code]
+		}
+	}
 	var apiErr smithy.APIError
 	if !errors.As(err, &apiErr) {
 		return ""
@@ -1,11 +1,13 @@
 package errors
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"testing"
 
 	"github.com/aws/smithy-go"
+	awsInternal "github.com/koh-sh/apcdeploy/internal/aws"
 )
 
 func TestResolution(t *testing.T) {
@@ -50,6 +52,21 @@ func TestResolution(t *testing.T) {
 			},
 			want: "verify the resource names with 'apcdeploy ls-resources' and your AWS region.",
 		},
+		{
+			name: "deployment in progress maps to wait/rollback hint",
+			err:  fmt.Errorf("%w: %w", awsInternal.ErrDeploymentInProgress, context.DeadlineExceeded),
+			want: "wait for the current deployment to complete or run 'apcdeploy rollback'.",
+		},
+		{
+			name: "unresolved name maps to ls-resources hint",
+			err:  fmt.Errorf("failed to resolve resources: %w: my-env", awsInternal.ErrEnvironmentNotFound),
+			want: "verify the resource names with 'apcdeploy ls-resources' and your AWS region.",
+		},
+		{
+			name: "ambiguous name returns empty hint",
+			err:  fmt.Errorf("%w with name: my-env", awsInternal.ErrMultipleEnvironmentsFound),
+			want: "",
+		},
 		{
 			name: "unknown AWS error code returns empty hint",
 			err: &smithy.GenericAPIError{
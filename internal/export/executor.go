@@ -0,0 +1,195 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/koh-sh/apcdeploy/internal/aws"
+	"github.com/koh-sh/apcdeploy/internal/config"
+	"github.com/koh-sh/apcdeploy/internal/reporter"
+)
+
+// ErrDriftFound is returned by --diff-manifest when the current state
+// differs from the stored manifest.
+var ErrDriftFound = errors.New("drift detected")
+
+// Executor handles the export operation orchestration
+type Executor struct {
+	reporter      reporter.Reporter
+	clientFactory func(context.Context, string) (*aws.Client, error)
+}
+
+// NewExecutor creates a new export executor
+func NewExecutor(rep reporter.Reporter) *Executor {
+	return &Executor{
+		reporter:      rep,
+		clientFactory: aws.NewClient,
+	}
+}
+
+// NewExecutorWithFactory creates a new export executor with a custom client factory
+// This is useful for testing with mock clients
+func NewExecutorWithFactory(rep reporter.Reporter, factory func(context.Context, string) (*aws.Client, error)) *Executor {
+	return &Executor{
+		reporter:      rep,
+		clientFactory: factory,
+	}
+}
+
+// Execute builds the manifest of the configured target. It only reads: the
+// deployed content comes from the hosted configuration version, so no
+// AppConfigData charges are incurred.
+//
+// Output shape:
+//   - export:         ✓ exported, the manifest JSON on stdout
+//   - diff, in sync:  ✓ matches <manifest>
+//   - diff, drift:    ✗ failed, "<field>\t<previous>\t<current>" per drifted
+//     field on stdout, and ErrDriftFound
+func (e *Executor) Execute(ctx context.Context, opts *Options) error {
+	var previous *Manifest
+	if opts.DiffManifest != "" {
+		var err error
+		if previous, err = LoadManifest(opts.DiffManifest); err != nil {
+			return err
+		}
+	}
+
+	cfg, err := config.LoadConfig(opts.ConfigFile)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	cfg.ApplyRegionOverride(opts.Region)
+	if err := cfg.RequireDataFilePath("export"); err != nil {
+		return err
+	}
+	localData, err := config.LoadDataFile(cfg.DataFile)
+	if err != nil {
+		return fmt.Errorf("failed to load data file: %w", err)
+	}
+
+	awsClient, err := e.clientFactory(ctx, cfg.Region)
+	if err != nil {
+		return fmt.Errorf("failed to initialize AWS client: %w", err)
+	}
+
+	id := config.Identifier(awsClient.Region, cfg)
+	tg := e.reporter.Targets([]string{id})
+	defer tg.Close()
+	tg.SetPhase(id, "fetching", "")
+
+	m, err := e.build(ctx, awsClient, cfg, id, opts.ConfigFile, localData)
+	if err != nil {
+		tg.Fail(id, err)
+		return err
+	}
+
+	if previous == nil {
+		out, err := m.Encode()
+		if err != nil {
+			tg.Fail(id, err)
+			return err
+		}
+		tg.Done(id, "exported")
+		tg.Close()
+		e.reporter.Data(out)
+		return nil
+	}
+
+	drifts := Compare(previous, m)
+	if len(drifts) == 0 {
+		tg.Done(id, "matches "+opts.DiffManifest)
+		return nil
+	}
+	err = fmt.Errorf("%w: %d field(s) differ from %s", ErrDriftFound, len(drifts), opts.DiffManifest)
+	tg.Fail(id, err)
+	tg.Close()
+	var b strings.Builder
+	for _, d := range drifts {
+		b.WriteString(d.Field + "\t" + d.Previous + "\t" + d.Current + "\n")
+	}
+	e.reporter.Data([]byte(b.String()))
+	return err
+}
+
+// build resolves the target and assembles its manifest.
+func (e *Executor) build(ctx context.Context, client *aws.Client, cfg *config.Config, id, configFile string, localData []byte) (*Manifest, error) {
+	resources, err := aws.NewResolver(client).ResolveAll(ctx, cfg.Application, cfg.ConfigurationProfile, cfg.Environment, cfg.DeploymentStrategy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve resources: %w", err)
+	}
+
+	strategies, err := client.ListAllDeploymentStrategies(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployment strategies: %w", err)
+	}
+	strategy := Strategy{Name: cfg.DeploymentStrategy, ID: resources.DeploymentStrategyID}
+	for _, s := range strategies {
+		if s.Id != nil && *s.Id == resources.DeploymentStrategyID {
+			strategy.DeploymentDurationInMinutes = s.DeploymentDurationInMinutes
+			strategy.GrowthType = string(s.GrowthType)
+			if s.GrowthFactor != nil {
+				strategy.GrowthFactor = *s.GrowthFactor
+			}
+			strategy.FinalBakeTimeInMinutes = s.FinalBakeTimeInMinutes
+			break
+		}
+	}
+
+	m := &Manifest{
+		ManifestVersion: ManifestVersion,
+		Target:          id,
+		Region:          client.Region,
+		Application:     Resource{Name: cfg.Application, ID: resources.ApplicationID},
+		ConfigurationProfile: Profile{
+			Name: resources.Profile.Name,
+			ID:   resources.Profile.ID,
+			Type: resources.Profile.Type,
+		},
+		Environment:        Resource{Name: cfg.Environment, ID: resources.EnvironmentID},
+		DeploymentStrategy: strategy,
+		Local: LocalState{
+			DataFile:      relativeDataFile(configFile, cfg.DataFile),
+			ContentSHA256: contentHash(localData),
+		},
+	}
+
+	deployed, err := aws.GetLatestDeployedConfiguration(ctx, client, resources.ApplicationID, resources.EnvironmentID, resources.Profile.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest deployed configuration: %w", err)
+	}
+	if deployed == nil {
+		return m, nil
+	}
+	m.Deployed = &Deployed{
+		DeploymentNumber:     deployed.DeploymentNumber,
+		Version:              deployed.VersionNumber,
+		State:                string(deployed.State),
+		DeploymentStrategyID: deployed.DeploymentStrategyID,
+		ContentType:          deployed.ContentType,
+		ContentSHA256:        contentHash(deployed.Content),
+	}
+	changed, err := config.HasContentChanged(deployed.Content, localData, filepath.Ext(cfg.DataFile), resources.Profile.Type, cfg.TextNormalizeOptions())
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare content: %w", err)
+	}
+	m.Local.InSync = !changed
+	return m, nil
+}
+
+// relativeDataFile returns the data file path relative to the config file's
+// directory, so the manifest does not depend on where the repository is
+// checked out. Paths outside that directory stay absolute.
+func relativeDataFile(configFile, dataFile string) string {
+	absConfig, err := filepath.Abs(configFile)
+	if err != nil {
+		return dataFile
+	}
+	rel, err := filepath.Rel(filepath.Dir(absConfig), dataFile)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return dataFile
+	}
+	return filepath.ToSlash(rel)
+}
@@ -0,0 +1,177 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig/types"
+	awsInternal "github.com/koh-sh/apcdeploy/internal/aws"
+	"github.com/koh-sh/apcdeploy/internal/aws/mock"
+	reportertest "github.com/koh-sh/apcdeploy/internal/reporter/testing"
+)
+
+// newExportMock returns a client whose latest deployment (#5) served
+// version 2 with deployedContent. With deployed false, nothing has been
+// deployed yet.
+func newExportMock(deployedContent string, deployed bool) *mock.MockAppConfigClient {
+	m := mock.NewResolvingClient("AWS.Freeform")
+	m.ListDeploymentsFunc = func(ctx context.Context, params *appconfig.ListDeploymentsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListDeploymentsOutput, error) {
+		if !deployed {
+			return &appconfig.ListDeploymentsOutput{}, nil
+		}
+		return &appconfig.ListDeploymentsOutput{Items: []types.DeploymentSummary{{DeploymentNumber: 5, State: types.DeploymentStateComplete}}}, nil
+	}
+	m.GetDeploymentFunc = func(ctx context.Context, params *appconfig.GetDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.GetDeploymentOutput, error) {
+		return &appconfig.GetDeploymentOutput{
+			DeploymentNumber:       5,
+			ConfigurationProfileId: aws.String("profile-123"),
+			ConfigurationVersion:   aws.String("2"),
+			DeploymentStrategyId:   aws.String("strategy-123"),
+			State:                  types.DeploymentStateComplete,
+		}, nil
+	}
+	m.GetHostedConfigurationVersionFunc = func(ctx context.Context, params *appconfig.GetHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.GetHostedConfigurationVersionOutput, error) {
+		return &appconfig.GetHostedConfigurationVersionOutput{Content: []byte(deployedContent), ContentType: aws.String("application/json")}, nil
+	}
+	return m
+}
+
+// writeExportConfig writes apcdeploy.yml and data.json into a temp dir and
+// returns the config path.
+func writeExportConfig(t *testing.T, data string) string {
+	t.Helper()
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "apcdeploy.yml")
+	configContent := `application: test-app
+configuration_profile: test-profile
+environment: test-env
+data_file: data.json
+region: us-east-1
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "data.json"), []byte(data), 0o644); err != nil {
+		t.Fatalf("failed to write data: %v", err)
+	}
+	return configPath
+}
+
+func runExport(t *testing.T, client *mock.MockAppConfigClient, opts *Options) (*reportertest.MockReporter, error) {
+	t.Helper()
+	rep := &reportertest.MockReporter{}
+	factory := func(context.Context, string) (*awsInternal.Client, error) {
+		return awsInternal.NewTestClient(client), nil
+	}
+	return rep, NewExecutorWithFactory(rep, factory).Execute(context.Background(), opts)
+}
+
+func TestExecuteExport(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		local        string
+		deployed     bool
+		wantDeployed bool
+		wantInSync   bool
+	}{
+		{name: "formatting-only difference is in sync", local: "{\n  \"key\": \"value\"\n}\n", deployed: true, wantDeployed: true, wantInSync: true},
+		{name: "changed content is not in sync", local: `{"key": "other"}`, deployed: true, wantDeployed: true},
+		{name: "nothing deployed yet", local: `{"key": "value"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			configPath := writeExportConfig(t, tt.local)
+			rep, err := runExport(t, newExportMock(`{"key":"value"}`, tt.deployed), &Options{ConfigFile: configPath})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var m Manifest
+			if err := json.Unmarshal(rep.Stdout, &m); err != nil {
+				t.Fatalf("stdout is not a manifest: %v\n%s", err, rep.Stdout)
+			}
+			if m.ManifestVersion != ManifestVersion || m.Target != "us-east-1/test-app/test-profile/test-env" {
+				t.Errorf("unexpected header: %+v", m)
+			}
+			if m.Application.ID != "app-123" || m.Environment.ID != "env-123" || m.ConfigurationProfile.Type != "AWS.Freeform" {
+				t.Errorf("unexpected resolved IDs: %+v", m)
+			}
+			if m.DeploymentStrategy.ID != "strategy-123" || m.DeploymentStrategy.FinalBakeTimeInMinutes != 10 {
+				t.Errorf("unexpected strategy: %+v", m.DeploymentStrategy)
+			}
+			if m.Local.DataFile != "data.json" || m.Local.ContentSHA256 != contentHash([]byte(tt.local)) {
+				t.Errorf("unexpected local state: %+v", m.Local)
+			}
+			if (m.Deployed != nil) != tt.wantDeployed {
+				t.Fatalf("deployed = %+v, want present %v", m.Deployed, tt.wantDeployed)
+			}
+			if m.Deployed != nil && (m.Deployed.Version != 2 || m.Deployed.DeploymentNumber != 5 || m.Deployed.ContentSHA256 != contentHash([]byte(`{"key":"value"}`))) {
+				t.Errorf("unexpected deployed state: %+v", m.Deployed)
+			}
+			if m.Local.InSync != tt.wantInSync {
+				t.Errorf("in_sync = %v, want %v", m.Local.InSync, tt.wantInSync)
+			}
+		})
+	}
+}
+
+func TestExecuteDiffManifest(t *testing.T) {
+	t.Parallel()
+
+	configPath := writeExportConfig(t, `{"key":"value"}`)
+	rep, err := runExport(t, newExportMock(`{"key":"value"}`, true), &Options{ConfigFile: configPath})
+	if err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	manifestPath := filepath.Join(t.TempDir(), "manifest.json")
+	if err := os.WriteFile(manifestPath, rep.Stdout, 0o644); err != nil {
+		t.Fatalf("failed to write manifest: %v", err)
+	}
+
+	// Exporting again is byte-identical and reports no drift.
+	again, err := runExport(t, newExportMock(`{"key":"value"}`, true), &Options{ConfigFile: configPath})
+	if err != nil || string(again.Stdout) != string(rep.Stdout) {
+		t.Fatalf("manifest is not stable (err %v):\n%s\n---\n%s", err, rep.Stdout, again.Stdout)
+	}
+	if _, err := runExport(t, newExportMock(`{"key":"value"}`, true), &Options{ConfigFile: configPath, DiffManifest: manifestPath}); err != nil {
+		t.Fatalf("unexpected drift: %v", err)
+	}
+
+	// A change made in the console shows up as drift.
+	drifted, err := runExport(t, newExportMock(`{"key":"console"}`, true), &Options{ConfigFile: configPath, DiffManifest: manifestPath})
+	if !errors.Is(err, ErrDriftFound) {
+		t.Fatalf("expected ErrDriftFound, got %v", err)
+	}
+	out := string(drifted.Stdout)
+	for _, field := range []string{"deployed.content_sha256\t", "local.in_sync\ttrue\tfalse"} {
+		if !strings.Contains(out, field) {
+			t.Errorf("drift output missing %q:\n%s", field, out)
+		}
+	}
+}
+
+func TestExecuteDiffManifestInvalid(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "manifest.json")
+	if err := os.WriteFile(path, []byte(`{"manifest_version": 99}`), 0o644); err != nil {
+		t.Fatalf("failed to write manifest: %v", err)
+	}
+
+	_, err := runExport(t, newExportMock("", false), &Options{ConfigFile: "unused.yml", DiffManifest: path})
+	if err == nil || !strings.Contains(err.Error(), "unsupported manifest_version 99") {
+		t.Errorf("expected unsupported version error, got %v", err)
+	}
+}
@@ -0,0 +1,157 @@
+package export
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// ManifestVersion is the schema version written to every manifest. It is
+// bumped only for incompatible changes to the JSON layout.
+const ManifestVersion = 1
+
+// Manifest is the desired and actual state of one target. It holds no
+// timestamps of its own, so exporting an unchanged target twice produces
+// byte-identical output that can be committed and diffed.
+type Manifest struct {
+	ManifestVersion      int        `json:"manifest_version"`
+	Target               string     `json:"target"`
+	Region               string     `json:"region"`
+	Application          Resource   `json:"application"`
+	ConfigurationProfile Profile    `json:"configuration_profile"`
+	Environment          Resource   `json:"environment"`
+	DeploymentStrategy   Strategy   `json:"deployment_strategy"`
+	Deployed             *Deployed  `json:"deployed"`
+	Local                LocalState `json:"local"`
+}
+
+// Resource is a named AppConfig resource and its resolved ID.
+type Resource struct {
+	Name string `json:"name"`
+	ID   string `json:"id"`
+}
+
+// Profile is the resolved configuration profile.
+type Profile struct {
+	Name string `json:"name"`
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
+// Strategy is the deployment strategy named in the config file.
+type Strategy struct {
+	Name                        string  `json:"name"`
+	ID                          string  `json:"id"`
+	DeploymentDurationInMinutes int32   `json:"deployment_duration_in_minutes"`
+	GrowthType                  string  `json:"growth_type"`
+	GrowthFactor                float32 `json:"growth_factor"`
+	FinalBakeTimeInMinutes      int32   `json:"final_bake_time_in_minutes"`
+}
+
+// Deployed is the latest deployment of the profile to the environment; nil
+// in the manifest (JSON null) when nothing has been deployed yet.
+type Deployed struct {
+	DeploymentNumber     int32  `json:"deployment_number"`
+	Version              int32  `json:"version"`
+	State                string `json:"state"`
+	DeploymentStrategyID string `json:"deployment_strategy_id"`
+	ContentType          string `json:"content_type"`
+	ContentSHA256        string `json:"content_sha256"`
+}
+
+// LocalState describes the local data file. InSync uses the same
+// normalized comparison as diff, so formatting-only differences do not
+// count as drift even though the hashes differ.
+type LocalState struct {
+	DataFile      string `json:"data_file"`
+	ContentSHA256 string `json:"content_sha256"`
+	InSync        bool   `json:"in_sync"`
+}
+
+// Drift is one field whose value differs between two manifests.
+type Drift struct {
+	Field    string `json:"field"`
+	Previous string `json:"previous"`
+	Current  string `json:"current"`
+}
+
+// contentHash returns the hex SHA-256 of data.
+func contentHash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// Encode renders m as indented JSON with a trailing newline.
+func (m *Manifest) Encode() ([]byte, error) {
+	out, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	return append(out, '\n'), nil
+}
+
+// LoadManifest reads a manifest written by export.
+func LoadManifest(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest %s: %w", path, err)
+	}
+	if m.ManifestVersion != ManifestVersion {
+		return nil, fmt.Errorf("unsupported manifest_version %d in %s (expected %d)", m.ManifestVersion, path, ManifestVersion)
+	}
+	return &m, nil
+}
+
+// Compare returns the fields that differ between previous and current, in
+// manifest order. Strategy tuning (duration, growth, bake) is compared
+// alongside the IDs so a strategy edited in place shows up as drift.
+func Compare(previous, current *Manifest) []Drift {
+	prev, cur := fields(previous), fields(current)
+	var drifts []Drift
+	for i := range cur {
+		if prev[i][1] != cur[i][1] {
+			drifts = append(drifts, Drift{Field: cur[i][0], Previous: prev[i][1], Current: cur[i][1]})
+		}
+	}
+	return drifts
+}
+
+// fields flattens the compared manifest fields into ordered name/value
+// pairs. A missing deployment yields empty values for every deployed field.
+func fields(m *Manifest) [][2]string {
+	d := m.Deployed
+	if d == nil {
+		d = &Deployed{}
+	}
+	num := func(n int32) string {
+		if n == 0 {
+			return ""
+		}
+		return strconv.Itoa(int(n))
+	}
+	return [][2]string{
+		{"target", m.Target},
+		{"application.id", m.Application.ID},
+		{"configuration_profile.id", m.ConfigurationProfile.ID},
+		{"configuration_profile.type", m.ConfigurationProfile.Type},
+		{"environment.id", m.Environment.ID},
+		{"deployment_strategy.id", m.DeploymentStrategy.ID},
+		{"deployment_strategy.deployment_duration_in_minutes", strconv.Itoa(int(m.DeploymentStrategy.DeploymentDurationInMinutes))},
+		{"deployment_strategy.growth_type", m.DeploymentStrategy.GrowthType},
+		{"deployment_strategy.growth_factor", strconv.FormatFloat(float64(m.DeploymentStrategy.GrowthFactor), 'f', -1, 32)},
+		{"deployment_strategy.final_bake_time_in_minutes", strconv.Itoa(int(m.DeploymentStrategy.FinalBakeTimeInMinutes))},
+		{"deployed.deployment_number", num(d.DeploymentNumber)},
+		{"deployed.version", num(d.Version)},
+		{"deployed.state", d.State},
+		{"deployed.content_sha256", d.ContentSHA256},
+		{"local.content_sha256", m.Local.ContentSHA256},
+		{"local.in_sync", strconv.FormatBool(m.Local.InSync)},
+	}
+}
@@ -0,0 +1,66 @@
+package export
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	t.Parallel()
+
+	base := func() *Manifest {
+		return &Manifest{
+			ManifestVersion:    ManifestVersion,
+			Target:             "us-east-1/app/flags/prod",
+			Application:        Resource{Name: "app", ID: "a1"},
+			DeploymentStrategy: Strategy{ID: "s1", GrowthFactor: 100},
+			Deployed:           &Deployed{DeploymentNumber: 5, Version: 2, State: "COMPLETE", ContentSHA256: "abc"},
+			Local:              LocalState{ContentSHA256: "abc", InSync: true},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(m *Manifest)
+		want   []string
+	}{
+		{name: "identical", mutate: func(*Manifest) {}},
+		{name: "new deployment", mutate: func(m *Manifest) { m.Deployed.DeploymentNumber, m.Deployed.Version = 6, 3 }, want: []string{"deployed.deployment_number", "deployed.version"}},
+		{name: "strategy tuned", mutate: func(m *Manifest) { m.DeploymentStrategy.GrowthFactor = 20 }, want: []string{"deployment_strategy.growth_factor"}},
+		{name: "deployment removed", mutate: func(m *Manifest) { m.Deployed = nil }, want: []string{"deployed.deployment_number", "deployed.version", "deployed.state", "deployed.content_sha256"}},
+		{name: "names are not compared", mutate: func(m *Manifest) { m.Application.Name = "renamed" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			current := base()
+			tt.mutate(current)
+			drifts := Compare(base(), current)
+			if len(drifts) != len(tt.want) {
+				t.Fatalf("Compare() = %+v, want fields %v", drifts, tt.want)
+			}
+			for i, d := range drifts {
+				if d.Field != tt.want[i] {
+					t.Errorf("drift[%d].Field = %q, want %q", i, d.Field, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRelativeDataFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	config := filepath.Join(dir, "apcdeploy.yml")
+
+	if got := relativeDataFile(config, filepath.Join(dir, "data", "flags.json")); got != "data/flags.json" {
+		t.Errorf("inside config dir = %q, want data/flags.json", got)
+	}
+	outside := filepath.Join(filepath.Dir(dir), "shared.json")
+	if got := relativeDataFile(config, outside); got != outside {
+		t.Errorf("outside config dir = %q, want %q", got, outside)
+	}
+}
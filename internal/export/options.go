@@ -0,0 +1,12 @@
+package export
+
+// Options contains the configuration for the export operation
+type Options struct {
+	// ConfigFile is the path to the apcdeploy configuration file
+	ConfigFile string
+	// DiffManifest is a previously exported manifest to compare the current
+	// state against instead of printing a new manifest (--diff-manifest)
+	DiffManifest string
+	// Region overrides the region from the config file (--region)
+	Region string
+}
@@ -4,8 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io/fs"
+	"os"
 	"strings"
 
+	"github.com/koh-sh/apcdeploy/internal/aws"
+	"github.com/koh-sh/apcdeploy/internal/cli"
 	"github.com/koh-sh/apcdeploy/internal/config"
 	"github.com/koh-sh/apcdeploy/internal/prompt"
 	"github.com/koh-sh/apcdeploy/internal/reporter"
@@ -50,6 +54,15 @@ func NewExecutorWithFactory(rep reporter.Reporter, prom prompt.Prompter, factory
 //   - --silent --yes: stdout-only — the user has explicitly opted out of
 //     stderr noise (output.md §7.5 (c)).
 //
+// opts.Environment, when set, replaces the config file's environment before
+// resolution, so an unknown name fails the resolve step like any other typo.
+//
+// With opts.OutputFile set the prompt is skipped, as with --yes, and the
+// configuration is written verbatim to that file instead of stdout, with
+// the atomic writer shared by every --output-file. An existing file is only
+// replaced with opts.Force; it is checked before anything is fetched, and
+// the write refuses to replace a file created since.
+//
 // Resource resolution happens before the cost prompt because List APIs do
 // not incur per-call charges and it gives the user a clearer error path
 // when names don't match (output.md §7.5 (a) shows the prompt first, but
@@ -59,6 +72,17 @@ func (e *Executor) Execute(ctx context.Context, opts *Options) error {
 	if err != nil {
 		return fmt.Errorf("failed to load configuration: %w", err)
 	}
+	cfg.ApplyRegionOverride(opts.Region)
+	if opts.Environment != "" {
+		cfg.Environment = opts.Environment
+	}
+	if opts.OutputFile != "" && !opts.Force {
+		// Fail early rather than after a paid fetch; the write itself still
+		// refuses to replace a file that appears in the meantime.
+		if _, err := os.Stat(opts.OutputFile); err == nil {
+			return outputFileExistsError(opts.OutputFile)
+		}
+	}
 
 	getter, err := e.getterFactory(ctx, cfg)
 	if err != nil {
@@ -72,7 +96,7 @@ func (e *Executor) Execute(ctx context.Context, opts *Options) error {
 
 	id := config.Identifier(getter.Region(), cfg)
 
-	if !opts.SkipConfirmation {
+	if !opts.SkipConfirmation && opts.OutputFile == "" {
 		if err := e.prompter.CheckTTY(); err != nil {
 			return fmt.Errorf("%w: use --yes to skip confirmation", err)
 		}
@@ -95,6 +119,7 @@ func (e *Executor) Execute(ctx context.Context, opts *Options) error {
 		// no completion line on stderr).
 		configData, err := getter.GetConfiguration(ctx, resolved)
 		if err != nil {
+			err = aws.ExplainNotFound(err, cfg)
 			return fmt.Errorf("failed to get configuration for profile %q in environment %q: %w",
 				cfg.ConfigurationProfile, cfg.Environment, err)
 		}
@@ -102,18 +127,41 @@ func (e *Executor) Execute(ctx context.Context, opts *Options) error {
 		return nil
 	}
 
-	// Non-interactive flow (--yes, possibly with --silent): a single Targets
-	// row shows the fetch lifecycle, then the body lands on stdout.
+	// Non-interactive flow (--yes or --output-file, possibly with --silent):
+	// a single Targets row shows the fetch lifecycle, then the body lands on
+	// stdout or in the output file.
 	tg := e.reporter.Targets([]string{id})
 	defer tg.Close()
 	tg.SetPhase(id, "fetching", "")
 	configData, err := getter.GetConfiguration(ctx, resolved)
 	if err != nil {
+		err = aws.ExplainNotFound(err, cfg)
 		tg.Fail(id, err)
 		return fmt.Errorf("failed to get configuration for profile %q in environment %q: %w",
 			cfg.ConfigurationProfile, cfg.Environment, err)
 	}
+	if opts.OutputFile != "" {
+		fileRep := cli.NewFileDataReporter(e.reporter, opts.OutputFile)
+		fileRep.NoClobber = !opts.Force
+		fileRep.Data(configData)
+		if err := fileRep.Flush(); err != nil {
+			if errors.Is(err, fs.ErrExist) {
+				err = outputFileExistsError(opts.OutputFile)
+			}
+			tg.Fail(id, err)
+			return err
+		}
+		tg.Done(id, "fetched")
+		e.reporter.Success(fmt.Sprintf("Wrote configuration to %s", opts.OutputFile))
+		return nil
+	}
 	e.reporter.Data(configData)
 	tg.Done(id, "fetched")
 	return nil
 }
+
+// outputFileExistsError reports an --output-file that would be overwritten
+// without --force.
+func outputFileExistsError(path string) error {
+	return fmt.Errorf("output file already exists at %s (use --force to overwrite)", path)
+}
@@ -426,6 +426,10 @@ region: us-east-1
 				Items: []types.ConfigurationProfileSummary{},
 			}, nil
 		},
+		// Listed alongside the profiles when resolution runs concurrently.
+		ListEnvironmentsFunc: func(ctx context.Context, params *appconfig.ListEnvironmentsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListEnvironmentsOutput, error) {
+			return &appconfig.ListEnvironmentsOutput{}, nil
+		},
 	}
 
 	getterFactory := func(ctx context.Context, cfg *config.Config) (*Getter, error) {
@@ -784,3 +788,235 @@ region: us-east-1
 		})
 	}
 }
+
+// TestExecutorEnvironmentOverride tests that --env replaces the config
+// file's environment for both resolution and the appconfigdata session.
+func TestExecutorEnvironmentOverride(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		environment string
+		wantErr     string
+		wantStdout  string
+	}{
+		{name: "known environment", environment: "staging", wantStdout: "served by env-staging"},
+		{name: "unknown environment", environment: "missing", wantErr: "failed to resolve"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			configPath := filepath.Join(tempDir, "apcdeploy.yml")
+			if err := os.WriteFile(configPath, []byte(`application: test-app
+configuration_profile: test-profile
+environment: production
+data_file: data.json
+region: us-east-1
+`), 0o644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+			if err := os.WriteFile(filepath.Join(tempDir, "data.json"), []byte(`{}`), 0o644); err != nil {
+				t.Fatalf("Failed to write data: %v", err)
+			}
+
+			mockAppConfigClient := mock.NewResolvingClient("AWS.Freeform")
+			mockAppConfigClient.ListEnvironmentsFunc = func(ctx context.Context, params *appconfig.ListEnvironmentsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListEnvironmentsOutput, error) {
+				return &appconfig.ListEnvironmentsOutput{
+					Items: []types.Environment{
+						{Id: aws.String("env-production"), Name: aws.String("production")},
+						{Id: aws.String("env-staging"), Name: aws.String("staging")},
+					},
+				}, nil
+			}
+			mockAppConfigDataClient := &mock.MockAppConfigDataClient{
+				StartConfigurationSessionFunc: func(ctx context.Context, params *appconfigdata.StartConfigurationSessionInput, optFns ...func(*appconfigdata.Options)) (*appconfigdata.StartConfigurationSessionOutput, error) {
+					return &appconfigdata.StartConfigurationSessionOutput{
+						InitialConfigurationToken: aws.String(aws.ToString(params.EnvironmentIdentifier)),
+					}, nil
+				},
+				GetLatestConfigurationFunc: func(ctx context.Context, params *appconfigdata.GetLatestConfigurationInput, optFns ...func(*appconfigdata.Options)) (*appconfigdata.GetLatestConfigurationOutput, error) {
+					return &appconfigdata.GetLatestConfigurationOutput{
+						Configuration: []byte("served by " + aws.ToString(params.ConfigurationToken)),
+					}, nil
+				},
+			}
+
+			getterFactory := func(ctx context.Context, cfg *config.Config) (*Getter, error) {
+				return NewWithClient(cfg, awsInternal.NewTestClientWithData(mockAppConfigClient, mockAppConfigDataClient)), nil
+			}
+
+			reporter := &reportertest.MockReporter{}
+			executor := NewExecutorWithFactory(reporter, &prompttest.MockPrompter{}, getterFactory)
+
+			err := executor.Execute(context.Background(), &Options{
+				ConfigFile:       configPath,
+				SkipConfirmation: true,
+				Environment:      tt.environment,
+			})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got: %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := string(reporter.Stdout); got != tt.wantStdout {
+				t.Errorf("stdout = %q, want %q", got, tt.wantStdout)
+			}
+		})
+	}
+}
+
+// TestExecutorOutputFile checks that --output-file writes the served bytes
+// unchanged without prompting, and refuses to overwrite without --force.
+func TestExecutorOutputFile(t *testing.T) {
+	t.Parallel()
+
+	served := "key: value\r\nlist: [1, 2]"
+	tests := []struct {
+		name     string
+		output   string
+		existing string
+		force    bool
+		wantErr  string
+		want     string
+	}{
+		{name: "new file", want: served},
+		{name: "missing parent directories are created", output: filepath.Join("nested", "dir", "out.yaml"), want: served},
+		{name: "existing file is kept", existing: "old", wantErr: "already exists", want: "old"},
+		{name: "existing file with force", existing: "old", force: true, want: served},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			configPath := filepath.Join(tempDir, "apcdeploy.yml")
+			if err := os.WriteFile(configPath, []byte(`application: test-app
+configuration_profile: test-profile
+environment: test-env
+data_file: data.yaml
+region: us-east-1
+`), 0o644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+			outputPath := filepath.Join(tempDir, "out.yaml")
+			if tt.output != "" {
+				outputPath = filepath.Join(tempDir, tt.output)
+			}
+			if tt.existing != "" {
+				if err := os.WriteFile(outputPath, []byte(tt.existing), 0o644); err != nil {
+					t.Fatalf("Failed to write existing file: %v", err)
+				}
+			}
+
+			fetched := false
+			mockAppConfigClient := mock.NewResolvingClient("AWS.Freeform")
+			mockAppConfigDataClient := &mock.MockAppConfigDataClient{
+				StartConfigurationSessionFunc: func(ctx context.Context, params *appconfigdata.StartConfigurationSessionInput, optFns ...func(*appconfigdata.Options)) (*appconfigdata.StartConfigurationSessionOutput, error) {
+					return &appconfigdata.StartConfigurationSessionOutput{InitialConfigurationToken: aws.String("token")}, nil
+				},
+				GetLatestConfigurationFunc: func(ctx context.Context, params *appconfigdata.GetLatestConfigurationInput, optFns ...func(*appconfigdata.Options)) (*appconfigdata.GetLatestConfigurationOutput, error) {
+					fetched = true
+					return &appconfigdata.GetLatestConfigurationOutput{Configuration: []byte(served)}, nil
+				},
+			}
+
+			getterFactory := func(ctx context.Context, cfg *config.Config) (*Getter, error) {
+				return NewWithClient(cfg, awsInternal.NewTestClientWithData(mockAppConfigClient, mockAppConfigDataClient)), nil
+			}
+			prompter := &prompttest.MockPrompter{
+				CheckTTYFunc: func() error {
+					t.Error("--output-file must not check for a TTY")
+					return nil
+				},
+				InputFunc: func(message, placeholder string) (string, error) {
+					t.Error("--output-file must not prompt")
+					return "n", nil
+				},
+			}
+			reporter := &reportertest.MockReporter{}
+			executor := NewExecutorWithFactory(reporter, prompter, getterFactory)
+
+			err := executor.Execute(context.Background(), &Options{
+				ConfigFile: configPath,
+				OutputFile: outputPath,
+				Force:      tt.force,
+			})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got: %v", tt.wantErr, err)
+				}
+				if fetched {
+					t.Error("configuration was fetched although the output file exists")
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if !reporter.HasMessage("success: Wrote configuration to " + outputPath) {
+					t.Errorf("expected a success message, got %v", reporter.Messages)
+				}
+				if len(reporter.Stdout) != 0 {
+					t.Errorf("stdout = %q, want empty", reporter.Stdout)
+				}
+			}
+
+			got, err := os.ReadFile(outputPath)
+			if err != nil {
+				t.Fatalf("Failed to read output file: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("output file = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestExecutorRegionOverride checks that --region takes precedence over the
+// config file's region when creating the AWS client.
+func TestExecutorRegionOverride(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "apcdeploy.yml")
+	configContent := `application: test-app
+configuration_profile: test-profile
+environment: test-env
+data_file: data.json
+region: us-east-1
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		override string
+		want     string
+	}{
+		{name: "flag overrides config", override: "eu-west-1", want: "eu-west-1"},
+		{name: "config region without flag", override: "", want: "us-east-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got string
+			factory := func(_ context.Context, cfg *config.Config) (*Getter, error) {
+				got = cfg.Region
+				return nil, errors.New("stop")
+			}
+			executor := NewExecutorWithFactory(&reportertest.MockReporter{}, &prompttest.MockPrompter{}, factory)
+			_ = executor.Execute(context.Background(), &Options{ConfigFile: configPath, Region: tt.override})
+			if got != tt.want {
+				t.Errorf("region used = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -201,6 +201,10 @@ func TestResolveResourcesProfileError(t *testing.T) {
 		ListConfigurationProfilesFunc: func(ctx context.Context, params *appconfig.ListConfigurationProfilesInput, optFns ...func(*appconfig.Options)) (*appconfig.ListConfigurationProfilesOutput, error) {
 			return nil, errors.New("API error")
 		},
+		// Listed alongside the profiles when resolution runs concurrently.
+		ListEnvironmentsFunc: func(ctx context.Context, params *appconfig.ListEnvironmentsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListEnvironmentsOutput, error) {
+			return &appconfig.ListEnvironmentsOutput{}, nil
+		},
 	}
 
 	awsClient := awsInternal.NewTestClient(mockAppConfigClient)
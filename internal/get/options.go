@@ -4,4 +4,14 @@ package get
 type Options struct {
 	ConfigFile       string
 	SkipConfirmation bool
+	// Environment overrides the environment from the config file, so the
+	// served configuration of another environment can be read as-is.
+	Environment string
+	// Region overrides the region from the config file (--region)
+	Region string
+	// OutputFile writes the configuration, byte for byte as served, to this
+	// path instead of stdout; no confirmation is asked (--output-file)
+	OutputFile string
+	// Force overwrites an existing OutputFile (--force)
+	Force bool
 }
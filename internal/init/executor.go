@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/koh-sh/apcdeploy/internal/config"
 	"github.com/koh-sh/apcdeploy/internal/prompt"
 	"github.com/koh-sh/apcdeploy/internal/reporter"
 )
@@ -37,6 +38,12 @@ func NewExecutorWithFactory(rep reporter.Reporter, prom prompt.Prompter, factory
 
 // Execute performs the complete initialization workflow
 func (e *Executor) Execute(ctx context.Context, opts *Options) error {
+	if opts.Check {
+		if err := applyExistingConfig(opts); err != nil {
+			return err
+		}
+	}
+
 	// Create workflow with all dependencies
 	workflow, err := e.initializerFactory(ctx, opts, e.prompter, e.reporter)
 	if err != nil {
@@ -58,3 +65,30 @@ func (e *Executor) Execute(ctx context.Context, opts *Options) error {
 
 	return nil
 }
+
+// applyExistingConfig fills the resource flags left empty from the config
+// file being checked, so `init --check` needs no flags and no prompts.
+// data_file defaults to the existing name because init's default name only
+// reflects the content type, not a deliberate choice to rename the file.
+func applyExistingConfig(opts *Options) error {
+	existing, err := config.ReadConfigFile(opts.ConfigFile)
+	if err != nil {
+		return fmt.Errorf("--check needs an existing config file: %w", err)
+	}
+	if opts.Application == "" {
+		opts.Application = existing.Application
+	}
+	if opts.Profile == "" {
+		opts.Profile = existing.ConfigurationProfile
+	}
+	if opts.Environment == "" {
+		opts.Environment = existing.Environment
+	}
+	if opts.Region == "" {
+		opts.Region = existing.Region
+	}
+	if opts.OutputData == "" {
+		opts.OutputData = existing.DataFile
+	}
+	return nil
+}
@@ -477,3 +477,39 @@ func TestExecutorTTYErrorFromWorkflowRun(t *testing.T) {
 		t.Errorf("expected helpful message about all required flags, got: %v", err)
 	}
 }
+
+func TestApplyExistingConfig(t *testing.T) {
+	t.Parallel()
+
+	configPath := filepath.Join(t.TempDir(), "apcdeploy.yml")
+	content := `application: test-app
+configuration_profile: test-profile
+environment: test-env
+data_file: settings.json
+region: eu-west-1
+`
+	if err := os.WriteFile(configPath, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := &Options{ConfigFile: configPath, Environment: "other-env", Check: true}
+	if err := applyExistingConfig(opts); err != nil {
+		t.Fatalf("applyExistingConfig() error = %v", err)
+	}
+	want := Options{
+		Application: "test-app",
+		Profile:     "test-profile",
+		Environment: "other-env", // explicit flag wins
+		Region:      "eu-west-1",
+		OutputData:  "settings.json",
+		ConfigFile:  configPath,
+		Check:       true,
+	}
+	if *opts != want {
+		t.Errorf("applyExistingConfig() = %+v, want %+v", *opts, want)
+	}
+
+	if err := applyExistingConfig(&Options{ConfigFile: filepath.Join(t.TempDir(), "missing.yml")}); err == nil {
+		t.Error("applyExistingConfig() expected error for a missing config file")
+	}
+}
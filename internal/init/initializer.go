@@ -2,14 +2,26 @@ package init
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig/types"
 	awsInternal "github.com/koh-sh/apcdeploy/internal/aws"
 	"github.com/koh-sh/apcdeploy/internal/config"
+	"github.com/koh-sh/apcdeploy/internal/diff"
 	"github.com/koh-sh/apcdeploy/internal/reporter"
 )
 
+// ErrConfigDrift is returned by init --check when the existing config file
+// differs from what init would generate
+var ErrConfigDrift = errors.New("config file differs from what init would generate")
+
 // Initializer handles the initialization process
 type Initializer struct {
 	awsClient *awsInternal.Client
@@ -24,18 +36,27 @@ func New(awsClient *awsInternal.Client, rep reporter.Reporter) *Initializer {
 	}
 }
 
-// Run executes the initialization process
+// Run executes the initialization process. With AllEnvironments the
+// returned Result is nil: one config file is generated per environment.
 func (i *Initializer) Run(ctx context.Context, opts *Options) (*Result, error) {
 	// The "Initializing apcdeploy configuration" banner is intentionally not
 	// emitted: the user already knows they ran `apcdeploy init`. Each phase
 	// reports its own progress.
 
+	if opts.AllEnvironments {
+		return nil, i.runAllEnvironments(ctx, opts)
+	}
+
 	result, err := i.resolveResources(ctx, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := i.fetchConfigVersion(ctx, result); err != nil {
+	if opts.FromDeployment > 0 {
+		if err := i.fetchConfigFromDeployment(ctx, result, opts.FromDeployment); err != nil {
+			return nil, err
+		}
+	} else if err := i.fetchConfigVersion(ctx, result); err != nil {
 		return nil, err
 	}
 
@@ -43,7 +64,11 @@ func (i *Initializer) Run(ctx context.Context, opts *Options) (*Result, error) {
 
 	i.determineDataFileName(opts, result)
 
-	if err := i.generateFiles(opts, result); err != nil {
+	if opts.Check {
+		return result, i.checkConfigFile(opts, result)
+	}
+
+	if err := i.generateFiles(opts, []*Result{result}); err != nil {
 		return nil, err
 	}
 
@@ -52,6 +77,112 @@ func (i *Initializer) Run(ctx context.Context, opts *Options) (*Result, error) {
 	return result, nil
 }
 
+// runAllEnvironments generates one config file per environment of the
+// application (--all-environments), all pointing at a single data file
+// seeded from the profile's latest hosted configuration version. Each
+// config file takes the deployment strategy of its environment's latest
+// deployment.
+func (i *Initializer) runAllEnvironments(ctx context.Context, opts *Options) error {
+	base, envs, err := i.resolveAllEnvironments(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	if err := i.fetchLatestVersion(ctx, base); err != nil {
+		return err
+	}
+	i.determineDataFileName(opts, base)
+
+	results := make([]*Result, 0, len(envs))
+	for _, env := range envs {
+		result := *base
+		result.EnvID = awssdk.ToString(env.Id)
+		result.EnvName = awssdk.ToString(env.Name)
+		result.ConfigFile = environmentConfigFile(opts.ConfigFile, result.EnvName)
+		i.fetchDeploymentStrategy(ctx, &result)
+		results = append(results, &result)
+	}
+
+	if err := i.generateFiles(opts, results); err != nil {
+		return err
+	}
+
+	i.showNextSteps()
+
+	return nil
+}
+
+// resolveAllEnvironments resolves the application and profile and lists the
+// application's environments for --all-environments. The returned Result has
+// no environment set.
+func (i *Initializer) resolveAllEnvironments(ctx context.Context, opts *Options) (*Result, []types.Environment, error) {
+	sp := i.reporter.Spin("Resolving AWS resources...")
+	resolver := awsInternal.NewResolver(i.awsClient)
+	appID, err := resolver.ResolveApplication(ctx, opts.Application)
+	if err != nil {
+		sp.Stop()
+		return nil, nil, err
+	}
+	profile, err := resolver.ResolveConfigurationProfile(ctx, appID, opts.Profile)
+	if err != nil {
+		sp.Stop()
+		return nil, nil, err
+	}
+	envs, err := i.awsClient.ListAllEnvironments(ctx, appID)
+	if err != nil {
+		sp.Stop()
+		return nil, nil, err
+	}
+	if len(envs) == 0 {
+		sp.Stop()
+		return nil, nil, fmt.Errorf("application %s has no environments", opts.Application)
+	}
+	sp.Done(fmt.Sprintf("Resolved resources: App=%s, Profile=%s, Type=%s, %d environment(s)",
+		opts.Application, opts.Profile, profile.Type, len(envs)))
+
+	return &Result{
+		AppID:       appID,
+		AppName:     opts.Application,
+		ProfileID:   profile.ID,
+		ProfileName: opts.Profile,
+		ProfileType: profile.Type,
+		ConfigFile:  opts.ConfigFile,
+	}, envs, nil
+}
+
+// fetchLatestVersion fetches the newest hosted configuration version of the
+// profile, deployed or not, as the shared data file for --all-environments.
+func (i *Initializer) fetchLatestVersion(ctx context.Context, result *Result) error {
+	sp := i.reporter.Spin("Fetching latest configuration version...")
+	latest, err := awsInternal.GetLatestHostedConfiguration(ctx, i.awsClient, result.AppID, result.ProfileID)
+	if err != nil {
+		sp.Stop()
+		return fmt.Errorf("failed to get latest configuration version: %w", err)
+	}
+	if latest == nil {
+		sp.Done("No configuration versions — config files will be created without data")
+		return nil
+	}
+	sp.Done(fmt.Sprintf("Loaded configuration version %d (%s)", latest.VersionNumber, latest.ContentType))
+	result.DeployedConfig = latest
+	return nil
+}
+
+// environmentConfigFile returns the config file generated for env with
+// --all-environments: configFile with "-<env>" inserted before the
+// extension (apcdeploy.yml -> apcdeploy-prod.yml). Characters other than
+// letters, digits, '.', '_' and '-' in the environment name become '-'.
+func environmentConfigFile(configFile, env string) string {
+	name := strings.Map(func(r rune) rune {
+		if r < utf8.RuneSelf && (r == '.' || r == '_' || r == '-' || unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return r
+		}
+		return '-'
+	}, env)
+	ext := filepath.Ext(configFile)
+	return strings.TrimSuffix(configFile, ext) + "-" + name + ext
+}
+
 // resolveResources resolves AWS resources (Application, Profile, Environment)
 func (i *Initializer) resolveResources(ctx context.Context, opts *Options) (*Result, error) {
 	sp := i.reporter.Spin("Resolving AWS resources...")
@@ -106,6 +237,27 @@ func (i *Initializer) fetchConfigVersion(ctx context.Context, result *Result) er
 	return nil
 }
 
+// fetchConfigFromDeployment fetches the configuration version served by a
+// specific historical deployment (--from-deployment). Unlike
+// fetchConfigVersion, a missing deployment is an error: the user asked for
+// that exact baseline.
+func (i *Initializer) fetchConfigFromDeployment(ctx context.Context, result *Result, deploymentNumber int32) error {
+	sp := i.reporter.Spin(fmt.Sprintf("Fetching configuration from deployment #%d...", deploymentNumber))
+	deployedConfig, err := awsInternal.GetDeployedConfigurationByNumber(ctx, i.awsClient, result.AppID, result.EnvID, result.ProfileID, deploymentNumber)
+	if err != nil {
+		sp.Stop()
+		return fmt.Errorf("failed to get configuration from deployment #%d: %w", deploymentNumber, err)
+	}
+
+	sp.Done(fmt.Sprintf("Loaded configuration from deployment #%d (version %d, %s)",
+		deployedConfig.DeploymentNumber,
+		deployedConfig.VersionNumber,
+		deployedConfig.ContentType))
+
+	result.DeployedConfig = deployedConfig
+	return nil
+}
+
 // fetchDeploymentStrategy fetches the deployment strategy from the latest deployment
 func (i *Initializer) fetchDeploymentStrategy(ctx context.Context, result *Result) {
 	sp := i.reporter.Spin("Fetching latest deployment strategy...")
@@ -141,32 +293,102 @@ func (i *Initializer) determineDataFileName(opts *Options, result *Result) {
 	case opts.OutputData != "":
 		result.DataFile = opts.OutputData
 	case result.DeployedConfig != nil:
-		result.DataFile = config.DetermineDataFileName(result.DeployedConfig.ContentType)
+		result.DataFile = config.DetermineDataFileName(config.EffectiveContentType(result.ProfileType, result.DeployedConfig.ContentType))
 	default:
 		result.DataFile = "data.json" // Default if no version exists
 	}
 }
 
-// generateFiles generates the configuration and data files
-func (i *Initializer) generateFiles(opts *Options, result *Result) error {
+// generateFiles generates the config file of each result and the data file
+// they share (every result carries the same DataFile and DeployedConfig).
+// With --all-environments, files that already exist are skipped rather than
+// overwritten unless --force is set; otherwise an existing file is an error.
+func (i *Initializer) generateFiles(opts *Options, results []*Result) error {
+	skipExisting := opts.AllEnvironments && !opts.Force
+
 	// File writes are instant local operations — no spinner needed; a single
 	// Success line per file is the user-facing signal that the file landed.
-	if err := config.GenerateConfigFile(result.AppName, result.ProfileName, result.EnvName, result.DataFile, i.awsClient.Region, result.DeploymentStrategy, result.ConfigFile, opts.Force); err != nil {
-		return fmt.Errorf("failed to generate config file: %w", err)
+	for _, result := range results {
+		if skipExisting && fileExists(result.ConfigFile) {
+			i.reporter.Info(fmt.Sprintf("Skipped %s (already exists)", result.ConfigFile))
+			continue
+		}
+		if err := config.GenerateConfigFile(result.AppName, result.ProfileName, result.EnvName, result.DataFile, i.awsClient.Region, result.DeploymentStrategy, result.ConfigFile, opts.ConfigFormat, opts.Force); err != nil {
+			return fmt.Errorf("failed to generate config file: %w", err)
+		}
+		i.reporter.Success(fmt.Sprintf("Generated %s", result.ConfigFile))
 	}
-	i.reporter.Success(fmt.Sprintf("Generated %s", result.ConfigFile))
 
-	if result.DeployedConfig != nil {
-		dataFilePath := filepath.Join(filepath.Dir(result.ConfigFile), result.DataFile)
-		if err := config.WriteDataFile(result.DeployedConfig.Content, result.DeployedConfig.ContentType, dataFilePath, result.ProfileType, opts.Force); err != nil {
-			return fmt.Errorf("failed to write data file: %w", err)
-		}
-		i.reporter.Success(fmt.Sprintf("Wrote %s", dataFilePath))
+	result := results[0]
+	if result.DeployedConfig == nil {
+		return nil
+	}
+	dataFilePath := filepath.Join(filepath.Dir(result.ConfigFile), result.DataFile)
+	if skipExisting && fileExists(dataFilePath) {
+		i.reporter.Info(fmt.Sprintf("Skipped %s (already exists)", dataFilePath))
+		return nil
+	}
+	if err := config.WriteDataFile(result.DeployedConfig.Content, config.EffectiveContentType(result.ProfileType, result.DeployedConfig.ContentType), dataFilePath, result.ProfileType, opts.Force); err != nil {
+		return fmt.Errorf("failed to write data file: %w", err)
 	}
+	i.reporter.Success(fmt.Sprintf("Wrote %s", dataFilePath))
 
 	return nil
 }
 
+// fileExists reports whether path exists.
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// checkConfigFile diffs the existing config file against the one init would
+// generate now, without writing anything. Only the fields init generates are
+// compared, so hand-added settings (text_normalize, content_type, ...) never
+// count as drift. Both sides are rendered the same way so formatting and
+// key order do not either.
+func (i *Initializer) checkConfigFile(opts *Options, result *Result) error {
+	existing, err := config.ReadConfigFile(result.ConfigFile)
+	if err != nil {
+		return err
+	}
+
+	format := opts.ConfigFormat
+	if format == "" {
+		format = config.ConfigFormatForPath(result.ConfigFile)
+	}
+
+	// An omitted region falls back to the AWS default region, which is what
+	// init resolved, so it is not drift.
+	region := existing.Region
+	if region == "" {
+		region = i.awsClient.Region
+	}
+
+	current, err := config.RenderConfigFile(existing.Application, existing.ConfigurationProfile, existing.Environment, existing.DataFile, region, existing.DeploymentStrategy, format)
+	if err != nil {
+		return err
+	}
+	expected, err := config.RenderConfigFile(result.AppName, result.ProfileName, result.EnvName, result.DataFile, i.awsClient.Region, result.DeploymentStrategy, format)
+	if err != nil {
+		return err
+	}
+
+	res, err := diff.Calculate(string(current), string(expected), result.ConfigFile, "", config.TextNormalizeOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to calculate diff: %w", err)
+	}
+	if !res.HasChanges {
+		i.reporter.Success(fmt.Sprintf("%s is up to date", result.ConfigFile))
+		return nil
+	}
+
+	res.Relabel("current", "init")
+	i.reporter.Warn(fmt.Sprintf("%s differs from what init would generate (- current, + init)", result.ConfigFile))
+	i.reporter.Diff([]byte(res.UnifiedDiff))
+	return ErrConfigDrift
+}
+
 // showNextSteps displays next steps after initialization.
 func (i *Initializer) showNextSteps() {
 	i.reporter.Success("Initialization complete!")
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -237,6 +238,59 @@ func TestInitializer_FetchConfigVersion(t *testing.T) {
 	}
 }
 
+func TestInitializer_FetchConfigFromDeployment(t *testing.T) {
+	tests := []struct {
+		name      string
+		profileID string
+		wantErr   bool
+	}{
+		{name: "deployment of the selected profile", profileID: "prof-456"},
+		{name: "deployment of another profile", profileID: "prof-other", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mock.MockAppConfigClient{
+				GetDeploymentFunc: func(ctx context.Context, params *appconfig.GetDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.GetDeploymentOutput, error) {
+					return &appconfig.GetDeploymentOutput{
+						DeploymentNumber:       *params.DeploymentNumber,
+						ConfigurationProfileId: aws.String("prof-456"),
+						ConfigurationVersion:   aws.String("2"),
+						State:                  types.DeploymentStateComplete,
+					}, nil
+				},
+				GetHostedConfigurationVersionFunc: func(ctx context.Context, params *appconfig.GetHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.GetHostedConfigurationVersionOutput, error) {
+					return &appconfig.GetHostedConfigurationVersionOutput{
+						Content:     []byte(`{"baseline":true}`),
+						ContentType: aws.String("application/json"),
+					}, nil
+				},
+			}
+
+			reporter := &reportertest.MockReporter{}
+			initializer := New(awsInternal.NewTestClient(mockClient), reporter)
+			result := &Result{AppID: "app-123", ProfileID: tt.profileID, EnvID: "env-789"}
+
+			err := initializer.fetchConfigFromDeployment(context.Background(), result, 4)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.DeployedConfig == nil || result.DeployedConfig.DeploymentNumber != 4 || result.DeployedConfig.VersionNumber != 2 {
+				t.Errorf("unexpected DeployedConfig: %+v", result.DeployedConfig)
+			}
+			if !reporter.HasMessage("Loaded configuration from deployment #4") {
+				t.Errorf("expected spinner done message, got %v", reporter.Messages)
+			}
+		})
+	}
+}
+
 func TestInitializer_FetchDeploymentStrategy(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -774,7 +828,7 @@ func TestInitializer_GenerateFiles(t *testing.T) {
 			reporter := &reportertest.MockReporter{}
 			initializer := New(awsClient, reporter)
 
-			err := initializer.generateFiles(tt.opts, tt.result)
+			err := initializer.generateFiles(tt.opts, []*Result{tt.result})
 
 			if tt.wantErr {
 				if err == nil {
@@ -796,3 +850,229 @@ func TestInitializer_GenerateFiles(t *testing.T) {
 		})
 	}
 }
+
+func TestInitializer_CheckConfigFile(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		existing  string
+		strategy  string
+		wantErr   error
+		wantDiff  []string
+		noFile    bool
+		errSubstr string
+	}{
+		{
+			name: "up to date",
+			existing: `application: test-app
+configuration_profile: test-profile
+environment: test-env
+data_file: data.json
+deployment_strategy: AppConfig.AllAtOnce
+region: us-east-1
+`,
+			strategy: "AppConfig.AllAtOnce",
+		},
+		{
+			name: "strategy changed in AWS",
+			existing: `application: test-app
+configuration_profile: test-profile
+environment: test-env
+data_file: data.json
+deployment_strategy: AppConfig.AllAtOnce
+region: us-east-1
+`,
+			strategy: "AppConfig.Linear50PercentEvery30Seconds",
+			wantErr:  ErrConfigDrift,
+			wantDiff: []string{"-deployment_strategy: AppConfig.AllAtOnce", "+deployment_strategy: AppConfig.Linear50PercentEvery30Seconds"},
+		},
+		{
+			name: "hand-added settings and omitted region are not drift",
+			existing: `application: test-app
+configuration_profile: test-profile
+environment: test-env
+data_file: data.json
+deployment_strategy: AppConfig.AllAtOnce
+text_normalize:
+  - trim_trailing_ws
+`,
+			strategy: "AppConfig.AllAtOnce",
+		},
+		{
+			name:      "missing config file",
+			noFile:    true,
+			strategy:  "AppConfig.AllAtOnce",
+			errSubstr: "failed to read config file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			configPath := t.TempDir() + "/apcdeploy.yml"
+			if !tt.noFile {
+				if err := os.WriteFile(configPath, []byte(tt.existing), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			reporter := &reportertest.MockReporter{}
+			initializer := New(awsInternal.NewTestClient(&mock.MockAppConfigClient{}), reporter)
+			result := &Result{
+				AppName:            "test-app",
+				ProfileName:        "test-profile",
+				EnvName:            "test-env",
+				ConfigFile:         configPath,
+				DataFile:           "data.json",
+				DeploymentStrategy: tt.strategy,
+			}
+
+			err := initializer.checkConfigFile(&Options{ConfigFile: configPath}, result)
+			switch {
+			case tt.errSubstr != "":
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("checkConfigFile() error = %v, want containing %q", err, tt.errSubstr)
+				}
+				return
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("checkConfigFile() error = %v, want %v", err, tt.wantErr)
+				}
+			case err != nil:
+				t.Fatalf("checkConfigFile() unexpected error: %v", err)
+			}
+
+			for _, want := range tt.wantDiff {
+				if !strings.Contains(string(reporter.Stdout), want) {
+					t.Errorf("diff output missing %q:\n%s", want, reporter.Stdout)
+				}
+			}
+			if tt.wantDiff == nil && len(reporter.Stdout) != 0 {
+				t.Errorf("expected no diff output, got %q", reporter.Stdout)
+			}
+
+			got, err := os.ReadFile(configPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.existing {
+				t.Errorf("--check must not modify the config file, got %q", got)
+			}
+		})
+	}
+}
+
+func TestInitializer_RunAllEnvironments(t *testing.T) {
+	tempDir := t.TempDir()
+	configFile := filepath.Join(tempDir, "apcdeploy.yml")
+	existing := filepath.Join(tempDir, "apcdeploy-stg.yml")
+	if err := os.WriteFile(existing, []byte("hand edited\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	m := &mock.MockAppConfigClient{
+		ListApplicationsFunc: func(ctx context.Context, params *appconfig.ListApplicationsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListApplicationsOutput, error) {
+			return &appconfig.ListApplicationsOutput{Items: []types.Application{{Id: aws.String("app-123"), Name: aws.String("test-app")}}}, nil
+		},
+		ListConfigurationProfilesFunc: func(ctx context.Context, params *appconfig.ListConfigurationProfilesInput, optFns ...func(*appconfig.Options)) (*appconfig.ListConfigurationProfilesOutput, error) {
+			return &appconfig.ListConfigurationProfilesOutput{Items: []types.ConfigurationProfileSummary{{Id: aws.String("prof-456"), Name: aws.String("test-profile")}}}, nil
+		},
+		GetConfigurationProfileFunc: func(ctx context.Context, params *appconfig.GetConfigurationProfileInput, optFns ...func(*appconfig.Options)) (*appconfig.GetConfigurationProfileOutput, error) {
+			return &appconfig.GetConfigurationProfileOutput{Id: aws.String("prof-456"), Name: aws.String("test-profile"), Type: aws.String(config.ProfileTypeFreeform)}, nil
+		},
+		ListEnvironmentsFunc: func(ctx context.Context, params *appconfig.ListEnvironmentsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListEnvironmentsOutput, error) {
+			return &appconfig.ListEnvironmentsOutput{Items: []types.Environment{
+				{Id: aws.String("env-prod"), Name: aws.String("prod")},
+				{Id: aws.String("env-stg"), Name: aws.String("stg")},
+				{Id: aws.String("env-dev"), Name: aws.String("dev/eu")},
+			}}, nil
+		},
+		ListHostedConfigurationVersionsFunc: func(ctx context.Context, params *appconfig.ListHostedConfigurationVersionsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListHostedConfigurationVersionsOutput, error) {
+			return &appconfig.ListHostedConfigurationVersionsOutput{Items: []types.HostedConfigurationVersionSummary{{VersionNumber: 2}, {VersionNumber: 5}}}, nil
+		},
+		GetHostedConfigurationVersionFunc: func(ctx context.Context, params *appconfig.GetHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.GetHostedConfigurationVersionOutput, error) {
+			if aws.ToInt32(params.VersionNumber) != 5 {
+				t.Errorf("fetched version %d, want the latest (5)", aws.ToInt32(params.VersionNumber))
+			}
+			return &appconfig.GetHostedConfigurationVersionOutput{Content: []byte(`{"key":"value"}`), ContentType: aws.String(config.ContentTypeJSON)}, nil
+		},
+		// Only prod has been deployed to; the others get the default strategy
+		ListDeploymentsFunc: func(ctx context.Context, params *appconfig.ListDeploymentsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListDeploymentsOutput, error) {
+			if aws.ToString(params.EnvironmentId) != "env-prod" {
+				return &appconfig.ListDeploymentsOutput{}, nil
+			}
+			return &appconfig.ListDeploymentsOutput{Items: []types.DeploymentSummary{{DeploymentNumber: 1, ConfigurationVersion: aws.String("2"), State: types.DeploymentStateComplete}}}, nil
+		},
+		GetDeploymentFunc: func(ctx context.Context, params *appconfig.GetDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.GetDeploymentOutput, error) {
+			return &appconfig.GetDeploymentOutput{DeploymentNumber: 1, ConfigurationProfileId: aws.String("prof-456"), DeploymentStrategyId: aws.String("strategy-canary"), State: types.DeploymentStateComplete}, nil
+		},
+		ListDeploymentStrategiesFunc: func(ctx context.Context, params *appconfig.ListDeploymentStrategiesInput, optFns ...func(*appconfig.Options)) (*appconfig.ListDeploymentStrategiesOutput, error) {
+			return &appconfig.ListDeploymentStrategiesOutput{Items: []types.DeploymentStrategy{{Id: aws.String("strategy-canary"), Name: aws.String("AppConfig.Canary10Percent20Minutes")}}}, nil
+		},
+	}
+	reporter := &reportertest.MockReporter{}
+	initializer := New(awsInternal.NewTestClient(m), reporter)
+
+	result, err := initializer.Run(context.Background(), &Options{
+		Application:     "test-app",
+		Profile:         "test-profile",
+		ConfigFile:      configFile,
+		AllEnvironments: true,
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if result != nil {
+		t.Errorf("Run() result = %+v, want nil with AllEnvironments", result)
+	}
+
+	prod, err := config.LoadConfig(filepath.Join(tempDir, "apcdeploy-prod.yml"))
+	if err != nil {
+		t.Fatalf("prod config: %v", err)
+	}
+	if prod.Environment != "prod" || prod.DeploymentStrategy != "AppConfig.Canary10Percent20Minutes" || filepath.Base(prod.DataFile) != "data.json" {
+		t.Errorf("prod config = %+v", prod)
+	}
+	dev, err := config.LoadConfig(filepath.Join(tempDir, "apcdeploy-dev-eu.yml"))
+	if err != nil {
+		t.Fatalf("dev config: %v", err)
+	}
+	if dev.Environment != "dev/eu" || dev.DeploymentStrategy != config.DefaultDeploymentStrategy || dev.DataFile != prod.DataFile {
+		t.Errorf("dev config = %+v", dev)
+	}
+
+	if got, _ := os.ReadFile(existing); string(got) != "hand edited\n" {
+		t.Errorf("existing config was overwritten: %q", got)
+	}
+	if !reporter.HasMessage("info: Skipped " + existing + " (already exists)") {
+		t.Errorf("expected skip message, got %v", reporter.Messages)
+	}
+	if _, err := os.Stat(filepath.Join(tempDir, "data.json")); err != nil {
+		t.Errorf("shared data file not written: %v", err)
+	}
+	if _, err := os.Stat(configFile); err == nil {
+		t.Error("the plain config file must not be generated")
+	}
+}
+
+func TestEnvironmentConfigFile(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		configFile string
+		env        string
+		want       string
+	}{
+		{"apcdeploy.yml", "prod", "apcdeploy-prod.yml"},
+		{"configs/apcdeploy.json", "staging", "configs/apcdeploy-staging.json"},
+		{"apcdeploy.yml", "eu west/1", "apcdeploy-eu-west-1.yml"},
+	}
+
+	for _, tt := range tests {
+		if got := environmentConfigFile(tt.configFile, tt.env); got != tt.want {
+			t.Errorf("environmentConfigFile(%q, %q) = %q, want %q", tt.configFile, tt.env, got, tt.want)
+		}
+	}
+}
@@ -11,9 +11,22 @@ type Options struct {
 	Environment string
 	Region      string
 	ConfigFile  string
-	OutputData  string
-	Force       bool
-	Silent      bool
+	// ConfigFormat is config.ConfigFormatYAML or config.ConfigFormatJSON.
+	// Empty derives the format from the ConfigFile extension.
+	ConfigFormat string
+	OutputData   string
+	// FromDeployment seeds the data file from this deployment number
+	// instead of the latest deployment. 0 means latest.
+	FromDeployment int32
+	Force          bool
+	Silent         bool
+	// Check compares the existing config file against what init would
+	// generate instead of writing any file (--check)
+	Check bool
+	// AllEnvironments generates one config file per environment of the
+	// application instead of one for Environment, sharing a single data file
+	// seeded from the latest configuration version (--all-environments)
+	AllEnvironments bool
 }
 
 // Result contains the result of initialization
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/account"
 	awsInternal "github.com/koh-sh/apcdeploy/internal/aws"
 	"github.com/koh-sh/apcdeploy/internal/prompt"
@@ -23,7 +22,7 @@ type InitWorkflow struct {
 // NewInitWorkflow creates a new InitWorkflow
 func NewInitWorkflow(ctx context.Context, opts *Options, prompter prompt.Prompter, rep reporter.Reporter) (*InitWorkflow, error) {
 	// Check TTY availability if any interactive prompts will be needed
-	needsInteractive := opts.Region == "" || opts.Application == "" || opts.Profile == "" || opts.Environment == ""
+	needsInteractive := opts.Region == "" || opts.Application == "" || opts.Profile == "" || (opts.Environment == "" && !opts.AllEnvironments)
 	if needsInteractive {
 		if err := prompter.CheckTTY(); err != nil {
 			return nil, fmt.Errorf("%w: please provide --region, --app, --profile, and --env flags", err)
@@ -55,9 +54,9 @@ func SelectOrUseRegion(ctx context.Context, providedRegion string, prompter prom
 		return providedRegion, nil
 	}
 
-	accountCfg, err := config.LoadDefaultConfig(ctx)
+	accountCfg, err := awsInternal.LoadConfig(ctx, "")
 	if err != nil {
-		return "", fmt.Errorf("failed to load AWS config: %w", err)
+		return "", err
 	}
 	accountClient := account.NewFromConfig(accountCfg)
 
@@ -80,7 +79,7 @@ func NewInitWorkflowWithClient(awsClient *awsInternal.Client, prompter prompt.Pr
 // Run executes the initialization workflow
 func (w *InitWorkflow) Run(ctx context.Context, opts *Options) error {
 	// Check TTY availability if any interactive prompts will be needed
-	needsInteractive := opts.Application == "" || opts.Profile == "" || opts.Environment == ""
+	needsInteractive := opts.Application == "" || opts.Profile == "" || (opts.Environment == "" && !opts.AllEnvironments)
 	if needsInteractive {
 		if err := w.prompter.CheckTTY(); err != nil {
 			return fmt.Errorf("%w: please provide --region, --app, --profile, and --env flags", err)
@@ -106,21 +105,28 @@ func (w *InitWorkflow) Run(ctx context.Context, opts *Options) error {
 		return err
 	}
 
-	// Step 6: Environment selection
-	selectedEnv, err := w.selector.SelectEnvironment(ctx, w.awsClient, appID, opts.Environment)
-	if err != nil {
-		return err
+	// Step 6: Environment selection (--all-environments covers every one)
+	var selectedEnv string
+	if !opts.AllEnvironments {
+		selectedEnv, err = w.selector.SelectEnvironment(ctx, w.awsClient, appID, opts.Environment)
+		if err != nil {
+			return err
+		}
 	}
 
 	// Step 7: Create options with selected/provided values
 	finalOpts := &Options{
-		Application: selectedApp,
-		Profile:     selectedProfile,
-		Environment: selectedEnv,
-		Region:      opts.Region,
-		ConfigFile:  opts.ConfigFile,
-		OutputData:  opts.OutputData,
-		Force:       opts.Force,
+		Application:     selectedApp,
+		Profile:         selectedProfile,
+		Environment:     selectedEnv,
+		Region:          opts.Region,
+		ConfigFile:      opts.ConfigFile,
+		ConfigFormat:    opts.ConfigFormat,
+		OutputData:      opts.OutputData,
+		Force:           opts.Force,
+		FromDeployment:  opts.FromDeployment,
+		Check:           opts.Check,
+		AllEnvironments: opts.AllEnvironments,
 	}
 
 	// Step 8: Run existing initialization logic
@@ -38,7 +38,8 @@ func NewExecutorWithFactory(rep reporter.Reporter, factory ClientFactory) *Execu
 //
 // In JSON mode the encoded payload is written to stdout via Reporter.Data;
 // in normal mode the tree is rendered through Reporter.Header / Reporter.Table
-// (stderr, suppressed under --silent).
+// (stderr, suppressed under --silent). opts.Filter is applied to the listed
+// tree before either is rendered.
 func (e *Executor) Execute(ctx context.Context, opts *Options) error {
 	client, err := e.clientFactory(ctx, opts.Region)
 	if err != nil {
@@ -53,6 +54,10 @@ func (e *Executor) Execute(ctx context.Context, opts *Options) error {
 		sp.Stop()
 		return fmt.Errorf("failed to list resources: %w", err)
 	}
+	if err := FilterTree(tree, opts.Filter); err != nil {
+		sp.Stop()
+		return err
+	}
 	sp.Done(fmt.Sprintf("Found %d application(s) in %s", len(tree.Applications), region))
 
 	if opts.JSON {
@@ -67,3 +72,35 @@ func (e *Executor) Execute(ctx context.Context, opts *Options) error {
 	RenderHumanReadable(e.reporter, tree, opts.ShowStrategies)
 	return nil
 }
+
+// ExecuteStrategies lists only the deployment strategies in opts.Region.
+// ShowStrategies is implied; JSON selects the stdout payload as in Execute.
+func (e *Executor) ExecuteStrategies(ctx context.Context, opts *Options) error {
+	client, err := e.clientFactory(ctx, opts.Region)
+	if err != nil {
+		return fmt.Errorf("failed to create AWS client: %w", err)
+	}
+
+	region := client.Region
+	sp := e.reporter.Spin(fmt.Sprintf("Fetching deployment strategies (%s)...", region))
+
+	strategies, err := New(client, region).ListDeploymentStrategies(ctx)
+	if err != nil {
+		sp.Stop()
+		// Already wrapped as "failed to list deployment strategies" by the client.
+		return err
+	}
+	sp.Done(fmt.Sprintf("Found %d deployment strategy(ies) in %s", len(strategies), region))
+
+	if opts.JSON {
+		payload, err := FormatStrategiesJSON(region, strategies)
+		if err != nil {
+			return fmt.Errorf("failed to format JSON output: %w", err)
+		}
+		e.reporter.Data(payload)
+		return nil
+	}
+
+	RenderStrategies(e.reporter, region, strategies)
+	return nil
+}
@@ -2,6 +2,7 @@ package lsresources
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"strings"
 	"testing"
@@ -340,3 +341,94 @@ func TestExecutor_Execute(t *testing.T) {
 		})
 	}
 }
+
+func TestExecutor_ExecuteStrategies(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		json           bool
+		validateOutput func(*testing.T, *reporterTesting.MockReporter)
+	}{
+		{
+			name: "JSON output includes every page",
+			json: true,
+			validateOutput: func(t *testing.T, r *reporterTesting.MockReporter) {
+				var got struct {
+					Region               string               `json:"region"`
+					DeploymentStrategies []DeploymentStrategy `json:"deployment_strategies"`
+				}
+				if err := json.Unmarshal(r.Stdout, &got); err != nil {
+					t.Fatalf("invalid JSON: %v\n%s", err, r.Stdout)
+				}
+				if got.Region != "us-east-1" || len(got.DeploymentStrategies) != 2 {
+					t.Fatalf("unexpected payload: %+v", got)
+				}
+				if got.DeploymentStrategies[0].Name != "AppConfig.AllAtOnce" || got.DeploymentStrategies[1].GrowthType != "LINEAR" {
+					t.Errorf("unexpected strategies: %+v", got.DeploymentStrategies)
+				}
+			},
+		},
+		{
+			name: "text output renders table without applications",
+			validateOutput: func(t *testing.T, r *reporterTesting.MockReporter) {
+				output := reporterText(r)
+				if !strings.Contains(output, "Custom.Linear|strat-2|30m|10m|20.0%|LINEAR|") {
+					t.Errorf("expected strategy row, got: %s", output)
+				}
+				if strings.Contains(output, "Applications") {
+					t.Errorf("strategies output should not list applications, got: %s", output)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockAppConfig := &awsMock.MockAppConfigClient{}
+			mockAppConfig.ListDeploymentStrategiesFunc = func(ctx context.Context, params *appconfig.ListDeploymentStrategiesInput, optFns ...func(*appconfig.Options)) (*appconfig.ListDeploymentStrategiesOutput, error) {
+				if params.NextToken == nil {
+					return &appconfig.ListDeploymentStrategiesOutput{
+						Items: []appconfigTypes.DeploymentStrategy{
+							{Name: aws.String("Custom.Linear"), Id: aws.String("strat-2"), DeploymentDurationInMinutes: 30, FinalBakeTimeInMinutes: 10, GrowthFactor: aws.Float32(20), GrowthType: appconfigTypes.GrowthTypeLinear},
+						},
+						NextToken: aws.String("page-2"),
+					}, nil
+				}
+				return &appconfig.ListDeploymentStrategiesOutput{
+					Items: []appconfigTypes.DeploymentStrategy{
+						{Name: aws.String("AppConfig.AllAtOnce"), Id: aws.String("strat-1"), GrowthFactor: aws.Float32(100), GrowthType: appconfigTypes.GrowthTypeLinear},
+					},
+				}, nil
+			}
+
+			factory := func(ctx context.Context, region string) (*awsInternal.Client, error) {
+				return awsInternal.NewTestClient(mockAppConfig), nil
+			}
+			mockReporter := &reporterTesting.MockReporter{}
+			err := NewExecutorWithFactory(mockReporter, factory).ExecuteStrategies(context.Background(), &Options{JSON: tt.json})
+			if err != nil {
+				t.Fatalf("ExecuteStrategies() error = %v", err)
+			}
+			tt.validateOutput(t, mockReporter)
+		})
+	}
+}
+
+func TestExecutor_ExecuteStrategiesError(t *testing.T) {
+	t.Parallel()
+
+	mockAppConfig := &awsMock.MockAppConfigClient{}
+	mockAppConfig.ListDeploymentStrategiesFunc = func(ctx context.Context, params *appconfig.ListDeploymentStrategiesInput, optFns ...func(*appconfig.Options)) (*appconfig.ListDeploymentStrategiesOutput, error) {
+		return nil, errors.New("access denied")
+	}
+	factory := func(ctx context.Context, region string) (*awsInternal.Client, error) {
+		return awsInternal.NewTestClient(mockAppConfig), nil
+	}
+
+	err := NewExecutorWithFactory(&reporterTesting.MockReporter{}, factory).ExecuteStrategies(context.Background(), &Options{})
+	if err == nil || !strings.Contains(err.Error(), "failed to list deployment strategies") {
+		t.Errorf("expected list error, got: %v", err)
+	}
+}
@@ -0,0 +1,44 @@
+package lsresources
+
+import (
+	"fmt"
+
+	"github.com/koh-sh/apcdeploy/internal/config"
+)
+
+// FilterTree drops the applications and configuration profiles rejected by
+// f. With a profile regex, applications left without a matching profile are
+// dropped too, so the tree only shows where the pattern hits. Environments
+// and deployment strategies are kept as listed. It returns an error when
+// --app names an application that does not exist.
+func FilterTree(tree *ResourcesTree, f *config.ProfileFilter) error {
+	if f == nil {
+		return nil
+	}
+
+	apps := make([]Application, 0, len(tree.Applications))
+	foundApp := false
+	for _, app := range tree.Applications {
+		if !f.MatchApplication(app.Name) {
+			continue
+		}
+		foundApp = true
+
+		profiles := make([]ConfigurationProfile, 0, len(app.Profiles))
+		for _, p := range app.Profiles {
+			if f.MatchProfile(p.Name) {
+				profiles = append(profiles, p)
+			}
+		}
+		if f.ProfileRegex != nil && len(profiles) == 0 {
+			continue
+		}
+		app.Profiles = profiles
+		apps = append(apps, app)
+	}
+	if f.Application != "" && !foundApp {
+		return fmt.Errorf("application %q not found in %s", f.Application, tree.Region)
+	}
+	tree.Applications = apps
+	return nil
+}
@@ -0,0 +1,113 @@
+package lsresources
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/koh-sh/apcdeploy/internal/config"
+)
+
+func newFilterTestTree() *ResourcesTree {
+	return &ResourcesTree{
+		Region: "us-east-1",
+		Applications: []Application{
+			{
+				Name:         "api",
+				Profiles:     []ConfigurationProfile{{Name: "feature-flags"}, {Name: "settings"}},
+				Environments: []Environment{{Name: "prod"}},
+			},
+			{
+				Name:         "web",
+				Profiles:     []ConfigurationProfile{{Name: "feature-ui"}, {Name: "theme"}},
+				Environments: []Environment{{Name: "prod"}, {Name: "staging"}},
+			},
+			{
+				Name:     "batch",
+				Profiles: []ConfigurationProfile{{Name: "jobs"}},
+			},
+		},
+	}
+}
+
+// treeNames flattens a tree into "app/profile" entries.
+func treeNames(tree *ResourcesTree) []string {
+	var names []string
+	for _, app := range tree.Applications {
+		for _, p := range app.Profiles {
+			names = append(names, app.Name+"/"+p.Name)
+		}
+		if len(app.Profiles) == 0 {
+			names = append(names, app.Name+"/")
+		}
+	}
+	return names
+}
+
+func TestFilterTree(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		app          string
+		profileRegex string
+		want         []string
+		wantErr      string
+	}{
+		{
+			name: "no filter keeps everything",
+			want: []string{"api/feature-flags", "api/settings", "web/feature-ui", "web/theme", "batch/jobs"},
+		},
+		{
+			name:         "regex drops applications without a match",
+			profileRegex: "^feature-",
+			want:         []string{"api/feature-flags", "web/feature-ui"},
+		},
+		{
+			name: "app keeps all of its profiles",
+			app:  "web",
+			want: []string{"web/feature-ui", "web/theme"},
+		},
+		{
+			name:         "app and regex",
+			app:          "web",
+			profileRegex: "theme|flags",
+			want:         []string{"web/theme"},
+		},
+		{
+			name:         "existing app without a match is empty",
+			app:          "batch",
+			profileRegex: "^feature-",
+		},
+		{
+			name:    "unknown app",
+			app:     "missing",
+			wantErr: `application "missing" not found in us-east-1`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := config.NewProfileFilter(tt.app, tt.profileRegex)
+			if err != nil {
+				t.Fatalf("NewProfileFilter() error = %v", err)
+			}
+			tree := newFilterTestTree()
+			err = FilterTree(tree, f)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got: %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FilterTree() error = %v", err)
+			}
+			if got := treeNames(tree); !slices.Equal(got, tt.want) {
+				t.Errorf("FilterTree() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -25,6 +25,29 @@ func FormatJSON(tree *ResourcesTree, showStrategies bool) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+// FormatStrategiesJSON encodes a deployment strategy list as indented JSON
+// for the strategies command.
+func FormatStrategiesJSON(region string, strategies []DeploymentStrategy) ([]byte, error) {
+	payload := struct {
+		Region               string               `json:"region"`
+		DeploymentStrategies []DeploymentStrategy `json:"deployment_strategies"`
+	}{Region: region, DeploymentStrategies: strategies}
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(payload); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// RenderStrategies renders only the deployment-strategies section, preceded
+// by the region header. Used by the strategies command.
+func RenderStrategies(r reporter.Reporter, region string, strategies []DeploymentStrategy) {
+	r.Header(fmt.Sprintf("Region: %s", region))
+	renderStrategies(r, strategies)
+}
+
 // RenderHumanReadable renders the resources tree through Reporter primitives
 // (Header / Table). Color and ANSI handling stay inside the Reporter
 // implementation; this function only describes structure and content.
@@ -37,27 +60,7 @@ func RenderHumanReadable(r reporter.Reporter, tree *ResourcesTree, showStrategie
 	r.Header(fmt.Sprintf("Region: %s", tree.Region))
 
 	if showStrategies {
-		r.Header("Deployment Strategies")
-		if len(tree.DeploymentStrategies) == 0 {
-			r.Info("No deployment strategies found.")
-		} else {
-			rows := make([][]string, 0, len(tree.DeploymentStrategies))
-			for _, s := range tree.DeploymentStrategies {
-				rows = append(rows, []string{
-					s.Name,
-					s.ID,
-					fmt.Sprintf("%dm", s.DeploymentDurationInMinutes),
-					fmt.Sprintf("%dm", s.FinalBakeTimeInMinutes),
-					fmt.Sprintf("%.1f%%", s.GrowthFactor),
-					s.GrowthType,
-					s.Description,
-				})
-			}
-			r.Table(
-				[]string{"Name", "ID", "Duration", "Bake Time", "Growth", "Type", "Description"},
-				rows,
-			)
-		}
+		renderStrategies(r, tree.DeploymentStrategies)
 	}
 
 	if len(tree.Applications) == 0 {
@@ -90,3 +93,29 @@ func RenderHumanReadable(r reporter.Reporter, tree *ResourcesTree, showStrategie
 		}
 	}
 }
+
+// renderStrategies emits the "Deployment Strategies" header and table, or an
+// Info line when the region has none.
+func renderStrategies(r reporter.Reporter, strategies []DeploymentStrategy) {
+	r.Header("Deployment Strategies")
+	if len(strategies) == 0 {
+		r.Info("No deployment strategies found.")
+		return
+	}
+	rows := make([][]string, 0, len(strategies))
+	for _, s := range strategies {
+		rows = append(rows, []string{
+			s.Name,
+			s.ID,
+			fmt.Sprintf("%dm", s.DeploymentDurationInMinutes),
+			fmt.Sprintf("%dm", s.FinalBakeTimeInMinutes),
+			fmt.Sprintf("%.1f%%", s.GrowthFactor),
+			s.GrowthType,
+			s.Description,
+		})
+	}
+	r.Table(
+		[]string{"Name", "ID", "Duration", "Bake Time", "Growth", "Type", "Description"},
+		rows,
+	)
+}
@@ -31,7 +31,7 @@ func (l *Lister) ListResources(ctx context.Context) (*ResourcesTree, error) {
 	}
 
 	// List deployment strategies
-	strategies, err := l.listDeploymentStrategies(ctx)
+	strategies, err := l.ListDeploymentStrategies(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list deployment strategies: %w", err)
 	}
@@ -131,8 +131,9 @@ func (l *Lister) listEnvironments(ctx context.Context, appID string) ([]Environm
 	return environments, nil
 }
 
-// listDeploymentStrategies fetches all deployment strategies
-func (l *Lister) listDeploymentStrategies(ctx context.Context) ([]DeploymentStrategy, error) {
+// ListDeploymentStrategies fetches all deployment strategies (predefined and
+// custom), sorted by name
+func (l *Lister) ListDeploymentStrategies(ctx context.Context) ([]DeploymentStrategy, error) {
 	items, err := l.client.ListAllDeploymentStrategies(ctx)
 	if err != nil {
 		return nil, err
@@ -1,5 +1,7 @@
 package lsresources
 
+import "github.com/koh-sh/apcdeploy/internal/config"
+
 // Options contains the configuration options for listing resources
 type Options struct {
 	// Region specifies the AWS region (empty string uses SDK default)
@@ -8,4 +10,7 @@ type Options struct {
 	JSON bool
 	// ShowStrategies includes deployment strategies in output
 	ShowStrategies bool
+	// Filter limits the tree to one application (--app) and the profiles
+	// whose names match --profile-regex (nil lists everything)
+	Filter *config.ProfileFilter
 }
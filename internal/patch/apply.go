@@ -0,0 +1,404 @@
+package patch
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// ApplyMergePatch applies an RFC 7386 JSON merge patch to doc and returns the
+// resulting JSON document.
+func ApplyMergePatch(doc, patch []byte) ([]byte, error) {
+	target, err := decodeJSON(doc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid document: %w", err)
+	}
+	p, err := decodeJSON(patch)
+	if err != nil {
+		return nil, fmt.Errorf("invalid merge patch: %w", err)
+	}
+	return json.Marshal(mergePatch(target, p))
+}
+
+// mergePatch implements the MergePatch pseudo-code from RFC 7386 section 2:
+// objects merge recursively, null removes a member, anything else replaces
+// the target wholesale.
+func mergePatch(target, patch any) any {
+	p, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	t, ok := target.(map[string]any)
+	if !ok {
+		t = map[string]any{}
+	}
+	for key, value := range p {
+		if value == nil {
+			delete(t, key)
+			continue
+		}
+		t[key] = mergePatch(t[key], value)
+	}
+	return t
+}
+
+// operation is one entry of an RFC 6902 JSON patch document.
+type operation struct {
+	Op    string           `json:"op"`
+	Path  *string          `json:"path"`
+	From  *string          `json:"from"`
+	Value *json.RawMessage `json:"value"`
+}
+
+// ApplyJSONPatch applies an RFC 6902 JSON patch to doc and returns the
+// resulting JSON document. Operations are applied in order; the first
+// failing operation (including a failed "test") aborts the whole patch.
+func ApplyJSONPatch(doc, patch []byte) ([]byte, error) {
+	target, err := decodeJSON(doc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid document: %w", err)
+	}
+	var ops []operation
+	if err := json.Unmarshal(patch, &ops); err != nil {
+		return nil, fmt.Errorf("invalid JSON patch: %w", err)
+	}
+
+	for i, op := range ops {
+		target, err = applyOperation(target, op)
+		if err != nil {
+			return nil, fmt.Errorf("operation %d (%s): %w", i, op.Op, err)
+		}
+	}
+	return json.Marshal(target)
+}
+
+// applyOperation applies a single JSON patch operation and returns the new
+// document root (the root itself changes when path is "").
+func applyOperation(doc any, op operation) (any, error) {
+	if op.Path == nil {
+		return nil, fmt.Errorf("missing path")
+	}
+	path, err := parsePointer(*op.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	value := func() (any, error) {
+		if op.Value == nil {
+			return nil, fmt.Errorf("missing value")
+		}
+		return decodeJSON(*op.Value)
+	}
+	from := func() ([]string, error) {
+		if op.From == nil {
+			return nil, fmt.Errorf("missing from")
+		}
+		return parsePointer(*op.From)
+	}
+
+	switch op.Op {
+	case "add":
+		v, err := value()
+		if err != nil {
+			return nil, err
+		}
+		return add(doc, path, v)
+	case "remove":
+		doc, _, err := remove(doc, path)
+		return doc, err
+	case "replace":
+		v, err := value()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := get(doc, path); err != nil {
+			return nil, err
+		}
+		doc, _, err := remove(doc, path)
+		if err != nil {
+			return nil, err
+		}
+		return add(doc, path, v)
+	case "move":
+		src, err := from()
+		if err != nil {
+			return nil, err
+		}
+		if isProperPrefix(src, path) {
+			return nil, fmt.Errorf("cannot move %q into one of its children", *op.From)
+		}
+		doc, v, err := remove(doc, src)
+		if err != nil {
+			return nil, err
+		}
+		return add(doc, path, v)
+	case "copy":
+		src, err := from()
+		if err != nil {
+			return nil, err
+		}
+		v, err := get(doc, src)
+		if err != nil {
+			return nil, err
+		}
+		// Deep-copy through JSON so later operations on the copy do not
+		// alias the source.
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		cp, err := decodeJSON(raw)
+		if err != nil {
+			return nil, err
+		}
+		return add(doc, path, cp)
+	case "test":
+		v, err := value()
+		if err != nil {
+			return nil, err
+		}
+		actual, err := get(doc, path)
+		if err != nil {
+			return nil, err
+		}
+		if !valuesEqual(actual, v) {
+			return nil, fmt.Errorf("test failed at %q", *op.Path)
+		}
+		return doc, nil
+	default:
+		return nil, fmt.Errorf("unsupported op %q", op.Op)
+	}
+}
+
+// numberPrec is the mantissa precision used to compare JSON numbers; it
+// keeps integers of up to 512 bits exact.
+const numberPrec = 512
+
+// valuesEqual reports whether two decoded JSON values are equal in the sense
+// of RFC 6902 section 4.6: numbers compare by value (1, 1.0 and 1e0 are
+// equal), objects by members regardless of order, arrays element-wise.
+func valuesEqual(a, b any) bool {
+	switch av := a.(type) {
+	case map[string]any:
+		bv, ok := b.(map[string]any)
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for k, x := range av {
+			y, ok := bv[k]
+			if !ok || !valuesEqual(x, y) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		bv, ok := b.([]any)
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for i := range av {
+			if !valuesEqual(av[i], bv[i]) {
+				return false
+			}
+		}
+		return true
+	case json.Number:
+		bv, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		x, _, errA := big.ParseFloat(string(av), 10, numberPrec, big.ToNearestEven)
+		y, _, errB := big.ParseFloat(string(bv), 10, numberPrec, big.ToNearestEven)
+		if errA != nil || errB != nil {
+			return av == bv
+		}
+		return x.Cmp(y) == 0
+	default:
+		return a == b
+	}
+}
+
+// parsePointer splits an RFC 6901 JSON pointer into unescaped reference
+// tokens. The empty pointer "" refers to the whole document.
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid JSON pointer %q: must start with /", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, tok := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(tok, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+// get returns the value at path.
+func get(doc any, path []string) (any, error) {
+	cur := doc
+	for _, tok := range path {
+		switch node := cur.(type) {
+		case map[string]any:
+			v, ok := node[tok]
+			if !ok {
+				return nil, fmt.Errorf("path not found: %s", formatPointer(path))
+			}
+			cur = v
+		case []any:
+			idx, err := arrayIndex(tok, len(node)-1)
+			if err != nil {
+				return nil, err
+			}
+			cur = node[idx]
+		default:
+			return nil, fmt.Errorf("path not found: %s", formatPointer(path))
+		}
+	}
+	return cur, nil
+}
+
+// add inserts value at path following the "add" semantics of RFC 6902:
+// object members are created or replaced, array elements are inserted
+// ("-" appends), and the root is replaced when path is empty.
+func add(doc any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	parent, err := get(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	last := path[len(path)-1]
+	switch node := parent.(type) {
+	case map[string]any:
+		node[last] = value
+		return doc, nil
+	case []any:
+		idx := len(node)
+		if last != "-" {
+			if idx, err = arrayIndex(last, len(node)); err != nil {
+				return nil, err
+			}
+		}
+		grown := append(node[:idx:idx], append([]any{value}, node[idx:]...)...)
+		return set(doc, path[:len(path)-1], grown)
+	default:
+		return nil, fmt.Errorf("path not found: %s", formatPointer(path))
+	}
+}
+
+// remove deletes the value at path and returns the updated document along
+// with the removed value.
+func remove(doc any, path []string) (any, any, error) {
+	if len(path) == 0 {
+		return nil, doc, nil
+	}
+	parent, err := get(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, nil, err
+	}
+	last := path[len(path)-1]
+	switch node := parent.(type) {
+	case map[string]any:
+		v, ok := node[last]
+		if !ok {
+			return nil, nil, fmt.Errorf("path not found: %s", formatPointer(path))
+		}
+		delete(node, last)
+		return doc, v, nil
+	case []any:
+		idx, err := arrayIndex(last, len(node)-1)
+		if err != nil {
+			return nil, nil, err
+		}
+		v := node[idx]
+		shrunk := append(node[:idx:idx], node[idx+1:]...)
+		doc, err := set(doc, path[:len(path)-1], shrunk)
+		return doc, v, err
+	default:
+		return nil, nil, fmt.Errorf("path not found: %s", formatPointer(path))
+	}
+}
+
+// set replaces the value at path. Arrays are re-sliced by add/remove, so
+// the new slice has to be stored back into its parent.
+func set(doc any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	parent, err := get(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	last := path[len(path)-1]
+	switch node := parent.(type) {
+	case map[string]any:
+		node[last] = value
+	case []any:
+		idx, err := arrayIndex(last, len(node)-1)
+		if err != nil {
+			return nil, err
+		}
+		node[idx] = value
+	}
+	return doc, nil
+}
+
+// arrayIndex parses an array reference token and checks it is within
+// [0, upper].
+func arrayIndex(tok string, upper int) (int, error) {
+	if tok == "" || (len(tok) > 1 && tok[0] == '0') {
+		return 0, fmt.Errorf("invalid array index %q", tok)
+	}
+	idx, err := strconv.Atoi(tok)
+	if err != nil || idx < 0 {
+		return 0, fmt.Errorf("invalid array index %q", tok)
+	}
+	if idx > upper {
+		return 0, fmt.Errorf("array index %d out of range", idx)
+	}
+	return idx, nil
+}
+
+// isProperPrefix reports whether prefix is a strict ancestor of path.
+func isProperPrefix(prefix, path []string) bool {
+	if len(prefix) >= len(path) {
+		return false
+	}
+	for i := range prefix {
+		if prefix[i] != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// formatPointer renders reference tokens back into a JSON pointer for error
+// messages.
+func formatPointer(path []string) string {
+	var b strings.Builder
+	for _, tok := range path {
+		b.WriteString("/")
+		b.WriteString(strings.ReplaceAll(strings.ReplaceAll(tok, "~", "~0"), "/", "~1"))
+	}
+	return b.String()
+}
+
+// decodeJSON decodes data keeping numbers as json.Number so large integers
+// survive the round trip unchanged.
+func decodeJSON(data []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("unexpected data after JSON value")
+	}
+	return v, nil
+}
@@ -0,0 +1,223 @@
+package patch
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// jsonEqual compares two JSON documents ignoring formatting and key order.
+func jsonEqual(t *testing.T, got []byte, want string) bool {
+	t.Helper()
+	var g, w any
+	if err := json.Unmarshal(got, &g); err != nil {
+		t.Fatalf("result is not valid JSON: %v (%s)", err, got)
+	}
+	if err := json.Unmarshal([]byte(want), &w); err != nil {
+		t.Fatalf("want is not valid JSON: %v", err)
+	}
+	return reflect.DeepEqual(g, w)
+}
+
+func TestApplyMergePatch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		doc     string
+		patch   string
+		want    string
+		exact   bool // compare bytes instead of decoded values
+		wantErr bool
+	}{
+		{
+			name:  "replace and add members",
+			doc:   `{"a":"b","c":{"d":"e","f":"g"}}`,
+			patch: `{"a":"z","c":{"f":null},"h":1}`,
+			want:  `{"a":"z","c":{"d":"e"},"h":1}`,
+		},
+		{
+			name:  "null removes a member",
+			doc:   `{"a":"b","b":"c"}`,
+			patch: `{"a":null}`,
+			want:  `{"b":"c"}`,
+		},
+		{
+			name:  "arrays are replaced wholesale",
+			doc:   `{"a":[{"b":"c"}]}`,
+			patch: `{"a":[1]}`,
+			want:  `{"a":[1]}`,
+		},
+		{
+			name:  "non-object patch replaces the document",
+			doc:   `{"a":"foo"}`,
+			patch: `["c"]`,
+			want:  `["c"]`,
+		},
+		{
+			name:  "object patch over a scalar member",
+			doc:   `{"a":"foo"}`,
+			patch: `{"a":{"bb":{"ccc":null}}}`,
+			want:  `{"a":{"bb":{}}}`,
+		},
+		{
+			name:  "large integers are preserved",
+			doc:   `{"id":9007199254740993}`,
+			patch: `{"name":"x"}`,
+			want:  `{"id":9007199254740993,"name":"x"}`,
+			exact: true,
+		},
+		{name: "invalid document", doc: `{`, patch: `{}`, wantErr: true},
+		{name: "invalid patch", doc: `{}`, patch: `{`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ApplyMergePatch([]byte(tt.doc), []byte(tt.patch))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ApplyMergePatch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if tt.exact {
+				if string(got) != tt.want {
+					t.Errorf("ApplyMergePatch() = %s, want %s", got, tt.want)
+				}
+				return
+			}
+			if !jsonEqual(t, got, tt.want) {
+				t.Errorf("ApplyMergePatch() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyJSONPatch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		doc     string
+		patch   string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "add object member",
+			doc:   `{"foo":"bar"}`,
+			patch: `[{"op":"add","path":"/baz","value":"qux"}]`,
+			want:  `{"baz":"qux","foo":"bar"}`,
+		},
+		{
+			name:  "add array element",
+			doc:   `{"foo":["bar","baz"]}`,
+			patch: `[{"op":"add","path":"/foo/1","value":"qux"}]`,
+			want:  `{"foo":["bar","qux","baz"]}`,
+		},
+		{
+			name:  "append with dash",
+			doc:   `{"foo":[1]}`,
+			patch: `[{"op":"add","path":"/foo/-","value":2}]`,
+			want:  `{"foo":[1,2]}`,
+		},
+		{
+			name:  "remove object member",
+			doc:   `{"baz":"qux","foo":"bar"}`,
+			patch: `[{"op":"remove","path":"/baz"}]`,
+			want:  `{"foo":"bar"}`,
+		},
+		{
+			name:  "remove array element",
+			doc:   `{"foo":["bar","qux","baz"]}`,
+			patch: `[{"op":"remove","path":"/foo/1"}]`,
+			want:  `{"foo":["bar","baz"]}`,
+		},
+		{
+			name:  "replace value",
+			doc:   `{"baz":"qux","foo":"bar"}`,
+			patch: `[{"op":"replace","path":"/baz","value":"boo"}]`,
+			want:  `{"baz":"boo","foo":"bar"}`,
+		},
+		{
+			name:  "move value",
+			doc:   `{"foo":{"bar":"baz","waldo":"fred"},"qux":{"corge":"grault"}}`,
+			patch: `[{"op":"move","from":"/foo/waldo","path":"/qux/thud"}]`,
+			want:  `{"foo":{"bar":"baz"},"qux":{"corge":"grault","thud":"fred"}}`,
+		},
+		{
+			name:  "move array element",
+			doc:   `{"foo":["all","grass","cows","eat"]}`,
+			patch: `[{"op":"move","from":"/foo/1","path":"/foo/3"}]`,
+			want:  `{"foo":["all","cows","eat","grass"]}`,
+		},
+		{
+			name:  "copy value",
+			doc:   `{"a":{"b":1}}`,
+			patch: `[{"op":"copy","from":"/a","path":"/c"},{"op":"replace","path":"/c/b","value":2}]`,
+			want:  `{"a":{"b":1},"c":{"b":2}}`,
+		},
+		{
+			name:  "test passes",
+			doc:   `{"baz":"qux","foo":["a",2,"c"]}`,
+			patch: `[{"op":"test","path":"/baz","value":"qux"},{"op":"test","path":"/foo/1","value":2}]`,
+			want:  `{"baz":"qux","foo":["a",2,"c"]}`,
+		},
+		{
+			name:  "escaped pointer tokens",
+			doc:   `{"a/b":1,"m~n":2}`,
+			patch: `[{"op":"replace","path":"/a~1b","value":3},{"op":"remove","path":"/m~0n"}]`,
+			want:  `{"a/b":3}`,
+		},
+		{
+			name:  "replace root",
+			doc:   `{"a":1}`,
+			patch: `[{"op":"replace","path":"","value":{"b":2}}]`,
+			want:  `{"b":2}`,
+		},
+		{
+			name:  "test compares numbers by value",
+			doc:   `{"a":1.0,"b":100,"c":12345678901234567890}`,
+			patch: `[{"op":"test","path":"/a","value":1},{"op":"test","path":"/b","value":1e2},{"op":"test","path":"/c","value":12345678901234567890.0}]`,
+			want:  `{"a":1.0,"b":100,"c":12345678901234567890}`,
+		},
+		{
+			name:  "test compares nested objects",
+			doc:   `{"a":{"x":[1,{"y":2.0}],"z":null,"s":"t"}}`,
+			patch: `[{"op":"test","path":"/a","value":{"s":"t","z":null,"x":[1.0,{"y":2}]}}]`,
+			want:  `{"a":{"x":[1,{"y":2.0}],"z":null,"s":"t"}}`,
+		},
+		{name: "test fails on nearby large integer", doc: `{"a":12345678901234567890}`, patch: `[{"op":"test","path":"/a","value":12345678901234567891}]`, wantErr: true},
+		{name: "test fails on nested difference", doc: `{"a":{"x":[1,2]}}`, patch: `[{"op":"test","path":"/a","value":{"x":[1,3]}}]`, wantErr: true},
+		{name: "test fails on number against string", doc: `{"a":1}`, patch: `[{"op":"test","path":"/a","value":"1"}]`, wantErr: true},
+		{name: "test fails", doc: `{"baz":"qux"}`, patch: `[{"op":"test","path":"/baz","value":"bar"}]`, wantErr: true},
+		{name: "remove missing member", doc: `{"a":1}`, patch: `[{"op":"remove","path":"/b"}]`, wantErr: true},
+		{name: "replace missing member", doc: `{"a":1}`, patch: `[{"op":"replace","path":"/b","value":1}]`, wantErr: true},
+		{name: "add to missing parent", doc: `{"a":1}`, patch: `[{"op":"add","path":"/b/c","value":1}]`, wantErr: true},
+		{name: "array index out of range", doc: `{"a":[1]}`, patch: `[{"op":"add","path":"/a/5","value":1}]`, wantErr: true},
+		{name: "leading zero index", doc: `{"a":[1,2]}`, patch: `[{"op":"remove","path":"/a/01"}]`, wantErr: true},
+		{name: "move into own child", doc: `{"a":{"b":1}}`, patch: `[{"op":"move","from":"/a","path":"/a/c"}]`, wantErr: true},
+		{name: "unsupported op", doc: `{}`, patch: `[{"op":"frobnicate","path":"/a"}]`, wantErr: true},
+		{name: "missing value", doc: `{}`, patch: `[{"op":"add","path":"/a"}]`, wantErr: true},
+		{name: "missing path", doc: `{}`, patch: `[{"op":"remove"}]`, wantErr: true},
+		{name: "pointer without slash", doc: `{"a":1}`, patch: `[{"op":"remove","path":"a"}]`, wantErr: true},
+		{name: "patch is not an array", doc: `{}`, patch: `{"op":"add"}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ApplyJSONPatch([]byte(tt.doc), []byte(tt.patch))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ApplyJSONPatch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !jsonEqual(t, got, tt.want) {
+				t.Errorf("ApplyJSONPatch() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
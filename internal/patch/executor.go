@@ -0,0 +1,169 @@
+package patch
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/koh-sh/apcdeploy/internal/aws"
+	"github.com/koh-sh/apcdeploy/internal/config"
+	"github.com/koh-sh/apcdeploy/internal/diff"
+	"github.com/koh-sh/apcdeploy/internal/reporter"
+	"github.com/koh-sh/apcdeploy/internal/run"
+)
+
+// Executor handles the patch operation orchestration
+type Executor struct {
+	reporter      reporter.Reporter
+	clientFactory func(context.Context, string) (*aws.Client, error)
+}
+
+// NewExecutor creates a new patch executor
+func NewExecutor(rep reporter.Reporter) *Executor {
+	return &Executor{
+		reporter:      rep,
+		clientFactory: aws.NewClient,
+	}
+}
+
+// NewExecutorWithFactory creates a new patch executor with a custom client factory
+// This is useful for testing with mock clients
+func NewExecutorWithFactory(rep reporter.Reporter, factory func(context.Context, string) (*aws.Client, error)) *Executor {
+	return &Executor{
+		reporter:      rep,
+		clientFactory: factory,
+	}
+}
+
+// Execute fetches the deployed configuration, applies the patch, writes the
+// result to the local data file, and deploys it through the run workflow.
+//
+// Output shape:
+//   - patched:        ✓ patched <data-file-path>, followed by the run row
+//   - no deployment:  ✗ failed: no deployment found  (returns aws.ErrNoDeployment)
+//   - patch errors:   ✗ failed: <message>; the data file is left untouched
+//
+// When ShowDiff is set the diff between the deployed and patched content is
+// written to stdout before deploying.
+func (e *Executor) Execute(ctx context.Context, opts *Options) error {
+	if (opts.MergePatchFile == "") == (opts.JSONPatchFile == "") {
+		return fmt.Errorf("exactly one of --merge-patch or --json-patch is required")
+	}
+	if opts.Timeout < 0 {
+		return fmt.Errorf("timeout must be a non-negative value")
+	}
+	if opts.WaitDeploy && opts.WaitBake {
+		return fmt.Errorf("--wait-deploy and --wait-bake cannot be used together")
+	}
+
+	cfg, err := config.LoadConfig(opts.ConfigFile)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	cfg.ApplyRegionOverride(opts.Region)
+	if err := cfg.RequireDataFilePath("patch"); err != nil {
+		return err
+	}
+
+	patchFile, apply := opts.MergePatchFile, ApplyMergePatch
+	if opts.JSONPatchFile != "" {
+		patchFile, apply = opts.JSONPatchFile, ApplyJSONPatch
+	}
+	patchData, err := os.ReadFile(patchFile)
+	if err != nil {
+		return fmt.Errorf("failed to read patch file: %w", err)
+	}
+
+	awsClient, err := e.clientFactory(ctx, cfg.Region)
+	if err != nil {
+		return fmt.Errorf("failed to initialize AWS client: %w", err)
+	}
+
+	if err := e.patchDataFile(ctx, awsClient, cfg, patchData, apply, opts.ShowDiff); err != nil {
+		return err
+	}
+
+	runExecutor := run.NewExecutorWithFactory(e.reporter, func(_ context.Context, cfg *config.Config) (*run.Deployer, error) {
+		return run.NewWithClient(cfg, awsClient), nil
+	})
+	return runExecutor.Execute(ctx, &run.Options{
+		ConfigFile:            opts.ConfigFile,
+		WaitDeploy:            opts.WaitDeploy,
+		WaitBake:              opts.WaitBake,
+		Timeout:               opts.Timeout,
+		VersionDescription:    opts.Description,
+		DeploymentDescription: opts.Description,
+		PollBackoff:           opts.PollBackoff,
+		PollInterval:          opts.PollInterval,
+		Region:                opts.Region,
+		AcceptLongBake:        opts.AcceptLongBake,
+	})
+}
+
+// patchDataFile applies the patch to the deployed content and overwrites the
+// local data file with the validated result. It runs in its own Targets row
+// so the row is closed before run opens the deployment row.
+func (e *Executor) patchDataFile(ctx context.Context, awsClient *aws.Client, cfg *config.Config, patchData []byte, apply func(doc, patch []byte) ([]byte, error), showDiff bool) error {
+	id := config.Identifier(awsClient.Region, cfg)
+	tg := e.reporter.Targets([]string{id})
+	defer tg.Close()
+	tg.SetPhase(id, "fetching", "")
+
+	resolver := aws.NewResolver(awsClient)
+	resources, err := resolver.ResolveAll(ctx, cfg.Application, cfg.ConfigurationProfile, cfg.Environment, "")
+	if err != nil {
+		tg.Fail(id, err)
+		return fmt.Errorf("failed to resolve resources: %w", err)
+	}
+
+	deployed, err := aws.GetLatestDeployedConfiguration(ctx, awsClient, resources.ApplicationID, resources.EnvironmentID, resources.Profile.ID)
+	if err != nil {
+		tg.Fail(id, err)
+		return fmt.Errorf("failed to get latest deployed configuration: %w", err)
+	}
+	if deployed == nil {
+		tg.Fail(id, aws.ErrNoDeployment)
+		return fmt.Errorf("%w: run 'apcdeploy run' to create the first deployment", aws.ErrNoDeployment)
+	}
+	if contentType := config.EffectiveContentType(resources.Profile.Type, deployed.ContentType); !strings.EqualFold(config.ExtensionForContentType(contentType), ".json") {
+		err := fmt.Errorf("patch requires JSON content, but the deployed content type is %s", deployed.ContentType)
+		tg.Fail(id, err)
+		return err
+	}
+
+	patched, err := apply(deployed.Content, patchData)
+	if err != nil {
+		tg.Fail(id, err)
+		return fmt.Errorf("failed to apply patch: %w", err)
+	}
+	if err := config.ValidateData(patched, config.ContentTypeJSON); err != nil {
+		tg.Fail(id, err)
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	if err := config.WriteDataFile(patched, config.ContentTypeJSON, cfg.DataFile, resources.Profile.Type, true); err != nil {
+		tg.Fail(id, err)
+		return fmt.Errorf("failed to write data file: %w", err)
+	}
+
+	if showDiff {
+		written, err := config.LoadDataFile(cfg.DataFile)
+		if err != nil {
+			tg.Fail(id, err)
+			return fmt.Errorf("failed to read data file: %w", err)
+		}
+		result, err := diff.Calculate(string(deployed.Content), string(written), cfg.DataFile, resources.Profile.Type, cfg.TextNormalizeOptions())
+		if err != nil {
+			tg.Fail(id, err)
+			return fmt.Errorf("failed to calculate diff: %w", err)
+		}
+		result.Redact(cfg.RedactFields)
+		if result.HasChanges {
+			e.reporter.Diff([]byte(result.UnifiedDiff))
+		}
+	}
+
+	tg.Done(id, "patched "+cfg.DataFile)
+	return nil
+}
@@ -0,0 +1,296 @@
+package patch
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig/types"
+	awsInternal "github.com/koh-sh/apcdeploy/internal/aws"
+	"github.com/koh-sh/apcdeploy/internal/aws/mock"
+	reportertest "github.com/koh-sh/apcdeploy/internal/reporter/testing"
+)
+
+// newPatchMock returns a mock client with one deployed version holding
+// content, and records the content uploaded by CreateHostedConfigurationVersion.
+func newPatchMock(content []byte, contentType string, uploaded *[]byte) *mock.MockAppConfigClient {
+	m := mock.NewResolvingClient("AWS.Freeform")
+	m.ListDeploymentsFunc = func(ctx context.Context, params *appconfig.ListDeploymentsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListDeploymentsOutput, error) {
+		return &appconfig.ListDeploymentsOutput{
+			Items: []types.DeploymentSummary{{DeploymentNumber: 1, State: types.DeploymentStateComplete, ConfigurationVersion: aws.String("1")}},
+		}, nil
+	}
+	m.GetDeploymentFunc = func(ctx context.Context, params *appconfig.GetDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.GetDeploymentOutput, error) {
+		return &appconfig.GetDeploymentOutput{
+			ApplicationId:          aws.String("app-123"),
+			EnvironmentId:          aws.String("env-123"),
+			DeploymentNumber:       1,
+			ConfigurationProfileId: aws.String("profile-123"),
+			ConfigurationVersion:   aws.String("1"),
+			DeploymentStrategyId:   aws.String("strategy-123"),
+			State:                  types.DeploymentStateComplete,
+		}, nil
+	}
+	m.GetHostedConfigurationVersionFunc = func(ctx context.Context, params *appconfig.GetHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.GetHostedConfigurationVersionOutput, error) {
+		return &appconfig.GetHostedConfigurationVersionOutput{
+			VersionNumber: 1,
+			Content:       content,
+			ContentType:   aws.String(contentType),
+		}, nil
+	}
+	m.CreateHostedConfigurationVersionFunc = func(ctx context.Context, params *appconfig.CreateHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.CreateHostedConfigurationVersionOutput, error) {
+		*uploaded = params.Content
+		return &appconfig.CreateHostedConfigurationVersionOutput{VersionNumber: 2}, nil
+	}
+	m.StartDeploymentFunc = func(ctx context.Context, params *appconfig.StartDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.StartDeploymentOutput, error) {
+		return &appconfig.StartDeploymentOutput{DeploymentNumber: 2}, nil
+	}
+	return m
+}
+
+// writePatchFixtures writes apcdeploy.yml, a stale data.json and the patch
+// file, returning the config and patch paths.
+func writePatchFixtures(t *testing.T, patch string) (string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "apcdeploy.yml")
+	configContent := `application: test-app
+configuration_profile: test-profile
+environment: test-env
+data_file: data.json
+region: us-east-1
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "data.json"), []byte(`{"stale": true}`), 0o644); err != nil {
+		t.Fatalf("Failed to write data: %v", err)
+	}
+	patchPath := filepath.Join(dir, "patch.json")
+	if err := os.WriteFile(patchPath, []byte(patch), 0o644); err != nil {
+		t.Fatalf("Failed to write patch: %v", err)
+	}
+	return configPath, patchPath
+}
+
+func TestExecutorValidateOptions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		opts    *Options
+		wantErr string
+	}{
+		{"no patch", &Options{ConfigFile: "apcdeploy.yml"}, "exactly one of"},
+		{"both patches", &Options{ConfigFile: "apcdeploy.yml", MergePatchFile: "a.json", JSONPatchFile: "b.json"}, "exactly one of"},
+		{"negative timeout", &Options{ConfigFile: "apcdeploy.yml", MergePatchFile: "a.json", Timeout: -1}, "timeout"},
+		{"both wait flags", &Options{ConfigFile: "apcdeploy.yml", MergePatchFile: "a.json", WaitDeploy: true, WaitBake: true}, "cannot be used together"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := NewExecutor(&reportertest.MockReporter{}).Execute(context.Background(), tt.opts)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Execute() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExecutorPatchAndDeploy(t *testing.T) {
+	t.Parallel()
+
+	deployed := []byte(`{"feature":{"enabled":false,"limit":10},"name":"svc"}`)
+
+	tests := []struct {
+		name       string
+		merge      bool
+		patch      string
+		showDiff   bool
+		wantInFile []string
+	}{
+		{
+			name:       "merge patch",
+			merge:      true,
+			patch:      `{"feature":{"enabled":true}}`,
+			wantInFile: []string{`"enabled": true`, `"limit": 10`, `"name": "svc"`},
+		},
+		{
+			name:       "json patch with diff",
+			patch:      `[{"op":"replace","path":"/feature/limit","value":20},{"op":"remove","path":"/name"}]`,
+			showDiff:   true,
+			wantInFile: []string{`"enabled": false`, `"limit": 20`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			configPath, patchPath := writePatchFixtures(t, tt.patch)
+			var uploaded []byte
+			mockClient := newPatchMock(deployed, "application/json", &uploaded)
+			factory := func(ctx context.Context, region string) (*awsInternal.Client, error) {
+				return awsInternal.NewTestClient(mockClient), nil
+			}
+
+			reporter := &reportertest.MockReporter{}
+			opts := &Options{ConfigFile: configPath, ShowDiff: tt.showDiff, Timeout: 300}
+			if tt.merge {
+				opts.MergePatchFile = patchPath
+			} else {
+				opts.JSONPatchFile = patchPath
+			}
+			if err := NewExecutorWithFactory(reporter, factory).Execute(context.Background(), opts); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			written, err := os.ReadFile(filepath.Join(filepath.Dir(configPath), "data.json"))
+			if err != nil {
+				t.Fatalf("Failed to read data file: %v", err)
+			}
+			for _, want := range tt.wantInFile {
+				if !strings.Contains(string(written), want) {
+					t.Errorf("data file missing %q:\n%s", want, written)
+				}
+			}
+			if string(uploaded) != string(written) {
+				t.Errorf("uploaded content = %s, want the written data file %s", uploaded, written)
+			}
+
+			// The diff is only emitted when requested (TTY mode).
+			if gotDiff := len(reporter.Stdout) > 0; gotDiff != tt.showDiff {
+				t.Errorf("diff emitted = %v, want %v (stdout: %s)", gotDiff, tt.showDiff, reporter.Stdout)
+			}
+
+			// One row for the patch step, one for the deployment.
+			if len(reporter.TargetsCalls) != 2 {
+				t.Fatalf("expected 2 Targets calls, got %d", len(reporter.TargetsCalls))
+			}
+			foundPatched := false
+			for _, tr := range reporter.TargetsCalls[0].Transitions {
+				if tr.Kind == "done" && strings.HasPrefix(tr.Summary, "patched ") {
+					foundPatched = true
+				}
+			}
+			if !foundPatched {
+				t.Errorf("expected 'patched <path>' done summary, got: %+v", reporter.TargetsCalls[0].Transitions)
+			}
+		})
+	}
+}
+
+func TestExecutorPatchErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		content     string
+		contentType string
+		patch       string
+		wantErr     string
+	}{
+		{"failed test op", `{"a":1}`, "application/json", `[{"op":"test","path":"/a","value":2}]`, "failed to apply patch"},
+		{"non-JSON content", "a: 1\n", "application/x-yaml", `[{"op":"remove","path":"/a"}]`, "patch requires JSON content"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			configPath, patchPath := writePatchFixtures(t, tt.patch)
+			var uploaded []byte
+			mockClient := newPatchMock([]byte(tt.content), tt.contentType, &uploaded)
+			factory := func(ctx context.Context, region string) (*awsInternal.Client, error) {
+				return awsInternal.NewTestClient(mockClient), nil
+			}
+
+			opts := &Options{ConfigFile: configPath, JSONPatchFile: patchPath}
+			err := NewExecutorWithFactory(&reportertest.MockReporter{}, factory).Execute(context.Background(), opts)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Execute() error = %v, want containing %q", err, tt.wantErr)
+			}
+			if uploaded != nil {
+				t.Error("nothing should be deployed when patching fails")
+			}
+			written, _ := os.ReadFile(filepath.Join(filepath.Dir(configPath), "data.json"))
+			if string(written) != `{"stale": true}` {
+				t.Errorf("data file should be untouched, got %s", written)
+			}
+		})
+	}
+}
+
+func TestExecutorNoDeployment(t *testing.T) {
+	t.Parallel()
+
+	configPath, patchPath := writePatchFixtures(t, `{}`)
+	var uploaded []byte
+	mockClient := newPatchMock(nil, "application/json", &uploaded)
+	mockClient.ListDeploymentsFunc = func(ctx context.Context, params *appconfig.ListDeploymentsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListDeploymentsOutput, error) {
+		return &appconfig.ListDeploymentsOutput{}, nil
+	}
+	factory := func(ctx context.Context, region string) (*awsInternal.Client, error) {
+		return awsInternal.NewTestClient(mockClient), nil
+	}
+
+	opts := &Options{ConfigFile: configPath, MergePatchFile: patchPath}
+	err := NewExecutorWithFactory(&reportertest.MockReporter{}, factory).Execute(context.Background(), opts)
+	if !errors.Is(err, awsInternal.ErrNoDeployment) {
+		t.Errorf("Execute() error = %v, want ErrNoDeployment", err)
+	}
+}
+
+// TestExecutorRegionOverride checks that --region takes precedence over the
+// config file's region when creating the AWS client.
+func TestExecutorRegionOverride(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "apcdeploy.yml")
+	configContent := `application: test-app
+configuration_profile: test-profile
+environment: test-env
+data_file: data.json
+region: us-east-1
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	patchPath := filepath.Join(tempDir, "patch.json")
+	if err := os.WriteFile(patchPath, []byte(`{"key": "value"}`), 0o644); err != nil {
+		t.Fatalf("Failed to write patch: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		override string
+		want     string
+	}{
+		{name: "flag overrides config", override: "eu-west-1", want: "eu-west-1"},
+		{name: "config region without flag", override: "", want: "us-east-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got string
+			factory := func(_ context.Context, region string) (*awsInternal.Client, error) {
+				got = region
+				return nil, errors.New("stop")
+			}
+			executor := NewExecutorWithFactory(&reportertest.MockReporter{}, factory)
+			_ = executor.Execute(context.Background(), &Options{ConfigFile: configPath, Region: tt.override, MergePatchFile: patchPath})
+			if got != tt.want {
+				t.Errorf("region used = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
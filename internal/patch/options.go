@@ -0,0 +1,30 @@
+package patch
+
+import "time"
+
+// Options contains the configuration options for the patch command
+type Options struct {
+	ConfigFile string
+	// MergePatchFile is an RFC 7386 merge patch to apply (--merge-patch)
+	MergePatchFile string
+	// JSONPatchFile is an RFC 6902 JSON patch to apply (--json-patch)
+	JSONPatchFile string
+	// ShowDiff emits the diff between the deployed and patched content
+	// before deploying; the command sets it when stdout is a TTY
+	ShowDiff    bool
+	WaitDeploy  bool
+	WaitBake    bool
+	Timeout     int
+	Description string
+	// PollBackoff polls deployment status with exponential backoff instead
+	// of a fixed interval while waiting (--poll-backoff)
+	PollBackoff bool
+	// PollInterval replaces the polling interval while waiting
+	// (--poll-interval); zero keeps the default
+	PollInterval time.Duration
+	// Region overrides the region from the config file (--region)
+	Region string
+	// AcceptLongBake lets WaitBake proceed with a strategy estimated above
+	// run.DefaultLongBakeThreshold (--accept-long-bake)
+	AcceptLongBake bool
+}
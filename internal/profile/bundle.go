@@ -0,0 +1,143 @@
+package profile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig/types"
+	awsInternal "github.com/koh-sh/apcdeploy/internal/aws"
+)
+
+// BundleVersion is the schema version written to every bundle. It is bumped
+// only for incompatible changes to the JSON layout.
+const BundleVersion = 1
+
+// Bundle is a portable copy of one configuration profile: its definition
+// and, for hosted profiles, the content of its newest hosted version.
+// Resource IDs are deliberately absent so a bundle can be imported into any
+// application or account.
+type Bundle struct {
+	BundleVersion    int         `json:"bundle_version"`
+	Name             string      `json:"name"`
+	Description      string      `json:"description,omitempty"`
+	Type             string      `json:"type"`
+	LocationURI      string      `json:"location_uri"`
+	RetrievalRoleARN string      `json:"retrieval_role_arn,omitempty"`
+	Validators       []Validator `json:"validators"`
+	Version          *Version    `json:"version"`
+}
+
+// Validator is one profile validator. Content is the JSON Schema document
+// for JSON_SCHEMA validators and the function ARN for LAMBDA validators.
+type Validator struct {
+	Type    string `json:"type"`
+	Content string `json:"content"`
+}
+
+// Version is the newest hosted configuration version; nil in the bundle
+// (JSON null) for non-hosted profiles and hosted profiles without versions.
+// Content is base64 in JSON so binary payloads survive the round trip.
+type Version struct {
+	Number      int32  `json:"number"`
+	ContentType string `json:"content_type"`
+	Content     []byte `json:"content"`
+}
+
+// newBundle builds a bundle from a profile definition and its optional
+// newest hosted version.
+func newBundle(def *awsInternal.ProfileDefinition, version *Version) *Bundle {
+	b := &Bundle{
+		BundleVersion:    BundleVersion,
+		Name:             def.Name,
+		Description:      def.Description,
+		Type:             def.Type,
+		LocationURI:      def.LocationURI,
+		RetrievalRoleARN: def.RetrievalRoleARN,
+		Validators:       []Validator{},
+		Version:          version,
+	}
+	for _, v := range def.Validators {
+		b.Validators = append(b.Validators, Validator{Type: string(v.Type), Content: aws.ToString(v.Content)})
+	}
+	return b
+}
+
+// Encode renders the bundle as indented JSON with a trailing newline.
+func (b *Bundle) Encode() ([]byte, error) {
+	out, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode profile bundle: %w", err)
+	}
+	return append(out, '\n'), nil
+}
+
+// LoadBundle reads and validates a bundle written by profile export.
+func LoadBundle(path string) (*Bundle, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read profile bundle: %w", err)
+	}
+	var b Bundle
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("failed to parse profile bundle %s: %w", path, err)
+	}
+	if err := b.validate(); err != nil {
+		return nil, fmt.Errorf("invalid profile bundle %s: %w", path, err)
+	}
+	return &b, nil
+}
+
+func (b *Bundle) validate() error {
+	if b.BundleVersion != BundleVersion {
+		return fmt.Errorf("unsupported bundle_version %d (expected %d)", b.BundleVersion, BundleVersion)
+	}
+	if b.Name == "" {
+		return errors.New("name is required")
+	}
+	if b.LocationURI == "" {
+		return errors.New("location_uri is required")
+	}
+	for i, v := range b.Validators {
+		switch types.ValidatorType(v.Type) {
+		case types.ValidatorTypeJsonSchema, types.ValidatorTypeLambda:
+		default:
+			return fmt.Errorf("validators[%d]: unknown type %q", i, v.Type)
+		}
+	}
+	if b.Version != nil && b.LocationURI != awsInternal.LocationHosted {
+		return fmt.Errorf("version is only supported for %q profiles, not %q", awsInternal.LocationHosted, b.LocationURI)
+	}
+	return nil
+}
+
+// definition converts the bundle back into a profile definition. LAMBDA
+// validators reference a function ARN in the source account, so they are
+// dropped unless keepLambda is set; the dropped ARNs are returned for the
+// caller to warn about.
+func (b *Bundle) definition(name, retrievalRoleARN string, keepLambda bool) (*awsInternal.ProfileDefinition, []string) {
+	def := &awsInternal.ProfileDefinition{
+		Name:             b.Name,
+		Description:      b.Description,
+		Type:             b.Type,
+		LocationURI:      b.LocationURI,
+		RetrievalRoleARN: b.RetrievalRoleARN,
+	}
+	if name != "" {
+		def.Name = name
+	}
+	if retrievalRoleARN != "" {
+		def.RetrievalRoleARN = retrievalRoleARN
+	}
+	var dropped []string
+	for _, v := range b.Validators {
+		if types.ValidatorType(v.Type) == types.ValidatorTypeLambda && !keepLambda {
+			dropped = append(dropped, v.Content)
+			continue
+		}
+		def.Validators = append(def.Validators, types.Validator{Type: types.ValidatorType(v.Type), Content: aws.String(v.Content)})
+	}
+	return def, dropped
+}
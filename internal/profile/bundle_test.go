@@ -0,0 +1,83 @@
+package profile
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadBundle(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "valid hosted bundle",
+			content: `{"bundle_version":1,"name":"p","type":"AWS.Freeform","location_uri":"hosted","validators":[{"type":"JSON_SCHEMA","content":"{}"}],"version":{"number":2,"content_type":"application/json","content":"e30="}}`,
+		},
+		{name: "not json", content: `nope`, wantErr: "failed to parse profile bundle"},
+		{name: "unsupported version", content: `{"bundle_version":2,"name":"p","location_uri":"hosted"}`, wantErr: "unsupported bundle_version 2"},
+		{name: "missing name", content: `{"bundle_version":1,"location_uri":"hosted"}`, wantErr: "name is required"},
+		{name: "missing location", content: `{"bundle_version":1,"name":"p"}`, wantErr: "location_uri is required"},
+		{name: "unknown validator", content: `{"bundle_version":1,"name":"p","location_uri":"hosted","validators":[{"type":"REGEX","content":"x"}]}`, wantErr: `validators[0]: unknown type "REGEX"`},
+		{name: "content on non-hosted profile", content: `{"bundle_version":1,"name":"p","location_uri":"s3://b/k","version":{"number":1}}`, wantErr: `version is only supported for "hosted" profiles`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			path := filepath.Join(t.TempDir(), "bundle.json")
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatalf("failed to write bundle: %v", err)
+			}
+
+			b, err := LoadBundle(path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if b.Version == nil || string(b.Version.Content) != "{}" {
+				t.Errorf("content did not round-trip: %+v", b.Version)
+			}
+		})
+	}
+}
+
+func TestBundleDefinition(t *testing.T) {
+	t.Parallel()
+
+	b := &Bundle{
+		Name:             "p",
+		LocationURI:      "hosted",
+		RetrievalRoleARN: "arn:source-role",
+		Validators: []Validator{
+			{Type: "JSON_SCHEMA", Content: "{}"},
+			{Type: "LAMBDA", Content: "arn:fn"},
+		},
+	}
+
+	def, dropped := b.definition("", "", false)
+	if def.Name != "p" || def.RetrievalRoleARN != "arn:source-role" {
+		t.Errorf("unexpected definition: %+v", def)
+	}
+	if len(def.Validators) != 1 || len(dropped) != 1 || dropped[0] != "arn:fn" {
+		t.Errorf("expected the LAMBDA validator to be dropped, got validators %+v dropped %v", def.Validators, dropped)
+	}
+
+	def, dropped = b.definition("renamed", "arn:target-role", true)
+	if def.Name != "renamed" || def.RetrievalRoleARN != "arn:target-role" {
+		t.Errorf("overrides not applied: %+v", def)
+	}
+	if len(def.Validators) != 2 || len(dropped) != 0 {
+		t.Errorf("expected both validators kept, got %+v dropped %v", def.Validators, dropped)
+	}
+}
@@ -0,0 +1,186 @@
+// Package profile implements `apcdeploy profile export` and `profile import`,
+// which back up a configuration profile's definition and newest hosted
+// content to a portable bundle and recreate it elsewhere.
+package profile
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/koh-sh/apcdeploy/internal/aws"
+	"github.com/koh-sh/apcdeploy/internal/config"
+	"github.com/koh-sh/apcdeploy/internal/reporter"
+)
+
+// Executor handles the profile export and import orchestration
+type Executor struct {
+	reporter      reporter.Reporter
+	clientFactory func(context.Context, string) (*aws.Client, error)
+}
+
+// NewExecutor creates a new profile executor
+func NewExecutor(rep reporter.Reporter) *Executor {
+	return &Executor{
+		reporter:      rep,
+		clientFactory: aws.NewClient,
+	}
+}
+
+// NewExecutorWithFactory creates a new profile executor with a custom client factory
+// This is useful for testing with mock clients
+func NewExecutorWithFactory(rep reporter.Reporter, factory func(context.Context, string) (*aws.Client, error)) *Executor {
+	return &Executor{
+		reporter:      rep,
+		clientFactory: factory,
+	}
+}
+
+// Export writes the bundle of the configured profile to stdout. It only
+// reads: the content comes from the newest hosted configuration version,
+// whether or not it was ever deployed.
+func (e *Executor) Export(ctx context.Context, opts *ExportOptions) error {
+	cfg, err := config.LoadConfig(opts.ConfigFile)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	cfg.ApplyRegionOverride(opts.Region)
+
+	awsClient, err := e.clientFactory(ctx, cfg.Region)
+	if err != nil {
+		return fmt.Errorf("failed to initialize AWS client: %w", err)
+	}
+
+	id := profileIdentifier(awsClient.Region, cfg.Application, cfg.ConfigurationProfile)
+	tg := e.reporter.Targets([]string{id})
+	defer tg.Close()
+	tg.SetPhase(id, "fetching", "")
+
+	bundle, err := e.buildBundle(ctx, awsClient, cfg.Application, cfg.ConfigurationProfile)
+	if err != nil {
+		tg.Fail(id, err)
+		return err
+	}
+	out, err := bundle.Encode()
+	if err != nil {
+		tg.Fail(id, err)
+		return err
+	}
+
+	summary := "exported (definition only)"
+	if bundle.Version != nil {
+		summary = fmt.Sprintf("exported — v%d", bundle.Version.Number)
+	}
+	tg.Done(id, summary)
+	tg.Close()
+	e.reporter.Data(out)
+	return nil
+}
+
+// buildBundle resolves the profile and assembles its bundle.
+func (e *Executor) buildBundle(ctx context.Context, client *aws.Client, appName, profileName string) (*Bundle, error) {
+	resolver := aws.NewResolver(client)
+	appID, err := resolver.ResolveApplication(ctx, appName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve application: %w", err)
+	}
+	profile, err := resolver.ResolveConfigurationProfile(ctx, appID, profileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve configuration profile: %w", err)
+	}
+
+	def, err := client.GetProfileDefinition(ctx, appID, profile.ID)
+	if err != nil {
+		return nil, err
+	}
+	if def.LocationURI != aws.LocationHosted {
+		return newBundle(def, nil), nil
+	}
+
+	latest, err := aws.GetLatestHostedConfiguration(ctx, client, appID, profile.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest hosted configuration version: %w", err)
+	}
+	if latest == nil {
+		return newBundle(def, nil), nil
+	}
+	return newBundle(def, &Version{
+		Number:      latest.VersionNumber,
+		ContentType: latest.ContentType,
+		Content:     latest.Content,
+	}), nil
+}
+
+// Import recreates the profile stored in a bundle inside opts.Application,
+// followed by a hosted version holding the bundled content when there is
+// one. It refuses to touch an existing profile of the same name.
+func (e *Executor) Import(ctx context.Context, opts *ImportOptions) error {
+	bundle, err := LoadBundle(opts.File)
+	if err != nil {
+		return err
+	}
+	def, dropped := bundle.definition(opts.Name, opts.RetrievalRoleARN, opts.KeepLambdaValidators)
+
+	awsClient, err := e.clientFactory(ctx, opts.Region)
+	if err != nil {
+		return fmt.Errorf("failed to initialize AWS client: %w", err)
+	}
+
+	for _, arn := range dropped {
+		e.reporter.Warn(fmt.Sprintf("dropping LAMBDA validator %s: function ARNs are account-specific; re-add it after import or pass --keep-lambda-validators", arn))
+	}
+
+	id := profileIdentifier(awsClient.Region, opts.Application, def.Name)
+	tg := e.reporter.Targets([]string{id})
+	defer tg.Close()
+	tg.SetPhase(id, "resolving", "")
+
+	summary, err := e.importBundle(ctx, awsClient, opts.Application, bundle, def, func(phase string) { tg.SetPhase(id, phase, "") })
+	if err != nil {
+		tg.Fail(id, err)
+		return err
+	}
+	tg.Done(id, summary)
+	return nil
+}
+
+// importBundle creates the profile and its version, reporting phase changes
+// through setPhase, and returns the row summary.
+func (e *Executor) importBundle(ctx context.Context, client *aws.Client, appName string, bundle *Bundle, def *aws.ProfileDefinition, setPhase func(string)) (string, error) {
+	appID, err := aws.NewResolver(client).ResolveApplication(ctx, appName)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve application: %w", err)
+	}
+	existing, err := client.ListAllConfigurationProfiles(ctx, appID)
+	if err != nil {
+		return "", fmt.Errorf("failed to list configuration profiles: %w", err)
+	}
+	for _, p := range existing {
+		if p.Name != nil && *p.Name == def.Name {
+			return "", fmt.Errorf("configuration profile %q already exists in application %q; pass --name to import under a different name", def.Name, appName)
+		}
+	}
+
+	setPhase("creating-profile")
+	profileID, err := client.CreateConfigurationProfile(ctx, appID, def)
+	if err != nil {
+		return "", err
+	}
+	if bundle.Version == nil {
+		return "imported (definition only)", nil
+	}
+
+	setPhase("creating-version")
+	description := fmt.Sprintf("Imported from v%d by apcdeploy profile import", bundle.Version.Number)
+	version, err := client.CreateHostedConfigurationVersion(ctx, appID, profileID, bundle.Version.Content, bundle.Version.ContentType, description)
+	if err != nil {
+		return "", fmt.Errorf("profile %q was created (ID %s) but its content was not: %w", def.Name, profileID, err)
+	}
+	return fmt.Sprintf("imported — v%d (from v%d)", version, bundle.Version.Number), nil
+}
+
+// profileIdentifier is the row id of a profile-level target. Profiles are
+// not tied to an environment, so the id stops at the profile name.
+func profileIdentifier(region, appName, profileName string) string {
+	return strings.Join([]string{region, appName, profileName}, "/")
+}
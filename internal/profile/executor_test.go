@@ -0,0 +1,256 @@
+package profile
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig/types"
+	awsInternal "github.com/koh-sh/apcdeploy/internal/aws"
+	"github.com/koh-sh/apcdeploy/internal/aws/mock"
+	reportertest "github.com/koh-sh/apcdeploy/internal/reporter/testing"
+)
+
+// newSourceMock returns a client holding one profile at locationURI with
+// a JSON_SCHEMA and a LAMBDA validator and hosted versions 1 and 3.
+func newSourceMock(locationURI string) *mock.MockAppConfigClient {
+	m := mock.NewResolvingClient("AWS.Freeform")
+	m.GetConfigurationProfileFunc = func(ctx context.Context, params *appconfig.GetConfigurationProfileInput, optFns ...func(*appconfig.Options)) (*appconfig.GetConfigurationProfileOutput, error) {
+		return &appconfig.GetConfigurationProfileOutput{
+			Id:          aws.String("profile-123"),
+			Name:        aws.String("test-profile"),
+			Description: aws.String("service settings"),
+			Type:        aws.String("AWS.Freeform"),
+			LocationUri: aws.String(locationURI),
+			Validators: []types.Validator{
+				{Type: types.ValidatorTypeJsonSchema, Content: aws.String(`{"type":"object"}`)},
+				{Type: types.ValidatorTypeLambda, Content: aws.String("arn:aws:lambda:us-east-1:111111111111:function:check")},
+			},
+		}, nil
+	}
+	m.ListHostedConfigurationVersionsFunc = func(ctx context.Context, params *appconfig.ListHostedConfigurationVersionsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListHostedConfigurationVersionsOutput, error) {
+		return &appconfig.ListHostedConfigurationVersionsOutput{Items: []types.HostedConfigurationVersionSummary{{VersionNumber: 1}, {VersionNumber: 3}}}, nil
+	}
+	m.GetHostedConfigurationVersionFunc = func(ctx context.Context, params *appconfig.GetHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.GetHostedConfigurationVersionOutput, error) {
+		if aws.ToInt32(params.VersionNumber) != 3 {
+			return nil, errors.New("expected the newest version to be fetched")
+		}
+		return &appconfig.GetHostedConfigurationVersionOutput{VersionNumber: 3, Content: []byte(`{"key":"value"}`), ContentType: aws.String("application/json")}, nil
+	}
+	return m
+}
+
+// targetCalls records what import sent to the target account.
+type targetCalls struct {
+	profile *appconfig.CreateConfigurationProfileInput
+	version *appconfig.CreateHostedConfigurationVersionInput
+}
+
+// newTargetMock returns a client for the import target whose application
+// "dest-app" already holds the profiles named in existing.
+func newTargetMock(calls *targetCalls, existing ...string) *mock.MockAppConfigClient {
+	return &mock.MockAppConfigClient{
+		ListApplicationsFunc: func(ctx context.Context, params *appconfig.ListApplicationsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListApplicationsOutput, error) {
+			return &appconfig.ListApplicationsOutput{Items: []types.Application{{Id: aws.String("dest-id"), Name: aws.String("dest-app")}}}, nil
+		},
+		ListConfigurationProfilesFunc: func(ctx context.Context, params *appconfig.ListConfigurationProfilesInput, optFns ...func(*appconfig.Options)) (*appconfig.ListConfigurationProfilesOutput, error) {
+			out := &appconfig.ListConfigurationProfilesOutput{}
+			for _, name := range existing {
+				out.Items = append(out.Items, types.ConfigurationProfileSummary{Id: aws.String("id-" + name), Name: aws.String(name)})
+			}
+			return out, nil
+		},
+		CreateConfigurationProfileFunc: func(ctx context.Context, params *appconfig.CreateConfigurationProfileInput, optFns ...func(*appconfig.Options)) (*appconfig.CreateConfigurationProfileOutput, error) {
+			calls.profile = params
+			return &appconfig.CreateConfigurationProfileOutput{Id: aws.String("new-profile")}, nil
+		},
+		CreateHostedConfigurationVersionFunc: func(ctx context.Context, params *appconfig.CreateHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.CreateHostedConfigurationVersionOutput, error) {
+			calls.version = params
+			return &appconfig.CreateHostedConfigurationVersionOutput{VersionNumber: 1}, nil
+		},
+	}
+}
+
+// writeProfileConfig writes apcdeploy.yml into a temp dir and returns its path.
+func writeProfileConfig(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "apcdeploy.yml")
+	configContent := `application: test-app
+configuration_profile: test-profile
+environment: test-env
+data_file: data.json
+region: us-east-1
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "data.json"), []byte(`{}`), 0o644); err != nil {
+		t.Fatalf("failed to write data: %v", err)
+	}
+	return configPath
+}
+
+func newTestExecutor(client *mock.MockAppConfigClient) (*reportertest.MockReporter, *Executor) {
+	rep := &reportertest.MockReporter{}
+	factory := func(context.Context, string) (*awsInternal.Client, error) {
+		return awsInternal.NewTestClient(client), nil
+	}
+	return rep, NewExecutorWithFactory(rep, factory)
+}
+
+// exportBundle exports the source profile and writes the bundle to disk.
+func exportBundle(t *testing.T, locationURI string) string {
+	t.Helper()
+	rep, executor := newTestExecutor(newSourceMock(locationURI))
+	if err := executor.Export(context.Background(), &ExportOptions{ConfigFile: writeProfileConfig(t)}); err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "bundle.json")
+	if err := os.WriteFile(path, rep.Stdout, 0o644); err != nil {
+		t.Fatalf("failed to write bundle: %v", err)
+	}
+	return path
+}
+
+func TestExport(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		locationURI string
+		wantVersion bool
+	}{
+		{name: "hosted profile includes newest version", locationURI: "hosted", wantVersion: true},
+		{name: "ssm profile is definition only", locationURI: "ssm-parameter://settings"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rep, executor := newTestExecutor(newSourceMock(tt.locationURI))
+			if err := executor.Export(context.Background(), &ExportOptions{ConfigFile: writeProfileConfig(t)}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var b Bundle
+			if err := json.Unmarshal(rep.Stdout, &b); err != nil {
+				t.Fatalf("stdout is not a bundle: %v\n%s", err, rep.Stdout)
+			}
+			if b.BundleVersion != BundleVersion || b.Name != "test-profile" || b.Description != "service settings" ||
+				b.Type != "AWS.Freeform" || b.LocationURI != tt.locationURI {
+				t.Errorf("unexpected definition: %+v", b)
+			}
+			if len(b.Validators) != 2 || b.Validators[0].Type != "JSON_SCHEMA" || b.Validators[1].Type != "LAMBDA" {
+				t.Errorf("unexpected validators: %+v", b.Validators)
+			}
+			if (b.Version != nil) != tt.wantVersion {
+				t.Fatalf("version = %+v, want present %v", b.Version, tt.wantVersion)
+			}
+			if b.Version != nil && (b.Version.Number != 3 || b.Version.ContentType != "application/json" || string(b.Version.Content) != `{"key":"value"}`) {
+				t.Errorf("unexpected version: %+v", b.Version)
+			}
+			if strings.Contains(string(rep.Stdout), "profile-123") || strings.Contains(string(rep.Stdout), "app-123") {
+				t.Errorf("bundle should not contain resource IDs:\n%s", rep.Stdout)
+			}
+		})
+	}
+}
+
+func TestImport(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		locationURI string
+		opts        ImportOptions
+		existing    []string
+		wantErr     string
+		wantName    string
+		wantLambda  bool
+		wantVersion bool
+	}{
+		{
+			name: "hosted profile with content", locationURI: "hosted",
+			wantName: "test-profile", wantVersion: true,
+		},
+		{
+			name: "rename and keep lambda validators", locationURI: "hosted",
+			opts:     ImportOptions{Name: "restored", KeepLambdaValidators: true},
+			existing: []string{"test-profile"},
+			wantName: "restored", wantLambda: true, wantVersion: true,
+		},
+		{
+			name: "non-hosted profile is definition only", locationURI: "ssm-parameter://settings",
+			opts:     ImportOptions{RetrievalRoleARN: "arn:aws:iam::222222222222:role/read"},
+			wantName: "test-profile",
+		},
+		{
+			name: "existing profile is left alone", locationURI: "hosted",
+			existing: []string{"test-profile"},
+			wantErr:  `configuration profile "test-profile" already exists in application "dest-app"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			calls := &targetCalls{}
+			rep, executor := newTestExecutor(newTargetMock(calls, tt.existing...))
+			opts := tt.opts
+			opts.File = exportBundle(t, tt.locationURI)
+			opts.Application = "dest-app"
+
+			err := executor.Import(context.Background(), &opts)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				if calls.profile != nil {
+					t.Error("no profile should be created on error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if calls.profile == nil {
+				t.Fatal("expected CreateConfigurationProfile to be called")
+			}
+			if aws.ToString(calls.profile.ApplicationId) != "dest-id" || aws.ToString(calls.profile.Name) != tt.wantName ||
+				aws.ToString(calls.profile.LocationUri) != tt.locationURI || aws.ToString(calls.profile.Description) != "service settings" {
+				t.Errorf("unexpected profile input: %+v", calls.profile)
+			}
+			if aws.ToString(calls.profile.RetrievalRoleArn) != tt.opts.RetrievalRoleARN {
+				t.Errorf("retrieval role = %q, want %q", aws.ToString(calls.profile.RetrievalRoleArn), tt.opts.RetrievalRoleARN)
+			}
+			hasLambda := false
+			for _, v := range calls.profile.Validators {
+				hasLambda = hasLambda || v.Type == types.ValidatorTypeLambda
+			}
+			if hasLambda != tt.wantLambda {
+				t.Errorf("lambda validator kept = %v, want %v", hasLambda, tt.wantLambda)
+			}
+			if warned := rep.HasMessage("dropping LAMBDA validator"); warned == tt.wantLambda {
+				t.Errorf("lambda warning shown = %v, want %v", warned, !tt.wantLambda)
+			}
+
+			if (calls.version != nil) != tt.wantVersion {
+				t.Fatalf("version created = %v, want %v", calls.version != nil, tt.wantVersion)
+			}
+			if calls.version != nil && (aws.ToString(calls.version.ConfigurationProfileId) != "new-profile" ||
+				string(calls.version.Content) != `{"key":"value"}` || aws.ToString(calls.version.ContentType) != "application/json") {
+				t.Errorf("unexpected version input: %+v", calls.version)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package profile
+
+// ExportOptions contains the configuration for the profile export operation
+type ExportOptions struct {
+	// ConfigFile is the path to the apcdeploy configuration file naming the
+	// application and configuration profile to export
+	ConfigFile string
+	// Region overrides the region from the config file (--region)
+	Region string
+}
+
+// ImportOptions contains the configuration for the profile import operation
+type ImportOptions struct {
+	// File is the bundle written by profile export
+	File string
+	// Application is the name of the application to create the profile in
+	Application string
+	// Name overrides the profile name stored in the bundle (--name)
+	Name string
+	// Region is the region to import into; empty uses the SDK default
+	Region string
+	// RetrievalRoleARN replaces the bundle's retrieval role (--retrieval-role-arn)
+	RetrievalRoleARN string
+	// KeepLambdaValidators recreates LAMBDA validators as-is instead of
+	// dropping them (--keep-lambda-validators)
+	KeepLambdaValidators bool
+}
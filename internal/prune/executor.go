@@ -0,0 +1,220 @@
+package prune
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig/types"
+	"github.com/koh-sh/apcdeploy/internal/aws"
+	"github.com/koh-sh/apcdeploy/internal/config"
+	"github.com/koh-sh/apcdeploy/internal/prompt"
+	"github.com/koh-sh/apcdeploy/internal/reporter"
+)
+
+// ErrUserDeclined is returned when the user declines the deletion
+var ErrUserDeclined = errors.New("operation declined by user")
+
+// Executor handles the prune operation orchestration
+type Executor struct {
+	reporter      reporter.Reporter
+	prompter      prompt.Prompter
+	clientFactory func(context.Context, string) (*aws.Client, error)
+}
+
+// NewExecutor creates a new prune executor
+func NewExecutor(rep reporter.Reporter, prom prompt.Prompter) *Executor {
+	return &Executor{
+		reporter:      rep,
+		prompter:      prom,
+		clientFactory: aws.NewClient,
+	}
+}
+
+// NewExecutorWithFactory creates a new prune executor with a custom client factory
+// This is useful for testing with mock clients
+func NewExecutorWithFactory(rep reporter.Reporter, prom prompt.Prompter, factory func(context.Context, string) (*aws.Client, error)) *Executor {
+	return &Executor{
+		reporter:      rep,
+		prompter:      prom,
+		clientFactory: factory,
+	}
+}
+
+// Execute deletes the hosted configuration versions of the configured
+// profile that are neither among the opts.Keep newest nor in use (see
+// protectedVersions).
+//
+// Output shape:
+//   - nothing to delete: an Info line, no prompt.
+//   - otherwise a table of the versions to delete on stderr. --dry-run stops
+//     there and prints their numbers on stdout, one per line.
+//   - a confirmation prompt unless --yes, then a single Targets row
+//     transitioning deleting → ✓ deleted N versions.
+func (e *Executor) Execute(ctx context.Context, opts *Options) error {
+	if opts.Keep < 0 {
+		return fmt.Errorf("--keep must be a non-negative number")
+	}
+
+	cfg, err := config.LoadConfig(opts.ConfigFile)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	cfg.ApplyRegionOverride(opts.Region)
+
+	awsClient, err := e.clientFactory(ctx, cfg.Region)
+	if err != nil {
+		return fmt.Errorf("failed to initialize AWS client: %w", err)
+	}
+
+	resolver := aws.NewResolver(awsClient)
+	appID, err := resolver.ResolveApplication(ctx, cfg.Application)
+	if err != nil {
+		return fmt.Errorf("failed to resolve application: %w", err)
+	}
+	profile, err := resolver.ResolveConfigurationProfile(ctx, appID, cfg.ConfigurationProfile)
+	if err != nil {
+		return fmt.Errorf("failed to resolve configuration profile: %w", err)
+	}
+
+	versions, err := awsClient.ListAllHostedConfigurationVersions(ctx, appID, profile.ID)
+	if err != nil {
+		return err
+	}
+	protected, err := protectedVersions(ctx, awsClient, appID, profile)
+	if err != nil {
+		return err
+	}
+	doomed := pruneCandidates(versions, opts.Keep, protected)
+	if len(doomed) == 0 {
+		e.reporter.Info(fmt.Sprintf("nothing to prune: %d hosted configuration versions, keeping the newest %d and every deployed version", len(versions), opts.Keep))
+		return nil
+	}
+
+	e.reporter.Header(fmt.Sprintf("Hosted configuration versions to delete (%d of %d)", len(doomed), len(versions)))
+	rows := make([][]string, 0, len(doomed))
+	for _, v := range doomed {
+		rows = append(rows, []string{strconv.Itoa(int(v.VersionNumber)), awssdk.ToString(v.ContentType), awssdk.ToString(v.Description)})
+	}
+	e.reporter.Table([]string{"Version", "Content Type", "Description"}, rows)
+
+	if opts.DryRun {
+		var b strings.Builder
+		for _, v := range doomed {
+			fmt.Fprintf(&b, "%d\n", v.VersionNumber)
+		}
+		e.reporter.Data([]byte(b.String()))
+		e.reporter.Info(fmt.Sprintf("dry run: %d versions would be deleted", len(doomed)))
+		return nil
+	}
+
+	if !opts.SkipConfirmation {
+		if err := e.prompter.CheckTTY(); err != nil {
+			return fmt.Errorf("use --yes to skip confirmation: %w", err)
+		}
+		message := fmt.Sprintf("Delete %d hosted configuration versions of %s? This cannot be undone. (Y/Yes)", len(doomed), profile.Name)
+		response, err := e.prompter.Input(message, "")
+		if err != nil {
+			return fmt.Errorf("failed to get user confirmation: %w", err)
+		}
+		normalized := strings.ToLower(strings.TrimSpace(response))
+		if normalized != "y" && normalized != "yes" {
+			return ErrUserDeclined
+		}
+	}
+
+	id := config.Identifier(awsClient.Region, cfg)
+	tg := e.reporter.Targets([]string{id})
+	defer tg.Close()
+	tg.SetPhase(id, "deleting", "")
+	for i, v := range doomed {
+		if err := awsClient.DeleteHostedConfigurationVersion(ctx, appID, profile.ID, v.VersionNumber); err != nil {
+			err = fmt.Errorf("version %d (%d of %d deleted): %w", v.VersionNumber, i, len(doomed), err)
+			tg.Fail(id, err)
+			return err
+		}
+		tg.SetProgress(id, float64(i+1)/float64(len(doomed)), 0)
+	}
+	tg.Done(id, fmt.Sprintf("deleted %d versions (%d kept)", len(doomed), len(versions)-len(doomed)))
+	return nil
+}
+
+// protectedVersions returns the version numbers of profile that
+// deployments in any environment of the application still rely on: every
+// version of a deployment that completed or is in progress, plus the
+// version of each environment's latest deployment of the profile whatever
+// its state.
+//
+// Deployment summaries only carry the profile name as it was at deployment
+// time, so a deployment under another name is looked up with GetDeployment
+// (once per name) and counts when its profile ID matches; otherwise the live
+// version of a renamed profile would be pruned.
+func protectedVersions(ctx context.Context, client *aws.Client, appID string, profile *aws.ProfileInfo) (map[string]bool, error) {
+	envs, err := client.ListAllEnvironments(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+	// ours caches whether a deployed configuration name belongs to profile
+	ours := map[string]bool{profile.Name: true}
+	protected := map[string]bool{}
+	for _, env := range envs {
+		envID := awssdk.ToString(env.Id)
+		deployments, err := client.ListAllDeployments(ctx, appID, envID)
+		if err != nil {
+			return nil, err
+		}
+		var latest *types.DeploymentSummary
+		for i, d := range deployments {
+			name := awssdk.ToString(d.ConfigurationName)
+			match, known := ours[name]
+			if !known {
+				out, err := client.GetDeployment(ctx, &appconfig.GetDeploymentInput{
+					ApplicationId:    awssdk.String(appID),
+					EnvironmentId:    awssdk.String(envID),
+					DeploymentNumber: awssdk.Int32(d.DeploymentNumber),
+				})
+				if err != nil {
+					return nil, fmt.Errorf("failed to look up deployment #%d: %w", d.DeploymentNumber, err)
+				}
+				match = awssdk.ToString(out.ConfigurationProfileId) == profile.ID
+				ours[name] = match
+			}
+			if !match {
+				continue
+			}
+			if latest == nil || d.DeploymentNumber > latest.DeploymentNumber {
+				latest = &deployments[i]
+			}
+			switch d.State {
+			case types.DeploymentStateComplete, types.DeploymentStateDeploying, types.DeploymentStateBaking, types.DeploymentStateValidating, types.DeploymentStateRollingBack:
+				protected[awssdk.ToString(d.ConfigurationVersion)] = true
+			}
+		}
+		if latest != nil {
+			protected[awssdk.ToString(latest.ConfigurationVersion)] = true
+		}
+	}
+	return protected, nil
+}
+
+// pruneCandidates returns the versions to delete, newest first: all but the
+// keep newest, minus the protected ones.
+func pruneCandidates(versions []types.HostedConfigurationVersionSummary, keep int, protected map[string]bool) []types.HostedConfigurationVersionSummary {
+	sorted := slices.Clone(versions)
+	slices.SortFunc(sorted, func(a, b types.HostedConfigurationVersionSummary) int {
+		return int(b.VersionNumber - a.VersionNumber)
+	})
+	var doomed []types.HostedConfigurationVersionSummary
+	for i, v := range sorted {
+		if i < keep || protected[strconv.Itoa(int(v.VersionNumber))] {
+			continue
+		}
+		doomed = append(doomed, v)
+	}
+	return doomed
+}
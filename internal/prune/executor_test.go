@@ -0,0 +1,203 @@
+package prune
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig/types"
+	awsInternal "github.com/koh-sh/apcdeploy/internal/aws"
+	"github.com/koh-sh/apcdeploy/internal/aws/mock"
+	prompttest "github.com/koh-sh/apcdeploy/internal/prompt/testing"
+	reportertest "github.com/koh-sh/apcdeploy/internal/reporter/testing"
+)
+
+func deployment(number int32, profile, version string, state types.DeploymentState) types.DeploymentSummary {
+	return types.DeploymentSummary{
+		DeploymentNumber:     number,
+		ConfigurationName:    aws.String(profile),
+		ConfigurationVersion: aws.String(version),
+		State:                state,
+	}
+}
+
+// newPruneMock returns a mock with hosted versions 1-8 of test-profile and
+// deployments that protect versions 2 (complete), 4 (latest in env-1, rolled
+// back), 5 (in progress in env-2) and 7 (complete in env-2 before the
+// profile was renamed from test-profile-old). Deleted version numbers are
+// appended to deleted.
+func newPruneMock(deleted *[]int32, deleteErr error) *mock.MockAppConfigClient {
+	deployments := map[string][]types.DeploymentSummary{
+		"env-1": {
+			deployment(1, "test-profile", "2", types.DeploymentStateComplete),
+			deployment(2, "test-profile", "4", types.DeploymentStateRolledBack),
+			deployment(3, "other-profile", "1", types.DeploymentStateComplete),
+		},
+		"env-2": {
+			deployment(1, "test-profile", "1", types.DeploymentStateRolledBack),
+			deployment(2, "test-profile", "5", types.DeploymentStateDeploying),
+			deployment(3, "test-profile-old", "7", types.DeploymentStateComplete),
+		},
+	}
+	profileIDs := map[string]string{"other-profile": "profile-999", "test-profile-old": "profile-123"}
+	m := mock.NewResolvingClient("AWS.Freeform")
+	m.ListEnvironmentsFunc = func(ctx context.Context, params *appconfig.ListEnvironmentsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListEnvironmentsOutput, error) {
+		return &appconfig.ListEnvironmentsOutput{Items: []types.Environment{
+			{Id: aws.String("env-1"), Name: aws.String("test-env")},
+			{Id: aws.String("env-2"), Name: aws.String("staging")},
+		}}, nil
+	}
+	m.ListDeploymentsFunc = func(ctx context.Context, params *appconfig.ListDeploymentsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListDeploymentsOutput, error) {
+		return &appconfig.ListDeploymentsOutput{Items: deployments[aws.ToString(params.EnvironmentId)]}, nil
+	}
+	m.GetDeploymentFunc = func(ctx context.Context, params *appconfig.GetDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.GetDeploymentOutput, error) {
+		for _, d := range deployments[aws.ToString(params.EnvironmentId)] {
+			if d.DeploymentNumber == aws.ToInt32(params.DeploymentNumber) {
+				return &appconfig.GetDeploymentOutput{DeploymentNumber: d.DeploymentNumber, ConfigurationProfileId: aws.String(profileIDs[aws.ToString(d.ConfigurationName)])}, nil
+			}
+		}
+		return nil, &types.ResourceNotFoundException{Message: aws.String("deployment not found")}
+	}
+	m.ListHostedConfigurationVersionsFunc = func(ctx context.Context, params *appconfig.ListHostedConfigurationVersionsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListHostedConfigurationVersionsOutput, error) {
+		var items []types.HostedConfigurationVersionSummary
+		for v := int32(1); v <= 8; v++ {
+			items = append(items, types.HostedConfigurationVersionSummary{VersionNumber: v, ContentType: aws.String("application/json")})
+		}
+		return &appconfig.ListHostedConfigurationVersionsOutput{Items: items}, nil
+	}
+	m.DeleteHostedConfigurationVersionFunc = func(ctx context.Context, params *appconfig.DeleteHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.DeleteHostedConfigurationVersionOutput, error) {
+		if deleteErr != nil {
+			return nil, deleteErr
+		}
+		*deleted = append(*deleted, aws.ToInt32(params.VersionNumber))
+		return &appconfig.DeleteHostedConfigurationVersionOutput{}, nil
+	}
+	return m
+}
+
+func TestExecute(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		opts        Options
+		prompter    *prompttest.MockPrompter
+		deleteErr   error
+		wantErr     string
+		wantDeleted []int32
+		wantStdout  string
+		wantMessage string
+		wantDone    string
+	}{
+		{
+			name:        "dry run lists the unprotected versions beyond keep",
+			opts:        Options{Keep: 3, DryRun: true},
+			wantStdout:  "3\n1\n",
+			wantMessage: "info: dry run: 2 versions would be deleted",
+		},
+		{
+			name:        "--yes deletes newest first",
+			opts:        Options{Keep: 3, SkipConfirmation: true},
+			wantDeleted: []int32{3, 1},
+			wantDone:    "deleted 2 versions (6 kept)",
+		},
+		{
+			name: "confirmed deletion",
+			opts: Options{Keep: 0},
+			prompter: &prompttest.MockPrompter{InputFunc: func(string, string) (string, error) {
+				return "yes", nil
+			}},
+			wantDeleted: []int32{8, 6, 3, 1},
+			wantDone:    "deleted 4 versions (4 kept)",
+		},
+		{
+			name: "declined",
+			opts: Options{Keep: 3},
+			prompter: &prompttest.MockPrompter{InputFunc: func(string, string) (string, error) {
+				return "n", nil
+			}},
+			wantErr: ErrUserDeclined.Error(),
+		},
+		{
+			name: "no TTY without --yes",
+			opts: Options{Keep: 3},
+			prompter: &prompttest.MockPrompter{CheckTTYFunc: func() error {
+				return errors.New("not a terminal")
+			}},
+			wantErr: "use --yes to skip confirmation",
+		},
+		{
+			name:        "nothing beyond keep",
+			opts:        Options{Keep: 8},
+			wantMessage: "info: nothing to prune: 8 hosted configuration versions, keeping the newest 8 and every deployed version",
+		},
+		{
+			name:      "delete failure",
+			opts:      Options{Keep: 3, SkipConfirmation: true},
+			deleteErr: errors.New("access denied"),
+			wantErr:   "version 3 (0 of 2 deleted)",
+		},
+		{
+			name:    "negative keep",
+			opts:    Options{Keep: -1},
+			wantErr: "--keep must be a non-negative number",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			configPath := filepath.Join(dir, "apcdeploy.yml")
+			cfg := "application: test-app\nconfiguration_profile: test-profile\nenvironment: test-env\ndeployment_strategy: AppConfig.AllAtOnce\ndata_file: data.json\nregion: us-east-1\n"
+			if err := os.WriteFile(configPath, []byte(cfg), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			var deleted []int32
+			mockClient := newPruneMock(&deleted, tt.deleteErr)
+			prompter := tt.prompter
+			if prompter == nil {
+				prompter = &prompttest.MockPrompter{}
+			}
+			reporter := &reportertest.MockReporter{}
+			executor := NewExecutorWithFactory(reporter, prompter, func(ctx context.Context, region string) (*awsInternal.Client, error) {
+				return awsInternal.NewTestClient(mockClient), nil
+			})
+
+			opts := tt.opts
+			opts.ConfigFile = configPath
+			err := executor.Execute(context.Background(), &opts)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Execute() error = %v, want %q", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(deleted, tt.wantDeleted) {
+				t.Errorf("deleted = %v, want %v", deleted, tt.wantDeleted)
+			}
+			if got := string(reporter.Stdout); got != tt.wantStdout {
+				t.Errorf("stdout = %q, want %q", got, tt.wantStdout)
+			}
+			if tt.wantMessage != "" && !reporter.HasMessage(tt.wantMessage) {
+				t.Errorf("expected message %q, got %v", tt.wantMessage, reporter.Messages)
+			}
+			if tt.wantDone != "" {
+				last := reporter.TargetsCalls[0].Transitions
+				if got := last[len(last)-1]; got.Kind != "done" || got.Summary != tt.wantDone {
+					t.Errorf("final transition = %+v, want done %q", got, tt.wantDone)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package prune
+
+// DefaultKeep is the number of newest hosted configuration versions prune
+// keeps when --keep is not given.
+const DefaultKeep = 100
+
+// Options contains the options for the prune operation
+type Options struct {
+	ConfigFile string
+	// Region overrides the region from the config file (--region)
+	Region string
+	// Keep is the number of newest hosted configuration versions to keep
+	// regardless of deployments (--keep)
+	Keep int
+	// DryRun lists the versions that would be deleted without deleting
+	// them (--dry-run)
+	DryRun bool
+	// SkipConfirmation deletes without asking (--yes)
+	SkipConfirmation bool
+}
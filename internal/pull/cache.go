@@ -0,0 +1,89 @@
+package pull
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/koh-sh/apcdeploy/internal/config"
+)
+
+// resumeCache is the fetched configuration saved when writing the data file
+// fails, so `pull --resume` can finish the write without downloading the
+// content again. It is only reused for the same target and deployment.
+type resumeCache struct {
+	Target           string `json:"target"`
+	DeploymentNumber int32  `json:"deployment_number"`
+	ContentType      string `json:"content_type"`
+	Content          []byte `json:"content"`
+}
+
+// resumeCachePath returns the cache location for a data file, or "" when
+// the user has no cache directory. The cache lives under the user cache
+// directory (e.g. ~/.cache/apcdeploy/pull), since the data file's own
+// directory may be the one that cannot be written, and is keyed by the
+// absolute data file path.
+func resumeCachePath(dataFilePath string) string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	abs, err := filepath.Abs(dataFilePath)
+	if err != nil {
+		abs = dataFilePath
+	}
+	sum := sha256.Sum256([]byte(abs))
+	return filepath.Join(dir, "apcdeploy", "pull", hex.EncodeToString(sum[:8])+".json")
+}
+
+// loadResumeCache reads the cache at path. A missing file (or an empty
+// path) returns nil without an error. Anything but a regular file owned by
+// the current user is refused, so another local user cannot plant content
+// for pull to write.
+func loadResumeCache(path string) (*resumeCache, error) {
+	if path == "" {
+		return nil, nil
+	}
+	info, err := os.Lstat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pull cache: %w", err)
+	}
+	if !info.Mode().IsRegular() {
+		return nil, fmt.Errorf("refusing to use pull cache %s: not a regular file", path)
+	}
+	if !ownedByCurrentUser(info) {
+		return nil, fmt.Errorf("refusing to use pull cache %s: not owned by the current user", path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pull cache: %w", err)
+	}
+	var c resumeCache
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("failed to parse pull cache %s: %w", path, err)
+	}
+	return &c, nil
+}
+
+// saveResumeCache writes the cache readable only by the current user, as it
+// holds configuration content. Missing cache directories are created 0700.
+func saveResumeCache(path string, c *resumeCache) error {
+	if path == "" {
+		return fmt.Errorf("failed to write pull cache: no user cache directory")
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("failed to encode pull cache: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create pull cache directory: %w", err)
+	}
+	return config.WriteFileAtomic(path, data, 0o600)
+}
@@ -0,0 +1,12 @@
+//go:build !unix
+
+package pull
+
+import "os"
+
+// ownedByCurrentUser reports whether info belongs to the current user. File
+// ownership is not exposed through os.FileInfo on this platform, where the
+// user cache directory is already private to the user.
+func ownedByCurrentUser(os.FileInfo) bool {
+	return true
+}
@@ -0,0 +1,103 @@
+package pull
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestResumeCachePath(t *testing.T) {
+	t.Parallel()
+
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		t.Skipf("no user cache directory: %v", err)
+	}
+	path := resumeCachePath("data.json")
+	if !strings.HasPrefix(path, filepath.Join(cacheDir, "apcdeploy", "pull")+string(filepath.Separator)) {
+		t.Errorf("resumeCachePath() = %q, want under %s", path, cacheDir)
+	}
+	if other := resumeCachePath("other.json"); other == path {
+		t.Errorf("different data files share the cache path %q", path)
+	}
+}
+
+func TestSaveAndLoadResumeCache(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "apcdeploy", "pull", "cache.json")
+	want := &resumeCache{Target: "us-east-1/app/profile/env", DeploymentNumber: 3, ContentType: "application/json", Content: []byte(`{"a":1}`)}
+	if err := saveResumeCache(path, want); err != nil {
+		t.Fatalf("saveResumeCache() error = %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("cache file mode = %o, want 600", perm)
+	}
+	dirInfo, err := os.Stat(filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := dirInfo.Mode().Perm(); perm != 0o700 {
+		t.Errorf("cache dir mode = %o, want 700", perm)
+	}
+
+	got, err := loadResumeCache(path)
+	if err != nil {
+		t.Fatalf("loadResumeCache() error = %v", err)
+	}
+	if got.Target != want.Target || got.DeploymentNumber != want.DeploymentNumber || string(got.Content) != string(want.Content) {
+		t.Errorf("loadResumeCache() = %+v, want %+v", got, want)
+	}
+}
+
+func TestLoadResumeCache(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	target := filepath.Join(dir, "target.json")
+	if err := os.WriteFile(target, []byte(`{"target":"x"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	link := filepath.Join(dir, "link.json")
+	if err := os.Symlink(target, link); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		wantNil bool
+		wantErr string
+	}{
+		{name: "no cache directory", path: "", wantNil: true},
+		{name: "missing file", path: filepath.Join(dir, "missing.json"), wantNil: true},
+		{name: "symlink is refused", path: link, wantErr: "not a regular file"},
+		{name: "directory is refused", path: dir, wantErr: "not a regular file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := loadResumeCache(tt.path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("loadResumeCache() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("loadResumeCache() error = %v", err)
+			}
+			if (got == nil) != tt.wantNil {
+				t.Errorf("loadResumeCache() = %+v, want nil %v", got, tt.wantNil)
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+//go:build unix
+
+package pull
+
+import (
+	"os"
+	"syscall"
+)
+
+// ownedByCurrentUser reports whether info belongs to the effective user.
+func ownedByCurrentUser(info os.FileInfo) bool {
+	st, ok := info.Sys().(*syscall.Stat_t)
+	return ok && int(st.Uid) == os.Geteuid()
+}
@@ -3,6 +3,7 @@ package pull
 import (
 	"context"
 	"fmt"
+	"os"
 	"path/filepath"
 
 	"github.com/koh-sh/apcdeploy/internal/aws"
@@ -37,14 +38,35 @@ func NewExecutorWithFactory(rep reporter.Reporter, factory func(context.Context,
 //
 // Output shape (docs/design/output.md §7.3):
 //   - updated:        ✓ updated <data-file-path>
+//   - resumed:        ✓ updated <data-file-path> (resumed from cache)
 //   - no changes:     ✓ no changes
+//   - from hosted:    ✓ updated <data-file-path> (undeployed v<N>)
 //   - no deployment:  ✗ failed: no deployment found  (returns aws.ErrNoDeployment)
 //   - resolve/fetch/write errors: ✗ failed: <message> (returns wrapped error)
+//
+// When writing the data file fails, the fetched content is kept in a cache
+// file so `pull --resume` can retry the write without downloading it again.
+//
+// With FromVersion set to FromVersionLatest, a profile that has never been
+// deployed is seeded from its newest hosted configuration version instead
+// of failing.
+//
+// With Deployment set, the configuration version of that deployment is
+// pulled instead of the latest one; the deployment must belong to the
+// resolved configuration profile.
+//
+// With As set, the content is converted into that format before it is
+// compared and written; content that cannot be converted fails the pull
+// without touching the data file.
 func (e *Executor) Execute(ctx context.Context, opts *Options) error {
 	cfg, err := config.LoadConfig(opts.ConfigFile)
 	if err != nil {
 		return fmt.Errorf("failed to load configuration: %w", err)
 	}
+	cfg.ApplyRegionOverride(opts.Region)
+	if err := cfg.RequireDataFilePath("pull"); err != nil {
+		return err
+	}
 
 	awsClient, err := e.clientFactory(ctx, cfg.Region)
 	if err != nil {
@@ -63,19 +85,66 @@ func (e *Executor) Execute(ctx context.Context, opts *Options) error {
 		return fmt.Errorf("failed to resolve resources: %w", err)
 	}
 
-	deployedConfig, err := aws.GetLatestDeployedConfiguration(ctx, awsClient, resources.ApplicationID, resources.EnvironmentID, resources.Profile.ID)
+	dataFilePath := cfg.DataFile
+	if !filepath.IsAbs(dataFilePath) {
+		dataFilePath = filepath.Join(filepath.Dir(opts.ConfigFile), cfg.DataFile)
+	}
+	cachePath := resumeCachePath(dataFilePath)
+
+	deployedConfig, resumed, err := e.fetchDeployed(ctx, awsClient, resources, id, cachePath, opts)
 	if err != nil {
+		err = aws.ExplainNotFound(err, cfg)
 		tg.Fail(id, err)
+		if opts.Deployment != 0 {
+			return fmt.Errorf("failed to get deployed configuration: %w", err)
+		}
 		return fmt.Errorf("failed to get latest deployed configuration: %w", err)
 	}
+	fromHosted := false
+	if deployedConfig == nil && opts.FromVersion == FromVersionLatest {
+		deployedConfig, err = aws.GetLatestHostedConfiguration(ctx, awsClient, resources.ApplicationID, resources.Profile.ID)
+		if err != nil {
+			err = aws.ExplainNotFound(err, cfg)
+			tg.Fail(id, err)
+			return fmt.Errorf("failed to get latest hosted configuration version: %w", err)
+		}
+		fromHosted = deployedConfig != nil
+	}
 	if deployedConfig == nil {
 		tg.Fail(id, aws.ErrNoDeployment)
-		return fmt.Errorf("%w: run 'apcdeploy run' to create the first deployment", aws.ErrNoDeployment)
+		if opts.FromVersion == FromVersionLatest {
+			return fmt.Errorf("%w and the profile has no hosted configuration versions", aws.ErrNoDeployment)
+		}
+		return fmt.Errorf("%w: run 'apcdeploy run' to create the first deployment, or pass --from-version latest to pull the newest hosted version", aws.ErrNoDeployment)
 	}
 
-	dataFilePath := cfg.DataFile
-	if !filepath.IsAbs(dataFilePath) {
-		dataFilePath = filepath.Join(filepath.Dir(opts.ConfigFile), cfg.DataFile)
+	content, contentType := deployedConfig.Content, deployedConfig.ContentType
+	ext := filepath.Ext(dataFilePath)
+	if opts.As != "" {
+		if _, forced := config.ProfileContentType(resources.Profile.Type); forced && opts.As != config.FormatJSON {
+			err := fmt.Errorf("--as %s is not supported for %s profiles, whose content is always JSON", opts.As, resources.Profile.Type)
+			tg.Fail(id, err)
+			return err
+		}
+		// run reads the data file by content_type or extension; writing
+		// another format there would make the next deployment fail.
+		if want := config.ContentTypeFor(resources.Profile.Type, cfg.ContentType, dataFilePath); want != config.ContentTypeForFormat(opts.As) {
+			err := fmt.Errorf("--as %s conflicts with data_file %s, which is read as %s: rename data_file or set content_type to match", opts.As, cfg.DataFile, want)
+			tg.Fail(id, err)
+			return err
+		}
+		content, contentType, err = config.ConvertContent(content, contentType, opts.As)
+		if err != nil {
+			tg.Fail(id, err)
+			return err
+		}
+		ext = config.ExtensionForContentType(contentType)
+	} else if converted, ok, err := config.FeatureFlagsLocalContent(resources.Profile.Type, dataFilePath, content); err != nil {
+		tg.Fail(id, err)
+		return err
+	} else if ok {
+		// FeatureFlags are served as JSON; a YAML data file stays YAML
+		content, contentType = converted, config.ContentTypeYAML
 	}
 
 	// Compare against the existing local file (if any) so a no-op pull skips
@@ -83,22 +152,89 @@ func (e *Executor) Execute(ctx context.Context, opts *Options) error {
 	// changed. A read error is treated as "file missing" and falls through to
 	// the write path.
 	if localData, readErr := config.LoadDataFile(dataFilePath); readErr == nil {
-		ext := filepath.Ext(dataFilePath)
-		hasChanges, err := config.HasContentChanged(localData, deployedConfig.Content, ext, resources.Profile.Type)
+		hasChanges, err := config.HasContentChanged(localData, content, ext, resources.Profile.Type, cfg.TextNormalizeOptions())
 		if err != nil {
 			tg.Fail(id, err)
 			return fmt.Errorf("failed to check for changes: %w", err)
 		}
 		if !hasChanges {
+			_ = os.Remove(cachePath)
 			tg.Done(id, "no changes")
 			return nil
 		}
 	}
 
-	if err := config.WriteDataFile(deployedConfig.Content, deployedConfig.ContentType, dataFilePath, resources.Profile.Type, true); err != nil {
+	if err := config.WriteDataFile(content, contentType, dataFilePath, resources.Profile.Type, true); err != nil {
 		tg.Fail(id, err)
-		return fmt.Errorf("failed to write data file: %w", err)
+		// --resume matches the cache against a deployment number; content
+		// seeded from an undeployed hosted version has none, so it is not
+		// cached.
+		if fromHosted {
+			return fmt.Errorf("failed to write data file: %w", err)
+		}
+		cacheErr := saveResumeCache(cachePath, &resumeCache{
+			Target:           id,
+			DeploymentNumber: deployedConfig.DeploymentNumber,
+			ContentType:      deployedConfig.ContentType,
+			Content:          deployedConfig.Content,
+		})
+		if cacheErr != nil {
+			return fmt.Errorf("failed to write data file: %w", err)
+		}
+		return fmt.Errorf("failed to write data file: %w (fetched content cached; retry with 'apcdeploy pull --resume')", err)
 	}
-	tg.Done(id, "updated "+dataFilePath)
+	_ = os.Remove(cachePath)
+
+	summary := "updated " + dataFilePath
+	switch {
+	case resumed:
+		summary += " (resumed from cache)"
+	case fromHosted:
+		summary += fmt.Sprintf(" (undeployed v%d)", deployedConfig.VersionNumber)
+	case opts.Deployment != 0:
+		summary += fmt.Sprintf(" (deployment #%d)", opts.Deployment)
+	}
+	tg.Done(id, summary)
 	return nil
 }
+
+// fetchDeployed returns the configuration of opts.Deployment, or the latest
+// deployed configuration when it is zero. With opts.Resume set, content
+// cached by a previous failed pull is reused when it belongs to the same
+// target and the requested (or still latest) deployment is the one it was
+// fetched from; otherwise the content is downloaded again. A cache that
+// cannot be read is reported and ignored.
+func (e *Executor) fetchDeployed(ctx context.Context, client *aws.Client, resources *aws.ResolvedResources, id, cachePath string, opts *Options) (*aws.DeployedConfigInfo, bool, error) {
+	if opts.Resume {
+		cached, err := loadResumeCache(cachePath)
+		if err != nil {
+			e.reporter.Warn(fmt.Sprintf("ignoring pull cache: %v", err))
+		}
+		if cached != nil && cached.Target == id {
+			number := opts.Deployment
+			if number == 0 {
+				deployment, err := aws.GetLatestDeployment(ctx, client, resources.ApplicationID, resources.EnvironmentID, resources.Profile.ID)
+				if err != nil {
+					return nil, false, err
+				}
+				if deployment != nil {
+					number = deployment.DeploymentNumber
+				}
+			}
+			if number != 0 && number == cached.DeploymentNumber {
+				return &aws.DeployedConfigInfo{
+					DeploymentNumber: cached.DeploymentNumber,
+					Content:          cached.Content,
+					ContentType:      cached.ContentType,
+				}, true, nil
+			}
+		}
+	}
+
+	if opts.Deployment != 0 {
+		deployed, err := aws.GetDeployedConfigurationByNumber(ctx, client, resources.ApplicationID, resources.EnvironmentID, resources.Profile.ID, opts.Deployment)
+		return deployed, false, err
+	}
+	deployed, err := aws.GetLatestDeployedConfiguration(ctx, client, resources.ApplicationID, resources.EnvironmentID, resources.Profile.ID)
+	return deployed, false, err
+}
@@ -379,6 +379,36 @@ region: us-east-1
 	}
 }
 
+// TestExecutorStdinDataFile checks that data_file "-" is refused before any
+// AWS call instead of writing a file named "-".
+func TestExecutorStdinDataFile(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "apcdeploy.yml")
+	if err := os.WriteFile(configPath, []byte(`application: test-app
+configuration_profile: test-profile
+environment: test-env
+data_file: "-"
+content_type: application/json
+region: us-east-1
+`), 0o644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	clientFactory := func(ctx context.Context, region string) (*awsInternal.Client, error) {
+		t.Error("no AWS client should be created")
+		return nil, errors.New("unexpected")
+	}
+	err := NewExecutorWithFactory(&reportertest.MockReporter{}, clientFactory).Execute(context.Background(), &Options{ConfigFile: configPath})
+	if err == nil || !strings.Contains(err.Error(), `data_file "-" (stdin) is only supported by run`) {
+		t.Fatalf("Execute() error = %v, want stdin data_file error", err)
+	}
+	if _, err := os.Stat(filepath.Join(tempDir, "-")); !os.IsNotExist(err) {
+		t.Errorf("a file named \"-\" was written (stat err = %v)", err)
+	}
+}
+
 // TestExecutorGetDeploymentError tests error during deployment retrieval
 func TestExecutorGetDeploymentError(t *testing.T) {
 	t.Parallel()
@@ -706,53 +736,7 @@ region: us-east-1
   }
 }`)
 
-	mockAppConfigClient := &mock.MockAppConfigClient{
-		ListApplicationsFunc: func(ctx context.Context, params *appconfig.ListApplicationsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListApplicationsOutput, error) {
-			return &appconfig.ListApplicationsOutput{
-				Items: []types.Application{{Id: aws.String("app-123"), Name: aws.String("test-app")}},
-			}, nil
-		},
-		ListConfigurationProfilesFunc: func(ctx context.Context, params *appconfig.ListConfigurationProfilesInput, optFns ...func(*appconfig.Options)) (*appconfig.ListConfigurationProfilesOutput, error) {
-			return &appconfig.ListConfigurationProfilesOutput{
-				Items: []types.ConfigurationProfileSummary{{Id: aws.String("profile-123"), Name: aws.String("test-profile")}},
-			}, nil
-		},
-		GetConfigurationProfileFunc: func(ctx context.Context, params *appconfig.GetConfigurationProfileInput, optFns ...func(*appconfig.Options)) (*appconfig.GetConfigurationProfileOutput, error) {
-			return &appconfig.GetConfigurationProfileOutput{
-				Id:   aws.String("profile-123"),
-				Type: aws.String("AWS.AppConfig.FeatureFlags"),
-			}, nil
-		},
-		ListEnvironmentsFunc: func(ctx context.Context, params *appconfig.ListEnvironmentsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListEnvironmentsOutput, error) {
-			return &appconfig.ListEnvironmentsOutput{
-				Items: []types.Environment{{Id: aws.String("env-123"), Name: aws.String("test-env")}},
-			}, nil
-		},
-		ListDeploymentsFunc: func(ctx context.Context, params *appconfig.ListDeploymentsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListDeploymentsOutput, error) {
-			return &appconfig.ListDeploymentsOutput{
-				Items: []types.DeploymentSummary{{DeploymentNumber: 1, State: types.DeploymentStateComplete}},
-			}, nil
-		},
-		GetDeploymentFunc: func(ctx context.Context, params *appconfig.GetDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.GetDeploymentOutput, error) {
-			return &appconfig.GetDeploymentOutput{
-				ApplicationId:          aws.String("app-123"),
-				EnvironmentId:          aws.String("env-123"),
-				DeploymentNumber:       1,
-				ConfigurationProfileId: aws.String("profile-123"),
-				ConfigurationVersion:   aws.String("1"),
-				State:                  types.DeploymentStateComplete,
-			}, nil
-		},
-		GetHostedConfigurationVersionFunc: func(ctx context.Context, params *appconfig.GetHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.GetHostedConfigurationVersionOutput, error) {
-			return &appconfig.GetHostedConfigurationVersionOutput{
-				ApplicationId:          aws.String("app-123"),
-				ConfigurationProfileId: aws.String("profile-123"),
-				VersionNumber:          1,
-				Content:                remoteConfigData,
-				ContentType:            aws.String("application/json"),
-			}, nil
-		},
-	}
+	mockAppConfigClient := newFeatureFlagsMock(remoteConfigData)
 
 	clientFactory := func(ctx context.Context, region string) (*awsInternal.Client, error) {
 		return awsInternal.NewTestClient(mockAppConfigClient), nil
@@ -789,3 +773,572 @@ region: us-east-1
 		t.Errorf("expected data file to contain feature1, got: %s", string(updatedData))
 	}
 }
+
+// newFeatureFlagsMock returns a client serving remote as the deployed content
+// of a FeatureFlags profile.
+func newFeatureFlagsMock(remote []byte) *mock.MockAppConfigClient {
+	m := mock.NewResolvingClient("AWS.AppConfig.FeatureFlags")
+	m.ListDeploymentsFunc = func(ctx context.Context, params *appconfig.ListDeploymentsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListDeploymentsOutput, error) {
+		return &appconfig.ListDeploymentsOutput{
+			Items: []types.DeploymentSummary{{DeploymentNumber: 1, State: types.DeploymentStateComplete}},
+		}, nil
+	}
+	m.GetDeploymentFunc = func(ctx context.Context, params *appconfig.GetDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.GetDeploymentOutput, error) {
+		return &appconfig.GetDeploymentOutput{
+			ApplicationId:          aws.String("app-123"),
+			EnvironmentId:          aws.String("env-123"),
+			DeploymentNumber:       1,
+			ConfigurationProfileId: aws.String("profile-123"),
+			ConfigurationVersion:   aws.String("1"),
+			State:                  types.DeploymentStateComplete,
+		}, nil
+	}
+	m.GetHostedConfigurationVersionFunc = func(ctx context.Context, params *appconfig.GetHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.GetHostedConfigurationVersionOutput, error) {
+		return &appconfig.GetHostedConfigurationVersionOutput{
+			ApplicationId:          aws.String("app-123"),
+			ConfigurationProfileId: aws.String("profile-123"),
+			VersionNumber:          1,
+			Content:                remote,
+			ContentType:            aws.String("application/json"),
+		}, nil
+	}
+	return m
+}
+
+// TestExecutorFeatureFlagsYAML checks that pulling a FeatureFlags profile
+// into a YAML data file writes YAML, and that a second pull sees no changes.
+func TestExecutorFeatureFlagsYAML(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "apcdeploy.yml")
+	if err := os.WriteFile(configPath, []byte(`application: test-app
+configuration_profile: test-profile
+environment: test-env
+data_file: flags.yaml
+region: us-east-1
+`), 0o644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	remote := []byte(`{"flags":{"beta":{"name":"Beta","_createdAt":"2024-01-01T00:00:00Z"}},"values":{"beta":{"enabled":true}},"version":"1"}`)
+	clientFactory := func(ctx context.Context, region string) (*awsInternal.Client, error) {
+		return awsInternal.NewTestClient(newFeatureFlagsMock(remote)), nil
+	}
+
+	if err := NewExecutorWithFactory(&reportertest.MockReporter{}, clientFactory).Execute(context.Background(), &Options{ConfigFile: configPath}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(tempDir, "flags.yaml"))
+	if err != nil {
+		t.Fatalf("Failed to read data file: %v", err)
+	}
+	want := "flags:\n  beta:\n    name: Beta\nvalues:\n  beta:\n    enabled: true\nversion: \"1\"\n"
+	if string(data) != want {
+		t.Errorf("data file = %q, want %q", data, want)
+	}
+
+	reporter := &reportertest.MockReporter{}
+	if err := NewExecutorWithFactory(reporter, clientFactory).Execute(context.Background(), &Options{ConfigFile: configPath}); err != nil {
+		t.Fatalf("unexpected error on second pull: %v", err)
+	}
+	if got := reporter.TargetsCalls[0].Transitions; got[len(got)-1].Summary != "no changes" {
+		t.Errorf("second pull transitions = %+v, want no changes", got)
+	}
+}
+
+// TestExecutorRegionOverride checks that --region takes precedence over the
+// config file's region when creating the AWS client.
+func TestExecutorRegionOverride(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "apcdeploy.yml")
+	configContent := `application: test-app
+configuration_profile: test-profile
+environment: test-env
+data_file: data.json
+region: us-east-1
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		override string
+		want     string
+	}{
+		{name: "flag overrides config", override: "eu-west-1", want: "eu-west-1"},
+		{name: "config region without flag", override: "", want: "us-east-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got string
+			factory := func(_ context.Context, region string) (*awsInternal.Client, error) {
+				got = region
+				return nil, errors.New("stop")
+			}
+			executor := NewExecutorWithFactory(&reportertest.MockReporter{}, factory)
+			_ = executor.Execute(context.Background(), &Options{ConfigFile: configPath, Region: tt.override})
+			if got != tt.want {
+				t.Errorf("region used = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestExecutorResume checks that a failed write leaves the fetched content in
+// the resume cache and that --resume reuses it only while the latest
+// deployment is unchanged.
+func TestExecutorResume(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		latest      int32 // latest deployment number when resuming
+		wantFetches int
+		wantSummary string
+	}{
+		{name: "same deployment reuses the cache", latest: 1, wantFetches: 0, wantSummary: "(resumed from cache)"},
+		{name: "new deployment fetches again", latest: 2, wantFetches: 1, wantSummary: "updated"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			configPath := filepath.Join(tempDir, "apcdeploy.yml")
+			configContent := `application: test-app
+configuration_profile: test-profile
+environment: test-env
+data_file: data.json
+region: us-east-1
+`
+			if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+			dataPath := filepath.Join(tempDir, "data.json")
+			cachePath := resumeCachePath(dataPath)
+			t.Cleanup(func() { os.Remove(cachePath) })
+
+			deploymentNumber := int32(1)
+			fetches := 0
+			mockClient := mock.NewResolvingClient("AWS.Freeform")
+			mockClient.ListDeploymentsFunc = func(ctx context.Context, params *appconfig.ListDeploymentsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListDeploymentsOutput, error) {
+				return &appconfig.ListDeploymentsOutput{
+					Items: []types.DeploymentSummary{{DeploymentNumber: deploymentNumber, State: types.DeploymentStateComplete}},
+				}, nil
+			}
+			mockClient.GetDeploymentFunc = func(ctx context.Context, params *appconfig.GetDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.GetDeploymentOutput, error) {
+				return &appconfig.GetDeploymentOutput{
+					DeploymentNumber:       deploymentNumber,
+					ConfigurationProfileId: aws.String("profile-123"),
+					ConfigurationVersion:   aws.String("1"),
+					State:                  types.DeploymentStateComplete,
+				}, nil
+			}
+			mockClient.GetHostedConfigurationVersionFunc = func(ctx context.Context, params *appconfig.GetHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.GetHostedConfigurationVersionOutput, error) {
+				fetches++
+				return &appconfig.GetHostedConfigurationVersionOutput{
+					Content:     []byte(`{"key": "remote"}`),
+					ContentType: aws.String("application/json"),
+				}, nil
+			}
+			clientFactory := func(ctx context.Context, region string) (*awsInternal.Client, error) {
+				return awsInternal.NewTestClient(mockClient), nil
+			}
+
+			// A directory in place of the data file makes the write fail.
+			if err := os.Mkdir(dataPath, 0o755); err != nil {
+				t.Fatalf("Failed to create directory: %v", err)
+			}
+			err := NewExecutorWithFactory(&reportertest.MockReporter{}, clientFactory).Execute(context.Background(), &Options{ConfigFile: configPath})
+			if err == nil || !strings.Contains(err.Error(), "--resume") {
+				t.Fatalf("Execute() error = %v, want a write failure mentioning --resume", err)
+			}
+			if _, err := os.Stat(cachePath); err != nil {
+				t.Fatalf("expected resume cache at %s: %v", cachePath, err)
+			}
+
+			if err := os.Remove(dataPath); err != nil {
+				t.Fatalf("Failed to remove directory: %v", err)
+			}
+			deploymentNumber = tt.latest
+			fetches = 0
+			rep := &reportertest.MockReporter{}
+			if err := NewExecutorWithFactory(rep, clientFactory).Execute(context.Background(), &Options{ConfigFile: configPath, Resume: true}); err != nil {
+				t.Fatalf("unexpected error on resume: %v", err)
+			}
+
+			if fetches != tt.wantFetches {
+				t.Errorf("GetHostedConfigurationVersion calls = %d, want %d", fetches, tt.wantFetches)
+			}
+			transitions := rep.TargetsCalls[0].Transitions
+			if last := transitions[len(transitions)-1]; !strings.Contains(last.Summary, tt.wantSummary) {
+				t.Errorf("summary = %q, want containing %q", last.Summary, tt.wantSummary)
+			}
+			data, err := os.ReadFile(dataPath)
+			if err != nil || !strings.Contains(string(data), `"remote"`) {
+				t.Errorf("data file = %q (err %v), want the pulled content", data, err)
+			}
+			if _, err := os.Stat(cachePath); !os.IsNotExist(err) {
+				t.Errorf("resume cache should be removed after a successful write, stat err = %v", err)
+			}
+		})
+	}
+}
+
+func TestExecutorFromVersionLatest(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		fromVersion string
+		versions    []int32
+		wantErr     string
+		wantSummary string
+	}{
+		{
+			name:        "seeds from the newest hosted version",
+			fromVersion: FromVersionLatest,
+			versions:    []int32{1, 3, 2},
+			wantSummary: "(undeployed v3)",
+		},
+		{
+			name:        "no hosted versions either",
+			fromVersion: FromVersionLatest,
+			wantErr:     "no hosted configuration versions",
+		},
+		{
+			name:     "default keeps the no-deployment error",
+			versions: []int32{1},
+			wantErr:  "--from-version latest",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			configPath := filepath.Join(tempDir, "apcdeploy.yml")
+			if err := os.WriteFile(configPath, []byte(`application: test-app
+configuration_profile: test-profile
+environment: test-env
+data_file: data.json
+region: us-east-1
+`), 0o644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+
+			var fetched int32
+			mockClient := mock.NewResolvingClient("AWS.Freeform")
+			mockClient.ListDeploymentsFunc = func(ctx context.Context, params *appconfig.ListDeploymentsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListDeploymentsOutput, error) {
+				return &appconfig.ListDeploymentsOutput{}, nil
+			}
+			mockClient.ListHostedConfigurationVersionsFunc = func(ctx context.Context, params *appconfig.ListHostedConfigurationVersionsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListHostedConfigurationVersionsOutput, error) {
+				items := make([]types.HostedConfigurationVersionSummary, 0, len(tt.versions))
+				for _, v := range tt.versions {
+					items = append(items, types.HostedConfigurationVersionSummary{VersionNumber: v})
+				}
+				return &appconfig.ListHostedConfigurationVersionsOutput{Items: items}, nil
+			}
+			mockClient.GetHostedConfigurationVersionFunc = func(ctx context.Context, params *appconfig.GetHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.GetHostedConfigurationVersionOutput, error) {
+				fetched = *params.VersionNumber
+				return &appconfig.GetHostedConfigurationVersionOutput{
+					Content:     []byte(`{"seeded": true}`),
+					ContentType: aws.String("application/json"),
+				}, nil
+			}
+
+			reporter := &reportertest.MockReporter{}
+			executor := NewExecutorWithFactory(reporter, func(ctx context.Context, region string) (*awsInternal.Client, error) {
+				return awsInternal.NewTestClient(mockClient), nil
+			})
+
+			err := executor.Execute(context.Background(), &Options{ConfigFile: configPath, FromVersion: tt.fromVersion})
+			if tt.wantErr != "" {
+				if !errors.Is(err, awsInternal.ErrNoDeployment) || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected ErrNoDeployment containing %q, got: %v", tt.wantErr, err)
+				}
+				if _, statErr := os.Stat(filepath.Join(tempDir, "data.json")); !os.IsNotExist(statErr) {
+					t.Error("data file should not be written")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if fetched != 3 {
+				t.Errorf("fetched v%d, want v3", fetched)
+			}
+			data, err := os.ReadFile(filepath.Join(tempDir, "data.json"))
+			if err != nil || !strings.Contains(string(data), `"seeded": true`) {
+				t.Errorf("data file = %q (%v), want seeded content", data, err)
+			}
+			transitions := reporter.TargetsCalls[0].Transitions
+			if last := transitions[len(transitions)-1]; last.Kind != "done" || !strings.HasSuffix(last.Summary, tt.wantSummary) {
+				t.Errorf("final transition = %+v, want done ending in %q", last, tt.wantSummary)
+			}
+		})
+	}
+}
+
+// TestExecutorFromVersionLatestWriteFailure checks that a failed write of
+// content seeded from a hosted version leaves no resume cache behind and
+// does not suggest --resume, which only reuses deployed content.
+func TestExecutorFromVersionLatestWriteFailure(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "apcdeploy.yml")
+	if err := os.WriteFile(configPath, []byte(`application: test-app
+configuration_profile: test-profile
+environment: test-env
+data_file: data.json
+region: us-east-1
+`), 0o644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	dataPath := filepath.Join(tempDir, "data.json")
+	cachePath := resumeCachePath(dataPath)
+	t.Cleanup(func() { os.Remove(cachePath) })
+
+	mockClient := mock.NewResolvingClient("AWS.Freeform")
+	mockClient.ListDeploymentsFunc = func(ctx context.Context, params *appconfig.ListDeploymentsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListDeploymentsOutput, error) {
+		return &appconfig.ListDeploymentsOutput{}, nil
+	}
+	mockClient.ListHostedConfigurationVersionsFunc = func(ctx context.Context, params *appconfig.ListHostedConfigurationVersionsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListHostedConfigurationVersionsOutput, error) {
+		return &appconfig.ListHostedConfigurationVersionsOutput{
+			Items: []types.HostedConfigurationVersionSummary{{VersionNumber: 2}},
+		}, nil
+	}
+	mockClient.GetHostedConfigurationVersionFunc = func(ctx context.Context, params *appconfig.GetHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.GetHostedConfigurationVersionOutput, error) {
+		return &appconfig.GetHostedConfigurationVersionOutput{
+			Content:     []byte(`{"seeded": true}`),
+			ContentType: aws.String("application/json"),
+		}, nil
+	}
+	clientFactory := func(ctx context.Context, region string) (*awsInternal.Client, error) {
+		return awsInternal.NewTestClient(mockClient), nil
+	}
+
+	// A directory in place of the data file makes the write fail.
+	if err := os.Mkdir(dataPath, 0o755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	err := NewExecutorWithFactory(&reportertest.MockReporter{}, clientFactory).Execute(context.Background(), &Options{ConfigFile: configPath, FromVersion: FromVersionLatest})
+	if err == nil || !strings.Contains(err.Error(), "failed to write data file") {
+		t.Fatalf("Execute() error = %v, want a write failure", err)
+	}
+	if strings.Contains(err.Error(), "--resume") {
+		t.Errorf("error = %v, should not suggest --resume", err)
+	}
+	if _, err := os.Stat(cachePath); !os.IsNotExist(err) {
+		t.Errorf("resume cache should not be written for hosted content, stat err = %v", err)
+	}
+}
+
+func TestExecutorDeployment(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		profileID   string
+		wantErr     string
+		wantVersion int32
+	}{
+		{
+			name:        "pulls the version of the given deployment",
+			profileID:   "profile-123",
+			wantVersion: 2,
+		},
+		{
+			name:      "deployment of another profile",
+			profileID: "profile-other",
+			wantErr:   "deployment #4 is not for this configuration profile",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			configPath := filepath.Join(tempDir, "apcdeploy.yml")
+			if err := os.WriteFile(configPath, []byte(`application: test-app
+configuration_profile: test-profile
+environment: test-env
+data_file: data.json
+region: us-east-1
+`), 0o644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+
+			var requested, fetched int32
+			mockClient := mock.NewResolvingClient("AWS.Freeform")
+			mockClient.GetDeploymentFunc = func(ctx context.Context, params *appconfig.GetDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.GetDeploymentOutput, error) {
+				requested = *params.DeploymentNumber
+				return &appconfig.GetDeploymentOutput{
+					DeploymentNumber:       *params.DeploymentNumber,
+					ConfigurationProfileId: aws.String(tt.profileID),
+					ConfigurationVersion:   aws.String("2"),
+					State:                  types.DeploymentStateComplete,
+				}, nil
+			}
+			mockClient.GetHostedConfigurationVersionFunc = func(ctx context.Context, params *appconfig.GetHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.GetHostedConfigurationVersionOutput, error) {
+				fetched = *params.VersionNumber
+				return &appconfig.GetHostedConfigurationVersionOutput{
+					Content:     []byte(`{"known": "good"}`),
+					ContentType: aws.String("application/json"),
+				}, nil
+			}
+
+			reporter := &reportertest.MockReporter{}
+			executor := NewExecutorWithFactory(reporter, func(ctx context.Context, region string) (*awsInternal.Client, error) {
+				return awsInternal.NewTestClient(mockClient), nil
+			})
+
+			err := executor.Execute(context.Background(), &Options{ConfigFile: configPath, Deployment: 4})
+			if requested != 4 {
+				t.Errorf("GetDeployment requested #%d, want #4", requested)
+			}
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Execute() error = %v, want %q", err, tt.wantErr)
+				}
+				if _, statErr := os.Stat(filepath.Join(tempDir, "data.json")); !os.IsNotExist(statErr) {
+					t.Error("data file should not be written")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if fetched != tt.wantVersion {
+				t.Errorf("fetched v%d, want v%d", fetched, tt.wantVersion)
+			}
+			data, err := os.ReadFile(filepath.Join(tempDir, "data.json"))
+			if err != nil || !strings.Contains(string(data), `"known": "good"`) {
+				t.Errorf("data file = %q (%v), want the deployment's content", data, err)
+			}
+			transitions := reporter.TargetsCalls[0].Transitions
+			if last := transitions[len(transitions)-1]; last.Kind != "done" || !strings.HasSuffix(last.Summary, "(deployment #4)") {
+				t.Errorf("final transition = %+v, want done ending in (deployment #4)", last)
+			}
+		})
+	}
+}
+
+func TestExecutorAs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		profileType string
+		content     string
+		contentType string
+		dataFile    string
+		as          string
+		wantData    string
+		wantErr     string
+	}{
+		{
+			name: "json to yaml", profileType: "AWS.Freeform",
+			content: `{"b": 1, "a": "x"}`, contentType: "application/json", dataFile: "data.yaml", as: "yaml",
+			wantData: "b: 1\na: x\n",
+		},
+		{
+			name: "yaml to json", profileType: "AWS.Freeform",
+			content: "key: value\n", contentType: "application/x-yaml", dataFile: "data.json", as: "json",
+			wantData: "{\n  \"key\": \"value\"\n}\n",
+		},
+		{
+			name: "plain text cannot become yaml", profileType: "AWS.Freeform",
+			content: "hello world", contentType: "text/plain", dataFile: "data.yaml", as: "yaml",
+			wantErr: "not a YAML mapping or sequence",
+		},
+		{
+			name: "feature flags stay json", profileType: "AWS.AppConfig.FeatureFlags",
+			content: `{"flags": {}}`, contentType: "application/json", dataFile: "flags.yaml", as: "yaml",
+			wantErr: "--as yaml is not supported for AWS.AppConfig.FeatureFlags profiles",
+		},
+		{
+			name: "format must match the data file", profileType: "AWS.Freeform",
+			content: `{"a": 1}`, contentType: "application/json", dataFile: "data.json", as: "yaml",
+			wantErr: "data.json, which is read as application/json",
+		},
+		{
+			name: "text keeps a text data file", profileType: "AWS.Freeform",
+			content: `{"a": 1}`, contentType: "application/json", dataFile: "data.txt", as: "text",
+			wantData: `{"a": 1}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			configPath := filepath.Join(tempDir, "apcdeploy.yml")
+			if err := os.WriteFile(configPath, []byte(`application: test-app
+configuration_profile: test-profile
+environment: test-env
+data_file: `+tt.dataFile+`
+region: us-east-1
+`), 0o644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+
+			mockClient := mock.NewResolvingClient(tt.profileType)
+			mockClient.ListDeploymentsFunc = func(ctx context.Context, params *appconfig.ListDeploymentsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListDeploymentsOutput, error) {
+				return &appconfig.ListDeploymentsOutput{
+					Items: []types.DeploymentSummary{{DeploymentNumber: 1, State: types.DeploymentStateComplete}},
+				}, nil
+			}
+			mockClient.GetDeploymentFunc = func(ctx context.Context, params *appconfig.GetDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.GetDeploymentOutput, error) {
+				return &appconfig.GetDeploymentOutput{
+					DeploymentNumber:       1,
+					ConfigurationProfileId: aws.String("profile-123"),
+					ConfigurationVersion:   aws.String("1"),
+					State:                  types.DeploymentStateComplete,
+				}, nil
+			}
+			mockClient.GetHostedConfigurationVersionFunc = func(ctx context.Context, params *appconfig.GetHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.GetHostedConfigurationVersionOutput, error) {
+				return &appconfig.GetHostedConfigurationVersionOutput{
+					Content:     []byte(tt.content),
+					ContentType: aws.String(tt.contentType),
+				}, nil
+			}
+
+			reporter := &reportertest.MockReporter{}
+			executor := NewExecutorWithFactory(reporter, func(ctx context.Context, region string) (*awsInternal.Client, error) {
+				return awsInternal.NewTestClient(mockClient), nil
+			})
+
+			err := executor.Execute(context.Background(), &Options{ConfigFile: configPath, As: tt.as})
+			dataPath := filepath.Join(tempDir, tt.dataFile)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got: %v", tt.wantErr, err)
+				}
+				if _, statErr := os.Stat(dataPath); !os.IsNotExist(statErr) {
+					t.Error("data file should not be written")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			data, err := os.ReadFile(dataPath)
+			if err != nil || string(data) != tt.wantData {
+				t.Errorf("data file = %q (%v), want %q", data, err, tt.wantData)
+			}
+		})
+	}
+}
@@ -1,6 +1,25 @@
 package pull
 
+// FromVersionLatest is the only --from-version value: fall back to the newest
+// hosted configuration version when the profile has never been deployed.
+const FromVersionLatest = "latest"
+
 // Options contains the configuration options for pulling configuration
 type Options struct {
 	ConfigFile string
+	// Resume reuses the content cached by a previous pull whose write failed,
+	// as long as the deployment has not changed since (--resume)
+	Resume bool
+	// FromVersion selects a fallback when nothing is deployed yet
+	// (--from-version); empty keeps the no-deployment error
+	FromVersion string
+	// Deployment pulls the configuration served by this deployment number
+	// instead of the latest deployment (--deployment); zero means latest
+	Deployment int32
+	// Region overrides the region from the config file (--region)
+	Region string
+	// As converts the fetched content into this data format (json, yaml or
+	// text) before writing, regardless of its content type or the data file
+	// extension (--as); empty writes it as fetched
+	As string
 }
@@ -3,6 +3,7 @@ package testing
 
 import (
 	"strings"
+	"sync"
 
 	"github.com/koh-sh/apcdeploy/internal/reporter"
 )
@@ -28,6 +29,10 @@ type MockReporter struct {
 	// TargetsCalls records each Targets lifecycle: the initial identifier
 	// list and every recorded transition.
 	TargetsCalls []TargetsCall
+
+	// mu guards Targets transitions, which bulk executors issue from
+	// several goroutines at once.
+	mu sync.Mutex
 }
 
 // TableCall captures the arguments to Reporter.Table.
@@ -68,6 +68,8 @@ func (t *mockTargets) Skip(id, reason string) {
 }
 
 func (t *mockTargets) Close() {
+	t.m.mu.Lock()
+	defer t.m.mu.Unlock()
 	if t.closed {
 		return
 	}
@@ -77,6 +79,8 @@ func (t *mockTargets) Close() {
 }
 
 func (t *mockTargets) record(tr TargetsTransition) {
+	t.m.mu.Lock()
+	defer t.m.mu.Unlock()
 	if t.closed {
 		return
 	}
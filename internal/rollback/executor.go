@@ -58,6 +58,7 @@ func (e *Executor) Execute(ctx context.Context, opts *Options) error {
 	if err != nil {
 		return fmt.Errorf("failed to load configuration: %w", err)
 	}
+	cfg.ApplyRegionOverride(opts.Region)
 
 	awsClient, err := e.clientFactory(ctx, cfg.Region)
 	if err != nil {
@@ -722,3 +722,47 @@ func TestExecutorTargetsFailOnStopError(t *testing.T) {
 		t.Errorf("expected fail.Err to mention ConflictException, got %v", fail.Err)
 	}
 }
+
+// TestExecutorRegionOverride checks that --region takes precedence over the
+// config file's region when creating the AWS client.
+func TestExecutorRegionOverride(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "apcdeploy.yml")
+	configContent := `application: test-app
+configuration_profile: test-profile
+environment: test-env
+data_file: data.json
+region: us-east-1
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		override string
+		want     string
+	}{
+		{name: "flag overrides config", override: "eu-west-1", want: "eu-west-1"},
+		{name: "config region without flag", override: "", want: "us-east-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got string
+			factory := func(_ context.Context, region string) (*awsInternal.Client, error) {
+				got = region
+				return nil, errors.New("stop")
+			}
+			executor := NewExecutorWithFactory(&reportertest.MockReporter{}, &prompttest.MockPrompter{}, factory)
+			_ = executor.Execute(context.Background(), &Options{ConfigFile: configPath, Region: tt.override})
+			if got != tt.want {
+				t.Errorf("region used = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -5,4 +5,34 @@ type Options struct {
 	ConfigFile       string
 	Silent           bool
 	SkipConfirmation bool
+	// Region overrides the region from the config file (--region)
+	Region string
+	// ToDescription redeploys the version of the newest deployment whose
+	// description contains this text instead of stopping a deployment
+	ToDescription string
+	// LatestMatch picks the newest deployment when ToDescription matches
+	// more than one
+	LatestMatch bool
+	// ToVersion redeploys this hosted configuration version (--to-version)
+	ToVersion int32
+	// ToDeployment redeploys the version used by this deployment number
+	// (--to-deployment)
+	ToDeployment int32
+	// ToPrevious redeploys the version of the most recent completed
+	// deployment before the current one (--to-previous)
+	ToPrevious bool
+	// WaitDeploy, WaitBake and Timeout (seconds) control waiting after a
+	// redeploy starts, as for run
+	WaitDeploy bool
+	WaitBake   bool
+	Timeout    int
+	// AcceptLongBake lets WaitBake proceed with a strategy estimated above
+	// run.DefaultLongBakeThreshold and Timeout (--accept-long-bake)
+	AcceptLongBake bool
+}
+
+// Redeploys reports whether the options select a redeploy of an earlier
+// version rather than stopping the ongoing deployment.
+func (o *Options) Redeploys() bool {
+	return o.ToDescription != "" || o.ToVersion > 0 || o.ToDeployment > 0 || o.ToPrevious
 }
@@ -0,0 +1,248 @@
+package rollback
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/appconfig/types"
+	"github.com/koh-sh/apcdeploy/internal/aws"
+	"github.com/koh-sh/apcdeploy/internal/cli"
+	"github.com/koh-sh/apcdeploy/internal/config"
+	"github.com/koh-sh/apcdeploy/internal/run"
+)
+
+// redeployTarget is the configuration version a redeploy brings back.
+type redeployTarget struct {
+	version string
+	// source names where the version came from in the confirmation prompt,
+	// e.g. `deployment #2 "release-1.2.0"`; empty for --to-version.
+	source string
+	// description is the description of the new deployment.
+	description string
+}
+
+// deploymentTarget redeploys the version used by a past deployment.
+func deploymentTarget(d *aws.DeploymentDetails) *redeployTarget {
+	return &redeployTarget{
+		version:     d.ConfigurationVersion,
+		source:      fmt.Sprintf("deployment #%d %q", d.DeploymentNumber, d.Description),
+		description: fmt.Sprintf("Rollback to deployment #%d (v%s)", d.DeploymentNumber, d.ConfigurationVersion),
+	}
+}
+
+// pickFunc selects the version to redeploy. current is the profile's latest
+// non-rolled-back deployment, or nil when it has never been deployed.
+type pickFunc func(ctx context.Context, client *aws.Client, resources *aws.ResolvedResources, current *aws.DeploymentInfo) (*redeployTarget, error)
+
+// ExecuteRedeploy redeploys an earlier configuration version instead of
+// stopping a deployment. The version is chosen by the first option set:
+// ToDescription, ToDeployment, ToVersion, and otherwise the version of the
+// most recent completed deployment before the current one.
+func (e *Executor) ExecuteRedeploy(ctx context.Context, opts *Options) error {
+	switch {
+	case opts.ToDescription != "":
+		return e.ExecuteToDescription(ctx, opts)
+	case opts.ToDeployment > 0:
+		return e.redeploy(ctx, opts, pickDeployment(opts.ToDeployment))
+	case opts.ToVersion > 0:
+		return e.redeploy(ctx, opts, pickVersion(opts.ToVersion))
+	default:
+		return e.redeploy(ctx, opts, pickPrevious)
+	}
+}
+
+// pickDeployment copies the version used by deployment number of the same
+// configuration profile.
+func pickDeployment(number int32) pickFunc {
+	return func(ctx context.Context, client *aws.Client, resources *aws.ResolvedResources, _ *aws.DeploymentInfo) (*redeployTarget, error) {
+		details, err := aws.GetDeploymentDetails(ctx, client, resources.ApplicationID, resources.EnvironmentID, number)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get deployment #%d: %w", number, err)
+		}
+		if details.ConfigurationProfileID != resources.Profile.ID {
+			return nil, fmt.Errorf("deployment #%d deployed a different configuration profile", number)
+		}
+		return deploymentTarget(details), nil
+	}
+}
+
+// pickVersion redeploys a hosted configuration version by number after
+// checking that it exists.
+func pickVersion(version int32) pickFunc {
+	return func(ctx context.Context, client *aws.Client, resources *aws.ResolvedResources, _ *aws.DeploymentInfo) (*redeployTarget, error) {
+		v := strconv.Itoa(int(version))
+		if _, err := aws.GetHostedConfigurationVersionDescription(ctx, client, resources.ApplicationID, resources.Profile.ID, v); err != nil {
+			return nil, fmt.Errorf("configuration version %s: %w", v, err)
+		}
+		return &redeployTarget{version: v, description: "Rollback to v" + v}, nil
+	}
+}
+
+// pickPrevious finds the most recent completed deployment of the profile
+// that precedes the current one and serves a different version. Having no
+// such deployment is an error rather than a redeploy of the current version.
+func pickPrevious(ctx context.Context, client *aws.Client, resources *aws.ResolvedResources, current *aws.DeploymentInfo) (*redeployTarget, error) {
+	if current == nil {
+		return nil, fmt.Errorf("no deployment found for this configuration profile; nothing to roll back to")
+	}
+	deployments, err := client.ListAllDeployments(ctx, resources.ApplicationID, resources.EnvironmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+	slices.SortFunc(deployments, func(a, b types.DeploymentSummary) int {
+		return int(b.DeploymentNumber) - int(a.DeploymentNumber)
+	})
+	for _, d := range deployments {
+		if d.DeploymentNumber >= current.DeploymentNumber || d.State != types.DeploymentStateComplete {
+			continue
+		}
+		details, err := aws.GetDeploymentDetails(ctx, client, resources.ApplicationID, resources.EnvironmentID, d.DeploymentNumber)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get deployment details: %w", err)
+		}
+		if details.ConfigurationProfileID == resources.Profile.ID && details.ConfigurationVersion != current.ConfigurationVersion {
+			return deploymentTarget(details), nil
+		}
+	}
+	return nil, fmt.Errorf("no completed deployment before #%d (v%s) deployed a different version", current.DeploymentNumber, current.ConfigurationVersion)
+}
+
+// redeploy starts a deployment of the version chosen by pick, using the
+// deployment strategy from the config file.
+//
+// Output shape:
+//   - started:     optional confirmation, then a Targets row transitioning
+//     deploying → ✓ started v<V> (deployment #<N>), or the run-style
+//     deployed/complete summary with --wait-deploy / --wait-bake
+//   - up to date:  ⊘ already serving v<V>
+//   - no target / ongoing deployment: a non-nil error before any Targets
+//     row is opened.
+func (e *Executor) redeploy(ctx context.Context, opts *Options, pick pickFunc) error {
+	cfg, err := config.LoadConfig(opts.ConfigFile)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	cfg.ApplyRegionOverride(opts.Region)
+
+	awsClient, err := e.clientFactory(ctx, cfg.Region)
+	if err != nil {
+		return fmt.Errorf("failed to initialize AWS client: %w", err)
+	}
+
+	id := config.Identifier(awsClient.Region, cfg)
+
+	resolver := aws.NewResolver(awsClient)
+	resources, err := resolver.ResolveAll(ctx, cfg.Application, cfg.ConfigurationProfile, cfg.Environment, cfg.DeploymentStrategy)
+	if err != nil {
+		return fmt.Errorf("failed to resolve resources: %w", err)
+	}
+
+	deployer := run.NewWithClient(cfg, awsClient)
+	hasOngoing, ongoing, err := deployer.CheckOngoingDeployment(ctx, resources)
+	if err != nil {
+		return fmt.Errorf("failed to check ongoing deployment: %w", err)
+	}
+	if hasOngoing {
+		if summary, ok := ongoing.(*types.DeploymentSummary); ok && summary != nil {
+			return fmt.Errorf("%w: deployment #%d; stop it first with 'apcdeploy rollback'", aws.ErrDeploymentInProgress, summary.DeploymentNumber)
+		}
+		return aws.ErrDeploymentInProgress
+	}
+
+	current, err := aws.GetLatestDeployment(ctx, awsClient, resources.ApplicationID, resources.EnvironmentID, resources.Profile.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get latest deployment: %w", err)
+	}
+
+	target, err := pick(ctx, awsClient, resources, current)
+	if err != nil {
+		return err
+	}
+	if current != nil && current.ConfigurationVersion == target.version {
+		tg := e.reporter.Targets([]string{id})
+		tg.Skip(id, "already serving v"+target.version)
+		tg.Close()
+		return nil
+	}
+
+	version, err := strconv.ParseInt(target.version, 10, 32)
+	if err != nil {
+		return fmt.Errorf("non-numeric configuration version %q", target.version)
+	}
+
+	timeout := time.Duration(cfg.EffectiveTimeout(opts.Timeout)) * time.Second
+	if opts.WaitBake {
+		if err := run.CheckBakeTime(ctx, awsClient, e.reporter, id, resources.DeploymentStrategyID, cfg.DeploymentStrategy, timeout, run.DefaultLongBakeThreshold, opts.AcceptLongBake); err != nil {
+			return err
+		}
+	}
+
+	if !opts.SkipConfirmation {
+		if err := e.prompter.CheckTTY(); err != nil {
+			return fmt.Errorf("use --yes to skip confirmation: %w", err)
+		}
+
+		message := fmt.Sprintf("Deploy v%s again? (Y/Yes)", target.version)
+		if target.source != "" {
+			message = fmt.Sprintf("Deploy v%s again (from %s)? (Y/Yes)", target.version, target.source)
+		}
+		response, err := e.prompter.Input(message, "")
+		if err != nil {
+			return fmt.Errorf("failed to get user confirmation: %w", err)
+		}
+
+		normalized := strings.ToLower(strings.TrimSpace(response))
+		if normalized != "y" && normalized != "yes" {
+			return ErrUserDeclined
+		}
+	}
+
+	tg := e.reporter.Targets([]string{id})
+	defer tg.Close()
+	deployStart := time.Now()
+	tg.SetPhase(id, "deploying", fmt.Sprintf("(v%s)", target.version))
+	deploymentNumber, err := awsClient.StartDeployment(ctx, resources.ApplicationID, resources.EnvironmentID, resources.Profile.ID, resources.DeploymentStrategyID, int32(version), target.description)
+	if err != nil {
+		tg.Fail(id, err)
+		return fmt.Errorf("failed to start deployment: %w", err)
+	}
+
+	switch {
+	case opts.WaitDeploy:
+		if err := awsClient.WaitForDeploymentPhase(ctx, resources.ApplicationID, resources.EnvironmentID, deploymentNumber, false, timeout, run.MakeTargetsDeployTick(tg, id)); err != nil {
+			tg.Fail(id, err)
+			return fmt.Errorf("deployment failed: %w", err)
+		}
+		tg.Done(id, cli.FormatDeploymentSummary("deployed", deployStart, int32(version), cfg.DeploymentStrategy, fmt.Sprintf("deployment #%d, baking started", deploymentNumber)))
+	case opts.WaitBake:
+		// waitCtx caps the deploy and bake waits together at timeout.
+		deadline := time.Now().Add(timeout)
+		waitCtx, cancel := context.WithDeadline(ctx, deadline)
+		defer cancel()
+
+		if err := awsClient.WaitForDeploymentPhase(waitCtx, resources.ApplicationID, resources.EnvironmentID, deploymentNumber, false, remainingDuration(deadline), run.MakeTargetsDeployTick(tg, id)); err != nil {
+			tg.Fail(id, err)
+			return fmt.Errorf("deployment failed: %w", err)
+		}
+		tg.SetPhase(id, "baking", "")
+		if err := awsClient.WaitForBakingComplete(waitCtx, resources.ApplicationID, resources.EnvironmentID, deploymentNumber, remainingDuration(deadline), run.MakeTargetsBakeTick(tg, id)); err != nil {
+			tg.Fail(id, err)
+			return fmt.Errorf("deployment failed: %w", err)
+		}
+		tg.Done(id, cli.FormatDeploymentSummary("complete", deployStart, int32(version), cfg.DeploymentStrategy, fmt.Sprintf("deployment #%d", deploymentNumber)))
+	default:
+		tg.Done(id, fmt.Sprintf("started v%s (deployment #%d)", target.version, deploymentNumber))
+	}
+	return nil
+}
+
+// remainingDuration returns the time until deadline, clamped at 1s so the
+// wait functions never receive a zero timeout. The shared waitCtx deadline
+// bounds the actual wait regardless.
+func remainingDuration(deadline time.Time) time.Duration {
+	return max(time.Until(deadline), time.Second)
+}
@@ -0,0 +1,180 @@
+package rollback
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig/types"
+	awsInternal "github.com/koh-sh/apcdeploy/internal/aws"
+	prompttest "github.com/koh-sh/apcdeploy/internal/prompt/testing"
+	reportertest "github.com/koh-sh/apcdeploy/internal/reporter/testing"
+)
+
+func TestExecuteRedeploy(t *testing.T) {
+	t.Parallel()
+
+	history := []historyDeployment{
+		{6, "profile-999", "9", "other profile", types.DeploymentStateComplete},
+		{5, "profile-123", "4", "release-1.3.0", types.DeploymentStateComplete},
+		{4, "profile-123", "4", "release-1.3.0 again", types.DeploymentStateComplete},
+		{3, "profile-123", "3", "release-1.2.1", types.DeploymentStateRolledBack},
+		{2, "profile-123", "2", "release-1.2.0", types.DeploymentStateComplete},
+		{1, "profile-123", "1", "release-1.1.0", types.DeploymentStateComplete},
+	}
+
+	tests := []struct {
+		name        string
+		history     []historyDeployment
+		opts        Options
+		wantVersion string
+		wantDesc    string
+		wantSkip    string
+		wantDone    string
+		wantErr     string
+		wantErrIs   error
+	}{
+		{
+			name:        "previous skips rolled back and same-version deployments",
+			history:     history,
+			opts:        Options{ToPrevious: true},
+			wantVersion: "2",
+			wantDesc:    "Rollback to deployment #2 (v2)",
+		},
+		{
+			name: "previous without an earlier version is refused",
+			history: []historyDeployment{
+				{2, "profile-123", "1", "again", types.DeploymentStateComplete},
+				{1, "profile-123", "1", "first", types.DeploymentStateComplete},
+			},
+			opts:    Options{ToPrevious: true},
+			wantErr: "no completed deployment before #2 (v1) deployed a different version",
+		},
+		{
+			name:    "previous without any deployment is refused",
+			opts:    Options{ToPrevious: true},
+			wantErr: "no deployment found for this configuration profile",
+		},
+		{
+			name:        "to-deployment copies its version",
+			history:     history,
+			opts:        Options{ToDeployment: 1},
+			wantVersion: "1",
+			wantDesc:    "Rollback to deployment #1 (v1)",
+		},
+		{
+			name:    "to-deployment of another profile is refused",
+			history: history,
+			opts:    Options{ToDeployment: 6},
+			wantErr: "deployment #6 deployed a different configuration profile",
+		},
+		{
+			name:        "to-version redeploys the version",
+			history:     history,
+			opts:        Options{ToVersion: 3},
+			wantVersion: "3",
+			wantDesc:    "Rollback to v3",
+		},
+		{
+			name:    "unknown version is refused",
+			history: history,
+			opts:    Options{ToVersion: 42},
+			wantErr: "configuration version 42",
+		},
+		{
+			name:     "current version is skipped",
+			history:  history,
+			opts:     Options{ToVersion: 4},
+			wantSkip: "already serving v4",
+		},
+		{
+			name: "ongoing deployment is refused",
+			history: append([]historyDeployment{
+				{7, "profile-123", "5", "release-1.4.0", types.DeploymentStateBaking},
+			}, history...),
+			opts:      Options{ToPrevious: true},
+			wantErr:   "deployment already in progress: deployment #7",
+			wantErrIs: awsInternal.ErrDeploymentInProgress,
+		},
+		{
+			name:        "wait-bake reports completion",
+			history:     history,
+			opts:        Options{ToVersion: 1, WaitBake: true, Timeout: 60, AcceptLongBake: true},
+			wantVersion: "1",
+			wantDone:    "complete",
+		},
+		{
+			name:    "wait-bake on a long strategy is refused",
+			history: history,
+			opts:    Options{ToVersion: 1, WaitBake: true, Timeout: 60},
+			wantErr: "pass --accept-long-bake",
+		},
+		{
+			name:        "wait-bake proceeds when the timeout covers the strategy",
+			history:     history,
+			opts:        Options{ToVersion: 1, WaitBake: true, Timeout: 90 * 60},
+			wantVersion: "1",
+			wantDone:    "complete",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			configPath, cleanup := createTestConfig(t)
+			defer cleanup()
+
+			var started appconfig.StartDeploymentInput
+			reporter := &reportertest.MockReporter{}
+			executor := NewExecutorWithFactory(reporter, &prompttest.MockPrompter{}, newHistoryMock(tt.history, &started))
+
+			opts := tt.opts
+			opts.ConfigFile = configPath
+			opts.SkipConfirmation = true
+			err := executor.ExecuteRedeploy(context.Background(), &opts)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got: %v", tt.wantErr, err)
+				}
+				if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+					t.Errorf("error = %v, want errors.Is %v", err, tt.wantErrIs)
+				}
+				if started.ConfigurationVersion != nil {
+					t.Error("StartDeployment should not be called")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			transitions := reporter.TargetsCalls[0].Transitions
+			last := transitions[len(transitions)-1]
+			if tt.wantSkip != "" {
+				if started.ConfigurationVersion != nil {
+					t.Error("StartDeployment should not be called")
+				}
+				if last.Kind != "skip" || last.Reason != tt.wantSkip {
+					t.Errorf("final transition = %+v, want skip %q", last, tt.wantSkip)
+				}
+				return
+			}
+
+			if got := aws.ToString(started.ConfigurationVersion); got != tt.wantVersion {
+				t.Errorf("deployed version = %q, want %q", got, tt.wantVersion)
+			}
+			if tt.wantDesc != "" {
+				if got := aws.ToString(started.Description); got != tt.wantDesc {
+					t.Errorf("description = %q, want %q", got, tt.wantDesc)
+				}
+			}
+			if tt.wantDone != "" && (last.Kind != "done" || !strings.HasPrefix(last.Summary, tt.wantDone)) {
+				t.Errorf("final transition = %+v, want done %q", last, tt.wantDone)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package rollback
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/appconfig/types"
+	"github.com/koh-sh/apcdeploy/internal/aws"
+)
+
+// ExecuteToDescription redeploys the configuration version of the most
+// recent deployment whose description contains opts.ToDescription, using the
+// deployment strategy from the config file. No match or an ambiguous match
+// is an error; see redeploy for the output shape.
+func (e *Executor) ExecuteToDescription(ctx context.Context, opts *Options) error {
+	return e.redeploy(ctx, opts, func(ctx context.Context, client *aws.Client, resources *aws.ResolvedResources, _ *aws.DeploymentInfo) (*redeployTarget, error) {
+		matches, err := findDeploymentsByDescription(ctx, client, resources, opts.ToDescription, opts.LatestMatch)
+		if err != nil {
+			return nil, err
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no deployment description contains %q", opts.ToDescription)
+		}
+		if len(matches) > 1 && !opts.LatestMatch {
+			candidates := make([]string, 0, len(matches))
+			for _, m := range matches {
+				candidates = append(candidates, fmt.Sprintf("#%d (v%s) %q", m.DeploymentNumber, m.ConfigurationVersion, m.Description))
+			}
+			return nil, fmt.Errorf("%d deployments match %q: %s; narrow the text or pass --latest-match to use #%d",
+				len(matches), opts.ToDescription, strings.Join(candidates, ", "), matches[0].DeploymentNumber)
+		}
+		return deploymentTarget(matches[0]), nil
+	})
+}
+
+// findDeploymentsByDescription returns the deployments of the resolved
+// profile whose description contains substr, newest first; with firstOnly it
+// stops at the newest match. The list response is used to skip rolled-back
+// deployments, whose version was already rejected once. Deployment summaries
+// carry no description or profile ID, so the remaining ones are fetched and
+// matched by profile ID; the summary's profile name is not trusted because a
+// renamed profile's earlier deployments carry its old name.
+func findDeploymentsByDescription(ctx context.Context, client *aws.Client, resources *aws.ResolvedResources, substr string, firstOnly bool) ([]*aws.DeploymentDetails, error) {
+	deployments, err := client.ListAllDeployments(ctx, resources.ApplicationID, resources.EnvironmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+	slices.SortFunc(deployments, func(a, b types.DeploymentSummary) int {
+		return int(b.DeploymentNumber) - int(a.DeploymentNumber)
+	})
+
+	var matches []*aws.DeploymentDetails
+	for _, d := range deployments {
+		if d.State == types.DeploymentStateRolledBack {
+			continue
+		}
+		details, err := aws.GetDeploymentDetails(ctx, client, resources.ApplicationID, resources.EnvironmentID, d.DeploymentNumber)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get deployment details: %w", err)
+		}
+		if details.ConfigurationProfileID == resources.Profile.ID && strings.Contains(details.Description, substr) {
+			matches = append(matches, details)
+			if firstOnly {
+				break
+			}
+		}
+	}
+	return matches, nil
+}
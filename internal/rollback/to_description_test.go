@@ -0,0 +1,348 @@
+package rollback
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig/types"
+	awsInternal "github.com/koh-sh/apcdeploy/internal/aws"
+	"github.com/koh-sh/apcdeploy/internal/aws/mock"
+	prompttest "github.com/koh-sh/apcdeploy/internal/prompt/testing"
+	reportertest "github.com/koh-sh/apcdeploy/internal/reporter/testing"
+)
+
+// historyDeployment is one entry of the deployment history served by
+// newHistoryMock.
+type historyDeployment struct {
+	number      int32
+	profileID   string
+	version     string
+	description string
+	state       types.DeploymentState
+}
+
+// historyProfileName is the profile name ListDeployments reports for a
+// history entry; profile-123 is the configured test-profile.
+func historyProfileName(profileID string) string {
+	if profileID == "profile-123" {
+		return "test-profile"
+	}
+	return "other-profile"
+}
+
+// newHistoryMock serves the given deployments (newest first) and their
+// versions, and records the StartDeployment input in started.
+func newHistoryMock(history []historyDeployment, started *appconfig.StartDeploymentInput) func(ctx context.Context, region string) (*awsInternal.Client, error) {
+	mockClient := createStandardMockClient(
+		func(ctx context.Context, params *appconfig.ListDeploymentsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListDeploymentsOutput, error) {
+			items := make([]types.DeploymentSummary, 0, len(history))
+			for _, d := range history {
+				items = append(items, types.DeploymentSummary{DeploymentNumber: d.number, State: d.state, ConfigurationName: aws.String(historyProfileName(d.profileID))})
+			}
+			return &appconfig.ListDeploymentsOutput{Items: items}, nil
+		},
+		func(ctx context.Context, params *appconfig.GetDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.GetDeploymentOutput, error) {
+			// The deployment started by the rollback completes immediately.
+			if aws.ToInt32(params.DeploymentNumber) == 9 && started.ConfigurationVersion != nil {
+				return &appconfig.GetDeploymentOutput{DeploymentNumber: 9, State: types.DeploymentStateComplete}, nil
+			}
+			for _, d := range history {
+				if d.number == aws.ToInt32(params.DeploymentNumber) {
+					return &appconfig.GetDeploymentOutput{
+						DeploymentNumber:       d.number,
+						ConfigurationProfileId: aws.String(d.profileID),
+						ConfigurationVersion:   aws.String(d.version),
+						Description:            aws.String(d.description),
+						State:                  d.state,
+					}, nil
+				}
+			}
+			return nil, &types.ResourceNotFoundException{Message: aws.String("deployment not found")}
+		},
+		nil,
+	)
+	mockClient.ListHostedConfigurationVersionsFunc = func(ctx context.Context, params *appconfig.ListHostedConfigurationVersionsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListHostedConfigurationVersionsOutput, error) {
+		items := make([]types.HostedConfigurationVersionSummary, 0, len(history))
+		for _, d := range history {
+			n, _ := strconv.Atoi(d.version)
+			items = append(items, types.HostedConfigurationVersionSummary{VersionNumber: int32(n)})
+		}
+		return &appconfig.ListHostedConfigurationVersionsOutput{Items: items}, nil
+	}
+	// A 90 minute strategy (30m rollout + 60m final bake) exercises the
+	// long-bake check of --wait-bake.
+	mockClient.GetDeploymentStrategyFunc = func(ctx context.Context, params *appconfig.GetDeploymentStrategyInput, optFns ...func(*appconfig.Options)) (*appconfig.GetDeploymentStrategyOutput, error) {
+		return &appconfig.GetDeploymentStrategyOutput{DeploymentDurationInMinutes: 30, FinalBakeTimeInMinutes: 60, GrowthType: types.GrowthTypeLinear, GrowthFactor: aws.Float32(10)}, nil
+	}
+	mockClient.StartDeploymentFunc = func(ctx context.Context, params *appconfig.StartDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.StartDeploymentOutput, error) {
+		*started = *params
+		return &appconfig.StartDeploymentOutput{DeploymentNumber: 9}, nil
+	}
+	return func(ctx context.Context, region string) (*awsInternal.Client, error) {
+		return awsInternal.NewTestClient(mockClient), nil
+	}
+}
+
+func TestExecuteToDescription(t *testing.T) {
+	t.Parallel()
+
+	history := []historyDeployment{
+		{5, "profile-999", "7", "release-1.1.0 other profile", types.DeploymentStateComplete},
+		{4, "profile-123", "4", "deploy 1.3.0", types.DeploymentStateComplete},
+		{3, "profile-123", "3", "release-1.2.1 hotfix", types.DeploymentStateRolledBack},
+		{2, "profile-123", "2", "release-1.2.0", types.DeploymentStateComplete},
+		{1, "profile-123", "1", "release-1.1.0", types.DeploymentStateComplete},
+	}
+
+	tests := []struct {
+		name        string
+		history     []historyDeployment
+		substr      string
+		latestMatch bool
+		wantVersion string
+		wantSkip    string
+		wantErr     string
+	}{
+		{
+			name:        "unique match is redeployed",
+			history:     history,
+			substr:      "1.1.0",
+			wantVersion: "1",
+		},
+		{
+			name:    "ambiguous match is refused",
+			history: history,
+			substr:  "release-1",
+			wantErr: "--latest-match to use #2",
+		},
+		{
+			name:        "latest match picks the newest",
+			history:     history,
+			substr:      "release-1",
+			latestMatch: true,
+			wantVersion: "2",
+		},
+		{
+			name:    "rolled back deployments are ignored",
+			history: history,
+			substr:  "hotfix",
+			wantErr: `no deployment description contains "hotfix"`,
+		},
+		{
+			name:    "other profiles are ignored",
+			history: history,
+			substr:  "other profile",
+			wantErr: "no deployment description contains",
+		},
+		{
+			name:     "version already deployed is skipped",
+			history:  history,
+			substr:   "1.3.0",
+			wantSkip: "already serving v4",
+		},
+		{
+			name: "ongoing deployment is refused",
+			history: append([]historyDeployment{
+				{6, "profile-123", "5", "release-1.4.0", types.DeploymentStateDeploying},
+			}, history...),
+			substr:  "1.1.0",
+			wantErr: "deployment already in progress: deployment #6",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			configPath, cleanup := createTestConfig(t)
+			defer cleanup()
+
+			var started appconfig.StartDeploymentInput
+			reporter := &reportertest.MockReporter{}
+			executor := NewExecutorWithFactory(reporter, &prompttest.MockPrompter{}, newHistoryMock(tt.history, &started))
+
+			err := executor.ExecuteToDescription(context.Background(), &Options{
+				ConfigFile:       configPath,
+				SkipConfirmation: true,
+				ToDescription:    tt.substr,
+				LatestMatch:      tt.latestMatch,
+			})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got: %v", tt.wantErr, err)
+				}
+				if started.ConfigurationVersion != nil {
+					t.Error("StartDeployment should not be called")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tt.wantSkip != "" {
+				if started.ConfigurationVersion != nil {
+					t.Error("StartDeployment should not be called")
+				}
+				last := reporter.TargetsCalls[0].Transitions[len(reporter.TargetsCalls[0].Transitions)-1]
+				if last.Kind != "skip" || last.Reason != tt.wantSkip {
+					t.Errorf("final transition = %+v, want skip %q", last, tt.wantSkip)
+				}
+				return
+			}
+
+			if got := aws.ToString(started.ConfigurationVersion); got != tt.wantVersion {
+				t.Errorf("deployed version = %q, want %q", got, tt.wantVersion)
+			}
+			if got := aws.ToString(started.DeploymentStrategyId); got != "strategy-123" {
+				t.Errorf("deployment strategy = %q, want strategy-123", got)
+			}
+			if got := aws.ToString(started.Description); !strings.HasPrefix(got, "Rollback to deployment #") {
+				t.Errorf("description = %q, want rollback description", got)
+			}
+		})
+	}
+}
+
+func TestExecuteToDescriptionUserDeclined(t *testing.T) {
+	t.Parallel()
+
+	configPath, cleanup := createTestConfig(t)
+	defer cleanup()
+
+	var started appconfig.StartDeploymentInput
+	history := []historyDeployment{
+		{2, "profile-123", "2", "release-1.2.0", types.DeploymentStateComplete},
+		{1, "profile-123", "1", "release-1.1.0", types.DeploymentStateComplete},
+	}
+	prompter := &prompttest.MockPrompter{
+		InputFunc: func(message string, placeholder string) (string, error) {
+			if !strings.Contains(message, "v1") || !strings.Contains(message, "#1") {
+				t.Errorf("prompt should name the version and deployment, got: %q", message)
+			}
+			return "no", nil
+		},
+	}
+	executor := NewExecutorWithFactory(&reportertest.MockReporter{}, prompter, newHistoryMock(history, &started))
+
+	err := executor.ExecuteToDescription(context.Background(), &Options{ConfigFile: configPath, ToDescription: "1.1.0"})
+	if !errors.Is(err, ErrUserDeclined) {
+		t.Fatalf("expected ErrUserDeclined, got: %v", err)
+	}
+	if started.ConfigurationVersion != nil {
+		t.Error("StartDeployment should not be called")
+	}
+}
+
+func TestFindDeploymentsByDescriptionFetchesCandidatesOnly(t *testing.T) {
+	t.Parallel()
+
+	history := []historyDeployment{
+		{5, "profile-999", "7", "release-1.1.0 other profile", types.DeploymentStateComplete},
+		{4, "profile-123", "4", "release-1.3.0", types.DeploymentStateComplete},
+		{3, "profile-123", "3", "release-1.2.1", types.DeploymentStateRolledBack},
+		{2, "profile-123", "2", "release-1.2.0", types.DeploymentStateComplete},
+		{1, "profile-123", "1", "release-1.1.0", types.DeploymentStateComplete},
+	}
+	tests := []struct {
+		name        string
+		firstOnly   bool
+		wantMatches []int32
+		wantFetched []int32
+	}{
+		{name: "all matches", wantMatches: []int32{4, 2, 1}, wantFetched: []int32{5, 4, 2, 1}},
+		{name: "latest match stops early", firstOnly: true, wantMatches: []int32{4}, wantFetched: []int32{5, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var fetched []int32
+			client := awsInternal.NewTestClient(&mock.MockAppConfigClient{
+				ListDeploymentsFunc: func(ctx context.Context, params *appconfig.ListDeploymentsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListDeploymentsOutput, error) {
+					items := make([]types.DeploymentSummary, 0, len(history))
+					for _, d := range history {
+						items = append(items, types.DeploymentSummary{DeploymentNumber: d.number, State: d.state, ConfigurationName: aws.String(historyProfileName(d.profileID))})
+					}
+					return &appconfig.ListDeploymentsOutput{Items: items}, nil
+				},
+				GetDeploymentFunc: func(ctx context.Context, params *appconfig.GetDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.GetDeploymentOutput, error) {
+					n := aws.ToInt32(params.DeploymentNumber)
+					fetched = append(fetched, n)
+					for _, d := range history {
+						if d.number == n {
+							return &appconfig.GetDeploymentOutput{DeploymentNumber: n, ConfigurationProfileId: aws.String(d.profileID), ConfigurationVersion: aws.String(d.version), Description: aws.String(d.description), State: d.state}, nil
+						}
+					}
+					return nil, &types.ResourceNotFoundException{Message: aws.String("deployment not found")}
+				},
+			})
+			resources := &awsInternal.ResolvedResources{ApplicationID: "app-123", EnvironmentID: "env-123", Profile: &awsInternal.ProfileInfo{ID: "profile-123", Name: "test-profile"}}
+
+			matches, err := findDeploymentsByDescription(context.Background(), client, resources, "release-1", tt.firstOnly)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got := make([]int32, 0, len(matches))
+			for _, m := range matches {
+				got = append(got, m.DeploymentNumber)
+			}
+			if !slices.Equal(got, tt.wantMatches) {
+				t.Errorf("matches = %v, want %v", got, tt.wantMatches)
+			}
+			if !slices.Equal(fetched, tt.wantFetched) {
+				t.Errorf("GetDeployment called for %v, want %v", fetched, tt.wantFetched)
+			}
+		})
+	}
+}
+
+func TestFindDeploymentsByDescriptionAfterProfileRename(t *testing.T) {
+	t.Parallel()
+
+	// Deployments 1 and 2 were made before profile-123 was renamed from
+	// old-profile to test-profile; deployment 3 belongs to another profile
+	// that now carries the old name.
+	summaries := []types.DeploymentSummary{
+		{DeploymentNumber: 4, State: types.DeploymentStateComplete, ConfigurationName: aws.String("test-profile")},
+		{DeploymentNumber: 3, State: types.DeploymentStateComplete, ConfigurationName: aws.String("old-profile")},
+		{DeploymentNumber: 2, State: types.DeploymentStateComplete, ConfigurationName: aws.String("old-profile")},
+		{DeploymentNumber: 1, State: types.DeploymentStateComplete, ConfigurationName: aws.String("old-profile")},
+	}
+	profileIDs := map[int32]string{4: "profile-123", 3: "profile-999", 2: "profile-123", 1: "profile-123"}
+	client := awsInternal.NewTestClient(&mock.MockAppConfigClient{
+		ListDeploymentsFunc: func(ctx context.Context, params *appconfig.ListDeploymentsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListDeploymentsOutput, error) {
+			return &appconfig.ListDeploymentsOutput{Items: summaries}, nil
+		},
+		GetDeploymentFunc: func(ctx context.Context, params *appconfig.GetDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.GetDeploymentOutput, error) {
+			n := aws.ToInt32(params.DeploymentNumber)
+			return &appconfig.GetDeploymentOutput{
+				DeploymentNumber:       n,
+				ConfigurationProfileId: aws.String(profileIDs[n]),
+				ConfigurationVersion:   aws.String(strconv.Itoa(int(n))),
+				Description:            aws.String("release-1." + strconv.Itoa(int(n))),
+				State:                  types.DeploymentStateComplete,
+			}, nil
+		},
+	})
+	resources := &awsInternal.ResolvedResources{ApplicationID: "app-123", EnvironmentID: "env-123", Profile: &awsInternal.ProfileInfo{ID: "profile-123", Name: "test-profile"}}
+
+	matches, err := findDeploymentsByDescription(context.Background(), client, resources, "release-1", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := make([]int32, 0, len(matches))
+	for _, m := range matches {
+		got = append(got, m.DeploymentNumber)
+	}
+	if want := []int32{4, 2, 1}; !slices.Equal(got, want) {
+		t.Errorf("matches = %v, want %v", got, want)
+	}
+}
@@ -2,16 +2,20 @@ package run
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"math"
 	"os"
-	"path/filepath"
-	"strings"
 	"time"
 
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig"
 	"github.com/aws/aws-sdk-go-v2/service/appconfig/types"
 	"github.com/koh-sh/apcdeploy/internal/aws"
+	"github.com/koh-sh/apcdeploy/internal/cli"
 	"github.com/koh-sh/apcdeploy/internal/config"
+	"github.com/koh-sh/apcdeploy/internal/diff"
 	"github.com/koh-sh/apcdeploy/internal/reporter"
 )
 
@@ -19,6 +23,10 @@ import (
 type Deployer struct {
 	cfg       *config.Config
 	awsClient *aws.Client
+	// shared holds the application, profile and strategy resolved once for
+	// a multi-environment run; when set, ResolveResources only resolves the
+	// environment
+	shared *aws.ResolvedResources
 }
 
 // New creates a new Deployer instance
@@ -47,16 +55,26 @@ func NewWithClient(cfg *config.Config, awsClient *aws.Client) *Deployer {
 //
 // Parameters:
 //   - configPath: Path to the apcdeploy.yml configuration file
+//   - opts: DataFile and ContentType override data_file and content_type
+//     when non-empty; Stdin is read when the data file is "-"
 //
 // Returns:
 //   - *config.Config: Parsed configuration with resolved paths
 //   - []byte: Raw content of the data file
 //   - error: Any error during loading or parsing
-func loadConfiguration(configPath string) (*config.Config, []byte, error) {
+func loadConfiguration(configPath string, opts *Options) (*config.Config, []byte, error) {
 	// Load the config file
-	cfg, err := config.LoadConfig(configPath)
+	cfg, err := loadConfigFile(configPath, opts)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to load configuration: %w", err)
+		return nil, nil, err
+	}
+	applyOverrides(cfg, opts)
+	if cfg.DataFile == config.StdinDataFile {
+		dataContent, err := loadStdin(cfg, opts.Stdin)
+		if err != nil {
+			return nil, nil, err
+		}
+		return cfg, dataContent, nil
 	}
 
 	// Read data file (path is already resolved by LoadConfig)
@@ -68,31 +86,90 @@ func loadConfiguration(configPath string) (*config.Config, []byte, error) {
 	return cfg, dataContent, nil
 }
 
-// ValidateLocalData validates the configuration data locally
-func (d *Deployer) ValidateLocalData(data []byte, contentType string) error {
-	return config.ValidateData(data, contentType)
+// loadConfigFile loads the config file at configPath, or returns a copy of
+// opts.Config when one is given so overrides never leak into the caller's
+// value.
+func loadConfigFile(configPath string, opts *Options) (*config.Config, error) {
+	if opts.Config != nil {
+		cfg := *opts.Config
+		return &cfg, nil
+	}
+	return config.LoadConfig(configPath)
 }
 
-// DetermineContentType determines the content type based on profile type and file extension
-func (d *Deployer) DetermineContentType(profileType, dataPath string) (string, error) {
-	// Feature Flags always use JSON
-	if profileType == config.ProfileTypeFeatureFlags {
-		return config.ContentTypeJSON, nil
+// applyOverrides applies the --data-file and --content-type overrides to cfg.
+func applyOverrides(cfg *config.Config, opts *Options) {
+	if opts.DataFile != "" {
+		cfg.DataFile = opts.DataFile
+	}
+	if opts.ContentType != "" {
+		cfg.ContentType = opts.ContentType
+	}
+}
+
+// loadStdin reads the data for data_file "-". There is no extension to infer
+// the content type from, so content_type must be set; cfg.DataFile is then
+// replaced by a name with the matching extension ("stdin.json"), which is
+// what change detection and diffs pick their normalization from.
+func loadStdin(cfg *config.Config, stdin io.Reader) ([]byte, error) {
+	if cfg.ContentType == "" {
+		return nil, fmt.Errorf("reading the data from stdin requires --content-type or content_type in the config file")
+	}
+	dataContent, err := config.LoadDataReader(stdin)
+	if err != nil {
+		return nil, err
 	}
+	cfg.DataFile = "stdin" + config.ExtensionForContentType(cfg.ContentType)
+	return dataContent, nil
+}
 
-	// For Freeform, determine from file extension
-	ext := strings.ToLower(filepath.Ext(dataPath))
-	switch ext {
-	case ".json":
-		return config.ContentTypeJSON, nil
-	case ".yaml", ".yml":
-		return config.ContentTypeYAML, nil
-	case ".txt":
-		return config.ContentTypeText, nil
-	default:
-		// Default to text/plain for unknown extensions
-		return config.ContentTypeText, nil
+// loadConfigurationFromEnv loads the configuration file but takes the
+// deployment payload from a base64-encoded environment variable instead of
+// data_file (opts.DataBase64Env). data_file is still used to derive the
+// content type and diff normalization unless content_type or --content-type
+// is set.
+func loadConfigurationFromEnv(configPath string, opts *Options) (*config.Config, []byte, error) {
+	cfg, err := loadConfigFile(configPath, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	applyOverrides(cfg, opts)
+
+	dataContent, err := config.LoadDataBase64Env(opts.DataBase64Env)
+	if err != nil {
+		return nil, nil, err
 	}
+
+	return cfg, dataContent, nil
+}
+
+// ValidateLocalData validates the configuration data locally. FeatureFlags
+// JSON is also checked against the FeatureFlags schema, and JSON or YAML is
+// checked against schema_file when the config sets one.
+func (d *Deployer) ValidateLocalData(data []byte, contentType, profileType string) error {
+	if err := config.ValidateData(data, contentType); err != nil {
+		return err
+	}
+	if contentType == config.ContentTypeJSON && profileType == config.ProfileTypeFeatureFlags {
+		if err := config.ValidateFeatureFlags(data); err != nil {
+			return err
+		}
+	}
+	if d.cfg != nil {
+		return config.ValidateSchema(data, contentType, d.cfg.SchemaFile)
+	}
+	return nil
+}
+
+// DetermineContentType determines the content type based on profile type and file extension.
+// An explicit content_type in the config takes precedence over the extension;
+// see config.ContentTypeFor for the full precedence.
+func (d *Deployer) DetermineContentType(profileType, dataPath string) (string, error) {
+	configured := ""
+	if d.cfg != nil {
+		configured = d.cfg.ContentType
+	}
+	return config.ContentTypeFor(profileType, configured, dataPath), nil
 }
 
 // ResolveResources resolves all resource names to IDs
@@ -100,6 +177,16 @@ func (d *Deployer) ResolveResources(ctx context.Context) (*aws.ResolvedResources
 	// Create a resolver
 	resolver := aws.NewResolver(d.awsClient)
 
+	if d.shared != nil {
+		envID, err := resolver.ResolveEnvironment(ctx, d.shared.ApplicationID, d.cfg.Environment)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve resources: %w", err)
+		}
+		resolved := *d.shared
+		resolved.EnvironmentID = envID
+		return &resolved, nil
+	}
+
 	// Resolve all resources
 	resolved, err := resolver.ResolveAll(ctx,
 		d.cfg.Application,
@@ -126,12 +213,101 @@ func (d *Deployer) CreateVersion(ctx context.Context, resolved *aws.ResolvedReso
 	return d.awsClient.CreateHostedConfigurationVersion(ctx, resolved.ApplicationID, resolved.Profile.ID, content, contentType, description)
 }
 
+// GetVersion fetches an existing hosted configuration version of the
+// resolved profile, failing when it does not exist. Used by
+// --config-version to deploy a version without uploading one.
+func (d *Deployer) GetVersion(ctx context.Context, resolved *aws.ResolvedResources, versionNumber int32) ([]byte, string, error) {
+	output, err := d.awsClient.GetHostedConfigurationVersion(ctx, &appconfig.GetHostedConfigurationVersionInput{
+		ApplicationId:          &resolved.ApplicationID,
+		ConfigurationProfileId: &resolved.Profile.ID,
+		VersionNumber:          &versionNumber,
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("configuration version %d of profile %s: %w", versionNumber, resolved.Profile.Name, err)
+	}
+	return output.Content, awssdk.ToString(output.ContentType), nil
+}
+
+// DeleteVersion deletes a hosted configuration version. Used only to remove
+// the throwaway version created by --validate-remote.
+func (d *Deployer) DeleteVersion(ctx context.Context, resolved *aws.ResolvedResources, versionNumber int32) error {
+	return d.awsClient.DeleteHostedConfigurationVersion(ctx, resolved.ApplicationID, resolved.Profile.ID, versionNumber)
+}
+
+// ErrChangesFound is returned by a --check run when deploying would change
+// the configuration of at least one target.
+var ErrChangesFound = errors.New("changes would be deployed")
+
+// ErrLongBake is returned when --wait-bake would wait on a deployment
+// strategy estimated to take longer than the long-bake threshold and the run
+// was not started with --accept-long-bake.
+var ErrLongBake = errors.New("deployment strategy has a long bake time")
+
+// CheckBakeTime estimates how long --wait-bake will block from the
+// deployment strategy's rollout duration and final bake time. An estimate
+// above threshold (zero means DefaultLongBakeThreshold) is reported as a
+// warning and fails with ErrLongBake unless accept is set, since the wait
+// commands never prompt. A timeout that already covers the estimate counts
+// as accepting it. Shared by run, edit and rollback.
+func CheckBakeTime(ctx context.Context, client *aws.Client, rep reporter.Reporter, id, strategyID, strategyName string, timeout, threshold time.Duration, accept bool) error {
+	timing, err := client.GetStrategyTiming(ctx, strategyID)
+	if err != nil {
+		return err
+	}
+	if threshold <= 0 {
+		threshold = DefaultLongBakeThreshold
+	}
+	if timing.Total() <= threshold || timing.Total() <= timeout {
+		return nil
+	}
+
+	rep.Warn(fmt.Sprintf("%s: strategy %s takes about %s to complete (%s rollout, %s %g%% growth, %s final bake)",
+		id, strategyName, cli.FormatElapsed(timing.Total()), cli.FormatElapsed(timing.DeploymentDuration),
+		timing.GrowthType, timing.GrowthFactor, cli.FormatElapsed(timing.FinalBakeTime)))
+	if !accept {
+		return fmt.Errorf("%w: --wait-bake would wait about %s, longer than %s; pass --accept-long-bake or a --timeout that covers it to proceed",
+			ErrLongBake, cli.FormatElapsed(timing.Total()), cli.FormatElapsed(threshold))
+	}
+	return nil
+}
+
+// ErrServedContentMismatch is returned by VerifyServedContent when the
+// configuration served through AppConfigData differs from what was uploaded.
+var ErrServedContentMismatch = errors.New("served configuration does not match the deployed content")
+
+// VerifyServedContent fetches the configuration currently served to clients
+// through the AppConfigData API and compares it with uploaded after both are
+// normalized for contentType. It returns ErrServedContentMismatch when they
+// differ, e.g. because an extension transformed the content.
+func (d *Deployer) VerifyServedContent(ctx context.Context, resolved *aws.ResolvedResources, uploaded []byte, contentType string) error {
+	served, err := d.awsClient.GetConfiguration(ctx, resolved.ApplicationID, resolved.EnvironmentID, resolved.Profile.ID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch served configuration: %w", err)
+	}
+	changed, err := config.HasContentChanged(uploaded, served, config.ExtensionForContentType(contentType), resolved.Profile.Type, d.cfg.TextNormalizeOptions())
+	if err != nil {
+		return fmt.Errorf("failed to compare served configuration: %w", err)
+	}
+	if changed {
+		return ErrServedContentMismatch
+	}
+	return nil
+}
+
 // StartDeployment starts a deployment. The description (when non-empty) is
 // forwarded to AppConfig and shown in the console / on `apcdeploy status`.
 func (d *Deployer) StartDeployment(ctx context.Context, resolved *aws.ResolvedResources, versionNumber int32, description string) (int32, error) {
 	return d.awsClient.StartDeployment(ctx, resolved.ApplicationID, resolved.EnvironmentID, resolved.Profile.ID, resolved.DeploymentStrategyID, versionNumber, description)
 }
 
+// WaitForSlot waits until the target environment has no deployment in
+// progress. onWait is invoked with the blocking deployment on each poll that
+// still finds one; nil is allowed.
+func (d *Deployer) WaitForSlot(ctx context.Context, resolved *aws.ResolvedResources, timeoutSeconds int, onWait func(ongoing *types.DeploymentSummary)) error {
+	timeout := time.Duration(timeoutSeconds) * time.Second
+	return d.awsClient.WaitForNoOngoingDeployment(ctx, resolved.ApplicationID, resolved.EnvironmentID, timeout, onWait)
+}
+
 // WaitForDeploymentPhase waits for a deployment to reach a specific phase.
 // onTick is invoked on each polling tick; nil is allowed.
 func (d *Deployer) WaitForDeploymentPhase(ctx context.Context, resolved *aws.ResolvedResources, deploymentNumber int32, waitForBaking bool, timeoutSeconds int, onTick aws.DeploymentTickFunc) error {
@@ -201,23 +377,86 @@ func remainingFromElapsedSuffix(elapsed, total time.Duration) string {
 }
 
 // HasConfigurationChanges checks if the local configuration differs from the deployed version
-func (d *Deployer) HasConfigurationChanges(ctx context.Context, resolved *aws.ResolvedResources, localContent []byte, fileName, contentType string) (bool, error) {
-	// Get the latest deployment to find the deployed version number
+func (d *Deployer) HasConfigurationChanges(ctx context.Context, resolved *aws.ResolvedResources, localContent []byte, contentType string) (bool, error) {
+	previous, err := d.GetPreviousDeployment(ctx, resolved)
+	if err != nil {
+		return false, err
+	}
+	return d.HasChangesSince(ctx, resolved, previous, localContent, contentType)
+}
+
+// GetPreviousDeployment returns the deployment currently in effect for the
+// target (the latest one that was not rolled back), or nil when nothing has
+// been deployed yet.
+func (d *Deployer) GetPreviousDeployment(ctx context.Context, resolved *aws.ResolvedResources) (*aws.DeploymentInfo, error) {
 	deployment, err := aws.GetLatestDeployment(ctx, d.awsClient, resolved.ApplicationID, resolved.EnvironmentID, resolved.Profile.ID)
 	if err != nil {
-		return false, fmt.Errorf("failed to get latest deployment: %w", err)
+		return nil, fmt.Errorf("failed to get latest deployment: %w", err)
+	}
+	return deployment, nil
+}
+
+// NormalizedContents returns the normalized deployed and local content that
+// HasChangesSince compares, for --dump-normalized. The deployed side is nil
+// when previous is nil (first deployment). Both sides are normalized for
+// contentType, so stdin data and --content-type overrides compare the same
+// way as a data file with the matching extension.
+func (d *Deployer) NormalizedContents(ctx context.Context, resolved *aws.ResolvedResources, previous *aws.DeploymentInfo, localContent []byte, contentType string) (*string, string, error) {
+	ext := config.ExtensionForContentType(contentType)
+	local, err := config.NormalizeByExtension(string(localContent), ext, resolved.Profile.Type, d.cfg.TextNormalizeOptions())
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to normalize local content: %w", err)
+	}
+	if previous == nil {
+		return nil, local, nil
+	}
+
+	remoteContent, err := aws.GetHostedConfigurationVersion(ctx, d.awsClient, resolved.ApplicationID, resolved.Profile.ID, previous.ConfigurationVersion)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get deployed configuration: %w", err)
 	}
+	remote, err := config.NormalizeByExtension(string(remoteContent), ext, resolved.Profile.Type, d.cfg.TextNormalizeOptions())
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to normalize deployed content: %w", err)
+	}
+	return &remote, local, nil
+}
+
+// DiffSince reports, like HasChangesSince, whether the local configuration
+// differs from the version deployed by previous, and also returns the
+// unified diff (diff.Unified) of the normalized contents with the config's
+// redact_fields masked. A nil previous (first deployment) diffs against
+// empty content, so every local line is an addition.
+func (d *Deployer) DiffSince(ctx context.Context, resolved *aws.ResolvedResources, previous *aws.DeploymentInfo, localContent []byte, contentType string) (bool, string, error) {
+	remote, local, err := d.NormalizedContents(ctx, resolved, previous, localContent, contentType)
+	if err != nil {
+		return false, "", err
+	}
+	ext := config.ExtensionForContentType(contentType)
+	if remote == nil {
+		_, masked := config.RedactPair(local, local, ext, d.cfg.RedactFields)
+		return true, diff.Unified("", masked, diff.DefaultContext), nil
+	}
+	if *remote == local {
+		return false, "", nil
+	}
+	maskedRemote, maskedLocal := config.RedactPair(*remote, local, ext, d.cfg.RedactFields)
+	return true, diff.Unified(maskedRemote, maskedLocal, diff.DefaultContext), nil
+}
 
-	// If no deployment exists, this is the first deployment - has changes
-	if deployment == nil {
+// HasChangesSince checks if the local configuration differs from the version
+// deployed by previous. A nil previous is a first deployment, which always
+// has changes.
+func (d *Deployer) HasChangesSince(ctx context.Context, resolved *aws.ResolvedResources, previous *aws.DeploymentInfo, localContent []byte, contentType string) (bool, error) {
+	if previous == nil {
 		return true, nil
 	}
 
 	// Get the deployed configuration version content
-	remoteContent, err := aws.GetHostedConfigurationVersion(ctx, d.awsClient, resolved.ApplicationID, resolved.Profile.ID, deployment.ConfigurationVersion)
+	remoteContent, err := aws.GetHostedConfigurationVersion(ctx, d.awsClient, resolved.ApplicationID, resolved.Profile.ID, previous.ConfigurationVersion)
 	if err != nil {
 		return false, fmt.Errorf("failed to get deployed configuration: %w", err)
 	}
 
-	return config.HasContentChanged(remoteContent, localContent, filepath.Ext(fileName), resolved.Profile.Type)
+	return config.HasContentChanged(remoteContent, localContent, config.ExtensionForContentType(contentType), resolved.Profile.Type, d.cfg.TextNormalizeOptions())
 }
@@ -2,9 +2,11 @@ package run
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -82,7 +84,7 @@ region: us-east-1
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			cfg, dataContent, err := loadConfiguration(tt.configPath)
+			cfg, dataContent, err := loadConfiguration(tt.configPath, &Options{})
 			if (err != nil) != tt.wantErr {
 				t.Errorf("loadConfiguration() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -127,7 +129,7 @@ region: us-east-1
 		t.Fatalf("Failed to write data file: %v", err)
 	}
 
-	cfg, dataContent, err := loadConfiguration(configPath)
+	cfg, dataContent, err := loadConfiguration(configPath, &Options{})
 	if err != nil {
 		t.Fatalf("loadConfiguration() error = %v", err)
 	}
@@ -147,6 +149,7 @@ func TestDeployer_ValidateLocalData(t *testing.T) {
 		name        string
 		data        []byte
 		contentType string
+		profileType string
 		wantErr     bool
 	}{
 		{
@@ -191,12 +194,31 @@ func TestDeployer_ValidateLocalData(t *testing.T) {
 			contentType: "application/xml",
 			wantErr:     true,
 		},
+		{
+			name:        "valid feature flags",
+			data:        []byte(`{"flags": {"beta": {"name": "Beta"}}, "values": {"beta": {"enabled": true}}, "version": "1"}`),
+			contentType: "application/json",
+			profileType: config.ProfileTypeFeatureFlags,
+		},
+		{
+			name:        "feature flags schema violation",
+			data:        []byte(`{"flags": {"beta": {"name": "Beta"}}, "version": "1"}`),
+			contentType: "application/json",
+			profileType: config.ProfileTypeFeatureFlags,
+			wantErr:     true,
+		},
+		{
+			name:        "freeform JSON is not checked against the flags schema",
+			data:        []byte(`{"flags": {"beta": {}}}`),
+			contentType: "application/json",
+			profileType: config.ProfileTypeFreeform,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			d := &Deployer{}
-			err := d.ValidateLocalData(tt.data, tt.contentType)
+			err := d.ValidateLocalData(tt.data, tt.contentType, tt.profileType)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("ValidateLocalData() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -204,11 +226,45 @@ func TestDeployer_ValidateLocalData(t *testing.T) {
 	}
 }
 
+func TestDeployer_ValidateLocalDataSchemaFile(t *testing.T) {
+	t.Parallel()
+
+	schemaPath := filepath.Join(t.TempDir(), "schema.json")
+	if err := os.WriteFile(schemaPath, []byte(`{"type": "object", "properties": {"port": {"type": "integer"}}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		data        string
+		contentType string
+		wantErr     bool
+	}{
+		{name: "matching JSON", data: `{"port": 80}`, contentType: config.ContentTypeJSON},
+		{name: "JSON violating the schema", data: `{"port": "80"}`, contentType: config.ContentTypeJSON, wantErr: true},
+		{name: "matching YAML", data: "port: 80\n", contentType: config.ContentTypeYAML},
+		{name: "YAML violating the schema", data: "port: \"80\"\n", contentType: config.ContentTypeYAML, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			d := &Deployer{cfg: &config.Config{SchemaFile: schemaPath}}
+			err := d.ValidateLocalData([]byte(tt.data), tt.contentType, config.ProfileTypeFreeform)
+			var schemaErr *config.SchemaError
+			if got := errors.As(err, &schemaErr); got != tt.wantErr {
+				t.Errorf("ValidateLocalData() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestDeployer_DetermineContentType(t *testing.T) {
 	tests := []struct {
 		name        string
 		profileType string
 		dataPath    string
+		cfg         *config.Config
 		want        string
 		wantErr     bool
 	}{
@@ -254,11 +310,27 @@ func TestDeployer_DetermineContentType(t *testing.T) {
 			want:        "text/plain",
 			wantErr:     false,
 		},
+		{
+			name:        "Freeform content_type overrides extension",
+			profileType: config.ProfileTypeFreeform,
+			dataPath:    "config.json",
+			cfg:         &config.Config{ContentType: config.ContentTypeYAML},
+			want:        "application/x-yaml",
+			wantErr:     false,
+		},
+		{
+			name:        "Feature Flags ignore content_type",
+			profileType: config.ProfileTypeFeatureFlags,
+			dataPath:    "flags.json",
+			cfg:         &config.Config{ContentType: config.ContentTypeText},
+			want:        "application/json",
+			wantErr:     false,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			d := &Deployer{}
+			d := &Deployer{cfg: tt.cfg}
 			got, err := d.DetermineContentType(tt.profileType, tt.dataPath)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("DetermineContentType() error = %v, wantErr %v", err, tt.wantErr)
@@ -652,6 +724,7 @@ func TestHasConfigurationChanges(t *testing.T) {
 		localContent         []byte
 		remoteContent        []byte
 		fileName             string
+		contentType          string // overrides the type derived from fileName
 		profileType          string
 		hasDeployment        bool
 		wantChanges          bool
@@ -721,6 +794,20 @@ func TestHasConfigurationChanges(t *testing.T) {
 				State:                types.DeploymentStateComplete,
 			},
 		},
+		{
+			name:          "stdin JSON is normalized by content type",
+			localContent:  []byte("{\n  \"key\": \"value\"\n}\n"),
+			remoteContent: []byte(`{"key":"value"}`),
+			fileName:      config.StdinDataFile,
+			contentType:   config.ContentTypeJSON,
+			profileType:   config.ProfileTypeFreeform,
+			hasDeployment: true,
+			wantChanges:   false,
+			mockDeployment: &types.DeploymentSummary{
+				ConfigurationVersion: aws.String("1"),
+				State:                types.DeploymentStateComplete,
+			},
+		},
 		{
 			name:          "identical JSON content",
 			localContent:  []byte(`{"key":"value"}`),
@@ -866,7 +953,11 @@ func TestHasConfigurationChanges(t *testing.T) {
 				},
 			}
 
-			hasChanges, err := deployer.HasConfigurationChanges(context.Background(), resolved, tt.localContent, tt.fileName, "application/json")
+			contentType := tt.contentType
+			if contentType == "" {
+				contentType = config.ContentTypeFor(tt.profileType, "", tt.fileName)
+			}
+			hasChanges, err := deployer.HasConfigurationChanges(context.Background(), resolved, tt.localContent, contentType)
 			if (err != nil) != tt.wantErr {
 				t.Fatalf("HasConfigurationChanges() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -878,6 +969,80 @@ func TestHasConfigurationChanges(t *testing.T) {
 	}
 }
 
+func TestDiffSince(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		local       string
+		remote      *string // nil: no previous deployment
+		redact      []string
+		wantChanged bool
+		want        string
+	}{
+		{
+			name:        "formatting-only difference",
+			local:       `{"a":1,"b":2}`,
+			remote:      aws.String("{\n  \"b\": 2,\n  \"a\": 1\n}"),
+			wantChanged: false,
+		},
+		{
+			name:        "changed value",
+			local:       `{"a":1,"b":3}`,
+			remote:      aws.String(`{"a":1,"b":2}`),
+			wantChanged: true,
+			want:        "--- remote\n+++ local\n@@ -1,4 +1,4 @@\n {\n   \"a\": 1,\n-  \"b\": 2\n+  \"b\": 3\n }\n",
+		},
+		{
+			name:        "redacted value",
+			local:       `{"token":"new"}`,
+			remote:      aws.String(`{"token":"old"}`),
+			redact:      []string{"token"},
+			wantChanged: true,
+			want:        "--- remote\n+++ local\n@@ -1,3 +1,3 @@\n {\n-  \"token\": \"[REDACTED]\"\n+  \"token\": \"[REDACTED (changed)]\"\n }\n",
+		},
+		{
+			name:        "first deployment",
+			local:       `{"a":1}`,
+			wantChanged: true,
+			want:        "--- remote\n+++ local\n@@ -0,0 +1,3 @@\n+{\n+  \"a\": 1\n+}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockClient := &mock.MockAppConfigClient{
+				GetHostedConfigurationVersionFunc: func(ctx context.Context, params *appconfig.GetHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.GetHostedConfigurationVersionOutput, error) {
+					return &appconfig.GetHostedConfigurationVersionOutput{Content: []byte(*tt.remote)}, nil
+				},
+			}
+			deployer := NewWithClient(&config.Config{DataFile: "data.json", RedactFields: tt.redact}, awsInternal.NewTestClient(mockClient))
+			resolved := &awsInternal.ResolvedResources{
+				ApplicationID: "app-123",
+				EnvironmentID: "env-123",
+				Profile:       &awsInternal.ProfileInfo{ID: "profile-123", Type: config.ProfileTypeFreeform},
+			}
+			var previous *awsInternal.DeploymentInfo
+			if tt.remote != nil {
+				previous = &awsInternal.DeploymentInfo{ConfigurationVersion: "1"}
+			}
+
+			changed, unified, err := deployer.DiffSince(context.Background(), resolved, previous, []byte(tt.local), config.ContentTypeJSON)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if changed != tt.wantChanged {
+				t.Errorf("changed = %v, want %v", changed, tt.wantChanged)
+			}
+			if unified != tt.want {
+				t.Errorf("diff =\n%s\nwant\n%s", unified, tt.want)
+			}
+		})
+	}
+}
+
 // TestMakeTargetsDeployTick verifies that progress / phase finalisation
 // branches route correctly: DEPLOYING reports the live percent + ETA via
 // SetProgress, while BAKING / COMPLETE pin to 1.0 (the row's caller swaps
@@ -1038,3 +1203,106 @@ func TestRemainingFromElapsedSuffix(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadConfigurationFromEnv(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "apcdeploy.yml")
+	configContent := `application: test-app
+configuration_profile: test-profile
+environment: test-env
+data_file: data.json
+content_type: application/json
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	// data.json intentionally does not exist: the payload comes from the env var.
+	t.Setenv("APCDEPLOY_TEST_PAYLOAD", base64.StdEncoding.EncodeToString([]byte(`{"from": "env"}`)))
+
+	cfg, dataContent, err := loadConfigurationFromEnv(configPath, &Options{DataBase64Env: "APCDEPLOY_TEST_PAYLOAD"})
+	if err != nil {
+		t.Fatalf("loadConfigurationFromEnv() error = %v", err)
+	}
+	if string(dataContent) != `{"from": "env"}` {
+		t.Errorf("Data content = %q", dataContent)
+	}
+	if cfg.ContentType != config.ContentTypeJSON {
+		t.Errorf("ContentType = %q, want %q", cfg.ContentType, config.ContentTypeJSON)
+	}
+
+	if _, _, err := loadConfigurationFromEnv(configPath, &Options{DataBase64Env: "APCDEPLOY_TEST_UNSET"}); err == nil {
+		t.Error("expected error for unset environment variable")
+	}
+}
+
+func TestLoadConfigurationStdin(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		dataFile     string // data_file in the config
+		override     string // --data-file
+		contentType  string // content_type in the config
+		flagType     string // --content-type
+		wantErr      string
+		wantDataFile string
+	}{
+		{
+			name:         "data_file is -",
+			dataFile:     "-",
+			contentType:  config.ContentTypeJSON,
+			wantDataFile: "stdin.json",
+		},
+		{
+			name:         "--data-file - overrides data_file",
+			dataFile:     "data.yaml",
+			override:     "-",
+			contentType:  config.ContentTypeYAML,
+			wantDataFile: "stdin.yaml",
+		},
+		{
+			name:         "--content-type stands in for content_type",
+			dataFile:     "-",
+			flagType:     config.ContentTypeText,
+			wantDataFile: "stdin.txt",
+		},
+		{
+			name:     "content_type is required",
+			dataFile: "-",
+			wantErr:  "reading the data from stdin requires --content-type or content_type",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			configPath := filepath.Join(t.TempDir(), "apcdeploy.yml")
+			configContent := "application: test-app\nconfiguration_profile: test-profile\nenvironment: test-env\ndata_file: \"" + tt.dataFile + "\"\n"
+			if tt.contentType != "" {
+				configContent += "content_type: " + tt.contentType + "\n"
+			}
+			if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+
+			cfg, dataContent, err := loadConfiguration(configPath, &Options{DataFile: tt.override, ContentType: tt.flagType, Stdin: strings.NewReader(`{"from": "stdin"}`)})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("loadConfiguration() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("loadConfiguration() error = %v", err)
+			}
+			if string(dataContent) != `{"from": "stdin"}` {
+				t.Errorf("Data content = %q", dataContent)
+			}
+			if cfg.DataFile != tt.wantDataFile {
+				t.Errorf("DataFile = %q, want %q", cfg.DataFile, tt.wantDataFile)
+			}
+		})
+	}
+}
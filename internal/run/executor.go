@@ -2,19 +2,32 @@ package run
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig/types"
 	"github.com/koh-sh/apcdeploy/internal/aws"
 	"github.com/koh-sh/apcdeploy/internal/cli"
 	"github.com/koh-sh/apcdeploy/internal/config"
+	"github.com/koh-sh/apcdeploy/internal/diff"
 	"github.com/koh-sh/apcdeploy/internal/reporter"
+	"github.com/koh-sh/apcdeploy/internal/state"
+	"github.com/koh-sh/apcdeploy/internal/tracing"
 )
 
 // Executor handles the deployment orchestration
 type Executor struct {
 	reporter        reporter.Reporter
 	deployerFactory func(context.Context, *config.Config) (*Deployer, error)
+
+	mu      sync.Mutex
+	results []TargetResult
 }
 
 // NewExecutor creates a new deployment executor
@@ -45,129 +58,834 @@ func NewExecutorWithFactory(rep reporter.Reporter, factory func(context.Context,
 //
 // Sub-phases (output.md §3.2):
 //
-//	preparing → comparing → creating-version → deploying → baking
+//	preparing → comparing → creating-version → deploying → baking → verifying
 //
 // The deploying sub-phase drives Targets.SetProgress with AppConfig's
 // PercentageComplete so the caller sees a real rollout bar; the baking
 // sub-phase uses Targets.SetPhase("baking", detail) instead because there
 // is no quantified progress to report (it's a monitoring wait).
+//
+// With GuardAlarms set, a "checking-alarms" sub-phase runs just before
+// creating-version and the target fails with aws.ErrAlarmFiring while any
+// of the alarms is in the ALARM state; nothing is created or deployed.
+//
+// With WaitBake set, a strategy whose rollout plus final bake time exceeds
+// LongBakeThreshold and the wait timeout is reported with a warning next,
+// and the target fails with ErrLongBake unless AcceptLongBake is set.
+//
+// With CreateOnly set the target stops once the version is created: the row
+// reads "created vN (not deployed)", no deployment is started and the local
+// deploy record is not written. An ongoing deployment does not block it.
+//
+// With ListStrategies set nothing is deployed: the strategy names are
+// written to stdout, one per line, for copying into deployment_strategy.
+//
+// With Check set nothing is created or deployed either: each target is
+// compared with its deployed version and ErrChangesFound is returned when
+// any of them would change.
+//
+// The command runs inside an "apcdeploy.run" span, with a child span per
+// phase (see internal/tracing); spans are no-ops unless tracing is enabled.
 func (e *Executor) Execute(ctx context.Context, opts *Options) error {
+	ctx, span := tracing.Start(ctx, "apcdeploy.run")
+	err := e.execute(ctx, opts)
+	span.End(err)
+	return err
+}
+
+func (e *Executor) execute(ctx context.Context, opts *Options) error {
+	if opts.ListStrategies {
+		return e.listStrategies(ctx, opts)
+	}
 	if opts.Timeout < 0 {
 		return fmt.Errorf("timeout must be a non-negative value")
 	}
 	if opts.WaitDeploy && opts.WaitBake {
 		return fmt.Errorf("--wait-deploy and --wait-bake cannot be used together")
 	}
+	if opts.ValidateRemote && (opts.WaitDeploy || opts.WaitBake) {
+		return fmt.Errorf("--validate-remote cannot be used with --wait-deploy or --wait-bake")
+	}
+	if opts.KeepValidationVersion && !opts.ValidateRemote {
+		return fmt.Errorf("--keep-validation-version requires --validate-remote")
+	}
+	if opts.Verify && !opts.WaitDeploy && !opts.WaitBake {
+		return fmt.Errorf("--verify requires --wait-deploy or --wait-bake")
+	}
+	if opts.DumpNormalized != "" && (opts.EnvironmentsByTag != "" || len(opts.Environments) > 1 || opts.ValidateRemote) {
+		return fmt.Errorf("--dump-normalized cannot be used with --environments-by-tag, several --environment or --validate-remote")
+	}
+	if opts.EnvironmentsByTag != "" && len(opts.Environments) > 0 {
+		return fmt.Errorf("--environment cannot be used with --environments-by-tag")
+	}
+	for i, name := range opts.Environments {
+		if name == "" {
+			return fmt.Errorf("--environment cannot be empty")
+		}
+		if slices.Contains(opts.Environments[:i], name) {
+			return fmt.Errorf("--environment %s is given more than once", name)
+		}
+	}
+	if opts.DryRun && (opts.WaitDeploy || opts.WaitBake || opts.WaitForSlot || opts.ValidateRemote) {
+		return fmt.Errorf("--dry-run cannot be used with --wait-deploy, --wait-bake, --wait-for-slot or --validate-remote")
+	}
+	if opts.DataFile != "" && opts.DataBase64Env != "" {
+		return fmt.Errorf("--data-file cannot be used with --data-base64-env")
+	}
+	if opts.Check && (opts.DryRun || opts.Force || opts.WaitDeploy || opts.WaitBake || opts.WaitForSlot || opts.ValidateRemote) {
+		return fmt.Errorf("--check cannot be used with --dry-run, --force, --wait-deploy, --wait-bake, --wait-for-slot or --validate-remote")
+	}
+
+	if opts.SkipDiffCheck && (opts.DryRun || opts.Check || opts.ValidateRemote || opts.DumpNormalized != "") {
+		return fmt.Errorf("--skip-diff-check cannot be used with --dry-run, --check, --validate-remote or --dump-normalized")
+	}
+
+	if opts.ConfigVersion < 0 {
+		return fmt.Errorf("--config-version must be a positive version number")
+	}
+	if opts.ConfigVersion != 0 && (opts.DataFile != "" || opts.DataBase64Env != "" || opts.ContentType != "" || opts.ExpandEnv || opts.ApplyNormalize || opts.ValidateRemote || opts.DryRun || opts.Check || opts.DumpNormalized != "") {
+		return fmt.Errorf("--config-version cannot be used with --data-file, --data-base64-env, --content-type, --expand-env, --apply-normalize, --validate-remote, --dry-run, --check or --dump-normalized")
+	}
+
+	if opts.CreateOnly && (opts.WaitDeploy || opts.WaitBake || opts.WaitForSlot || opts.ConfigVersion != 0 || opts.ValidateRemote || opts.DryRun || opts.Check || len(opts.GuardAlarms) > 0) {
+		return fmt.Errorf("--create-only cannot be used with --wait-deploy, --wait-bake, --wait-for-slot, --config-version, --validate-remote, --dry-run, --check or --guard-alarm")
+	}
+	// The version belongs to the profile, not an environment, so staging it
+	// once per environment would only create duplicates
+	if opts.CreateOnly && (opts.EnvironmentsByTag != "" || len(opts.Environments) > 1) {
+		return fmt.Errorf("--create-only cannot be used with --environments-by-tag or several --environment")
+	}
+
+	var (
+		cfg         *config.Config
+		dataContent []byte
+		err         error
+	)
+	_, phase := tracing.Start(ctx, "load")
+	switch {
+	case opts.ConfigVersion != 0:
+		// The version's content is fetched once its profile is resolved;
+		// the data file is not read
+		cfg, err = loadConfigFile(opts.ConfigFile, opts)
+	case opts.DataBase64Env != "":
+		cfg, dataContent, err = loadConfigurationFromEnv(opts.ConfigFile, opts)
+	default:
+		cfg, dataContent, err = loadConfiguration(opts.ConfigFile, opts)
+	}
+	phase.End(err)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	// Expansion happens before anything inspects the content, so the
+	// expanded document is what gets validated, compared and uploaded.
+	if opts.ExpandEnv {
+		dataContent, err = config.ExpandEnv(dataContent, os.LookupEnv)
+		if err != nil {
+			return err
+		}
+	}
+	// The size is checked on the content that would be uploaded, before any
+	// AWS call, so an oversized file fails fast with its exact size.
+	nearLimit, err := config.CheckDataSize(dataContent)
+	if err != nil {
+		return err
+	}
+	if nearLimit {
+		e.reporter.Warn(fmt.Sprintf("configuration is %d bytes, %.0f%% of the %d byte hosted configuration limit", len(dataContent), float64(len(dataContent))*100/config.MaxConfigSize, config.MaxConfigSize))
+	}
+	cfg.ApplyRegionOverride(opts.Region)
+	e.applyStrategyOverride(cfg, opts.DeploymentStrategy)
 
-	cfg, dataContent, err := loadConfiguration(opts.ConfigFile)
+	deployer, err := e.deployerFactory(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create deployer: %w", err)
+	}
+	if opts.PollBackoff {
+		deployer.awsClient.PollBackoff = true
+	}
+	if opts.PollInterval > 0 {
+		deployer.awsClient.PollingInterval = opts.PollInterval
+	}
+
+	// The account guard runs before anything else touches AWS so a wrong
+	// profile or role never gets as far as creating a version.
+	if cfg.AccountID != "" {
+		if err := deployer.awsClient.CheckAccount(ctx, cfg.AccountID); err != nil {
+			return fmt.Errorf("refusing to deploy: %w", err)
+		}
+	}
+
+	st := e.loadState(opts)
+
+	if opts.EnvironmentsByTag != "" {
+		return e.deployByTag(ctx, opts, cfg, dataContent, deployer, st)
+	}
+	if len(opts.Environments) > 0 {
+		return e.deployToEnvironments(ctx, opts, cfg, dataContent, deployer, st)
+	}
+	return e.deploy(ctx, opts, cfg, dataContent, deployer, st)
+}
+
+// applyStrategyOverride replaces the configured deployment strategy with
+// --deployment-strategy. A strategy other than the configured one is warned
+// about so a non-standard rollout (e.g. AllAtOnce for a hotfix) stands out
+// in the log.
+func (e *Executor) applyStrategyOverride(cfg *config.Config, strategy string) {
+	if strategy == "" {
+		return
+	}
+	if strategy != cfg.DeploymentStrategy {
+		e.reporter.Warn(fmt.Sprintf("deploying with strategy %s instead of %s from the config (--deployment-strategy)", strategy, cfg.DeploymentStrategy))
+	}
+	cfg.DeploymentStrategy = strategy
+}
+
+// listStrategies writes the names of the deployment strategies in the
+// config's region to stdout. Only the config file is read, so a data file
+// that does not exist yet does not get in the way.
+func (e *Executor) listStrategies(ctx context.Context, opts *Options) error {
+	cfg, err := config.LoadConfig(opts.ConfigFile)
 	if err != nil {
 		return fmt.Errorf("failed to load configuration: %w", err)
 	}
+	cfg.ApplyRegionOverride(opts.Region)
 
 	deployer, err := e.deployerFactory(ctx, cfg)
 	if err != nil {
 		return fmt.Errorf("failed to create deployer: %w", err)
 	}
+	strategies, err := deployer.awsClient.ListAllDeploymentStrategies(ctx)
+	if err != nil {
+		return err
+	}
 
+	var b strings.Builder
+	for _, strategy := range strategies {
+		b.WriteString(awssdk.ToString(strategy.Name))
+		b.WriteString("\n")
+	}
+	e.reporter.Data([]byte(b.String()))
+	return nil
+}
+
+// loadState reads the local deploy record unless --no-state is set. An
+// unreadable file is reported and then ignored so a corrupt record never
+// blocks a deployment; it is overwritten by the next successful one.
+func (e *Executor) loadState(opts *Options) *state.State {
+	if opts.NoState {
+		return nil
+	}
+	st, err := state.Load(state.PathFor(opts.ConfigFile))
+	if err != nil {
+		e.reporter.Warn(fmt.Sprintf("ignoring local deploy state: %v", err))
+		return &state.State{Targets: map[string]state.Record{}}
+	}
+	return st
+}
+
+// saveState records a completed deployment of id. Failing to write the
+// record only warns: the deployment itself already succeeded.
+func (e *Executor) saveState(opts *Options, st *state.State, id string, rec state.Record) {
+	if st == nil {
+		return
+	}
+	st.Set(id, rec)
+	if err := st.Save(state.PathFor(opts.ConfigFile)); err != nil {
+		e.reporter.Warn(err.Error())
+	}
+}
+
+// recordStillDeployed reports whether rec lets a run skip: the data still
+// hashes to the recorded content and the environment's newest deployment is
+// still the recorded one, COMPLETE. Any later deployment (rollback, edit,
+// patch, apply or a console change) or a failed lookup falls back to the
+// full comparison, as do records written before the application and
+// environment IDs were stored.
+func recordStillDeployed(ctx context.Context, deployer *Deployer, rec state.Record, contentHash string) bool {
+	if rec.ContentSHA256 != contentHash || rec.ApplicationID == "" || rec.EnvironmentID == "" {
+		return false
+	}
+	latest, err := deployer.awsClient.LatestDeploymentSummary(ctx, rec.ApplicationID, rec.EnvironmentID)
+	if err != nil || latest == nil {
+		return false
+	}
+	return latest.DeploymentNumber == rec.DeploymentNumber && latest.State == types.DeploymentStateComplete
+}
+
+// deployByTag deploys to every environment of the application carrying the
+// tag given by --environments-by-tag. The matched environments are reported
+// before anything is deployed; each then gets its own Targets row and the
+// first failure stops the remaining deployments.
+func (e *Executor) deployByTag(ctx context.Context, opts *Options, cfg *config.Config, dataContent []byte, deployer *Deployer, st *state.State) error {
+	key, value, err := aws.ParseTagFilter(opts.EnvironmentsByTag)
+	if err != nil {
+		return err
+	}
+
+	appID, err := aws.NewResolver(deployer.awsClient).ResolveApplication(ctx, cfg.Application)
+	if err != nil {
+		return fmt.Errorf("failed to resolve resources: %w", err)
+	}
+	envs, err := deployer.awsClient.ListEnvironmentsByTag(ctx, appID, key, value)
+	if err != nil {
+		return fmt.Errorf("failed to list environments by tag: %w", err)
+	}
+	if len(envs) == 0 {
+		return fmt.Errorf("no environments of application %s match tag %s", cfg.Application, opts.EnvironmentsByTag)
+	}
+
+	names := make([]string, len(envs))
+	for i, env := range envs {
+		names[i] = awssdk.ToString(env.Name)
+	}
+	e.reporter.Info(fmt.Sprintf("%d environment(s) match tag %s: %s", len(names), opts.EnvironmentsByTag, strings.Join(names, ", ")))
+	return e.deploySequentially(ctx, opts, cfg, dataContent, deployer, st, names, nil)
+}
+
+// deployToEnvironments deploys to the environments given by --environment, in the
+// order given, in place of the configured environment. The application,
+// profile and strategy are resolved once up front; each environment is
+// then resolved and deployed in its own Targets row.
+func (e *Executor) deployToEnvironments(ctx context.Context, opts *Options, cfg *config.Config, dataContent []byte, deployer *Deployer, st *state.State) error {
+	resolver := aws.NewResolver(deployer.awsClient)
+	appID, err := resolver.ResolveApplication(ctx, cfg.Application)
+	if err != nil {
+		return fmt.Errorf("failed to resolve resources: %w", err)
+	}
+	profile, err := resolver.ResolveConfigurationProfile(ctx, appID, cfg.ConfigurationProfile)
+	if err != nil {
+		return fmt.Errorf("failed to resolve resources: %w", err)
+	}
+	strategyID, err := resolver.ResolveDeploymentStrategy(ctx, cfg.DeploymentStrategy)
+	if err != nil {
+		return fmt.Errorf("failed to resolve resources: %w", err)
+	}
+	shared := &aws.ResolvedResources{ApplicationID: appID, Profile: profile, DeploymentStrategyID: strategyID}
+
+	if len(opts.Environments) > 1 {
+		e.reporter.Info(fmt.Sprintf("deploying to %d environments in order: %s", len(opts.Environments), strings.Join(opts.Environments, ", ")))
+	}
+	return e.deploySequentially(ctx, opts, cfg, dataContent, deployer, st, opts.Environments, shared)
+}
+
+// deploySequentially deploys to each named environment in turn and stops
+// at the first failure. Deployments that already started are left running;
+// the warning names them along with the environments that were not
+// attempted. shared, when non-nil, skips re-resolving everything but the
+// environment.
+func (e *Executor) deploySequentially(ctx context.Context, opts *Options, cfg *config.Config, dataContent []byte, deployer *Deployer, st *state.State, names []string, shared *aws.ResolvedResources) error {
+	// With --check every environment is compared before ErrChangesFound is
+	// returned, so the output lists all of those that would change.
+	changed := false
+	for i, name := range names {
+		envCfg := *cfg
+		envCfg.Environment = name
+		envDeployer := NewWithClient(&envCfg, deployer.awsClient)
+		envDeployer.shared = shared
+		err := e.deploy(ctx, opts, &envCfg, dataContent, envDeployer, st)
+		if errors.Is(err, ErrChangesFound) {
+			changed = true
+			continue
+		}
+		if err != nil {
+			if len(names) > 1 {
+				msg := "stopped at environment " + name
+				if i > 0 {
+					msg += "; already processed: " + strings.Join(names[:i], ", ")
+				}
+				if rest := names[i+1:]; len(rest) > 0 {
+					msg += "; not attempted: " + strings.Join(rest, ", ")
+				}
+				e.reporter.Warn(msg)
+			}
+			return fmt.Errorf("environment %s: %w", name, err)
+		}
+	}
+	if changed {
+		return ErrChangesFound
+	}
+	return nil
+}
+
+// deploy runs the deployment workflow for a single target inside its own
+// Targets row and its own "apcdeploy.deploy" span.
+func (e *Executor) deploy(ctx context.Context, opts *Options, cfg *config.Config, dataContent []byte, deployer *Deployer, st *state.State) (err error) {
 	id := config.Identifier(deployer.awsClient.Region, cfg)
+	ctx, span := tracing.Start(ctx, "apcdeploy.deploy",
+		tracing.String(tracing.AttrRegion, deployer.awsClient.Region),
+		tracing.String(tracing.AttrApplication, cfg.Application),
+		tracing.String(tracing.AttrProfile, cfg.ConfigurationProfile),
+		tracing.String(tracing.AttrEnvironment, cfg.Environment),
+	)
+	defer func() { span.End(err) }()
+
+	res := TargetResult{
+		Target:      id,
+		Application: cfg.Application,
+		Profile:     cfg.ConfigurationProfile,
+		Environment: cfg.Environment,
+		Region:      deployer.awsClient.Region,
+	}
+	// Registered before tg.Close so the warning is printed after the row
+	// is finalised rather than in the middle of a TTY redraw.
+	defer func() {
+		if err != nil && !errors.Is(err, ErrChangesFound) {
+			res.Status = StatusFailed
+			res.Error = err.Error()
+		}
+		if res.FirstDeploy && res.Status != StatusFailed {
+			e.reporter.Warn(id + ": no previous deployment; deployed as the first version")
+		}
+		e.record(res)
+	}()
+
 	tg := e.reporter.Targets([]string{id})
 	defer tg.Close()
 	tg.SetPhase(id, "preparing", "")
+	timeout := cfg.EffectiveTimeout(opts.Timeout)
+
+	// The local deploy record lets an unchanged file skip with one
+	// ListDeployments call instead of resolving and fetching the deployed
+	// content; --force or --no-state always compare against the deployed
+	// content. --check and --dry-run always compare against AppConfig, since
+	// answering from the record would hide drift.
+	contentHash := state.Hash(dataContent)
+	if st != nil && !opts.Force && !opts.SkipDiffCheck && !opts.ValidateRemote && !opts.Check && !opts.DryRun && opts.ConfigVersion == 0 {
+		if rec, ok := st.Get(id); ok && recordStillDeployed(ctx, deployer, rec, contentHash) {
+			tg.Skip(id, fmt.Sprintf("skipped (unchanged since v%d, local state)", rec.Version))
+			res.Status = StatusSkipped
+			return nil
+		}
+	}
 
+	// The resolve span carries the mode so --concurrent-resolve=false runs
+	// can be compared against the default in the trace backend.
+	tg.SetPhase(id, "resolving", "("+aws.ResolveMode()+")")
+	_, phase := tracing.Start(ctx, "resolve", tracing.String(tracing.AttrResolveMode, aws.ResolveMode()))
 	resolved, err := deployer.ResolveResources(ctx)
+	phase.End(err)
 	if err != nil {
 		tg.Fail(id, err)
 		return fmt.Errorf("failed to resolve resources: %w", err)
 	}
 
-	hasOngoing, _, err := deployer.CheckOngoingDeployment(ctx, resolved)
-	if err != nil {
-		tg.Fail(id, err)
-		return fmt.Errorf("failed to check ongoing deployments: %w", err)
+	if opts.ContentType != "" {
+		if err := config.ValidateContentTypeOverride(resolved.Profile.Type, opts.ContentType); err != nil {
+			tg.Fail(id, err)
+			return err
+		}
+	}
+
+	if opts.ValidateRemote {
+		res.Status = StatusValidated
+		return e.validateRemote(ctx, opts, cfg, dataContent, deployer, resolved, tg, id)
+	}
+
+	// --create-only starts no deployment, so one already running does not
+	// get in the way
+	hasOngoing := false
+	if !opts.CreateOnly {
+		hasOngoing, _, err = deployer.CheckOngoingDeployment(ctx, resolved)
+		if err != nil {
+			err = aws.ExplainNotFound(err, cfg)
+			tg.Fail(id, err)
+			return fmt.Errorf("failed to check ongoing deployments: %w", err)
+		}
 	}
 	if hasOngoing {
-		ongoingErr := fmt.Errorf("deployment already in progress")
-		tg.Fail(id, ongoingErr)
-		return ongoingErr
+		if !opts.WaitForSlot {
+			tg.Fail(id, aws.ErrDeploymentInProgress)
+			return aws.ErrDeploymentInProgress
+		}
+		// --wait-for-slot queues behind the running deployment instead of
+		// failing; the wait is bounded by --timeout on its own
+		err = deployer.WaitForSlot(ctx, resolved, timeout, func(ongoing *types.DeploymentSummary) {
+			tg.SetPhase(id, "waiting-for-slot", fmt.Sprintf("(deployment #%d is %s)", ongoing.DeploymentNumber, strings.ToLower(string(ongoing.State))))
+		})
+		if err != nil {
+			err = fmt.Errorf("%w: %w", aws.ErrDeploymentInProgress, err)
+			tg.Fail(id, err)
+			return err
+		}
 	}
 
-	contentType, err := deployer.DetermineContentType(resolved.Profile.Type, cfg.DataFile)
+	var contentType string
+	if opts.ConfigVersion != 0 {
+		dataContent, contentType, err = deployer.GetVersion(ctx, resolved, opts.ConfigVersion)
+		if err != nil {
+			err = aws.ExplainNotFound(err, cfg)
+			tg.Fail(id, err)
+			return err
+		}
+		contentHash = state.Hash(dataContent)
+	} else {
+		contentType, err = deployer.DetermineContentType(resolved.Profile.Type, cfg.DataFile)
+		if err != nil {
+			tg.Fail(id, err)
+			return fmt.Errorf("failed to determine content type: %w", err)
+		}
+		dataContent, err = config.FeatureFlagsUploadContent(resolved.Profile.Type, cfg.DataFile, dataContent)
+		if err != nil {
+			tg.Fail(id, err)
+			return fmt.Errorf("validation failed: %w", err)
+		}
+	}
+
+	// --apply-normalize uploads text content in the same normalized form
+	// used for comparison, so whitespace-only drift is not re-deployed
+	if opts.ApplyNormalize && contentType == config.ContentTypeText {
+		dataContent = []byte(config.NormalizeText(string(dataContent), cfg.TextNormalizeOptions()))
+	}
+
+	// An existing version was accepted by AppConfig when it was created, so
+	// only uploaded content is validated
+	if opts.ConfigVersion == 0 {
+		_, phase = tracing.Start(ctx, "validate")
+		err = deployer.ValidateLocalData(dataContent, contentType, resolved.Profile.Type)
+		phase.End(err)
+		if err != nil {
+			tg.Fail(id, err)
+			return fmt.Errorf("validation failed: %w", err)
+		}
+	}
+
+	// The deployment being replaced is looked up even with --force so the
+	// summary can report the version transition ("previously vN").
+	previous, err := deployer.GetPreviousDeployment(ctx, resolved)
 	if err != nil {
+		err = aws.ExplainNotFound(err, cfg)
 		tg.Fail(id, err)
-		return fmt.Errorf("failed to determine content type: %w", err)
+		return fmt.Errorf("failed to check for changes: %w", err)
+	}
+	previousNote := previousVersionNote(previous)
+	if previous != nil {
+		res.PreviousVersion = previous.ConfigurationVersion
 	}
 
-	if err := deployer.ValidateLocalData(dataContent, contentType); err != nil {
-		tg.Fail(id, err)
-		return fmt.Errorf("validation failed: %w", err)
+	if opts.DumpNormalized != "" {
+		if err := e.dumpNormalized(ctx, opts.DumpNormalized, deployer, resolved, previous, dataContent, contentType); err != nil {
+			tg.Fail(id, err)
+			return err
+		}
 	}
 
-	if !opts.Force {
+	if opts.Check {
+		return e.check(ctx, deployer, resolved, previous, dataContent, contentType, tg, id, &res)
+	}
+	if opts.DryRun {
+		return e.dryRun(ctx, opts, cfg, deployer, resolved, previous, dataContent, contentType, tg, id, &res)
+	}
+
+	// --skip-diff-check never fetches the deployed content, so a failing
+	// GetHostedConfigurationVersion does not block the deployment; the
+	// ongoing-deployment check above still applies
+	if !opts.Force && !opts.SkipDiffCheck && opts.ConfigVersion == 0 {
 		tg.SetPhase(id, "comparing", "")
-		hasChanges, err := deployer.HasConfigurationChanges(ctx, resolved, dataContent, cfg.DataFile, contentType)
+		hasChanges, err := deployer.HasChangesSince(ctx, resolved, previous, dataContent, contentType)
 		if err != nil {
 			tg.Fail(id, err)
 			return fmt.Errorf("failed to check for changes: %w", err)
 		}
 		if !hasChanges {
 			tg.Skip(id, "skipped (no changes)")
+			res.Status = StatusSkipped
 			return nil
 		}
 	}
 
-	tg.SetPhase(id, "creating-version", "")
-	versionNumber, err := deployer.CreateVersion(ctx, resolved, dataContent, contentType, opts.Description)
-	if err != nil {
-		tg.Fail(id, err)
-		if aws.IsValidationError(err) {
-			return fmt.Errorf("%s", aws.FormatValidationError(err))
+	if len(opts.GuardAlarms) > 0 {
+		tg.SetPhase(id, "checking-alarms", "")
+		if err := deployer.awsClient.CheckAlarms(ctx, opts.GuardAlarms); err != nil {
+			tg.Fail(id, err)
+			return fmt.Errorf("refusing to deploy: %w", err)
 		}
-		return fmt.Errorf("failed to create configuration version: %w", err)
+	}
+
+	if opts.WaitBake {
+		if err := CheckBakeTime(ctx, deployer.awsClient, e.reporter, id, resolved.DeploymentStrategyID, cfg.DeploymentStrategy, time.Duration(timeout)*time.Second, opts.LongBakeThreshold, opts.AcceptLongBake); err != nil {
+			tg.Fail(id, err)
+			return err
+		}
+	}
+
+	versionNumber := opts.ConfigVersion
+	if versionNumber != 0 {
+		e.reporter.Info(fmt.Sprintf("Deploying existing version %d", versionNumber))
+	} else {
+		tg.SetPhase(id, "creating-version", "")
+		_, phase = tracing.Start(ctx, "create")
+		versionNumber, err = deployer.CreateVersion(ctx, resolved, dataContent, contentType, opts.VersionDescription)
+		phase.End(err)
+		if err != nil {
+			err = aws.ExplainNotFound(err, cfg)
+			tg.Fail(id, err)
+			if aws.IsValidationError(err) {
+				return fmt.Errorf("%s", aws.FormatValidationError(err))
+			}
+			return fmt.Errorf("failed to create configuration version: %w", err)
+		}
+	}
+	span.SetAttributes(tracing.Int(tracing.AttrVersion, int64(versionNumber)))
+	res.Version = versionNumber
+
+	if opts.CreateOnly {
+		tg.Done(id, fmt.Sprintf("created v%d (not deployed)", versionNumber))
+		e.reporter.Info(fmt.Sprintf("%s: deploy it later with 'apcdeploy run -c %s --config-version %d'", id, opts.ConfigFile, versionNumber))
+		res.Status = StatusCreated
+		return nil
 	}
 
 	deployStart := time.Now()
 	tg.SetPhase(id, "deploying", "")
-	deploymentNumber, err := deployer.StartDeployment(ctx, resolved, versionNumber, opts.Description)
+	_, phase = tracing.Start(ctx, "deploy")
+	deploymentNumber, err := deployer.StartDeployment(ctx, resolved, versionNumber, opts.DeploymentDescription)
+	phase.End(err)
 	if err != nil {
+		err = aws.ExplainNotFound(err, cfg)
 		tg.Fail(id, err)
 		return fmt.Errorf("failed to start deployment: %w", err)
 	}
+	span.SetAttributes(tracing.Int(tracing.AttrDeploymentNumber, int64(deploymentNumber)))
+	res.DeploymentNumber = deploymentNumber
+	res.Strategy = cfg.DeploymentStrategy
+	res.FirstDeploy = previous == nil
+
+	record := state.Record{
+		Version:          versionNumber,
+		DeploymentNumber: deploymentNumber,
+		ContentSHA256:    contentHash,
+		DeployedAt:       time.Now().UTC(),
+		ApplicationID:    resolved.ApplicationID,
+		EnvironmentID:    resolved.EnvironmentID,
+	}
 
 	strategyName := cfg.DeploymentStrategy
 	switch {
 	case opts.WaitDeploy:
-		if err := deployer.WaitForDeploymentPhase(ctx, resolved, deploymentNumber, false, opts.Timeout, MakeTargetsDeployTick(tg, id)); err != nil {
+		_, phase = tracing.Start(ctx, "wait")
+		err = deployer.WaitForDeploymentPhase(ctx, resolved, deploymentNumber, false, timeout, MakeTargetsDeployTick(tg, id))
+		phase.End(err)
+		if err != nil {
 			tg.Fail(id, err)
 			return fmt.Errorf("deployment failed: %w", err)
 		}
-		tg.Done(id, cli.FormatDeploymentSummary("deployed", deployStart, versionNumber, strategyName, "baking started"))
+		verifiedNote, err := e.verify(ctx, opts, deployer, resolved, dataContent, contentType, tg, id)
+		if err != nil {
+			return err
+		}
+		tg.Done(id, cli.FormatDeploymentSummary("deployed", deployStart, versionNumber, strategyName, "baking started, "+verifiedNote+previousNote))
+		res.Status = StatusDeployed
 
 	case opts.WaitBake:
-		// waitCtx caps total wait at opts.Timeout. The per-phase timeout passed
+		// waitCtx caps total wait at timeout. The per-phase timeout passed
 		// below is the remaining budget against that deadline so the inner
 		// Wait* timeout reflects "how long this phase may still take".
-		deadline := time.Now().Add(time.Duration(opts.Timeout) * time.Second)
+		deadline := time.Now().Add(time.Duration(timeout) * time.Second)
 		waitCtx, cancel := context.WithDeadline(ctx, deadline)
 		defer cancel()
 
-		if err := deployer.WaitForDeploymentPhase(waitCtx, resolved, deploymentNumber, false, remainingSeconds(deadline), MakeTargetsDeployTick(tg, id)); err != nil {
-			tg.Fail(id, err)
-			return fmt.Errorf("deployment failed: %w", err)
+		_, phase = tracing.Start(ctx, "wait")
+		err = deployer.WaitForDeploymentPhase(waitCtx, resolved, deploymentNumber, false, remainingSeconds(deadline), MakeTargetsDeployTick(tg, id))
+		if err == nil {
+			tg.SetPhase(id, "baking", "")
+			err = deployer.WaitForBakingComplete(waitCtx, resolved, deploymentNumber, remainingSeconds(deadline), MakeTargetsBakeTick(tg, id))
 		}
-		tg.SetPhase(id, "baking", "")
-		if err := deployer.WaitForBakingComplete(waitCtx, resolved, deploymentNumber, remainingSeconds(deadline), MakeTargetsBakeTick(tg, id)); err != nil {
+		phase.End(err)
+		if err != nil {
 			tg.Fail(id, err)
 			return fmt.Errorf("deployment failed: %w", err)
 		}
-		tg.Done(id, cli.FormatDeploymentSummary("complete", deployStart, versionNumber, strategyName, ""))
+		verifiedNote, err := e.verify(ctx, opts, deployer, resolved, dataContent, contentType, tg, id)
+		if err != nil {
+			return err
+		}
+		tg.Done(id, cli.FormatDeploymentSummary("complete", deployStart, versionNumber, strategyName, verifiedNote+previousNote))
+		res.Status = StatusComplete
+		// Only a deployment seen through to COMPLETE is recorded; one that
+		// is still rolling out may yet be rolled back
+		e.saveState(opts, st, id, record)
 
 	default:
-		tg.Done(id, cli.FormatDeploymentSummary("started", deployStart, versionNumber, strategyName, fmt.Sprintf("deployment #%d", deploymentNumber)))
+		tg.Done(id, cli.FormatDeploymentSummary("started", deployStart, versionNumber, strategyName, fmt.Sprintf("deployment #%d, %s", deploymentNumber, previousNote)))
+		res.Status = StatusStarted
+	}
+
+	return nil
+}
+
+// verify reads the served configuration back after a completed wait when
+// --verify is set and returns the summary addendum ("served content
+// verified, "). A mismatch fails the row; the local deploy record is not
+// written so the next run compares against AppConfig again.
+func (e *Executor) verify(ctx context.Context, opts *Options, deployer *Deployer, resolved *aws.ResolvedResources, dataContent []byte, contentType string, tg reporter.Targets, id string) (string, error) {
+	if !opts.Verify {
+		return "", nil
+	}
+	// AppConfigData serves the evaluated flag values of a FeatureFlags
+	// profile, not the flag definitions that were uploaded, so the two
+	// cannot be compared
+	if resolved.Profile.Type == config.ProfileTypeFeatureFlags {
+		e.reporter.Info(id + ": --verify skipped: AppConfig serves evaluated flag values for FeatureFlags profiles, not the uploaded document")
+		return "", nil
+	}
+	tg.SetPhase(id, "verifying", "")
+	if err := deployer.VerifyServedContent(ctx, resolved, dataContent, contentType); err != nil {
+		tg.Fail(id, err)
+		return "", fmt.Errorf("verification failed: %w", err)
 	}
+	return "served content verified, ", nil
+}
 
+// dryRun finishes a --dry-run target once resolution, validation and the
+// previous-deployment lookup have passed: the normalized diff against the
+// deployed version and the resolved resources are reported, and nothing is
+// created or deployed. The guard alarms are still checked so a dry run fails
+// where the real deployment would.
+//
+// Output shape:
+//   - changes:     diff on stdout, resources on stderr, then
+//     ✓ dry run — would deploy (+A -R lines)
+//   - no changes:  ⊘ no changes detected (dry run)
+//   - --force:     ✓ dry run — no changes, a deployment would be forced
+func (e *Executor) dryRun(ctx context.Context, opts *Options, cfg *config.Config, deployer *Deployer, resolved *aws.ResolvedResources, previous *aws.DeploymentInfo, dataContent []byte, contentType string, tg reporter.Targets, id string, res *TargetResult) error {
+	tg.SetPhase(id, "comparing", "")
+	changed, unified, err := deployer.DiffSince(ctx, resolved, previous, dataContent, contentType)
+	if err != nil {
+		tg.Fail(id, err)
+		return fmt.Errorf("failed to check for changes: %w", err)
+	}
+	if !changed && !opts.Force {
+		tg.Skip(id, "no changes detected (dry run)")
+		res.Status = StatusSkipped
+		return nil
+	}
+
+	if len(opts.GuardAlarms) > 0 {
+		tg.SetPhase(id, "checking-alarms", "")
+		if err := deployer.awsClient.CheckAlarms(ctx, opts.GuardAlarms); err != nil {
+			tg.Fail(id, err)
+			return fmt.Errorf("refusing to deploy: %w", err)
+		}
+	}
+
+	e.reporter.Info(fmt.Sprintf("%s: would deploy to application %s (%s), profile %s (%s), environment %s (%s) with strategy %s (%s)",
+		id, cfg.Application, resolved.ApplicationID, cfg.ConfigurationProfile, resolved.Profile.ID,
+		cfg.Environment, resolved.EnvironmentID, cfg.DeploymentStrategy, resolved.DeploymentStrategyID))
+	if !changed {
+		tg.Done(id, "dry run — no changes, a deployment would be forced")
+		return nil
+	}
+	e.reporter.Diff([]byte(unified))
+	added, removed := diff.CountChanges(unified)
+	tg.Done(id, fmt.Sprintf("dry run — would deploy (+%d -%d lines)", added, removed))
 	return nil
 }
 
+// check compares the local content with the deployed version for --check
+// and returns ErrChangesFound when a deployment would change it. Nothing is
+// printed besides the target row, so the exit code carries the answer.
+//
+// Output shape:
+//   - changes:     ✓ changes would be deployed (+A -R lines)
+//   - no changes:  ⊘ no changes (check)
+func (e *Executor) check(ctx context.Context, deployer *Deployer, resolved *aws.ResolvedResources, previous *aws.DeploymentInfo, dataContent []byte, contentType string, tg reporter.Targets, id string, res *TargetResult) error {
+	tg.SetPhase(id, "comparing", "")
+	changed, unified, err := deployer.DiffSince(ctx, resolved, previous, dataContent, contentType)
+	if err != nil {
+		tg.Fail(id, err)
+		return fmt.Errorf("failed to check for changes: %w", err)
+	}
+	res.Status = StatusSkipped
+	if !changed {
+		tg.Skip(id, "no changes (check)")
+		return nil
+	}
+	if previous == nil {
+		tg.Done(id, "changes would be deployed (first deployment)")
+		return ErrChangesFound
+	}
+	added, removed := diff.CountChanges(unified)
+	tg.Done(id, fmt.Sprintf("changes would be deployed (+%d -%d lines)", added, removed))
+	return ErrChangesFound
+}
+
+// dumpNormalized writes the content compared for change detection for
+// --dump-normalized and reports where it went.
+func (e *Executor) dumpNormalized(ctx context.Context, dir string, deployer *Deployer, resolved *aws.ResolvedResources, previous *aws.DeploymentInfo, dataContent []byte, contentType string) error {
+	remote, local, err := deployer.NormalizedContents(ctx, resolved, previous, dataContent, contentType)
+	if err != nil {
+		return err
+	}
+	paths, err := config.DumpNormalized(dir, config.ExtensionForContentType(contentType), remote, local)
+	if err != nil {
+		return fmt.Errorf("failed to dump normalized content: %w", err)
+	}
+	e.reporter.Info("normalized content written to " + strings.Join(paths, ", "))
+	return nil
+}
+
+// validateRemote runs the local checks and then exercises AppConfig's own
+// validators by creating a configuration version that is never deployed.
+// Only the version number returned by that create call is deleted, so an
+// existing version can never be removed by mistake.
+//
+// Output shape:
+//   - passed:  ✓ remote validation passed — v<N> deleted  (or "kept as v<N>")
+//   - failed:  ✗ failed: <validator message>  (nothing was created)
+func (e *Executor) validateRemote(ctx context.Context, opts *Options, cfg *config.Config, dataContent []byte, deployer *Deployer, resolved *aws.ResolvedResources, tg reporter.Targets, id string) error {
+	contentType, err := deployer.DetermineContentType(resolved.Profile.Type, cfg.DataFile)
+	if err != nil {
+		tg.Fail(id, err)
+		return fmt.Errorf("failed to determine content type: %w", err)
+	}
+	dataContent, err = config.FeatureFlagsUploadContent(resolved.Profile.Type, cfg.DataFile, dataContent)
+	if err != nil {
+		tg.Fail(id, err)
+		return fmt.Errorf("validation failed: %w", err)
+	}
+	if opts.ApplyNormalize && contentType == config.ContentTypeText {
+		dataContent = []byte(config.NormalizeText(string(dataContent), cfg.TextNormalizeOptions()))
+	}
+	if err := deployer.ValidateLocalData(dataContent, contentType, resolved.Profile.Type); err != nil {
+		tg.Fail(id, err)
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	tg.SetPhase(id, "validating-remote", "")
+	versionNumber, err := deployer.CreateVersion(ctx, resolved, dataContent, contentType, validateRemoteDescription)
+	if err != nil {
+		tg.Fail(id, err)
+		if aws.IsValidationError(err) {
+			return fmt.Errorf("%s", aws.FormatValidationError(err))
+		}
+		return fmt.Errorf("failed to create configuration version: %w", err)
+	}
+
+	if opts.KeepValidationVersion {
+		tg.Done(id, fmt.Sprintf("remote validation passed — kept as v%d", versionNumber))
+		return nil
+	}
+	if err := deployer.DeleteVersion(ctx, resolved, versionNumber); err != nil {
+		tg.Fail(id, err)
+		return fmt.Errorf("remote validation passed but the throwaway version v%d could not be deleted: %w", versionNumber, err)
+	}
+	tg.Done(id, fmt.Sprintf("remote validation passed — v%d deleted", versionNumber))
+	return nil
+}
+
+// validateRemoteDescription marks versions created by --validate-remote so a
+// kept or orphaned one is recognizable in the console.
+const validateRemoteDescription = "apcdeploy validate-remote (not deployed)"
+
+// previousVersionNote renders the summary addendum naming the version that
+// the new deployment replaces, or "first deployment" when there is none.
+func previousVersionNote(previous *aws.DeploymentInfo) string {
+	if previous == nil {
+		return "first deployment"
+	}
+	return "previously v" + previous.ConfigurationVersion
+}
+
 // remainingSeconds returns the seconds remaining until deadline, clamped at
 // 1 to avoid passing 0/negative values to wait functions that interpret 0
 // as "no timeout". The actual wait is bounded by the shared waitCtx
@@ -1,20 +1,31 @@
 package run
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/appconfig"
 	"github.com/aws/aws-sdk-go-v2/service/appconfig/types"
+	"github.com/aws/aws-sdk-go-v2/service/appconfigdata"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cwtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
 	awsInternal "github.com/koh-sh/apcdeploy/internal/aws"
 	"github.com/koh-sh/apcdeploy/internal/aws/mock"
 	"github.com/koh-sh/apcdeploy/internal/config"
 	reportertest "github.com/koh-sh/apcdeploy/internal/reporter/testing"
+	"github.com/koh-sh/apcdeploy/internal/state"
+	"github.com/koh-sh/apcdeploy/internal/tracing"
+	tracingtest "github.com/koh-sh/apcdeploy/internal/tracing/testing"
 )
 
 func TestNewExecutor(t *testing.T) {
@@ -130,6 +141,9 @@ func TestExecutorLoadConfigurationError(t *testing.T) {
 	if !strings.Contains(err.Error(), "failed to load configuration") {
 		t.Errorf("expected 'failed to load configuration' error, got: %v", err)
 	}
+	if strings.Count(err.Error(), "failed to load configuration") != 1 {
+		t.Errorf("expected the error to be wrapped once, got: %v", err)
+	}
 
 	// Config loading is an instant operation: per the output contract it does
 	// not produce any reporter output on failure — the returned error is the
@@ -291,12 +305,12 @@ region: us-east-1
 	}
 	foundStarted := false
 	for _, tr := range tc.Transitions {
-		if tr.Kind == "done" && strings.Contains(tr.Summary, "started") && strings.Contains(tr.Summary, "v1") {
+		if tr.Kind == "done" && strings.Contains(tr.Summary, "started") && strings.Contains(tr.Summary, "v1") && strings.Contains(tr.Summary, "first deployment") {
 			foundStarted = true
 		}
 	}
 	if !foundStarted {
-		t.Errorf("expected Targets.Done summary mentioning 'started' and 'v1'; got: %+v", tc.Transitions)
+		t.Errorf("expected Targets.Done summary mentioning 'started', 'v1' and 'first deployment'; got: %+v", tc.Transitions)
 	}
 }
 
@@ -400,6 +414,9 @@ region: us-east-1
 				StartDeploymentFunc: func(ctx context.Context, params *appconfig.StartDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.StartDeploymentOutput, error) {
 					return &appconfig.StartDeploymentOutput{DeploymentNumber: 1}, nil
 				},
+				GetDeploymentStrategyFunc: func(ctx context.Context, params *appconfig.GetDeploymentStrategyInput, optFns ...func(*appconfig.Options)) (*appconfig.GetDeploymentStrategyOutput, error) {
+					return &appconfig.GetDeploymentStrategyOutput{FinalBakeTimeInMinutes: 10}, nil
+				},
 				GetDeploymentFunc: func(ctx context.Context, params *appconfig.GetDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.GetDeploymentOutput, error) {
 					var state types.DeploymentState
 					if callCount < len(tt.mockStates) {
@@ -739,17 +756,114 @@ region: us-east-1
 	found := false
 	for _, call := range reporter.TargetsCalls {
 		for _, tr := range call.Transitions {
-			if tr.Kind == "done" && strings.Contains(tr.Summary, "deployment #2") {
+			if tr.Kind == "done" && strings.Contains(tr.Summary, "deployment #2, previously v1") {
 				found = true
 			}
 		}
 	}
 	if !found {
-		t.Errorf("expected Targets.Done summary mentioning 'deployment #2'; got: %+v", reporter.TargetsCalls)
+		t.Errorf("expected Targets.Done summary mentioning 'deployment #2, previously v1'; got: %+v", reporter.TargetsCalls)
 	}
 }
 
 // TestExecutorWithOngoingDeployment tests error when deployment is in progress
+// TestExecutorSkipDiffCheck tests that --skip-diff-check deploys without
+// fetching the deployed content but still refuses on an ongoing deployment
+func TestExecutorSkipDiffCheck(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		latestState types.DeploymentState
+		wantErr     error
+		wantDeploy  bool
+	}{
+		{name: "deploys unchanged content", latestState: types.DeploymentStateComplete, wantDeploy: true},
+		{name: "ongoing deployment still fails", latestState: types.DeploymentStateDeploying, wantErr: awsInternal.ErrDeploymentInProgress},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			configPath := filepath.Join(tempDir, "apcdeploy.yml")
+			configContent := "application: test-app\nconfiguration_profile: test-profile\nenvironment: test-env\ndeployment_strategy: AppConfig.AllAtOnce\ndata_file: data.json\nregion: us-east-1\n"
+			if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(tempDir, "data.json"), []byte(`{"key": "value"}`), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			var listDeploymentsCalls, getVersionCalls int
+			started := false
+			mockClient := mock.NewResolvingClient("AWS.Freeform")
+			mockClient.ListDeploymentsFunc = func(ctx context.Context, params *appconfig.ListDeploymentsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListDeploymentsOutput, error) {
+				listDeploymentsCalls++
+				return &appconfig.ListDeploymentsOutput{
+					Items: []types.DeploymentSummary{{DeploymentNumber: 1, State: tt.latestState, ConfigurationVersion: aws.String("1")}},
+				}, nil
+			}
+			mockClient.GetDeploymentFunc = func(ctx context.Context, params *appconfig.GetDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.GetDeploymentOutput, error) {
+				return &appconfig.GetDeploymentOutput{
+					State:                  tt.latestState,
+					ConfigurationProfileId: aws.String("profile-123"),
+					ConfigurationVersion:   aws.String("1"),
+				}, nil
+			}
+			mockClient.GetHostedConfigurationVersionFunc = func(ctx context.Context, params *appconfig.GetHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.GetHostedConfigurationVersionOutput, error) {
+				getVersionCalls++
+				return nil, errors.New("remote fetch failed")
+			}
+			mockClient.CreateHostedConfigurationVersionFunc = func(ctx context.Context, params *appconfig.CreateHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.CreateHostedConfigurationVersionOutput, error) {
+				return &appconfig.CreateHostedConfigurationVersionOutput{VersionNumber: 2}, nil
+			}
+			mockClient.StartDeploymentFunc = func(ctx context.Context, params *appconfig.StartDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.StartDeploymentOutput, error) {
+				started = true
+				return &appconfig.StartDeploymentOutput{DeploymentNumber: 2}, nil
+			}
+			factory := func(ctx context.Context, cfg *config.Config) (*Deployer, error) {
+				return NewWithClient(cfg, awsInternal.NewTestClient(mockClient)), nil
+			}
+
+			err := NewExecutorWithFactory(&reportertest.MockReporter{}, factory).Execute(context.Background(), &Options{ConfigFile: configPath, NoState: true, SkipDiffCheck: true})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+
+			if getVersionCalls != 0 {
+				t.Errorf("GetHostedConfigurationVersion called %d times, want 0", getVersionCalls)
+			}
+			if listDeploymentsCalls == 0 {
+				t.Error("expected the ongoing-deployment check (ListDeployments) to run")
+			}
+			if started != tt.wantDeploy {
+				t.Errorf("StartDeployment called = %v, want %v", started, tt.wantDeploy)
+			}
+		})
+	}
+}
+
+func TestExecutorSkipDiffCheckConflicts(t *testing.T) {
+	t.Parallel()
+
+	for _, opts := range []Options{
+		{SkipDiffCheck: true, DryRun: true},
+		{SkipDiffCheck: true, Check: true},
+		{SkipDiffCheck: true, ValidateRemote: true},
+	} {
+		err := NewExecutor(&reportertest.MockReporter{}).Execute(context.Background(), &opts)
+		if err == nil || !strings.Contains(err.Error(), "--skip-diff-check cannot be used") {
+			t.Errorf("Execute(%+v) error = %v, want a --skip-diff-check conflict", opts, err)
+		}
+	}
+}
+
 func TestExecutorWithOngoingDeployment(t *testing.T) {
 	tempDir, err := os.MkdirTemp("", "executor-ongoing-*")
 	if err != nil {
@@ -831,8 +945,8 @@ region: us-east-1
 		t.Fatal("expected error for ongoing deployment")
 	}
 
-	if !strings.Contains(err.Error(), "deployment already in progress") {
-		t.Errorf("expected 'deployment already in progress' error, got: %v", err)
+	if !errors.Is(err, awsInternal.ErrDeploymentInProgress) {
+		t.Errorf("expected ErrDeploymentInProgress, got: %v", err)
 	}
 
 	// The Targets row must finalise with Fail so the user sees `✗ failed:
@@ -841,7 +955,7 @@ region: us-east-1
 	foundFail := false
 	for _, call := range reporter.TargetsCalls {
 		for _, tr := range call.Transitions {
-			if tr.Kind == "fail" && tr.Err != nil && strings.Contains(tr.Err.Error(), "deployment already in progress") {
+			if tr.Kind == "fail" && errors.Is(tr.Err, awsInternal.ErrDeploymentInProgress) {
 				foundFail = true
 			}
 		}
@@ -850,3 +964,2230 @@ region: us-east-1
 		t.Errorf("expected Targets.Fail with 'deployment already in progress'; got: %+v", reporter.TargetsCalls)
 	}
 }
+
+// TestExecutorWaitForSlot checks that --wait-for-slot queues behind a
+// running deployment and deploys once it finishes, and still fails when the
+// slot does not free up within --timeout.
+func TestExecutorWaitForSlot(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		busyPolls   int32
+		wantErr     string
+		wantVersion bool
+	}{
+		{name: "deploys once the running deployment finishes", busyPolls: 3, wantVersion: true},
+		{name: "times out while still running", busyPolls: 1 << 30, wantErr: "waiting for deployment #2 to finish"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			configPath := filepath.Join(tempDir, "apcdeploy.yml")
+			configContent := `application: test-app
+configuration_profile: test-profile
+environment: test-env
+deployment_strategy: AppConfig.AllAtOnce
+data_file: data.json
+region: us-east-1
+`
+			if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+			if err := os.WriteFile(filepath.Join(tempDir, "data.json"), []byte(`{"key": "value"}`), 0o644); err != nil {
+				t.Fatalf("Failed to write data: %v", err)
+			}
+
+			var versions, polls atomic.Int32
+			mockClient := newFirstDeploymentMock(&versions)
+			mockClient.ListDeploymentsFunc = func(ctx context.Context, params *appconfig.ListDeploymentsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListDeploymentsOutput, error) {
+				if polls.Add(1) <= tt.busyPolls {
+					return &appconfig.ListDeploymentsOutput{Items: []types.DeploymentSummary{{DeploymentNumber: 2, State: types.DeploymentStateBaking}}}, nil
+				}
+				return &appconfig.ListDeploymentsOutput{}, nil
+			}
+			client := awsInternal.NewTestClient(mockClient)
+			client.PollingInterval = time.Millisecond
+			factory := func(_ context.Context, cfg *config.Config) (*Deployer, error) {
+				return NewWithClient(cfg, client), nil
+			}
+
+			rep := &reportertest.MockReporter{}
+			err := NewExecutorWithFactory(rep, factory).Execute(context.Background(), &Options{ConfigFile: configPath, NoState: true, Timeout: 1, WaitForSlot: true})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := versions.Load() > 0; got != tt.wantVersion {
+				t.Errorf("version created = %v, want %v", got, tt.wantVersion)
+			}
+
+			waiting := false
+			for _, call := range rep.TargetsCalls {
+				for _, tr := range call.Transitions {
+					if tr.Kind == "phase" && tr.Phase == "waiting-for-slot" && strings.Contains(tr.Detail, "deployment #2 is baking") {
+						waiting = true
+					}
+				}
+			}
+			if !waiting {
+				t.Errorf("expected a waiting-for-slot phase naming deployment #2; got %+v", rep.TargetsCalls)
+			}
+		})
+	}
+}
+
+func TestPreviousVersionNote(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		previous *awsInternal.DeploymentInfo
+		want     string
+	}{
+		{"first deployment", nil, "first deployment"},
+		{"replaces earlier version", &awsInternal.DeploymentInfo{DeploymentNumber: 3, ConfigurationVersion: "7"}, "previously v7"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := previousVersionNote(tt.previous); got != tt.want {
+				t.Errorf("previousVersionNote() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestExecutorApplyNormalize checks that --apply-normalize uploads text
+// content in its normalized form, while the default uploads it untouched.
+func TestExecutorApplyNormalize(t *testing.T) {
+	t.Parallel()
+
+	raw := "key = value  \r\n\r\n\r\n\r\nother = 1"
+
+	tests := []struct {
+		name           string
+		applyNormalize bool
+		want           string
+	}{
+		{"default uploads as-is", false, raw},
+		{"apply-normalize uploads normalized text", true, "key = value\n\nother = 1\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			configPath := filepath.Join(tempDir, "apcdeploy.yml")
+			configContent := `application: test-app
+configuration_profile: test-profile
+environment: test-env
+data_file: data.txt
+region: us-east-1
+text_normalize:
+  - trim_trailing_ws
+  - collapse_blank_lines
+`
+			if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+			if err := os.WriteFile(filepath.Join(tempDir, "data.txt"), []byte(raw), 0o644); err != nil {
+				t.Fatalf("Failed to write data: %v", err)
+			}
+
+			var uploaded string
+			mockClient := mock.NewResolvingClient("AWS.Freeform")
+			mockClient.ListDeploymentsFunc = func(ctx context.Context, params *appconfig.ListDeploymentsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListDeploymentsOutput, error) {
+				return &appconfig.ListDeploymentsOutput{}, nil
+			}
+			mockClient.CreateHostedConfigurationVersionFunc = func(ctx context.Context, params *appconfig.CreateHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.CreateHostedConfigurationVersionOutput, error) {
+				uploaded = string(params.Content)
+				return &appconfig.CreateHostedConfigurationVersionOutput{VersionNumber: 1}, nil
+			}
+			mockClient.StartDeploymentFunc = func(ctx context.Context, params *appconfig.StartDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.StartDeploymentOutput, error) {
+				return &appconfig.StartDeploymentOutput{DeploymentNumber: 1}, nil
+			}
+
+			deployerFactory := func(ctx context.Context, cfg *config.Config) (*Deployer, error) {
+				return NewWithClient(cfg, awsInternal.NewTestClient(mockClient)), nil
+			}
+			executor := NewExecutorWithFactory(&reportertest.MockReporter{}, deployerFactory)
+
+			opts := &Options{ConfigFile: configPath, Timeout: 300, ApplyNormalize: tt.applyNormalize}
+			if err := executor.Execute(context.Background(), opts); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if uploaded != tt.want {
+				t.Errorf("uploaded content = %q, want %q", uploaded, tt.want)
+			}
+		})
+	}
+}
+
+// TestExecutorEnvironmentsByTag checks that --environments-by-tag reports the
+// matching environments and deploys to each of them in order.
+func TestExecutorEnvironmentsByTag(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		tag          string
+		wantEnvIDs   []string
+		wantInfo     string
+		wantErr      string
+		wantNoTarget bool
+	}{
+		{
+			name:       "deploys to every matching environment",
+			tag:        "tier=canary",
+			wantEnvIDs: []string{"env-1", "env-3"},
+			wantInfo:   "info: 2 environment(s) match tag tier=canary: canary-a, canary-b",
+		},
+		{
+			name:         "no matching environment",
+			tag:          "tier=staging",
+			wantErr:      "no environments of application test-app match tag tier=staging",
+			wantNoTarget: true,
+		},
+		{
+			name:         "invalid tag filter",
+			tag:          "tier",
+			wantErr:      "expected key=value",
+			wantNoTarget: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			configPath := filepath.Join(tempDir, "apcdeploy.yml")
+			configContent := `application: test-app
+configuration_profile: test-profile
+environment: test-env
+data_file: data.json
+region: us-east-1
+`
+			if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+			if err := os.WriteFile(filepath.Join(tempDir, "data.json"), []byte(`{"key": "value"}`), 0o644); err != nil {
+				t.Fatalf("Failed to write data: %v", err)
+			}
+
+			envTags := map[string]string{"env-1": "canary", "env-2": "production", "env-3": "canary"}
+			var deployedEnvIDs []string
+			mockClient := mock.NewResolvingClient("AWS.Freeform")
+			mockClient.ListEnvironmentsFunc = func(ctx context.Context, params *appconfig.ListEnvironmentsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListEnvironmentsOutput, error) {
+				return &appconfig.ListEnvironmentsOutput{
+					Items: []types.Environment{
+						{Id: aws.String("env-1"), Name: aws.String("canary-a")},
+						{Id: aws.String("env-2"), Name: aws.String("production")},
+						{Id: aws.String("env-3"), Name: aws.String("canary-b")},
+					},
+				}, nil
+			}
+			mockClient.ListTagsForResourceFunc = func(ctx context.Context, params *appconfig.ListTagsForResourceInput, optFns ...func(*appconfig.Options)) (*appconfig.ListTagsForResourceOutput, error) {
+				arn := aws.ToString(params.ResourceArn)
+				return &appconfig.ListTagsForResourceOutput{
+					Tags: map[string]string{"tier": envTags[arn[strings.LastIndex(arn, "/")+1:]]},
+				}, nil
+			}
+			mockClient.ListDeploymentsFunc = func(ctx context.Context, params *appconfig.ListDeploymentsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListDeploymentsOutput, error) {
+				return &appconfig.ListDeploymentsOutput{}, nil
+			}
+			mockClient.CreateHostedConfigurationVersionFunc = func(ctx context.Context, params *appconfig.CreateHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.CreateHostedConfigurationVersionOutput, error) {
+				return &appconfig.CreateHostedConfigurationVersionOutput{VersionNumber: 1}, nil
+			}
+			mockClient.StartDeploymentFunc = func(ctx context.Context, params *appconfig.StartDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.StartDeploymentOutput, error) {
+				deployedEnvIDs = append(deployedEnvIDs, aws.ToString(params.EnvironmentId))
+				return &appconfig.StartDeploymentOutput{DeploymentNumber: 1}, nil
+			}
+
+			deployerFactory := func(ctx context.Context, cfg *config.Config) (*Deployer, error) {
+				client := awsInternal.NewTestClient(mockClient)
+				client.STS = &mock.MockSTSClient{
+					GetCallerIdentityFunc: func(ctx context.Context, params *sts.GetCallerIdentityInput, optFns ...func(*sts.Options)) (*sts.GetCallerIdentityOutput, error) {
+						return &sts.GetCallerIdentityOutput{Account: aws.String("123456789012")}, nil
+					},
+				}
+				return NewWithClient(cfg, client), nil
+			}
+			rep := &reportertest.MockReporter{}
+			executor := NewExecutorWithFactory(rep, deployerFactory)
+
+			err := executor.Execute(context.Background(), &Options{ConfigFile: configPath, Timeout: 300, EnvironmentsByTag: tt.tag})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Execute() error = %v, want containing %q", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if strings.Join(deployedEnvIDs, ",") != strings.Join(tt.wantEnvIDs, ",") {
+				t.Errorf("deployed environments = %v, want %v", deployedEnvIDs, tt.wantEnvIDs)
+			}
+			if tt.wantInfo != "" && (len(rep.Messages) == 0 || rep.Messages[0] != tt.wantInfo) {
+				t.Errorf("first message = %v, want %q", rep.Messages, tt.wantInfo)
+			}
+			if tt.wantNoTarget && len(rep.TargetsCalls) != 0 {
+				t.Errorf("expected no Targets rows, got %d", len(rep.TargetsCalls))
+			}
+			if !tt.wantNoTarget && len(rep.TargetsCalls) != len(tt.wantEnvIDs) {
+				t.Errorf("Targets rows = %d, want %d", len(rep.TargetsCalls), len(tt.wantEnvIDs))
+			}
+		})
+	}
+}
+
+// TestExecutorEnvironments checks that --environment deploys to each
+// environment in order, resolving the application only once, and stops at the
+// first failure.
+func TestExecutorEnvironments(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		envs       []string
+		wantEnvIDs []string
+		wantErr    error
+		wantWarn   string
+	}{
+		{
+			name:       "deploys in the given order",
+			envs:       []string{"staging", "qa"},
+			wantEnvIDs: []string{"env-1", "env-3"},
+		},
+		{
+			name:       "ongoing deployment stops the remaining environments",
+			envs:       []string{"staging", "production", "qa"},
+			wantEnvIDs: []string{"env-1"},
+			wantErr:    awsInternal.ErrDeploymentInProgress,
+			wantWarn:   "warn: stopped at environment production; already processed: staging; not attempted: qa",
+		},
+		{
+			name:    "unknown environment",
+			envs:    []string{"prod"},
+			wantErr: awsInternal.ErrEnvironmentNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			configPath := filepath.Join(tempDir, "apcdeploy.yml")
+			configContent := "application: test-app\nconfiguration_profile: test-profile\nenvironment: test-env\ndeployment_strategy: AppConfig.AllAtOnce\ndata_file: data.json\nregion: us-east-1\n"
+			if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(tempDir, "data.json"), []byte(`{"key": "value"}`), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			var deployedEnvIDs []string
+			listApplicationsCalls := 0
+			mockClient := mock.NewResolvingClient("AWS.Freeform")
+			mockClient.ListApplicationsFunc = func(ctx context.Context, params *appconfig.ListApplicationsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListApplicationsOutput, error) {
+				listApplicationsCalls++
+				return &appconfig.ListApplicationsOutput{Items: []types.Application{{Id: aws.String("app-123"), Name: aws.String("test-app")}}}, nil
+			}
+			mockClient.ListEnvironmentsFunc = func(ctx context.Context, params *appconfig.ListEnvironmentsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListEnvironmentsOutput, error) {
+				return &appconfig.ListEnvironmentsOutput{
+					Items: []types.Environment{
+						{Id: aws.String("env-1"), Name: aws.String("staging")},
+						{Id: aws.String("env-2"), Name: aws.String("production")},
+						{Id: aws.String("env-3"), Name: aws.String("qa")},
+					},
+				}, nil
+			}
+			mockClient.ListDeploymentsFunc = func(ctx context.Context, params *appconfig.ListDeploymentsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListDeploymentsOutput, error) {
+				// production already has a deployment rolling out
+				if aws.ToString(params.EnvironmentId) == "env-2" {
+					return &appconfig.ListDeploymentsOutput{
+						Items: []types.DeploymentSummary{{DeploymentNumber: 4, State: types.DeploymentStateDeploying}},
+					}, nil
+				}
+				return &appconfig.ListDeploymentsOutput{}, nil
+			}
+			mockClient.CreateHostedConfigurationVersionFunc = func(ctx context.Context, params *appconfig.CreateHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.CreateHostedConfigurationVersionOutput, error) {
+				return &appconfig.CreateHostedConfigurationVersionOutput{VersionNumber: 1}, nil
+			}
+			mockClient.StartDeploymentFunc = func(ctx context.Context, params *appconfig.StartDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.StartDeploymentOutput, error) {
+				deployedEnvIDs = append(deployedEnvIDs, aws.ToString(params.EnvironmentId))
+				return &appconfig.StartDeploymentOutput{DeploymentNumber: 1}, nil
+			}
+			factory := func(ctx context.Context, cfg *config.Config) (*Deployer, error) {
+				return NewWithClient(cfg, awsInternal.NewTestClient(mockClient)), nil
+			}
+			rep := &reportertest.MockReporter{}
+
+			err := NewExecutorWithFactory(rep, factory).Execute(context.Background(), &Options{ConfigFile: configPath, NoState: true, Environments: tt.envs})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+
+			if strings.Join(deployedEnvIDs, ",") != strings.Join(tt.wantEnvIDs, ",") {
+				t.Errorf("deployed environments = %v, want %v", deployedEnvIDs, tt.wantEnvIDs)
+			}
+			if listApplicationsCalls != 1 {
+				t.Errorf("ListApplications called %d times, want 1", listApplicationsCalls)
+			}
+			if tt.wantWarn != "" && !rep.HasMessage(tt.wantWarn) {
+				t.Errorf("expected %q, got %v", tt.wantWarn, rep.Messages)
+			}
+		})
+	}
+}
+
+func TestExecutorEnvironmentsValidation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		opts    Options
+		wantErr string
+	}{
+		{opts: Options{Environments: []string{"staging", "staging"}}, wantErr: "--environment staging is given more than once"},
+		{opts: Options{Environments: []string{"staging", ""}}, wantErr: "--environment cannot be empty"},
+		{opts: Options{Environments: []string{"staging"}, EnvironmentsByTag: "tier=canary"}, wantErr: "--environment cannot be used with --environments-by-tag"},
+	}
+
+	for _, tt := range tests {
+		err := NewExecutor(&reportertest.MockReporter{}).Execute(context.Background(), &tt.opts)
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("Execute(%+v) error = %v, want %q", tt.opts, err, tt.wantErr)
+		}
+	}
+}
+
+// TestExecutorValidateRemote checks that --validate-remote creates a version
+// without deploying it and deletes only the version it created.
+func TestExecutorValidateRemote(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		opts        Options
+		createErr   error
+		wantErr     string
+		wantDeleted []int32
+		wantSummary string
+	}{
+		{
+			name:        "passes and deletes the throwaway version",
+			opts:        Options{ValidateRemote: true},
+			wantDeleted: []int32{5},
+			wantSummary: "remote validation passed — v5 deleted",
+		},
+		{
+			name:        "keep-validation-version skips the delete",
+			opts:        Options{ValidateRemote: true, KeepValidationVersion: true},
+			wantSummary: "remote validation passed — kept as v5",
+		},
+		{
+			name:      "validator rejection deletes nothing",
+			opts:      Options{ValidateRemote: true},
+			createErr: &types.BadRequestException{Message: aws.String("schema mismatch")},
+			wantErr:   "schema mismatch",
+		},
+		{
+			name:    "cannot wait for a deployment",
+			opts:    Options{ValidateRemote: true, WaitDeploy: true},
+			wantErr: "--validate-remote cannot be used with --wait-deploy or --wait-bake",
+		},
+		{
+			name:    "keep requires validate-remote",
+			opts:    Options{KeepValidationVersion: true},
+			wantErr: "--keep-validation-version requires --validate-remote",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			configPath := filepath.Join(tempDir, "apcdeploy.yml")
+			configContent := `application: test-app
+configuration_profile: test-profile
+environment: test-env
+data_file: data.json
+region: us-east-1
+`
+			if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+			if err := os.WriteFile(filepath.Join(tempDir, "data.json"), []byte(`{"key": "value"}`), 0o644); err != nil {
+				t.Fatalf("Failed to write data: %v", err)
+			}
+
+			var deleted []int32
+			var createdDescription string
+			mockClient := mock.NewResolvingClient("AWS.Freeform")
+			mockClient.CreateHostedConfigurationVersionFunc = func(ctx context.Context, params *appconfig.CreateHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.CreateHostedConfigurationVersionOutput, error) {
+				if tt.createErr != nil {
+					return nil, tt.createErr
+				}
+				createdDescription = aws.ToString(params.Description)
+				return &appconfig.CreateHostedConfigurationVersionOutput{VersionNumber: 5}, nil
+			}
+			mockClient.DeleteHostedConfigurationVersionFunc = func(ctx context.Context, params *appconfig.DeleteHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.DeleteHostedConfigurationVersionOutput, error) {
+				deleted = append(deleted, aws.ToInt32(params.VersionNumber))
+				return &appconfig.DeleteHostedConfigurationVersionOutput{}, nil
+			}
+			mockClient.StartDeploymentFunc = func(ctx context.Context, params *appconfig.StartDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.StartDeploymentOutput, error) {
+				t.Error("StartDeployment must not be called with --validate-remote")
+				return &appconfig.StartDeploymentOutput{}, nil
+			}
+
+			deployerFactory := func(ctx context.Context, cfg *config.Config) (*Deployer, error) {
+				return NewWithClient(cfg, awsInternal.NewTestClient(mockClient)), nil
+			}
+			rep := &reportertest.MockReporter{}
+			executor := NewExecutorWithFactory(rep, deployerFactory)
+
+			opts := tt.opts
+			opts.ConfigFile = configPath
+			opts.Timeout = 300
+			err := executor.Execute(context.Background(), &opts)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Execute() error = %v, want containing %q", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(deleted) != len(tt.wantDeleted) || (len(deleted) > 0 && deleted[0] != tt.wantDeleted[0]) {
+				t.Errorf("deleted versions = %v, want %v", deleted, tt.wantDeleted)
+			}
+			if tt.wantSummary != "" {
+				if createdDescription != validateRemoteDescription {
+					t.Errorf("version description = %q, want %q", createdDescription, validateRemoteDescription)
+				}
+				transitions := rep.TargetsCalls[0].Transitions
+				last := transitions[len(transitions)-1]
+				if last.Summary != tt.wantSummary {
+					t.Errorf("summary = %q, want %q", last.Summary, tt.wantSummary)
+				}
+			}
+		})
+	}
+}
+
+// TestExecutorLocalState checks that a deployment waited on to COMPLETE is
+// recorded in .apcdeploy.last.json and that an unchanged file then skips
+// without resolving resources while the recorded deployment is still the
+// environment's latest, unless --force, --no-state, --check or --dry-run is
+// given. A deployment that was only started is not recorded.
+func TestExecutorLocalState(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		first Options
+		// later is a deployment started between the two runs, e.g. by
+		// rollback --to-previous
+		later       *types.DeploymentSummary
+		second      Options
+		wantRecord  bool
+		wantSkip    bool
+		wantResolve bool
+		wantErr     error
+	}{
+		{name: "unchanged file skips from local state", first: Options{WaitBake: true}, wantRecord: true, wantSkip: true},
+		{name: "force ignores local state", first: Options{WaitBake: true}, second: Options{Force: true}, wantRecord: true, wantResolve: true},
+		{name: "no-state ignores local state", first: Options{WaitBake: true}, second: Options{NoState: true}, wantRecord: true, wantResolve: true},
+		{name: "dry run ignores local state", first: Options{WaitBake: true}, second: Options{DryRun: true}, wantRecord: true, wantResolve: true},
+		{name: "check ignores local state", first: Options{WaitBake: true}, second: Options{Check: true}, wantRecord: true, wantResolve: true, wantErr: ErrChangesFound},
+		{
+			name:       "later deployment invalidates local state",
+			first:      Options{WaitBake: true},
+			later:      &types.DeploymentSummary{DeploymentNumber: 10, ConfigurationVersion: aws.String("3"), State: types.DeploymentStateComplete},
+			wantRecord: true, wantResolve: true,
+		},
+		{
+			name:       "rolled back deployment invalidates local state",
+			first:      Options{WaitBake: true},
+			later:      &types.DeploymentSummary{DeploymentNumber: 9, ConfigurationVersion: aws.String("4"), State: types.DeploymentStateRolledBack},
+			wantRecord: true, wantResolve: true,
+		},
+		{name: "started deployment is not recorded", first: Options{}, wantResolve: true},
+		{name: "deploy phase wait is not recorded", first: Options{WaitDeploy: true}, wantResolve: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			configPath := filepath.Join(tempDir, "apcdeploy.yml")
+			configContent := `application: test-app
+configuration_profile: test-profile
+environment: test-env
+data_file: data.json
+region: us-east-1
+`
+			if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+			if err := os.WriteFile(filepath.Join(tempDir, "data.json"), []byte(`{"key": "value"}`), 0o644); err != nil {
+				t.Fatalf("Failed to write data: %v", err)
+			}
+
+			resolveCalls := 0
+			var deployments []types.DeploymentSummary
+			mockClient := mock.NewResolvingClient("AWS.Freeform")
+			mockClient.ListApplicationsFunc = func(ctx context.Context, params *appconfig.ListApplicationsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListApplicationsOutput, error) {
+				resolveCalls++
+				return &appconfig.ListApplicationsOutput{
+					Items: []types.Application{{Id: aws.String("app-123"), Name: aws.String("test-app")}},
+				}, nil
+			}
+			mockClient.ListDeploymentsFunc = func(ctx context.Context, params *appconfig.ListDeploymentsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListDeploymentsOutput, error) {
+				return &appconfig.ListDeploymentsOutput{Items: deployments}, nil
+			}
+			mockClient.CreateHostedConfigurationVersionFunc = func(ctx context.Context, params *appconfig.CreateHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.CreateHostedConfigurationVersionOutput, error) {
+				return &appconfig.CreateHostedConfigurationVersionOutput{VersionNumber: 4}, nil
+			}
+			mockClient.StartDeploymentFunc = func(ctx context.Context, params *appconfig.StartDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.StartDeploymentOutput, error) {
+				number := int32(9 + len(deployments))
+				deployments = append([]types.DeploymentSummary{{DeploymentNumber: number, ConfigurationVersion: params.ConfigurationVersion, State: types.DeploymentStateComplete}}, deployments...)
+				return &appconfig.StartDeploymentOutput{DeploymentNumber: number}, nil
+			}
+			mockClient.GetDeploymentStrategyFunc = func(ctx context.Context, params *appconfig.GetDeploymentStrategyInput, optFns ...func(*appconfig.Options)) (*appconfig.GetDeploymentStrategyOutput, error) {
+				return &appconfig.GetDeploymentStrategyOutput{}, nil
+			}
+			mockClient.GetDeploymentFunc = func(ctx context.Context, params *appconfig.GetDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.GetDeploymentOutput, error) {
+				return &appconfig.GetDeploymentOutput{State: types.DeploymentStateComplete}, nil
+			}
+			deployerFactory := func(ctx context.Context, cfg *config.Config) (*Deployer, error) {
+				awsClient := awsInternal.NewTestClient(mockClient)
+				awsClient.PollingInterval = 10 * time.Millisecond
+				return NewWithClient(cfg, awsClient), nil
+			}
+
+			first := tt.first
+			first.ConfigFile = configPath
+			first.Timeout = 300
+			if err := NewExecutorWithFactory(&reportertest.MockReporter{}, deployerFactory).Execute(context.Background(), &first); err != nil {
+				t.Fatalf("first run: %v", err)
+			}
+			st, err := state.Load(state.PathFor(configPath))
+			if err != nil {
+				t.Fatalf("state.Load() error = %v", err)
+			}
+			rec, ok := st.Get("us-east-1/test-app/test-profile/test-env")
+			if ok != tt.wantRecord {
+				t.Fatalf("recorded = %v, want %v", ok, tt.wantRecord)
+			}
+			if ok && (rec.Version != 4 || rec.DeploymentNumber != 9 || rec.ContentSHA256 != state.Hash([]byte(`{"key": "value"}`)) || rec.ApplicationID != "app-123" || rec.EnvironmentID != "env-123") {
+				t.Fatalf("recorded state = %+v", rec)
+			}
+			if tt.later != nil {
+				if tt.later.DeploymentNumber == deployments[0].DeploymentNumber {
+					deployments[0] = *tt.later
+				} else {
+					deployments = append([]types.DeploymentSummary{*tt.later}, deployments...)
+				}
+			}
+
+			resolveCalls = 0
+			rep := &reportertest.MockReporter{}
+			second := tt.second
+			second.ConfigFile = configPath
+			second.Timeout = 300
+			if err := NewExecutorWithFactory(rep, deployerFactory).Execute(context.Background(), &second); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("second run error = %v, want %v", err, tt.wantErr)
+			}
+			if (resolveCalls > 0) != tt.wantResolve {
+				t.Errorf("resolved = %v, want %v", resolveCalls > 0, tt.wantResolve)
+			}
+			transitions := rep.TargetsCalls[0].Transitions
+			last := transitions[len(transitions)-1]
+			if got := last.Kind == "skip"; got != tt.wantSkip {
+				t.Errorf("final transition = %+v, want skip %v", last, tt.wantSkip)
+			}
+		})
+	}
+}
+
+// TestExecutorVerify checks that --verify reads the served configuration back
+// after the wait and fails the row when it differs from the uploaded content.
+func TestExecutorVerify(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		opts        Options
+		profileType string
+		data        string
+		served      string
+		wantErr     string
+		wantFail    bool
+		wantSummary string
+		wantMessage string
+	}{
+		{
+			name:        "served content matches after formatting differences",
+			opts:        Options{Verify: true, WaitBake: true},
+			served:      "{\n  \"key\": \"value\"\n}\n",
+			wantSummary: "served content verified",
+		},
+		{
+			name:     "served content differs",
+			opts:     Options{Verify: true, WaitDeploy: true},
+			served:   `{"key": "transformed"}`,
+			wantErr:  ErrServedContentMismatch.Error(),
+			wantFail: true,
+		},
+		{
+			name:        "feature flags are not verified",
+			opts:        Options{Verify: true, WaitBake: true},
+			profileType: config.ProfileTypeFeatureFlags,
+			data:        `{"flags": {"beta": {"name": "Beta"}}, "values": {"beta": {"enabled": true}}, "version": "1"}`,
+			served:      `{"beta": {"enabled": true}}`,
+			wantMessage: "info: us-east-1/test-app/test-profile/test-env: --verify skipped",
+		},
+		{
+			name:    "requires a wait flag",
+			opts:    Options{Verify: true},
+			wantErr: "--verify requires --wait-deploy or --wait-bake",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			configPath := filepath.Join(tempDir, "apcdeploy.yml")
+			configContent := `application: test-app
+configuration_profile: test-profile
+environment: test-env
+data_file: data.json
+region: us-east-1
+`
+			if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+			data := tt.data
+			if data == "" {
+				data = `{"key": "value"}`
+			}
+			if err := os.WriteFile(filepath.Join(tempDir, "data.json"), []byte(data), 0o644); err != nil {
+				t.Fatalf("Failed to write data: %v", err)
+			}
+			profileType := tt.profileType
+			if profileType == "" {
+				profileType = config.ProfileTypeFreeform
+			}
+
+			mockClient := mock.NewResolvingClient(profileType)
+			mockClient.ListDeploymentsFunc = func(ctx context.Context, params *appconfig.ListDeploymentsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListDeploymentsOutput, error) {
+				return &appconfig.ListDeploymentsOutput{Items: []types.DeploymentSummary{}}, nil
+			}
+			mockClient.CreateHostedConfigurationVersionFunc = func(ctx context.Context, params *appconfig.CreateHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.CreateHostedConfigurationVersionOutput, error) {
+				return &appconfig.CreateHostedConfigurationVersionOutput{VersionNumber: 1}, nil
+			}
+			mockClient.StartDeploymentFunc = func(ctx context.Context, params *appconfig.StartDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.StartDeploymentOutput, error) {
+				return &appconfig.StartDeploymentOutput{DeploymentNumber: 1}, nil
+			}
+			mockClient.GetDeploymentStrategyFunc = func(ctx context.Context, params *appconfig.GetDeploymentStrategyInput, optFns ...func(*appconfig.Options)) (*appconfig.GetDeploymentStrategyOutput, error) {
+				return &appconfig.GetDeploymentStrategyOutput{FinalBakeTimeInMinutes: 10}, nil
+			}
+			mockClient.GetDeploymentFunc = func(ctx context.Context, params *appconfig.GetDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.GetDeploymentOutput, error) {
+				return &appconfig.GetDeploymentOutput{State: types.DeploymentStateComplete}, nil
+			}
+			mockData := &mock.MockAppConfigDataClient{
+				StartConfigurationSessionFunc: func(ctx context.Context, params *appconfigdata.StartConfigurationSessionInput, optFns ...func(*appconfigdata.Options)) (*appconfigdata.StartConfigurationSessionOutput, error) {
+					return &appconfigdata.StartConfigurationSessionOutput{InitialConfigurationToken: aws.String("token")}, nil
+				},
+				GetLatestConfigurationFunc: func(ctx context.Context, params *appconfigdata.GetLatestConfigurationInput, optFns ...func(*appconfigdata.Options)) (*appconfigdata.GetLatestConfigurationOutput, error) {
+					return &appconfigdata.GetLatestConfigurationOutput{Configuration: []byte(tt.served)}, nil
+				},
+			}
+
+			deployerFactory := func(ctx context.Context, cfg *config.Config) (*Deployer, error) {
+				awsClient := awsInternal.NewTestClientWithData(mockClient, mockData)
+				awsClient.PollingInterval = 10 * time.Millisecond
+				return NewWithClient(cfg, awsClient), nil
+			}
+			rep := &reportertest.MockReporter{}
+			executor := NewExecutorWithFactory(rep, deployerFactory)
+
+			opts := tt.opts
+			opts.ConfigFile = configPath
+			opts.Timeout = 30
+			opts.NoState = true
+			err := executor.Execute(context.Background(), &opts)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Execute() error = %v, want containing %q", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tt.wantSummary != "" || tt.wantFail {
+				transitions := rep.TargetsCalls[0].Transitions
+				last := transitions[len(transitions)-1]
+				if tt.wantFail && (last.Kind != "fail" || !errors.Is(last.Err, ErrServedContentMismatch)) {
+					t.Errorf("last transition = %+v, want fail with ErrServedContentMismatch", last)
+				}
+				if tt.wantSummary != "" && !strings.Contains(last.Summary, tt.wantSummary) {
+					t.Errorf("summary = %q, want containing %q", last.Summary, tt.wantSummary)
+				}
+			}
+			if tt.wantMessage != "" && !rep.HasMessage(tt.wantMessage) {
+				t.Errorf("expected message %q, got %v", tt.wantMessage, rep.Messages)
+			}
+		})
+	}
+}
+
+// TestExecutorLongBake checks that --wait-bake warns about a deployment
+// strategy estimated above the long-bake threshold and refuses to start it
+// without --accept-long-bake.
+func TestExecutorLongBake(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		opts        Options
+		wantErr     error
+		wantWarn    bool
+		wantCreated bool
+	}{
+		{
+			name:        "short strategy proceeds without a warning",
+			opts:        Options{WaitBake: true, LongBakeThreshold: 2 * time.Hour},
+			wantCreated: true,
+		},
+		{
+			name:     "long strategy is refused",
+			opts:     Options{WaitBake: true},
+			wantErr:  ErrLongBake,
+			wantWarn: true,
+		},
+		{
+			name:        "long strategy proceeds with --accept-long-bake",
+			opts:        Options{WaitBake: true, AcceptLongBake: true},
+			wantWarn:    true,
+			wantCreated: true,
+		},
+		{
+			name:        "a timeout covering the estimate proceeds without a warning",
+			opts:        Options{WaitBake: true, Timeout: 90 * 60},
+			wantCreated: true,
+		},
+		{
+			name:        "without --wait-bake the strategy is not checked",
+			opts:        Options{WaitDeploy: true},
+			wantCreated: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			configPath := filepath.Join(tempDir, "apcdeploy.yml")
+			configContent := `application: test-app
+configuration_profile: test-profile
+environment: test-env
+deployment_strategy: Slow.Linear
+data_file: data.json
+region: us-east-1
+`
+			if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+			if err := os.WriteFile(filepath.Join(tempDir, "data.json"), []byte(`{"key": "value"}`), 0o644); err != nil {
+				t.Fatalf("Failed to write data: %v", err)
+			}
+
+			created := false
+			mockClient := mock.NewResolvingClient("AWS.Freeform")
+			mockClient.ListDeploymentStrategiesFunc = func(ctx context.Context, params *appconfig.ListDeploymentStrategiesInput, optFns ...func(*appconfig.Options)) (*appconfig.ListDeploymentStrategiesOutput, error) {
+				return &appconfig.ListDeploymentStrategiesOutput{
+					Items: []types.DeploymentStrategy{{Id: aws.String("strategy-123"), Name: aws.String("Slow.Linear")}},
+				}, nil
+			}
+			mockClient.GetDeploymentStrategyFunc = func(ctx context.Context, params *appconfig.GetDeploymentStrategyInput, optFns ...func(*appconfig.Options)) (*appconfig.GetDeploymentStrategyOutput, error) {
+				return &appconfig.GetDeploymentStrategyOutput{
+					DeploymentDurationInMinutes: 30,
+					FinalBakeTimeInMinutes:      60,
+					GrowthType:                  types.GrowthTypeLinear,
+					GrowthFactor:                aws.Float32(10),
+				}, nil
+			}
+			mockClient.ListDeploymentsFunc = func(ctx context.Context, params *appconfig.ListDeploymentsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListDeploymentsOutput, error) {
+				return &appconfig.ListDeploymentsOutput{Items: []types.DeploymentSummary{}}, nil
+			}
+			mockClient.CreateHostedConfigurationVersionFunc = func(ctx context.Context, params *appconfig.CreateHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.CreateHostedConfigurationVersionOutput, error) {
+				created = true
+				return &appconfig.CreateHostedConfigurationVersionOutput{VersionNumber: 1}, nil
+			}
+			mockClient.StartDeploymentFunc = func(ctx context.Context, params *appconfig.StartDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.StartDeploymentOutput, error) {
+				return &appconfig.StartDeploymentOutput{DeploymentNumber: 1}, nil
+			}
+			mockClient.GetDeploymentFunc = func(ctx context.Context, params *appconfig.GetDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.GetDeploymentOutput, error) {
+				return &appconfig.GetDeploymentOutput{State: types.DeploymentStateComplete}, nil
+			}
+
+			deployerFactory := func(ctx context.Context, cfg *config.Config) (*Deployer, error) {
+				awsClient := awsInternal.NewTestClient(mockClient)
+				awsClient.PollingInterval = 10 * time.Millisecond
+				return NewWithClient(cfg, awsClient), nil
+			}
+			rep := &reportertest.MockReporter{}
+			executor := NewExecutorWithFactory(rep, deployerFactory)
+
+			opts := tt.opts
+			opts.ConfigFile = configPath
+			if opts.Timeout == 0 {
+				opts.Timeout = 30
+			}
+			opts.NoState = true
+			err := executor.Execute(context.Background(), &opts)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+				}
+				if !strings.Contains(err.Error(), "--accept-long-bake") {
+					t.Errorf("error %q does not mention --accept-long-bake", err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			warning := "warn: us-east-1/test-app/test-profile/test-env: strategy Slow.Linear takes about 90m to complete (30m rollout, LINEAR 10% growth, 60m final bake)"
+			if got := rep.HasMessage(warning); got != tt.wantWarn {
+				t.Errorf("warning reported = %v, want %v; messages: %v", got, tt.wantWarn, rep.Messages)
+			}
+			if created != tt.wantCreated {
+				t.Errorf("version created = %v, want %v", created, tt.wantCreated)
+			}
+		})
+	}
+}
+
+// TestExecutorRegionOverride checks that --region takes precedence over the
+// config file's region when creating the AWS client.
+func TestExecutorRegionOverride(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "apcdeploy.yml")
+	configContent := `application: test-app
+configuration_profile: test-profile
+environment: test-env
+data_file: data.json
+region: us-east-1
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tempDir, "data.json"), []byte(`{"key": "value"}`), 0o644); err != nil {
+		t.Fatalf("Failed to write data: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		override string
+		want     string
+	}{
+		{name: "flag overrides config", override: "eu-west-1", want: "eu-west-1"},
+		{name: "config region without flag", override: "", want: "us-east-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got string
+			factory := func(_ context.Context, cfg *config.Config) (*Deployer, error) {
+				got = cfg.Region
+				return nil, errors.New("stop")
+			}
+			executor := NewExecutorWithFactory(&reportertest.MockReporter{}, factory)
+			_ = executor.Execute(context.Background(), &Options{ConfigFile: configPath, Region: tt.override})
+			if got != tt.want {
+				t.Errorf("region used = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecutorAccountGuard(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		accountID    string
+		wantMismatch bool
+		wantAppCalls bool
+	}{
+		{name: "matching account proceeds", accountID: "123456789012", wantAppCalls: true},
+		{name: "other account is refused", accountID: "999999999999", wantMismatch: true},
+		{name: "no account_id skips the guard", accountID: "", wantAppCalls: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			configPath := filepath.Join(tempDir, "apcdeploy.yml")
+			configContent := `application: test-app
+configuration_profile: test-profile
+environment: test-env
+data_file: data.json
+region: us-east-1
+`
+			if tt.accountID != "" {
+				configContent += "account_id: \"" + tt.accountID + "\"\n"
+			}
+			if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+			if err := os.WriteFile(filepath.Join(tempDir, "data.json"), []byte(`{"key": "value"}`), 0o644); err != nil {
+				t.Fatalf("Failed to write data: %v", err)
+			}
+
+			appCalls, stsCalls := 0, 0
+			client := awsInternal.NewTestClient(&mock.MockAppConfigClient{
+				ListApplicationsFunc: func(ctx context.Context, params *appconfig.ListApplicationsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListApplicationsOutput, error) {
+					appCalls++
+					return nil, errors.New("stop")
+				},
+			})
+			client.STS = &mock.MockSTSClient{
+				GetCallerIdentityFunc: func(ctx context.Context, params *sts.GetCallerIdentityInput, optFns ...func(*sts.Options)) (*sts.GetCallerIdentityOutput, error) {
+					stsCalls++
+					return &sts.GetCallerIdentityOutput{Account: aws.String("123456789012")}, nil
+				},
+			}
+			factory := func(_ context.Context, cfg *config.Config) (*Deployer, error) {
+				return NewWithClient(cfg, client), nil
+			}
+
+			err := NewExecutorWithFactory(&reportertest.MockReporter{}, factory).Execute(context.Background(), &Options{ConfigFile: configPath, NoState: true})
+			if err == nil {
+				t.Fatal("expected an error (mismatch or stopped resolve)")
+			}
+			if got := errors.Is(err, awsInternal.ErrAccountMismatch); got != tt.wantMismatch {
+				t.Errorf("errors.Is(err, ErrAccountMismatch) = %v, want %v (err: %v)", got, tt.wantMismatch, err)
+			}
+			if (appCalls > 0) != tt.wantAppCalls {
+				t.Errorf("AppConfig calls = %d, wantAppCalls %v", appCalls, tt.wantAppCalls)
+			}
+			if wantSTS := map[bool]int{true: 1, false: 0}[tt.accountID != ""]; stsCalls != wantSTS {
+				t.Errorf("STS calls = %d, want %d", stsCalls, wantSTS)
+			}
+		})
+	}
+}
+
+func TestExecutorListStrategies(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "apcdeploy.yml")
+	// No data file: listing strategies only needs the config.
+	configContent := `application: test-app
+configuration_profile: test-profile
+environment: test-env
+deployment_strategy: Typo.Strategy
+data_file: data.json
+region: us-east-1
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	mockClient := &mock.MockAppConfigClient{
+		ListDeploymentStrategiesFunc: func(ctx context.Context, params *appconfig.ListDeploymentStrategiesInput, optFns ...func(*appconfig.Options)) (*appconfig.ListDeploymentStrategiesOutput, error) {
+			return &appconfig.ListDeploymentStrategiesOutput{
+				Items: []types.DeploymentStrategy{
+					{Id: aws.String("AppConfig.AllAtOnce"), Name: aws.String("AppConfig.AllAtOnce")},
+					{Id: aws.String("abc123"), Name: aws.String("Custom.Slow")},
+				},
+			}, nil
+		},
+	}
+	factory := func(_ context.Context, cfg *config.Config) (*Deployer, error) {
+		return NewWithClient(cfg, awsInternal.NewTestClient(mockClient)), nil
+	}
+
+	rep := &reportertest.MockReporter{}
+	if err := NewExecutorWithFactory(rep, factory).Execute(context.Background(), &Options{ConfigFile: configPath, ListStrategies: true}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if got, want := string(rep.Stdout), "AppConfig.AllAtOnce\nCustom.Slow\n"; got != want {
+		t.Errorf("stdout = %q, want %q", got, want)
+	}
+	if len(rep.TargetsCalls) != 0 {
+		t.Errorf("listing strategies should not open a deployment row, got %d", len(rep.TargetsCalls))
+	}
+}
+
+// TestExecutorTracing swaps the process-wide span provider, so it does not
+// run in parallel.
+func TestExecutorTracing(t *testing.T) {
+	rec := &tracingtest.Recorder{}
+	t.Cleanup(tracing.SetProvider(rec))
+
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "apcdeploy.yml")
+	configContent := `application: test-app
+configuration_profile: test-profile
+environment: test-env
+deployment_strategy: AppConfig.AllAtOnce
+data_file: data.json
+region: us-east-1
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tempDir, "data.json"), []byte(`{"key": "value"}`), 0o644); err != nil {
+		t.Fatalf("Failed to write data: %v", err)
+	}
+
+	mockClient := newFirstDeploymentMock(nil)
+	factory := func(_ context.Context, cfg *config.Config) (*Deployer, error) {
+		return NewWithClient(cfg, awsInternal.NewTestClient(mockClient)), nil
+	}
+
+	err := NewExecutorWithFactory(&reportertest.MockReporter{}, factory).Execute(context.Background(), &Options{ConfigFile: configPath, WaitDeploy: true, Timeout: 60, NoState: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wantParents := map[string]string{
+		"apcdeploy.run":    "",
+		"load":             "apcdeploy.run",
+		"apcdeploy.deploy": "apcdeploy.run",
+		"resolve":          "apcdeploy.deploy",
+		"validate":         "apcdeploy.deploy",
+		"create":           "apcdeploy.deploy",
+		"deploy":           "apcdeploy.deploy",
+		"wait":             "apcdeploy.deploy",
+	}
+	for name, parent := range wantParents {
+		span, ok := rec.Find(name)
+		if !ok {
+			t.Errorf("span %q not recorded", name)
+			continue
+		}
+		if span.Parent != parent {
+			t.Errorf("span %q parent = %q, want %q", name, span.Parent, parent)
+		}
+		if span.Err != nil {
+			t.Errorf("span %q err = %v, want nil", name, span.Err)
+		}
+	}
+
+	target, _ := rec.Find("apcdeploy.deploy")
+	wantAttrs := map[string]any{
+		tracing.AttrRegion:           "us-east-1",
+		tracing.AttrApplication:      "test-app",
+		tracing.AttrEnvironment:      "test-env",
+		tracing.AttrVersion:          int64(7),
+		tracing.AttrDeploymentNumber: int64(3),
+	}
+	for key, want := range wantAttrs {
+		if got := target.Attrs[key]; got != want {
+			t.Errorf("attribute %s = %v, want %v", key, got, want)
+		}
+	}
+}
+
+// TestExecutorSplitDescriptions verifies that the version and deployment
+// descriptions reach CreateHostedConfigurationVersion and StartDeployment
+// independently.
+func TestExecutorSplitDescriptions(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "apcdeploy.yml")
+	configContent := `application: test-app
+configuration_profile: test-profile
+environment: test-env
+deployment_strategy: AppConfig.AllAtOnce
+data_file: data.json
+region: us-east-1
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tempDir, "data.json"), []byte(`{"key": "value"}`), 0o644); err != nil {
+		t.Fatalf("Failed to write data: %v", err)
+	}
+
+	var versionDesc, deployDesc *string
+	mockClient := newFirstDeploymentMock(nil)
+	mockClient.CreateHostedConfigurationVersionFunc = func(ctx context.Context, params *appconfig.CreateHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.CreateHostedConfigurationVersionOutput, error) {
+		versionDesc = params.Description
+		return &appconfig.CreateHostedConfigurationVersionOutput{VersionNumber: 7}, nil
+	}
+	mockClient.StartDeploymentFunc = func(ctx context.Context, params *appconfig.StartDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.StartDeploymentOutput, error) {
+		deployDesc = params.Description
+		return &appconfig.StartDeploymentOutput{DeploymentNumber: 3}, nil
+	}
+	factory := func(_ context.Context, cfg *config.Config) (*Deployer, error) {
+		return NewWithClient(cfg, awsInternal.NewTestClient(mockClient)), nil
+	}
+
+	err := NewExecutorWithFactory(&reportertest.MockReporter{}, factory).Execute(context.Background(), &Options{
+		ConfigFile:            configPath,
+		Timeout:               60,
+		NoState:               true,
+		VersionDescription:    "adds checkout flag",
+		DeploymentDescription: "rollout for ticket 42",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := aws.ToString(versionDesc); got != "adds checkout flag" {
+		t.Errorf("version description = %q, want %q", got, "adds checkout flag")
+	}
+	if got := aws.ToString(deployDesc); got != "rollout for ticket 42" {
+		t.Errorf("deployment description = %q, want %q", got, "rollout for ticket 42")
+	}
+}
+
+func TestExecutorDeploymentStrategyOverride(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		strategy     string
+		wantStrategy string
+		wantID       string
+		wantWarn     bool
+		wantErr      bool
+	}{
+		{name: "configured strategy", wantStrategy: "Gradual", wantID: "strategy-gradual"},
+		{name: "override", strategy: "AppConfig.AllAtOnce", wantStrategy: "AppConfig.AllAtOnce", wantID: "strategy-123", wantWarn: true},
+		{name: "override equal to the config", strategy: "Gradual", wantStrategy: "Gradual", wantID: "strategy-gradual"},
+		{name: "unknown strategy", strategy: "Missing", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			configPath := filepath.Join(tempDir, "apcdeploy.yml")
+			configContent := `application: test-app
+configuration_profile: test-profile
+environment: test-env
+deployment_strategy: Gradual
+data_file: data.json
+region: us-east-1
+`
+			if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+			if err := os.WriteFile(filepath.Join(tempDir, "data.json"), []byte(`{"key": "value"}`), 0o644); err != nil {
+				t.Fatalf("Failed to write data: %v", err)
+			}
+
+			mockClient := newFirstDeploymentMock(nil)
+			mockClient.ListDeploymentStrategiesFunc = func(ctx context.Context, params *appconfig.ListDeploymentStrategiesInput, optFns ...func(*appconfig.Options)) (*appconfig.ListDeploymentStrategiesOutput, error) {
+				return &appconfig.ListDeploymentStrategiesOutput{Items: []types.DeploymentStrategy{
+					{Id: aws.String("strategy-123"), Name: aws.String("AppConfig.AllAtOnce")},
+					{Id: aws.String("strategy-gradual"), Name: aws.String("Gradual")},
+				}}, nil
+			}
+			var startedWith string
+			mockClient.StartDeploymentFunc = func(ctx context.Context, params *appconfig.StartDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.StartDeploymentOutput, error) {
+				startedWith = aws.ToString(params.DeploymentStrategyId)
+				return &appconfig.StartDeploymentOutput{DeploymentNumber: 3}, nil
+			}
+			factory := func(_ context.Context, cfg *config.Config) (*Deployer, error) {
+				return NewWithClient(cfg, awsInternal.NewTestClient(mockClient)), nil
+			}
+
+			rep := &reportertest.MockReporter{}
+			executor := NewExecutorWithFactory(rep, factory)
+			err := executor.Execute(context.Background(), &Options{ConfigFile: configPath, DeploymentStrategy: tt.strategy, NoState: true})
+			if tt.wantErr {
+				if !errors.Is(err, awsInternal.ErrStrategyNotFound) {
+					t.Fatalf("Execute() error = %v, want ErrStrategyNotFound", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if startedWith != tt.wantID {
+				t.Errorf("StartDeployment strategy = %q, want %q", startedWith, tt.wantID)
+			}
+			if res := executor.Results()[0]; res.Strategy != tt.wantStrategy {
+				t.Errorf("result strategy = %q, want %q", res.Strategy, tt.wantStrategy)
+			}
+			transitions := rep.TargetsCalls[0].Transitions
+			if last := transitions[len(transitions)-1]; !strings.Contains(last.Summary, tt.wantStrategy) {
+				t.Errorf("final summary = %q, want it to name %s", last.Summary, tt.wantStrategy)
+			}
+			if got := rep.HasMessage("warn: deploying with strategy AppConfig.AllAtOnce instead of Gradual"); got != tt.wantWarn {
+				t.Errorf("override warning = %v, want %v (messages: %v)", got, tt.wantWarn, rep.Messages)
+			}
+		})
+	}
+}
+
+// newFirstDeploymentMock returns a client for test-app/test-profile/test-env
+// with no previous deployment, so run always creates version 7 and starts
+// deployment #3. versions, when non-nil, counts CreateHostedConfigurationVersion
+// calls.
+func newFirstDeploymentMock(versions *atomic.Int32) *mock.MockAppConfigClient {
+	m := mock.NewResolvingClient("AWS.Freeform")
+	m.ListDeploymentsFunc = func(ctx context.Context, params *appconfig.ListDeploymentsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListDeploymentsOutput, error) {
+		return &appconfig.ListDeploymentsOutput{}, nil
+	}
+	m.CreateHostedConfigurationVersionFunc = func(ctx context.Context, params *appconfig.CreateHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.CreateHostedConfigurationVersionOutput, error) {
+		if versions != nil {
+			versions.Add(1)
+		}
+		return &appconfig.CreateHostedConfigurationVersionOutput{VersionNumber: 7}, nil
+	}
+	m.StartDeploymentFunc = func(ctx context.Context, params *appconfig.StartDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.StartDeploymentOutput, error) {
+		return &appconfig.StartDeploymentOutput{DeploymentNumber: 3}, nil
+	}
+	m.GetDeploymentFunc = func(ctx context.Context, params *appconfig.GetDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.GetDeploymentOutput, error) {
+		return &appconfig.GetDeploymentOutput{State: types.DeploymentStateComplete}, nil
+	}
+	return m
+}
+
+// cloudWatchStub adapts a function to awsInternal.CloudWatchAPI.
+type cloudWatchStub func(ctx context.Context, params *cloudwatch.DescribeAlarmsInput) (*cloudwatch.DescribeAlarmsOutput, error)
+
+func (f cloudWatchStub) DescribeAlarms(ctx context.Context, params *cloudwatch.DescribeAlarmsInput, _ ...func(*cloudwatch.Options)) (*cloudwatch.DescribeAlarmsOutput, error) {
+	return f(ctx, params)
+}
+
+func TestExecutorAlarmGuard(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		alarms      []string
+		state       string
+		wantFiring  bool
+		wantVersion bool
+	}{
+		{name: "ok alarm deploys", alarms: []string{"api-errors"}, state: "OK", wantVersion: true},
+		{name: "firing alarm blocks", alarms: []string{"api-errors"}, state: "ALARM", wantFiring: true},
+		{name: "no guard skips CloudWatch", wantVersion: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			configPath := filepath.Join(tempDir, "apcdeploy.yml")
+			configContent := `application: test-app
+configuration_profile: test-profile
+environment: test-env
+deployment_strategy: AppConfig.AllAtOnce
+data_file: data.json
+region: us-east-1
+`
+			if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+			if err := os.WriteFile(filepath.Join(tempDir, "data.json"), []byte(`{"key": "value"}`), 0o644); err != nil {
+				t.Fatalf("Failed to write data: %v", err)
+			}
+
+			var versions atomic.Int32
+			cwCalls := 0
+			client := awsInternal.NewTestClient(newFirstDeploymentMock(&versions))
+			client.CloudWatch = cloudWatchStub(func(_ context.Context, params *cloudwatch.DescribeAlarmsInput) (*cloudwatch.DescribeAlarmsOutput, error) {
+				cwCalls++
+				return &cloudwatch.DescribeAlarmsOutput{MetricAlarms: []cwtypes.MetricAlarm{
+					{AlarmName: aws.String(params.AlarmNames[0]), StateValue: cwtypes.StateValue(tt.state), StateReason: aws.String("Threshold Crossed")},
+				}}, nil
+			})
+			factory := func(_ context.Context, cfg *config.Config) (*Deployer, error) {
+				return NewWithClient(cfg, client), nil
+			}
+
+			rep := &reportertest.MockReporter{}
+			err := NewExecutorWithFactory(rep, factory).Execute(context.Background(), &Options{ConfigFile: configPath, NoState: true, GuardAlarms: tt.alarms})
+			if got := errors.Is(err, awsInternal.ErrAlarmFiring); got != tt.wantFiring {
+				t.Fatalf("errors.Is(err, ErrAlarmFiring) = %v, want %v (err: %v)", got, tt.wantFiring, err)
+			}
+			if !tt.wantFiring && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantFiring && !strings.Contains(err.Error(), "api-errors") {
+				t.Errorf("error should name the blocking alarm: %v", err)
+			}
+			if got := versions.Load() > 0; got != tt.wantVersion {
+				t.Errorf("version created = %v, want %v", got, tt.wantVersion)
+			}
+			if wantCW := map[bool]int{true: 1, false: 0}[len(tt.alarms) > 0]; cwCalls != wantCW {
+				t.Errorf("DescribeAlarms calls = %d, want %d", cwCalls, wantCW)
+			}
+		})
+	}
+}
+
+func TestExecutorDryRun(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		local    string
+		remote   *string // nil: nothing deployed yet
+		force    bool
+		wantErr  string
+		wantKind string
+		wantText string
+		wantDiff []string
+	}{
+		{
+			name:     "changes are shown",
+			local:    `{"key": "value"}`,
+			remote:   aws.String(`{"key": "old"}`),
+			wantKind: "done",
+			wantText: "dry run — would deploy (+1 -1 lines)",
+			wantDiff: []string{"--- remote\n+++ local\n@@ -1,3 +1,3 @@\n", `-  "key": "old"`, `+  "key": "value"`},
+		},
+		{
+			name:     "first deployment shows everything as added",
+			local:    `{"key": "value"}`,
+			wantKind: "done",
+			wantText: "dry run — would deploy",
+			wantDiff: []string{`+  "key": "value"`},
+		},
+		{
+			name:     "formatting-only difference is no change",
+			local:    `{"key": "value"}`,
+			remote:   aws.String("{\n\"key\":\"value\"}"),
+			wantKind: "skip",
+			wantText: "no changes detected (dry run)",
+		},
+		{
+			name:     "force notes the forced deployment",
+			local:    `{"key": "value"}`,
+			remote:   aws.String(`{"key": "value"}`),
+			force:    true,
+			wantKind: "done",
+			wantText: "a deployment would be forced",
+		},
+		{
+			name:    "invalid local data fails validation",
+			local:   `{"key": `,
+			wantErr: "validation failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			configPath := filepath.Join(tempDir, "apcdeploy.yml")
+			configContent := `application: test-app
+configuration_profile: test-profile
+environment: test-env
+deployment_strategy: AppConfig.AllAtOnce
+data_file: data.json
+region: us-east-1
+`
+			if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+			if err := os.WriteFile(filepath.Join(tempDir, "data.json"), []byte(tt.local), 0o644); err != nil {
+				t.Fatalf("Failed to write data: %v", err)
+			}
+
+			var versions atomic.Int32
+			mockClient := newFirstDeploymentMock(&versions)
+			if tt.remote != nil {
+				mockClient.ListDeploymentsFunc = func(ctx context.Context, params *appconfig.ListDeploymentsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListDeploymentsOutput, error) {
+					return &appconfig.ListDeploymentsOutput{Items: []types.DeploymentSummary{{DeploymentNumber: 1, State: types.DeploymentStateComplete, ConfigurationVersion: aws.String("1")}}}, nil
+				}
+				mockClient.GetDeploymentFunc = func(ctx context.Context, params *appconfig.GetDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.GetDeploymentOutput, error) {
+					return &appconfig.GetDeploymentOutput{State: types.DeploymentStateComplete, ConfigurationProfileId: aws.String("profile-123"), ConfigurationVersion: aws.String("1")}, nil
+				}
+				mockClient.GetHostedConfigurationVersionFunc = func(ctx context.Context, params *appconfig.GetHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.GetHostedConfigurationVersionOutput, error) {
+					return &appconfig.GetHostedConfigurationVersionOutput{Content: []byte(*tt.remote)}, nil
+				}
+			}
+			factory := func(_ context.Context, cfg *config.Config) (*Deployer, error) {
+				return NewWithClient(cfg, awsInternal.NewTestClient(mockClient)), nil
+			}
+
+			rep := &reportertest.MockReporter{}
+			err := NewExecutorWithFactory(rep, factory).Execute(context.Background(), &Options{ConfigFile: configPath, NoState: true, DryRun: true, Force: tt.force})
+			if versions.Load() != 0 {
+				t.Error("a dry run must not create a configuration version")
+			}
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got: %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			transitions := rep.TargetsCalls[0].Transitions
+			last := transitions[len(transitions)-1]
+			text := last.Summary
+			if last.Kind == "skip" {
+				text = last.Reason
+			}
+			if last.Kind != tt.wantKind || !strings.Contains(text, tt.wantText) {
+				t.Errorf("final transition = %+v, want %s %q", last, tt.wantKind, tt.wantText)
+			}
+			for _, line := range tt.wantDiff {
+				if !strings.Contains(string(rep.Stdout), line) {
+					t.Errorf("diff missing %q:\n%s", line, rep.Stdout)
+				}
+			}
+			if tt.wantKind == "done" && !rep.HasMessage("would deploy to application test-app (app-123)") {
+				t.Errorf("resolved resources not reported: %v", rep.Messages)
+			}
+		})
+	}
+}
+
+func TestExecutorCheck(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		local    string
+		remote   *string // nil: nothing deployed yet
+		force    bool
+		wantErr  string
+		wantKind string
+		wantText string
+	}{
+		{
+			name:     "changes",
+			local:    `{"key": "value"}`,
+			remote:   aws.String(`{"key": "old"}`),
+			wantErr:  ErrChangesFound.Error(),
+			wantKind: "done",
+			wantText: "changes would be deployed (+1 -1 lines)",
+		},
+		{
+			name:     "first deployment",
+			local:    `{"key": "value"}`,
+			wantErr:  ErrChangesFound.Error(),
+			wantKind: "done",
+			wantText: "changes would be deployed (first deployment)",
+		},
+		{
+			name:     "formatting-only difference is no change",
+			local:    `{"key": "value"}`,
+			remote:   aws.String("{\n\"key\":\"value\"}"),
+			wantKind: "skip",
+			wantText: "no changes (check)",
+		},
+		{
+			name:    "invalid local data fails validation",
+			local:   `{"key": `,
+			wantErr: "validation failed",
+		},
+		{
+			name:    "force is rejected",
+			local:   `{"key": "value"}`,
+			force:   true,
+			wantErr: "--check cannot be used with",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			configPath := filepath.Join(tempDir, "apcdeploy.yml")
+			configContent := `application: test-app
+configuration_profile: test-profile
+environment: test-env
+deployment_strategy: AppConfig.AllAtOnce
+data_file: data.json
+region: us-east-1
+`
+			if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+			if err := os.WriteFile(filepath.Join(tempDir, "data.json"), []byte(tt.local), 0o644); err != nil {
+				t.Fatalf("Failed to write data: %v", err)
+			}
+
+			var versions atomic.Int32
+			mockClient := newFirstDeploymentMock(&versions)
+			if tt.remote != nil {
+				mockClient.ListDeploymentsFunc = func(ctx context.Context, params *appconfig.ListDeploymentsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListDeploymentsOutput, error) {
+					return &appconfig.ListDeploymentsOutput{Items: []types.DeploymentSummary{{DeploymentNumber: 1, State: types.DeploymentStateComplete, ConfigurationVersion: aws.String("1")}}}, nil
+				}
+				mockClient.GetDeploymentFunc = func(ctx context.Context, params *appconfig.GetDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.GetDeploymentOutput, error) {
+					return &appconfig.GetDeploymentOutput{State: types.DeploymentStateComplete, ConfigurationProfileId: aws.String("profile-123"), ConfigurationVersion: aws.String("1")}, nil
+				}
+				mockClient.GetHostedConfigurationVersionFunc = func(ctx context.Context, params *appconfig.GetHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.GetHostedConfigurationVersionOutput, error) {
+					return &appconfig.GetHostedConfigurationVersionOutput{Content: []byte(*tt.remote)}, nil
+				}
+			}
+			factory := func(_ context.Context, cfg *config.Config) (*Deployer, error) {
+				return NewWithClient(cfg, awsInternal.NewTestClient(mockClient)), nil
+			}
+
+			rep := &reportertest.MockReporter{}
+			err := NewExecutorWithFactory(rep, factory).Execute(context.Background(), &Options{ConfigFile: configPath, NoState: true, Check: true, Force: tt.force})
+			if versions.Load() != 0 {
+				t.Error("a check must not create a configuration version")
+			}
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("expected error containing %q, got: %v", tt.wantErr, err)
+			}
+			if tt.wantKind == "" {
+				return
+			}
+
+			transitions := rep.TargetsCalls[0].Transitions
+			last := transitions[len(transitions)-1]
+			text := last.Summary
+			if last.Kind == "skip" {
+				text = last.Reason
+			}
+			if last.Kind != tt.wantKind || !strings.Contains(text, tt.wantText) {
+				t.Errorf("final transition = %+v, want %s %q", last, tt.wantKind, tt.wantText)
+			}
+			if len(rep.Stdout) != 0 {
+				t.Errorf("expected nothing on stdout, got %q", rep.Stdout)
+			}
+		})
+	}
+}
+
+func TestExecutorExpandEnv(t *testing.T) {
+	t.Setenv("APCDEPLOY_TEST_HOST", "db.internal")
+	t.Setenv("APCDEPLOY_TEST_PORT", "5432")
+
+	tests := []struct {
+		name       string
+		data       string
+		wantUpload string
+		wantErr    string
+	}{
+		{
+			// The unexpanded document is not valid JSON, so this also shows
+			// expansion runs before validation
+			name:       "references are expanded before validation and upload",
+			data:       `{"host": "${APCDEPLOY_TEST_HOST}", "port": $APCDEPLOY_TEST_PORT}`,
+			wantUpload: `{"host": "db.internal", "port": 5432}`,
+		},
+		{
+			name:    "unset variables are listed",
+			data:    `{"host": "${APCDEPLOY_TEST_HOST}", "user": "${APCDEPLOY_TEST_UNSET_USER}", "pass": "$APCDEPLOY_TEST_UNSET_PASS"}`,
+			wantErr: "unset environment variable(s): APCDEPLOY_TEST_UNSET_USER, APCDEPLOY_TEST_UNSET_PASS",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			configPath := filepath.Join(tempDir, "apcdeploy.yml")
+			configContent := `application: test-app
+configuration_profile: test-profile
+environment: test-env
+deployment_strategy: AppConfig.AllAtOnce
+data_file: data.json
+region: us-east-1
+`
+			if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+			if err := os.WriteFile(filepath.Join(tempDir, "data.json"), []byte(tt.data), 0o644); err != nil {
+				t.Fatalf("Failed to write data: %v", err)
+			}
+
+			var uploaded []byte
+			mockClient := newFirstDeploymentMock(nil)
+			mockClient.CreateHostedConfigurationVersionFunc = func(ctx context.Context, params *appconfig.CreateHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.CreateHostedConfigurationVersionOutput, error) {
+				uploaded = params.Content
+				return &appconfig.CreateHostedConfigurationVersionOutput{VersionNumber: 1}, nil
+			}
+			factory := func(_ context.Context, cfg *config.Config) (*Deployer, error) {
+				return NewWithClient(cfg, awsInternal.NewTestClient(mockClient)), nil
+			}
+
+			err := NewExecutorWithFactory(&reportertest.MockReporter{}, factory).Execute(context.Background(), &Options{ConfigFile: configPath, NoState: true, ExpandEnv: true})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got: %v", tt.wantErr, err)
+				}
+				if uploaded != nil {
+					t.Error("nothing should be uploaded when a variable is unset")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(uploaded) != tt.wantUpload {
+				t.Errorf("uploaded = %q, want %q", uploaded, tt.wantUpload)
+			}
+		})
+	}
+}
+
+func TestExecutorDataSize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		size     int
+		wantWarn string
+		wantErr  string
+	}{
+		{
+			name: "below the warning threshold",
+			size: config.ConfigSizeWarningThreshold - 1,
+		},
+		{
+			name:     "approaching the limit warns",
+			size:     config.ConfigSizeWarningThreshold,
+			wantWarn: "warn: configuration is 1677721 bytes, 80% of the 2097152 byte hosted configuration limit",
+		},
+		{
+			name:    "over the limit fails before any AWS call",
+			size:    config.MaxConfigSize + 1,
+			wantErr: "configuration is 2097153 bytes, limit is 2097152",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			configPath := filepath.Join(tempDir, "apcdeploy.yml")
+			configContent := `application: test-app
+configuration_profile: test-profile
+environment: test-env
+deployment_strategy: AppConfig.AllAtOnce
+data_file: data.txt
+region: us-east-1
+`
+			if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+			if err := os.WriteFile(filepath.Join(tempDir, "data.txt"), bytes.Repeat([]byte("a"), tt.size), 0o644); err != nil {
+				t.Fatalf("Failed to write data: %v", err)
+			}
+
+			factoryCalled := false
+			factory := func(_ context.Context, cfg *config.Config) (*Deployer, error) {
+				factoryCalled = true
+				return NewWithClient(cfg, awsInternal.NewTestClient(newFirstDeploymentMock(nil))), nil
+			}
+			reporter := &reportertest.MockReporter{}
+
+			err := NewExecutorWithFactory(reporter, factory).Execute(context.Background(), &Options{ConfigFile: configPath, NoState: true})
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("Execute() error = %v, want %q", err, tt.wantErr)
+				}
+				if factoryCalled {
+					t.Error("no AWS client should be created for oversized data")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			warned := false
+			for _, msg := range reporter.Messages {
+				if strings.HasPrefix(msg, "warn: configuration is") {
+					warned = true
+				}
+			}
+			if tt.wantWarn == "" && warned {
+				t.Errorf("unexpected size warning: %v", reporter.Messages)
+			}
+			if tt.wantWarn != "" && !reporter.HasMessage(tt.wantWarn) {
+				t.Errorf("expected %q, got %v", tt.wantWarn, reporter.Messages)
+			}
+		})
+	}
+}
+
+func TestExecutorConfigVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		opts        Options
+		versionErr  error
+		wantErr     string
+		wantStarted string
+	}{
+		{
+			name:        "deploys the existing version without reading the data file",
+			opts:        Options{ConfigVersion: 5},
+			wantStarted: "5",
+		},
+		{
+			name:       "missing version fails before deploying",
+			opts:       Options{ConfigVersion: 9},
+			versionErr: &types.ResourceNotFoundException{Message: aws.String("version not found")},
+			wantErr:    "configuration version 9 of profile test-profile",
+		},
+		{
+			name:    "data file override is rejected",
+			opts:    Options{ConfigVersion: 5, DataFile: "other.json"},
+			wantErr: "--config-version cannot be used with",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			configPath := filepath.Join(tempDir, "apcdeploy.yml")
+			configContent := `application: test-app
+configuration_profile: test-profile
+environment: test-env
+deployment_strategy: AppConfig.AllAtOnce
+data_file: data.json
+region: us-east-1
+`
+			if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+
+			var versions atomic.Int32
+			var started string
+			mockClient := newFirstDeploymentMock(&versions)
+			mockClient.GetHostedConfigurationVersionFunc = func(ctx context.Context, params *appconfig.GetHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.GetHostedConfigurationVersionOutput, error) {
+				if tt.versionErr != nil {
+					return nil, tt.versionErr
+				}
+				return &appconfig.GetHostedConfigurationVersionOutput{
+					VersionNumber: *params.VersionNumber,
+					Content:       []byte(`{"key": "value"}`),
+					ContentType:   aws.String("application/json"),
+				}, nil
+			}
+			mockClient.StartDeploymentFunc = func(ctx context.Context, params *appconfig.StartDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.StartDeploymentOutput, error) {
+				started = aws.ToString(params.ConfigurationVersion)
+				return &appconfig.StartDeploymentOutput{DeploymentNumber: 3}, nil
+			}
+			factory := func(_ context.Context, cfg *config.Config) (*Deployer, error) {
+				return NewWithClient(cfg, awsInternal.NewTestClient(mockClient)), nil
+			}
+			reporter := &reportertest.MockReporter{}
+
+			opts := tt.opts
+			opts.ConfigFile = configPath
+			opts.NoState = true
+			err := NewExecutorWithFactory(reporter, factory).Execute(context.Background(), &opts)
+			if versions.Load() != 0 {
+				t.Errorf("created %d configuration versions, want none", versions.Load())
+			}
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Execute() error = %v, want %q", err, tt.wantErr)
+				}
+				if started != "" {
+					t.Errorf("deployment of v%s started, want none", started)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if started != tt.wantStarted {
+				t.Errorf("started deployment of version %q, want %q", started, tt.wantStarted)
+			}
+			if !reporter.HasMessage("info: Deploying existing version " + tt.wantStarted) {
+				t.Errorf("expected existing version message, got %v", reporter.Messages)
+			}
+		})
+	}
+}
+
+// TestExecutorCreateOnly checks that --create-only creates the version after
+// the usual change detection and never starts a deployment.
+func TestExecutorCreateOnly(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		remote      *string // nil: nothing deployed yet
+		force       bool
+		wantCreated bool
+	}{
+		{name: "first version", wantCreated: true},
+		{name: "changed content", remote: aws.String(`{"key": "old"}`), wantCreated: true},
+		{name: "unchanged content is skipped", remote: aws.String(`{"key": "value"}`)},
+		{name: "force creates unchanged content", remote: aws.String(`{"key": "value"}`), force: true, wantCreated: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			configPath := filepath.Join(tempDir, "apcdeploy.yml")
+			configContent := `application: test-app
+configuration_profile: test-profile
+environment: test-env
+deployment_strategy: AppConfig.AllAtOnce
+data_file: data.json
+region: us-east-1
+`
+			if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+			if err := os.WriteFile(filepath.Join(tempDir, "data.json"), []byte(`{"key": "value"}`), 0o644); err != nil {
+				t.Fatalf("Failed to write data: %v", err)
+			}
+
+			var versions atomic.Int32
+			mockClient := newFirstDeploymentMock(&versions)
+			mockClient.StartDeploymentFunc = func(ctx context.Context, params *appconfig.StartDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.StartDeploymentOutput, error) {
+				t.Error("--create-only must not start a deployment")
+				return &appconfig.StartDeploymentOutput{DeploymentNumber: 3}, nil
+			}
+			if tt.remote != nil {
+				mockClient.ListDeploymentsFunc = func(ctx context.Context, params *appconfig.ListDeploymentsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListDeploymentsOutput, error) {
+					return &appconfig.ListDeploymentsOutput{Items: []types.DeploymentSummary{{DeploymentNumber: 1, State: types.DeploymentStateComplete, ConfigurationVersion: aws.String("1")}}}, nil
+				}
+				mockClient.GetDeploymentFunc = func(ctx context.Context, params *appconfig.GetDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.GetDeploymentOutput, error) {
+					return &appconfig.GetDeploymentOutput{State: types.DeploymentStateComplete, ConfigurationProfileId: aws.String("profile-123"), ConfigurationVersion: aws.String("1")}, nil
+				}
+				mockClient.GetHostedConfigurationVersionFunc = func(ctx context.Context, params *appconfig.GetHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.GetHostedConfigurationVersionOutput, error) {
+					return &appconfig.GetHostedConfigurationVersionOutput{Content: []byte(*tt.remote)}, nil
+				}
+			}
+			factory := func(_ context.Context, cfg *config.Config) (*Deployer, error) {
+				return NewWithClient(cfg, awsInternal.NewTestClient(mockClient)), nil
+			}
+
+			rep := &reportertest.MockReporter{}
+			executor := NewExecutorWithFactory(rep, factory)
+			err := executor.Execute(context.Background(), &Options{ConfigFile: configPath, CreateOnly: true, Force: tt.force})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got := versions.Load() == 1; got != tt.wantCreated {
+				t.Errorf("version created = %v, want %v", got, tt.wantCreated)
+			}
+			res := executor.Results()[0]
+			transitions := rep.TargetsCalls[0].Transitions
+			last := transitions[len(transitions)-1]
+			if tt.wantCreated {
+				if res.Status != StatusCreated || res.Version != 7 || res.DeploymentNumber != 0 {
+					t.Errorf("result = %+v, want created v7 without a deployment", res)
+				}
+				if last.Kind != "done" || last.Summary != "created v7 (not deployed)" {
+					t.Errorf("final transition = %+v, want done \"created v7 (not deployed)\"", last)
+				}
+				if !rep.HasMessage("--config-version 7") {
+					t.Errorf("expected a promotion hint, got %v", rep.Messages)
+				}
+				if _, err := os.Stat(filepath.Join(tempDir, state.FileName)); !os.IsNotExist(err) {
+					t.Errorf("the local deploy record must not be written, stat error = %v", err)
+				}
+			} else if res.Status != StatusSkipped {
+				t.Errorf("result = %+v, want skipped", res)
+			}
+		})
+	}
+}
+
+func TestExecutorCreateOnlyConflicts(t *testing.T) {
+	t.Parallel()
+
+	for _, opts := range []Options{
+		{CreateOnly: true, WaitDeploy: true},
+		{CreateOnly: true, WaitBake: true},
+		{CreateOnly: true, ConfigVersion: 3},
+		{CreateOnly: true, DryRun: true},
+		{CreateOnly: true, GuardAlarms: []string{"errors"}},
+		{CreateOnly: true, Environments: []string{"a", "b"}},
+	} {
+		err := NewExecutor(&reportertest.MockReporter{}).Execute(context.Background(), &opts)
+		if err == nil || !strings.Contains(err.Error(), "--create-only cannot be used with") {
+			t.Errorf("Execute(%+v) error = %v, want a --create-only conflict", opts, err)
+		}
+	}
+}
+
+// TestExecutorPollInterval checks that --poll-interval replaces the client's
+// polling interval and that the default is kept without it.
+func TestExecutorPollInterval(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		interval time.Duration
+		want     time.Duration
+	}{
+		{name: "default", want: 5 * time.Second},
+		{name: "override", interval: 2 * time.Second, want: 2 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			configPath := filepath.Join(tempDir, "apcdeploy.yml")
+			configContent := `application: test-app
+configuration_profile: test-profile
+environment: test-env
+deployment_strategy: AppConfig.AllAtOnce
+data_file: data.json
+region: us-east-1
+`
+			if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+			if err := os.WriteFile(filepath.Join(tempDir, "data.json"), []byte(`{"key": "value"}`), 0o644); err != nil {
+				t.Fatalf("Failed to write data: %v", err)
+			}
+
+			var client *awsInternal.Client
+			factory := func(_ context.Context, cfg *config.Config) (*Deployer, error) {
+				client = awsInternal.NewTestClient(newFirstDeploymentMock(nil))
+				client.PollingInterval = config.DefaultPollingInterval
+				return NewWithClient(cfg, client), nil
+			}
+
+			err := NewExecutorWithFactory(&reportertest.MockReporter{}, factory).Execute(context.Background(), &Options{ConfigFile: configPath, NoState: true, PollInterval: tt.interval})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if client.PollingInterval != tt.want {
+				t.Errorf("PollingInterval = %v, want %v", client.PollingInterval, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecutorContentTypeOverride(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		data        string
+		profileType string
+		contentType string
+		wantErr     string
+		wantType    string
+	}{
+		{
+			name:        "conf file uploaded as JSON",
+			data:        `{"key": "value"}`,
+			profileType: config.ProfileTypeFreeform,
+			contentType: config.ContentTypeJSON,
+			wantType:    config.ContentTypeJSON,
+		},
+		{
+			name:        "content is validated as the forced type",
+			data:        "key = value",
+			profileType: config.ProfileTypeFreeform,
+			contentType: config.ContentTypeJSON,
+			wantErr:     "validation failed",
+		},
+		{
+			name:        "feature flags keep their type",
+			data:        `{"key": "value"}`,
+			profileType: config.ProfileTypeFeatureFlags,
+			contentType: config.ContentTypeYAML,
+			wantErr:     "cannot be used with AWS.AppConfig.FeatureFlags profiles",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			configPath := filepath.Join(tempDir, "apcdeploy.yml")
+			configContent := `application: test-app
+configuration_profile: test-profile
+environment: test-env
+deployment_strategy: AppConfig.AllAtOnce
+data_file: app.conf
+region: us-east-1
+`
+			if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+			if err := os.WriteFile(filepath.Join(tempDir, "app.conf"), []byte(tt.data), 0o644); err != nil {
+				t.Fatalf("Failed to write data: %v", err)
+			}
+
+			var uploadedType string
+			mockClient := newFirstDeploymentMock(nil)
+			mockClient.GetConfigurationProfileFunc = func(ctx context.Context, params *appconfig.GetConfigurationProfileInput, optFns ...func(*appconfig.Options)) (*appconfig.GetConfigurationProfileOutput, error) {
+				return &appconfig.GetConfigurationProfileOutput{Id: aws.String("profile-123"), Name: aws.String("test-profile"), Type: aws.String(tt.profileType)}, nil
+			}
+			mockClient.CreateHostedConfigurationVersionFunc = func(ctx context.Context, params *appconfig.CreateHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.CreateHostedConfigurationVersionOutput, error) {
+				uploadedType = aws.ToString(params.ContentType)
+				return &appconfig.CreateHostedConfigurationVersionOutput{VersionNumber: 1}, nil
+			}
+			factory := func(_ context.Context, cfg *config.Config) (*Deployer, error) {
+				return NewWithClient(cfg, awsInternal.NewTestClient(mockClient)), nil
+			}
+
+			err := NewExecutorWithFactory(&reportertest.MockReporter{}, factory).Execute(context.Background(), &Options{ConfigFile: configPath, NoState: true, ContentType: tt.contentType})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got: %v", tt.wantErr, err)
+				}
+				if uploadedType != "" {
+					t.Error("nothing should be uploaded")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if uploadedType != tt.wantType {
+				t.Errorf("uploaded content type = %q, want %q", uploadedType, tt.wantType)
+			}
+		})
+	}
+}
+
+// TestExecutorFeatureFlagsYAML checks that a FeatureFlags data file written
+// in YAML is uploaded as JSON, and that it compares equal to the deployed
+// JSON it was converted from.
+func TestExecutorFeatureFlagsYAML(t *testing.T) {
+	t.Parallel()
+
+	const local = `# rollout flags
+flags:
+  beta:
+    name: Beta
+values:
+  beta:
+    enabled: true
+version: "1"
+`
+	deployed := `{"flags":{"beta":{"name":"Beta","_createdAt":"2024-01-01T00:00:00Z"}},"values":{"beta":{"enabled":true}},"version":"1"}`
+
+	tests := []struct {
+		name        string
+		remote      *string
+		wantVersion bool
+	}{
+		{name: "first deployment uploads JSON", wantVersion: true},
+		{name: "same flags as deployed JSON are unchanged", remote: &deployed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			configPath := filepath.Join(tempDir, "apcdeploy.yml")
+			configContent := `application: test-app
+configuration_profile: test-profile
+environment: test-env
+deployment_strategy: AppConfig.AllAtOnce
+data_file: flags.yaml
+region: us-east-1
+`
+			if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+			if err := os.WriteFile(filepath.Join(tempDir, "flags.yaml"), []byte(local), 0o644); err != nil {
+				t.Fatalf("Failed to write data: %v", err)
+			}
+
+			var uploaded *appconfig.CreateHostedConfigurationVersionInput
+			mockClient := newFirstDeploymentMock(nil)
+			mockClient.GetConfigurationProfileFunc = func(ctx context.Context, params *appconfig.GetConfigurationProfileInput, optFns ...func(*appconfig.Options)) (*appconfig.GetConfigurationProfileOutput, error) {
+				return &appconfig.GetConfigurationProfileOutput{Id: aws.String("profile-123"), Name: aws.String("test-profile"), Type: aws.String(config.ProfileTypeFeatureFlags)}, nil
+			}
+			mockClient.ListConfigurationProfilesFunc = func(ctx context.Context, params *appconfig.ListConfigurationProfilesInput, optFns ...func(*appconfig.Options)) (*appconfig.ListConfigurationProfilesOutput, error) {
+				return &appconfig.ListConfigurationProfilesOutput{Items: []types.ConfigurationProfileSummary{{Id: aws.String("profile-123"), Name: aws.String("test-profile"), Type: aws.String(config.ProfileTypeFeatureFlags)}}}, nil
+			}
+			mockClient.CreateHostedConfigurationVersionFunc = func(ctx context.Context, params *appconfig.CreateHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.CreateHostedConfigurationVersionOutput, error) {
+				uploaded = params
+				return &appconfig.CreateHostedConfigurationVersionOutput{VersionNumber: 7}, nil
+			}
+			if tt.remote != nil {
+				mockClient.ListDeploymentsFunc = func(ctx context.Context, params *appconfig.ListDeploymentsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListDeploymentsOutput, error) {
+					return &appconfig.ListDeploymentsOutput{Items: []types.DeploymentSummary{{DeploymentNumber: 1, State: types.DeploymentStateComplete, ConfigurationVersion: aws.String("1")}}}, nil
+				}
+				mockClient.GetDeploymentFunc = func(ctx context.Context, params *appconfig.GetDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.GetDeploymentOutput, error) {
+					return &appconfig.GetDeploymentOutput{State: types.DeploymentStateComplete, ConfigurationProfileId: aws.String("profile-123"), ConfigurationVersion: aws.String("1")}, nil
+				}
+				mockClient.GetHostedConfigurationVersionFunc = func(ctx context.Context, params *appconfig.GetHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.GetHostedConfigurationVersionOutput, error) {
+					return &appconfig.GetHostedConfigurationVersionOutput{Content: []byte(*tt.remote), ContentType: aws.String(config.ContentTypeJSON)}, nil
+				}
+			}
+			factory := func(_ context.Context, cfg *config.Config) (*Deployer, error) {
+				return NewWithClient(cfg, awsInternal.NewTestClient(mockClient)), nil
+			}
+
+			err := NewExecutorWithFactory(&reportertest.MockReporter{}, factory).Execute(context.Background(), &Options{ConfigFile: configPath, NoState: true})
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if !tt.wantVersion {
+				if uploaded != nil {
+					t.Errorf("expected no new version, uploaded %s", uploaded.Content)
+				}
+				return
+			}
+			if uploaded == nil {
+				t.Fatal("expected a hosted configuration version to be created")
+			}
+			if got := aws.ToString(uploaded.ContentType); got != config.ContentTypeJSON {
+				t.Errorf("ContentType = %q, want %q", got, config.ContentTypeJSON)
+			}
+			var doc map[string]any
+			if err := json.Unmarshal(uploaded.Content, &doc); err != nil {
+				t.Fatalf("uploaded content is not JSON: %v\n%s", err, uploaded.Content)
+			}
+			if doc["version"] != "1" {
+				t.Errorf("uploaded content = %s", uploaded.Content)
+			}
+		})
+	}
+}
@@ -1,11 +1,120 @@
 package run
 
+import (
+	"io"
+	"time"
+
+	"github.com/koh-sh/apcdeploy/internal/config"
+)
+
+// DefaultLongBakeThreshold is the estimated strategy duration above which
+// --wait-bake requires AcceptLongBake.
+const DefaultLongBakeThreshold = 30 * time.Minute
+
 // Options contains the configuration options for deployment
 type Options struct {
-	ConfigFile  string
-	WaitDeploy  bool
-	WaitBake    bool
-	Timeout     int
-	Force       bool
-	Description string
+	ConfigFile string
+	// Config, when non-nil, is deployed instead of loading ConfigFile (an
+	// inline apply manifest entry). It must already be prepared with
+	// config.PrepareConfig; ConfigFile then only names it in messages.
+	Config     *config.Config
+	WaitDeploy bool
+	WaitBake   bool
+	Timeout    int
+	Force      bool
+	// SkipDiffCheck deploys without comparing against the local deploy
+	// record or the deployed content, so the deployed version is never
+	// fetched; unlike Force, dry runs and change checks are rejected
+	// (--skip-diff-check)
+	SkipDiffCheck bool
+	// VersionDescription is attached to the created configuration version
+	// and DeploymentDescription to the deployment (--version-description,
+	// --deploy-description; --description sets both)
+	VersionDescription    string
+	DeploymentDescription string
+	// PollBackoff polls deployment status with exponential backoff instead
+	// of a fixed interval while waiting (--poll-backoff)
+	PollBackoff bool
+	// PollInterval replaces the client's polling interval while waiting;
+	// zero keeps config.DefaultPollingInterval (--poll-interval)
+	PollInterval time.Duration
+	// DataBase64Env names an environment variable holding the base64-encoded
+	// payload to deploy in place of data_file (--data-base64-env)
+	DataBase64Env string
+	// ApplyNormalize uploads text content after normalizing it (CRLF, trailing
+	// newline and any text_normalize options) instead of as-is (--apply-normalize)
+	ApplyNormalize bool
+	// EnvironmentsByTag ("key=value") deploys to every environment of the
+	// application carrying that tag instead of the configured environment
+	// (--environments-by-tag)
+	EnvironmentsByTag string
+	// Environments deploys to these environments, in order, instead of the
+	// configured environment, stopping at the first failure (--environment)
+	Environments []string
+	// ValidateRemote creates a throwaway configuration version to run the
+	// profile's AppConfig validators without deploying (--validate-remote)
+	ValidateRemote bool
+	// KeepValidationVersion keeps the version created by ValidateRemote
+	// instead of deleting it (--keep-validation-version)
+	KeepValidationVersion bool
+	// NoState disables reading and writing the local deploy record
+	// (.apcdeploy.last.json) next to the config file (--no-state)
+	NoState bool
+	// Verify reads the served configuration back through AppConfigData once
+	// the wait completes and fails when it differs from the uploaded content
+	// (--verify; requires WaitDeploy or WaitBake)
+	Verify bool
+	// DumpNormalized is a directory to write the normalized deployed and
+	// local content to, as compared for change detection (--dump-normalized)
+	DumpNormalized string
+	// Region overrides the region from the config file (--region)
+	Region string
+	// ListStrategies prints the deployment strategy names available in the
+	// target region and exits without deploying (--list-strategies)
+	ListStrategies bool
+	// GuardAlarms are CloudWatch alarm names that must not be in the ALARM
+	// state when the deployment starts (--guard-alarm)
+	GuardAlarms []string
+	// WaitForSlot waits for a deployment already in progress on the
+	// environment to finish, up to Timeout, instead of failing (--wait-for-slot)
+	WaitForSlot bool
+	// DryRun resolves, validates and compares as a real run would, then
+	// reports the diff and the resolved resources instead of creating a
+	// version or deploying (--dry-run)
+	DryRun bool
+	// Check resolves, validates and compares as a real run would and reports
+	// whether a deployment would change anything, returning ErrChangesFound
+	// when it would; nothing is created or deployed (--check)
+	Check bool
+	// ExpandEnv substitutes ${VAR} and $VAR references in the data with
+	// environment variables before it is validated and uploaded; an unset
+	// variable is an error (--expand-env)
+	ExpandEnv bool
+	// DataFile overrides data_file from the config file; "-" reads the data
+	// from Stdin (--data-file)
+	DataFile string
+	// Stdin is read when the data file is "-"
+	Stdin io.Reader
+	// ContentType overrides content_type and the type inferred from the
+	// data file extension (--content-type)
+	ContentType string
+	// DeploymentStrategy overrides deployment_strategy from the config file
+	// (--deployment-strategy); it is resolved like the configured name, so
+	// predefined and custom strategies both work
+	DeploymentStrategy string
+	// ConfigVersion deploys this existing hosted configuration version
+	// instead of uploading the data file, which is not read
+	// (--config-version); zero uploads as usual
+	ConfigVersion int32
+	// CreateOnly validates, compares and creates the hosted configuration
+	// version as usual, then stops without starting a deployment, so the
+	// version can be promoted later with ConfigVersion (--create-only)
+	CreateOnly bool
+	// AcceptLongBake lets WaitBake proceed when the deployment strategy's
+	// rollout plus final bake time exceeds LongBakeThreshold; without it
+	// such a run fails before anything is created (--accept-long-bake)
+	AcceptLongBake bool
+	// LongBakeThreshold is the estimated strategy duration that counts as a
+	// long bake; zero means DefaultLongBakeThreshold (--long-bake-threshold)
+	LongBakeThreshold time.Duration
 }
@@ -0,0 +1,75 @@
+package run
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Target statuses reported in TargetResult.Status.
+const (
+	StatusStarted   = "started"
+	StatusDeployed  = "deployed"
+	StatusComplete  = "complete"
+	StatusSkipped   = "skipped"
+	StatusValidated = "validated"
+	StatusCreated   = "created"
+	StatusFailed    = "failed"
+)
+
+// TargetResult is the outcome of one target in the run --output json report.
+type TargetResult struct {
+	Target      string `json:"target"`
+	Application string `json:"application"`
+	Profile     string `json:"profile"`
+	Environment string `json:"environment"`
+	Region      string `json:"region"`
+	// Status mirrors the row summary: started, deployed (--wait-deploy),
+	// complete (--wait-bake), skipped, validated (--validate-remote),
+	// created (--create-only) or failed
+	Status           string `json:"status"`
+	Version          int32  `json:"version,omitempty"`
+	DeploymentNumber int32  `json:"deployment_number,omitempty"`
+	// Strategy is the deployment strategy the deployment was started
+	// with, which differs from the config under --deployment-strategy
+	Strategy string `json:"strategy,omitempty"`
+	// PreviousVersion is the version that was deployed before this run;
+	// empty together with FirstDeploy when nothing was deployed yet
+	PreviousVersion string `json:"previous_version,omitempty"`
+	FirstDeploy     bool   `json:"first_deploy,omitempty"`
+	Error           string `json:"error,omitempty"`
+}
+
+// Report is the run --output json payload. Warnings holds every warning
+// reported during the run, so CI does not have to scrape stderr.
+type Report struct {
+	Targets  []TargetResult `json:"targets"`
+	Warnings []string       `json:"warnings"`
+}
+
+// record stores the outcome of one target. deployByTag runs targets one
+// after another, but the lock keeps Results safe for concurrent callers.
+func (e *Executor) record(res TargetResult) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.results = append(e.results, res)
+}
+
+// Results returns the outcome of every target attempted by Execute, in the
+// order they finished. The result is never nil so it encodes as a JSON array.
+func (e *Executor) Results() []TargetResult {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]TargetResult{}, e.results...)
+}
+
+// WriteReport writes the run --output json payload to stdout: the outcome
+// of every target plus the warnings collected by the caller. It is written
+// even when Execute failed so CI sees which target failed and why.
+func (e *Executor) WriteReport(warnings []string) error {
+	out, err := json.MarshalIndent(Report{Targets: e.Results(), Warnings: warnings}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode run report: %w", err)
+	}
+	e.reporter.Data(append(out, '\n'))
+	return nil
+}
@@ -0,0 +1,106 @@
+package run
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/appconfig"
+	awsInternal "github.com/koh-sh/apcdeploy/internal/aws"
+	"github.com/koh-sh/apcdeploy/internal/config"
+	reportertest "github.com/koh-sh/apcdeploy/internal/reporter/testing"
+)
+
+func TestExecutorWriteReport(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		createErr  error
+		want       TargetResult
+		wantWarned bool
+	}{
+		{
+			name: "first deployment",
+			want: TargetResult{
+				Target: "us-east-1/test-app/test-profile/test-env", Application: "test-app", Profile: "test-profile", Environment: "test-env", Region: "us-east-1",
+				Status: StatusStarted, Version: 7, DeploymentNumber: 3, Strategy: "AppConfig.AllAtOnce", FirstDeploy: true,
+			},
+			wantWarned: true,
+		},
+		{
+			name:      "failed target carries the error",
+			createErr: errors.New("throttled"),
+			want: TargetResult{
+				Target: "us-east-1/test-app/test-profile/test-env", Application: "test-app", Profile: "test-profile", Environment: "test-env", Region: "us-east-1",
+				Status: StatusFailed, Error: "failed to create configuration version: failed to create hosted configuration version failed: throttled",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			configPath := filepath.Join(tempDir, "apcdeploy.yml")
+			configContent := `application: test-app
+configuration_profile: test-profile
+environment: test-env
+deployment_strategy: AppConfig.AllAtOnce
+data_file: data.json
+region: us-east-1
+`
+			if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+			if err := os.WriteFile(filepath.Join(tempDir, "data.json"), []byte(`{"key": "value"}`), 0o644); err != nil {
+				t.Fatalf("Failed to write data: %v", err)
+			}
+
+			mockClient := newFirstDeploymentMock(nil)
+			if tt.createErr != nil {
+				mockClient.CreateHostedConfigurationVersionFunc = func(ctx context.Context, params *appconfig.CreateHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.CreateHostedConfigurationVersionOutput, error) {
+					return nil, tt.createErr
+				}
+			}
+			factory := func(_ context.Context, cfg *config.Config) (*Deployer, error) {
+				return NewWithClient(cfg, awsInternal.NewTestClient(mockClient)), nil
+			}
+			rep := &reportertest.MockReporter{}
+			executor := NewExecutorWithFactory(rep, factory)
+
+			execErr := executor.Execute(context.Background(), &Options{ConfigFile: configPath, Timeout: 60, NoState: true})
+			if (execErr != nil) != (tt.createErr != nil) {
+				t.Fatalf("Execute() error = %v", execErr)
+			}
+			if err := executor.WriteReport([]string{"collected elsewhere"}); err != nil {
+				t.Fatalf("WriteReport() error = %v", err)
+			}
+
+			var got Report
+			if err := json.Unmarshal(rep.Stdout, &got); err != nil {
+				t.Fatalf("invalid JSON: %v\n%s", err, rep.Stdout)
+			}
+			if len(got.Targets) != 1 || got.Targets[0] != tt.want {
+				t.Errorf("targets = %+v, want [%+v]", got.Targets, tt.want)
+			}
+			if len(got.Warnings) != 1 || got.Warnings[0] != "collected elsewhere" {
+				t.Errorf("warnings = %v, want the ones passed in", got.Warnings)
+			}
+			warned := false
+			for _, msg := range rep.Messages {
+				if strings.HasPrefix(msg, "warn: ") && strings.Contains(msg, "no previous deployment") {
+					warned = true
+				}
+			}
+			if warned != tt.wantWarned {
+				t.Errorf("first deployment warning = %v, want %v (messages %v)", warned, tt.wantWarned, rep.Messages)
+			}
+		})
+	}
+}
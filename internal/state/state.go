@@ -0,0 +1,94 @@
+// Package state reads and writes the local deploy record (.apcdeploy.last.json)
+// kept next to apcdeploy.yml. After each successful deployment run records
+// the version, deployment number and content hash per target, so later runs
+// can skip unchanged content with a single ListDeployments call instead of
+// resolving resources and fetching the deployed content.
+package state
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/koh-sh/apcdeploy/internal/config"
+)
+
+// FileName is the name of the state file, created in the directory of the
+// configuration file.
+const FileName = ".apcdeploy.last.json"
+
+// Record is the last successful deployment of one target.
+type Record struct {
+	Version          int32     `json:"version"`
+	DeploymentNumber int32     `json:"deployment_number"`
+	ContentSHA256    string    `json:"content_sha256"`
+	DeployedAt       time.Time `json:"deployed_at"`
+	// ApplicationID and EnvironmentID locate the environment whose latest
+	// deployment must still be DeploymentNumber for the record to apply.
+	ApplicationID string `json:"application_id,omitempty"`
+	EnvironmentID string `json:"environment_id,omitempty"`
+}
+
+// State maps target identifiers (config.Identifier) to their last record.
+type State struct {
+	Targets map[string]Record `json:"targets"`
+}
+
+// PathFor returns the state file path for the given configuration file.
+func PathFor(configFile string) string {
+	return filepath.Join(filepath.Dir(configFile), FileName)
+}
+
+// Load reads the state file at path. A missing file yields an empty State.
+func Load(path string) (*State, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &State{Targets: map[string]Record{}}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state file: %w", err)
+	}
+
+	var s State
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse state file %s: %w", path, err)
+	}
+	if s.Targets == nil {
+		s.Targets = map[string]Record{}
+	}
+	return &s, nil
+}
+
+// Save writes the state to path atomically.
+func (s *State) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode state: %w", err)
+	}
+	if err := config.WriteFileAtomic(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	return nil
+}
+
+// Get returns the record for id, if any.
+func (s *State) Get(id string) (Record, bool) {
+	r, ok := s.Targets[id]
+	return r, ok
+}
+
+// Set stores the record for id.
+func (s *State) Set(id string, r Record) {
+	s.Targets[id] = r
+}
+
+// Hash returns the hex SHA-256 of content, as stored in Record.ContentSHA256.
+func Hash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
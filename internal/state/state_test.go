@@ -0,0 +1,96 @@
+package state
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestPathFor(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		configFile string
+		want       string
+	}{
+		{"apcdeploy.yml", FileName},
+		{"envs/prod/apcdeploy.yml", filepath.Join("envs/prod", FileName)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.configFile, func(t *testing.T) {
+			t.Parallel()
+			if got := PathFor(tt.configFile); got != tt.want {
+				t.Errorf("PathFor(%q) = %q, want %q", tt.configFile, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	t.Parallel()
+
+	s, err := Load(filepath.Join(t.TempDir(), FileName))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if _, ok := s.Get("any"); ok {
+		t.Error("empty state should have no records")
+	}
+}
+
+func TestSaveLoadRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), FileName)
+	rec := Record{
+		Version:          3,
+		DeploymentNumber: 7,
+		ContentSHA256:    Hash([]byte("content")),
+		DeployedAt:       time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+
+	s, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	s.Set("us-east-1/app/profile/env", rec)
+	if err := s.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	got, ok := loaded.Get("us-east-1/app/profile/env")
+	if !ok {
+		t.Fatal("record missing after round trip")
+	}
+	if !got.DeployedAt.Equal(rec.DeployedAt) || got.Version != rec.Version || got.DeploymentNumber != rec.DeploymentNumber || got.ContentSHA256 != rec.ContentSHA256 {
+		t.Errorf("record = %+v, want %+v", got, rec)
+	}
+}
+
+func TestLoadInvalidFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), FileName)
+	if err := os.WriteFile(path, []byte("{not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Error("Load() expected error for invalid JSON")
+	}
+}
+
+func TestHash(t *testing.T) {
+	t.Parallel()
+
+	if Hash([]byte("a")) == Hash([]byte("b")) {
+		t.Error("different content must hash differently")
+	}
+	if got := Hash(nil); got != "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855" {
+		t.Errorf("Hash(nil) = %q", got)
+	}
+}
@@ -0,0 +1,118 @@
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/koh-sh/apcdeploy/internal/aws"
+	"github.com/koh-sh/apcdeploy/internal/bulk"
+	"github.com/koh-sh/apcdeploy/internal/config"
+)
+
+// stateNone is reported for targets with no deployment yet, matching the
+// single-target stdout payload.
+const stateNone = "NONE"
+
+// targetStatus is one row of the aggregated bulk status report.
+type targetStatus struct {
+	Target           string `json:"target"`
+	Application      string `json:"application"`
+	Profile          string `json:"profile"`
+	Environment      string `json:"environment"`
+	Region           string `json:"region"`
+	State            string `json:"state,omitempty"`
+	DeploymentNumber int32  `json:"deployment_number,omitempty"`
+	Version          string `json:"version,omitempty"`
+	Error            string `json:"error,omitempty"`
+}
+
+// ExecuteBulk checks the latest deployment of every target in
+// opts.TargetsFile concurrently.
+//
+// Each target gets its own Targets row. The aggregated report goes to
+// stdout: one "<id>\t<STATE>" line per target for text output, or a JSON
+// array of targetStatus for --output json. Targets whose lookup failed are
+// reported with an error and make the command return a non-nil error once
+// every row has finished.
+func (e *Executor) ExecuteBulk(ctx context.Context, opts *Options) error {
+	targets, err := bulk.Prepare(ctx, opts.TargetsFile, opts.Region, opts.Filter, e.clientFactory)
+	if err != nil {
+		return err
+	}
+
+	tg := e.reporter.Targets(bulk.IDs(targets))
+	defer tg.Close()
+
+	results := make([]targetStatus, len(targets))
+	bulk.Run(targets, opts.Concurrency, func(i int, t bulk.Target) {
+		res := targetStatus{
+			Target:      t.ID,
+			Application: t.Config.Application,
+			Profile:     t.Config.ConfigurationProfile,
+			Environment: t.Config.Environment,
+			Region:      t.Client.Region,
+		}
+		defer func() { results[i] = res }()
+
+		tg.SetPhase(t.ID, "fetching", "")
+		resources, err := aws.NewResolver(t.Client).ResolveAll(ctx, t.Config.Application, t.Config.ConfigurationProfile, t.Config.Environment, "")
+		if err != nil {
+			tg.Fail(t.ID, err)
+			res.Error = err.Error()
+			return
+		}
+		details, err := e.lookupDeployment(ctx, t.Client, resources, "")
+		if err != nil {
+			tg.Fail(t.ID, err)
+			res.Error = err.Error()
+			return
+		}
+		if details == nil {
+			tg.Skip(t.ID, "no deployment")
+			res.State = stateNone
+			return
+		}
+		tg.Done(t.ID, summarizeDeployment(details))
+		res.State = string(details.State)
+		res.DeploymentNumber = details.DeploymentNumber
+		res.Version = details.ConfigurationVersion
+	})
+	// Finalise the rows before the stdout payload so TTY redraws do not
+	// interleave with it.
+	tg.Close()
+
+	failed := 0
+	for _, r := range results {
+		if r.Error != "" {
+			failed++
+		}
+	}
+
+	if opts.Output == config.OutputFormatJSON {
+		out, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode status report: %w", err)
+		}
+		e.reporter.Data(append(out, '\n'))
+	} else {
+		e.reporter.Data([]byte(formatBulkText(results)))
+	}
+
+	return bulk.FailedError(failed, len(results))
+}
+
+// formatBulkText renders the text stdout payload: "<id>\t<STATE>" per
+// target, with "ERROR" for targets whose lookup failed.
+func formatBulkText(results []targetStatus) string {
+	var b strings.Builder
+	for _, r := range results {
+		state := r.State
+		if r.Error != "" {
+			state = "ERROR"
+		}
+		b.WriteString(r.Target + "\t" + state + "\n")
+	}
+	return b.String()
+}
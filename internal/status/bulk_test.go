@@ -0,0 +1,169 @@
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig/types"
+	awsInternal "github.com/koh-sh/apcdeploy/internal/aws"
+	"github.com/koh-sh/apcdeploy/internal/aws/mock"
+	"github.com/koh-sh/apcdeploy/internal/config"
+	reportertest "github.com/koh-sh/apcdeploy/internal/reporter/testing"
+)
+
+// newBulkMock returns a client with two environments: "prod" has a completed
+// deployment of version 3, "staging" has never been deployed.
+func newBulkMock() *mock.MockAppConfigClient {
+	now := time.Now()
+	m := mock.NewResolvingClient("AWS.Freeform")
+	m.ListEnvironmentsFunc = func(ctx context.Context, params *appconfig.ListEnvironmentsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListEnvironmentsOutput, error) {
+		return &appconfig.ListEnvironmentsOutput{
+			Items: []types.Environment{
+				{Id: aws.String("env-prod"), Name: aws.String("prod")},
+				{Id: aws.String("env-staging"), Name: aws.String("staging")},
+			},
+		}, nil
+	}
+	m.ListDeploymentsFunc = func(ctx context.Context, params *appconfig.ListDeploymentsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListDeploymentsOutput, error) {
+		if aws.ToString(params.EnvironmentId) != "env-prod" {
+			return &appconfig.ListDeploymentsOutput{}, nil
+		}
+		return &appconfig.ListDeploymentsOutput{
+			Items: []types.DeploymentSummary{{DeploymentNumber: 7, State: types.DeploymentStateComplete}},
+		}, nil
+	}
+	m.GetDeploymentFunc = func(ctx context.Context, params *appconfig.GetDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.GetDeploymentOutput, error) {
+		return &appconfig.GetDeploymentOutput{
+			DeploymentNumber:       7,
+			ConfigurationProfileId: aws.String("profile-123"),
+			ConfigurationVersion:   aws.String("3"),
+			DeploymentStrategyId:   aws.String("strategy-123"),
+			State:                  types.DeploymentStateComplete,
+			CompletedAt:            &now,
+		}, nil
+	}
+	return m
+}
+
+func writeBulkTargets(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "targets.yml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write targets file: %v", err)
+	}
+	return path
+}
+
+const bulkTargets = `- application: test-app
+  profile: test-profile
+  environment: prod
+- application: test-app
+  profile: test-profile
+  environment: staging
+- application: test-app
+  profile: test-profile
+  environment: missing
+`
+
+func TestExecuteBulk(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		output string
+		check  func(t *testing.T, stdout string)
+	}{
+		{
+			name:   "text output",
+			output: config.OutputFormatText,
+			check: func(t *testing.T, stdout string) {
+				want := "us-east-1/test-app/test-profile/prod\tCOMPLETE\n" +
+					"us-east-1/test-app/test-profile/staging\tNONE\n" +
+					"us-east-1/test-app/test-profile/missing\tERROR\n"
+				if stdout != want {
+					t.Errorf("stdout = %q, want %q", stdout, want)
+				}
+			},
+		},
+		{
+			name:   "json output",
+			output: config.OutputFormatJSON,
+			check: func(t *testing.T, stdout string) {
+				var got []targetStatus
+				if err := json.Unmarshal([]byte(stdout), &got); err != nil {
+					t.Fatalf("invalid JSON: %v\n%s", err, stdout)
+				}
+				if len(got) != 3 {
+					t.Fatalf("expected 3 rows, got %d", len(got))
+				}
+				if got[0].State != "COMPLETE" || got[0].Version != "3" || got[0].DeploymentNumber != 7 {
+					t.Errorf("unexpected prod row: %+v", got[0])
+				}
+				if got[1].State != stateNone {
+					t.Errorf("unexpected staging row: %+v", got[1])
+				}
+				if !strings.Contains(got[2].Error, "not found") {
+					t.Errorf("expected not-found error for missing env, got %+v", got[2])
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rep := &reportertest.MockReporter{}
+			executor := NewExecutorWithFactory(rep, func(ctx context.Context, region string) (*awsInternal.Client, error) {
+				return awsInternal.NewTestClient(newBulkMock()), nil
+			})
+
+			err := executor.ExecuteBulk(context.Background(), &Options{
+				TargetsFile: writeBulkTargets(t, bulkTargets),
+				Output:      tt.output,
+			})
+			if err == nil || err.Error() != "1 of 3 targets failed" {
+				t.Fatalf("expected aggregated failure, got %v", err)
+			}
+			tt.check(t, string(rep.Stdout))
+
+			if len(rep.TargetsCalls) != 1 || len(rep.TargetsCalls[0].IDs) != 3 {
+				t.Fatalf("expected one Targets block with 3 rows, got %+v", rep.TargetsCalls)
+			}
+			kinds := map[string]string{}
+			for _, tr := range rep.TargetsCalls[0].Transitions {
+				if tr.Kind != "phase" {
+					kinds[tr.ID] = tr.Kind
+				}
+			}
+			want := map[string]string{
+				"us-east-1/test-app/test-profile/prod":    "done",
+				"us-east-1/test-app/test-profile/staging": "skip",
+				"us-east-1/test-app/test-profile/missing": "fail",
+			}
+			for id, kind := range want {
+				if kinds[id] != kind {
+					t.Errorf("row %s: got %q, want %q", id, kinds[id], kind)
+				}
+			}
+		})
+	}
+}
+
+func TestExecuteBulkTargetsFileError(t *testing.T) {
+	t.Parallel()
+
+	rep := &reportertest.MockReporter{}
+	executor := NewExecutor(rep)
+	err := executor.ExecuteBulk(context.Background(), &Options{TargetsFile: "nonexistent.yml"})
+	if err == nil || !strings.Contains(err.Error(), "failed to read targets file") {
+		t.Fatalf("expected targets file error, got %v", err)
+	}
+}
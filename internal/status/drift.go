@@ -0,0 +1,93 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/koh-sh/apcdeploy/internal/aws"
+	"github.com/koh-sh/apcdeploy/internal/config"
+)
+
+// ErrDriftDetected is returned by status --check-drift when the local data
+// file no longer matches the deployed content, or the deployment used a
+// different strategy than the config file names.
+var ErrDriftDetected = errors.New("drift detected")
+
+// strategyDrift describes how the strategy deployment d used differs from
+// deployment_strategy in cfg, or returns "" when they match or the config
+// names no strategy. The resolved IDs are compared; the names shown come
+// from the resolver's ID-to-name mapping.
+//
+// Region and profile need no such check: the deployment was looked up with
+// the config's region and resolved profile, so they match by construction.
+func strategyDrift(cfg *config.Config, resources *aws.ResolvedResources, d *aws.DeploymentDetails) string {
+	if cfg.DeploymentStrategy == "" || d.DeploymentStrategyID == resources.DeploymentStrategyID {
+		return ""
+	}
+	return fmt.Sprintf("deployment_strategy is %s, but deployment #%d used %s", cfg.DeploymentStrategy, d.DeploymentNumber, d.DeploymentStrategyName)
+}
+
+// reportStrategyDrift prints a "Drift detected" block when deployment d used
+// a different strategy than the config file names, e.g. after a manual
+// deployment from the console.
+func (e *Executor) reportStrategyDrift(cfg *config.Config, resources *aws.ResolvedResources, d *aws.DeploymentDetails) {
+	msg := strategyDrift(cfg, resources, d)
+	if msg == "" {
+		return
+	}
+	e.reporter.Box("Drift detected", []string{
+		msg,
+		"Update deployment_strategy in the config file, or redeploy with it.",
+	})
+}
+
+// checkDrift compares the local data file with the content of the deployed
+// version after the same normalization diff uses, then the deployment's
+// strategy with deployment_strategy (skipped with opts.ContentOnly).
+// Descriptions and other metadata are never compared, so redeploying
+// unchanged data with a new description is not drift.
+//
+// d is the deployment status reported; without --deployment the version
+// compared is the one currently served, which skips rolled-back deployments.
+func (e *Executor) checkDrift(ctx context.Context, client *aws.Client, resources *aws.ResolvedResources, cfg *config.Config, d *aws.DeploymentDetails, opts *Options) error {
+	local, err := config.LoadDataFile(cfg.DataFile)
+	if err != nil {
+		return fmt.Errorf("failed to load local configuration file: %w", err)
+	}
+
+	version := d.ConfigurationVersion
+	if opts.DeploymentID == "" {
+		served, err := aws.GetLatestDeployment(ctx, client, resources.ApplicationID, resources.EnvironmentID, resources.Profile.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get latest deployment: %w", err)
+		}
+		if served == nil {
+			return fmt.Errorf("%w: every deployment of this profile was rolled back, so nothing is served to compare with", ErrDriftDetected)
+		}
+		version = served.ConfigurationVersion
+	}
+
+	remote, err := aws.GetHostedConfigurationVersion(ctx, client, resources.ApplicationID, resources.Profile.ID, version)
+	if err != nil {
+		return fmt.Errorf("failed to get deployed configuration: %w", err)
+	}
+
+	changed, err := config.HasContentChanged(remote, local, filepath.Ext(cfg.DataFile), resources.Profile.Type, cfg.TextNormalizeOptions())
+	if err != nil {
+		return fmt.Errorf("failed to compare content: %w", err)
+	}
+	if changed {
+		return fmt.Errorf("%w: %s differs from the content of v%s; run 'apcdeploy diff' to see the changes", ErrDriftDetected, cfg.DataFile, version)
+	}
+	if opts.ContentOnly {
+		e.reporter.Success(fmt.Sprintf("No drift: %s matches the content of v%s (content only)", cfg.DataFile, version))
+		return nil
+	}
+	if msg := strategyDrift(cfg, resources, d); msg != "" {
+		return fmt.Errorf("%w: %s", ErrDriftDetected, msg)
+	}
+	e.reporter.Success(fmt.Sprintf("No drift: %s matches the content of v%s and the strategy matches deployment_strategy", cfg.DataFile, version))
+	return nil
+}
@@ -0,0 +1,188 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig/types"
+	awsInternal "github.com/koh-sh/apcdeploy/internal/aws"
+	"github.com/koh-sh/apcdeploy/internal/aws/mock"
+	reportertest "github.com/koh-sh/apcdeploy/internal/reporter/testing"
+)
+
+// newDriftMock serves one target whose deployments (newest first) point at
+// the hosted versions in contents. Deployment and version descriptions
+// never match the local expectation.
+func newDriftMock(deployments []types.DeploymentSummary, versionOf map[int32]string, contents map[string]string) *mock.MockAppConfigClient {
+	m := mock.NewResolvingClient("AWS.Freeform")
+	m.ListDeploymentsFunc = func(ctx context.Context, params *appconfig.ListDeploymentsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListDeploymentsOutput, error) {
+		return &appconfig.ListDeploymentsOutput{Items: deployments}, nil
+	}
+	m.GetDeploymentFunc = func(ctx context.Context, params *appconfig.GetDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.GetDeploymentOutput, error) {
+		n := aws.ToInt32(params.DeploymentNumber)
+		state := types.DeploymentStateComplete
+		for _, d := range deployments {
+			if d.DeploymentNumber == n {
+				state = d.State
+			}
+		}
+		return &appconfig.GetDeploymentOutput{
+			DeploymentNumber:       n,
+			ConfigurationProfileId: aws.String("profile-123"),
+			ConfigurationVersion:   aws.String(versionOf[n]),
+			DeploymentStrategyId:   aws.String("strategy-123"),
+			State:                  state,
+			Description:            aws.String("deployed by someone else with a new description"),
+		}, nil
+	}
+	m.GetHostedConfigurationVersionFunc = func(ctx context.Context, params *appconfig.GetHostedConfigurationVersionInput, optFns ...func(*appconfig.Options)) (*appconfig.GetHostedConfigurationVersionOutput, error) {
+		content := contents[strconv.Itoa(int(aws.ToInt32(params.VersionNumber)))]
+		return &appconfig.GetHostedConfigurationVersionOutput{
+			Content:     []byte(content),
+			Description: aws.String("version description edited in the console"),
+		}, nil
+	}
+	m.ListHostedConfigurationVersionsFunc = func(ctx context.Context, params *appconfig.ListHostedConfigurationVersionsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListHostedConfigurationVersionsOutput, error) {
+		var items []types.HostedConfigurationVersionSummary
+		for v := range contents {
+			n, _ := strconv.Atoi(v)
+			items = append(items, types.HostedConfigurationVersionSummary{VersionNumber: int32(n), Description: aws.String("version description edited in the console")})
+		}
+		return &appconfig.ListHostedConfigurationVersionsOutput{Items: items}, nil
+	}
+	return m
+}
+
+func TestExecuteCheckDrift(t *testing.T) {
+	t.Parallel()
+
+	complete := []types.DeploymentSummary{{DeploymentNumber: 2, State: types.DeploymentStateComplete}, {DeploymentNumber: 1, State: types.DeploymentStateComplete}}
+	rolledBack := []types.DeploymentSummary{{DeploymentNumber: 2, State: types.DeploymentStateRolledBack}, {DeploymentNumber: 1, State: types.DeploymentStateComplete}}
+	versions := map[int32]string{1: "1", 2: "2"}
+
+	tests := []struct {
+		name        string
+		local       string
+		deployments []types.DeploymentSummary
+		contents    map[string]string
+		// strategy is the strategy ID the deployment used (default
+		// strategy-123, the config's AppConfig.AllAtOnce)
+		strategy    string
+		contentOnly bool
+		wantDrift   bool
+		wantMessage string
+	}{
+		{
+			name:        "only descriptions differ",
+			local:       `{"key": "value", "n": 1}`,
+			deployments: complete,
+			contents:    map[string]string{"2": "{\n  \"n\": 1,\n  \"key\": \"value\"\n}\n"},
+			wantMessage: "matches the content of v2",
+		},
+		{
+			name:        "content differs",
+			local:       `{"key": "changed"}`,
+			deployments: complete,
+			contents:    map[string]string{"2": `{"key": "value"}`},
+			wantDrift:   true,
+		},
+		{
+			name:        "rolled back deployment compares the served version",
+			local:       `{"key": "v1"}`,
+			deployments: rolledBack,
+			contents:    map[string]string{"1": `{"key": "v1"}`, "2": `{"key": "v2"}`},
+			wantMessage: "matches the content of v1",
+		},
+		{
+			name:        "strategy differs",
+			local:       `{"key": "value"}`,
+			deployments: complete,
+			contents:    map[string]string{"2": `{"key": "value"}`},
+			strategy:    "strategy-456",
+			wantDrift:   true,
+			wantMessage: "deployment_strategy is AppConfig.AllAtOnce, but deployment #2 used Manual.Linear",
+		},
+		{
+			name:        "strategy differs with content only",
+			local:       `{"key": "value"}`,
+			deployments: complete,
+			contents:    map[string]string{"2": `{"key": "value"}`},
+			strategy:    "strategy-456",
+			contentOnly: true,
+			wantMessage: "matches the content of v2 (content only)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			configPath := filepath.Join(dir, "apcdeploy.yml")
+			config := "application: test-app\nconfiguration_profile: test-profile\nenvironment: test-env\ndeployment_strategy: AppConfig.AllAtOnce\ndata_file: data.json\nregion: us-east-1\n"
+			if err := os.WriteFile(configPath, []byte(config), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(dir, "data.json"), []byte(tt.local), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			mockClient := newDriftMock(tt.deployments, versions, tt.contents)
+			if tt.strategy != "" {
+				mockClient.ListDeploymentStrategiesFunc = func(ctx context.Context, params *appconfig.ListDeploymentStrategiesInput, optFns ...func(*appconfig.Options)) (*appconfig.ListDeploymentStrategiesOutput, error) {
+					return &appconfig.ListDeploymentStrategiesOutput{Items: []types.DeploymentStrategy{
+						{Id: aws.String("strategy-123"), Name: aws.String("AppConfig.AllAtOnce")},
+						{Id: aws.String("strategy-456"), Name: aws.String("Manual.Linear")},
+					}}, nil
+				}
+				getDeployment := mockClient.GetDeploymentFunc
+				mockClient.GetDeploymentFunc = func(ctx context.Context, params *appconfig.GetDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.GetDeploymentOutput, error) {
+					out, err := getDeployment(ctx, params, optFns...)
+					out.DeploymentStrategyId = aws.String(tt.strategy)
+					return out, err
+				}
+			}
+			reporter := &reportertest.MockReporter{}
+			executor := NewExecutorWithFactory(reporter, func(ctx context.Context, region string) (*awsInternal.Client, error) {
+				return awsInternal.NewTestClient(mockClient), nil
+			})
+
+			err := executor.Execute(context.Background(), &Options{ConfigFile: configPath, CheckDrift: true, ContentOnly: tt.contentOnly})
+			if tt.strategy != "" {
+				if len(reporter.Boxes) != 1 || reporter.Boxes[0].Title != "Drift detected" {
+					t.Errorf("expected a Drift detected box, got %+v", reporter.Boxes)
+				}
+			} else if len(reporter.Boxes) != 0 {
+				t.Errorf("expected no box, got %+v", reporter.Boxes)
+			}
+			if tt.wantDrift {
+				if !errors.Is(err, ErrDriftDetected) {
+					t.Fatalf("expected ErrDriftDetected, got: %v", err)
+				}
+				if tt.wantMessage != "" && !strings.Contains(err.Error(), tt.wantMessage) {
+					t.Errorf("error = %v, want containing %q", err, tt.wantMessage)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no drift, got: %v", err)
+			}
+			found := false
+			for _, m := range reporter.Messages {
+				if strings.Contains(m, tt.wantMessage) {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("expected a message containing %q, got %v", tt.wantMessage, reporter.Messages)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package status
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"time"
@@ -45,11 +46,29 @@ func NewExecutorWithFactory(rep reporter.Reporter, factory func(context.Context,
 //   - no deployment: ⊘ no deployment on the Targets row, NONE on stdout, a
 //     short Box of next-step guidance on stderr, and aws.ErrNoDeployment as
 //     the returned error so cmd/root.go exits 2.
+//   - --output json: the table and the stdout state are replaced by one
+//     deploymentReport JSON document on stdout (state NONE when there is no
+//     deployment); the Targets row and warnings stay on stderr.
+//   - strategy drift: when the deployment used a different strategy than
+//     deployment_strategy, a "Drift detected" Box follows the table.
+//   - --check-drift: after the table, ✓ no drift, or ErrDriftDetected when
+//     the local data file differs from the deployed content or, unless
+//     --content-only, the strategy drifted.
+//   - --watch: a DEPLOYING/VALIDATING deployment is polled with a progress
+//     bar on the Targets row until it reaches COMPLETE, BAKING or a rollback
+//     state, then a summary line precedes the usual output. A rollback seen
+//     while watching fails the row and is returned as an error.
 func (e *Executor) Execute(ctx context.Context, opts *Options) error {
 	cfg, err := config.LoadConfig(opts.ConfigFile)
 	if err != nil {
 		return fmt.Errorf("failed to load configuration: %w", err)
 	}
+	cfg.ApplyRegionOverride(opts.Region)
+	if opts.CheckDrift {
+		if err := cfg.RequireDataFilePath("status --check-drift"); err != nil {
+			return err
+		}
+	}
 
 	awsClient, err := e.clientFactory(ctx, cfg.Region)
 	if err != nil {
@@ -72,19 +91,21 @@ func (e *Executor) Execute(ctx context.Context, opts *Options) error {
 		return fmt.Errorf("failed to resolve resources: %w", err)
 	}
 
-	var deploymentInfo *aws.DeploymentDetails
-	if opts.DeploymentID != "" {
-		deploymentInfo, err = e.getDeploymentByID(ctx, awsClient, resources, opts.DeploymentID)
-	} else {
-		deploymentInfo, err = e.getLatestDeployment(ctx, awsClient, resources)
-	}
+	deploymentInfo, err := e.lookupDeployment(ctx, awsClient, resources, opts.DeploymentID)
 	if err != nil {
+		err = aws.ExplainNotFound(err, cfg)
 		tg.Fail(id, err)
 		return fmt.Errorf("failed to get deployment: %w", err)
 	}
 
 	if deploymentInfo == nil {
 		tg.Skip(id, "no deployment")
+		if opts.Output == config.OutputFormatJSON {
+			if err := e.writeReport(newDeploymentReport(id, awsClient.Region, cfg, nil)); err != nil {
+				return err
+			}
+			return fmt.Errorf("status: %w", aws.ErrNoDeployment)
+		}
 		// stdout payload is fixed at "NONE\n" so scripts can branch on it
 		// (output.md §7.4 (b)). Always emitted, even under --silent.
 		e.reporter.Data([]byte("NONE\n"))
@@ -94,7 +115,30 @@ func (e *Executor) Execute(ctx context.Context, opts *Options) error {
 		})
 		return fmt.Errorf("status: %w", aws.ErrNoDeployment)
 	}
+	var watchErr error
+	if opts.Watch && isWatchable(deploymentInfo.State) {
+		deploymentInfo, err = e.watch(ctx, awsClient, resources, deploymentInfo, tg, id, opts.Timeout)
+		if err != nil {
+			tg.Fail(id, err)
+			return err
+		}
+		watchErr = rolledBackError(deploymentInfo)
+	}
+	tags, err := versionTags(ctx, awsClient, resources, deploymentInfo)
+	if err != nil {
+		// Tags only annotate the report; the deployment status still stands.
+		e.reporter.Warn(err.Error())
+	}
+	deploymentInfo.VersionTags = tags
+	deploymentInfo.Label, deploymentInfo.Description = aws.SplitDeploymentLabel(deploymentInfo.Description)
 
+	if watchErr != nil {
+		tg.Fail(id, watchErr)
+		if err := e.showDeployment(opts, id, awsClient.Region, deploymentInfo, cfg, resources); err != nil {
+			return err
+		}
+		return watchErr
+	}
 	tg.Done(id, summarizeDeployment(deploymentInfo))
 	// Two output systems intentionally coexist on the success path
 	// (output.md §11 Q-2 — to be revisited when status grows multi-target
@@ -105,10 +149,81 @@ func (e *Executor) Execute(ctx context.Context, opts *Options) error {
 	// In TTY mode the Targets renderer has already finalised by the time
 	// the table prints, so the two views stack cleanly without competing
 	// for the cursor.
-	display.DeploymentStatus(e.reporter, deploymentInfo, cfg, resources)
+	if err := e.showDeployment(opts, id, awsClient.Region, deploymentInfo, cfg, resources); err != nil {
+		return err
+	}
+	e.reportStrategyDrift(cfg, resources, deploymentInfo)
+	if opts.CheckDrift {
+		if err := e.checkDrift(ctx, awsClient, resources, cfg, deploymentInfo, opts); err != nil {
+			return err
+		}
+	}
+	if opts.DeploymentID != "" {
+		return nil
+	}
+	return e.checkVersionAge(deploymentInfo, cfg, opts)
+}
+
+// versionTags reads the --version-tag pairs recorded in the description of
+// the deployed hosted configuration version. Versions stored outside the
+// hosted store (S3, SSM, Secrets Manager) and hosted versions that have
+// since been deleted simply have no tags; any other failure, such as
+// missing permission to list versions, is returned.
+func versionTags(ctx context.Context, client *aws.Client, resources *aws.ResolvedResources, d *aws.DeploymentDetails) ([]aws.VersionTag, error) {
+	if _, err := strconv.ParseInt(d.ConfigurationVersion, 10, 32); err != nil {
+		return nil, nil
+	}
+	description, err := aws.GetHostedConfigurationVersionDescription(ctx, client, resources.ApplicationID, resources.Profile.ID, d.ConfigurationVersion)
+	if errors.Is(err, aws.ErrVersionNotFound) || aws.IsResourceNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read version tags: %w", err)
+	}
+	_, tags := aws.SplitVersionTags(description)
+	return tags, nil
+}
+
+// ErrVersionTooOld is returned by status --strict when the deployed version
+// is older than the max version age.
+var ErrVersionTooOld = errors.New("deployed version is older than the max version age")
+
+// checkVersionAge warns when the latest deployment completed longer ago than
+// opts.MaxVersionAge, falling back to max_version_age in the config. With
+// opts.Strict the warning becomes ErrVersionTooOld. Deployments that are
+// still running or were rolled back are not checked.
+func (e *Executor) checkVersionAge(d *aws.DeploymentDetails, cfg *config.Config, opts *Options) error {
+	limit := opts.MaxVersionAge
+	if limit == 0 && cfg.MaxVersionAge != "" {
+		// Already validated by LoadConfig.
+		limit, _ = config.ParseAge(cfg.MaxVersionAge)
+	}
+	if limit == 0 || d.State != types.DeploymentStateComplete || d.CompletedAt == nil {
+		return nil
+	}
+	age := time.Since(*d.CompletedAt)
+	if age <= limit {
+		return nil
+	}
+
+	msg := fmt.Sprintf("v%s was deployed %s ago, longer than the max version age of %s; consider redeploying or reviewing it", d.ConfigurationVersion, display.FormatAge(age), display.FormatAge(limit))
+	if opts.Strict {
+		return fmt.Errorf("%w: %s", ErrVersionTooOld, msg)
+	}
+	e.reporter.Warn(msg)
 	return nil
 }
 
+// lookupDeployment returns the deployment with the given number, or the
+// latest deployment for the profile when deploymentID is empty. A nil result
+// with a nil error means no deployment exists yet.
+func (e *Executor) lookupDeployment(ctx context.Context, client *aws.Client, resources *aws.ResolvedResources, deploymentID string) (*aws.DeploymentDetails, error) {
+	if deploymentID != "" {
+		return e.getDeploymentByID(ctx, client, resources, deploymentID)
+	}
+	return e.getLatestDeployment(ctx, client, resources)
+}
+
 // summarizeDeployment renders the post-icon Targets summary for a deployment.
 // Format: "<STATE> [<percent>%] — v<ConfigVersion>[ (<verb> <relative-time>)]"
 // per docs/design/output.md §7.4 (a)/(a').
@@ -167,7 +282,7 @@ func (e *Executor) getDeploymentByID(ctx context.Context, client *aws.Client, re
 	}
 
 	if deployment.ConfigurationProfileID != resources.Profile.ID {
-		return nil, fmt.Errorf("deployment #%d is not for configuration profile %s", deploymentNumber, resources.Profile.Name)
+		return nil, &aws.ProfileMismatchError{DeploymentNumber: int32(deploymentNumber), Profile: resources.Profile.Name}
 	}
 
 	resolver := aws.NewResolver(client)
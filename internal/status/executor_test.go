@@ -15,6 +15,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/appconfig/types"
 	awsInternal "github.com/koh-sh/apcdeploy/internal/aws"
 	"github.com/koh-sh/apcdeploy/internal/aws/mock"
+	"github.com/koh-sh/apcdeploy/internal/config"
 	reportertest "github.com/koh-sh/apcdeploy/internal/reporter/testing"
 )
 
@@ -268,7 +269,7 @@ region: us-east-1
 				ConfigurationVersion:   aws.String("1"),
 				DeploymentStrategyId:   aws.String("strategy-123"),
 				State:                  types.DeploymentStateComplete,
-				Description:            aws.String("Test deployment"),
+				Description:            aws.String("[release-1] Test deployment"),
 				StartedAt:              &now,
 				CompletedAt:            &now,
 				PercentageComplete:     aws.Float32(100),
@@ -276,6 +277,14 @@ region: us-east-1
 				FinalBakeTimeInMinutes: 0,
 			}, nil
 		},
+		ListHostedConfigurationVersionsFunc: func(ctx context.Context, params *appconfig.ListHostedConfigurationVersionsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListHostedConfigurationVersionsOutput, error) {
+			return &appconfig.ListHostedConfigurationVersionsOutput{
+				Items: []types.HostedConfigurationVersionSummary{
+					{VersionNumber: 2, Description: aws.String("newer")},
+					{VersionNumber: 1, Description: aws.String("Deployed by apcdeploy [apcdeploy-tags: build=123; commit=4f2a9c1]")},
+				},
+			}, nil
+		},
 	}
 
 	reporter := &reportertest.MockReporter{}
@@ -291,6 +300,22 @@ region: us-east-1
 	if err != nil {
 		t.Errorf("expected no error, got: %v", err)
 	}
+
+	want := map[string]string{
+		"Version Tags": "build=123, commit=4f2a9c1",
+		"Label":        "release-1",
+		"Description":  "Test deployment",
+	}
+	for _, table := range reporter.Tables {
+		for _, row := range table.Rows {
+			if want[row[0]] == row[1] {
+				delete(want, row[0])
+			}
+		}
+	}
+	if len(want) != 0 {
+		t.Errorf("expected rows %v, got tables %+v", want, reporter.Tables)
+	}
 }
 
 func TestGetDeploymentByIDInvalidID(t *testing.T) {
@@ -358,8 +383,9 @@ func TestGetDeploymentByIDWrongProfile(t *testing.T) {
 		t.Error("expected error for wrong profile")
 	}
 
-	if !strings.Contains(err.Error(), "not for configuration profile") {
-		t.Errorf("expected 'not for configuration profile' error, got: %v", err)
+	var mismatch *awsInternal.ProfileMismatchError
+	if !errors.As(err, &mismatch) || mismatch.DeploymentNumber != 1 {
+		t.Errorf("expected *ProfileMismatchError for deployment #1, got: %v", err)
 	}
 }
 
@@ -598,6 +624,10 @@ region: us-east-1
 				GrowthFactor:           aws.Float32(100),
 			}, nil
 		},
+		// A failed version tag lookup is reported but does not fail status
+		ListHostedConfigurationVersionsFunc: func(ctx context.Context, params *appconfig.ListHostedConfigurationVersionsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListHostedConfigurationVersionsOutput, error) {
+			return nil, errors.New("AccessDeniedException: not authorized")
+		},
 	}
 
 	reporter := &reportertest.MockReporter{}
@@ -629,6 +659,9 @@ region: us-east-1
 	if !foundDetail {
 		t.Errorf("expected Targets phase detail mentioning 'deployment #3'; got: %+v", reporter.TargetsCalls)
 	}
+	if !reporter.HasMessage("warn: failed to read version tags") {
+		t.Errorf("expected a warning about the version tag lookup; got: %v", reporter.Messages)
+	}
 }
 
 func TestExecutorAWSClientError(t *testing.T) {
@@ -795,6 +828,35 @@ func TestGetLatestDeploymentListError(t *testing.T) {
 	}
 }
 
+func TestExecutorEnvironmentDeleted(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "apcdeploy.yml")
+	cfg := "application: test-app\nconfiguration_profile: test-profile\nenvironment: test-env\ndeployment_strategy: AppConfig.AllAtOnce\ndata_file: data.json\nregion: us-east-1\n"
+	if err := os.WriteFile(configPath, []byte(cfg), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	mockClient := newDriftMock(nil, nil, nil)
+	mockClient.ListDeploymentsFunc = func(ctx context.Context, params *appconfig.ListDeploymentsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListDeploymentsOutput, error) {
+		return nil, &types.ResourceNotFoundException{Message: aws.String("Environment env-123 not found"), ResourceName: aws.String("Environment")}
+	}
+	executor := NewExecutorWithFactory(&reportertest.MockReporter{}, func(ctx context.Context, region string) (*awsInternal.Client, error) {
+		return awsInternal.NewTestClient(mockClient), nil
+	})
+
+	err := executor.Execute(context.Background(), &Options{ConfigFile: configPath})
+	var gone *awsInternal.ResourceGoneError
+	if !errors.As(err, &gone) {
+		t.Fatalf("Execute() error = %v, want *ResourceGoneError", err)
+	}
+	want := "environment 'test-env' no longer exists in application 'test-app'; run 'apcdeploy init' to regenerate config"
+	if !strings.Contains(err.Error(), want) {
+		t.Errorf("Execute() error = %q, want it to contain %q", err, want)
+	}
+}
+
 func TestGetLatestDeploymentNoMatchingProfile(t *testing.T) {
 	deploymentNumber := int32(1)
 	now := time.Now()
@@ -854,3 +916,97 @@ func TestGetLatestDeploymentNoMatchingProfile(t *testing.T) {
 		t.Error("expected nil deployment when no matching profile found")
 	}
 }
+
+// TestExecutorRegionOverride checks that --region takes precedence over the
+// config file's region when creating the AWS client.
+func TestExecutorRegionOverride(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "apcdeploy.yml")
+	configContent := `application: test-app
+configuration_profile: test-profile
+environment: test-env
+data_file: data.json
+region: us-east-1
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		override string
+		want     string
+	}{
+		{name: "flag overrides config", override: "eu-west-1", want: "eu-west-1"},
+		{name: "config region without flag", override: "", want: "us-east-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got string
+			factory := func(_ context.Context, region string) (*awsInternal.Client, error) {
+				got = region
+				return nil, errors.New("stop")
+			}
+			executor := NewExecutorWithFactory(&reportertest.MockReporter{}, factory)
+			_ = executor.Execute(context.Background(), &Options{ConfigFile: configPath, Region: tt.override})
+			if got != tt.want {
+				t.Errorf("region used = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckVersionAge(t *testing.T) {
+	t.Parallel()
+
+	old := time.Now().Add(-100 * 24 * time.Hour)
+	recent := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name      string
+		state     types.DeploymentState
+		completed *time.Time
+		flagAge   time.Duration
+		cfgAge    string
+		strict    bool
+		wantWarn  bool
+		wantErr   bool
+	}{
+		{name: "no threshold", state: types.DeploymentStateComplete, completed: &old},
+		{name: "recent version", state: types.DeploymentStateComplete, completed: &recent, flagAge: 90 * 24 * time.Hour},
+		{name: "old version warns", state: types.DeploymentStateComplete, completed: &old, flagAge: 90 * 24 * time.Hour, wantWarn: true},
+		{name: "config threshold", state: types.DeploymentStateComplete, completed: &old, cfgAge: "90d", wantWarn: true},
+		{name: "flag overrides config", state: types.DeploymentStateComplete, completed: &old, flagAge: 365 * 24 * time.Hour, cfgAge: "90d"},
+		{name: "strict fails", state: types.DeploymentStateComplete, completed: &old, cfgAge: "90d", strict: true, wantErr: true},
+		{name: "rolled back is not checked", state: types.DeploymentStateRolledBack, completed: &old, cfgAge: "90d"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rep := &reportertest.MockReporter{}
+			d := &awsInternal.DeploymentDetails{State: tt.state, CompletedAt: tt.completed, ConfigurationVersion: "3"}
+			cfg := &config.Config{MaxVersionAge: tt.cfgAge}
+			err := NewExecutor(rep).checkVersionAge(d, cfg, &Options{MaxVersionAge: tt.flagAge, Strict: tt.strict})
+
+			if got := errors.Is(err, ErrVersionTooOld); got != tt.wantErr {
+				t.Fatalf("errors.Is(err, ErrVersionTooOld) = %v, want %v (err: %v)", got, tt.wantErr, err)
+			}
+			warned := false
+			for _, m := range rep.Messages {
+				if strings.HasPrefix(m, "warn: v3 was deployed 100d") {
+					warned = true
+				}
+			}
+			if warned != tt.wantWarn {
+				t.Errorf("warned = %v, want %v (messages: %v)", warned, tt.wantWarn, rep.Messages)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig/types"
+	"github.com/koh-sh/apcdeploy/internal/aws"
+	"github.com/koh-sh/apcdeploy/internal/config"
+)
+
+// ExecuteFindVersion searches the hosted configuration versions of the
+// profile for descriptions containing opts.FindVersionByDescription.
+//
+// Output shape:
+//   - found: ✓ v<N> (deployed|not deployed) on the Targets row for the newest
+//     match, a Table of every match on stderr, and <N> on stdout.
+//   - no match: ✗ failed: no configuration version ... and a non-nil error.
+func (e *Executor) ExecuteFindVersion(ctx context.Context, opts *Options) error {
+	cfg, err := config.LoadConfig(opts.ConfigFile)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	cfg.ApplyRegionOverride(opts.Region)
+
+	awsClient, err := e.clientFactory(ctx, cfg.Region)
+	if err != nil {
+		return fmt.Errorf("failed to initialize AWS client: %w", err)
+	}
+
+	id := config.Identifier(awsClient.Region, cfg)
+	tg := e.reporter.Targets([]string{id})
+	defer tg.Close()
+	tg.SetPhase(id, "searching", fmt.Sprintf("(description contains %q)", opts.FindVersionByDescription))
+
+	resolver := aws.NewResolver(awsClient)
+	resources, err := resolver.ResolveAll(ctx, cfg.Application, cfg.ConfigurationProfile, cfg.Environment, "")
+	if err != nil {
+		tg.Fail(id, err)
+		return fmt.Errorf("failed to resolve resources: %w", err)
+	}
+
+	versions, err := awsClient.ListAllHostedConfigurationVersions(ctx, resources.ApplicationID, resources.Profile.ID)
+	if err != nil {
+		tg.Fail(id, err)
+		return err
+	}
+	matches := matchVersionsByDescription(versions, opts.FindVersionByDescription)
+	if len(matches) == 0 {
+		err := fmt.Errorf("no configuration version description contains %q", opts.FindVersionByDescription)
+		tg.Fail(id, err)
+		return err
+	}
+
+	// "Currently deployed" follows run/pull: the latest deployment that was
+	// not rolled back.
+	deployed, err := aws.GetLatestDeployment(ctx, awsClient, resources.ApplicationID, resources.EnvironmentID, resources.Profile.ID)
+	if err != nil {
+		tg.Fail(id, err)
+		return fmt.Errorf("failed to get latest deployment: %w", err)
+	}
+	deployedVersion := ""
+	if deployed != nil {
+		deployedVersion = deployed.ConfigurationVersion
+	}
+
+	rows := make([][]string, 0, len(matches))
+	for _, v := range matches {
+		rows = append(rows, []string{
+			strconv.Itoa(int(v.VersionNumber)),
+			deployedLabel(v.VersionNumber, deployedVersion),
+			awssdk.ToString(v.Description),
+		})
+	}
+
+	newest := matches[0]
+	tg.Done(id, fmt.Sprintf("v%d (%s)", newest.VersionNumber, deployedLabel(newest.VersionNumber, deployedVersion)))
+	tg.Close()
+	e.reporter.Table([]string{"Version", "Deployed", "Description"}, rows)
+	e.reporter.Data([]byte(strconv.Itoa(int(newest.VersionNumber)) + "\n"))
+	return nil
+}
+
+// matchVersionsByDescription returns the versions whose description contains
+// substr, newest (highest version number) first.
+func matchVersionsByDescription(versions []types.HostedConfigurationVersionSummary, substr string) []types.HostedConfigurationVersionSummary {
+	var matches []types.HostedConfigurationVersionSummary
+	for _, v := range versions {
+		if v.Description != nil && strings.Contains(*v.Description, substr) {
+			matches = append(matches, v)
+		}
+	}
+	slices.SortFunc(matches, func(a, b types.HostedConfigurationVersionSummary) int {
+		return int(b.VersionNumber) - int(a.VersionNumber)
+	})
+	return matches
+}
+
+// deployedLabel reports whether version is the one currently deployed.
+func deployedLabel(version int32, deployedVersion string) string {
+	if strconv.Itoa(int(version)) == deployedVersion {
+		return "deployed"
+	}
+	return "not deployed"
+}
@@ -0,0 +1,151 @@
+package status
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig/types"
+	awsInternal "github.com/koh-sh/apcdeploy/internal/aws"
+	"github.com/koh-sh/apcdeploy/internal/aws/mock"
+	reportertest "github.com/koh-sh/apcdeploy/internal/reporter/testing"
+)
+
+// newFindVersionMock serves hosted versions 1-4 across two pages, with
+// version deployedVersion currently deployed.
+func newFindVersionMock(deployedVersion string) *mock.MockAppConfigClient {
+	pages := map[string]*appconfig.ListHostedConfigurationVersionsOutput{
+		"": {
+			Items: []types.HostedConfigurationVersionSummary{
+				{VersionNumber: 1, Description: aws.String("release-2024.01")},
+				{VersionNumber: 2, Description: aws.String("hotfix for release-2024.01")},
+			},
+			NextToken: aws.String("page2"),
+		},
+		"page2": {
+			Items: []types.HostedConfigurationVersionSummary{
+				{VersionNumber: 3, Description: aws.String("release-2024.02")},
+				{VersionNumber: 4},
+			},
+		},
+	}
+	m := mock.NewResolvingClient("AWS.Freeform")
+	m.ListHostedConfigurationVersionsFunc = func(ctx context.Context, params *appconfig.ListHostedConfigurationVersionsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListHostedConfigurationVersionsOutput, error) {
+		return pages[aws.ToString(params.NextToken)], nil
+	}
+	m.ListDeploymentsFunc = func(ctx context.Context, params *appconfig.ListDeploymentsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListDeploymentsOutput, error) {
+		if deployedVersion == "" {
+			return &appconfig.ListDeploymentsOutput{}, nil
+		}
+		return &appconfig.ListDeploymentsOutput{
+			Items: []types.DeploymentSummary{{DeploymentNumber: 1, State: types.DeploymentStateComplete}},
+		}, nil
+	}
+	m.GetDeploymentFunc = func(ctx context.Context, params *appconfig.GetDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.GetDeploymentOutput, error) {
+		return &appconfig.GetDeploymentOutput{
+			DeploymentNumber:       1,
+			ConfigurationProfileId: aws.String("profile-123"),
+			ConfigurationVersion:   aws.String(deployedVersion),
+			State:                  types.DeploymentStateComplete,
+		}, nil
+	}
+	return m
+}
+
+func TestExecuteFindVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		substr          string
+		deployedVersion string
+		wantErr         bool
+		wantSummary     string
+		wantStdout      string
+		wantRows        int
+	}{
+		{
+			name:            "newest match across pages is deployed",
+			substr:          "release-2024",
+			deployedVersion: "3",
+			wantSummary:     "v3 (deployed)",
+			wantStdout:      "3\n",
+			wantRows:        3,
+		},
+		{
+			name:            "match that is not deployed",
+			substr:          "hotfix",
+			deployedVersion: "3",
+			wantSummary:     "v2 (not deployed)",
+			wantStdout:      "2\n",
+			wantRows:        1,
+		},
+		{
+			name:        "nothing deployed yet",
+			substr:      "release-2024.01",
+			wantSummary: "v2 (not deployed)",
+			wantStdout:  "2\n",
+			wantRows:    2,
+		},
+		{
+			name:            "no match",
+			substr:          "release-2099",
+			deployedVersion: "3",
+			wantErr:         true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			configPath := filepath.Join(t.TempDir(), "apcdeploy.yml")
+			configContent := `application: test-app
+configuration_profile: test-profile
+environment: test-env
+data_file: data.json
+region: us-east-1
+`
+			if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+
+			mockClient := newFindVersionMock(tt.deployedVersion)
+			reporter := &reportertest.MockReporter{}
+			executor := NewExecutorWithFactory(reporter, func(ctx context.Context, region string) (*awsInternal.Client, error) {
+				return awsInternal.NewTestClient(mockClient), nil
+			})
+
+			err := executor.ExecuteFindVersion(context.Background(), &Options{ConfigFile: configPath, FindVersionByDescription: tt.substr})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ExecuteFindVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if !strings.Contains(err.Error(), tt.substr) {
+					t.Errorf("error should mention the search text, got: %v", err)
+				}
+				return
+			}
+
+			if got := string(reporter.Stdout); got != tt.wantStdout {
+				t.Errorf("stdout = %q, want %q", got, tt.wantStdout)
+			}
+			found := false
+			for _, tr := range reporter.TargetsCalls[0].Transitions {
+				if tr.Kind == "done" && tr.Summary == tt.wantSummary {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("expected done summary %q, got: %+v", tt.wantSummary, reporter.TargetsCalls[0].Transitions)
+			}
+			if len(reporter.Tables) != 1 || len(reporter.Tables[0].Rows) != tt.wantRows {
+				t.Fatalf("expected one table with %d rows, got: %+v", tt.wantRows, reporter.Tables)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/appconfig/types"
+	"github.com/koh-sh/apcdeploy/internal/aws"
+	"github.com/koh-sh/apcdeploy/internal/config"
+	"github.com/koh-sh/apcdeploy/internal/display"
+)
+
+// DefaultHistoryLimit is the number of deployments --history lists when no
+// count is given.
+const DefaultHistoryLimit = 10
+
+// ExecuteHistory lists the last opts.History deployments of the profile to
+// the configured environment, newest first.
+//
+// Output shape:
+//   - found: ✓ <N> deployments on the Targets row and a Table of them on
+//     stderr (number, state, version, strategy, started, percentage).
+//   - --output json: a JSON array of deploymentReport documents on stdout
+//     instead of the table.
+//   - none: ⊘ no deployment on the Targets row ("[]" with --output json)
+//     and aws.ErrNoDeployment as the returned error, as in Execute.
+func (e *Executor) ExecuteHistory(ctx context.Context, opts *Options) error {
+	cfg, err := config.LoadConfig(opts.ConfigFile)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	cfg.ApplyRegionOverride(opts.Region)
+
+	awsClient, err := e.clientFactory(ctx, cfg.Region)
+	if err != nil {
+		return fmt.Errorf("failed to initialize AWS client: %w", err)
+	}
+
+	id := config.Identifier(awsClient.Region, cfg)
+	tg := e.reporter.Targets([]string{id})
+	defer tg.Close()
+	tg.SetPhase(id, "fetching", fmt.Sprintf("(last %d deployments)", opts.History))
+
+	resolver := aws.NewResolver(awsClient)
+	resources, err := resolver.ResolveAll(ctx, cfg.Application, cfg.ConfigurationProfile, cfg.Environment, "")
+	if err != nil {
+		tg.Fail(id, err)
+		return fmt.Errorf("failed to resolve resources: %w", err)
+	}
+
+	history, err := e.getDeploymentHistory(ctx, awsClient, resources, opts.History, opts.Since)
+	if err != nil {
+		tg.Fail(id, err)
+		return fmt.Errorf("failed to get deployment history: %w", err)
+	}
+
+	if len(history) == 0 {
+		tg.Skip(id, "no deployment")
+	} else {
+		tg.Done(id, fmt.Sprintf("%d deployments", len(history)))
+	}
+	tg.Close()
+
+	if opts.Output == config.OutputFormatJSON {
+		reports := make([]deploymentReport, 0, len(history))
+		for _, d := range history {
+			reports = append(reports, newDeploymentReport(id, awsClient.Region, cfg, d))
+		}
+		out, err := json.MarshalIndent(reports, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode status report: %w", err)
+		}
+		e.reporter.Data(append(out, '\n'))
+	} else if len(history) > 0 {
+		display.DeploymentHistory(e.reporter, history)
+	}
+	if len(history) == 0 {
+		return fmt.Errorf("status: %w", aws.ErrNoDeployment)
+	}
+	return nil
+}
+
+// getDeploymentHistory returns up to limit deployments of the resolved
+// profile, highest deployment number first. ListDeployments is paginated
+// in full, but its summaries carry no profile ID, so GetDeployment is called
+// newest first only until limit deployments of the profile are found.
+// Strategy IDs are named through the client's cached strategy list.
+//
+// A non-zero since drops deployments that started before it, or that have
+// no start time, using the summaries' StartedAt before any GetDeployment.
+func (e *Executor) getDeploymentHistory(ctx context.Context, client *aws.Client, resources *aws.ResolvedResources, limit int, since time.Time) ([]*aws.DeploymentDetails, error) {
+	summaries, err := client.ListAllDeployments(ctx, resources.ApplicationID, resources.EnvironmentID)
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(summaries, func(a, b types.DeploymentSummary) int {
+		return int(b.DeploymentNumber) - int(a.DeploymentNumber)
+	})
+
+	resolver := aws.NewResolver(client)
+	var history []*aws.DeploymentDetails
+	for _, s := range summaries {
+		if len(history) == limit {
+			break
+		}
+		if !since.IsZero() && (s.StartedAt == nil || s.StartedAt.Before(since)) {
+			continue
+		}
+		details, err := aws.GetDeploymentDetails(ctx, client, resources.ApplicationID, resources.EnvironmentID, s.DeploymentNumber)
+		if err != nil {
+			return nil, err
+		}
+		if details.ConfigurationProfileID != resources.Profile.ID {
+			continue
+		}
+		name, err := resolver.ResolveDeploymentStrategyIDToName(ctx, details.DeploymentStrategyID)
+		if err != nil {
+			name = details.DeploymentStrategyID
+		}
+		details.DeploymentStrategyName = name
+		history = append(history, details)
+	}
+	return history, nil
+}
@@ -0,0 +1,155 @@
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig/types"
+	awsInternal "github.com/koh-sh/apcdeploy/internal/aws"
+	"github.com/koh-sh/apcdeploy/internal/aws/mock"
+	"github.com/koh-sh/apcdeploy/internal/config"
+	reportertest "github.com/koh-sh/apcdeploy/internal/reporter/testing"
+)
+
+// historyStart is when deployment 1 of newHistoryMock would have started;
+// deployment n starts n-1 hours later.
+var historyStart = time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+// newHistoryMock serves deployments 1-4 of env-123 across two pages, out of
+// order; deployment 3 is for another profile and deployment 1 has no start
+// time in its summary.
+func newHistoryMock(empty bool) *mock.MockAppConfigClient {
+	started := func(n int) *time.Time {
+		t := historyStart.Add(time.Duration(n-1) * time.Hour)
+		return &t
+	}
+	m := newDriftMock(nil, nil, nil)
+	m.ListDeploymentsFunc = func(ctx context.Context, params *appconfig.ListDeploymentsInput, optFns ...func(*appconfig.Options)) (*appconfig.ListDeploymentsOutput, error) {
+		if empty {
+			return &appconfig.ListDeploymentsOutput{}, nil
+		}
+		if params.NextToken == nil {
+			return &appconfig.ListDeploymentsOutput{
+				Items:     []types.DeploymentSummary{{DeploymentNumber: 2, StartedAt: started(2)}, {DeploymentNumber: 4, StartedAt: started(4)}},
+				NextToken: aws.String("page2"),
+			}, nil
+		}
+		return &appconfig.ListDeploymentsOutput{Items: []types.DeploymentSummary{{DeploymentNumber: 1}, {DeploymentNumber: 3, StartedAt: started(3)}}}, nil
+	}
+	m.GetDeploymentFunc = func(ctx context.Context, params *appconfig.GetDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.GetDeploymentOutput, error) {
+		n := aws.ToInt32(params.DeploymentNumber)
+		profile, state := "profile-123", types.DeploymentStateComplete
+		if n == 3 {
+			profile = "profile-other"
+		}
+		if n == 4 {
+			state = types.DeploymentStateDeploying
+		}
+		return &appconfig.GetDeploymentOutput{
+			DeploymentNumber:       n,
+			ConfigurationProfileId: aws.String(profile),
+			ConfigurationVersion:   aws.String(strconv.Itoa(int(n))),
+			DeploymentStrategyId:   aws.String("strategy-123"),
+			State:                  state,
+			PercentageComplete:     aws.Float32(100),
+		}, nil
+	}
+	return m
+}
+
+func TestExecuteHistory(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		limit       int
+		since       time.Time
+		output      string
+		empty       bool
+		wantNumbers []string
+		wantJSON    []int32
+		wantErr     error
+	}{
+		{name: "newest first, other profiles skipped", limit: 10, wantNumbers: []string{"4", "2", "1"}},
+		{name: "limited", limit: 2, wantNumbers: []string{"4", "2"}},
+		{name: "since drops older and undated deployments", limit: 10, since: historyStart.Add(time.Hour), wantNumbers: []string{"4", "2"}},
+		{name: "since after every deployment", limit: 10, since: historyStart.Add(24 * time.Hour), wantErr: awsInternal.ErrNoDeployment},
+		{name: "json array", limit: 10, output: config.OutputFormatJSON, wantJSON: []int32{4, 2, 1}},
+		{name: "no deployment", limit: 10, empty: true, wantErr: awsInternal.ErrNoDeployment},
+		{name: "no deployment json", limit: 10, output: config.OutputFormatJSON, empty: true, wantJSON: []int32{}, wantErr: awsInternal.ErrNoDeployment},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			configPath := filepath.Join(dir, "apcdeploy.yml")
+			cfg := "application: test-app\nconfiguration_profile: test-profile\nenvironment: test-env\ndeployment_strategy: AppConfig.AllAtOnce\ndata_file: data.json\nregion: us-east-1\n"
+			if err := os.WriteFile(configPath, []byte(cfg), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			reporter := &reportertest.MockReporter{}
+			executor := NewExecutorWithFactory(reporter, func(ctx context.Context, region string) (*awsInternal.Client, error) {
+				return awsInternal.NewTestClient(newHistoryMock(tt.empty)), nil
+			})
+
+			err := executor.ExecuteHistory(context.Background(), &Options{ConfigFile: configPath, History: tt.limit, Since: tt.since, Output: tt.output})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("ExecuteHistory() error = %v, want %v", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("ExecuteHistory() error = %v", err)
+			}
+
+			if tt.wantJSON != nil {
+				var reports []deploymentReport
+				if err := json.Unmarshal(reporter.Stdout, &reports); err != nil {
+					t.Fatalf("stdout is not a JSON array: %v\n%s", err, reporter.Stdout)
+				}
+				got := []int32{}
+				for _, r := range reports {
+					got = append(got, r.DeploymentNumber)
+				}
+				if !reflect.DeepEqual(got, tt.wantJSON) {
+					t.Errorf("deployment numbers = %v, want %v", got, tt.wantJSON)
+				}
+				if len(reporter.Tables) != 0 {
+					t.Errorf("expected no table with --output json, got %+v", reporter.Tables)
+				}
+				return
+			}
+
+			if tt.wantNumbers == nil {
+				if len(reporter.Tables) != 0 {
+					t.Errorf("expected no table, got %+v", reporter.Tables)
+				}
+				return
+			}
+			if len(reporter.Tables) != 1 {
+				t.Fatalf("expected one table, got %+v", reporter.Tables)
+			}
+			var got []string
+			for _, row := range reporter.Tables[0].Rows {
+				got = append(got, row[0])
+				if row[3] != "AppConfig.AllAtOnce" {
+					t.Errorf("strategy = %q, want the resolved name", row[3])
+				}
+			}
+			if !reflect.DeepEqual(got, tt.wantNumbers) {
+				t.Errorf("deployment numbers = %v, want %v", got, tt.wantNumbers)
+			}
+		})
+	}
+}
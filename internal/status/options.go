@@ -1,11 +1,61 @@
 package status
 
+import (
+	"time"
+
+	"github.com/koh-sh/apcdeploy/internal/config"
+)
+
 // Options contains the configuration for status operation
 type Options struct {
 	// ConfigFile is the path to the apcdeploy configuration file
 	ConfigFile string
 	// DeploymentID is the deployment number to check (optional, defaults to latest)
 	DeploymentID string
+	// TargetsFile is the path to a --profiles-from-file targets list. When
+	// set, ConfigFile is ignored and every listed target is checked.
+	TargetsFile string
+	// Filter keeps only the TargetsFile entries matching --app and
+	// --profile-regex (nil keeps all)
+	Filter *config.ProfileFilter
+	// Concurrency bounds how many TargetsFile entries are processed at once
+	// (--concurrency; below 1 uses bulk.DefaultConcurrency)
+	Concurrency int
+	// FindVersionByDescription searches hosted configuration versions for a
+	// description containing this substring instead of reporting a deployment
+	FindVersionByDescription string
+	// Output is the stdout format ("text" or "json")
+	Output string
 	// Silent indicates whether to suppress verbose output
 	Silent bool
+	// Region overrides the region from the config file (--region)
+	Region string
+	// MaxVersionAge warns when the latest deployment completed longer ago
+	// than this; zero falls back to max_version_age in the config
+	// (--max-version-age)
+	MaxVersionAge time.Duration
+	// Strict turns the MaxVersionAge warning into an error (--strict)
+	Strict bool
+	// CheckDrift compares the local data file with the deployed content, and
+	// the deployment's strategy with deployment_strategy, and fails with
+	// ErrDriftDetected when either differs (--check-drift). Metadata such as
+	// descriptions never counts.
+	CheckDrift bool
+	// ContentOnly limits CheckDrift to the content (--content-only)
+	ContentOnly bool
+	// TUI renders a live-updating dashboard instead of a one-shot report
+	TUI bool
+	// RefreshInterval is how often the TUI re-fetches every target
+	RefreshInterval time.Duration
+	// Watch polls an in-progress deployment until it completes, starts
+	// baking or rolls back (--watch)
+	Watch bool
+	// Timeout bounds how long Watch polls
+	Timeout time.Duration
+	// History lists this many recent deployments of the profile instead of
+	// reporting one (--history); zero reports a single deployment
+	History int
+	// Since keeps only History deployments started at or after this time
+	// (--since); the zero time keeps all
+	Since time.Time
 }
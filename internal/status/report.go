@@ -0,0 +1,89 @@
+package status
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/koh-sh/apcdeploy/internal/aws"
+	"github.com/koh-sh/apcdeploy/internal/config"
+	"github.com/koh-sh/apcdeploy/internal/display"
+)
+
+// deploymentReport is the single-target --output json document. Field names
+// follow targetStatus so bulk and single-target consumers share parsing.
+type deploymentReport struct {
+	Target                 string     `json:"target"`
+	Application            string     `json:"application"`
+	Profile                string     `json:"profile"`
+	Environment            string     `json:"environment"`
+	Region                 string     `json:"region"`
+	State                  string     `json:"state"`
+	DeploymentNumber       int32      `json:"deployment_number,omitempty"`
+	Version                string     `json:"version,omitempty"`
+	Strategy               string     `json:"strategy,omitempty"`
+	Label                  string     `json:"label,omitempty"`
+	PercentageComplete     float32    `json:"percentage_complete"`
+	GrowthFactor           float32    `json:"growth_factor"`
+	FinalBakeTimeInMinutes int32      `json:"final_bake_time_minutes"`
+	StartedAt              *time.Time `json:"started_at,omitempty"`
+	CompletedAt            *time.Time `json:"completed_at,omitempty"`
+}
+
+// newDeploymentReport builds the JSON document for d, or for a target that
+// has never been deployed when d is nil.
+func newDeploymentReport(id, region string, cfg *config.Config, d *aws.DeploymentDetails) deploymentReport {
+	report := deploymentReport{
+		Target:      id,
+		Application: cfg.Application,
+		Profile:     cfg.ConfigurationProfile,
+		Environment: cfg.Environment,
+		Region:      region,
+		State:       stateNone,
+	}
+	if d == nil {
+		return report
+	}
+	report.State = string(d.State)
+	report.DeploymentNumber = d.DeploymentNumber
+	report.Version = d.ConfigurationVersion
+	report.Strategy = d.DeploymentStrategyName
+	report.Label = d.Label
+	report.PercentageComplete = d.PercentageComplete
+	report.GrowthFactor = d.GrowthFactor
+	report.FinalBakeTimeInMinutes = d.FinalBakeTimeInMinutes
+	report.StartedAt = utc(d.StartedAt)
+	report.CompletedAt = utc(d.CompletedAt)
+	return report
+}
+
+// utc returns t in UTC so the JSON timestamps do not depend on the local
+// time zone.
+func utc(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	u := t.UTC()
+	return &u
+}
+
+// writeReport emits a single-target deploymentReport as the JSON stdout
+// payload.
+func (e *Executor) writeReport(report deploymentReport) error {
+	out, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode status report: %w", err)
+	}
+	e.reporter.Data(append(out, '\n'))
+	return nil
+}
+
+// showDeployment writes the report for a found deployment: the JSON
+// document for --output json, otherwise display.DeploymentStatus.
+func (e *Executor) showDeployment(opts *Options, id, region string, d *aws.DeploymentDetails, cfg *config.Config, resources *aws.ResolvedResources) error {
+	if opts.Output == config.OutputFormatJSON {
+		return e.writeReport(newDeploymentReport(id, region, cfg, d))
+	}
+	display.DeploymentStatus(e.reporter, d, cfg, resources)
+	return nil
+}
@@ -0,0 +1,165 @@
+package status
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig/types"
+	awsInternal "github.com/koh-sh/apcdeploy/internal/aws"
+	"github.com/koh-sh/apcdeploy/internal/cli"
+	"github.com/koh-sh/apcdeploy/internal/config"
+	reportertest "github.com/koh-sh/apcdeploy/internal/reporter/testing"
+)
+
+func TestExecuteJSONOutput(t *testing.T) {
+	t.Parallel()
+
+	started := time.Date(2026, 3, 1, 9, 0, 0, 0, time.FixedZone("JST", 9*60*60))
+	completed := started.Add(10 * time.Minute)
+
+	tests := []struct {
+		name        string
+		deployments []types.DeploymentSummary
+		want        string
+		wantErr     error
+	}{
+		{
+			name:        "deployment",
+			deployments: []types.DeploymentSummary{{DeploymentNumber: 4, State: types.DeploymentStateComplete}},
+			want: `{
+  "target": "us-east-1/test-app/test-profile/test-env",
+  "application": "test-app",
+  "profile": "test-profile",
+  "environment": "test-env",
+  "region": "us-east-1",
+  "state": "COMPLETE",
+  "deployment_number": 4,
+  "version": "7",
+  "strategy": "AppConfig.AllAtOnce",
+  "percentage_complete": 100,
+  "growth_factor": 20,
+  "final_bake_time_minutes": 10,
+  "started_at": "2026-03-01T00:00:00Z",
+  "completed_at": "2026-03-01T00:10:00Z"
+}
+`,
+		},
+		{
+			name: "no deployment",
+			want: `{
+  "target": "us-east-1/test-app/test-profile/test-env",
+  "application": "test-app",
+  "profile": "test-profile",
+  "environment": "test-env",
+  "region": "us-east-1",
+  "state": "NONE",
+  "percentage_complete": 0,
+  "growth_factor": 0,
+  "final_bake_time_minutes": 0
+}
+`,
+			wantErr: awsInternal.ErrNoDeployment,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			configPath := filepath.Join(dir, "apcdeploy.yml")
+			cfg := "application: test-app\nconfiguration_profile: test-profile\nenvironment: test-env\ndeployment_strategy: AppConfig.AllAtOnce\ndata_file: data.json\nregion: us-east-1\n"
+			if err := os.WriteFile(configPath, []byte(cfg), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			mockClient := newDriftMock(tt.deployments, nil, nil)
+			mockClient.GetDeploymentFunc = func(ctx context.Context, params *appconfig.GetDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.GetDeploymentOutput, error) {
+				return &appconfig.GetDeploymentOutput{
+					DeploymentNumber:       4,
+					ConfigurationProfileId: aws.String("profile-123"),
+					ConfigurationVersion:   aws.String("7"),
+					DeploymentStrategyId:   aws.String("strategy-123"),
+					State:                  types.DeploymentStateComplete,
+					PercentageComplete:     aws.Float32(100),
+					GrowthFactor:           aws.Float32(20),
+					FinalBakeTimeInMinutes: 10,
+					StartedAt:              &started,
+					CompletedAt:            &completed,
+				}, nil
+			}
+			reporter := &reportertest.MockReporter{}
+			executor := NewExecutorWithFactory(reporter, func(ctx context.Context, region string) (*awsInternal.Client, error) {
+				return awsInternal.NewTestClient(mockClient), nil
+			})
+
+			err := executor.Execute(context.Background(), &Options{ConfigFile: configPath, Output: config.OutputFormatJSON})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+
+			if got := string(reporter.Stdout); got != tt.want {
+				t.Errorf("stdout = %s, want %s", got, tt.want)
+			}
+			if !json.Valid(reporter.Stdout) {
+				t.Error("stdout is not a single JSON document")
+			}
+			if len(reporter.Tables) != 0 {
+				t.Errorf("expected no human-readable table, got %+v", reporter.Tables)
+			}
+		})
+	}
+}
+
+func TestExecuteRenderedOutput(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "apcdeploy.yml")
+	cfg := "application: test-app\nconfiguration_profile: test-profile\nenvironment: test-env\ndeployment_strategy: AppConfig.AllAtOnce\ndata_file: data.json\nregion: us-east-1\n"
+	if err := os.WriteFile(configPath, []byte(cfg), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	mockClient := newDriftMock([]types.DeploymentSummary{{DeploymentNumber: 4, State: types.DeploymentStateComplete}}, nil, nil)
+	mockClient.GetDeploymentFunc = func(ctx context.Context, params *appconfig.GetDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.GetDeploymentOutput, error) {
+		return &appconfig.GetDeploymentOutput{
+			DeploymentNumber:       4,
+			ConfigurationProfileId: aws.String("profile-123"),
+			ConfigurationVersion:   aws.String("7"),
+			DeploymentStrategyId:   aws.String("strategy-123"),
+			State:                  types.DeploymentStateComplete,
+			PercentageComplete:     aws.Float32(100),
+		}, nil
+	}
+	var stdout, stderr bytes.Buffer
+	executor := NewExecutorWithFactory(cli.NewReporterWithWriters(&stdout, &stderr), func(ctx context.Context, region string) (*awsInternal.Client, error) {
+		return awsInternal.NewTestClient(mockClient), nil
+	})
+
+	if err := executor.Execute(context.Background(), &Options{ConfigFile: configPath}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if got := stdout.String(); got != "COMPLETE\n" {
+		t.Errorf("stdout = %q, want %q", got, "COMPLETE\n")
+	}
+	for _, want := range []string{"Deployment Status", "test-app", "test-profile", "Deployment Number", "4", "Hosted Config Version", "7"} {
+		if !strings.Contains(stderr.String(), want) {
+			t.Errorf("stderr does not contain %q:\n%s", want, stderr.String())
+		}
+	}
+}
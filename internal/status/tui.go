@@ -0,0 +1,249 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/koh-sh/apcdeploy/internal/aws"
+	"github.com/koh-sh/apcdeploy/internal/bulk"
+	"github.com/koh-sh/apcdeploy/internal/cli"
+	"github.com/koh-sh/apcdeploy/internal/config"
+)
+
+// DefaultRefreshInterval is the TUI refresh period when none is given.
+const DefaultRefreshInterval = 10 * time.Second
+
+// stateError is shown for targets whose lookup failed, matching the bulk
+// text payload.
+const stateError = "ERROR"
+
+// ExecuteTUI renders a full-screen dashboard of the latest deployment of
+// every target and re-fetches them every opts.RefreshInterval until the user
+// quits. Targets come from opts.TargetsFile, or the single config file when
+// it is not set. Nothing is written through the reporter; the caller is
+// responsible for only using it on a terminal.
+func (e *Executor) ExecuteTUI(ctx context.Context, opts *Options) error {
+	targets, err := e.tuiTargets(ctx, opts)
+	if err != nil {
+		return err
+	}
+	interval := opts.RefreshInterval
+	if interval <= 0 {
+		interval = DefaultRefreshInterval
+	}
+
+	m := newTUIModel(func() []targetStatus { return e.fetchStatuses(ctx, targets, opts.Concurrency) }, interval)
+	if _, err := tea.NewProgram(m, tea.WithContext(ctx), tea.WithAltScreen()).Run(); err != nil {
+		return fmt.Errorf("status dashboard failed: %w", err)
+	}
+	return nil
+}
+
+// tuiTargets returns the targets shown by the dashboard.
+func (e *Executor) tuiTargets(ctx context.Context, opts *Options) ([]bulk.Target, error) {
+	if opts.TargetsFile != "" {
+		return bulk.Prepare(ctx, opts.TargetsFile, opts.Region, opts.Filter, e.clientFactory)
+	}
+
+	cfg, err := config.LoadConfig(opts.ConfigFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	cfg.ApplyRegionOverride(opts.Region)
+	client, err := e.clientFactory(ctx, cfg.Region)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize AWS client: %w", err)
+	}
+	return []bulk.Target{{ID: config.Identifier(client.Region, cfg), Config: cfg, Client: client}}, nil
+}
+
+// fetchStatuses looks up the latest deployment of every target, up to
+// concurrency at once.
+// Failures are recorded on the row instead of aborting the refresh.
+func (e *Executor) fetchStatuses(ctx context.Context, targets []bulk.Target, concurrency int) []targetStatus {
+	results := make([]targetStatus, len(targets))
+	bulk.Run(targets, concurrency, func(i int, t bulk.Target) {
+		res := targetStatus{
+			Target:      t.ID,
+			Application: t.Config.Application,
+			Profile:     t.Config.ConfigurationProfile,
+			Environment: t.Config.Environment,
+			Region:      t.Client.Region,
+		}
+		defer func() { results[i] = res }()
+
+		resources, err := aws.NewResolver(t.Client).ResolveAll(ctx, t.Config.Application, t.Config.ConfigurationProfile, t.Config.Environment, "")
+		if err != nil {
+			res.Error = err.Error()
+			return
+		}
+		details, err := e.lookupDeployment(ctx, t.Client, resources, "")
+		if err != nil {
+			res.Error = err.Error()
+			return
+		}
+		if details == nil {
+			res.State = stateNone
+			return
+		}
+		res.State = string(details.State)
+		res.DeploymentNumber = details.DeploymentNumber
+		res.Version = details.ConfigurationVersion
+	})
+	return results
+}
+
+// tuiRefreshedMsg carries the result of one refresh.
+type tuiRefreshedMsg struct {
+	rows []targetStatus
+	at   time.Time
+}
+
+// tuiTickMsg triggers the next periodic refresh. gen ties the tick to the
+// refresh that scheduled it, so a manual refresh does not start a second
+// timer chain.
+type tuiTickMsg struct{ gen int }
+
+// tuiModel is the bubbletea model behind `status --tui`.
+type tuiModel struct {
+	fetch    func() []targetStatus
+	interval time.Duration
+
+	rows       []targetStatus
+	refreshed  time.Time
+	refreshing bool
+	gen        int
+}
+
+func newTUIModel(fetch func() []targetStatus, interval time.Duration) tuiModel {
+	return tuiModel{fetch: fetch, interval: interval, refreshing: true}
+}
+
+func (m tuiModel) Init() tea.Cmd {
+	return m.refresh()
+}
+
+func (m tuiModel) refresh() tea.Cmd {
+	fetch := m.fetch
+	return func() tea.Msg {
+		return tuiRefreshedMsg{rows: fetch(), at: time.Now()}
+	}
+}
+
+func (m tuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			return m, tea.Quit
+		case "r":
+			if !m.refreshing {
+				m.refreshing = true
+				return m, m.refresh()
+			}
+		}
+	case tuiRefreshedMsg:
+		m.rows = msg.rows
+		m.refreshed = msg.at
+		m.refreshing = false
+		m.gen++
+		gen := m.gen
+		return m, tea.Tick(m.interval, func(time.Time) tea.Msg { return tuiTickMsg{gen: gen} })
+	case tuiTickMsg:
+		if msg.gen == m.gen && !m.refreshing {
+			m.refreshing = true
+			return m, m.refresh()
+		}
+	}
+	return m, nil
+}
+
+func (m tuiModel) View() string {
+	var b strings.Builder
+
+	status := "refreshing..."
+	if !m.refreshing && !m.refreshed.IsZero() {
+		status = "refreshed " + m.refreshed.Format("15:04:05")
+	}
+	b.WriteString(cli.HeadingText("apcdeploy status"))
+	b.WriteString(cli.SubtleText(fmt.Sprintf("  %s · every %s", status, m.interval)))
+	b.WriteString("\n\n")
+
+	headers := []string{"TARGET", "STATE", "VERSION", "DEPLOYMENT"}
+	cells := make([][]string, len(m.rows))
+	for i, r := range m.rows {
+		state, version, deployment := r.State, r.Version, ""
+		if r.Error != "" {
+			state = stateError
+		}
+		if r.DeploymentNumber > 0 {
+			deployment = fmt.Sprintf("#%d", r.DeploymentNumber)
+		}
+		if version != "" {
+			version = "v" + version
+		}
+		cells[i] = []string{r.Target, state, version, deployment}
+	}
+	widths := make([]int, len(headers))
+	for i, h := range headers {
+		widths[i] = len(h)
+	}
+	for _, row := range cells {
+		for i, c := range row {
+			widths[i] = max(widths[i], lipgloss.Width(c))
+		}
+	}
+
+	pad := func(s string, w int) string {
+		return s + strings.Repeat(" ", w-lipgloss.Width(s)+2)
+	}
+	for i, h := range headers {
+		b.WriteString(pad(h, widths[i]))
+	}
+	b.WriteString("\n")
+
+	failing := 0
+	for i, row := range cells {
+		r := m.rows[i]
+		failed := isFailing(r)
+		if failed {
+			failing++
+		}
+		var line strings.Builder
+		for j, c := range row {
+			cell := pad(c, widths[j])
+			if j == 1 && !failed {
+				cell = cli.StateBadge(c) + strings.Repeat(" ", widths[j]-lipgloss.Width(c)+2)
+			}
+			line.WriteString(cell)
+		}
+		text := strings.TrimRight(line.String(), " ")
+		if failed {
+			text = cli.ErrorText(text)
+		}
+		b.WriteString(text + "\n")
+		if r.Error != "" {
+			b.WriteString(cli.ErrorText("  " + r.Error))
+			b.WriteString("\n")
+		}
+	}
+
+	b.WriteString("\n")
+	if failing > 0 {
+		b.WriteString(cli.ErrorText(fmt.Sprintf("%d of %d targets failing", failing, len(m.rows))))
+		b.WriteString("\n")
+	}
+	b.WriteString(cli.SubtleText("r refresh · q quit"))
+	b.WriteString("\n")
+	return b.String()
+}
+
+// isFailing reports whether a row should be highlighted: its lookup failed
+// or its latest deployment was rolled back.
+func isFailing(r targetStatus) bool {
+	return r.Error != "" || r.State == "ROLLED_BACK" || r.State == "ROLLING_BACK"
+}
@@ -0,0 +1,117 @@
+package status
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	awsInternal "github.com/koh-sh/apcdeploy/internal/aws"
+	"github.com/koh-sh/apcdeploy/internal/bulk"
+	reportertest "github.com/koh-sh/apcdeploy/internal/reporter/testing"
+)
+
+func TestFetchStatuses(t *testing.T) {
+	t.Parallel()
+
+	path := writeBulkTargets(t, bulkTargets)
+	client := awsInternal.NewTestClient(newBulkMock())
+	factory := func(context.Context, string) (*awsInternal.Client, error) { return client, nil }
+	targets, err := bulk.Prepare(context.Background(), path, "", nil, factory)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rows := NewExecutorWithFactory(&reportertest.MockReporter{}, factory).fetchStatuses(context.Background(), targets, 0)
+	if len(rows) != 3 {
+		t.Fatalf("got %d rows, want 3", len(rows))
+	}
+	if rows[0].State != "COMPLETE" || rows[0].Version != "3" || rows[0].DeploymentNumber != 7 {
+		t.Errorf("prod row = %+v", rows[0])
+	}
+	if rows[1].State != stateNone {
+		t.Errorf("staging state = %q, want %q", rows[1].State, stateNone)
+	}
+	if rows[2].Error == "" {
+		t.Errorf("missing environment should record an error, got %+v", rows[2])
+	}
+}
+
+func TestTUIModelUpdate(t *testing.T) {
+	t.Parallel()
+
+	fetches := 0
+	m := newTUIModel(func() []targetStatus {
+		fetches++
+		return []targetStatus{{Target: "us-east-1/app/flags/prod", State: "COMPLETE"}}
+	}, time.Minute)
+
+	// Init fetches immediately.
+	msg := m.Init()()
+	updated, cmd := m.Update(msg)
+	m = updated.(tuiModel)
+	if fetches != 1 || m.refreshing || len(m.rows) != 1 {
+		t.Fatalf("after first refresh: fetches=%d refreshing=%v rows=%d", fetches, m.refreshing, len(m.rows))
+	}
+	if cmd == nil {
+		t.Fatal("a refresh must schedule the next tick")
+	}
+
+	// A stale tick (from before a manual refresh) is ignored.
+	updated, cmd = m.Update(tuiTickMsg{gen: m.gen - 1})
+	m = updated.(tuiModel)
+	if cmd != nil || m.refreshing {
+		t.Error("stale tick must not trigger a refresh")
+	}
+
+	// The current tick refreshes.
+	updated, cmd = m.Update(tuiTickMsg{gen: m.gen})
+	m = updated.(tuiModel)
+	if cmd == nil || !m.refreshing {
+		t.Error("current tick must trigger a refresh")
+	}
+
+	// While refreshing, r is ignored; q quits.
+	if _, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")}); cmd != nil {
+		t.Error("r during a refresh must be ignored")
+	}
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	if cmd == nil {
+		t.Fatal("q must return a command")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("q must quit")
+	}
+}
+
+func TestTUIModelView(t *testing.T) {
+	t.Parallel()
+
+	m := newTUIModel(nil, 10*time.Second)
+	m.refreshing = false
+	m.refreshed = time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+	m.rows = []targetStatus{
+		{Target: "us-east-1/app/flags/prod", State: "COMPLETE", Version: "3", DeploymentNumber: 7},
+		{Target: "us-east-1/app/flags/staging", State: "ROLLED_BACK", Version: "2", DeploymentNumber: 4},
+		{Target: "us-east-1/app/flags/missing", Error: "environment not found"},
+	}
+
+	view := m.View()
+	for _, want := range []string{
+		"refreshed 15:04:05",
+		"every 10s",
+		"us-east-1/app/flags/prod",
+		"v3",
+		"#7",
+		"ROLLED_BACK",
+		"ERROR",
+		"environment not found",
+		"2 of 3 targets failing",
+		"q quit",
+	} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q:\n%s", want, view)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/appconfig/types"
+	"github.com/koh-sh/apcdeploy/internal/aws"
+	"github.com/koh-sh/apcdeploy/internal/cli"
+	"github.com/koh-sh/apcdeploy/internal/clock"
+	"github.com/koh-sh/apcdeploy/internal/config"
+	"github.com/koh-sh/apcdeploy/internal/reporter"
+)
+
+// isWatchable reports whether --watch keeps polling a deployment in state.
+// Polling stops once the rollout reaches COMPLETE or BAKING, or when it
+// starts rolling back.
+func isWatchable(state types.DeploymentState) bool {
+	return state == types.DeploymentStateDeploying || state == types.DeploymentStateValidating
+}
+
+// rolledBackError reports a watched deployment that ended rolling back or
+// rolled back, or nil when it did not.
+func rolledBackError(d *aws.DeploymentDetails) error {
+	if d.State != types.DeploymentStateRollingBack && d.State != types.DeploymentStateRolledBack {
+		return nil
+	}
+	if reason := aws.ExtractRollbackReason(d.EventLog); reason != "" {
+		return fmt.Errorf("deployment #%d was rolled back: %s", d.DeploymentNumber, reason)
+	}
+	return fmt.Errorf("deployment #%d was rolled back", d.DeploymentNumber)
+}
+
+// watch polls GetDeployment at the client's PollingInterval, updating the
+// Targets row's progress bar, until d leaves the in-progress states, then
+// prints a summary line and returns the last observed details. ctx
+// cancellation (Ctrl-C) is returned as is; running past timeout is an error.
+func (e *Executor) watch(ctx context.Context, client *aws.Client, resources *aws.ResolvedResources, d *aws.DeploymentDetails, tg reporter.Targets, id string, timeout time.Duration) (*aws.DeploymentDetails, error) {
+	clk := client.Clock
+	if clk == nil {
+		clk = clock.Real
+	}
+	interval := client.PollingInterval
+	if interval <= 0 {
+		interval = config.DefaultPollingInterval
+	}
+	start := clk.Now()
+	deadline := start.Add(timeout)
+
+	tg.SetPhase(id, "watching", fmt.Sprintf("(deployment #%d)", d.DeploymentNumber))
+	for isWatchable(d.State) {
+		tg.SetProgress(id, float64(d.PercentageComplete)/100.0, 0)
+
+		remaining := deadline.Sub(clk.Now())
+		if remaining <= 0 {
+			return d, fmt.Errorf("timed out after %v watching deployment #%d (%s %.0f%%)", timeout, d.DeploymentNumber, d.State, d.PercentageComplete)
+		}
+		select {
+		case <-ctx.Done():
+			return d, ctx.Err()
+		case <-clk.After(min(interval, remaining)):
+		}
+
+		next, err := aws.GetDeploymentDetails(ctx, client, resources.ApplicationID, resources.EnvironmentID, d.DeploymentNumber)
+		if err != nil {
+			return d, fmt.Errorf("failed to get deployment: %w", err)
+		}
+		next.DeploymentStrategyName = d.DeploymentStrategyName
+		d = next
+	}
+	e.reporter.Info(watchSummary(d, clk.Now().Sub(start)))
+	return d, nil
+}
+
+// watchSummary renders the line printed when --watch stops polling.
+func watchSummary(d *aws.DeploymentDetails, elapsed time.Duration) string {
+	return fmt.Sprintf("deployment #%d reached %s after watching for %s", d.DeploymentNumber, d.State, cli.FormatElapsed(elapsed))
+}
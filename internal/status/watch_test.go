@@ -0,0 +1,195 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig/types"
+	awsInternal "github.com/koh-sh/apcdeploy/internal/aws"
+	clocktest "github.com/koh-sh/apcdeploy/internal/clock/testing"
+	reportertest "github.com/koh-sh/apcdeploy/internal/reporter/testing"
+)
+
+func TestExecuteWatch(t *testing.T) {
+	t.Parallel()
+
+	type poll struct {
+		state   types.DeploymentState
+		percent float32
+	}
+
+	tests := []struct {
+		name         string
+		polls        []poll
+		timeout      time.Duration
+		wantErr      string
+		wantProgress []float64
+		wantWaits    []time.Duration
+		wantSummary  string
+		wantFinal    string
+	}{
+		{
+			name:         "polls until complete",
+			polls:        []poll{{types.DeploymentStateDeploying, 20}, {types.DeploymentStateDeploying, 60}, {types.DeploymentStateComplete, 100}},
+			timeout:      time.Minute,
+			wantProgress: []float64{0.2, 0.6},
+			wantWaits:    []time.Duration{5 * time.Second, 5 * time.Second},
+			wantSummary:  "deployment #1 reached COMPLETE after watching for 10s",
+			wantFinal:    "done",
+		},
+		{
+			name:         "stops when baking starts",
+			polls:        []poll{{types.DeploymentStateDeploying, 50}, {types.DeploymentStateBaking, 100}},
+			timeout:      time.Minute,
+			wantProgress: []float64{0.5},
+			wantWaits:    []time.Duration{5 * time.Second},
+			wantSummary:  "deployment #1 reached BAKING after watching for 5s",
+			wantFinal:    "done",
+		},
+		{
+			name:         "rollback fails the row",
+			polls:        []poll{{types.DeploymentStateDeploying, 50}, {types.DeploymentStateRolledBack, 0}},
+			timeout:      time.Minute,
+			wantErr:      "deployment #1 was rolled back",
+			wantProgress: []float64{0.5},
+			wantWaits:    []time.Duration{5 * time.Second},
+			wantSummary:  "deployment #1 reached ROLLED_BACK after watching for 5s",
+			wantFinal:    "fail",
+		},
+		{
+			name:         "times out",
+			polls:        []poll{{types.DeploymentStateDeploying, 10}},
+			timeout:      12 * time.Second,
+			wantErr:      "timed out after 12s watching deployment #1 (DEPLOYING 10%)",
+			wantProgress: []float64{0.1, 0.1, 0.1, 0.1},
+			wantWaits:    []time.Duration{5 * time.Second, 5 * time.Second, 2 * time.Second},
+			wantFinal:    "fail",
+		},
+		{
+			name:      "finished deployments are not polled",
+			polls:     []poll{{types.DeploymentStateComplete, 100}},
+			timeout:   time.Minute,
+			wantFinal: "done",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			configPath := filepath.Join(dir, "apcdeploy.yml")
+			config := "application: test-app\nconfiguration_profile: test-profile\nenvironment: test-env\ndeployment_strategy: AppConfig.AllAtOnce\ndata_file: data.json\nregion: us-east-1\n"
+			if err := os.WriteFile(configPath, []byte(config), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			mockClient := newDriftMock([]types.DeploymentSummary{{DeploymentNumber: 1, State: tt.polls[0].state}}, map[int32]string{1: "1"}, nil)
+			calls := 0
+			mockClient.GetDeploymentFunc = func(ctx context.Context, params *appconfig.GetDeploymentInput, optFns ...func(*appconfig.Options)) (*appconfig.GetDeploymentOutput, error) {
+				// The latest-deployment lookup and the details fetch both
+				// see the first poll
+				p := tt.polls[min(max(calls-1, 0), len(tt.polls)-1)]
+				calls++
+				return &appconfig.GetDeploymentOutput{
+					DeploymentNumber:       1,
+					ConfigurationProfileId: aws.String("profile-123"),
+					ConfigurationVersion:   aws.String("1"),
+					DeploymentStrategyId:   aws.String("strategy-123"),
+					State:                  p.state,
+					PercentageComplete:     aws.Float32(p.percent),
+				}, nil
+			}
+			clk := clocktest.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+			reporter := &reportertest.MockReporter{}
+			executor := NewExecutorWithFactory(reporter, func(ctx context.Context, region string) (*awsInternal.Client, error) {
+				client := awsInternal.NewTestClient(mockClient)
+				client.PollingInterval = 5 * time.Second
+				client.Clock = clk
+				return client, nil
+			})
+
+			err := executor.Execute(context.Background(), &Options{ConfigFile: configPath, Watch: true, Timeout: tt.timeout})
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Execute() error = %v", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Execute() error = %v, want %q", err, tt.wantErr)
+			}
+
+			var progress []float64
+			final := ""
+			for _, tr := range reporter.TargetsCalls[0].Transitions {
+				switch tr.Kind {
+				case "progress":
+					progress = append(progress, tr.Percent)
+				case "done", "fail", "skip":
+					final = tr.Kind
+				}
+			}
+			if !floatsEqual(progress, tt.wantProgress) {
+				t.Errorf("progress = %v, want %v", progress, tt.wantProgress)
+			}
+			if final != tt.wantFinal {
+				t.Errorf("final transition = %q, want %q", final, tt.wantFinal)
+			}
+			if got := clk.Waits(); !reflect.DeepEqual(got, tt.wantWaits) {
+				t.Errorf("waits = %v, want %v", got, tt.wantWaits)
+			}
+			if tt.wantSummary != "" && !reporter.HasMessage("info: "+tt.wantSummary) {
+				t.Errorf("expected summary %q, got %v", tt.wantSummary, reporter.Messages)
+			}
+		})
+	}
+}
+
+func TestExecuteWatchCanceled(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "apcdeploy.yml")
+	config := "application: test-app\nconfiguration_profile: test-profile\nenvironment: test-env\ndeployment_strategy: AppConfig.AllAtOnce\ndata_file: data.json\nregion: us-east-1\n"
+	if err := os.WriteFile(configPath, []byte(config), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	mockClient := newDriftMock([]types.DeploymentSummary{{DeploymentNumber: 1, State: types.DeploymentStateDeploying}}, map[int32]string{1: "1"}, nil)
+	mockClient.GetDeploymentFunc = func(context.Context, *appconfig.GetDeploymentInput, ...func(*appconfig.Options)) (*appconfig.GetDeploymentOutput, error) {
+		cancel()
+		return &appconfig.GetDeploymentOutput{
+			DeploymentNumber:       1,
+			ConfigurationProfileId: aws.String("profile-123"),
+			State:                  types.DeploymentStateDeploying,
+		}, nil
+	}
+	executor := NewExecutorWithFactory(&reportertest.MockReporter{}, func(ctx context.Context, region string) (*awsInternal.Client, error) {
+		return awsInternal.NewTestClient(mockClient), nil
+	})
+
+	err := executor.Execute(ctx, &Options{ConfigFile: configPath, Watch: true, Timeout: time.Hour})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Execute() error = %v, want context.Canceled", err)
+	}
+}
+
+func floatsEqual(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if diff := a[i] - b[i]; diff > 1e-6 || diff < -1e-6 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,11 @@
+//go:build !otel
+
+package tracing
+
+import "context"
+
+// Setup installs the OpenTelemetry exporter. This build has no OpenTelemetry
+// support, so it always returns ErrNotBuilt.
+func Setup(context.Context, string) error {
+	return ErrNotBuilt
+}
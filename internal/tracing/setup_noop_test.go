@@ -0,0 +1,17 @@
+//go:build !otel
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestSetupNotBuilt(t *testing.T) {
+	t.Parallel()
+
+	if err := Setup(context.Background(), "dev"); !errors.Is(err, ErrNotBuilt) {
+		t.Errorf("Setup() = %v, want ErrNotBuilt", err)
+	}
+}
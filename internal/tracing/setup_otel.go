@@ -0,0 +1,78 @@
+//go:build otel
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Setup installs an OTLP/HTTP exporter configured from the standard
+// OTEL_EXPORTER_OTLP_* environment variables (endpoint, headers, timeout)
+// and makes Start emit real spans. version is reported as service.version.
+func Setup(ctx context.Context, version string) error {
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create OTLP exporter: %w", err)
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		semconv.ServiceName("apcdeploy"),
+		semconv.ServiceVersion(version),
+	))
+	if err != nil {
+		return fmt.Errorf("failed to build OpenTelemetry resource: %w", err)
+	}
+	tp := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter), sdktrace.WithResource(res))
+	provider = otelProvider{tracer: tp.Tracer("github.com/koh-sh/apcdeploy")}
+	shutdown = tp.Shutdown
+	return nil
+}
+
+type otelProvider struct {
+	tracer trace.Tracer
+}
+
+func (p otelProvider) Start(ctx context.Context, name string, attrs ...Attr) (context.Context, Span) {
+	ctx, span := p.tracer.Start(ctx, name, trace.WithAttributes(toOTel(attrs)...))
+	return ctx, otelSpan{span: span}
+}
+
+type otelSpan struct {
+	span trace.Span
+}
+
+func (s otelSpan) SetAttributes(attrs ...Attr) {
+	s.span.SetAttributes(toOTel(attrs)...)
+}
+
+func (s otelSpan) End(err error) {
+	if err != nil {
+		s.span.RecordError(err)
+		s.span.SetStatus(codes.Error, err.Error())
+	}
+	s.span.End()
+}
+
+// toOTel converts attributes to their OpenTelemetry form.
+func toOTel(attrs []Attr) []attribute.KeyValue {
+	kvs := make([]attribute.KeyValue, 0, len(attrs))
+	for _, a := range attrs {
+		switch v := a.Value.(type) {
+		case int64:
+			kvs = append(kvs, attribute.Int64(a.Key, v))
+		case string:
+			kvs = append(kvs, attribute.String(a.Key, v))
+		default:
+			kvs = append(kvs, attribute.String(a.Key, fmt.Sprint(v)))
+		}
+	}
+	return kvs
+}
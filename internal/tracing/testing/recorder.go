@@ -0,0 +1,68 @@
+package testing
+
+import (
+	"context"
+	"sync"
+
+	"github.com/koh-sh/apcdeploy/internal/tracing"
+)
+
+// RecordedSpan is a finished span captured by Recorder.
+type RecordedSpan struct {
+	Name   string
+	Parent string // name of the parent span, empty for a root span
+	Attrs  map[string]any
+	Err    error
+}
+
+// Recorder is a tracing.Provider that keeps every finished span in memory,
+// in the order the spans ended.
+type Recorder struct {
+	mu    sync.Mutex
+	Spans []RecordedSpan
+}
+
+type spanKey struct{}
+
+// Start implements tracing.Provider.
+func (r *Recorder) Start(ctx context.Context, name string, attrs ...tracing.Attr) (context.Context, tracing.Span) {
+	parent, _ := ctx.Value(spanKey{}).(*recordedSpan)
+	s := &recordedSpan{recorder: r, name: name, parent: parent, attrs: map[string]any{}}
+	s.SetAttributes(attrs...)
+	return context.WithValue(ctx, spanKey{}, s), s
+}
+
+// Find returns the first finished span with the given name.
+func (r *Recorder) Find(name string) (RecordedSpan, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, s := range r.Spans {
+		if s.Name == name {
+			return s, true
+		}
+	}
+	return RecordedSpan{}, false
+}
+
+type recordedSpan struct {
+	recorder *Recorder
+	name     string
+	parent   *recordedSpan
+	attrs    map[string]any
+}
+
+func (s *recordedSpan) SetAttributes(attrs ...tracing.Attr) {
+	for _, a := range attrs {
+		s.attrs[a.Key] = a.Value
+	}
+}
+
+func (s *recordedSpan) End(err error) {
+	rec := RecordedSpan{Name: s.name, Attrs: s.attrs, Err: err}
+	if s.parent != nil {
+		rec.Parent = s.parent.name
+	}
+	s.recorder.mu.Lock()
+	defer s.recorder.mu.Unlock()
+	s.recorder.Spans = append(s.recorder.Spans, rec)
+}
@@ -0,0 +1,101 @@
+// Package tracing emits spans for the phases of a deployment (load, resolve,
+// validate, create, deploy, wait). The OpenTelemetry exporter is compiled in
+// only with the "otel" build tag; default builds keep a no-op provider, so
+// the SDK adds nothing to the binary of users who do not trace.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"os"
+)
+
+// ErrNotBuilt is returned by Setup when the binary was built without the
+// "otel" build tag.
+var ErrNotBuilt = errors.New("OpenTelemetry support is not compiled in; rebuild with -tags otel")
+
+// Attribute keys attached to deploy spans.
+const (
+	AttrRegion           = "apcdeploy.region"
+	AttrApplication      = "apcdeploy.application"
+	AttrProfile          = "apcdeploy.profile"
+	AttrEnvironment      = "apcdeploy.environment"
+	AttrVersion          = "apcdeploy.version"
+	AttrDeploymentNumber = "apcdeploy.deployment_number"
+	AttrResolveMode      = "apcdeploy.resolve_mode"
+)
+
+// Attr is a span attribute. Value is a string or an integer.
+type Attr struct {
+	Key   string
+	Value any
+}
+
+// String returns a string attribute.
+func String(key, value string) Attr {
+	return Attr{Key: key, Value: value}
+}
+
+// Int returns an integer attribute.
+func Int(key string, value int64) Attr {
+	return Attr{Key: key, Value: value}
+}
+
+// Span is an in-progress span. End records err (nil for success) and
+// finishes the span.
+type Span interface {
+	SetAttributes(attrs ...Attr)
+	End(err error)
+}
+
+// Provider starts spans. The returned context carries the span so spans
+// started from it become its children.
+type Provider interface {
+	Start(ctx context.Context, name string, attrs ...Attr) (context.Context, Span)
+}
+
+// provider is the process-wide span provider; a no-op until Setup succeeds.
+var provider Provider = noopProvider{}
+
+// shutdown flushes and stops the exporter installed by Setup.
+var shutdown func(context.Context) error
+
+// Start starts a span named name with the current provider.
+func Start(ctx context.Context, name string, attrs ...Attr) (context.Context, Span) {
+	return provider.Start(ctx, name, attrs...)
+}
+
+// SetProvider replaces the span provider and returns a function restoring
+// the previous one. It is meant for tests, which must not run in parallel
+// with other span producers while it is in effect.
+func SetProvider(p Provider) (restore func()) {
+	prev := provider
+	provider = p
+	return func() { provider = prev }
+}
+
+// Requested reports whether tracing was asked for, either with --otel or by
+// configuring an OTLP endpoint in the standard environment variables.
+func Requested(flag bool) bool {
+	return flag || os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" || os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != ""
+}
+
+// Shutdown flushes pending spans. It is a no-op when Setup was not called
+// or failed.
+func Shutdown(ctx context.Context) error {
+	if shutdown == nil {
+		return nil
+	}
+	return shutdown(ctx)
+}
+
+type noopProvider struct{}
+
+func (noopProvider) Start(ctx context.Context, _ string, _ ...Attr) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttributes(...Attr) {}
+func (noopSpan) End(error)             {}
@@ -0,0 +1,50 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestRequested(t *testing.T) {
+	tests := []struct {
+		name string
+		flag bool
+		env  map[string]string
+		want bool
+	}{
+		{name: "nothing set", want: false},
+		{name: "flag", flag: true, want: true},
+		{name: "endpoint env", env: map[string]string{"OTEL_EXPORTER_OTLP_ENDPOINT": "http://localhost:4318"}, want: true},
+		{name: "traces endpoint env", env: map[string]string{"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT": "http://localhost:4318/v1/traces"}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+			t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			if got := Requested(tt.flag); got != tt.want {
+				t.Errorf("Requested(%v) = %v, want %v", tt.flag, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNoopProvider(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	got, span := noopProvider{}.Start(ctx, "phase", String(AttrRegion, "us-east-1"))
+	if got != ctx {
+		t.Errorf("noop Start must return the context unchanged")
+	}
+	span.SetAttributes(Int(AttrVersion, 1))
+	span.End(errors.New("boom"))
+
+	if err := Shutdown(ctx); err != nil {
+		t.Errorf("Shutdown() without Setup = %v, want nil", err)
+	}
+}